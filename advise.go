@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/transpiler"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// adviceRule is the mechanical C edit, which keeps the behavior of C code
+// and improves transpiling. Rule finds places in C code, where the edit may
+// be applied.
+type adviceRule struct {
+	// Name is short name of rule
+	Name string
+
+	// Advice is text of suggested edit
+	Advice string
+
+	// Message is regular expression of warnings without position in C code,
+	// which are removed by the edit
+	Message string
+
+	// match returns true, if the edit may be applied to the node
+	match func(p *program.Program, n ast.Node) bool
+}
+
+// adviceRules - rules of command advise
+var adviceRules = []adviceRule{
+	{
+		Name: "computed-goto",
+		Advice: "replace computed goto (`goto *ptr` and `&&label`) by `switch` " +
+			"on integer state variable",
+		match: func(p *program.Program, n ast.Node) bool {
+			switch n.(type) {
+			case *ast.IndirectGotoStmt, *ast.AddrLabelExpr:
+				return true
+			}
+			return false
+		},
+	},
+	{
+		Name: "type-punning",
+		Advice: "replace cast of pointer to other arithmetic type by union " +
+			"with fields of both types",
+		Message: "Function `noarch\\.\\w+SliceTo\\w+Slice` haven`t implementation",
+		match:   isTypePunning,
+	},
+	{
+		Name: "inline-asm",
+		Advice: "isolate inline assembly in separate function with portable " +
+			"C implementation under `#ifdef`",
+		match: func(p *program.Program, n ast.Node) bool {
+			_, ok := n.(*ast.GCCAsmStmt)
+			return ok
+		},
+	},
+	{
+		Name: "setjmp",
+		Advice: "replace `setjmp` and `longjmp` by error codes returned " +
+			"through the callers",
+		match: func(p *program.Program, n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Children()) == 0 {
+				return false
+			}
+			name, ok := calledFunctionName(call.Children()[0])
+			if !ok {
+				return false
+			}
+			switch name {
+			case "setjmp", "_setjmp", "sigsetjmp", "__sigsetjmp",
+				"longjmp", "_longjmp", "siglongjmp":
+				return true
+			}
+			return false
+		},
+	},
+}
+
+// isTypePunning returns true for cast of pointer to arithmetic type to
+// pointer to other arithmetic type, like `*(int *)&f`. Slices of different
+// Go types cannot share memory without package unsafe.
+func isTypePunning(p *program.Program, n ast.Node) bool {
+	c, ok := n.(*ast.CStyleCastExpr)
+	if !ok || c.Kind != "BitCast" || len(c.Children()) == 0 {
+		return false
+	}
+	pointee := func(cType string) (string, bool) {
+		cType = types.CleanCType(cType)
+		if !types.IsCPointer(cType) {
+			return "", false
+		}
+		cType = strings.TrimSpace(cType[:len(cType)-1])
+		if !types.IsCInteger(p, cType) && !types.IsCFloat(p, cType) {
+			return "", false
+		}
+		goType, err := types.ResolveType(p, cType)
+		return goType, err == nil
+	}
+	to, ok := pointee(c.Type)
+	if !ok {
+		return false
+	}
+	from, ok := pointee(nodeType(c.Children()[0]))
+	return ok && from != to
+}
+
+// nodeType returns the C type of node with field Type, like expressions.
+func nodeType(n ast.Node) string {
+	v := reflect.ValueOf(n)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.Elem().FieldByName("Type")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+// advice is the rule with found places in C code.
+type advice struct {
+	Rule adviceRule
+
+	// Places - positions of found nodes in C code
+	Places []ast.Position
+
+	// Problems - amount of problems of transpiling at the places, which are
+	// removed by the edit
+	Problems int
+}
+
+// findAdvices returns advices for nodes of AST tree from user source, which
+// is checked by function isUserSource. Advices are ranked by amount of
+// removed problems of transpiling, so the most useful edit is the first.
+func findAdvices(p *program.Program, tree ast.Node, messages []string,
+	isUserSource func(file string) bool) []advice {
+	advices := make([]advice, len(adviceRules))
+	for i := range adviceRules {
+		advices[i].Rule = adviceRules[i]
+	}
+	var walk func(n ast.Node)
+	walk = func(n ast.Node) {
+		if n == nil {
+			return
+		}
+		if isUserSource(n.Position().File) {
+			for i := range advices {
+				if advices[i].Rule.match(p, n) {
+					advices[i].Places = append(advices[i].Places, n.Position())
+				}
+			}
+		}
+		for _, c := range n.Children() {
+			walk(c)
+		}
+	}
+	walk(tree)
+
+	// Each problem is removed by the first matched advice.
+	for _, message := range messages {
+		problem, ok := problemPosition(message)
+	search:
+		for i := range advices {
+			if len(advices[i].Places) == 0 {
+				continue
+			}
+			if !ok {
+				if advices[i].Rule.Message != "" && util.GetRegex(
+					advices[i].Rule.Message).MatchString(message) {
+					advices[i].Problems++
+					break search
+				}
+				continue
+			}
+			for _, place := range advices[i].Places {
+				end := place.LineEnd
+				if end < place.Line {
+					end = place.Line
+				}
+				if place.File == problem.File &&
+					place.Line <= problem.Line && problem.Line <= end {
+					advices[i].Problems++
+					break search
+				}
+			}
+		}
+	}
+
+	var result []advice
+	for _, a := range advices {
+		if len(a.Places) > 0 {
+			result = append(result, a)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Problems != result[j].Problems {
+			return result[i].Problems > result[j].Problems
+		}
+		return len(result[i].Places) > len(result[j].Places)
+	})
+	return result
+}
+
+// problemPosition returns position of warning of transpiling, like:
+//
+//     // Warning (*ast.GCCAsmStmt):  file.c:12 :cannot transpile asm, will be ignored
+//
+func problemPosition(message string) (_ ast.Position, ok bool) {
+	m := util.GetRegex(`^// Warning \(\*ast\.\w+\):\s+(.+):(\d+) :`).
+		FindStringSubmatch(message)
+	if m == nil {
+		return
+	}
+	return ast.Position{File: m[1], Line: util.Atoi(m[2])}, true
+}
+
+// isProblem returns true for warnings and errors of transpiling.
+func isProblem(message string) bool {
+	return strings.HasPrefix(message, "// Warning") ||
+		strings.HasPrefix(message, "/* AST Error") ||
+		strings.HasPrefix(message, "// Error")
+}
+
+// runAdvise transpiles C code without writing of Go code and prints advices
+// for C code. Only first top places are printed for each advice.
+func runAdvise(args ProgramArgs, w io.Writer, top int) error {
+	lines, filePP, err := generateAstLines(args)
+	if err != nil {
+		return err
+	}
+
+	p := program.NewProgram()
+	p.PreprocessorFile = filePP
+	nodes, astErrors := convertLinesToNodesParallel(lines)
+	for i := range astErrors {
+		p.AddMessage(fmt.Sprintf("/* AST Error :\n%v\n*/", astErrors[i].Error()))
+	}
+	tree := buildTree(nodes, 0)
+	ast.FixPositions(tree)
+
+	// transpiling is needed only for problems
+	if err = transpiler.TranspileAST(args.outputFile, args.packageName,
+		p, tree[0]); err != nil {
+		p.AddMessage(fmt.Sprintf("// Error: %v", err))
+	}
+
+	messages := p.GetMessages()
+	advices := findAdvices(p, tree[0], messages, filePP.IsUserSource)
+	var problems int
+	for _, message := range messages {
+		if isProblem(message) {
+			problems++
+		}
+	}
+	writeAdvices(w, advices, problems, top)
+	return nil
+}
+
+// writeAdvices prints advices with amount of removed problems of
+// transpiling.
+func writeAdvices(w io.Writer, advices []advice, problems, top int) {
+	fmt.Fprintf(w, "Problems of transpiling: %d\n", problems)
+	if len(advices) == 0 {
+		fmt.Fprintf(w, "\nNo advices\n")
+		return
+	}
+	for i, a := range advices {
+		fmt.Fprintf(w, "\n%d. %s (places: %d, removed problems: %d)\n",
+			i+1, a.Rule.Name, len(a.Places), a.Problems)
+		fmt.Fprintf(w, "   %s\n", a.Rule.Advice)
+		for j, place := range a.Places {
+			if top > 0 && j == top {
+				fmt.Fprintf(w, "   ... other places: %d\n", len(a.Places)-top)
+				break
+			}
+			fmt.Fprintf(w, "   %s:%d\n", place.File, place.Line)
+		}
+	}
+}
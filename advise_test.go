@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestAdvise(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(`
+TranslationUnitDecl 0x1 <<invalid sloc>> <invalid sloc>
+|-FunctionDecl 0x2 </usr/include/setjmp.h:1:1, col:10> col:5 used longjmp 'void (int)'
+| |-ParmVarDecl 0x3 <col:13> col:13 'int'
+| |-GCCAsmStmt 0x4 <line:2:3, col:16>
+| |-AddrLabelExpr 0x5 <col:13, col:14> 'void *' a 0x20
+`+"`"+`-FunctionDecl 0x10 <file.c:1:1, line:9:1> line:1:6 f 'void (float)'
+  |-ParmVarDecl 0x11 <col:8, col:14> col:14 used x 'float'
+  `+"`"+`-CompoundStmt 0x12 <col:17, line:9:1>
+    |-DeclStmt 0x13 <line:2:3, col:17>
+    | `+"`"+`-VarDecl 0x14 <col:3, col:14> col:9 used p 'void *' cinit
+    |   `+"`"+`-AddrLabelExpr 0x15 <col:13, col:14> 'void *' a 0x20
+    |-IndirectGotoStmt 0x16 <line:3:3, col:9>
+    | `+"`"+`-ImplicitCastExpr 0x17 <col:9> 'const void *' <BitCast>
+    |   `+"`"+`-ImplicitCastExpr 0x18 <col:9> 'void *' <LValueToRValue>
+    |     `+"`"+`-DeclRefExpr 0x19 <col:9> 'void *' lvalue Var 0x14 'p' 'void *'
+    |-LabelStmt 0x20 <line:4:1, line:5:16> 'a'
+    | `+"`"+`-GCCAsmStmt 0x21 <line:5:3, col:16>
+    |-DeclStmt 0x22 <line:6:3, col:22>
+    | `+"`"+`-VarDecl 0x23 <col:3, col:21> col:7 i 'int' cinit
+    |   `+"`"+`-ImplicitCastExpr 0x24 <col:11, col:21> 'int' <LValueToRValue>
+    |     `+"`"+`-UnaryOperator 0x25 <col:11, col:21> 'int' lvalue prefix '*' cannot overflow
+    |       `+"`"+`-CStyleCastExpr 0x26 <col:12, col:21> 'int *' <BitCast>
+    |         `+"`"+`-UnaryOperator 0x27 <col:20, col:21> 'float *' prefix '&' cannot overflow
+    |           `+"`"+`-DeclRefExpr 0x28 <col:21> 'float' lvalue ParmVar 0x11 'x' 'float'
+    |-CStyleCastExpr 0x29 <line:7:3, col:21> 'float *' <BitCast>
+    | `+"`"+`-UnaryOperator 0x30 <col:20, col:21> 'float *' prefix '&' cannot overflow
+    |   `+"`"+`-DeclRefExpr 0x31 <col:21> 'float' lvalue ParmVar 0x11 'x' 'float'
+    `+"`"+`-CallExpr 0x32 <line:8:3, col:12> 'void'
+      |-ImplicitCastExpr 0x33 <col:3> 'void (*)(int)' <FunctionToPointerDecay>
+      | `+"`"+`-DeclRefExpr 0x34 <col:3> 'void (int)' Function 0x2 'longjmp' 'void (int)'
+      `+"`"+`-IntegerLiteral 0x35 <col:11> 'int' 1
+`), "\n")
+	nodes, errs := convertLinesToNodes(lines)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	tree := buildTree(nodes, 0)
+	ast.FixPositions(tree)
+
+	messages := []string{
+		"// Warning (*ast.AddrLabelExpr):  file.c:2 :cannot transpile to expr : *ast.AddrLabelExpr",
+		"// Warning (*ast.IndirectGotoStmt):  file.c:3 :cannot transpile to expr : *ast.IndirectGotoStmt",
+		"// Warning (*ast.GCCAsmStmt):  file.c:5 :cannot transpile asm, will be ignored",
+		"// Warning Function `noarch.Float32SliceToIntSlice` haven`t implementation",
+		"// Warning (*ast.BinaryOperator):  file.c:100 :other problem",
+	}
+	p := program.NewProgram()
+	advices := findAdvices(p, tree[0], messages, func(file string) bool {
+		return file == "file.c"
+	})
+
+	var buf bytes.Buffer
+	writeAdvices(&buf, advices, len(messages), 1)
+	expected := "Problems of transpiling: 5\n" +
+		"\n1. computed-goto (places: 2, removed problems: 2)\n" +
+		"   " + adviceRules[0].Advice + "\n" +
+		"   file.c:2\n" +
+		"   ... other places: 1\n" +
+		"\n2. type-punning (places: 1, removed problems: 1)\n" +
+		"   " + adviceRules[1].Advice + "\n" +
+		"   file.c:6\n" +
+		"\n3. inline-asm (places: 1, removed problems: 1)\n" +
+		"   " + adviceRules[2].Advice + "\n" +
+		"   file.c:5\n" +
+		"\n4. setjmp (places: 1, removed problems: 0)\n" +
+		"   " + adviceRules[3].Advice + "\n" +
+		"   file.c:8\n"
+	if buf.String() != expected {
+		t.Errorf("Not expected advices:\n%s\nExpected:\n%s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	writeAdvices(&buf, nil, 0, 10)
+	if buf.String() != "Problems of transpiling: 0\n\nNo advices\n" {
+		t.Errorf("Not expected result without advices: %q", buf.String())
+	}
+}
@@ -0,0 +1,48 @@
+package ast
+
+// AddrLabelExpr is node represent address of label '&&label' of GNU C
+type AddrLabelExpr struct {
+	Addr       Address
+	Pos        Position
+	Type       string
+	Name       string
+	ChildNodes []Node
+}
+
+func parseAddrLabelExpr(line string) *AddrLabelExpr {
+	groups := groupsFromRegex(
+		"<(?P<position>.*)> '(?P<type>.*?)' (?P<name>\\w+) [0-9a-fx]+",
+		line,
+	)
+
+	return &AddrLabelExpr{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		Type:       groups["type"],
+		Name:       groups["name"],
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *AddrLabelExpr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *AddrLabelExpr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *AddrLabelExpr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *AddrLabelExpr) Position() Position {
+	return n.Pos
+}
@@ -0,0 +1,19 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestAddrLabelExpr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x5581a2c1c2f0 <col:25, col:27> 'void *' next 0x5581a2c1c1b8`: &AddrLabelExpr{
+			Addr:       0x5581a2c1c2f0,
+			Pos:        NewPositionFromString("col:25, col:27"),
+			Type:       "void *",
+			Name:       "next",
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
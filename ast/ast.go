@@ -50,6 +50,26 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseArrayFiller(line), nil
 	}
 
+	// Associations of generic selection `_Generic` do not have address.
+	if isGenericAssociation(line) {
+		return parseGenericAssociation(line), nil
+	}
+
+	// Captured variables of blocks do not have address.
+	if isBlockCapture(line) {
+		return parseBlockCapture(line), nil
+	}
+
+	// Types declared by `typeof` of C23 and GNU C are replaced by the types
+	// shown by clang after the colon, for example:
+	//
+	//     'typeof (x) *':'int *'  ->  'int *'
+	//
+	if strings.Contains(line, "typeof") {
+		line = util.GetRegex(`'[^']*(\btypeof|__typeof)[^']*':('[^']*')`).
+			ReplaceAllString(line, "$2")
+	}
+
 	parts := strings.SplitN(line, " ", 2)
 	nodeName := parts[0]
 
@@ -61,6 +81,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 	switch nodeName {
 	case "AccessSpecDecl":
 		return parseAccessSpecDecl(line), nil
+	case "AddrLabelExpr":
+		return parseAddrLabelExpr(line), nil
 	case "AlignedAttr":
 		return parseAlignedAttr(line), nil
 	case "AllocSizeAttr":
@@ -71,12 +93,22 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseArraySubscriptExpr(line), nil
 	case "AsmLabelAttr":
 		return parseAsmLabelAttr(line), nil
+	case "AttributedStmt":
+		return parseAttributedStmt(line), nil
 	case "AvailabilityAttr":
 		return parseAvailabilityAttr(line), nil
 	case "BinaryOperator":
 		return parseBinaryOperator(line), nil
 	case "BlockCommandComment":
 		return parseBlockCommandComment(line), nil
+	case "BlockDecl":
+		return parseBlockDecl(line), nil
+	case "BlockExpr":
+		return parseBlockExpr(line), nil
+	case "BlocksAttr":
+		return parseBlocksAttr(line), nil
+	case "BinaryConditionalOperator":
+		return parseBinaryConditionalOperator(line), nil
 	case "BreakStmt":
 		return parseBreakStmt(line), nil
 	case "BuiltinType":
@@ -91,18 +123,26 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseCompoundLiteralExpr(line), nil
 	case "CompoundStmt":
 		return parseCompoundStmt(line), nil
+	case "ComplexType":
+		return parseComplexType(line), nil
 	case "ConditionalOperator":
 		return parseConditionalOperator(line), nil
 	case "ConstAttr":
 		return parseConstAttr(line), nil
 	case "ConstantArrayType":
 		return parseConstantArrayType(line), nil
+	case "ConstructorAttr":
+		return parseConstructorAttr(line), nil
 	case "ContinueStmt":
 		return parseContinueStmt(line), nil
 	case "CompoundAssignOperator":
 		return parseCompoundAssignOperator(line), nil
 	case "CStyleCastExpr":
 		return parseCStyleCastExpr(line), nil
+	case "CXXBoolLiteralExpr":
+		return parseCXXBoolLiteralExpr(line), nil
+	case "CXX11NoReturnAttr":
+		return parseCXX11NoReturnAttr(line), nil
 	case "CXXConstructExpr":
 		return parseCXXConstructExpr(line), nil
 	case "CXXConstructorDecl":
@@ -111,6 +151,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseCXXMethodDecl(line), nil
 	case "CXXMemberCallExpr":
 		return parseCXXMemberCallExpr(line), nil
+	case "CXXNullPtrLiteralExpr":
+		return parseCXXNullPtrLiteralExpr(line), nil
 	case "CXXRecord":
 		return parseCXXRecord(line), nil
 	case "CXXRecordDecl":
@@ -127,6 +169,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseDefaultStmt(line), nil
 	case "DeprecatedAttr":
 		return parseDeprecatedAttr(line), nil
+	case "DestructorAttr":
+		return parseDestructorAttr(line), nil
 	case "DisableTailCallsAttr":
 		return parseDisableTailCallsAttr(line), nil
 	case "DoStmt":
@@ -145,6 +189,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseEnumType(line), nil
 	case "Field":
 		return parseField(line), nil
+	case "FallThroughAttr":
+		return parseFallThroughAttr(line), nil
 	case "FieldDecl":
 		return parseFieldDecl(line), nil
 	case "FloatingLiteral":
@@ -167,10 +213,16 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseHTMLEndTagComment(line), nil
 	case "GCCAsmStmt":
 		return parseGCCAsmStmt(line), nil
+	case "GNUInlineAttr":
+		return parseGNUInlineAttr(line), nil
+	case "GenericSelectionExpr":
+		return parseGenericSelectionExpr(line), nil
 	case "GotoStmt":
 		return parseGotoStmt(line), nil
 	case "IfStmt":
 		return parseIfStmt(line), nil
+	case "ImaginaryLiteral":
+		return parseImaginaryLiteral(line), nil
 	case "ImplicitCastExpr":
 		return parseImplicitCastExpr(line), nil
 	case "ImplicitValueInitExpr":
@@ -179,6 +231,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseIncompleteArrayType(line), nil
 	case "IndirectFieldDecl":
 		return parseIndirectFieldDecl(line), nil
+	case "IndirectGotoStmt":
+		return parseIndirectGotoStmt(line), nil
 	case "InitListExpr":
 		return parseInitListExpr(line), nil
 	case "InlineCommandComment":
@@ -205,6 +259,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseNonNullAttr(line), nil
 	case "OffsetOfExpr":
 		return parseOffsetOfExpr(line), nil
+	case "OpaqueValueExpr":
+		return parseOpaqueValueExpr(line), nil
 	case "PackedAttr":
 		return parsePackedAttr(line), nil
 	case "ParagraphComment":
@@ -239,6 +295,8 @@ func Parse(fullline string) (returnNode Node, err error) {
 		return parseReturnsTwiceAttr(line), nil
 	case "SentinelAttr":
 		return parseSentinelAttr(line), nil
+	case "StaticAssertDecl":
+		return parseStaticAssertDecl(line), nil
 	case "StmtExpr":
 		return parseStmtExpr(line), nil
 	case "StringLiteral":
@@ -0,0 +1,45 @@
+package ast
+
+// AttributedStmt is type of statement with attributes of C23, for example
+// `[[fallthrough]];`. Children are attributes and the statement.
+type AttributedStmt struct {
+	Addr       Address
+	Pos        Position
+	ChildNodes []Node
+}
+
+func parseAttributedStmt(line string) *AttributedStmt {
+	groups := groupsFromRegex(
+		"<(?P<position>.*)>",
+		line,
+	)
+
+	return &AttributedStmt{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *AttributedStmt) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *AttributedStmt) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *AttributedStmt) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *AttributedStmt) Position() Position {
+	return n.Pos
+}
@@ -0,0 +1,17 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestAttributedStmt(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d3c1a8e480 <line:7:5, col:20>`: &AttributedStmt{
+			Addr:       0x55d3c1a8e480,
+			Pos:        NewPositionFromString("line:7:5, col:20"),
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
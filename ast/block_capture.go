@@ -0,0 +1,72 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// BlockCapture is the variable captured by the block, for example:
+//
+//     capture Var 0x55d2c3b0e1a8 'a' 'int'
+//     capture byref Var 0x55d2c3b0e1a8 'b' 'int [10]'
+//
+// Variables with storage class `__block` are captured by reference.
+type BlockCapture struct {
+	IsByRef    bool
+	IsNested   bool
+	For        string
+	Address2   string
+	Name       string
+	Type       string
+	ChildNodes []Node
+}
+
+func parseBlockCapture(line string) *BlockCapture {
+	match := util.GetRegex(
+		`^capture( byref)?( nested)? (\w+) (0x[0-9a-f]+) '(.*?)' '(.*)'$`).
+		FindStringSubmatch(line)
+	if len(match) == 0 {
+		panic("could not match block capture\n" + line + "\n")
+	}
+
+	return &BlockCapture{
+		IsByRef:    len(match[1]) > 0,
+		IsNested:   len(match[2]) > 0,
+		For:        match[3],
+		Address2:   match[4],
+		Name:       match[5],
+		Type:       match[6],
+		ChildNodes: []Node{},
+	}
+}
+
+// isBlockCapture returns true, if the line of AST is the captured variable
+// of block.
+func isBlockCapture(line string) bool {
+	return strings.HasPrefix(line, "capture ")
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *BlockCapture) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. For an BlockCapture this
+// will always be zero. See the documentation for the Address type for more
+// information.
+func (n *BlockCapture) Address() Address {
+	return 0
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *BlockCapture) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *BlockCapture) Position() Position {
+	return Position{}
+}
@@ -0,0 +1,57 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+func TestBlockCapture(t *testing.T) {
+	tcs := map[string]*BlockCapture{
+		`capture Var 0x55d2c3b0e1a8 'a' 'int'`: {
+			For:        "Var",
+			Address2:   "0x55d2c3b0e1a8",
+			Name:       "a",
+			Type:       "int",
+			ChildNodes: []Node{},
+		},
+		`capture byref Var 0x55d2c3b0e1a8 'b' 'int [10]'`: {
+			IsByRef:    true,
+			For:        "Var",
+			Address2:   "0x55d2c3b0e1a8",
+			Name:       "b",
+			Type:       "int [10]",
+			ChildNodes: []Node{},
+		},
+		`capture nested ParmVar 0x55d2c3b0e1a8 'x' 'double'`: {
+			IsNested:   true,
+			For:        "ParmVar",
+			Address2:   "0x55d2c3b0e1a8",
+			Name:       "x",
+			Type:       "double",
+			ChildNodes: []Node{},
+		},
+	}
+
+	for line, expected := range tcs {
+		t.Run(line, func(t *testing.T) {
+			actual, err := Parse(line)
+			if err != nil {
+				t.Fatalf("Error parsing: %v", err)
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("%s", util.ShowDiff(formatMultiLine(expected),
+					formatMultiLine(actual)))
+			}
+			if uint64(actual.Address()) != 0 {
+				t.Fatal("Address is not zero")
+			}
+			actual.AddChild(&ArrayFiller{})
+			if len(actual.Children()) != 1 {
+				t.Fatal("Childrens is not correct")
+			}
+			_ = actual.Position()
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package ast
+
+// BlockDecl is the declaration of block of clang. Children are parameters,
+// captured variables (see BlockCapture) and the body of block.
+type BlockDecl struct {
+	Addr       Address
+	Pos        Position
+	Position2  string
+	IsVariadic bool
+	ChildNodes []Node
+}
+
+func parseBlockDecl(line string) *BlockDecl {
+	groups := groupsFromRegex(
+		`<(?P<position>.*)>
+		(?P<position2> [^ ]+:[\d:]+)?
+		(?P<variadic> variadic)?
+		`,
+		line,
+	)
+
+	return &BlockDecl{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		Position2:  groups["position2"],
+		IsVariadic: len(groups["variadic"]) > 0,
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *BlockDecl) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *BlockDecl) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *BlockDecl) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *BlockDecl) Position() Position {
+	return n.Pos
+}
@@ -0,0 +1,26 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestBlockDecl(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d2c3b0e230 <col:20, line:7:5> line:4:20`: &BlockDecl{
+			Addr:       0x55d2c3b0e230,
+			Pos:        NewPositionFromString("col:20, line:7:5"),
+			Position2:  " line:4:20",
+			IsVariadic: false,
+			ChildNodes: []Node{},
+		},
+		`0x55d2c3b0e230 <col:20, col:41> col:20 variadic`: &BlockDecl{
+			Addr:       0x55d2c3b0e230,
+			Pos:        NewPositionFromString("col:20, col:41"),
+			Position2:  " col:20",
+			IsVariadic: true,
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
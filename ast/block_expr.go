@@ -0,0 +1,48 @@
+package ast
+
+// BlockExpr is the block of clang, like `^int (int x) { return x; }`. The
+// child is BlockDecl with parameters and body of block. Nested functions of
+// GNU C are transpiled through blocks, see option `-fblocks` of clang.
+type BlockExpr struct {
+	Addr       Address
+	Pos        Position
+	Type       string
+	ChildNodes []Node
+}
+
+func parseBlockExpr(line string) *BlockExpr {
+	groups := groupsFromRegex(
+		"<(?P<position>.*)> '(?P<type>.*)'",
+		line,
+	)
+
+	return &BlockExpr{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		Type:       groups["type"],
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *BlockExpr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *BlockExpr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *BlockExpr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *BlockExpr) Position() Position {
+	return n.Pos
+}
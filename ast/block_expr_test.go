@@ -0,0 +1,18 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestBlockExpr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d2c3b0e2f0 <col:20, line:7:5> 'int (^)(int)'`: &BlockExpr{
+			Addr:       0x55d2c3b0e2f0,
+			Pos:        NewPositionFromString("col:20, line:7:5"),
+			Type:       "int (^)(int)",
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -0,0 +1,47 @@
+package ast
+
+// BlocksAttr is the attribute of variable with storage class `__block` of
+// clang. Such variable is captured by reference in blocks.
+type BlocksAttr struct {
+	Addr       Address
+	Pos        Position
+	Kind       string
+	ChildNodes []Node
+}
+
+func parseBlocksAttr(line string) *BlocksAttr {
+	groups := groupsFromRegex(
+		"<(?P<position>.*)> (?P<kind>.*)",
+		line,
+	)
+
+	return &BlocksAttr{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		Kind:       groups["kind"],
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *BlocksAttr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *BlocksAttr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *BlocksAttr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *BlocksAttr) Position() Position {
+	return n.Pos
+}
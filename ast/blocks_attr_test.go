@@ -0,0 +1,18 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestBlocksAttr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d2c3b0e1f0 <col:2> ByRef`: &BlocksAttr{
+			Addr:       0x55d2c3b0e1f0,
+			Pos:        NewPositionFromString("col:2"),
+			Kind:       "ByRef",
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -13,6 +13,9 @@ type CStyleCastExpr struct {
 // CStyleCastExprNullToPointer - string of kind NullToPointer
 var CStyleCastExprNullToPointer = "NullToPointer"
 
+// CStyleCastExprIntegralToPointer - string of kind IntegralToPointer
+var CStyleCastExprIntegralToPointer = "IntegralToPointer"
+
 // CStyleCastExprToVoid - string of kind ToVoid
 var CStyleCastExprToVoid = "ToVoid"
 
@@ -1,22 +1,33 @@
 package ast
 
-// CaseStmt is node represent 'case'
+// CaseStmt is node represent 'case'. Children are the value of case, the
+// upper value of GNU case range `case 1 ... 5:` (it is nil, if the case is
+// not range) and the statement. New versions of clang have not the upper
+// value, if the case is not range.
 type CaseStmt struct {
 	Addr       Address
 	Pos        Position
+	IsGNURange bool
 	ChildNodes []Node
 }
 
 func parseCaseStmt(line string) *CaseStmt {
-	groups := groupsFromRegex(`<(?P<position>.*)>`, line)
+	groups := groupsFromRegex(`<(?P<position>.*)>(?P<range> gnu_range)?`, line)
 
 	return &CaseStmt{
 		Addr:       ParseAddress(groups["address"]),
 		Pos:        NewPositionFromString(groups["position"]),
+		IsGNURange: len(groups["range"]) > 0,
 		ChildNodes: []Node{},
 	}
 }
 
+// IsRange returns true, if the case is GNU case range, like
+// `case 'a' ... 'z':`.
+func (n *CaseStmt) IsRange() bool {
+	return n.IsGNURange || (len(n.ChildNodes) == 3 && n.ChildNodes[1] != nil)
+}
+
 // AddChild adds a new child node. Child nodes can then be accessed with the
 // Children attribute.
 func (n *CaseStmt) AddChild(node Node) {
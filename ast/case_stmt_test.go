@@ -11,6 +11,12 @@ func TestCaseStmt(t *testing.T) {
 			Pos:        NewPositionFromString("line:11:5, line:12:21"),
 			ChildNodes: []Node{},
 		},
+		`0x55d8a2b04e18 <line:5:5, line:6:13> gnu_range`: &CaseStmt{
+			Addr:       0x55d8a2b04e18,
+			Pos:        NewPositionFromString("line:5:5, line:6:13"),
+			IsGNURange: true,
+			ChildNodes: []Node{},
+		},
 	}
 
 	runNodeTests(t, nodes)
@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"math"
+
 	"github.com/Konstantin8105/c4go/util"
 )
 
@@ -15,15 +17,22 @@ type CharacterLiteral struct {
 
 func parseCharacterLiteral(line string) *CharacterLiteral {
 	groups := groupsFromRegex(
-		"<(?P<position>.*)> '(?P<type>.*?)' (?P<value>\\d+)",
+		"<(?P<position>.*)> '(?P<type>.*?)' (?P<value>-?\\d+)",
 		line,
 	)
 
+	// value of literal is printed as unsigned, but character literals of
+	// type int are signed, for example '\xff' of signed char or 'ABCD'
+	value := util.Atoi(groups["value"])
+	if groups["type"] == "int" && value > math.MaxInt32 {
+		value -= 1 << 32
+	}
+
 	return &CharacterLiteral{
 		Addr:       ParseAddress(groups["address"]),
 		Pos:        NewPositionFromString(groups["position"]),
 		Type:       groups["type"],
-		Value:      util.Atoi(groups["value"]),
+		Value:      value,
 		ChildNodes: []Node{},
 	}
 }
@@ -13,6 +13,27 @@ func TestCharacterLiteral(t *testing.T) {
 			Value:      10,
 			ChildNodes: []Node{},
 		},
+		`0x55d0c6e1f0a8 <col:13> 'int' 1094861636`: &CharacterLiteral{
+			Addr:       0x55d0c6e1f0a8,
+			Pos:        NewPositionFromString("col:13"),
+			Type:       "int",
+			Value:      1094861636,
+			ChildNodes: []Node{},
+		},
+		`0x55d0c6e1f148 <col:13> 'int' 4294967295`: &CharacterLiteral{
+			Addr:       0x55d0c6e1f148,
+			Pos:        NewPositionFromString("col:13"),
+			Type:       "int",
+			Value:      -1,
+			ChildNodes: []Node{},
+		},
+		`0x55d0c6e1f1e8 <col:13> 'unsigned int' 4294967295`: &CharacterLiteral{
+			Addr:       0x55d0c6e1f1e8,
+			Pos:        NewPositionFromString("col:13"),
+			Type:       "unsigned int",
+			Value:      4294967295,
+			ChildNodes: []Node{},
+		},
 	}
 
 	runNodeTests(t, nodes)
@@ -0,0 +1,22 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestComplexType(t *testing.T) {
+	nodes := map[string]Node{
+		`0x2a9e3f0 '_Complex double'`: &ComplexType{
+			Addr:       0x2a9e3f0,
+			Type:       "_Complex double",
+			ChildNodes: []Node{},
+		},
+		`0x7f8a43024110 '_Complex float'`: &ComplexType{
+			Addr:       0x7f8a43024110,
+			Type:       "_Complex float",
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
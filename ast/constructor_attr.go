@@ -0,0 +1,60 @@
+package ast
+
+import "github.com/Konstantin8105/c4go/util"
+
+// ConstructorAttr is a type of attribute of function, which is called
+// automatically: the function is called before main(). Functions with smaller
+// priority are called earlier.
+type ConstructorAttr struct {
+	Addr        Address
+	Pos         Position
+	IsInherited bool
+	Priority    int
+	ChildNodes  []Node
+}
+
+func parseConstructorAttr(line string) *ConstructorAttr {
+	groups := groupsFromRegex(
+		`<(?P<position>.*)>
+		(?P<inherited> Inherited)?
+		(?P<priority> \d+)?`,
+		line,
+	)
+
+	// default priority of attribute
+	priority := 65535
+	if p := groups["priority"]; p != "" {
+		priority = util.Atoi(p[1:])
+	}
+
+	return &ConstructorAttr{
+		Addr:        ParseAddress(groups["address"]),
+		Pos:         NewPositionFromString(groups["position"]),
+		IsInherited: len(groups["inherited"]) > 0,
+		Priority:    priority,
+		ChildNodes:  []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *ConstructorAttr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *ConstructorAttr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *ConstructorAttr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *ConstructorAttr) Position() Position {
+	return n.Pos
+}
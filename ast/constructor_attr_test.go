@@ -0,0 +1,33 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestConstructorAttr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d0f2a3c1e8 <col:16>`: &ConstructorAttr{
+			Addr:        0x55d0f2a3c1e8,
+			Pos:         NewPositionFromString("col:16"),
+			IsInherited: false,
+			Priority:    65535,
+			ChildNodes:  []Node{},
+		},
+		`0x55d0f2a3c2a0 <col:16, col:31> 101`: &ConstructorAttr{
+			Addr:        0x55d0f2a3c2a0,
+			Pos:         NewPositionFromString("col:16, col:31"),
+			IsInherited: false,
+			Priority:    101,
+			ChildNodes:  []Node{},
+		},
+		`0x55d0f2a3c3b0 <line:3:16> Inherited 65535`: &ConstructorAttr{
+			Addr:        0x55d0f2a3c3b0,
+			Pos:         NewPositionFromString("line:3:16"),
+			IsInherited: true,
+			Priority:    65535,
+			ChildNodes:  []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
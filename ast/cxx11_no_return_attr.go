@@ -0,0 +1,44 @@
+package ast
+
+// CXX11NoReturnAttr is type of attribute `[[noreturn]]` of function
+type CXX11NoReturnAttr struct {
+	Addr       Address
+	Pos        Position
+	ChildNodes []Node
+}
+
+func parseCXX11NoReturnAttr(line string) *CXX11NoReturnAttr {
+	groups := groupsFromRegex(
+		`<(?P<position>.*)>( \w+)?`,
+		line,
+	)
+
+	return &CXX11NoReturnAttr{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *CXX11NoReturnAttr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *CXX11NoReturnAttr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *CXX11NoReturnAttr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *CXX11NoReturnAttr) Position() Position {
+	return n.Pos
+}
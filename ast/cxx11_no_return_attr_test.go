@@ -0,0 +1,22 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestCXX11NoReturnAttr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d3c1a8e560 <col:3>`: &CXX11NoReturnAttr{
+			Addr:       0x55d3c1a8e560,
+			Pos:        NewPositionFromString("col:3"),
+			ChildNodes: []Node{},
+		},
+		`0x55d3c1a8e5d0 <col:3> noreturn`: &CXX11NoReturnAttr{
+			Addr:       0x55d3c1a8e5d0,
+			Pos:        NewPositionFromString("col:3"),
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -0,0 +1,49 @@
+package ast
+
+// CXXBoolLiteralExpr is type of boolean literal `true` or `false`, which are
+// keywords of C23
+type CXXBoolLiteralExpr struct {
+	Addr       Address
+	Pos        Position
+	Type       string
+	Value      bool
+	ChildNodes []Node
+}
+
+func parseCXXBoolLiteralExpr(line string) *CXXBoolLiteralExpr {
+	groups := groupsFromRegex(
+		"<(?P<position>.*)> '(?P<type>.*?)' (?P<value>true|false)",
+		line,
+	)
+
+	return &CXXBoolLiteralExpr{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		Type:       groups["type"],
+		Value:      groups["value"] == "true",
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *CXXBoolLiteralExpr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *CXXBoolLiteralExpr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *CXXBoolLiteralExpr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *CXXBoolLiteralExpr) Position() Position {
+	return n.Pos
+}
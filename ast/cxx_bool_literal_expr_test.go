@@ -0,0 +1,26 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestCXXBoolLiteralExpr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55b7d2a0e4b8 <col:14> 'bool' true`: &CXXBoolLiteralExpr{
+			Addr:       0x55b7d2a0e4b8,
+			Pos:        NewPositionFromString("col:14"),
+			Type:       "bool",
+			Value:      true,
+			ChildNodes: []Node{},
+		},
+		`0x55b7d2a0e5d0 <line:7:19> 'bool' false`: &CXXBoolLiteralExpr{
+			Addr:       0x55b7d2a0e5d0,
+			Pos:        NewPositionFromString("line:7:19"),
+			Type:       "bool",
+			Value:      false,
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -0,0 +1,18 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestCXXNullPtrLiteralExpr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d3c1a8e410 <col:14> 'nullptr_t'`: &CXXNullPtrLiteralExpr{
+			Addr:       0x55d3c1a8e410,
+			Pos:        NewPositionFromString("col:14"),
+			Type:       "nullptr_t",
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
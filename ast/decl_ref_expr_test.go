@@ -6,6 +6,19 @@ import (
 
 func TestDeclRefExpr(t *testing.T) {
 	nodes := map[string]Node{
+		`0x55d3c1a8e6b0 <col:10> 'typeof (x)':'int' lvalue Var 0x55d3c1a8e640 'y' 'typeof (x)':'int'`: &DeclRefExpr{
+			Addr:       0x55d3c1a8e6b0,
+			Pos:        NewPositionFromString("col:10"),
+			Type:       "int",
+			Type1:      "",
+			IsLvalue:   true,
+			For:        "Var",
+			Address2:   "0x55d3c1a8e640",
+			Name:       "y",
+			Type2:      "int",
+			Type3:      "",
+			ChildNodes: []Node{},
+		},
 		`0x7fc972064460 <col:8> 'FILE *' lvalue ParmVar 0x7fc9720642d0 '_p' 'FILE *'`: &DeclRefExpr{
 			Addr:       0x7fc972064460,
 			Pos:        NewPositionFromString("col:8"),
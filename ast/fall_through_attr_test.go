@@ -0,0 +1,17 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestFallThroughAttr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d3c1a8e4f0 <col:7>`: &FallThroughAttr{
+			Addr:       0x55d3c1a8e4f0,
+			Pos:        NewPositionFromString("col:7"),
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -3,6 +3,7 @@ package ast
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/Konstantin8105/c4go/preprocessor"
 )
@@ -90,7 +91,8 @@ func RepairFloatingLiteralsFromSource(rootNode Node, filePP preprocessor.FilePP)
 			pos.Line, pos.LineEnd,
 			pos.Column, pos.ColumnEnd)
 
-		line := string(b)
+		// digit separators of C23 are removed, for example: 1'000.5
+		line := strings.Replace(string(b), "'", "", -1)
 
 		// If there was a problem reading the line we should raise a warning and
 		// use the value we have. Hopefully that will be an accurate enough
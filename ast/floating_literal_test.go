@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"math"
 	"testing"
 
 	"github.com/Konstantin8105/c4go/preprocessor"
@@ -22,6 +23,13 @@ func TestFloatingLiteral(t *testing.T) {
 			Value:      2.718282e+00,
 			ChildNodes: []Node{},
 		},
+		`0x55d1b0e2f8a0 <col:19> 'long double' 1.189731e+4932`: &FloatingLiteral{
+			Addr:       0x55d1b0e2f8a0,
+			Pos:        NewPositionFromString("col:19"),
+			Type:       "long double",
+			Value:      math.Inf(1),
+			ChildNodes: []Node{},
+		},
 	}
 
 	runNodeTests(t, nodes)
@@ -27,8 +27,8 @@ func parseFunctionDecl(line string) *FunctionDecl {
 	groups := groupsFromRegex(
 		`(?:parent (?P<parent>0x[0-9a-f]+) )?
 		(?:prev (?P<prev>0x[0-9a-f]+) )?
-		<(?P<position1>.*?)>
-		(?P<position2> <scratch space>[^ ]+| [^ ]+)?
+		<(?P<position1><invalid sloc>|.*?)>
+		(?P<position2> <scratch space>[^ ]+| <invalid sloc>| [^ ]+)?
 		(?P<implicit> implicit)?
 		(?P<used> used)?
 		(?P<referenced> referenced)?
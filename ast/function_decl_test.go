@@ -86,6 +86,22 @@ func TestFunctionDecl(t *testing.T) {
 			IsInline:     false,
 			ChildNodes:   []Node{},
 		},
+		`0x55f1d8a0e2c8 <<invalid sloc>> <invalid sloc> implicit used __builtin_alloca 'void *(unsigned long)' extern`: &FunctionDecl{
+			Addr:         0x55f1d8a0e2c8,
+			Pos:          NewPositionFromString("<invalid sloc>"),
+			Prev:         "",
+			Position2:    "<invalid sloc>",
+			Name:         "__builtin_alloca",
+			Type:         "void *(unsigned long)",
+			Type2:        "",
+			IsExtern:     true,
+			IsImplicit:   true,
+			IsUsed:       true,
+			IsReferenced: false,
+			IsStatic:     false,
+			IsInline:     false,
+			ChildNodes:   []Node{},
+		},
 		`0x2ae30d8 </usr/include/math.h:65:3, /usr/include/x86_64-linux-gnu/sys/cdefs.h:57:54> <scratch space>:17:1 __acos 'double (double)' extern`: &FunctionDecl{
 			Addr:         0x2ae30d8,
 			Pos:          NewPositionFromString("/usr/include/math.h:65:3, /usr/include/x86_64-linux-gnu/sys/cdefs.h:57:54"),
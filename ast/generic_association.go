@@ -0,0 +1,69 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// GenericAssociation is the association of generic selection `_Generic` of
+// C11, for example:
+//
+//     case 'double' selected
+//     default
+//
+// The last child is the expression of association. The association without
+// type is the default association.
+type GenericAssociation struct {
+	Type       string
+	Type2      string
+	IsSelected bool
+	ChildNodes []Node
+}
+
+func parseGenericAssociation(line string) *GenericAssociation {
+	match := util.GetRegex(
+		`^(?:case '(.*?)'(?::'(.*)')?|default)( selected)?$`).
+		FindStringSubmatch(line)
+	if len(match) == 0 {
+		panic("could not match generic association\n" + line + "\n")
+	}
+
+	return &GenericAssociation{
+		Type:       match[1],
+		Type2:      match[2],
+		IsSelected: len(match[3]) > 0,
+		ChildNodes: []Node{},
+	}
+}
+
+// isGenericAssociation returns true, if the line of AST is the association
+// of generic selection.
+func isGenericAssociation(line string) bool {
+	return line == "default" || line == "default selected" ||
+		strings.HasPrefix(line, "case '")
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *GenericAssociation) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. For an GenericAssociation
+// this will always be zero. See the documentation for the Address type for
+// more information.
+func (n *GenericAssociation) Address() Address {
+	return 0
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *GenericAssociation) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *GenericAssociation) Position() Position {
+	return Position{}
+}
@@ -0,0 +1,55 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+func TestGenericAssociation(t *testing.T) {
+	tcs := map[string]*GenericAssociation{
+		`case 'int'`: {
+			Type:       "int",
+			ChildNodes: []Node{},
+		},
+		`case 'double' selected`: {
+			Type:       "double",
+			IsSelected: true,
+			ChildNodes: []Node{},
+		},
+		`case 'size_t':'unsigned long'`: {
+			Type:       "size_t",
+			Type2:      "unsigned long",
+			ChildNodes: []Node{},
+		},
+		`default`: {
+			ChildNodes: []Node{},
+		},
+		`default selected`: {
+			IsSelected: true,
+			ChildNodes: []Node{},
+		},
+	}
+
+	for line, expected := range tcs {
+		t.Run(line, func(t *testing.T) {
+			actual, err := Parse(line)
+			if err != nil {
+				t.Fatalf("Error parsing: %v", err)
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("%s", util.ShowDiff(formatMultiLine(expected),
+					formatMultiLine(actual)))
+			}
+			if uint64(actual.Address()) != 0 {
+				t.Fatal("Address is not zero")
+			}
+			actual.AddChild(&ArrayFiller{})
+			if len(actual.Children()) != 1 {
+				t.Fatal("Childrens is not correct")
+			}
+			_ = actual.Position()
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestGenericSelectionExpr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55f1b4f0a3d8 <col:20, col:63> 'int'`: &GenericSelectionExpr{
+			Addr:       0x55f1b4f0a3d8,
+			Pos:        NewPositionFromString("col:20, col:63"),
+			Type:       "int",
+			Type2:      "",
+			IsLvalue:   false,
+			ChildNodes: []Node{},
+		},
+		`0x55f1b4f0a5e0 <line:5:10, col:52> 'const char *'`: &GenericSelectionExpr{
+			Addr:       0x55f1b4f0a5e0,
+			Pos:        NewPositionFromString("line:5:10, col:52"),
+			Type:       "const char *",
+			Type2:      "",
+			IsLvalue:   false,
+			ChildNodes: []Node{},
+		},
+		`0x55f1b4f0a7a0 <col:3, col:36> 'double':'double' lvalue`: &GenericSelectionExpr{
+			Addr:       0x55f1b4f0a7a0,
+			Pos:        NewPositionFromString("col:3, col:36"),
+			Type:       "double",
+			Type2:      "double",
+			IsLvalue:   true,
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
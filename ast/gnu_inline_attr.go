@@ -0,0 +1,47 @@
+package ast
+
+// GNUInlineAttr is the attribute gnu_inline of function. It is also attached
+// by clang to inline functions in mode -std=gnu89.
+type GNUInlineAttr struct {
+	Addr        Address
+	Pos         Position
+	IsInherited bool
+	ChildNodes  []Node
+}
+
+func parseGNUInlineAttr(line string) *GNUInlineAttr {
+	groups := groupsFromRegex(
+		`<(?P<position>.*)>(?P<inherited> Inherited)?`,
+		line,
+	)
+
+	return &GNUInlineAttr{
+		Addr:        ParseAddress(groups["address"]),
+		Pos:         NewPositionFromString(groups["position"]),
+		IsInherited: len(groups["inherited"]) > 0,
+		ChildNodes:  []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *GNUInlineAttr) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *GNUInlineAttr) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *GNUInlineAttr) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *GNUInlineAttr) Position() Position {
+	return n.Pos
+}
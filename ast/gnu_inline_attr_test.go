@@ -0,0 +1,24 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestGNUInlineAttr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55e0a1f1c2d8 <col:8>`: &GNUInlineAttr{
+			Addr:        0x55e0a1f1c2d8,
+			Pos:         NewPositionFromString("col:8"),
+			IsInherited: false,
+			ChildNodes:  []Node{},
+		},
+		`0x55e0a1f1c3a0 <line:4:23> Inherited`: &GNUInlineAttr{
+			Addr:        0x55e0a1f1c3a0,
+			Pos:         NewPositionFromString("line:4:23"),
+			IsInherited: true,
+			ChildNodes:  []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -0,0 +1,24 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestImaginaryLiteral(t *testing.T) {
+	nodes := map[string]Node{
+		`0x2b6a5b8 <col:21> '_Complex double'`: &ImaginaryLiteral{
+			Addr:       0x2b6a5b8,
+			Pos:        NewPositionFromString("col:21"),
+			Type:       "_Complex double",
+			ChildNodes: []Node{},
+		},
+		`0x55d1c8a0e2a8 <<built-in>:1:1> '_Complex float'`: &ImaginaryLiteral{
+			Addr:       0x55d1c8a0e2a8,
+			Pos:        NewPositionFromString("<built-in>:1:1"),
+			Type:       "_Complex float",
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
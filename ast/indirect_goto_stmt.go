@@ -0,0 +1,45 @@
+package ast
+
+// IndirectGotoStmt is node represent computed 'goto *ptr' of GNU C. The child
+// is the expression of address of label.
+type IndirectGotoStmt struct {
+	Addr       Address
+	Pos        Position
+	ChildNodes []Node
+}
+
+func parseIndirectGotoStmt(line string) *IndirectGotoStmt {
+	groups := groupsFromRegex(
+		"<(?P<position>.*)>",
+		line,
+	)
+
+	return &IndirectGotoStmt{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *IndirectGotoStmt) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *IndirectGotoStmt) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *IndirectGotoStmt) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *IndirectGotoStmt) Position() Position {
+	return n.Pos
+}
@@ -0,0 +1,17 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestIndirectGotoStmt(t *testing.T) {
+	nodes := map[string]Node{
+		`0x5581a2c1c4a8 <line:9:5, col:12>`: &IndirectGotoStmt{
+			Addr:       0x5581a2c1c4a8,
+			Pos:        NewPositionFromString("line:9:5, col:12"),
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -0,0 +1,36 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestOpaqueValueExpr(t *testing.T) {
+	nodes := map[string]Node{
+		`0x55d5c0e0b888 <col:12> 'int'`: &OpaqueValueExpr{
+			Addr:       0x55d5c0e0b888,
+			Pos:        NewPositionFromString("col:12"),
+			Type:       "int",
+			Type2:      "",
+			IsLvalue:   false,
+			ChildNodes: []Node{},
+		},
+		`0x1ff8708 <col:14, col:17> 'char *'`: &OpaqueValueExpr{
+			Addr:       0x1ff8708,
+			Pos:        NewPositionFromString("col:14, col:17"),
+			Type:       "char *",
+			Type2:      "",
+			IsLvalue:   false,
+			ChildNodes: []Node{},
+		},
+		`0x2283ec0 <col:10> 'T':'struct T' lvalue`: &OpaqueValueExpr{
+			Addr:       0x2283ec0,
+			Pos:        NewPositionFromString("col:10"),
+			Type:       "T",
+			Type2:      "struct T",
+			IsLvalue:   true,
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
@@ -233,6 +233,8 @@ func setPosition(node Node, position Position) {
 	switch n := node.(type) {
 	case *AccessSpecDecl:
 		n.Pos = position
+	case *AddrLabelExpr:
+		n.Pos = position
 	case *AlignedAttr:
 		n.Pos = position
 	case *AllocSizeAttr:
@@ -243,12 +245,22 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *AsmLabelAttr:
 		n.Pos = position
+	case *AttributedStmt:
+		n.Pos = position
 	case *AvailabilityAttr:
 		n.Pos = position
 	case *BinaryOperator:
 		n.Pos = position
+	case *BlockDecl:
+		n.Pos = position
+	case *BlockExpr:
+		n.Pos = position
+	case *BlocksAttr:
+		n.Pos = position
 	case *BlockCommandComment:
 		n.Pos = position
+	case *BinaryConditionalOperator:
+		n.Pos = position
 	case *BreakStmt:
 		n.Pos = position
 	case *CallExpr:
@@ -263,6 +275,8 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *ConstAttr:
 		n.Pos = position
+	case *ConstructorAttr:
+		n.Pos = position
 	case *ContinueStmt:
 		n.Pos = position
 	case *CompoundAssignOperator:
@@ -271,6 +285,10 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *CStyleCastExpr:
 		n.Pos = position
+	case *CXX11NoReturnAttr:
+		n.Pos = position
+	case *CXXBoolLiteralExpr:
+		n.Pos = position
 	case *CXXConstructorDecl:
 		n.Pos = position
 	case *CXXConstructExpr:
@@ -279,6 +297,8 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *CXXMemberCallExpr:
 		n.Pos = position
+	case *CXXNullPtrLiteralExpr:
+		n.Pos = position
 	case *CXXRecordDecl:
 		n.Pos = position
 	case *CXXThisExpr:
@@ -291,6 +311,8 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *DeprecatedAttr:
 		n.Pos = position
+	case *DestructorAttr:
+		n.Pos = position
 	case *DisableTailCallsAttr:
 		n.Pos = position
 	case *DoStmt:
@@ -301,6 +323,8 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *EnumDecl:
 		n.Pos = position
+	case *FallThroughAttr:
+		n.Pos = position
 	case *FieldDecl:
 		n.Pos = position
 	case *FloatingLiteral:
@@ -321,16 +345,24 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *HTMLEndTagComment:
 		n.Pos = position
+	case *GNUInlineAttr:
+		n.Pos = position
+	case *GenericSelectionExpr:
+		n.Pos = position
 	case *GotoStmt:
 		n.Pos = position
 	case *IfStmt:
 		n.Pos = position
+	case *ImaginaryLiteral:
+		n.Pos = position
 	case *ImplicitCastExpr:
 		n.Pos = position
 	case *ImplicitValueInitExpr:
 		n.Pos = position
 	case *IndirectFieldDecl:
 		n.Pos = position
+	case *IndirectGotoStmt:
+		n.Pos = position
 	case *InitListExpr:
 		n.Pos = position
 	case *InlineCommandComment:
@@ -357,6 +389,8 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *OffsetOfExpr:
 		n.Pos = position
+	case *OpaqueValueExpr:
+		n.Pos = position
 	case *PackedAttr:
 		n.Pos = position
 	case *ParagraphComment:
@@ -381,6 +415,8 @@ func setPosition(node Node, position Position) {
 		n.Pos = position
 	case *SentinelAttr:
 		n.Pos = position
+	case *StaticAssertDecl:
+		n.Pos = position
 	case *StmtExpr:
 		n.Pos = position
 	case *StringLiteral:
@@ -420,7 +456,8 @@ func setPosition(node Node, position Position) {
 	case *TypedefType, *Typedef, *TranslationUnitDecl, *RecordType, *Record,
 		*QualType, *PointerType, *ParenType, *IncompleteArrayType,
 		*FunctionProtoType, *EnumType, *Enum, *ElaboratedType,
-		*ConstantArrayType, *BuiltinType, *ArrayFiller, *Field,
+		*ConstantArrayType, *BuiltinType, *ComplexType, *ArrayFiller, *Field,
+		*GenericAssociation, *BlockCapture,
 		*DecayedType, *CXXRecord:
 		// These do not have positions so they can be ignored.
 	default:
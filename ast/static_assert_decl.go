@@ -0,0 +1,55 @@
+package ast
+
+import "strings"
+
+// StaticAssertDecl is node represents the static assertion `_Static_assert`
+// of C11 or `static_assert` of C23. The first child is the condition and
+// the second child is the message, if it exists.
+type StaticAssertDecl struct {
+	Addr       Address
+	Pos        Position
+	Position2  string
+	IsFailed   bool
+	ChildNodes []Node
+}
+
+func parseStaticAssertDecl(line string) *StaticAssertDecl {
+	groups := groupsFromRegex(
+		`<(?P<position>.*)>
+		(?P<position2> [^ ]+)?
+		(?P<failed> failed)?
+		`,
+		line,
+	)
+
+	return &StaticAssertDecl{
+		Addr:       ParseAddress(groups["address"]),
+		Pos:        NewPositionFromString(groups["position"]),
+		Position2:  strings.TrimSpace(groups["position2"]),
+		IsFailed:   len(groups["failed"]) > 0,
+		ChildNodes: []Node{},
+	}
+}
+
+// AddChild adds a new child node. Child nodes can then be accessed with the
+// Children attribute.
+func (n *StaticAssertDecl) AddChild(node Node) {
+	n.ChildNodes = append(n.ChildNodes, node)
+}
+
+// Address returns the numeric address of the node. See the documentation for
+// the Address type for more information.
+func (n *StaticAssertDecl) Address() Address {
+	return n.Addr
+}
+
+// Children returns the child nodes. If this node does not have any children or
+// this node does not support children it will always return an empty slice.
+func (n *StaticAssertDecl) Children() []Node {
+	return n.ChildNodes
+}
+
+// Position returns the position in the original source code.
+func (n *StaticAssertDecl) Position() Position {
+	return n.Pos
+}
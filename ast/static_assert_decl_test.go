@@ -0,0 +1,26 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestStaticAssertDecl(t *testing.T) {
+	nodes := map[string]Node{
+		`0x5581d3a0e2c8 <line:3:1, col:49> col:1`: &StaticAssertDecl{
+			Addr:       0x5581d3a0e2c8,
+			Pos:        NewPositionFromString("line:3:1, col:49"),
+			Position2:  "col:1",
+			IsFailed:   false,
+			ChildNodes: []Node{},
+		},
+		`0x5581d3a0e3f0 <line:7:5, col:42> col:5 failed`: &StaticAssertDecl{
+			Addr:       0x5581d3a0e3f0,
+			Pos:        NewPositionFromString("line:7:5, col:42"),
+			Position2:  "col:5",
+			IsFailed:   true,
+			ChildNodes: []Node{},
+		},
+	}
+
+	runNodeTests(t, nodes)
+}
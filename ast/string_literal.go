@@ -8,21 +8,28 @@ type StringLiteral struct {
 	Value      string
 	IsLvalue   bool
 	ChildNodes []Node
+
+	// Prefix is the encoding prefix of literal: "L", "u8", "u", "U" or
+	// empty for ordinary literals.
+	Prefix string
 }
 
 func parseStringLiteral(line string) *StringLiteral {
 	groups := groupsFromRegex(
-		`<(?P<position>.*)> '(?P<type>.*)' lvalue (?P<value>".*")`,
+		`<(?P<position>.*)> '(?P<type>.*)' lvalue (?P<prefix>L|u8|u|U)?(?P<value>".*")`,
 		line,
 	)
 
+	prefix := groups["prefix"]
+	wide := prefix != "" && prefix != "u8"
 	return &StringLiteral{
 		Addr:       ParseAddress(groups["address"]),
 		Pos:        NewPositionFromString(groups["position"]),
 		Type:       groups["type"],
-		Value:      unquote(groups["value"]),
+		Value:      unquoteLiteral(groups["value"], wide),
 		IsLvalue:   true,
 		ChildNodes: []Node{},
+		Prefix:     prefix,
 	}
 }
 
@@ -30,6 +30,41 @@ func TestStringLiteral(t *testing.T) {
 			Value:      "x\vx\x00xxx\axx\tx\n",
 			ChildNodes: []Node{},
 		},
+		`0x55b6b4f0a8a8 <col:16> 'char [9]' lvalue "a\303\251\033[0m\\\""`: &StringLiteral{
+			Addr:       0x55b6b4f0a8a8,
+			Pos:        NewPositionFromString("col:16"),
+			Type:       "char [9]",
+			IsLvalue:   true,
+			Value:      "a\u00e9\x1b[0m\\\"",
+			ChildNodes: []Node{},
+		},
+		`0x55b6b4f0a9c8 <col:18> 'char [5]' lvalue u8"\303\251t\303"`: &StringLiteral{
+			Addr:       0x55b6b4f0a9c8,
+			Pos:        NewPositionFromString("col:18"),
+			Type:       "char [5]",
+			IsLvalue:   true,
+			Value:      "\xc3\xa9t\xc3",
+			ChildNodes: []Node{},
+			Prefix:     "u8",
+		},
+		`0x55b6b4f0aa48 <col:18> 'int [4]' lvalue L"a\x4E2D\n"`: &StringLiteral{
+			Addr:       0x55b6b4f0aa48,
+			Pos:        NewPositionFromString("col:18"),
+			Type:       "int [4]",
+			IsLvalue:   true,
+			Value:      "a\u4e2d\n",
+			ChildNodes: []Node{},
+			Prefix:     "L",
+		},
+		`0x55b6b4f0ab18 <col:18> 'unsigned short [4]' lvalue u"\u00e9\xD83D\xDE00"`: &StringLiteral{
+			Addr:       0x55b6b4f0ab18,
+			Pos:        NewPositionFromString("col:18"),
+			Type:       "unsigned short [4]",
+			IsLvalue:   true,
+			Value:      "\u00e9\U0001F600",
+			ChildNodes: []Node{},
+			Prefix:     "u",
+		},
 	}
 
 	runNodeTests(t, nodes)
@@ -46,6 +46,16 @@ func TestUnaryOperator(t *testing.T) {
 			Operator:   "*",
 			ChildNodes: []Node{},
 		},
+		`0x55d0b1c2e6a0 <col:10, col:11> 'int' prefix '!' cannot overflow`: &UnaryOperator{
+			Addr:       0x55d0b1c2e6a0,
+			Pos:        NewPositionFromString("col:10, col:11"),
+			Type:       "int",
+			Type2:      "",
+			IsLvalue:   false,
+			IsPrefix:   true,
+			Operator:   "!",
+			ChildNodes: []Node{},
+		},
 	}
 
 	runNodeTests(t, nodes)
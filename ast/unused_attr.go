@@ -11,7 +11,7 @@ type UnusedAttr struct {
 
 func parseUnusedAttr(line string) *UnusedAttr {
 	groups := groupsFromRegex(
-		"<(?P<position>.*)>(?P<unused> unused)?",
+		"<(?P<position>.*)>(?P<unused> unused| maybe_unused)?",
 		line,
 	)
 
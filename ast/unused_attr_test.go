@@ -18,6 +18,12 @@ func TestUnusedAttr(t *testing.T) {
 			ChildNodes: []Node{},
 			IsUnused:   false,
 		},
+		`0x55d3c1a8e3a0 <col:3> maybe_unused`: &UnusedAttr{
+			Addr:       0x55d3c1a8e3a0,
+			Pos:        NewPositionFromString("col:3"),
+			ChildNodes: []Node{},
+			IsUnused:   true,
+		},
 	}
 
 	runNodeTests(t, nodes)
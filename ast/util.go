@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 func removeQuotes(s string) string {
@@ -28,20 +30,123 @@ func removeQuotes(s string) string {
 	return s
 }
 
+// atof returns the float64 value of string. A value, which is out of range
+// of float64, for example LDBL_MAX of long double, is returned as infinity.
 func atof(s string) float64 {
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
+		if e, ok := err.(*strconv.NumError); ok && e.Err == strconv.ErrRange {
+			return f
+		}
 		panic(err)
 	}
 	return f
 }
 
 func unquote(s string) string {
-	r, err := strconv.Unquote(s)
-	if err != nil {
+	return unquoteLiteral(s, false)
+}
+
+// unquoteLiteral returns the value of string literal of C with escape
+// sequences like `\n`, `\0`, `\377`, `\x1b` or `\u00e9`. Octal and
+// hexadecimal escape sequences of narrow literal are bytes of value. The
+// characters of wide literal are returned as UTF-8 string of runes.
+func unquoteLiteral(s string, wide bool) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
 		return s
 	}
-	return r
+	s = s[1 : len(s)-1]
+
+	var buf bytes.Buffer
+	var runes []rune
+	for i := 0; i < len(s); {
+		if s[i] != '\\' || i+1 == len(s) {
+			if wide {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				runes = append(runes, r)
+				i += size
+				continue
+			}
+			buf.WriteByte(s[i])
+			i++
+			continue
+		}
+		i++
+		c := s[i]
+		i++
+		var value uint64
+		switch c {
+		case 'a':
+			value = '\a'
+		case 'b':
+			value = '\b'
+		case 'e', 'E':
+			value = 27
+		case 'f':
+			value = '\f'
+		case 'n':
+			value = '\n'
+		case 'r':
+			value = '\r'
+		case 't':
+			value = '\t'
+		case 'v':
+			value = '\v'
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// octal escape sequence has from 1 to 3 digits
+			end := i - 1
+			for end < len(s) && end < i+2 && '0' <= s[end] && s[end] <= '7' {
+				end++
+			}
+			value, _ = strconv.ParseUint(s[i-1:end], 8, 32)
+			i = end
+		case 'x', 'u', 'U':
+			end := i
+			for end < len(s) && strings.IndexByte("0123456789abcdefABCDEF", s[end]) >= 0 {
+				end++
+			}
+			if c == 'u' && end > i+4 {
+				end = i + 4
+			}
+			if c == 'U' && end > i+8 {
+				end = i + 8
+			}
+			if end == i {
+				buf.WriteByte(c)
+				continue
+			}
+			value, _ = strconv.ParseUint(s[i:end], 16, 32)
+			i = end
+			if c != 'x' && !wide {
+				// universal character names are encoded in UTF-8
+				buf.WriteRune(rune(value))
+				continue
+			}
+		default:
+			// characters \\, \", \' and \?
+			value = uint64(c)
+		}
+		if wide {
+			runes = append(runes, rune(value))
+		} else {
+			buf.WriteByte(byte(value))
+		}
+	}
+	if !wide {
+		return buf.String()
+	}
+	// characters of UTF-16 literal are surrogate pairs
+	for i := 0; i < len(runes); i++ {
+		if i+1 < len(runes) && utf16.IsSurrogate(runes[i]) {
+			if r := utf16.DecodeRune(runes[i], runes[i+1]); r != utf8.RuneError {
+				buf.WriteRune(r)
+				i++
+				continue
+			}
+		}
+		buf.WriteRune(runes[i])
+	}
+	return buf.String()
 }
 
 // Atos - ASTree to string
@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -36,6 +37,15 @@ func TestAtof(t *testing.T) {
 	_ = atof("Some not float64")
 }
 
+func TestAtofRange(t *testing.T) {
+	if v := atof("1.18973149535723176502E+4932"); !math.IsInf(v, 1) {
+		t.Errorf("Not acceptable result: %v", v)
+	}
+	if v := atof("-1.18973149535723176502E+4932"); !math.IsInf(v, -1) {
+		t.Errorf("Not acceptable result: %v", v)
+	}
+}
+
 func TestUnquote(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -21,6 +21,7 @@ type VarDecl struct {
 	IsReferenced bool
 	IsStatic     bool
 	IsRegister   bool
+	IsTLS        bool
 	ChildNodes   []Node
 }
 
@@ -37,6 +38,7 @@ func parseVarDecl(line string) *VarDecl {
 		(?P<extern> extern)?
 		(?P<static> static)?
 		(?P<register> register)?
+		(?P<tls> tls(?:_dynamic)?)?
 		(?P<cinit> cinit)?
 		(?P<callinit> callinit)?
 		`,
@@ -64,6 +66,7 @@ func parseVarDecl(line string) *VarDecl {
 		IsReferenced: len(groups["referenced"]) > 0,
 		IsStatic:     len(groups["static"]) > 0,
 		IsRegister:   len(groups["register"]) > 0,
+		IsTLS:        len(groups["tls"]) > 0,
 		ChildNodes:   []Node{},
 	}
 }
@@ -6,6 +6,22 @@ import (
 
 func TestVarDecl(t *testing.T) {
 	nodes := map[string]Node{
+		`0x55d3c1a8e640 <col:3, col:20> col:16 used y 'typeof (x) *':'int *' cinit`: &VarDecl{
+			Addr:         0x55d3c1a8e640,
+			Pos:          NewPositionFromString("col:3, col:20"),
+			Position2:    "col:16",
+			Name:         "y",
+			Type:         "int *",
+			Type2:        "",
+			IsExtern:     false,
+			IsUsed:       true,
+			IsCInit:      true,
+			IsReferenced: false,
+			IsStatic:     false,
+			IsRegister:   false,
+			Parent:       0,
+			ChildNodes:   []Node{},
+		},
 		`0x7fd5e90e5a00 <col:14> col:17 'int'`: &VarDecl{
 			Addr:         0x7fd5e90e5a00,
 			Pos:          NewPositionFromString("col:14"),
@@ -200,6 +216,24 @@ func TestVarDecl(t *testing.T) {
 			IsRegister:   true,
 			ChildNodes:   []Node{},
 		},
+		`0x2b7a8e8 <line:3:1, col:34> col:26 used counter 'int' static tls cinit`: &VarDecl{
+			Addr: 0x2b7a8e8,
+			// Parent:       0x3dcaaf0,
+			// Prev:         0x3ec4088,
+			Pos:          NewPositionFromString("line:3:1, col:34"),
+			Position2:    "col:26",
+			Name:         "counter",
+			Type:         "int",
+			Type2:        "",
+			IsExtern:     false,
+			IsUsed:       true,
+			IsCInit:      true,
+			IsReferenced: false,
+			IsStatic:     true,
+			IsRegister:   false,
+			IsTLS:        true,
+			ChildNodes:   []Node{},
+		},
 		`0x1fb1890 <col:2, col:9> col:9 used obj 'class person' callinit`: &VarDecl{
 			Addr: 0x1fb1890,
 			// Parent:       0x3dcaaf0,
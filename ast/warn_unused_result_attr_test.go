@@ -16,6 +16,11 @@ func TestWarnUnusedResultAttr(t *testing.T) {
 			Pos:        NewPositionFromString("line:481:52"),
 			ChildNodes: []Node{},
 		},
+		`0x55d3c1a8e2f0 <col:3, col:5> nodiscard ""`: &WarnUnusedResultAttr{
+			Addr:       0x55d3c1a8e2f0,
+			Pos:        NewPositionFromString("col:3, col:5"),
+			ChildNodes: []Node{},
+		},
 	}
 
 	runNodeTests(t, nodes)
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Status of file in batch journal.
+const (
+	batchStarted = "started"
+	batchDone    = "done"
+	batchFailed  = "failed"
+	batchSkipped = "skipped"
+)
+
+// batchEntry is a line of batch journal. The journal is a text file with
+// one line for each change of file status. Fields are separated by
+// tabulation, example:
+//
+//     started	/home/user/project/a.c
+//     done	/home/user/project/a.c
+//     started	/home/user/project/b.c
+//     failed	/home/user/project/b.c	cannot transpile AST : ...
+//     skipped	/home/user/project/b.h	not a C source file
+//
+// The line "started" is written before transpiling, so a file with the last
+// status "started" is the file, which crashed or interrupted the previous
+// run.
+type batchEntry struct {
+	Status  string
+	File    string
+	Message string
+}
+
+// batchTranspile transpiles one file of batch. It is changed in tests.
+var batchTranspile = corpusTranspile
+
+// loadBatchJournal returns the last entry of journal for each file. If
+// journal is not exist, then result is empty.
+func loadBatchJournal(filename string) (entries map[string]batchEntry, err error) {
+	entries = map[string]batchEntry{}
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open journal: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) < 2 {
+			// the last line may be not completed, if c4go crashed
+			continue
+		}
+		e := batchEntry{Status: fields[0], File: fields[1]}
+		if len(fields) == 3 {
+			e.Message = fields[2]
+		}
+		switch e.Status {
+		case batchStarted, batchDone, batchFailed, batchSkipped:
+			entries[e.File] = e
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Cannot read journal: %v", err)
+	}
+	return entries, nil
+}
+
+// writeBatchEntry writes the line of journal. Message is written in one
+// line.
+func writeBatchEntry(w io.Writer, e batchEntry) error {
+	line := e.Status + "\t" + e.File
+	if e.Message != "" {
+		line += "\t" + strings.Join(strings.Fields(e.Message), " ")
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// isBatchSource returns true, if the file must be transpiled in batch.
+// Header files are skipped, because they are transpiled with sources.
+func isBatchSource(filename string, cppCode bool) bool {
+	switch filepath.Ext(filename) {
+	case ".c":
+		return true
+	case ".cpp", ".cc", ".cxx":
+		return cppCode
+	}
+	return false
+}
+
+// batchOutput returns the name of Go file for the input C file.
+func batchOutput(dir, filename string) string {
+	base := filepath.Base(filename)
+	return filepath.Join(dir, base[:len(base)-len(filepath.Ext(base))]+".go")
+}
+
+// runBatch transpiles each input file of args in the separate Go file in the
+// folder dir. The status of each file is written in the journal. If resume
+// is true, then files with status in the journal are not transpiled again.
+func runBatch(args ProgramArgs, dir, journal string, resume bool,
+	w io.Writer, verbose bool) (err error) {
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("Cannot create output folder: %v", err)
+	}
+
+	previous := map[string]batchEntry{}
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		if previous, err = loadBatchJournal(journal); err != nil {
+			return
+		}
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(journal, mode, 0644)
+	if err != nil {
+		return fmt.Errorf("Cannot open journal: %v", err)
+	}
+	defer func() {
+		if errClose := f.Close(); errClose != nil && err == nil {
+			err = fmt.Errorf("Cannot close journal: %v", errClose)
+		}
+	}()
+
+	var results []batchEntry
+	outputs := map[string]string{}
+	for _, file := range args.inputFiles {
+		var e batchEntry
+		e, err = runBatchFile(args, dir, file, previous, outputs, f, verbose)
+		if err != nil {
+			return fmt.Errorf("Cannot write journal: %v", err)
+		}
+		results = append(results, e)
+	}
+	writeBatchSummary(w, results, verbose)
+	return nil
+}
+
+// runBatchFile transpiles one file of batch and returns its status.
+func runBatchFile(args ProgramArgs, dir, file string,
+	previous map[string]batchEntry, outputs map[string]string,
+	journal io.Writer, verbose bool) (e batchEntry, err error) {
+	if abs, errAbs := filepath.Abs(file); errAbs == nil {
+		file = abs
+	}
+	e.File = file
+
+	if !isBatchSource(file, args.cppCode) {
+		e.Status = batchSkipped
+		e.Message = "not a C source file"
+		return e, writeBatchEntry(journal, e)
+	}
+
+	output := batchOutput(dir, file)
+	if other, ok := outputs[output]; ok {
+		e.Status = batchFailed
+		e.Message = fmt.Sprintf("output file `%s` is used for `%s`",
+			output, other)
+		return e, writeBatchEntry(journal, e)
+	}
+	outputs[output] = file
+
+	if p, ok := previous[file]; ok {
+		if p.Status != batchStarted {
+			return p, nil
+		}
+		e.Status = batchFailed
+		e.Message = "c4go crashed or was interrupted on this file"
+		return e, writeBatchEntry(journal, e)
+	}
+
+	if verbose {
+		fmt.Fprintf(stderr, "Batch file: %s\n", file)
+	}
+	e.Status = batchStarted
+	if err = writeBatchEntry(journal, e); err != nil {
+		return
+	}
+
+	fileArgs := args
+	fileArgs.inputFiles = []string{file}
+	fileArgs.outputFile = output
+	if errTranspile := batchTranspile(fileArgs); errTranspile != nil {
+		e.Status = batchFailed
+		e.Message = errTranspile.Error()
+	} else {
+		e.Status = batchDone
+	}
+	return e, writeBatchEntry(journal, e)
+}
+
+// writeBatchSummary prints the status of each file and the summary.
+func writeBatchSummary(w io.Writer, results []batchEntry, verbose bool) {
+	amount := map[string]int{}
+	for _, r := range results {
+		fmt.Fprintf(w, "%-8s %s\n", r.Status, r.File)
+		amount[r.Status]++
+	}
+	fmt.Fprintf(w, "\nDone: %d, failed: %d, skipped: %d\n",
+		amount[batchDone], amount[batchFailed], amount[batchSkipped])
+
+	if !verbose {
+		return
+	}
+	for _, r := range results {
+		if r.Status != batchFailed {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n%s\n", r.File, strings.TrimSpace(r.Message))
+	}
+}
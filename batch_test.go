@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBatchJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-batch-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "journal")
+	entries, err := loadBatchJournal(filename)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Journal is not exist: %v %v", entries, err)
+	}
+
+	var buf bytes.Buffer
+	for _, e := range []batchEntry{
+		{Status: batchStarted, File: "/a.c"},
+		{Status: batchDone, File: "/a.c"},
+		{Status: batchStarted, File: "/b.c"},
+		{Status: batchFailed, File: "/b.c", Message: "first line\nsecond line"},
+		{Status: batchStarted, File: "/c.c"},
+	} {
+		if err := writeBatchEntry(&buf, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// not completed line
+	buf.WriteString("fai")
+	if err := ioutil.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = loadBatchJournal(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]batchEntry{
+		"/a.c": {Status: batchDone, File: "/a.c"},
+		"/b.c": {Status: batchFailed, File: "/b.c", Message: "first line second line"},
+		"/c.c": {Status: batchStarted, File: "/c.c"},
+	}
+	if len(entries) != len(expect) {
+		t.Fatalf("Not same amount of entries: %v", entries)
+	}
+	for file, e := range expect {
+		if entries[file] != e {
+			t.Errorf("Not same entry for %s:\n%#v\n%#v", file, entries[file], e)
+		}
+	}
+}
+
+func TestBatchResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-batch-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var transpiled []string
+	crash := true
+	oldTranspile, oldStderr := batchTranspile, stderr
+	stderr = ioutil.Discard
+	batchTranspile = func(args ProgramArgs) error {
+		file := filepath.Base(args.inputFiles[0])
+		transpiled = append(transpiled, file)
+		if file == "c.c" && crash {
+			// stop run as crash of c4go
+			panic("crash")
+		}
+		if file == "b.c" {
+			return fmt.Errorf("cannot transpile")
+		}
+		return nil
+	}
+	defer func() {
+		batchTranspile, stderr = oldTranspile, oldStderr
+	}()
+
+	args := DefaultProgramArgs()
+	args.inputFiles = []string{
+		filepath.Join(dir, "a.c"),
+		filepath.Join(dir, "b.c"),
+		filepath.Join(dir, "a.h"),
+		filepath.Join(dir, "c.c"),
+		filepath.Join(dir, "d.c"),
+		filepath.Join(dir, "other", "a.c"),
+	}
+	journal := filepath.Join(dir, "journal")
+
+	run := func(resume bool) (out string, panicked bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicked = true
+			}
+		}()
+		var buf bytes.Buffer
+		if err := runBatch(args, dir, journal, resume, &buf, true); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String(), false
+	}
+
+	if _, panicked := run(false); !panicked {
+		t.Fatalf("First run is not crashed")
+	}
+	if s := strings.Join(transpiled, ","); s != "a.c,b.c,c.c" {
+		t.Fatalf("First run transpiled: %s", s)
+	}
+
+	transpiled = nil
+	crash = false
+	out, _ := run(true)
+	if s := strings.Join(transpiled, ","); s != "d.c" {
+		t.Fatalf("Resumed run transpiled: %s", s)
+	}
+	for _, expect := range []string{
+		"Done: 2, failed: 3, skipped: 1",
+		"cannot transpile",
+		"crashed or was interrupted",
+		"is used for",
+	} {
+		if !strings.Contains(out, expect) {
+			t.Errorf("Summary haven't `%s`:\n%s", expect, out)
+		}
+	}
+
+	transpiled = nil
+	out, _ = run(false)
+	if s := strings.Join(transpiled, ","); s != "a.c,b.c,c.c,d.c" {
+		t.Fatalf("New run transpiled: %s", s)
+	}
+	if !strings.Contains(out, "Done: 3, failed: 2, skipped: 1") {
+		t.Errorf("Wrong summary of new run:\n%s", out)
+	}
+}
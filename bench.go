@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// benchConfig is the list of functions for generating of Go benchmarks.
+// Arguments of functions are Go expressions, because benchmarks call the
+// transpiled Go code. Example of JSON file:
+//
+//     {
+//       "profile": "gprof.txt",
+//       "top": 3,
+//       "functions": [
+//         {"name": "fib", "args": ["25"]},
+//         {
+//           "name": "sum",
+//           "setup": ["data := make([]int32, 1000)"],
+//           "args": ["data", "1000"]
+//         }
+//       ]
+//     }
+//
+type benchConfig struct {
+	// Profile is the flat profile of C program, generated by command
+	// "gprof -b -p program". Path is relative to the configuration file.
+	Profile string `json:"profile"`
+
+	// Top is amount of the hottest functions from profile, which are
+	// benchmarked. By default: 5.
+	Top int `json:"top"`
+
+	// Functions is the list of benchmarked functions with fixture inputs.
+	Functions []benchFunction `json:"functions"`
+}
+
+// benchFunction is a function with the fixture inputs.
+type benchFunction struct {
+	// Name of C function.
+	Name string `json:"name"`
+
+	// Setup is Go statements, which are run before benchmark loop.
+	Setup []string `json:"setup"`
+
+	// Args is arguments of function as Go expressions.
+	Args []string `json:"args"`
+}
+
+// benchDefaultTop is the default amount of the hottest functions from profile.
+const benchDefaultTop = 5
+
+// loadBenchConfig reads the configuration of benchmarks from JSON file.
+func loadBenchConfig(filename string) (c benchConfig, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return c, fmt.Errorf("Cannot read benchmark configuration: %v", err)
+	}
+	if err = json.Unmarshal(content, &c); err != nil {
+		return c, fmt.Errorf("Cannot parse benchmark configuration: %v", err)
+	}
+	for i, f := range c.Functions {
+		if f.Name == "" {
+			return c, fmt.Errorf("Function %d in benchmark configuration "+
+				"haven`t name", i)
+		}
+	}
+	if c.Profile != "" && !filepath.IsAbs(c.Profile) {
+		c.Profile = filepath.Join(filepath.Dir(filename), c.Profile)
+	}
+	if c.Top <= 0 {
+		c.Top = benchDefaultTop
+	}
+	return c, nil
+}
+
+// parseGprofFlatProfile returns names of functions from the flat profile
+// of gprof in order of decreasing of time. Example of profile:
+//
+//     Flat profile:
+//
+//     Each sample counts as 0.01 seconds.
+//       %   cumulative   self              self     total
+//      time   seconds   seconds    calls  ms/call  ms/call  name
+//      60.00      0.03     0.03   242785     0.00     0.00  fib
+//      40.00      0.05     0.02        1    20.00    50.00  main
+//
+func parseGprofFlatProfile(filename string) (names []string, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open profile: %v", err)
+	}
+	defer f.Close()
+
+	type line struct {
+		name string
+		time float64
+	}
+	var lines []line
+	var table bool
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if !table {
+			table = len(fields) > 0 && fields[0] == "time" &&
+				fields[len(fields)-1] == "name"
+			continue
+		}
+		if len(fields) < 4 {
+			// end of table
+			break
+		}
+		t, errParse := strconv.ParseFloat(fields[0], 64)
+		if errParse != nil {
+			break
+		}
+		lines = append(lines, line{name: fields[len(fields)-1], time: t})
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Cannot read profile: %v", err)
+	}
+	if !table {
+		return nil, fmt.Errorf("Profile `%s` is not flat profile of gprof",
+			filename)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time > lines[j].time
+	})
+	for _, l := range lines {
+		names = append(names, l.name)
+	}
+	return names, nil
+}
+
+// benchFunctions returns the list of functions for benchmarks. Functions
+// from configuration are the first, after them - the hottest functions from
+// profile.
+func benchFunctions(c benchConfig) (fs []benchFunction, err error) {
+	fs = append(fs, c.Functions...)
+	if c.Profile == "" {
+		return
+	}
+	names, err := parseGprofFlatProfile(c.Profile)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > c.Top {
+		names = names[:c.Top]
+	}
+	for _, name := range names {
+		found := false
+		for _, f := range c.Functions {
+			if f.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fs = append(fs, benchFunction{Name: name})
+		}
+	}
+	return
+}
+
+// benchOutput returns the name of Go file with benchmarks for the output Go
+// file. Example: "main.go" -> "main_bench_test.go".
+func benchOutput(output string) string {
+	return strings.TrimSuffix(output, ".go") + "_bench_test.go"
+}
+
+// generateBenchmarks returns the Go code of benchmarks for the transpiled
+// functions. Functions without implementation in C code and functions with
+// wrong amount of arguments are skipped with the comment in the code.
+func generateBenchmarks(packageName string, p *program.Program,
+	tree ast.Node, fs []benchFunction) ([]byte, error) {
+
+	implemented := map[string]bool{}
+	for _, c := range tree.Children() {
+		if f, ok := c.(*ast.FunctionDecl); ok && getFunctionBody(f) != nil {
+			implemented[f.Name] = true
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(p.License)
+	fmt.Fprintf(&buf, "// Benchmarks of transpiled functions. Generated by c4go.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport \"testing\"\n", packageName)
+	generated := map[string]bool{}
+	for _, f := range fs {
+		name := util.ConvertFunctionNameFromCtoGo(f.Name)
+		bench := "Benchmark" + util.Ucfirst(name)
+		def := p.GetFunctionDefinition(name)
+		switch {
+		case generated[bench]:
+			continue
+		case name == "main":
+			fmt.Fprintf(&buf, "\n// Function main is not benchmarked\n")
+			continue
+		case !implemented[name] || def == nil:
+			fmt.Fprintf(&buf, "\n// Function %s is not found in C code\n",
+				f.Name)
+			continue
+		case len(def.ArgumentTypes) != len(f.Args):
+			fmt.Fprintf(&buf, "\n// Function %s is not benchmarked: "+
+				"amount of arguments is %d, but fixture has %d\n",
+				f.Name, len(def.ArgumentTypes), len(f.Args))
+			continue
+		}
+		generated[bench] = true
+
+		fmt.Fprintf(&buf, "\n// %s - benchmark of transpiled function %s\n",
+			bench, f.Name)
+		fmt.Fprintf(&buf, "func %s(b *testing.B) {\n", bench)
+		for _, s := range f.Setup {
+			fmt.Fprintf(&buf, "%s\n", s)
+		}
+		if len(f.Setup) > 0 {
+			fmt.Fprintf(&buf, "b.ResetTimer()\n")
+		}
+		fmt.Fprintf(&buf, "for i := 0; i < b.N; i++ {\n%s(%s)\n}\n}\n",
+			name, strings.Join(f.Args, ", "))
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Cannot format benchmarks, check setup and "+
+			"arguments of functions: %v", err)
+	}
+	return code, nil
+}
+
+// writeBenchmarks writes the Go file with benchmarks near the output Go file.
+func writeBenchmarks(args ProgramArgs, p *program.Program, tree ast.Node,
+	output string) error {
+	c, err := loadBenchConfig(args.benchConfig)
+	if err != nil {
+		return err
+	}
+	fs, err := benchFunctions(c)
+	if err != nil {
+		return err
+	}
+	code, err := generateBenchmarks(args.packageName, p, tree, fs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(benchOutput(output), code, 0644)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestBenchConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-bench-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	profile := `Flat profile:
+
+Each sample counts as 0.01 seconds.
+  %   cumulative   self              self     total
+ time   seconds   seconds    calls  ms/call  ms/call  name
+ 50.00      0.02     0.02   242785     0.00     0.00  fib
+ 25.00      0.03     0.01        1    10.00    10.00  sum
+ 25.00      0.04     0.01                             frame_dummy
+  0.00      0.04     0.00        1     0.00    40.00  main
+
+ %         the percentage of the total running time of the
+time       program used by this function.
+`
+	err = ioutil.WriteFile(filepath.Join(dir, "gprof.txt"), []byte(profile), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcs := []struct {
+		content string
+		isError bool
+		names   string
+	}{
+		{`{"functions":[{"name":"fib","args":["25"]}]}`, false, "fib"},
+		{`{"profile":"gprof.txt","top":2,"functions":[{"name":"sum"}]}`, false, "sum,fib"},
+		{`{"profile":"gprof.txt"}`, false, "fib,sum,frame_dummy,main"},
+		{`{"profile":"not_exist.txt"}`, true, ""},
+		{`{"profile":"bench.json"}`, true, ""},
+		{`{"functions":[{"args":["1"]}]}`, true, ""},
+		{`not json`, true, ""},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "bench.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := loadBenchConfig(filename)
+		var fs []benchFunction
+		if err == nil {
+			fs, err = benchFunctions(c)
+		}
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		var names []string
+		for _, f := range fs {
+			names = append(names, f.Name)
+		}
+		if s := strings.Join(names, ","); s != tc.names {
+			t.Errorf("Case %d: expected functions `%s`, got `%s`",
+				i, tc.names, s)
+		}
+	}
+}
+
+func TestGenerateBenchmarks(t *testing.T) {
+	p := program.NewProgram()
+	p.AddFunctionDefinition(program.FunctionDefinition{
+		Name:          "fib",
+		ReturnType:    "int",
+		ArgumentTypes: []string{"int"},
+	})
+	p.AddFunctionDefinition(program.FunctionDefinition{
+		Name:          "sum",
+		ReturnType:    "int",
+		ArgumentTypes: []string{"int *", "int"},
+	})
+
+	tree := &ast.TranslationUnitDecl{}
+	for _, name := range []string{"fib", "sum", "main"} {
+		f := &ast.FunctionDecl{Name: name}
+		f.AddChild(&ast.CompoundStmt{})
+		tree.AddChild(f)
+	}
+	tree.AddChild(&ast.FunctionDecl{Name: "strlen"})
+
+	code, err := generateBenchmarks("main", p, tree, []benchFunction{
+		{Name: "fib", Args: []string{"25"}},
+		{Name: "fib", Args: []string{"30"}},
+		{Name: "sum", Setup: []string{"data := make([]int32, 100)"},
+			Args: []string{"data", "100"}},
+		{Name: "sum"},
+		{Name: "strlen", Args: []string{"nil"}},
+		{Name: "main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(code)
+	for _, expect := range []string{
+		"func BenchmarkFib(b *testing.B) {",
+		"fib(25)",
+		"data := make([]int32, 100)\n\tb.ResetTimer()",
+		"sum(data, 100)",
+		"// Function strlen is not found in C code",
+		"// Function main is not benchmarked",
+	} {
+		if !strings.Contains(out, expect) {
+			t.Errorf("Benchmarks haven't `%s`:\n%s", expect, out)
+		}
+	}
+	if strings.Contains(out, "fib(30)") || strings.Count(out, "BenchmarkSum") != 2 {
+		t.Errorf("Benchmarks are duplicated:\n%s", out)
+	}
+
+	_, err = generateBenchmarks("main", p, tree, []benchFunction{
+		{Name: "fib", Args: []string{"25)"}},
+	})
+	if err == nil {
+		t.Errorf("Expected error for wrong argument")
+	}
+}
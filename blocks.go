@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/preprocessor"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+// blockStorages returns the positions of declarations of local variables of
+// functions with blocks. Nested functions of GNU C are rewritten into blocks
+// of clang, but variables captured by blocks are constant without the
+// storage class `__block`. Static variables, registers and variable length
+// arrays cannot have the storage class `__block`.
+func blockStorages(lines []string) (positions []preprocessor.BlockStorage) {
+	nodes, _ := convertLinesToNodes(lines)
+	tree := buildTree(nodes, 0)
+	ast.FixPositions(tree)
+
+	// acceptable - positions of declarations, false if any variable of
+	// declaration cannot have the storage class `__block`
+	acceptable := map[preprocessor.BlockStorage]bool{}
+	var order []preprocessor.BlockStorage
+	var variables func(node ast.Node)
+	variables = func(node ast.Node) {
+		if node == nil {
+			return
+		}
+		if v, ok := node.(*ast.VarDecl); ok {
+			pos := preprocessor.BlockStorage{
+				File:   v.Pos.File,
+				Line:   v.Pos.Line,
+				Column: v.Pos.Column,
+			}
+			_, size := types.GetVariableArrayTypeAndSize(v.Type)
+			ok := !v.IsStatic && !v.IsExtern && !v.IsRegister && !v.IsTLS &&
+				size == ""
+			if previous, found := acceptable[pos]; found {
+				ok = ok && previous
+			} else {
+				order = append(order, pos)
+			}
+			acceptable[pos] = ok
+		}
+		for _, child := range node.Children() {
+			variables(child)
+		}
+	}
+
+	var functions func(nodes []ast.Node)
+	functions = func(nodes []ast.Node) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			if f, ok := node.(*ast.FunctionDecl); ok {
+				if hasBlock(f) {
+					variables(f)
+				}
+				continue
+			}
+			functions(node.Children())
+		}
+	}
+	functions(tree)
+
+	for _, pos := range order {
+		if acceptable[pos] && pos.Line > 0 {
+			positions = append(positions, pos)
+		}
+	}
+	return
+}
+
+// hasBlock returns true, if the node has the block of clang.
+func hasBlock(node ast.Node) bool {
+	if _, ok := node.(*ast.BlockExpr); ok {
+		return true
+	}
+	for _, child := range node.Children() {
+		if child != nil && hasBlock(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockErrors returns the errors of clang for the code with blocks, except
+// errors of assignment of variables captured by blocks without the storage
+// class `__block`. The storage class is added by the AST tree of the first
+// run of clang, so such errors are expected for the first run.
+func blockErrors(output string) (errors []string) {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "error:") &&
+			!strings.Contains(line, "missing __block type specifier") {
+			errors = append(errors, line)
+		}
+	}
+	return
+}
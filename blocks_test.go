@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/preprocessor"
+)
+
+func TestBlockStorages(t *testing.T) {
+	// int sum(int n) {
+	//   int s = 0, t;
+	//   int m, v[n];
+	//   static int c;
+	//   int (*add)(int x) = (int (*)(int x))(void *)^int (int x) {...};
+	//   ...
+	// }
+	// void f(void) { int a; }
+	lines := strings.Split(`TranslationUnitDecl 0x1 <<invalid sloc>> <invalid sloc>
+|-FunctionDecl 0x2 <file.c:1:1, line:9:1> line:1:5 sum 'int (int)'
+| |-ParmVarDecl 0x3 <col:9, col:13> col:13 used n 'int'
+| `+"`"+`-CompoundStmt 0x4 <col:16, line:9:1>
+|   |-DeclStmt 0x5 <line:2:3, col:16>
+|   | |-VarDecl 0x6 <col:3, col:11> col:7 used s 'int' cinit
+|   | | `+"`"+`-IntegerLiteral 0x7 <col:11> 'int' 0
+|   | `+"`"+`-VarDecl 0x8 <col:3, col:14> col:14 t 'int'
+|   |-DeclStmt 0x9 <line:3:3, col:14>
+|   | |-VarDecl 0x10 <col:3, col:7> col:7 m 'int'
+|   | `+"`"+`-VarDecl 0x11 <col:3, col:13> col:10 v 'int [n]'
+|   |-DeclStmt 0x12 <line:4:3, col:15>
+|   | `+"`"+`-VarDecl 0x13 <col:3, col:14> col:14 c 'int' static
+|   `+"`"+`-DeclStmt 0x14 <line:5:3, line:7:4>
+|     `+"`"+`-VarDecl 0x15 <line:5:3, line:7:3> line:5:9 add 'int (*)(int)' cinit
+|       `+"`"+`-CStyleCastExpr 0x16 <col:23, line:7:3> 'int (*)(int)' <BitCast>
+|         `+"`"+`-CStyleCastExpr 0x17 <line:5:40, line:7:3> 'void *' <BitCast>
+|           `+"`"+`-BlockExpr 0x18 <line:5:48, line:7:3> 'int (^)(int)'
+|             `+"`"+`-BlockDecl 0x19 <line:5:48, line:7:3> line:5:48
+|               |-ParmVarDecl 0x20 <col:54, col:58> col:58 x 'int'
+|               `+"`"+`-CompoundStmt 0x21 <col:61, line:7:3>
+`+"`"+`-FunctionDecl 0x22 <line:10:1, col:23> col:6 f 'void (void)'
+  `+"`"+`-CompoundStmt 0x23 <col:14, col:23>
+    `+"`"+`-DeclStmt 0x24 <col:16, col:21>
+      `+"`"+`-VarDecl 0x25 <col:16, col:20> col:20 a 'int'`, "\n")
+
+	actual := blockStorages(lines)
+	expected := []preprocessor.BlockStorage{
+		{File: "file.c", Line: 2, Column: 3},
+		{File: "file.c", Line: 5, Column: 3},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Not expected positions: %v\nExpected: %v", actual, expected)
+	}
+}
+
+func TestBlockErrors(t *testing.T) {
+	output := `file.c:4:5: error: variable is not assignable (missing __block type specifier)
+    s += x;
+    ~ ^
+file.c:9:3: error: use of undeclared identifier 'y'
+  y = 1;
+  ^
+file.c:10:3: warning: unused variable 'z'
+2 errors generated.`
+	errors := blockErrors(output)
+	expected := []string{"file.c:9:3: error: use of undeclared identifier 'y'"}
+	if !reflect.DeepEqual(errors, expected) {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if errors := blockErrors(strings.Split(output, "\n")[0]); len(errors) != 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+}
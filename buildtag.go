@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"path/filepath"
+	"strings"
+)
+
+// buildTag - configuration of C code, which is transpiled in the separate Go
+// file with the build constraint. See option "-build-tag".
+type buildTag struct {
+	// Tag - expression of build constraint, for example "windows" or
+	// "!windows && debug"
+	Tag string
+
+	// ClangFlags - flags of clang for the configuration, for example
+	// "-D_WIN32"
+	ClangFlags []string
+}
+
+// parseBuildTag returns the configuration from value of option "-build-tag"
+// in format "tag=flags", for example:
+//
+//     windows=-D_WIN32 -U__linux__
+func parseBuildTag(value string) (b buildTag, err error) {
+	index := strings.Index(value, "=")
+	if index < 0 {
+		index = len(value)
+	}
+	b.Tag = strings.TrimSpace(value[:index])
+	if _, err = constraint.Parse("//go:build " + b.Tag); err != nil {
+		return b, fmt.Errorf("Wrong build tag `%s`: %v", b.Tag, err)
+	}
+	if index < len(value) {
+		b.ClangFlags = strings.Fields(value[index+1:])
+	}
+	return b, nil
+}
+
+// buildTagLines returns lines of build constraint for the top of Go file.
+// Lines `// +build` are added for Go before 1.17.
+func buildTagLines(tag string, goVersion int) (lines []string, err error) {
+	expr, err := constraint.Parse("//go:build " + tag)
+	if err != nil {
+		return
+	}
+	lines = append(lines, "//go:build "+expr.String())
+	if goVersion < 17 {
+		var plus []string
+		plus, err = constraint.PlusBuildLines(expr)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, plus...)
+	}
+	return
+}
+
+// buildTagFile returns the name of Go file for the build tag, for example
+// "main.go" and tag "!windows" gives "main.not_windows.go". Tag is separated
+// by dot, so the name of file has not implicit constraint of GOOS or GOARCH
+// like "main_windows.go".
+func buildTagFile(outputFile, tag string) string {
+	tag = strings.NewReplacer("!", " not ", "&&", " and ", "||", " or ").
+		Replace(tag)
+	words := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return !(r == '_' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9'))
+	})
+	extension := filepath.Ext(outputFile)
+	return strings.TrimSuffix(outputFile, extension) + "." +
+		strings.Join(words, "_") + extension
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBuildTag(t *testing.T) {
+	tcs := []struct {
+		value    string
+		expected buildTag
+		isError  bool
+	}{
+		{
+			value: "windows=-D_WIN32 -U__linux__",
+			expected: buildTag{
+				Tag:        "windows",
+				ClangFlags: []string{"-D_WIN32", "-U__linux__"},
+			},
+		},
+		{
+			value:    "!debug",
+			expected: buildTag{Tag: "!debug"},
+		},
+		{
+			value: "linux && debug = -DDEBUG=1",
+			expected: buildTag{
+				Tag:        "linux && debug",
+				ClangFlags: []string{"-DDEBUG=1"},
+			},
+		},
+		{value: "=-DDEBUG", isError: true},
+		{value: "linux &&=-DDEBUG", isError: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.value, func(t *testing.T) {
+			b, err := parseBuildTag(tc.value)
+			if tc.isError {
+				if err == nil {
+					t.Fatalf("Expect error for value %s", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(b, tc.expected) {
+				t.Fatalf("Unexpected build tag: %#v != %#v", b, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBuildTagFile(t *testing.T) {
+	tcs := []struct {
+		tag      string
+		expected string
+	}{
+		{tag: "windows", expected: "build/main.windows.go"},
+		{tag: "!windows", expected: "build/main.not_windows.go"},
+		{tag: "linux && !DEBUG", expected: "build/main.linux_and_not_debug.go"},
+		{tag: "(darwin || freebsd)", expected: "build/main.darwin_or_freebsd.go"},
+	}
+	for _, tc := range tcs {
+		if file := buildTagFile("build/main.go", tc.tag); file != tc.expected {
+			t.Errorf("Unexpected file for tag `%s`: %s != %s", tc.tag, file, tc.expected)
+		}
+	}
+}
+
+func TestBuildTagLines(t *testing.T) {
+	lines, err := buildTagLines("linux && !debug", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "//go:build linux && !debug\n// +build linux,!debug"
+	if s := strings.Join(lines, "\n"); s != expected {
+		t.Errorf("Unexpected lines:\n%s\nExpected:\n%s", s, expected)
+	}
+
+	lines, err = buildTagLines("windows", 17)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(lines, "\n"); s != "//go:build windows" {
+		t.Errorf("Unexpected lines: %s", s)
+	}
+}
@@ -34,6 +34,18 @@ var cliTests = map[string][]string{
 
 	// Test that help is printed if help flag is set, even if file is given
 	"AstHelpFlag": {"test", "ast", "-h", "foo.c"},
+
+	// Test that help is printed if help flag is set
+	"CorpusHelpFlag": {"test", "corpus", "-h"},
+
+	// Test that help is printed if help flag is set
+	"StatsHelpFlag": {"test", "stats", "-h"},
+
+	// Test that help is printed if no files are given
+	"BatchNoFilesHelp": {"test", "batch"},
+
+	// Test that help is printed if no files are given
+	"AdviseNoFilesHelp": {"test", "advise"},
 }
 
 func TestCLI(t *testing.T) {
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// corpusProject is an open-source C project for checking the progress of
+// transpiling. The list of projects is defined in JSON file, for example:
+//
+//     [
+//       {
+//         "name": "cJSON",
+//         "repository": "https://github.com/DaveGamble/cJSON",
+//         "files": ["cJSON.c", "test.c"],
+//         "test": true
+//       }
+//     ]
+//
+type corpusProject struct {
+	// Name of project. It is also the name of folder with the source code.
+	Name string `json:"name"`
+
+	// Repository is the URL of git repository.
+	Repository string `json:"repository"`
+
+	// Files is a list of glob patterns of C source files, relative to the
+	// root of repository. All files are transpiled in one Go file, so only
+	// one of them may have function main. By default: "*.c".
+	Files []string `json:"files"`
+
+	// ClangFlags are flags for clang, for example: "-I./include".
+	ClangFlags []string `json:"clang_flags"`
+
+	// Test defines - run or not the Go program after build. The program
+	// is passed, if exit code is zero.
+	Test bool `json:"test"`
+
+	// Args is a list of arguments for test run.
+	Args []string `json:"args"`
+}
+
+// defaultCorpus is the list of projects, used if configuration file is not
+// defined.
+var defaultCorpus = []corpusProject{
+	{
+		Name:       "cJSON",
+		Repository: "https://github.com/DaveGamble/cJSON",
+		Files:      []string{"cJSON.c", "test.c"},
+		Test:       true,
+	},
+	{
+		Name:       "tiny-AES-c",
+		Repository: "https://github.com/kokke/tiny-AES-c",
+		Files:      []string{"aes.c", "test.c"},
+		Test:       true,
+	},
+	{
+		Name:       "lsh",
+		Repository: "https://github.com/brenns10/lsh",
+		Files:      []string{"src/main.c"},
+	},
+	{
+		Name:       "kilo",
+		Repository: "https://github.com/antirez/kilo",
+		Files:      []string{"kilo.c"},
+	},
+}
+
+// Status of corpus step.
+const (
+	corpusOk   = "ok"
+	corpusFail = "fail"
+	corpusSkip = "-"
+)
+
+// corpusResult is a line of scoreboard.
+type corpusResult struct {
+	Name      string
+	Clone     string
+	Transpile string
+	Warnings  int
+	Build     string
+	Test      string
+
+	// Error is the reason of the first failed step.
+	Error string
+}
+
+// loadCorpusConfig reads the list of projects from JSON file.
+func loadCorpusConfig(filename string) (projects []corpusProject, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read corpus configuration: %v", err)
+	}
+	err = json.Unmarshal(content, &projects)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse corpus configuration `%s`: %v",
+			filename, err)
+	}
+	for i, p := range projects {
+		if p.Name == "" || p.Repository == "" {
+			return nil, fmt.Errorf(
+				"Project #%d in `%s` must have name and repository", i, filename)
+		}
+	}
+	return
+}
+
+// runCorpusProject clones, transpiles, builds and tests one project. All
+// files are placed in the folder dir.
+func runCorpusProject(project corpusProject, dir string) (r corpusResult) {
+	r = corpusResult{
+		Name:      project.Name,
+		Clone:     corpusSkip,
+		Transpile: corpusSkip,
+		Build:     corpusSkip,
+		Test:      corpusSkip,
+	}
+	source := filepath.Join(dir, project.Name)
+
+	// clone
+	if _, err := os.Stat(source); err == nil {
+		r.Clone = corpusOk
+	} else {
+		out, err := exec.Command("git", "clone", "--depth", "1",
+			project.Repository, source).CombinedOutput()
+		if err != nil {
+			r.Clone = corpusFail
+			r.Error = fmt.Sprintf("git clone: %v. %s", err, out)
+			return
+		}
+		r.Clone = corpusOk
+	}
+
+	// transpile
+	patterns := project.Files
+	if len(patterns) == 0 {
+		patterns = []string{"*.c"}
+	}
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(source, pattern))
+		if err != nil {
+			r.Transpile = corpusFail
+			r.Error = fmt.Sprintf("pattern `%s`: %v", pattern, err)
+			return
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		r.Transpile = corpusFail
+		r.Error = "C source files are not found"
+		return
+	}
+
+	output := filepath.Join(dir, project.Name+"-c4go", "main.go")
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		r.Transpile = corpusFail
+		r.Error = err.Error()
+		return
+	}
+	args := DefaultProgramArgs()
+	args.inputFiles = files
+	args.outputFile = output
+	args.clangFlags = project.ClangFlags
+	if err := corpusTranspile(args); err != nil {
+		r.Transpile = corpusFail
+		r.Error = err.Error()
+		return
+	}
+	r.Transpile = corpusOk
+	if content, err := ioutil.ReadFile(output); err == nil {
+		r.Warnings = strings.Count(string(content), "// Warning")
+	}
+
+	// build
+	binary := filepath.Join(filepath.Dir(output), project.Name)
+	out, err := exec.Command("go", "build", "-o", binary, output).CombinedOutput()
+	if err != nil {
+		r.Build = corpusFail
+		r.Error = fmt.Sprintf("go build: %v. %s", err, out)
+		return
+	}
+	r.Build = corpusOk
+
+	// test
+	if !project.Test {
+		return
+	}
+	cmd := exec.Command(binary, project.Args...)
+	cmd.Dir = source
+	out, err = cmd.CombinedOutput()
+	if err != nil {
+		r.Test = corpusFail
+		r.Error = fmt.Sprintf("test: %v. %s", err, out)
+		return
+	}
+	r.Test = corpusOk
+	return
+}
+
+// corpusTranspile transpiles the C code. Panics of transpiler are
+// converted to errors, because one project must not stop the corpus.
+func corpusTranspile(args ProgramArgs) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return Start(args)
+}
+
+// writeScoreboard prints results of corpus as table with the summary.
+func writeScoreboard(w io.Writer, results []corpusResult, verbose bool) {
+	fmt.Fprintf(w, "%-20s %-6s %-10s %-9s %-6s %-6s\n",
+		"project", "clone", "transpile", "warnings", "build", "test")
+	var transpiled, built, tested, withTest int
+	for _, r := range results {
+		fmt.Fprintf(w, "%-20s %-6s %-10s %-9d %-6s %-6s\n",
+			r.Name, r.Clone, r.Transpile, r.Warnings, r.Build, r.Test)
+		if r.Transpile == corpusOk {
+			transpiled++
+		}
+		if r.Build == corpusOk {
+			built++
+		}
+		if r.Test != corpusSkip {
+			withTest++
+		}
+		if r.Test == corpusOk {
+			tested++
+		}
+	}
+	fmt.Fprintf(w, "\nTranspiled: %d/%d, built: %d/%d, tests passed: %d/%d\n",
+		transpiled, len(results), built, len(results), tested, withTest)
+
+	if !verbose {
+		return
+	}
+	for _, r := range results {
+		if r.Error == "" {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n%s\n", r.Name, strings.TrimSpace(r.Error))
+	}
+}
+
+// runCorpus runs all projects of corpus and writes the scoreboard.
+func runCorpus(projects []corpusProject, dir string, w io.Writer,
+	verbose bool) (err error) {
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("Cannot create corpus folder: %v", err)
+	}
+	var results []corpusResult
+	for _, project := range projects {
+		if verbose {
+			fmt.Fprintf(stderr, "Corpus project: %s\n", project.Name)
+		}
+		results = append(results, runCorpusProject(project, dir))
+	}
+	writeScoreboard(w, results, verbose)
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadCorpusConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-corpus-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcs := []struct {
+		content string
+		isError bool
+		amount  int
+	}{
+		{`[{"name":"a","repository":"https://example.com/a","test":true}]`, false, 1},
+		{`[{"name":"a","repository":"r"},{"name":"b","repository":"r"}]`, false, 2},
+		{`[{"name":"a"}]`, true, 0},
+		{`not json`, true, 0},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "corpus.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		projects, err := loadCorpusConfig(filename)
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if len(projects) != tc.amount {
+			t.Errorf("Case %d: expected %d projects, got %d",
+				i, tc.amount, len(projects))
+		}
+	}
+
+	if _, err := loadCorpusConfig(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
+
+func TestCorpusScoreboard(t *testing.T) {
+	results := []corpusResult{
+		{Name: "a", Clone: corpusOk, Transpile: corpusOk, Warnings: 3,
+			Build: corpusOk, Test: corpusOk},
+		{Name: "b", Clone: corpusOk, Transpile: corpusOk,
+			Build: corpusFail, Test: corpusSkip, Error: "go build: fail"},
+		{Name: "c", Clone: corpusFail, Transpile: corpusSkip,
+			Build: corpusSkip, Test: corpusSkip, Error: "git clone: fail"},
+	}
+	var buf bytes.Buffer
+	writeScoreboard(&buf, results, true)
+	out := buf.String()
+
+	for _, expect := range []string{
+		"Transpiled: 2/3, built: 1/3, tests passed: 1/1",
+		"go build: fail",
+		"git clone: fail",
+	} {
+		if !strings.Contains(out, expect) {
+			t.Errorf("Scoreboard haven't `%s`:\n%s", expect, out)
+		}
+	}
+}
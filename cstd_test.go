@@ -63,6 +63,30 @@ var cstd = map[string][]string{
 		"perror",
 	},
 	"assert.h": {"assert"},
+	"complex.h": {
+		"cabs",
+		"cacos",
+		"cacosh",
+		"carg",
+		"casin",
+		"casinh",
+		"catan",
+		"catanh",
+		"ccos",
+		"ccosh",
+		"cexp",
+		"cimag",
+		"clog",
+		"conj",
+		"cpow",
+		"cproj",
+		"creal",
+		"csin",
+		"csinh",
+		"csqrt",
+		"ctan",
+		"ctanh",
+	},
 	"ctype.h": {
 		"isalnum",
 		"isalpha",
@@ -79,8 +103,30 @@ var cstd = map[string][]string{
 		"tolower",
 		"toupper",
 	},
-	"errno.h":  {"errno"},
-	"float.h":  {},
+	"errno.h": {"errno"},
+	"fenv.h": {
+		"feclearexcept",
+		"feraiseexcept",
+		"fegetexceptflag",
+		"fesetexceptflag",
+		"fegetround",
+		"fesetround",
+		"fegetenv",
+		"fesetenv",
+		"feholdexcept",
+		"feupdateenv",
+		"fetestexcept",
+	},
+	"float.h": {},
+	"inttypes.h": {
+		"imaxabs",
+		"imaxdiv",
+		"strtoimax",
+		"strtoumax",
+		"wcstoimax",
+		"wcstoumax",
+		"imaxdiv_t",
+	},
 	"iso646.h": {},
 	"limits.h": {},
 	"locale.h": {
@@ -147,6 +193,12 @@ var cstd = map[string][]string{
 		"fabs",
 		"abs",
 		"fma",
+		"fpclassify",
+		"isfinite",
+		"isinf",
+		"isnan",
+		"isnormal",
+		"signbit",
 	},
 	"setjmp.h": {
 		"longjmp",
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseGoVersion returns the minor version of Go 1.x from strings like
+// "1.22", "1.22.3" or "go1.22".
+func parseGoVersion(version string) (minor int, err error) {
+	parts := strings.Split(strings.TrimPrefix(version, "go"), ".")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "1" {
+		return 0, fmt.Errorf("Unknown version of Go: %s", version)
+	}
+	for _, part := range parts[1:] {
+		if n, err := strconv.Atoi(part); err != nil || n < 0 {
+			return 0, fmt.Errorf("Unknown version of Go: %s", version)
+		}
+	}
+	minor, _ = strconv.Atoi(parts[1])
+	return minor, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestParseGoVersion(t *testing.T) {
+	tcs := []struct {
+		version string
+		minor   int
+		isError bool
+	}{
+		{version: "1.22", minor: 22},
+		{version: "1.21.5", minor: 21},
+		{version: "go1.17", minor: 17},
+		{version: "1", isError: true},
+		{version: "2.0", isError: true},
+		{version: "1.x", isError: true},
+		{version: "1.-2", isError: true},
+		{version: "1.22.3.1", isError: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.version, func(t *testing.T) {
+			minor, err := parseGoVersion(tc.version)
+			if tc.isError {
+				if err == nil {
+					t.Fatalf("Expect error for version %s", tc.version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if minor != tc.minor {
+				t.Fatalf("Unexpected minor version: %d != %d", minor, tc.minor)
+			}
+		})
+	}
+}
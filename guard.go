@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// guardConfig is the list of global variables of C code, access to which is
+// guarded by the reentrant mutex in Go code. Teams embedding the transpiled
+// code in concurrent Go services may add variables in the list one by one,
+// running "go test -race" after each step. Example of JSON file:
+//
+//     {
+//       "globals": ["counter", "cache"]
+//     }
+//
+type guardConfig struct {
+	// Globals is the list of names of global variables in C code.
+	Globals []string `json:"globals"`
+}
+
+// loadGuardConfig reads the list of guarded global variables from JSON file.
+func loadGuardConfig(filename string) (names map[string]bool, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read guard configuration: %v", err)
+	}
+	var c guardConfig
+	if err = json.Unmarshal(content, &c); err != nil {
+		return nil, fmt.Errorf("Cannot parse guard configuration: %v", err)
+	}
+	names = map[string]bool{}
+	for _, name := range c.Globals {
+		if !util.GetRegex(`^[a-zA-Z_][a-zA-Z0-9_]*$`).MatchString(name) {
+			return nil, fmt.Errorf("Name `%s` in guard configuration "+
+				"is not valid name of variable", name)
+		}
+		names[name] = true
+	}
+	return names, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGuardConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-guard-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcs := []struct {
+		content string
+		isError bool
+		names   []string
+	}{
+		{`{"globals":["counter","cache_1"]}`, false, []string{"counter", "cache_1"}},
+		{`{"globals":[]}`, false, nil},
+		{`{"globals":["a.b"]}`, true, nil},
+		{`{"globals":["1a"]}`, true, nil},
+		{`not json`, true, nil},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "guard.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names, err := loadGuardConfig(filename)
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if len(names) != len(tc.names) {
+			t.Errorf("Case %d: expected %v, got %v", i, tc.names, names)
+		}
+		for _, name := range tc.names {
+			if !names[name] {
+				t.Errorf("Case %d: variable `%s` is not found", i, name)
+			}
+		}
+	}
+
+	if _, err := loadGuardConfig(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// licenseConfig is the configuration of licenses of C project. Leading
+// comments of C files with licenses are carried into the generated Go code
+// with the provenance note. Example of JSON file:
+//
+//     {
+//       "keywords": ["Copyright", "SPDX-License-Identifier"],
+//       "header": "Copyright (c) 2010 Author. Licensed under MIT.",
+//       "project": "https://github.com/user/project v1.2",
+//       "root": "/home/user/project"
+//     }
+//
+type licenseConfig struct {
+	// Keywords - the leading comment of C file is the license, if the
+	// comment contains one of keywords. Case of letters is ignored. By
+	// default: defaultLicenseKeywords.
+	Keywords []string `json:"keywords"`
+
+	// Header is the license of C files without license comment.
+	Header string `json:"header"`
+
+	// Project is the name or URL of C project in the provenance note.
+	Project string `json:"project"`
+
+	// Root is the folder of C project. Names of C files in the provenance
+	// note are relative to the folder. By default, base names of files are
+	// used.
+	Root string `json:"root"`
+}
+
+// defaultLicenseKeywords - keywords of license comments.
+var defaultLicenseKeywords = []string{
+	"copyright",
+	"license",
+	"licence",
+	"spdx-license-identifier",
+	"permission is hereby granted",
+	"all rights reserved",
+	"public domain",
+}
+
+// loadLicenseConfig reads the configuration of licenses from JSON file.
+func loadLicenseConfig(filename string) (c licenseConfig, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return c, fmt.Errorf("Cannot read license configuration: %v", err)
+	}
+	if err = json.Unmarshal(content, &c); err != nil {
+		return c, fmt.Errorf("Cannot parse license configuration: %v", err)
+	}
+	for _, keyword := range c.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return c, fmt.Errorf("Empty keyword in license configuration")
+		}
+	}
+	if len(c.Keywords) == 0 {
+		c.Keywords = defaultLicenseKeywords
+	}
+	return c, nil
+}
+
+// leadingComments returns texts of comments at the beginning of C source
+// before the first token of code. Markers of comments are removed.
+// Consecutive line comments `//` are one comment, blank line separates
+// comments.
+func leadingComments(source string) (comments []string) {
+	s := strings.TrimPrefix(source, "\uFEFF")
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			comments = append(comments, trimCommentLines(lines))
+			lines = nil
+		}
+	}
+	for {
+		trimmed := strings.TrimLeft(s, " \t\r\n")
+		if strings.Count(s[:len(s)-len(trimmed)], "\n") > 1 {
+			flush()
+		}
+		s = trimmed
+		switch {
+		case strings.HasPrefix(s, "//"):
+			end := strings.IndexByte(s, '\n')
+			if end < 0 {
+				end = len(s)
+			}
+			lines = append(lines, strings.TrimPrefix(s[2:end], " "))
+			s = s[end:]
+
+		case strings.HasPrefix(s, "/*"):
+			flush()
+			end := strings.Index(s, "*/")
+			if end < 0 {
+				return
+			}
+			var block []string
+			for _, line := range strings.Split(s[2:end], "\n") {
+				// decoration of block: " * text" and "*****"
+				line = strings.TrimLeft(line, " \t")
+				if strings.Trim(line, "*") == "" {
+					line = ""
+				} else if strings.HasPrefix(line, "*") {
+					line = strings.TrimPrefix(line[1:], " ")
+				}
+				block = append(block, line)
+			}
+			comments = append(comments, trimCommentLines(block))
+			s = s[end+2:]
+
+		default:
+			flush()
+			return
+		}
+	}
+}
+
+// trimCommentLines joins lines of comment without trailing spaces and empty
+// lines at the beginning and at the end.
+func trimCommentLines(lines []string) string {
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isLicense returns true, if comment contains one of keywords.
+func isLicense(comment string, keywords []string) bool {
+	comment = strings.ToLower(comment)
+	for _, keyword := range keywords {
+		if strings.Contains(comment, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// licenseHeader returns Go comments with licenses of C files and the
+// provenance note, for example:
+//
+//     // Copyright (c) 2010 Author
+//     // Licensed under MIT.
+//
+//     // Transpiled by c4go from C file src/list.c of project
+//     // https://github.com/user/project v1.2
+//
+// Files with the same license share the comment. If files have different
+// licenses, then the names of files are written before each license.
+func licenseHeader(c licenseConfig, files []string) (string, error) {
+	var licenses []string
+	licenseFiles := map[string][]string{}
+	var names []string
+	for _, file := range files {
+		source, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("Cannot read license of file: %v", err)
+		}
+		name := licenseFileName(c.Root, file)
+		names = append(names, name)
+
+		var found []string
+		for _, comment := range leadingComments(string(source)) {
+			if isLicense(comment, c.Keywords) {
+				found = append(found, comment)
+			}
+		}
+		if len(found) == 0 && strings.TrimSpace(c.Header) != "" {
+			found = append(found, strings.TrimSpace(c.Header))
+		}
+		if len(found) == 0 {
+			continue
+		}
+		license := strings.Join(found, "\n\n")
+		if _, ok := licenseFiles[license]; !ok {
+			licenses = append(licenses, license)
+		}
+		licenseFiles[license] = append(licenseFiles[license], name)
+	}
+
+	var buf bytes.Buffer
+	for _, license := range licenses {
+		if len(licenses) > 1 {
+			fmt.Fprintf(&buf, "// License of C files: %s\n//\n",
+				strings.Join(licenseFiles[license], ", "))
+		}
+		for _, line := range strings.Split(license, "\n") {
+			if line == "" {
+				buf.WriteString("//\n")
+				continue
+			}
+			fmt.Fprintf(&buf, "// %s\n", line)
+		}
+		buf.WriteString("\n")
+	}
+
+	note := "Transpiled by c4go from C file"
+	if len(names) > 1 {
+		note += "s"
+	}
+	note += " " + strings.Join(names, ", ")
+	if c.Project != "" {
+		note += " of project\n// " + c.Project
+	}
+	fmt.Fprintf(&buf, "// %s\n\n", note)
+	return buf.String(), nil
+}
+
+// licenseFileName returns the name of C file for the provenance note.
+func licenseFileName(root, file string) string {
+	if root != "" {
+		absRoot, errRoot := filepath.Abs(root)
+		absFile, errFile := filepath.Abs(file)
+		if errRoot == nil && errFile == nil {
+			rel, err := filepath.Rel(absRoot, absFile)
+			if err == nil && !strings.HasPrefix(rel, "..") {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+	return filepath.Base(file)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLeadingComments(t *testing.T) {
+	tcs := []struct {
+		source   string
+		comments []string
+	}{
+		{"int a;", nil},
+		{"", nil},
+		{
+			"/* Copyright (c) 2010 Author */\nint a;",
+			[]string{"Copyright (c) 2010 Author"},
+		},
+		{
+			"\uFEFF/*\n * Copyright (c) 2010 Author\n *\n * MIT License\n */\n",
+			[]string{"Copyright (c) 2010 Author\n\nMIT License"},
+		},
+		{
+			"/*********\n * Banner\n *********/\n",
+			[]string{"Banner"},
+		},
+		{
+			"// SPDX-License-Identifier: MIT\n// Copyright Author\n\n" +
+				"// list.c - linked list\n#include <stdio.h>\n// not leading\n",
+			[]string{"SPDX-License-Identifier: MIT\nCopyright Author",
+				"list.c - linked list"},
+		},
+		{
+			"/* first */ /* second */ int a; /* third */",
+			[]string{"first", "second"},
+		},
+		{"/* not closed", nil},
+	}
+	for i, tc := range tcs {
+		comments := leadingComments(tc.source)
+		if len(comments) != len(tc.comments) {
+			t.Errorf("Case %d: expected %q, got %q", i, tc.comments, comments)
+			continue
+		}
+		for j := range comments {
+			if comments[j] != tc.comments[j] {
+				t.Errorf("Case %d: expected %q, got %q", i, tc.comments[j], comments[j])
+			}
+		}
+	}
+}
+
+func TestLicenseHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-license-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"src/a.c": "/*\n * Copyright (c) 2010 Author\n */\n// a.c - parser\nint a;\n",
+		"src/b.c": "/* Copyright (c) 2010 Author */\nint b;\n",
+		"src/c.c": "// c.c - helpers\nint c;\n",
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := func(names ...string) (paths []string) {
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+		return
+	}
+
+	tcs := []struct {
+		c      licenseConfig
+		files  []string
+		header string
+	}{
+		{
+			licenseConfig{Keywords: defaultLicenseKeywords},
+			path("src/a.c", "src/b.c"),
+			"// Copyright (c) 2010 Author\n\n" +
+				"// Transpiled by c4go from C files a.c, b.c\n\n",
+		},
+		{
+			licenseConfig{Keywords: defaultLicenseKeywords, Root: dir,
+				Project: "https://github.com/user/project v1.2"},
+			path("src/c.c"),
+			"// Transpiled by c4go from C file src/c.c of project\n" +
+				"// https://github.com/user/project v1.2\n\n",
+		},
+		{
+			licenseConfig{Keywords: defaultLicenseKeywords,
+				Header: "Public domain.\n\nNo warranty."},
+			path("src/a.c", "src/c.c"),
+			"// License of C files: a.c\n//\n// Copyright (c) 2010 Author\n\n" +
+				"// License of C files: c.c\n//\n// Public domain.\n//\n// No warranty.\n\n" +
+				"// Transpiled by c4go from C files a.c, c.c\n\n",
+		},
+		{
+			licenseConfig{Keywords: []string{"PARSER"}},
+			path("src/a.c"),
+			"// a.c - parser\n\n// Transpiled by c4go from C file a.c\n\n",
+		},
+	}
+	for i, tc := range tcs {
+		header, err := licenseHeader(tc.c, tc.files)
+		if err != nil {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if header != tc.header {
+			t.Errorf("Case %d: expected:\n%s\ngot:\n%s", i, tc.header, header)
+		}
+	}
+
+	if _, err := licenseHeader(licenseConfig{}, path("not_exist.c")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
+
+func TestLicenseConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-license-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcs := []struct {
+		content  string
+		isError  bool
+		keywords string
+	}{
+		{`{"project":"lib"}`, false, strings.Join(defaultLicenseKeywords, ",")},
+		{`{"keywords":["Copyright","GPL"]}`, false, "Copyright,GPL"},
+		{`{"keywords":[" "]}`, true, ""},
+		{`not json`, true, ""},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "license.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := loadLicenseConfig(filename)
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if err == nil && strings.Join(c.Keywords, ",") != tc.keywords {
+			t.Errorf("Case %d: expected keywords %s, got %v", i, tc.keywords, c.Keywords)
+		}
+	}
+
+	if _, err := loadLicenseConfig(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
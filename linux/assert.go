@@ -3,27 +3,58 @@ package linux
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
 
 	"github.com/Konstantin8105/c4go/noarch"
 )
 
-var osExit func(int) = os.Exit
+var osAbort func() = Abort
 
 // AssertFail handles __assert_fail().
+//
+// Prints the message about failed assertion in standard error stream and
+// aborts the program, as in glibc:
+//
+//     program: file.c:12: void print_number(int *): Assertion `myInt != NULL' failed.
+//
 func AssertFail(
 	expression, filePath []byte,
 	lineNumber uint32,
 	functionName []byte,
 ) bool {
+	var function string
+	if name := noarch.CStringToString(functionName); name != "" {
+		function = name + ": "
+	}
 	fmt.Fprintf(
 		os.Stderr,
-		"a.out: %s:%d: %s: Assertion `%s' failed.\n",
+		"%s: %s:%d: %sAssertion `%s' failed.\n",
+		filepath.Base(os.Args[0]),
 		noarch.CStringToString(filePath),
 		lineNumber,
-		noarch.CStringToString(functionName),
+		function,
 		noarch.CStringToString(expression),
 	)
-	osExit(134)
+	osAbort()
 
 	return true
 }
+
+// Abort handles abort().
+//
+// Terminates the program by the signal SIGABRT, as in C. The Go runtime
+// prints the stack trace for SIGABRT, so the default action of signal is
+// restored before. Streams are not flushed.
+func Abort() {
+	// struct sigaction with handler SIG_DFL is zero
+	var action [4]uint64
+	_, _, _ = syscall.RawSyscall6(syscall.SYS_RT_SIGACTION,
+		uintptr(syscall.SIGABRT), uintptr(unsafe.Pointer(&action)), 0,
+		8, 0, 0)
+	_ = syscall.Tgkill(syscall.Getpid(), syscall.Gettid(), syscall.SIGABRT)
+
+	// signal is blocked or ignored
+	os.Exit(134)
+}
@@ -1,21 +1,45 @@
 package linux
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
 func TestAssertFail(t *testing.T) {
-	var res int
-	osExit = func(code int) {
-		res = code
+	var aborted bool
+	osAbort = func() {
+		aborted = true
 	}
+	stderr := os.Stderr
 	defer func() {
-		osExit = os.Exit
+		osAbort = Abort
+		os.Stderr = stderr
 	}()
 
-	_ = AssertFail([]byte(""), []byte(""), 10, []byte(""))
-	if res != 134 {
-		t.Fatalf("Another result")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+
+	_ = AssertFail([]byte("a != NULL\x00"), []byte("file.c\x00"), 10,
+		[]byte("void f(int *)\x00"))
+	_ = AssertFail([]byte("b\x00"), []byte("file.c\x00"), 12, nil)
+	w.Close()
+
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !aborted {
+		t.Fatalf("Program is not aborted")
+	}
+	name := filepath.Base(os.Args[0])
+	expect := name + ": file.c:10: void f(int *): Assertion `a != NULL' failed.\n" +
+		name + ": file.c:12: Assertion `b' failed.\n"
+	if string(out) != expect {
+		t.Fatalf("Not same message:\n%s\n%s", out, expect)
 	}
 }
@@ -1,96 +1,234 @@
 package linux
 
-import (
-	"unicode"
+// Classes of characters of ctype.h. The values are the same as the values of
+// enum constants _ISupper, _ISalpha and so on of glibc on little-endian
+// machines.
+const (
+	ctypeUpper  = (1 << (0)) << 8
+	ctypeLower  = (1 << (1)) << 8
+	ctypeAlpha  = (1 << (2)) << 8
+	ctypeDigit  = (1 << (3)) << 8
+	ctypeXdigit = (1 << (4)) << 8
+	ctypeSpace  = (1 << (5)) << 8
+	ctypePrint  = (1 << (6)) << 8
+	ctypeGraph  = (1 << (7)) << 8
+	ctypeBlank  = (1 << (8)) >> 8
+	ctypeCntrl  = (1 << (9)) >> 8
+	ctypePunct  = (1 << (10)) >> 8
+	ctypeAlnum  = (1 << (11)) >> 8
 )
 
-var characterTable []uint16
-
-func generateCharacterTable() {
-	for i := 0; i < 255; i++ {
-		var c uint16
-
-		// Each of the bitwise expressions below were copied from the enum
-		// values, like _ISupper, etc.
+// CtypeOffset is the offset of character in the tables of ctype.h. As in
+// glibc, the tables contain characters from -128 to 255, so the values of
+// signed char and EOF (-1) are valid indexes. The transpiler adds the offset
+// to indexes of the tables.
+const CtypeOffset = 128
+
+// Tables of ctype.h for the "C" locale, which are the same as the tables of
+// glibc. Only characters of ASCII have classes, other characters are not
+// changed by toupper() and tolower(). Negative values of signed char are
+// converted to unsigned char, but EOF is EOF.
+var (
+	ctypeB       = make([]uint16, 384)
+	ctypeToupper = make([]int32, 384)
+	ctypeTolower = make([]int32, 384)
+)
 
-		if unicode.IsUpper(rune(i)) {
-			c |= ((1 << (0)) << 8)
+func init() {
+	for c := -128; c < 256; c++ {
+		i := c + CtypeOffset
+		u := int32(c)
+		if c < -1 {
+			u = int32(c + 256)
 		}
-
-		if unicode.IsLower(rune(i)) {
-			c |= ((1 << (1)) << 8)
+		ctypeToupper[i] = u
+		ctypeTolower[i] = u
+		if c < 0 || c > 127 {
+			continue
 		}
-
-		if unicode.IsLetter(rune(i)) {
-			c |= ((1 << (2)) << 8)
+		var t uint16
+		switch {
+		case 'A' <= c && c <= 'Z':
+			t |= ctypeUpper | ctypeAlpha
+			ctypeTolower[i] = u + 'a' - 'A'
+		case 'a' <= c && c <= 'z':
+			t |= ctypeLower | ctypeAlpha
+			ctypeToupper[i] = u + 'A' - 'a'
+		case '0' <= c && c <= '9':
+			t |= ctypeDigit | ctypeXdigit
 		}
-
-		if unicode.IsDigit(rune(i)) {
-			c |= ((1 << (3)) << 8)
+		if t&(ctypeAlpha|ctypeDigit) != 0 {
+			t |= ctypeAlnum
 		}
-
-		if unicode.IsDigit(rune(i)) ||
-			(i >= 'a' && i <= 'f') ||
-			(i >= 'A' && i <= 'F') {
-			// IsXDigit. This is the same implementation as the Mac version.
-			// There may be a better way to do this.
-			c |= ((1 << (4)) << 8)
+		if ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F') {
+			t |= ctypeXdigit
 		}
-
-		if unicode.IsSpace(rune(i)) {
-			c |= ((1 << (5)) << 8)
+		if c == ' ' || ('\t' <= c && c <= '\r') {
+			t |= ctypeSpace
 		}
-
-		if unicode.IsPrint(rune(i)) {
-			c |= ((1 << (6)) << 8)
+		if c == ' ' || c == '\t' {
+			t |= ctypeBlank
 		}
-
-		// The IsSpace check is required because Go treats spaces as graphic
-		// characters, which C does not.
-		if unicode.IsGraphic(rune(i)) && !unicode.IsSpace(rune(i)) {
-			c |= ((1 << (7)) << 8)
+		if c < ' ' || c == 127 {
+			t |= ctypeCntrl
 		}
-
-		// http://www.cplusplus.com/reference/cctype/isblank/
-		// The standard "C" locale considers blank characters the tab
-		// character ('\t') and the space character (' ').
-		if i == int('\t') || i == int(' ') {
-			c |= ((1 << (8)) >> 8)
+		if ' ' <= c && c < 127 {
+			t |= ctypePrint
 		}
-
-		if unicode.IsControl(rune(i)) {
-			c |= ((1 << (9)) >> 8)
+		if ' ' < c && c < 127 {
+			t |= ctypeGraph
+			if t&ctypeAlnum == 0 {
+				t |= ctypePunct
+			}
 		}
+		ctypeB[i] = t
+	}
+}
 
-		if unicode.IsPunct(rune(i)) {
-			c |= ((1 << (10)) >> 8)
-		}
+// CtypeLoc handles __ctype_b_loc(). It returns a table of classes of
+// characters, an index of table is a character plus CtypeOffset.
+func CtypeLoc() [][]uint16 {
+	return [][]uint16{ctypeB}
+}
 
-		if unicode.IsLetter(rune(i)) || unicode.IsDigit(rune(i)) {
-			c |= ((1 << (11)) >> 8)
-		}
+// CtypeToupperLoc handles __ctype_toupper_loc(). It returns a table of upper
+// case of characters, an index of table is a character plus CtypeOffset.
+func CtypeToupperLoc() [][]int32 {
+	return [][]int32{ctypeToupper}
+}
 
-		// Yes, I know this is a hideously slow way to do it but I just want to
-		// test if this works right now.
-		characterTable = append(characterTable, c)
-	}
+// CtypeTolowerLoc handles __ctype_tolower_loc(). It returns a table of lower
+// case of characters, an index of table is a character plus CtypeOffset.
+func CtypeTolowerLoc() [][]int32 {
+	return [][]int32{ctypeTolower}
 }
 
-// CtypeLoc handles __ctype_b_loc(). It returns a character table.
-func CtypeLoc() [][]uint16 {
-	if len(characterTable) == 0 {
-		generateCharacterTable()
+// isCtype returns the class of character, if the character has the class.
+// Values outside of the tables have no classes.
+func isCtype(c int, class uint16) int {
+	if c < -CtypeOffset || c >= len(ctypeB)-CtypeOffset {
+		return 0
 	}
+	return int(ctypeB[c+CtypeOffset] & class)
+}
+
+// IsAlnum handles isalnum().
+func IsAlnum(c int) int {
+	return isCtype(c, ctypeAlnum)
+}
 
-	return [][]uint16{characterTable}
+// IsAlpha handles isalpha().
+func IsAlpha(c int) int {
+	return isCtype(c, ctypeAlpha)
 }
 
-// ToLower handles tolower().
-func ToLower(_c int) int {
-	return int(unicode.ToLower(rune(_c)))
+// IsBlank handles isblank().
+func IsBlank(c int) int {
+	return isCtype(c, ctypeBlank)
 }
 
-// ToUpper handles toupper().
-func ToUpper(_c int) int {
-	return int(unicode.ToUpper(rune(_c)))
+// IsCntrl handles iscntrl().
+func IsCntrl(c int) int {
+	return isCtype(c, ctypeCntrl)
 }
+
+// IsDigit handles isdigit().
+func IsDigit(c int) int {
+	return isCtype(c, ctypeDigit)
+}
+
+// IsGraph handles isgraph().
+func IsGraph(c int) int {
+	return isCtype(c, ctypeGraph)
+}
+
+// IsLower handles islower().
+func IsLower(c int) int {
+	return isCtype(c, ctypeLower)
+}
+
+// IsPrint handles isprint().
+func IsPrint(c int) int {
+	return isCtype(c, ctypePrint)
+}
+
+// IsPunct handles ispunct().
+func IsPunct(c int) int {
+	return isCtype(c, ctypePunct)
+}
+
+// IsSpace handles isspace().
+func IsSpace(c int) int {
+	return isCtype(c, ctypeSpace)
+}
+
+// IsUpper handles isupper().
+func IsUpper(c int) int {
+	return isCtype(c, ctypeUpper)
+}
+
+// IsXdigit handles isxdigit().
+func IsXdigit(c int) int {
+	return isCtype(c, ctypeXdigit)
+}
+
+// ToLower handles tolower(). Values outside of the tables are not changed.
+func ToLower(c int) int {
+	if c < -CtypeOffset || c >= len(ctypeTolower)-CtypeOffset {
+		return c
+	}
+	return int(ctypeTolower[c+CtypeOffset])
+}
+
+// ToUpper handles toupper(). Values outside of the tables are not changed.
+func ToUpper(c int) int {
+	if c < -CtypeOffset || c >= len(ctypeToupper)-CtypeOffset {
+		return c
+	}
+	return int(ctypeToupper[c+CtypeOffset])
+}
+
+// Functions with suffix "_l" of POSIX.1-2008 take the locale as argument.
+// Only the "C" locale is supported, so the locale is ignored.
+
+// IsAlnumL handles isalnum_l().
+func IsAlnumL(c int, _ interface{}) int { return IsAlnum(c) }
+
+// IsAlphaL handles isalpha_l().
+func IsAlphaL(c int, _ interface{}) int { return IsAlpha(c) }
+
+// IsBlankL handles isblank_l().
+func IsBlankL(c int, _ interface{}) int { return IsBlank(c) }
+
+// IsCntrlL handles iscntrl_l().
+func IsCntrlL(c int, _ interface{}) int { return IsCntrl(c) }
+
+// IsDigitL handles isdigit_l().
+func IsDigitL(c int, _ interface{}) int { return IsDigit(c) }
+
+// IsGraphL handles isgraph_l().
+func IsGraphL(c int, _ interface{}) int { return IsGraph(c) }
+
+// IsLowerL handles islower_l().
+func IsLowerL(c int, _ interface{}) int { return IsLower(c) }
+
+// IsPrintL handles isprint_l().
+func IsPrintL(c int, _ interface{}) int { return IsPrint(c) }
+
+// IsPunctL handles ispunct_l().
+func IsPunctL(c int, _ interface{}) int { return IsPunct(c) }
+
+// IsSpaceL handles isspace_l().
+func IsSpaceL(c int, _ interface{}) int { return IsSpace(c) }
+
+// IsUpperL handles isupper_l().
+func IsUpperL(c int, _ interface{}) int { return IsUpper(c) }
+
+// IsXdigitL handles isxdigit_l().
+func IsXdigitL(c int, _ interface{}) int { return IsXdigit(c) }
+
+// ToLowerL handles tolower_l().
+func ToLowerL(c int, _ interface{}) int { return ToLower(c) }
+
+// ToUpperL handles toupper_l().
+func ToUpperL(c int, _ interface{}) int { return ToUpper(c) }
@@ -0,0 +1,64 @@
+package linux
+
+import "testing"
+
+func TestCtype(t *testing.T) {
+	// values of glibc for the "C" locale
+	tcs := []struct {
+		c         int
+		class     uint16
+		upper     int
+		lower     int
+		isalpha   int
+		isxdigit  int
+		isspace   int
+		ispunct   int
+		isnotchar bool
+	}{
+		{c: -129, upper: -129, lower: -129, isnotchar: true},
+		{c: -128, upper: 128, lower: 128},
+		{c: -23, upper: 233, lower: 233},
+		{c: -1, upper: -1, lower: -1},
+		{c: 0, class: 0x0002, upper: 0, lower: 0},
+		{c: '\t', class: 0x2003, upper: '\t', lower: '\t', isspace: 0x2000},
+		{c: ' ', class: 0x6001, upper: ' ', lower: ' ', isspace: 0x2000},
+		{c: '*', class: 0xc004, upper: '*', lower: '*', ispunct: 4},
+		{c: '7', class: 0xd808, upper: '7', lower: '7', isxdigit: 0x1000},
+		{c: 'B', class: 0xd508, upper: 'B', lower: 'b', isalpha: 0x400, isxdigit: 0x1000},
+		{c: 'z', class: 0xc608, upper: 'Z', lower: 'z', isalpha: 0x400},
+		{c: 127, class: 0x0002, upper: 127, lower: 127},
+		{c: 0xE9, upper: 0xE9, lower: 0xE9},
+		{c: 256, upper: 256, lower: 256, isnotchar: true},
+	}
+	for _, tc := range tcs {
+		if !tc.isnotchar {
+			if class := CtypeLoc()[0][tc.c+CtypeOffset]; class != tc.class {
+				t.Errorf("Class of %d: %#04x != %#04x", tc.c, class, tc.class)
+			}
+			if upper := CtypeToupperLoc()[0][tc.c+CtypeOffset]; int(upper) != tc.upper {
+				t.Errorf("Table of upper case of %d: %d != %d", tc.c, upper, tc.upper)
+			}
+			if lower := CtypeTolowerLoc()[0][tc.c+CtypeOffset]; int(lower) != tc.lower {
+				t.Errorf("Table of lower case of %d: %d != %d", tc.c, lower, tc.lower)
+			}
+		}
+		if upper := ToUpper(tc.c); upper != tc.upper {
+			t.Errorf("toupper(%d): %d != %d", tc.c, upper, tc.upper)
+		}
+		if lower := ToLowerL(tc.c, nil); lower != tc.lower {
+			t.Errorf("tolower_l(%d): %d != %d", tc.c, lower, tc.lower)
+		}
+		if v := IsAlpha(tc.c); v != tc.isalpha {
+			t.Errorf("isalpha(%d): %d != %d", tc.c, v, tc.isalpha)
+		}
+		if v := IsXdigit(tc.c); v != tc.isxdigit {
+			t.Errorf("isxdigit(%d): %d != %d", tc.c, v, tc.isxdigit)
+		}
+		if v := IsSpaceL(tc.c, nil); v != tc.isspace {
+			t.Errorf("isspace_l(%d): %d != %d", tc.c, v, tc.isspace)
+		}
+		if v := IsPunct(tc.c); v != tc.ispunct {
+			t.Errorf("ispunct(%d): %d != %d", tc.c, v, tc.ispunct)
+		}
+	}
+}
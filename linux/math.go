@@ -11,19 +11,21 @@ func IsNanf(x float32) int {
 	return noarch.BoolToInt(math.IsNaN(float64(x)))
 }
 
-// IsInff ...
+// IsInff handles __isinff(). Returns 1 for positive infinity and -1 for
+// negative infinity as glibc.
 func IsInff(x float32) int {
-	return noarch.BoolToInt(math.IsInf(float64(x), 0))
+	return noarch.IsinfSignf(x)
 }
 
-// IsInf ...
+// IsInf handles __isinf(). Returns 1 for positive infinity and -1 for
+// negative infinity as glibc.
 func IsInf(x float64) int {
-	return noarch.BoolToInt(math.IsInf(x, 0))
+	return noarch.IsinfSign(x)
 }
 
-// NaN ...
+// NaN handles __builtin_nan().
 func NaN(s []byte) float64 {
-	return math.NaN()
+	return noarch.Nan(s)
 }
 
 // Inff handles __builtin_inff().
@@ -47,10 +47,66 @@ type ProgramArgs struct {
 	ast         bool
 	inputFiles  []string
 	clangFlags  []string
+	clang       string
 	outputFile  string
 	packageName string
 	cppCode     bool
 
+	// options of search of header files and forced includes, which mirror
+	// the options of cc
+	includes preprocessor.IncludeOptions
+
+	// keep declarations in order of original C source files
+	preserveOrder bool
+
+	// add statistics of transpiling problems in the local file
+	stats bool
+
+	// JSON file with functions for generating of Go benchmarks
+	benchConfig string
+
+	// JSON file with global variables, access to which is guarded by mutex
+	guardConfig string
+
+	// JSON file with C functions replaced by Go functions of project
+	replaceConfig string
+
+	// JSON file with functions and fields of reference counting, which are
+	// removed from Go code
+	refCountConfig string
+
+	// JSON file with C hash tables and linked lists, which are replaced by
+	// Go map and container/list
+	patternConfig string
+
+	// JSON file with the configuration of licenses of C project, which are
+	// carried into the Go code
+	licenseConfig string
+
+	// cast of byte buffers to pointers of other types: "safe" or "unsafe"
+	byteCast string
+
+	// target version of Go, for example "1.22"
+	goVersion string
+
+	// transpile expressions of simple function-like macros as calls of Go
+	// functions
+	macroFunctions bool
+
+	// configurations of C code, each of them is transpiled in the separate
+	// Go file with the build constraint
+	buildTags []buildTag
+
+	// build constraint of the Go code
+	buildTag string
+
+	// JSON file for writing the map of C types to Go types
+	typeMapFile string
+
+	// JSON file with the golden map of C types to Go types, which must not be
+	// changed
+	verifyTypeMapFile string
+
 	// A private option to output the Go as a *_test.go file.
 	outputAsTest bool
 }
@@ -199,6 +255,26 @@ func buildTree(nodes []treeNode, depth int) []ast.Node {
 
 // Start begins transpiling an input file.
 func Start(args ProgramArgs) (err error) {
+	// each configuration of C code is transpiled in the separate Go file
+	if len(args.buildTags) > 0 && !args.ast {
+		outputFile := outputFileName(args)
+		for _, b := range args.buildTags {
+			a := args
+			a.buildTags = nil
+			a.buildTag = b.Tag
+			a.clangFlags = append(append([]string{}, args.clangFlags...),
+				b.ClangFlags...)
+			a.outputFile = buildTagFile(outputFile, b.Tag)
+			if args.verbose {
+				fmt.Printf("Transpiling for build tag `%s`...\n", b.Tag)
+			}
+			if err = Start(a); err != nil {
+				return fmt.Errorf("build tag `%s`: %v", b.Tag, err)
+			}
+		}
+		return nil
+	}
+
 	lines, filePP, err := generateAstLines(args)
 	if err != nil {
 		return
@@ -243,15 +319,31 @@ func generateAstLines(args ProgramArgs) (lines []string, filePP preprocessor.Fil
 		}
 	}
 
-	// 2. Preprocess
+	// 2. Find clang
+	clang, err := preprocessor.FindClang(args.clang)
+	if err != nil {
+		return
+	}
+	if args.verbose {
+		fmt.Printf("Using clang %d.%d : %s\n",
+			clang.Major, clang.Minor, clang.Command(args.cppCode))
+	}
+
+	// 3. Preprocess
 	if args.verbose {
 		fmt.Println("Running clang preprocessor...")
 	}
 
+	includeFlags, err := args.includes.Flags()
+	if err != nil {
+		return
+	}
+
 	filePP, err = preprocessor.NewFilePP(
 		args.inputFiles,
-		args.clangFlags,
-		args.cppCode)
+		append(includeFlags, args.clangFlags...),
+		args.cppCode,
+		clang)
 	if err != nil {
 		return
 	}
@@ -273,23 +365,73 @@ func generateAstLines(args ProgramArgs) (lines []string, filePP preprocessor.Fil
 		return
 	}
 
-	// 3. Generate JSON from AST
+	// 4. Generate JSON from AST
 	if args.verbose {
 		fmt.Println("Running clang for AST tree...")
 	}
-	compiler := "clang"
+	compiler := clang.Command(args.cppCode)
 	compilerFlag := "" //"-std=c99"
 	if args.cppCode {
-		compiler = "clang++"
 		compilerFlag = "-std=c++98"
 	}
-	astPP, err := exec.Command(compiler, compilerFlag, "-Xclang", "-ast-dump",
-		"-fsyntax-only", "-fno-color-diagnostics", ppFilePath).Output()
+	// standard of language is need for parsing of preprocessed code too,
+	// for example: `-std=c2x` for nullptr
+	for _, flag := range args.clangFlags {
+		if strings.HasPrefix(flag, "-std=") {
+			compilerFlag = flag
+		}
+	}
+	flags := []string{compilerFlag}
+	// signedness of char changes values of character literals
+	for _, flag := range args.clangFlags {
+		switch flag {
+		case "-funsigned-char", "-fsigned-char",
+			"-fno-unsigned-char", "-fno-signed-char":
+			flags = append(flags, flag)
+		}
+	}
+	if !args.cppCode {
+		// C89 code with functions called before declaration and with
+		// implicit int is accepted, such functions are declared by
+		// prototypes inferred from calls. New versions of clang report
+		// errors for such code by default.
+		flags = append(flags,
+			"-Wno-error=implicit-function-declaration",
+			"-Wno-error=implicit-int")
+	}
+	astFlags := []string{"-Xclang", "-ast-dump",
+		"-fsyntax-only", "-fno-color-diagnostics", ppFilePath}
+	if filePP.HasNestedFunctions() {
+		// Nested functions of GNU C are rewritten into blocks of clang.
+		// Local variables are changed inside nested functions, so the
+		// variables captured by blocks are declared with storage class
+		// `__block` by AST tree of the first run of clang.
+		flags = append(flags, "-fblocks")
+		var astPP []byte
+		astPP, err = exec.Command(compiler, append(flags, astFlags...)...).Output()
+		if err != nil {
+			// Assignments of captured variables are errors of clang
+			// before the storage class `__block` is added, but the AST
+			// tree is printed, so only other errors are fatal.
+			errBody, _ := exec.Command(
+				compiler, append(flags, ppFilePath)...).CombinedOutput()
+			if len(astPP) == 0 || len(blockErrors(string(errBody))) > 0 {
+				panic("clang failed: " + err.Error() + ":\n\n" + string(errBody))
+			}
+		}
+		filePP.AddBlockStorage(blockStorages(strings.Split(string(astPP), "\n")))
+		err = ioutil.WriteFile(ppFilePath, filePP.GetSource(), 0644)
+		if err != nil {
+			err = fmt.Errorf("writing to %s failed: %v", ppFilePath, err)
+			return
+		}
+	}
+	astPP, err := exec.Command(compiler, append(flags, astFlags...)...).Output()
 	if err != nil {
 		// If clang fails it still prints out the AST, so we have to run it
 		// again to get the real error.
 		errBody, _ := exec.Command(
-			compiler, compilerFlag, ppFilePath).CombinedOutput()
+			compiler, append(flags, ppFilePath)...).CombinedOutput()
 
 		panic("clang failed: " + err.Error() + ":\n\n" + string(errBody))
 	}
@@ -304,7 +446,71 @@ func generateGoCode(args ProgramArgs, lines []string, filePP preprocessor.FilePP
 	p := program.NewProgram()
 	p.Verbose = args.verbose
 	p.OutputAsTest = args.outputAsTest
+	p.PreserveOrder = args.preserveOrder
 	p.PreprocessorFile = filePP
+	if args.guardConfig != "" {
+		p.GuardedVariables, err = loadGuardConfig(args.guardConfig)
+		if err != nil {
+			return err
+		}
+	}
+	if args.replaceConfig != "" {
+		p.Replacements, err = loadReplaceConfig(args.replaceConfig)
+		if err != nil {
+			return err
+		}
+	}
+	if args.refCountConfig != "" {
+		p.RefCounting, err = loadRefCountConfig(args.refCountConfig)
+		if err != nil {
+			return err
+		}
+	}
+	if args.patternConfig != "" {
+		p.Patterns, err = loadPatternConfig(args.patternConfig)
+		if err != nil {
+			return err
+		}
+	}
+	if args.licenseConfig != "" {
+		var c licenseConfig
+		c, err = loadLicenseConfig(args.licenseConfig)
+		if err != nil {
+			return err
+		}
+		p.License, err = licenseHeader(c, args.inputFiles)
+		if err != nil {
+			return err
+		}
+	}
+	p.UnsignedChar = isUnsignedChar(args.clangFlags)
+	p.FunctionMacros = args.macroFunctions
+	switch args.byteCast {
+	case "", "safe":
+	case "unsafe":
+		p.UnsafeByteCast = true
+	default:
+		return fmt.Errorf("Unknown value of option -byte-cast: %s", args.byteCast)
+	}
+	if args.goVersion != "" {
+		p.GoVersion, err = parseGoVersion(args.goVersion)
+		if err != nil {
+			return err
+		}
+		// function unsafe.Slice is added in Go 1.17
+		if p.UnsafeByteCast && !p.IsGoVersion(17) {
+			return fmt.Errorf("Option -byte-cast unsafe is not supported by Go %s",
+				args.goVersion)
+		}
+	}
+	if args.buildTag != "" {
+		var lines []string
+		lines, err = buildTagLines(args.buildTag, p.GoVersion)
+		if err != nil {
+			return err
+		}
+		p.BuildConstraint = strings.Join(lines, "\n")
+	}
 
 	// Converting to nodes
 	if args.verbose {
@@ -334,18 +540,9 @@ func generateGoCode(args ProgramArgs, lines []string, filePP preprocessor.FilePP
 		p.AddMessage(p.GenerateWarningMessage(errors.New(message), fErr.Node))
 	}
 
-	outputFilePath := args.outputFile
+	outputFilePath := outputFileName(args)
 
-	if outputFilePath == "" {
-		// Choose inputFile for creating name of output file
-		input := args.inputFiles[0]
-		// We choose name for output Go code at the base
-		// on filename for choosed input file
-		cleanFileName := filepath.Clean(filepath.Base(input))
-		extension := filepath.Ext(input)
-		outputFilePath = cleanFileName[0:len(cleanFileName)-len(extension)] +
-			".go"
-	}
+	p.OutputDir = filepath.Dir(outputFilePath)
 
 	// transpile ast tree
 	if args.verbose {
@@ -354,6 +551,14 @@ func generateGoCode(args ProgramArgs, lines []string, filePP preprocessor.FilePP
 
 	err = transpiler.TranspileAST(args.outputFile, args.packageName,
 		p, tree[0].(ast.Node))
+
+	// statistics are collected even if transpiling is failed
+	if args.stats {
+		if errStats := recordStats(p, tree[0]); errStats != nil {
+			fmt.Fprintf(os.Stderr, "Cannot record statistics: %v\n", errStats)
+		}
+	}
+
 	if err != nil {
 		for i := range astErrors {
 			fmt.Fprintf(os.Stderr, "AST error #%d:\n%v\n",
@@ -362,6 +567,27 @@ func generateGoCode(args ProgramArgs, lines []string, filePP preprocessor.FilePP
 		return fmt.Errorf("cannot transpile AST : %v", err)
 	}
 
+	// check the map of types before writing of the Go code, so the Go code
+	// with changed types does not replace the previous one
+	if args.verifyTypeMapFile != "" {
+		golden, err := loadTypeMap(args.verifyTypeMapFile)
+		if err != nil {
+			return err
+		}
+		if err = verifyTypeMap(golden, p.ResolvedTypes()); err != nil {
+			return fmt.Errorf("verification of type map failed: %v", err)
+		}
+	}
+	if args.typeMapFile != "" {
+		if args.verbose {
+			fmt.Println("Writing the type map...")
+		}
+		err = writeTypeMap(args.typeMapFile, p.ResolvedTypes())
+		if err != nil {
+			return fmt.Errorf("writing type map failed: %v", err)
+		}
+	}
+
 	// write the output Go code
 	if args.verbose {
 		fmt.Println("Writing the output Go code...")
@@ -375,9 +601,48 @@ func generateGoCode(args ProgramArgs, lines []string, filePP preprocessor.FilePP
 	// error ignored, because it is not change the workflow
 	_, _ = exec.Command("gofmt", "-w", outputFilePath).Output()
 
+	if args.benchConfig != "" {
+		if args.verbose {
+			fmt.Println("Writing the Go benchmarks...")
+		}
+		err = writeBenchmarks(args, p, tree[0], outputFilePath)
+		if err != nil {
+			return fmt.Errorf("writing Go benchmarks failed: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// outputFileName returns the name of output Go file. By default, the name is
+// the name of the first input file with extension ".go".
+func outputFileName(args ProgramArgs) string {
+	if args.outputFile != "" {
+		return args.outputFile
+	}
+	// Choose inputFile for creating name of output file
+	input := args.inputFiles[0]
+	// We choose name for output Go code at the base
+	// on filename for choosed input file
+	cleanFileName := filepath.Clean(filepath.Base(input))
+	extension := filepath.Ext(input)
+	return cleanFileName[0:len(cleanFileName)-len(extension)] + ".go"
+}
+
+// isUnsignedChar returns true, if type char is unsigned by flags of clang.
+// Type char is signed by default.
+func isUnsignedChar(clangFlags []string) (unsigned bool) {
+	for _, flag := range clangFlags {
+		switch flag {
+		case "-funsigned-char", "-fno-signed-char":
+			unsigned = true
+		case "-fsigned-char", "-fno-unsigned-char":
+			unsigned = false
+		}
+	}
+	return
+}
+
 type inputDataFlags []string
 
 func (i *inputDataFlags) String() (s string) {
@@ -406,12 +671,46 @@ func runCommand() int {
 			"transpile", flag.ContinueOnError)
 		cppFlag = transpileCommand.Bool(
 			"cpp", false, "transpile CPP code")
+		clangFlag = transpileCommand.String(
+			"clang", "", "path to clang (default: searched in PATH)")
 		verboseFlag = transpileCommand.Bool(
 			"V", false, "print progress as comments")
 		outputFlag = transpileCommand.String(
 			"o", "", "output Go generated code to the specified file")
 		packageFlag = transpileCommand.String(
 			"p", "main", "set the name of the generated package")
+		preserveOrderFlag = transpileCommand.Bool(
+			"preserve-order", false,
+			"keep declarations in order of original C source files")
+		statsFlag = transpileCommand.Bool(
+			"stats", false,
+			"add statistics of transpiling problems in local file (see command stats)")
+		benchFlag = transpileCommand.String(
+			"bench", "", "JSON file with functions for generating Go benchmarks")
+		guardFlag = transpileCommand.String(
+			"guard", "", "JSON file with global variables guarded by mutex for concurrent use")
+		replaceFlag = transpileCommand.String(
+			"replace", "", "JSON file with C functions replaced by Go functions of project")
+		refCountFlag = transpileCommand.String(
+			"refcount", "", "JSON file with functions of reference counting removed for garbage collector")
+		patternFlag = transpileCommand.String(
+			"pattern", "", "JSON file with C hash tables and linked lists replaced by Go map and container/list")
+		licenseFlag = transpileCommand.String(
+			"license", "", "JSON file with licenses of C project carried into Go code")
+		byteCastFlag = transpileCommand.String(
+			"byte-cast", "safe",
+			"cast of byte buffers to struct pointers: safe (decoding copy) or unsafe (zero-copy view)")
+		goVersionFlag = transpileCommand.String(
+			"golang", "",
+			"target version of Go, for example 1.22, for using new features of Go in output")
+		macroFuncFlag = transpileCommand.Bool(
+			"macro-func", false,
+			"transpile simple function-like macros as Go functions (generic with -golang 1.18 or later)")
+		typeMapFlag = transpileCommand.String(
+			"type-map", "", "write JSON file with the map of C types to Go types")
+		verifyTypeMapFlag = transpileCommand.String(
+			"verify-type-map", "",
+			"fail if Go types of C types are changed against the JSON file of option -type-map")
 		transpileHelpFlag = transpileCommand.Bool(
 			"h", false, "print help information")
 
@@ -419,16 +718,108 @@ func runCommand() int {
 			"ast", flag.ContinueOnError)
 		astCppFlag = astCommand.Bool(
 			"cpp", false, "transpile CPP code")
+		astClangFlag = astCommand.String(
+			"clang", "", "path to clang (default: searched in PATH)")
 		astHelpFlag = astCommand.Bool(
 			"h", false, "print help information")
+
+		corpusCommand = flag.NewFlagSet(
+			"corpus", flag.ContinueOnError)
+		corpusConfigFlag = corpusCommand.String(
+			"config", "", "JSON file with list of C projects (default: built-in list)")
+		corpusDirFlag = corpusCommand.String(
+			"dir", "", "folder for cloned projects and generated Go code (default: temporary folder)")
+		corpusOutputFlag = corpusCommand.String(
+			"o", "", "output scoreboard to the specified file")
+		corpusVerboseFlag = corpusCommand.Bool(
+			"V", false, "print progress and errors of projects")
+		corpusHelpFlag = corpusCommand.Bool(
+			"h", false, "print help information")
+
+		batchCommand = flag.NewFlagSet(
+			"batch", flag.ContinueOnError)
+		batchCppFlag = batchCommand.Bool(
+			"cpp", false, "transpile CPP code")
+		batchClangFlag = batchCommand.String(
+			"clang", "", "path to clang (default: searched in PATH)")
+		batchDirFlag = batchCommand.String(
+			"dir", ".", "folder for generated Go files")
+		batchJournalFlag = batchCommand.String(
+			"journal", "", "file of progress journal (default: c4go-batch.journal in output folder)")
+		batchResumeFlag = batchCommand.Bool(
+			"resume", false, "continue from the journal, transpiled and failed files are not transpiled again")
+		batchPackageFlag = batchCommand.String(
+			"p", "main", "set the name of the generated package")
+		batchLicenseFlag = batchCommand.String(
+			"license", "", "JSON file with licenses of C project carried into Go code")
+		batchVerboseFlag = batchCommand.Bool(
+			"V", false, "print progress and errors of files")
+		batchHelpFlag = batchCommand.Bool(
+			"h", false, "print help information")
+
+		statsCommand = flag.NewFlagSet(
+			"stats", flag.ContinueOnError)
+		statsTopFlag = statsCommand.Int(
+			"n", 10, "amount of the most often problems in each table (0 - all)")
+		statsResetFlag = statsCommand.Bool(
+			"reset", false, "remove all collected statistics")
+		statsHelpFlag = statsCommand.Bool(
+			"h", false, "print help information")
+
+		adviseCommand = flag.NewFlagSet(
+			"advise", flag.ContinueOnError)
+		adviseCppFlag = adviseCommand.Bool(
+			"cpp", false, "transpile CPP code")
+		adviseClangFlag = adviseCommand.String(
+			"clang", "", "path to clang (default: searched in PATH)")
+		adviseTopFlag = adviseCommand.Int(
+			"n", 10, "amount of printed places for each advice (0 - all)")
+		adviseHelpFlag = adviseCommand.Bool(
+			"h", false, "print help information")
 	)
 	var clangFlags inputDataFlags
 	transpileCommand.Var(&clangFlags,
 		"clang-flag",
 		"Pass arguments to clang. You may provide multiple -clang-flag items.")
+	var buildTagFlags inputDataFlags
+	transpileCommand.Var(&buildTagFlags,
+		"build-tag",
+		"transpile configuration \"tag=clang flags\" in Go file with build constraint, like \"windows=-D_WIN32\". You may provide multiple -build-tag items.")
 	astCommand.Var(&clangFlags,
 		"clang-flag",
 		"Pass arguments to clang. You may provide multiple -clang-flag items.")
+	batchCommand.Var(&clangFlags,
+		"clang-flag",
+		"Pass arguments to clang. You may provide multiple -clang-flag items.")
+	adviseCommand.Var(&clangFlags,
+		"clang-flag",
+		"Pass arguments to clang. You may provide multiple -clang-flag items.")
+
+	// options of search of header files as in cc
+	var quoteDirs, dirs, systemDirs, afterDirs, forceIncludes inputDataFlags
+	for _, command := range []*flag.FlagSet{
+		transpileCommand, astCommand, batchCommand, adviseCommand,
+	} {
+		command.Var(&quoteDirs, "iquote",
+			"add directory for quoted includes only. You may provide multiple -iquote items.")
+		command.Var(&dirs, "I",
+			"add directory for search of headers. You may provide multiple -I items.")
+		command.Var(&systemDirs, "isystem",
+			"add directory of system headers. You may provide multiple -isystem items.")
+		command.Var(&afterDirs, "idirafter",
+			"add directory searched after system headers. You may provide multiple -idirafter items.")
+		command.Var(&forceIncludes, "include",
+			"include file before the first line of sources. You may provide multiple -include items.")
+	}
+	includes := func() preprocessor.IncludeOptions {
+		return preprocessor.IncludeOptions{
+			QuoteDirs:     quoteDirs,
+			Dirs:          dirs,
+			SystemDirs:    systemDirs,
+			AfterDirs:     afterDirs,
+			ForceIncludes: forceIncludes,
+		}
+	}
 
 	// TODO : add update a c4go or check version
 	// TODO : add example for starters
@@ -438,6 +829,10 @@ func runCommand() int {
 		usage += "Commands:\n"
 		usage += "  transpile\ttranspile an input C source file or files to Go\n"
 		usage += "  ast\t\tprint AST before translated Go code\n"
+		usage += "  corpus\ttranspile, build and test a list of C projects\n"
+		usage += "  batch\t\ttranspile each file separately with progress journal\n"
+		usage += "  stats\t\tprint local statistics of transpiling problems\n"
+		usage += "  advise\tsuggest edits of C code, which improve transpiling\n"
 		usage += "\n"
 		fmt.Fprintf(stderr, usage, os.Args[0])
 
@@ -446,6 +841,10 @@ func runCommand() int {
 
 	transpileCommand.SetOutput(stderr)
 	astCommand.SetOutput(stderr)
+	corpusCommand.SetOutput(stderr)
+	batchCommand.SetOutput(stderr)
+	statsCommand.SetOutput(stderr)
+	adviseCommand.SetOutput(stderr)
 
 	flag.Parse()
 
@@ -473,6 +872,8 @@ func runCommand() int {
 		args.ast = true
 		args.inputFiles = astCommand.Args()
 		args.clangFlags = clangFlags
+		args.includes = includes()
+		args.clang = *astClangFlag
 		args.cppCode = *astCppFlag
 	case "transpile":
 		err := transpileCommand.Parse(os.Args[2:])
@@ -494,7 +895,169 @@ func runCommand() int {
 		args.packageName = *packageFlag
 		args.verbose = *verboseFlag
 		args.clangFlags = clangFlags
+		args.includes = includes()
+		args.clang = *clangFlag
 		args.cppCode = *cppFlag
+		args.preserveOrder = *preserveOrderFlag
+		args.stats = *statsFlag
+		args.benchConfig = *benchFlag
+		args.guardConfig = *guardFlag
+		args.replaceConfig = *replaceFlag
+		args.refCountConfig = *refCountFlag
+		args.patternConfig = *patternFlag
+		args.licenseConfig = *licenseFlag
+		args.byteCast = *byteCastFlag
+		args.goVersion = *goVersionFlag
+		args.macroFunctions = *macroFuncFlag
+		for _, value := range buildTagFlags {
+			b, err := parseBuildTag(value)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return 20
+			}
+			args.buildTags = append(args.buildTags, b)
+		}
+		args.typeMapFile = *typeMapFlag
+		args.verifyTypeMapFile = *verifyTypeMapFlag
+	case "corpus":
+		err := corpusCommand.Parse(os.Args[2:])
+		if err != nil {
+			fmt.Printf("corpus command cannot parse: %v", err)
+			return 8
+		}
+
+		if *corpusHelpFlag {
+			fmt.Fprintf(stderr,
+				"Usage: %s corpus [-config corpus.json] [-dir folder] [-o scoreboard.txt]\n",
+				os.Args[0])
+			corpusCommand.PrintDefaults()
+			return 9
+		}
+
+		projects := defaultCorpus
+		if *corpusConfigFlag != "" {
+			projects, err = loadCorpusConfig(*corpusConfigFlag)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return 10
+			}
+		}
+
+		var out io.Writer = os.Stdout
+		if *corpusOutputFlag != "" {
+			f, err := os.Create(*corpusOutputFlag)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return 10
+			}
+			defer f.Close()
+			out = f
+		}
+
+		dir := *corpusDirFlag
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "c4go-corpus")
+		}
+
+		if err := runCorpus(projects, dir, out,
+			*corpusVerboseFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 10
+		}
+		return 0
+	case "batch":
+		err := batchCommand.Parse(os.Args[2:])
+		if err != nil {
+			fmt.Printf("batch command cannot parse: %v", err)
+			return 14
+		}
+
+		if *batchHelpFlag || batchCommand.NArg() == 0 {
+			fmt.Fprintf(stderr,
+				"Usage: %s batch [-dir folder] [-journal file] [-resume] file1.c ...\n",
+				os.Args[0])
+			batchCommand.PrintDefaults()
+			return 15
+		}
+
+		args.inputFiles = batchCommand.Args()
+		args.packageName = *batchPackageFlag
+		args.clangFlags = clangFlags
+		args.includes = includes()
+		args.clang = *batchClangFlag
+		args.cppCode = *batchCppFlag
+		args.licenseConfig = *batchLicenseFlag
+
+		journal := *batchJournalFlag
+		if journal == "" {
+			journal = filepath.Join(*batchDirFlag, "c4go-batch.journal")
+		}
+
+		if err := runBatch(args, *batchDirFlag, journal, *batchResumeFlag,
+			os.Stdout, *batchVerboseFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 16
+		}
+		return 0
+	case "stats":
+		err := statsCommand.Parse(os.Args[2:])
+		if err != nil {
+			fmt.Printf("stats command cannot parse: %v", err)
+			return 11
+		}
+
+		if *statsHelpFlag {
+			fmt.Fprintf(stderr,
+				"Usage: %s stats [-n amount] [-reset]\n", os.Args[0])
+			fmt.Fprintf(stderr,
+				"Statistics are collected by: %s transpile -stats file.c\n",
+				os.Args[0])
+			statsCommand.PrintDefaults()
+			return 12
+		}
+
+		filename := statsFilename()
+		if *statsResetFlag {
+			if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Error: %v\n", err)
+				return 13
+			}
+			return 0
+		}
+
+		s, err := loadStats(filename)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 13
+		}
+		fmt.Fprintf(os.Stdout, "Statistics file: %s\n", filename)
+		writeStats(os.Stdout, s, *statsTopFlag)
+		return 0
+	case "advise":
+		err := adviseCommand.Parse(os.Args[2:])
+		if err != nil {
+			fmt.Printf("advise command cannot parse: %v", err)
+			return 17
+		}
+
+		if *adviseHelpFlag || adviseCommand.NArg() == 0 {
+			fmt.Fprintf(stderr,
+				"Usage: %s advise [-n amount] file1.c ...\n", os.Args[0])
+			adviseCommand.PrintDefaults()
+			return 18
+		}
+
+		args.inputFiles = adviseCommand.Args()
+		args.clangFlags = clangFlags
+		args.includes = includes()
+		args.clang = *adviseClangFlag
+		args.cppCode = *adviseCppFlag
+
+		if err := runAdvise(args, os.Stdout, *adviseTopFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 19
+		}
+		return 0
 	default:
 		flag.Usage()
 		return 6
@@ -29,6 +29,19 @@ type programOut struct {
 	isZero bool
 }
 
+// testFlags - flags of clang for test files, which are not C99 code.
+var testFlags = map[string]string{
+	"tests/c23.c":    "-std=c2x",
+	"tests/c89.c":    "-std=gnu89",
+	"tests/nested.c": "-std=gnu99",
+}
+
+// testCompilers - compilers of C programs for test files, which are not
+// accepted by clang, like nested functions of GNU C.
+var testCompilers = map[string]string{
+	"tests/nested.c": "gcc",
+}
+
 // TestIntegrationScripts tests all programs in the tests directory.
 //
 // Integration tests are not run by default (only unit tests). These are
@@ -85,6 +98,12 @@ func TestIntegrationScripts(t *testing.T) {
 				compiler = "clang++"
 				compilerFlag = "-std=c++98"
 			}
+			if flag, ok := testFlags[file]; ok {
+				compilerFlag = flag
+			}
+			if c, ok := testCompilers[file]; ok {
+				compiler = c
+			}
 
 			cProgram := programOut{}
 			goProgram := programOut{}
@@ -101,7 +120,7 @@ func TestIntegrationScripts(t *testing.T) {
 
 			// Compile C.
 			out, err := exec.Command(
-				compiler, compilerFlag, "-lm", "-o", cPath, file).
+				compiler, compilerFlag, "-lm", "-lpthread", "-o", cPath, file).
 				CombinedOutput()
 			if err != nil {
 				t.Fatalf("error: %s\n%s", err, out)
@@ -138,6 +157,9 @@ func TestIntegrationScripts(t *testing.T) {
 			if strings.HasSuffix(file, "cpp") {
 				programArgs.cppCode = true
 			}
+			if flag, ok := testFlags[file]; ok {
+				programArgs.clangFlags = []string{flag}
+			}
 
 			// Compile Go
 			err = Start(programArgs)
@@ -199,6 +221,11 @@ func TestIntegrationScripts(t *testing.T) {
 			}
 			goProgramStderr = strings.Replace(goProgramStderr, currentDir+"/", "", -1)
 
+			// The name of program in message of failed assertion is the name
+			// of test binary
+			r = util.GetRegex(`(?m)^[\w.-]+\.test: `)
+			goProgramStderr = r.ReplaceAllString(goProgramStderr, "a.out: ")
+
 			if cProgramStderr != goProgramStderr {
 				// Add addition debug information for lines like:
 				// build/tests/cast/main_test.go:195:1: expected '}', found 'type'
@@ -315,7 +342,12 @@ func TestIntegrationScripts(t *testing.T) {
 			removeLinesFromEnd := 5
 			if strings.Index(file, "examples/") >= 0 {
 				removeLinesFromEnd = 4
-			} else if strings.HasPrefix(goOutLines[len(goOutLines)-3], "exit status") {
+			} else if strings.HasPrefix(goOutLines[len(goOutLines)-3], "exit status") ||
+				strings.HasPrefix(goOutLines[len(goOutLines)-3], "signal: ") {
+				// program is terminated by signal, for example abort():
+				//
+				//     signal: aborted (core dumped)
+				//
 				removeLinesFromEnd = 3
 			}
 
@@ -432,6 +464,27 @@ func TestMultifileTranspilation(t *testing.T) {
 			},
 			"234ERROR!ERROR!ERROR!",
 		},
+		{
+			[]string{
+				"./tests/inline/main1.c",
+				"./tests/inline/main2.c",
+			},
+			"616",
+		},
+		{
+			[]string{
+				"./tests/static/main1.c",
+				"./tests/static/main2.c",
+			},
+			"2152",
+		},
+		{
+			[]string{
+				"./tests/extern/main1.c",
+				"./tests/extern/main2.c",
+			},
+			"35",
+		},
 	}
 
 	for pos, tc := range tcs {
@@ -508,6 +561,50 @@ func TestTrigraph(t *testing.T) {
 	}
 }
 
+func TestByteCastWrite(t *testing.T) {
+	tcs := []struct {
+		byteCast string
+		expected string
+	}{
+		// the pointer of cast is a copy of memory, so the write is lost
+		{"safe", "1 2 3"},
+		// the pointer of cast is a view of memory
+		{"unsafe", "0 0 3"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.byteCast, func(t *testing.T) {
+			var args = DefaultProgramArgs()
+			args.inputFiles = []string{"./tests/bytecast/main.c"}
+			dir, err := ioutil.TempDir("", "c4go_bytecast")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir) // clean up
+			args.outputFile = path.Join(dir, "main.go")
+			args.byteCast = tc.byteCast
+			args.packageName = "main"
+
+			err = Start(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Run Go program
+			var buf bytes.Buffer
+			cmd := exec.Command("go", "run", args.outputFile)
+			cmd.Stdout = &buf
+			cmd.Stderr = &buf
+			err = cmd.Run()
+			if err != nil {
+				t.Error(err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("Wrong result: %v", buf.String())
+			}
+		})
+	}
+}
+
 func TestExternalInclude(t *testing.T) {
 	var args = DefaultProgramArgs()
 	args.inputFiles = []string{"./tests/externalHeader/main/main.c"}
@@ -544,6 +641,42 @@ func TestExternalInclude(t *testing.T) {
 	}
 }
 
+func TestGeneratedHeader(t *testing.T) {
+	var args = DefaultProgramArgs()
+	args.inputFiles = []string{"./tests/generatedHeader/src/main.c"}
+	dir, err := ioutil.TempDir("", "c4go_generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // clean up
+	args.outputFile = path.Join(dir, "multi.go")
+	args.includes = preprocessor.IncludeOptions{
+		Dirs:          []string{"./tests/generatedHeader/build"},
+		ForceIncludes: []string{"config.h"},
+	}
+	args.packageName = "main"
+	args.outputAsTest = true
+
+	// testing
+	err = Start(args)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	// Run Go program
+	var buf bytes.Buffer
+	cmd := exec.Command("go", "run", args.outputFile)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	err = cmd.Run()
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	if buf.String() != "1.2.3" {
+		t.Errorf("Wrong result: %v", buf.String())
+	}
+}
+
 func TestComments(t *testing.T) {
 	var args = DefaultProgramArgs()
 	args.inputFiles = []string{"./tests/comment/main.c"}
@@ -0,0 +1,51 @@
+package noarch
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// allocaPools are pools of byte buffers for alloca() and variable length
+// arrays in recursive functions, where index is the binary logarithm of
+// capacity of buffers in pool. The buffers are reused between calls of
+// functions, so the deep recursion does not produce a lot of garbage.
+var allocaPools [bits.UintSize]sync.Pool
+
+// allocaClass returns the index of pool with buffers of capacity not less
+// than size.
+func allocaClass(size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len(uint(size - 1))
+}
+
+// Alloca handles alloca() and variable length arrays of char.
+//
+// Returns the zeroed buffer of size bytes from the pool of buffers. The
+// buffer must be returned in the pool by FreeAlloca() at the end of function,
+// where the buffer is allocated, like the memory on the stack in C.
+func Alloca(size int) []byte {
+	if size < 0 {
+		size = 0
+	}
+	class := allocaClass(size)
+	if b, ok := allocaPools[class].Get().(*[]byte); ok {
+		return (*b)[:size]
+	}
+	return make([]byte, size, 1<<uint(class))
+}
+
+// FreeAlloca returns the buffer allocated by Alloca() in the pool of buffers.
+// The buffer must not be used after that.
+func FreeAlloca(b []byte) {
+	b = b[:cap(b)]
+	if len(b) == 0 || len(b)&(len(b)-1) != 0 {
+		// buffer is not allocated by Alloca()
+		return
+	}
+	for i := range b {
+		b[i] = 0
+	}
+	allocaPools[allocaClass(len(b))].Put(&b)
+}
@@ -0,0 +1,32 @@
+package noarch
+
+import "testing"
+
+func TestAlloca(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 100, 1024, 1025} {
+		b := Alloca(size)
+		if len(b) != size {
+			t.Errorf("Size of buffer %d, but want %d", len(b), size)
+		}
+		for i := range b {
+			if b[i] != 0 {
+				t.Fatalf("Buffer of size %d is not zeroed", size)
+			}
+			b[i] = 42
+		}
+		FreeAlloca(b)
+
+		// reused buffers are zeroed too
+		b = Alloca(size)
+		for i := range b {
+			if b[i] != 0 {
+				t.Fatalf("Reused buffer of size %d is not zeroed", size)
+			}
+		}
+		FreeAlloca(b)
+	}
+
+	// buffers not allocated by Alloca() are ignored
+	FreeAlloca(make([]byte, 3))
+	FreeAlloca(nil)
+}
@@ -0,0 +1,148 @@
+package noarch
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// StructLayout is the layout of C type in memory, which is used for cast of
+// byte buffer to pointer of the type by function BytesToSlice. For scalar
+// types the list of fields is empty.
+type StructLayout struct {
+	// Size is the size of type in bytes including padding.
+	Size int
+
+	// Fields is the list of fields of struct.
+	Fields []FieldLayout
+}
+
+// FieldLayout is the layout of field of C struct.
+type FieldLayout struct {
+	// Name is the name of field in Go struct.
+	Name string
+
+	// Offset is the offset of field from the begin of struct in bytes.
+	Offset int
+
+	// Size is the size of value in bytes. For arrays it is the size of
+	// element.
+	Size int
+
+	// Struct is the layout of nested struct or nil.
+	Struct *StructLayout
+}
+
+// BytesToSlice handles the cast of byte buffer to pointer of other type in
+// C, for example:
+//
+//     struct header *h = (struct header *)buffer;
+//
+// The buffer is decoded in according to the C layout of type with the host
+// byte order, so it is the copy of buffer and later changes are not
+// shared. Fields with pointers are not decoded. The argument slice is nil
+// slice of result type, for example "[]header(nil)". Result has the same
+// type.
+func BytesToSlice(b []byte, layout *StructLayout, slice interface{}) interface{} {
+	t := reflect.TypeOf(slice)
+	if b == nil || layout.Size <= 0 {
+		return reflect.Zero(t).Interface()
+	}
+	// C code may read the beginning of struct from the buffer, which is
+	// shorter than struct
+	n := (len(b) + layout.Size - 1) / layout.Size
+	result := reflect.MakeSlice(t, n, n)
+	for i := 0; i < n; i++ {
+		decodeValue(result.Index(i), b[i*layout.Size:], layout.Size, layout)
+	}
+	return result.Interface()
+}
+
+// decodeValue decodes the value with size of scalar value or element of
+// array and layout of struct.
+func decodeValue(v reflect.Value, b []byte, size int, layout *StructLayout) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if layout == nil {
+			return
+		}
+		for _, f := range layout.Fields {
+			field := v.FieldByName(f.Name)
+			if !field.IsValid() || f.Offset >= len(b) {
+				continue
+			}
+			// names of fields of transpiled structs are not exported
+			field = reflect.NewAt(field.Type(),
+				unsafe.Pointer(field.UnsafeAddr())).Elem()
+			decodeValue(field, b[f.Offset:], f.Size, f.Struct)
+		}
+
+	case reflect.Array:
+		stride := cSize(v.Type().Elem(), size, layout)
+		for i := 0; i < v.Len() && i*stride < len(b); i++ {
+			decodeValue(v.Index(i), b[i*stride:], size, layout)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if u, ok := decodeUint(b, size); ok {
+			shift := uint(64 - 8*size)
+			v.SetInt(int64(u<<shift) >> shift)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		if u, ok := decodeUint(b, size); ok {
+			v.SetUint(u)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if u, ok := decodeUint(b, size); ok {
+			switch size {
+			case 4:
+				v.SetFloat(float64(math.Float32frombits(uint32(u))))
+			case 8:
+				v.SetFloat(math.Float64frombits(u))
+			}
+		}
+	}
+}
+
+// cSize returns the size of Go type in C, where size is the size of scalar
+// value and layout is the layout of struct.
+func cSize(t reflect.Type, size int, layout *StructLayout) int {
+	switch t.Kind() {
+	case reflect.Array:
+		return t.Len() * cSize(t.Elem(), size, layout)
+	case reflect.Struct:
+		if layout != nil {
+			return layout.Size
+		}
+	}
+	return size
+}
+
+// decodeUint returns the unsigned value in the host byte order.
+func decodeUint(b []byte, size int) (u uint64, ok bool) {
+	if size <= 0 || size > 8 || len(b) < size {
+		return 0, false
+	}
+	for i := 0; i < size; i++ {
+		if hostByteOrder == binary.BigEndian {
+			u = u<<8 | uint64(b[i])
+		} else {
+			u = u<<8 | uint64(b[size-1-i])
+		}
+	}
+	return u, true
+}
+
+// BytesPointer returns the pointer to the first element of byte buffer for
+// the cast of buffer without copy by package unsafe, or nil for empty
+// buffer.
+func BytesPointer(b []byte) unsafe.Pointer {
+	if len(b) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&b[0])
+}
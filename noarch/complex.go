@@ -0,0 +1,248 @@
+package noarch
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Complex numbers of "complex.h" are Go complex types: `_Complex double`
+// and `_Complex long double` are complex128, `_Complex float` is
+// complex64. Functions with suffix "f" are float variants.
+
+// Creal returns the real part of z.
+func Creal(z complex128) float64 {
+	return real(z)
+}
+
+// Crealf returns the real part of z.
+func Crealf(z complex64) float32 {
+	return real(z)
+}
+
+// Cimag returns the imaginary part of z.
+func Cimag(z complex128) float64 {
+	return imag(z)
+}
+
+// Cimagf returns the imaginary part of z.
+func Cimagf(z complex64) float32 {
+	return imag(z)
+}
+
+// Cabs returns the absolute value (modulus) of z.
+func Cabs(z complex128) float64 {
+	return cmplx.Abs(z)
+}
+
+// Cabsf returns the absolute value (modulus) of z.
+func Cabsf(z complex64) float32 {
+	return float32(Cabs(complex128(z)))
+}
+
+// Carg returns the argument (phase angle) of z in the range [-Pi, Pi].
+func Carg(z complex128) float64 {
+	return cmplx.Phase(z)
+}
+
+// Cargf returns the argument (phase angle) of z in the range [-Pi, Pi].
+func Cargf(z complex64) float32 {
+	return float32(Carg(complex128(z)))
+}
+
+// Conj returns the complex conjugate of z.
+func Conj(z complex128) complex128 {
+	return cmplx.Conj(z)
+}
+
+// Conjf returns the complex conjugate of z.
+func Conjf(z complex64) complex64 {
+	return complex(real(z), -imag(z))
+}
+
+// Cproj returns the projection of z onto the Riemann sphere: all complex
+// infinities are projected to the one infinity with the sign of zero of
+// imaginary part.
+func Cproj(z complex128) complex128 {
+	if cmplx.IsInf(z) {
+		return complex(math.Inf(1), math.Copysign(0, imag(z)))
+	}
+	return z
+}
+
+// Cprojf returns the projection of z onto the Riemann sphere. See Cproj.
+func Cprojf(z complex64) complex64 {
+	return complex64(Cproj(complex128(z)))
+}
+
+// Cpow returns x raised to the power y.
+func Cpow(x, y complex128) complex128 {
+	return cmplx.Pow(x, y)
+}
+
+// Cpowf returns x raised to the power y.
+func Cpowf(x, y complex64) complex64 {
+	return complex64(Cpow(complex128(x), complex128(y)))
+}
+
+// Cexp returns the base-e exponential of z.
+func Cexp(z complex128) complex128 {
+	if imag(z) == 0 {
+		// Go returns NaN imaginary part for overflow of real part
+		return complex(math.Exp(real(z)), imag(z))
+	}
+	return cmplx.Exp(z)
+}
+
+// Cexpf returns the base-e exponential of z.
+func Cexpf(z complex64) complex64 {
+	return complex64(Cexp(complex128(z)))
+}
+
+// Clog returns the natural logarithm of z. See Log for subnormal values.
+func Clog(z complex128) complex128 {
+	return complex(Log(cmplx.Abs(z)), cmplx.Phase(z))
+}
+
+// Clogf returns the natural logarithm of z.
+func Clogf(z complex64) complex64 {
+	return complex64(Clog(complex128(z)))
+}
+
+// Csqrt returns the square root of z.
+func Csqrt(z complex128) complex128 {
+	switch {
+	case math.IsNaN(real(z)) && !math.IsInf(imag(z), 0):
+		// csqrt(NaN + iy) is NaN + iNaN in C, but Go keeps zero y
+		return cmplx.NaN()
+	case z != 0 && math.Abs(real(z)) < 0x1p-1000 && math.Abs(imag(z)) < 0x1p-1000:
+		// Go loses tiny values, so the value is scaled exactly
+		r := cmplx.Sqrt(complex(real(z)*0x1p600, imag(z)*0x1p600))
+		return complex(real(r)*0x1p-300, imag(r)*0x1p-300)
+	}
+	return cmplx.Sqrt(z)
+}
+
+// Csqrtf returns the square root of z.
+func Csqrtf(z complex64) complex64 {
+	return complex64(Csqrt(complex128(z)))
+}
+
+// Csin returns the sine of z.
+func Csin(z complex128) complex128 {
+	return cmplx.Sin(z)
+}
+
+// Csinf returns the sine of z.
+func Csinf(z complex64) complex64 {
+	return complex64(Csin(complex128(z)))
+}
+
+// Ccos returns the cosine of z.
+func Ccos(z complex128) complex128 {
+	return cmplx.Cos(z)
+}
+
+// Ccosf returns the cosine of z.
+func Ccosf(z complex64) complex64 {
+	return complex64(Ccos(complex128(z)))
+}
+
+// Ctan returns the tangent of z.
+func Ctan(z complex128) complex128 {
+	return cmplx.Tan(z)
+}
+
+// Ctanf returns the tangent of z.
+func Ctanf(z complex64) complex64 {
+	return complex64(Ctan(complex128(z)))
+}
+
+// Casin returns the arc sine of z.
+func Casin(z complex128) complex128 {
+	return cmplx.Asin(z)
+}
+
+// Casinf returns the arc sine of z.
+func Casinf(z complex64) complex64 {
+	return complex64(Casin(complex128(z)))
+}
+
+// Cacos returns the arc cosine of z.
+func Cacos(z complex128) complex128 {
+	return cmplx.Acos(z)
+}
+
+// Cacosf returns the arc cosine of z.
+func Cacosf(z complex64) complex64 {
+	return complex64(Cacos(complex128(z)))
+}
+
+// Catan returns the arc tangent of z.
+func Catan(z complex128) complex128 {
+	return cmplx.Atan(z)
+}
+
+// Catanf returns the arc tangent of z.
+func Catanf(z complex64) complex64 {
+	return complex64(Catan(complex128(z)))
+}
+
+// Csinh returns the hyperbolic sine of z.
+func Csinh(z complex128) complex128 {
+	return cmplx.Sinh(z)
+}
+
+// Csinhf returns the hyperbolic sine of z.
+func Csinhf(z complex64) complex64 {
+	return complex64(Csinh(complex128(z)))
+}
+
+// Ccosh returns the hyperbolic cosine of z.
+func Ccosh(z complex128) complex128 {
+	return cmplx.Cosh(z)
+}
+
+// Ccoshf returns the hyperbolic cosine of z.
+func Ccoshf(z complex64) complex64 {
+	return complex64(Ccosh(complex128(z)))
+}
+
+// Ctanh returns the hyperbolic tangent of z.
+func Ctanh(z complex128) complex128 {
+	return cmplx.Tanh(z)
+}
+
+// Ctanhf returns the hyperbolic tangent of z.
+func Ctanhf(z complex64) complex64 {
+	return complex64(Ctanh(complex128(z)))
+}
+
+// Casinh returns the inverse hyperbolic sine of z.
+func Casinh(z complex128) complex128 {
+	return cmplx.Asinh(z)
+}
+
+// Casinhf returns the inverse hyperbolic sine of z.
+func Casinhf(z complex64) complex64 {
+	return complex64(Casinh(complex128(z)))
+}
+
+// Cacosh returns the inverse hyperbolic cosine of z.
+func Cacosh(z complex128) complex128 {
+	return cmplx.Acosh(z)
+}
+
+// Cacoshf returns the inverse hyperbolic cosine of z.
+func Cacoshf(z complex64) complex64 {
+	return complex64(Cacosh(complex128(z)))
+}
+
+// Catanh returns the inverse hyperbolic tangent of z.
+func Catanh(z complex128) complex128 {
+	return cmplx.Atanh(z)
+}
+
+// Catanhf returns the inverse hyperbolic tangent of z.
+func Catanhf(z complex64) complex64 {
+	return complex64(Catanh(complex128(z)))
+}
@@ -0,0 +1,46 @@
+package noarch
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplex(t *testing.T) {
+	inf := math.Inf(1)
+	negZero := math.Copysign(0, -1)
+	tcs := []struct {
+		name     string
+		f        func(complex128) complex128
+		z        complex128
+		expected complex128
+	}{
+		{"Csqrt", Csqrt, -4, 2i},
+		{"Csqrt", Csqrt, complex(0, 0x1p-1061), complex(0x1p-531, 0x1p-531)},
+		{"Csqrt", Csqrt, complex(0x1p-1060, negZero), complex(0x1p-530, negZero)},
+		{"Cexp", Cexp, complex(1000, 0), complex(inf, 0)},
+		{"Cexp", Cexp, complex(0, math.Pi/2), cmplx.Exp(complex(0, math.Pi/2))},
+		{"Clog", Clog, -1, complex(0, math.Pi)},
+		{"Clog", Clog, complex(0x1p-1074, 0), complex(-1074*math.Ln2, 0)},
+		{"Cproj", Cproj, complex(inf, -2), complex(inf, negZero)},
+		{"Cproj", Cproj, complex(1, inf), complex(inf, 0)},
+		{"Conj", Conj, complex(1, 2), complex(1, -2)},
+	}
+	for _, tc := range tcs {
+		r := tc.f(tc.z)
+		if r != tc.expected ||
+			math.Signbit(imag(r)) != math.Signbit(imag(tc.expected)) {
+			t.Errorf("%s(%v) = %v, but want %v", tc.name, tc.z, r, tc.expected)
+		}
+	}
+
+	if r := Csqrt(complex(math.NaN(), 0)); !math.IsNaN(real(r)) || !math.IsNaN(imag(r)) {
+		t.Errorf("Csqrt(NaN) = %v, but want NaN NaN", r)
+	}
+	if r := Cabsf(3 + 4i); r != 5 {
+		t.Errorf("Cabsf(3+4i) = %v, but want 5", r)
+	}
+	if r := Cpowf(2, 3); real(r) != 8 {
+		t.Errorf("Cpowf(2, 3) = %v, but want 8", r)
+	}
+}
@@ -0,0 +1,1121 @@
+//go:build conformance
+// +build conformance
+
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/linux"
+	"github.com/Konstantin8105/c4go/noarch"
+)
+
+// Mismatch is a difference between results of noarch and libc for one input.
+type Mismatch struct {
+	Input  string
+	Noarch string
+	Libc   string
+}
+
+// Row is a line of the conformance matrix for one C function.
+type Row struct {
+	Function   string
+	Total      int
+	Passed     int
+	Mismatches []Mismatch
+}
+
+// Matrix is a conformance matrix of all checked C functions.
+type Matrix []Row
+
+// check is a conformance check of one C function. Function compare is
+// called for each input and must return the results of noarch and libc in
+// comparable form.
+type check struct {
+	function string
+	inputs   []string
+	compare  func(input string) (noarchResult, libcResult string)
+}
+
+// Edge-case inputs for the functions, which parse numbers.
+var numberStrings = []string{
+	"", " ", "0", "-0", "+0", "1", "-1", "+1", "  42", "\t-17xyz", "\n7",
+	"12abc", "abc", "-", "+", "--1", "+-1", "0x", "0X1A", "0x1g", "-0x10",
+	"077", "08", "0b101", "1,000", "1 000",
+	"2147483647", "2147483648", "-2147483648", "-2147483649",
+	"4294967295", "4294967296",
+	"9223372036854775807", "9223372036854775808",
+	"-9223372036854775808", "-9223372036854775809",
+	"18446744073709551615", "18446744073709551616",
+	"zz", "ZZ", "1e10", "1.5", ".5", "5.", "-1.5e-3", "1e", "1e+", "1.5E+2x",
+	"inf", "-INFINITY", "Infinity", "nan", "NAN(123)", "0x1p4", "1e400",
+	"-1e400", "1e-400",
+}
+
+var radixes = []int{0, 2, 8, 10, 16, 36}
+
+// Edge-case inputs for the string functions.
+var stringValues = []string{
+	"", "a", "A", "abc", "abd", "ab", "abcd", "\x7f", "\x80", "\xff", "a b",
+}
+
+var intValues = []int{
+	0, 1, -1, 2, -2, 7, -7, 100, -100,
+	math.MaxInt32, math.MinInt32 + 1, math.MaxInt32 - 1,
+}
+
+var floatValues = []float64{
+	0, math.Copysign(0, -1), 1, -1, 0.5, -2.5, 1e308, -1e308, 5e-324,
+	math.Inf(1), math.Inf(-1), math.NaN(),
+}
+
+// Edge-case inputs for the rounding functions.
+var roundingValues = []float64{
+	0, math.Copysign(0, -1), 0.5, -0.5, 1.5, -1.5, 2.5, -2.5,
+	0.49999999999999994, 4503599627370497, 1e20, -1e20,
+	2147483647.5, -2147483648.5, 9.3e18, -9.3e18,
+	math.Inf(1), math.Inf(-1), math.NaN(),
+}
+
+// Edge-case inputs for the classification macros: the smallest normal and
+// subnormal values of double and float.
+var classificationValues = append(floatValues[:len(floatValues):len(floatValues)],
+	2.2250738585072014e-308, -1e-310, 1.1754943508222875e-38, -1e-40, 1e-45, 3.5e38)
+
+// Edge-case inputs for the Bessel functions: zeros, small, large and
+// negative values.
+var besselValues = []float64{
+	0, math.Copysign(0, -1), 1e-300, 0.5, 1, 2.404825557695773, 3.8317059702075125,
+	-1, -2.5, 10, 100, 1e10, 1e300, math.Inf(1), math.Inf(-1), math.NaN(),
+}
+
+// Edge-case orders of the Bessel functions jn() and yn().
+var besselOrders = []int{0, 1, 2, -1, -2, 5, 50}
+
+// Edge-case exponents for ldexp().
+var exponentValues = []int{0, 1, -1, 52, -1074, -1075, 1023, 1024, -2000, 2000}
+
+// Edge-case strings for nan().
+var nanStrings = []string{"", "0", "1", "123", "0x1f", "017", "abc", "1x"}
+
+// Seeds of pseudo-random generators.
+var seedValues = []uint32{
+	0, 1, 2, 42, 12345, 65535, 65536, math.MaxInt32, math.MaxInt32 + 1,
+	math.MaxUint32,
+}
+
+// Amount of checked values of pseudo-random generators for each seed.
+const randAmount = 20
+
+func seedInputs() (inputs []string) {
+	for _, seed := range seedValues {
+		inputs = append(inputs, fmt.Sprintf("%d", seed))
+	}
+	return
+}
+
+func parseSeed(input string) (seed uint32) {
+	if _, err := fmt.Sscan(input, &seed); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// xsubi returns the state of generators of drand48() family from seed.
+func xsubi(seed uint32) [3]uint16 {
+	return [3]uint16{uint16(seed), uint16(seed >> 16), uint16(seed*7 + 1)}
+}
+
+func cString(s string) []byte {
+	return noarch.StringToCString(s)
+}
+
+func endOffset(str []byte, endptr [][]byte) int {
+	return len(str) - len(endptr[0])
+}
+
+func pairs(values []string) (inputs []string) {
+	for _, a := range values {
+		for _, b := range values {
+			inputs = append(inputs, fmt.Sprintf("%q %q", a, b))
+		}
+	}
+	return
+}
+
+func splitPair(input string) (a, b string) {
+	_, err := fmt.Sscanf(input, "%q %q", &a, &b)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func intInputs(values []int) (inputs []string) {
+	for _, v := range values {
+		inputs = append(inputs, fmt.Sprintf("%d", v))
+	}
+	return
+}
+
+func intPairs(values []int) (inputs []string) {
+	for _, a := range values {
+		for _, b := range values {
+			if b == 0 {
+				// division by zero is undefined behavior
+				continue
+			}
+			inputs = append(inputs, fmt.Sprintf("%d %d", a, b))
+		}
+	}
+	return
+}
+
+func floatTuples(size int) (inputs []string) {
+	var generate func(prefix []string)
+	generate = func(prefix []string) {
+		if len(prefix) == size {
+			inputs = append(inputs, strings.Join(prefix, " "))
+			return
+		}
+		for _, v := range floatValues {
+			generate(append(prefix[:len(prefix):len(prefix)], fmt.Sprintf("%v", v)))
+		}
+	}
+	generate(nil)
+	return
+}
+
+func floatInputs(values []float64) (inputs []string) {
+	for _, v := range values {
+		inputs = append(inputs, fmt.Sprintf("%v", v))
+	}
+	return
+}
+
+func besselOrderInputs() (inputs []string) {
+	for _, n := range besselOrders {
+		for _, v := range besselValues {
+			inputs = append(inputs, fmt.Sprintf("%d %v", n, v))
+		}
+	}
+	return
+}
+
+func splitOrder(input string) (n int, x float64) {
+	fields := strings.Fields(input)
+	fmt.Sscan(fields[0], &n)
+	return n, parseFloats(fields[1])[0]
+}
+
+func floatIntInputs() (inputs []string) {
+	for _, v := range floatValues {
+		for _, e := range exponentValues {
+			inputs = append(inputs, fmt.Sprintf("%v %d", v, e))
+		}
+	}
+	return
+}
+
+// unaryFloat returns the check of function with one argument of type double.
+func unaryFloat(function string, values []float64,
+	noarchF, libcF func(float64) float64) check {
+	return check{
+		function: function,
+		inputs:   floatInputs(values),
+		compare: func(input string) (string, string) {
+			v := parseFloats(input)
+			return formatFloat(noarchF(v[0])), formatFloat(libcF(v[0]))
+		},
+	}
+}
+
+// binaryFloat returns the check of function with two arguments of type
+// double.
+func binaryFloat(function string, noarchF, libcF func(x, y float64) float64) check {
+	return check{
+		function: function,
+		inputs:   floatTuples(2),
+		compare: func(input string) (string, string) {
+			v := parseFloats(input)
+			return formatFloat(noarchF(v[0], v[1])), formatFloat(libcF(v[0], v[1]))
+		},
+	}
+}
+
+// unaryComplex returns the check of function with one argument of type
+// double complex. Inputs are pairs of real and imaginary parts.
+func unaryComplex(function string, noarchF, libcF func(complex128) complex128) check {
+	return check{
+		function: function,
+		inputs:   floatTuples(2),
+		compare: func(input string) (string, string) {
+			v := parseFloats(input)
+			z := complex(v[0], v[1])
+			return formatComplex(noarchF(z)), formatComplex(libcF(z))
+		},
+	}
+}
+
+func parseFloats(input string) (values []float64) {
+	for _, s := range strings.Fields(input) {
+		var v float64
+		switch s {
+		case "+Inf":
+			v = math.Inf(1)
+		case "-Inf":
+			v = math.Inf(-1)
+		case "NaN":
+			v = math.NaN()
+		default:
+			if _, err := fmt.Sscan(s, &v); err != nil {
+				panic(err)
+			}
+		}
+		values = append(values, v)
+	}
+	return
+}
+
+func radixInputs() (inputs []string) {
+	for _, s := range numberStrings {
+		for _, radix := range radixes {
+			inputs = append(inputs, fmt.Sprintf("%d %q", radix, s))
+		}
+	}
+	return
+}
+
+func splitRadix(input string) (radix int, s string) {
+	_, err := fmt.Sscanf(input, "%d %q", &radix, &s)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func quoted(values []string) (inputs []string) {
+	for _, v := range values {
+		inputs = append(inputs, fmt.Sprintf("%q", v))
+	}
+	return
+}
+
+func unquote(input string) (s string) {
+	_, err := fmt.Sscanf(input, "%q", &s)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// formatFloat returns the comparable view of float. Values NaN are equal.
+func formatFloat(f float64) string {
+	if math.IsNaN(f) {
+		return "NaN"
+	}
+	if f == 0 && math.Signbit(f) {
+		return "-0"
+	}
+	return fmt.Sprintf("%v", f)
+}
+
+func formatComplex(z complex128) string {
+	return formatFloat(real(z)) + " " + formatFloat(imag(z))
+}
+
+func checks() []check {
+	// values of signed char, EOF and values of unsigned char
+	characters := make([]int, 0, 384)
+	for c := -128; c < 256; c++ {
+		characters = append(characters, c)
+	}
+	return append([]check{
+		{
+			function: "atoi",
+			inputs:   quoted(numberStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return fmt.Sprint(noarch.Atoi(cString(s))),
+					fmt.Sprint(int32(libcAtoi(s)))
+			},
+		},
+		{
+			// type "long" is 32 bits in c4go
+			function: "atol",
+			inputs:   quoted(numberStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return fmt.Sprint(noarch.Atol(cString(s))),
+					fmt.Sprint(int32(libcAtol(s)))
+			},
+		},
+		{
+			function: "atoll",
+			inputs:   quoted(numberStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return fmt.Sprint(noarch.Atoll(cString(s))),
+					fmt.Sprint(libcAtoll(s))
+			},
+		},
+		{
+			function: "atof",
+			inputs:   quoted(numberStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return formatFloat(noarch.Atof(cString(s))),
+					formatFloat(libcAtof(s))
+			},
+		},
+		{
+			function: "strtol",
+			inputs:   radixInputs(),
+			compare: func(input string) (string, string) {
+				r, s := splitRadix(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtol(str, endptr, r)
+				lv, lend := libcStrtol(s, r)
+				return fmt.Sprint(v, " ", endOffset(str, endptr)),
+					fmt.Sprint(int32(lv), " ", lend)
+			},
+		},
+		{
+			function: "strtoll",
+			inputs:   radixInputs(),
+			compare: func(input string) (string, string) {
+				r, s := splitRadix(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtoll(str, endptr, r)
+				lv, lend := libcStrtoll(s, r)
+				return fmt.Sprint(v, " ", endOffset(str, endptr)),
+					fmt.Sprint(lv, " ", lend)
+			},
+		},
+		{
+			function: "strtoul",
+			inputs:   radixInputs(),
+			compare: func(input string) (string, string) {
+				r, s := splitRadix(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtoul(str, endptr, r)
+				lv, lend := libcStrtoul(s, r)
+				return fmt.Sprint(v, " ", endOffset(str, endptr)),
+					fmt.Sprint(uint32(lv), " ", lend)
+			},
+		},
+		{
+			function: "strtoull",
+			inputs:   radixInputs(),
+			compare: func(input string) (string, string) {
+				r, s := splitRadix(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtoull(str, endptr, r)
+				lv, lend := libcStrtoull(s, r)
+				return fmt.Sprint(v, " ", endOffset(str, endptr)),
+					fmt.Sprint(lv, " ", lend)
+			},
+		},
+		{
+			function: "strtoimax",
+			inputs:   radixInputs(),
+			compare: func(input string) (string, string) {
+				r, s := splitRadix(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtoimax(str, endptr, r)
+				lv, lend := libcStrtoimax(s, r)
+				return fmt.Sprint(v, " ", endOffset(str, endptr)),
+					fmt.Sprint(lv, " ", lend)
+			},
+		},
+		{
+			function: "strtoumax",
+			inputs:   radixInputs(),
+			compare: func(input string) (string, string) {
+				r, s := splitRadix(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtoumax(str, endptr, r)
+				lv, lend := libcStrtoumax(s, r)
+				return fmt.Sprint(v, " ", endOffset(str, endptr)),
+					fmt.Sprint(lv, " ", lend)
+			},
+		},
+		{
+			function: "strtod",
+			inputs:   quoted(numberStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				str := cString(s)
+				endptr := [][]byte{nil}
+				v := noarch.Strtod(str, endptr)
+				lv, lend := libcStrtod(s)
+				return formatFloat(v) + fmt.Sprint(" ", endOffset(str, endptr)),
+					formatFloat(lv) + fmt.Sprint(" ", lend)
+			},
+		},
+		{
+			function: "strlen",
+			inputs:   quoted(stringValues),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return fmt.Sprint(noarch.Strlen(cString(s))),
+					fmt.Sprint(libcStrlen(s))
+			},
+		},
+		{
+			// only sign of result is defined by C standard
+			function: "strcmp",
+			inputs:   pairs(stringValues),
+			compare: func(input string) (string, string) {
+				a, b := splitPair(input)
+				r := noarch.Strcmp(cString(a), cString(b))
+				sign := 0
+				if r > 0 {
+					sign = 1
+				} else if r < 0 {
+					sign = -1
+				}
+				return fmt.Sprint(sign), fmt.Sprint(libcStrcmp(a, b))
+			},
+		},
+		{
+			function: "strchr",
+			inputs:   pairs(stringValues),
+			compare: func(input string) (string, string) {
+				s, c := splitPair(input)
+				ch := 0
+				if len(c) > 0 {
+					ch = int(c[0])
+				}
+				str := cString(s)
+				r := noarch.Strchr(str, ch)
+				index := -1
+				if r != nil {
+					index = len(str) - len(r)
+				}
+				return fmt.Sprint(index), fmt.Sprint(libcStrchr(s, ch))
+			},
+		},
+		{
+			function: "abs",
+			inputs:   intInputs(intValues),
+			compare: func(input string) (string, string) {
+				var n int
+				fmt.Sscan(input, &n)
+				return fmt.Sprint(noarch.Abs(n)), fmt.Sprint(libcAbs(n))
+			},
+		},
+		{
+			function: "llabs",
+			inputs:   intInputs(intValues),
+			compare: func(input string) (string, string) {
+				var n int64
+				fmt.Sscan(input, &n)
+				return fmt.Sprint(noarch.Llabs(n)), fmt.Sprint(libcLlabs(n))
+			},
+		},
+		{
+			function: "imaxabs",
+			inputs:   intInputs(intValues),
+			compare: func(input string) (string, string) {
+				var n int64
+				fmt.Sscan(input, &n)
+				return fmt.Sprint(noarch.Imaxabs(n)), fmt.Sprint(libcImaxabs(n))
+			},
+		},
+		{
+			function: "div",
+			inputs:   intPairs(intValues),
+			compare: func(input string) (string, string) {
+				var a, b int
+				fmt.Sscan(input, &a, &b)
+				r := noarch.Div(a, b)
+				q, rem := libcDiv(a, b)
+				return fmt.Sprint(r.Quot, " ", r.Rem), fmt.Sprint(q, " ", rem)
+			},
+		},
+		{
+			function: "lldiv",
+			inputs:   intPairs(intValues),
+			compare: func(input string) (string, string) {
+				var a, b int64
+				fmt.Sscan(input, &a, &b)
+				r := noarch.Lldiv(a, b)
+				q, rem := libcLldiv(a, b)
+				return fmt.Sprint(r.Quot, " ", r.Rem), fmt.Sprint(q, " ", rem)
+			},
+		},
+		{
+			function: "imaxdiv",
+			inputs:   intPairs(intValues),
+			compare: func(input string) (string, string) {
+				var a, b int64
+				fmt.Sscan(input, &a, &b)
+				r := noarch.Imaxdiv(a, b)
+				q, rem := libcImaxdiv(a, b)
+				return fmt.Sprint(r.Quot, " ", r.Rem), fmt.Sprint(q, " ", rem)
+			},
+		},
+		{
+			function: "fmin",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return formatFloat(noarch.Fmin(v[0], v[1])),
+					formatFloat(libcFmin(v[0], v[1]))
+			},
+		},
+		{
+			function: "fmax",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return formatFloat(noarch.Fmax(v[0], v[1])),
+					formatFloat(libcFmax(v[0], v[1]))
+			},
+		},
+		{
+			function: "fdim",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return formatFloat(noarch.Fdim(v[0], v[1])),
+					formatFloat(libcFdim(v[0], v[1]))
+			},
+		},
+		{
+			function: "fma",
+			inputs:   floatTuples(3),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return formatFloat(noarch.Fma(v[0], v[1], v[2])),
+					formatFloat(libcFma(v[0], v[1], v[2]))
+			},
+		},
+		{
+			function: "fmaf",
+			inputs:   floatTuples(3),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				x, y, z := float32(v[0]), float32(v[1]), float32(v[2])
+				return formatFloat(float64(noarch.Fmaf(x, y, z))),
+					formatFloat(float64(libcFmaf(x, y, z)))
+			},
+		},
+		unaryFloat("sqrt", floatValues, math.Sqrt, libcSqrt),
+		unaryFloat("cbrt", floatValues, math.Cbrt, libcCbrt),
+		unaryFloat("fabs", floatValues, math.Abs, libcFabs),
+		unaryFloat("ceil", roundingValues, math.Ceil, libcCeil),
+		unaryFloat("floor", roundingValues, math.Floor, libcFloor),
+		unaryFloat("trunc", roundingValues, math.Trunc, libcTrunc),
+		unaryFloat("round", roundingValues, math.Round, libcRound),
+		unaryFloat("rint", roundingValues, noarch.Rint, libcRint),
+		unaryFloat("nearbyint", roundingValues, noarch.Nearbyint, libcNearbyint),
+		unaryFloat("logb", floatValues, math.Logb, libcLogb),
+		unaryFloat("exp", floatValues, math.Exp, libcExp),
+		unaryFloat("log", floatValues, noarch.Log, libcLog),
+		unaryFloat("log10", floatValues, noarch.Log10, libcLog10),
+		unaryFloat("sin", floatValues, math.Sin, libcSin),
+		unaryFloat("cos", floatValues, math.Cos, libcCos),
+		unaryFloat("lgamma", floatValues, noarch.Lgamma, libcLgamma),
+		unaryFloat("tgamma", floatValues, math.Gamma, libcTgamma),
+		{
+			function: "cabs",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				z := complex(v[0], v[1])
+				return formatFloat(noarch.Cabs(z)), formatFloat(libcCabs(z))
+			},
+		},
+		{
+			function: "carg",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				z := complex(v[0], v[1])
+				return formatFloat(noarch.Carg(z)), formatFloat(libcCarg(z))
+			},
+		},
+		unaryComplex("cproj", noarch.Cproj, libcCproj),
+		unaryComplex("cexp", noarch.Cexp, libcCexp),
+		unaryComplex("clog", noarch.Clog, libcClog),
+		unaryComplex("csqrt", noarch.Csqrt, libcCsqrt),
+		unaryComplex("csin", noarch.Csin, libcCsin),
+		unaryComplex("catan", noarch.Catan, libcCatan),
+		unaryFloat("erf", floatValues, math.Erf, libcErf),
+		unaryFloat("erfc", floatValues, math.Erfc, libcErfc),
+		unaryFloat("j0", besselValues, noarch.J0, libcJ0),
+		unaryFloat("j1", besselValues, noarch.J1, libcJ1),
+		unaryFloat("y0", besselValues, noarch.Y0, libcY0),
+		unaryFloat("y1", besselValues, noarch.Y1, libcY1),
+		{
+			function: "jn",
+			inputs:   besselOrderInputs(),
+			compare: func(input string) (string, string) {
+				n, x := splitOrder(input)
+				return formatFloat(noarch.Jn(n, x)), formatFloat(libcJn(n, x))
+			},
+		},
+		{
+			function: "yn",
+			inputs:   besselOrderInputs(),
+			compare: func(input string) (string, string) {
+				n, x := splitOrder(input)
+				return formatFloat(noarch.Yn(n, x)), formatFloat(libcYn(n, x))
+			},
+		},
+		{
+			function: "lgamma_r",
+			inputs:   floatInputs(floatValues),
+			compare: func(input string) (string, string) {
+				x := parseFloats(input)[0]
+				sign := []int{0}
+				r := noarch.LgammaR(x, sign)
+				libcR, libcSign := libcLgammaR(x)
+				return fmt.Sprintf("%s %d", formatFloat(r), sign[0]),
+					fmt.Sprintf("%s %d", formatFloat(libcR), libcSign)
+			},
+		},
+		binaryFloat("fmod", math.Mod, libcFmod),
+		binaryFloat("remainder", math.Remainder, libcRemainder),
+		binaryFloat("copysign", math.Copysign, libcCopysign),
+		binaryFloat("nextafter", noarch.Nextafter, libcNextafter),
+		binaryFloat("hypot", math.Hypot, libcHypot),
+		binaryFloat("pow", math.Pow, libcPow),
+		binaryFloat("atan2", math.Atan2, libcAtan2),
+		{
+			function: "frexp",
+			inputs:   floatInputs(floatValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				exp := []int{0}
+				frac := noarch.Frexp(v[0], exp)
+				libcFrac, libcExp := libcFrexp(v[0])
+				return formatFloat(frac) + " " + fmt.Sprint(exp[0]),
+					formatFloat(libcFrac) + " " + fmt.Sprint(libcExp)
+			},
+		},
+		{
+			function: "ldexp",
+			inputs:   floatIntInputs(),
+			compare: func(input string) (string, string) {
+				var e int
+				fmt.Sscan(strings.Fields(input)[1], &e)
+				x := parseFloats(input)[0]
+				return formatFloat(math.Ldexp(x, e)), formatFloat(libcLdexp(x, e))
+			},
+		},
+		{
+			function: "modf",
+			inputs:   floatInputs(roundingValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				integral := []float64{0}
+				frac := noarch.Modf(v[0], integral)
+				libcFrac, libcIntegral := libcModf(v[0])
+				return formatFloat(frac) + " " + formatFloat(integral[0]),
+					formatFloat(libcFrac) + " " + formatFloat(libcIntegral)
+			},
+		},
+		{
+			function: "ilogb",
+			inputs:   floatInputs(floatValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return fmt.Sprint(noarch.Ilogb(v[0])), fmt.Sprint(libcIlogb(v[0]))
+			},
+		},
+		{
+			function: "remquo",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				quo := []int{0}
+				r := noarch.Remquo(v[0], v[1], quo)
+				libcR, libcQuo := libcRemquo(v[0], v[1])
+				return formatFloat(r) + " " + fmt.Sprint(quo[0]),
+					formatFloat(libcR) + " " + fmt.Sprint(libcQuo)
+			},
+		},
+		{
+			function: "lround",
+			inputs:   floatInputs(roundingValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return fmt.Sprint(noarch.Lround(v[0])), fmt.Sprint(libcLround(v[0]))
+			},
+		},
+		{
+			function: "llround",
+			inputs:   floatInputs(roundingValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return fmt.Sprint(noarch.Llround(v[0])), fmt.Sprint(libcLlround(v[0]))
+			},
+		},
+		{
+			function: "lrint",
+			inputs:   floatInputs(roundingValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return fmt.Sprint(noarch.Lrint(v[0])), fmt.Sprint(libcLrint(v[0]))
+			},
+		},
+		{
+			function: "llrint",
+			inputs:   floatInputs(roundingValues),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				return fmt.Sprint(noarch.Llrint(v[0])), fmt.Sprint(libcLlrint(v[0]))
+			},
+		},
+		{
+			// payloads of NaN are compared
+			function: "nan",
+			inputs:   quoted(nanStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return fmt.Sprintf("%#x", math.Float64bits(noarch.Nan(cString(s)))),
+					fmt.Sprintf("%#x", libcNan(s))
+			},
+		},
+		{
+			function: "nanf",
+			inputs:   quoted(nanStrings),
+			compare: func(input string) (string, string) {
+				s := unquote(input)
+				return fmt.Sprintf("%#x", math.Float32bits(noarch.Nanf(cString(s)))),
+					fmt.Sprintf("%#x", libcNanf(s))
+			},
+		},
+		{
+			function: "sqrtf",
+			inputs:   floatInputs(floatValues),
+			compare: func(input string) (string, string) {
+				x := float32(parseFloats(input)[0])
+				return formatFloat(float64(noarch.Sqrtf(x))),
+					formatFloat(float64(libcSqrtf(x)))
+			},
+		},
+		{
+			function: "roundf",
+			inputs:   floatInputs(roundingValues),
+			compare: func(input string) (string, string) {
+				x := float32(parseFloats(input)[0])
+				return formatFloat(float64(noarch.Roundf(x))),
+					formatFloat(float64(libcRoundf(x)))
+			},
+		},
+		{
+			function: "fmodf",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				x, y := float32(v[0]), float32(v[1])
+				return formatFloat(float64(noarch.Fmodf(x, y))),
+					formatFloat(float64(libcFmodf(x, y)))
+			},
+		},
+		{
+			function: "nextafterf",
+			inputs:   floatTuples(2),
+			compare: func(input string) (string, string) {
+				v := parseFloats(input)
+				x, y := float32(v[0]), float32(v[1])
+				return formatFloat(float64(noarch.Nextafterf(x, y))),
+					formatFloat(float64(libcNextafterf(x, y)))
+			},
+		},
+		{
+			function: "ldexpf",
+			inputs:   floatIntInputs(),
+			compare: func(input string) (string, string) {
+				var e int
+				fmt.Sscan(strings.Fields(input)[1], &e)
+				x := float32(parseFloats(input)[0])
+				return formatFloat(float64(noarch.Ldexpf(x, e))),
+					formatFloat(float64(libcLdexpf(x, e)))
+			},
+		},
+		{
+			function: "fpclassify",
+			inputs:   floatInputs(classificationValues),
+			compare: func(input string) (string, string) {
+				x := parseFloats(input)[0]
+				return fmt.Sprint(noarch.Fpclassify(x)), fmt.Sprint(libcFpclassify(x))
+			},
+		},
+		{
+			function: "fpclassifyf",
+			inputs:   floatInputs(classificationValues),
+			compare: func(input string) (string, string) {
+				x := float32(parseFloats(input)[0])
+				return fmt.Sprint(noarch.Fpclassifyf(x)), fmt.Sprint(libcFpclassifyf(x))
+			},
+		},
+		{
+			function: "isinf",
+			inputs:   floatInputs(classificationValues),
+			compare: func(input string) (string, string) {
+				x := parseFloats(input)[0]
+				return fmt.Sprint(noarch.IsinfSign(x)), fmt.Sprint(libcIsinf(x))
+			},
+		},
+		{
+			function: "isnormalf",
+			inputs:   floatInputs(classificationValues),
+			compare: func(input string) (string, string) {
+				x := float32(parseFloats(input)[0])
+				return fmt.Sprint(noarch.Isnormalf(x)), fmt.Sprint(libcIsnormalf(x))
+			},
+		},
+		{
+			function: "tolower",
+			inputs:   intInputs(characters),
+			compare: func(input string) (string, string) {
+				var c int
+				fmt.Sscan(input, &c)
+				return fmt.Sprint(linux.ToLower(c)), fmt.Sprint(libcTolower(c))
+			},
+		},
+		{
+			function: "toupper",
+			inputs:   intInputs(characters),
+			compare: func(input string) (string, string) {
+				var c int
+				fmt.Sscan(input, &c)
+				return fmt.Sprint(linux.ToUpper(c)), fmt.Sprint(libcToupper(c))
+			},
+		},
+		{
+			function: "rand",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				seed := parseSeed(input)
+				var values []int
+				noarch.Srand(seed)
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Rand())
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcRand(seed, randAmount))
+			},
+		},
+		{
+			function: "random",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				seed := parseSeed(input)
+				var values []int32
+				noarch.Srandom(seed)
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Random())
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcRandom(seed, randAmount))
+			},
+		},
+		{
+			function: "rand_r",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				seed := parseSeed(input)
+				var values []int
+				s := []uint32{seed}
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.RandR(s))
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcRandR(seed, randAmount))
+			},
+		},
+		{
+			// type "long" is 32 bits in c4go
+			function: "drand48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				seed := int32(parseSeed(input))
+				var values []float64
+				noarch.Srand48(seed)
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Drand48())
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcDrand48(seed, randAmount))
+			},
+		},
+		{
+			function: "lrand48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				seed := int32(parseSeed(input))
+				var values []int32
+				noarch.Srand48(seed)
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Lrand48())
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcLrand48(seed, randAmount))
+			},
+		},
+		{
+			function: "mrand48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				seed := int32(parseSeed(input))
+				var values []int32
+				noarch.Srand48(seed)
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Mrand48())
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcMrand48(seed, randAmount))
+			},
+		},
+		{
+			function: "erand48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				x := xsubi(parseSeed(input))
+				libc := libcErand48(x, randAmount)
+				var values []float64
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Erand48(x[:]))
+				}
+				return fmt.Sprint(values), fmt.Sprint(libc)
+			},
+		},
+		{
+			function: "nrand48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				x := xsubi(parseSeed(input))
+				libc := libcNrand48(x, randAmount)
+				var values []int32
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Nrand48(x[:]))
+				}
+				return fmt.Sprint(values), fmt.Sprint(libc)
+			},
+		},
+		{
+			function: "jrand48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				x := xsubi(parseSeed(input))
+				libc := libcJrand48(x, randAmount)
+				var values []int32
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Jrand48(x[:]))
+				}
+				return fmt.Sprint(values), fmt.Sprint(libc)
+			},
+		},
+		{
+			function: "seed48",
+			inputs:   seedInputs(),
+			compare: func(input string) (string, string) {
+				x := xsubi(parseSeed(input))
+				var values []float64
+				noarch.Seed48(x[:])
+				for i := 0; i < randAmount; i++ {
+					values = append(values, noarch.Drand48())
+				}
+				return fmt.Sprint(values), fmt.Sprint(libcSeed48(x, randAmount))
+			},
+		},
+	}, ctypeChecks(characters)...)
+}
+
+// ctypeChecks returns checks of the classification functions of ctype.h.
+// Values of classes must be the same as in glibc, not only the truth.
+func ctypeChecks(characters []int) (checks []check) {
+	functions := []struct {
+		name   string
+		noarch func(int) int
+	}{
+		{"isalnum", linux.IsAlnum},
+		{"isalpha", linux.IsAlpha},
+		{"isblank", linux.IsBlank},
+		{"iscntrl", linux.IsCntrl},
+		{"isdigit", linux.IsDigit},
+		{"isgraph", linux.IsGraph},
+		{"islower", linux.IsLower},
+		{"isprint", linux.IsPrint},
+		{"ispunct", linux.IsPunct},
+		{"isspace", linux.IsSpace},
+		{"isupper", linux.IsUpper},
+		{"isxdigit", linux.IsXdigit},
+	}
+	for _, f := range functions {
+		f := f
+		checks = append(checks, check{
+			function: f.name,
+			inputs:   intInputs(characters),
+			compare: func(input string) (string, string) {
+				var c int
+				fmt.Sscan(input, &c)
+				return fmt.Sprint(f.noarch(c)), fmt.Sprint(libcCtype[f.name](c))
+			},
+		})
+	}
+	return
+}
+
+// Run executes all conformance checks and returns the conformance matrix.
+// A panic of noarch function is reported as mismatch.
+func Run() (matrix Matrix) {
+	for _, c := range checks() {
+		row := Row{Function: c.function}
+		for _, input := range c.inputs {
+			row.Total++
+			noarchResult, libcResult := safeCompare(c.compare, input)
+			if noarchResult == libcResult {
+				row.Passed++
+				continue
+			}
+			row.Mismatches = append(row.Mismatches, Mismatch{
+				Input:  input,
+				Noarch: noarchResult,
+				Libc:   libcResult,
+			})
+		}
+		matrix = append(matrix, row)
+	}
+	sort.SliceStable(matrix, func(i, j int) bool {
+		return matrix[i].Function < matrix[j].Function
+	})
+	return
+}
+
+func safeCompare(compare func(string) (string, string), input string) (
+	noarchResult, libcResult string) {
+	defer func() {
+		if r := recover(); r != nil {
+			noarchResult = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+	return compare(input)
+}
+
+// Write prints the conformance matrix. If verbose is true, then all
+// mismatches are printed too.
+func (m Matrix) Write(w io.Writer, verbose bool) {
+	fmt.Fprintf(w, "%-10s %6s %6s %8s\n", "function", "total", "passed", "percent")
+	for _, row := range m {
+		fmt.Fprintf(w, "%-10s %6d %6d %7.1f%%\n", row.Function, row.Total,
+			row.Passed, 100*float64(row.Passed)/float64(row.Total))
+		if !verbose {
+			continue
+		}
+		for _, mis := range row.Mismatches {
+			fmt.Fprintf(w, "\t%-30s noarch: %-30s libc: %s\n",
+				mis.Input, mis.Noarch, mis.Libc)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+//go:build conformance
+// +build conformance
+
+package conformance
+
+import (
+	"bytes"
+	"testing"
+)
+
+// passedBaseline is the number of matched results for each C function at
+// the moment of writing the check. Decreasing of that number means a
+// regression in package noarch. After the fix of some mismatches the
+// baseline must be increased.
+var passedBaseline = map[string]int{
+	"abs":         12,
+	"atan2":       142,
+	"atof":        55,
+	"atoi":        57,
+	"atol":        57,
+	"atoll":       57,
+	"cabs":        138,
+	"carg":        142,
+	"catan":       102,
+	"cbrt":        7,
+	"ceil":        19,
+	"cexp":        125,
+	"clog":        96,
+	"copysign":    144,
+	"cos":         10,
+	"cproj":       144,
+	"csin":        116,
+	"csqrt":       142,
+	"div":         132,
+	"drand48":     10,
+	"erand48":     10,
+	"erf":         12,
+	"erfc":        11,
+	"exp":         12,
+	"fabs":        12,
+	"fdim":        144,
+	"floor":       19,
+	"fma":         1728,
+	"fmaf":        1728,
+	"fmax":        144,
+	"fmin":        144,
+	"fmod":        144,
+	"fmodf":       144,
+	"fpclassify":  18,
+	"fpclassifyf": 18,
+	"frexp":       12,
+	"hypot":       138,
+	"ilogb":       12,
+	"imaxabs":     12,
+	"imaxdiv":     132,
+	"isalnum":     384,
+	"isalpha":     384,
+	"isblank":     384,
+	"iscntrl":     384,
+	"isdigit":     384,
+	"isgraph":     384,
+	"isinf":       18,
+	"islower":     384,
+	"isnormalf":   18,
+	"isprint":     384,
+	"ispunct":     384,
+	"isspace":     384,
+	"isupper":     384,
+	"isxdigit":    384,
+	"j0":          12,
+	"j1":          12,
+	"jn":          82,
+	"jrand48":     10,
+	"ldexp":       120,
+	"ldexpf":      120,
+	"lgamma":      11,
+	"lgamma_r":    11,
+	"llabs":       12,
+	"lldiv":       132,
+	"llrint":      19,
+	"llround":     19,
+	"log":         12,
+	"log10":       11,
+	"logb":        12,
+	"lrand48":     10,
+	"lrint":       19,
+	"lround":      19,
+	"modf":        19,
+	"mrand48":     10,
+	"nan":         8,
+	"nanf":        8,
+	"nearbyint":   19,
+	"nextafter":   144,
+	"nextafterf":  144,
+	"nrand48":     10,
+	"pow":         143,
+	"rand":        10,
+	"rand_r":      10,
+	"random":      10,
+	"remainder":   144,
+	"remquo":      144,
+	"rint":        19,
+	"round":       19,
+	"roundf":      19,
+	"seed48":      10,
+	"sin":         9,
+	"sqrt":        12,
+	"sqrtf":       12,
+	"strchr":      110,
+	"strcmp":      121,
+	"strlen":      11,
+	"strtod":      54,
+	"strtoimax":   281,
+	"strtol":      281,
+	"strtoll":     281,
+	"strtoul":     275,
+	"strtoull":    265,
+	"strtoumax":   265,
+	"tgamma":      11,
+	"tolower":     384,
+	"toupper":     384,
+	"trunc":       19,
+	"y0":          13,
+	"y1":          13,
+	"yn":          95,
+}
+
+// TestConformance prints the conformance matrix of package noarch. The list
+// of mismatches is printed with flag -v.
+func TestConformance(t *testing.T) {
+	matrix := Run()
+
+	var buf bytes.Buffer
+	matrix.Write(&buf, testing.Verbose())
+	t.Log("\n" + buf.String())
+
+	for _, row := range matrix {
+		baseline, ok := passedBaseline[row.Function]
+		if !ok {
+			t.Errorf("Function `%s` is not in baseline", row.Function)
+			continue
+		}
+		if row.Passed < baseline {
+			t.Errorf("Regression of function `%s`: passed %d, baseline %d",
+				row.Function, row.Passed, baseline)
+		}
+	}
+}
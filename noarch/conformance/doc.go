@@ -0,0 +1,14 @@
+// Package conformance checks the behavior of the functions of package noarch
+// against the C standard library of the host.
+//
+// Every check calls a noarch function and the same function of libc (through
+// a small cgo helper) with generated edge-case inputs and compares results.
+// The outcome is a conformance matrix: for each C function the number of
+// tested inputs, the number of matched results and the list of mismatches.
+//
+// The package requires cgo and a C compiler, so it is built only with the
+// tag "conformance":
+//
+//     go test -tags=conformance -v github.com/Konstantin8105/c4go/noarch/conformance
+//
+package conformance
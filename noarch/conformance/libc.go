@@ -0,0 +1,558 @@
+//go:build conformance
+// +build conformance
+
+package conformance
+
+/*
+#cgo LDFLAGS: -lm
+#include <complex.h>
+#include <ctype.h>
+#include <inttypes.h>
+#include <math.h>
+#include <stdlib.h>
+#include <string.h>
+
+static int c4go_strcmp_sign(const char *a, const char *b) {
+	int r = strcmp(a, b);
+	return (r > 0) - (r < 0);
+}
+
+static long c4go_strchr_index(const char *s, int c) {
+	const char *p = strchr(s, c);
+	return p == NULL ? -1 : (long)(p - s);
+}
+
+// classification macros cannot be called from Go
+static int c4go_fpclassify(double x) { return fpclassify(x); }
+static int c4go_fpclassifyf(float x) { return fpclassify(x); }
+static int c4go_isinf(double x) { return isinf(x); }
+static int c4go_isnormalf(float x) { return isnormal(x); }
+*/
+import "C"
+
+import (
+	"math"
+	"unsafe"
+)
+
+// The functions below are wrappers of libc functions. C strings are
+// created from Go strings and end pointers are returned as offsets from the
+// begin of the input string.
+
+func libcAtoi(s string) int {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int(C.atoi(cs))
+}
+
+func libcAtol(s string) int64 {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int64(C.atol(cs))
+}
+
+func libcAtoll(s string) int64 {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int64(C.atoll(cs))
+}
+
+func libcAtof(s string) float64 {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return float64(C.atof(cs))
+}
+
+func offset(begin, end *C.char) int {
+	return int(uintptr(unsafe.Pointer(end)) - uintptr(unsafe.Pointer(begin)))
+}
+
+func libcStrtol(s string, base int) (int64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtol(cs, &end, C.int(base))
+	return int64(v), offset(cs, end)
+}
+
+func libcStrtoll(s string, base int) (int64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtoll(cs, &end, C.int(base))
+	return int64(v), offset(cs, end)
+}
+
+func libcStrtoul(s string, base int) (uint64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtoul(cs, &end, C.int(base))
+	return uint64(v), offset(cs, end)
+}
+
+func libcStrtoull(s string, base int) (uint64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtoull(cs, &end, C.int(base))
+	return uint64(v), offset(cs, end)
+}
+
+func libcStrtoimax(s string, base int) (int64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtoimax(cs, &end, C.int(base))
+	return int64(v), offset(cs, end)
+}
+
+func libcStrtoumax(s string, base int) (uint64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtoumax(cs, &end, C.int(base))
+	return uint64(v), offset(cs, end)
+}
+
+func libcStrtod(s string) (float64, int) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	var end *C.char
+	v := C.strtod(cs, &end)
+	return float64(v), offset(cs, end)
+}
+
+func libcStrlen(s string) int {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int(C.strlen(cs))
+}
+
+func libcStrcmp(a, b string) int {
+	ca := C.CString(a)
+	defer C.free(unsafe.Pointer(ca))
+	cb := C.CString(b)
+	defer C.free(unsafe.Pointer(cb))
+	return int(C.c4go_strcmp_sign(ca, cb))
+}
+
+func libcStrchr(s string, c int) int {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int(C.c4go_strchr_index(cs, C.int(c)))
+}
+
+func libcAbs(n int) int {
+	return int(C.abs(C.int(n)))
+}
+
+func libcLlabs(n int64) int64 {
+	return int64(C.llabs(C.longlong(n)))
+}
+
+func libcImaxabs(n int64) int64 {
+	return int64(C.imaxabs(C.intmax_t(n)))
+}
+
+func libcDiv(numer, denom int) (int, int) {
+	r := C.div(C.int(numer), C.int(denom))
+	return int(r.quot), int(r.rem)
+}
+
+func libcLldiv(numer, denom int64) (int64, int64) {
+	r := C.lldiv(C.longlong(numer), C.longlong(denom))
+	return int64(r.quot), int64(r.rem)
+}
+
+func libcImaxdiv(numer, denom int64) (int64, int64) {
+	r := C.imaxdiv(C.intmax_t(numer), C.intmax_t(denom))
+	return int64(r.quot), int64(r.rem)
+}
+
+func libcFmin(x, y float64) float64 {
+	return float64(C.fmin(C.double(x), C.double(y)))
+}
+
+func libcFmax(x, y float64) float64 {
+	return float64(C.fmax(C.double(x), C.double(y)))
+}
+
+func libcFdim(x, y float64) float64 {
+	return float64(C.fdim(C.double(x), C.double(y)))
+}
+
+func libcFma(x, y, z float64) float64 {
+	return float64(C.fma(C.double(x), C.double(y), C.double(z)))
+}
+
+// libcCtype - classification functions of ctype.h.
+var libcCtype = map[string]func(int) int{
+	"isalnum":  func(c int) int { return int(C.isalnum(C.int(c))) },
+	"isalpha":  func(c int) int { return int(C.isalpha(C.int(c))) },
+	"isblank":  func(c int) int { return int(C.isblank(C.int(c))) },
+	"iscntrl":  func(c int) int { return int(C.iscntrl(C.int(c))) },
+	"isdigit":  func(c int) int { return int(C.isdigit(C.int(c))) },
+	"isgraph":  func(c int) int { return int(C.isgraph(C.int(c))) },
+	"islower":  func(c int) int { return int(C.islower(C.int(c))) },
+	"isprint":  func(c int) int { return int(C.isprint(C.int(c))) },
+	"ispunct":  func(c int) int { return int(C.ispunct(C.int(c))) },
+	"isspace":  func(c int) int { return int(C.isspace(C.int(c))) },
+	"isupper":  func(c int) int { return int(C.isupper(C.int(c))) },
+	"isxdigit": func(c int) int { return int(C.isxdigit(C.int(c))) },
+}
+
+func libcTolower(c int) int {
+	return int(C.tolower(C.int(c)))
+}
+
+func libcToupper(c int) int {
+	return int(C.toupper(C.int(c)))
+}
+
+// Wrappers of math.h functions.
+
+func libcSqrt(x float64) float64 {
+	return float64(C.sqrt(C.double(x)))
+}
+
+func libcCbrt(x float64) float64 {
+	return float64(C.cbrt(C.double(x)))
+}
+
+func libcCeil(x float64) float64 {
+	return float64(C.ceil(C.double(x)))
+}
+
+func libcFloor(x float64) float64 {
+	return float64(C.floor(C.double(x)))
+}
+
+func libcTrunc(x float64) float64 {
+	return float64(C.trunc(C.double(x)))
+}
+
+func libcRound(x float64) float64 {
+	return float64(C.round(C.double(x)))
+}
+
+func libcRint(x float64) float64 {
+	return float64(C.rint(C.double(x)))
+}
+
+func libcNearbyint(x float64) float64 {
+	return float64(C.nearbyint(C.double(x)))
+}
+
+func libcLogb(x float64) float64 {
+	return float64(C.logb(C.double(x)))
+}
+
+func libcExp(x float64) float64 {
+	return float64(C.exp(C.double(x)))
+}
+
+func libcLog(x float64) float64 {
+	return float64(C.log(C.double(x)))
+}
+
+func libcLog10(x float64) float64 {
+	return float64(C.log10(C.double(x)))
+}
+
+func libcSin(x float64) float64 {
+	return float64(C.sin(C.double(x)))
+}
+
+func libcCos(x float64) float64 {
+	return float64(C.cos(C.double(x)))
+}
+
+func libcLgamma(x float64) float64 {
+	return float64(C.lgamma(C.double(x)))
+}
+
+func libcTgamma(x float64) float64 {
+	return float64(C.tgamma(C.double(x)))
+}
+
+func libcCabs(z complex128) float64 {
+	return float64(C.cabs(C.complexdouble(z)))
+}
+
+func libcCarg(z complex128) float64 {
+	return float64(C.carg(C.complexdouble(z)))
+}
+
+func libcCproj(z complex128) complex128 {
+	return complex128(C.cproj(C.complexdouble(z)))
+}
+
+func libcCexp(z complex128) complex128 {
+	return complex128(C.cexp(C.complexdouble(z)))
+}
+
+func libcClog(z complex128) complex128 {
+	return complex128(C.clog(C.complexdouble(z)))
+}
+
+func libcCsqrt(z complex128) complex128 {
+	return complex128(C.csqrt(C.complexdouble(z)))
+}
+
+func libcCsin(z complex128) complex128 {
+	return complex128(C.csin(C.complexdouble(z)))
+}
+
+func libcCatan(z complex128) complex128 {
+	return complex128(C.catan(C.complexdouble(z)))
+}
+
+func libcErf(x float64) float64 {
+	return float64(C.erf(C.double(x)))
+}
+
+func libcErfc(x float64) float64 {
+	return float64(C.erfc(C.double(x)))
+}
+
+func libcJ0(x float64) float64 {
+	return float64(C.j0(C.double(x)))
+}
+
+func libcJ1(x float64) float64 {
+	return float64(C.j1(C.double(x)))
+}
+
+func libcJn(n int, x float64) float64 {
+	return float64(C.jn(C.int(n), C.double(x)))
+}
+
+func libcY0(x float64) float64 {
+	return float64(C.y0(C.double(x)))
+}
+
+func libcY1(x float64) float64 {
+	return float64(C.y1(C.double(x)))
+}
+
+func libcYn(n int, x float64) float64 {
+	return float64(C.yn(C.int(n), C.double(x)))
+}
+
+func libcLgammaR(x float64) (float64, int) {
+	var sign C.int
+	r := C.lgamma_r(C.double(x), &sign)
+	return float64(r), int(sign)
+}
+
+func libcFabs(x float64) float64 {
+	return float64(C.fabs(C.double(x)))
+}
+
+func libcFmod(x, y float64) float64 {
+	return float64(C.fmod(C.double(x), C.double(y)))
+}
+
+func libcRemainder(x, y float64) float64 {
+	return float64(C.remainder(C.double(x), C.double(y)))
+}
+
+func libcCopysign(x, y float64) float64 {
+	return float64(C.copysign(C.double(x), C.double(y)))
+}
+
+func libcNextafter(x, y float64) float64 {
+	return float64(C.nextafter(C.double(x), C.double(y)))
+}
+
+func libcHypot(x, y float64) float64 {
+	return float64(C.hypot(C.double(x), C.double(y)))
+}
+
+func libcPow(x, y float64) float64 {
+	return float64(C.pow(C.double(x), C.double(y)))
+}
+
+func libcAtan2(x, y float64) float64 {
+	return float64(C.atan2(C.double(x), C.double(y)))
+}
+
+func libcFrexp(x float64) (float64, int) {
+	var exp C.int
+	frac := C.frexp(C.double(x), &exp)
+	return float64(frac), int(exp)
+}
+
+func libcLdexp(x float64, exp int) float64 {
+	return float64(C.ldexp(C.double(x), C.int(exp)))
+}
+
+func libcModf(x float64) (float64, float64) {
+	var integral C.double
+	frac := C.modf(C.double(x), &integral)
+	return float64(frac), float64(integral)
+}
+
+func libcIlogb(x float64) int {
+	return int(C.ilogb(C.double(x)))
+}
+
+func libcRemquo(x, y float64) (float64, int) {
+	var quo C.int
+	r := C.remquo(C.double(x), C.double(y), &quo)
+	return float64(r), int(quo)
+}
+
+// type "long" is 32 bits in c4go
+func libcLround(x float64) int32 {
+	return int32(C.lround(C.double(x)))
+}
+
+func libcLlround(x float64) int64 {
+	return int64(C.llround(C.double(x)))
+}
+
+func libcLrint(x float64) int32 {
+	return int32(C.lrint(C.double(x)))
+}
+
+func libcLlrint(x float64) int64 {
+	return int64(C.llrint(C.double(x)))
+}
+
+func libcNan(s string) uint64 {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return math.Float64bits(float64(C.nan(cs)))
+}
+
+func libcNanf(s string) uint32 {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return math.Float32bits(float32(C.nanf(cs)))
+}
+
+func libcSqrtf(x float32) float32 {
+	return float32(C.sqrtf(C.float(x)))
+}
+
+func libcFmodf(x, y float32) float32 {
+	return float32(C.fmodf(C.float(x), C.float(y)))
+}
+
+func libcLdexpf(x float32, exp int) float32 {
+	return float32(C.ldexpf(C.float(x), C.int(exp)))
+}
+
+func libcNextafterf(x, y float32) float32 {
+	return float32(C.nextafterf(C.float(x), C.float(y)))
+}
+
+func libcRoundf(x float32) float32 {
+	return float32(C.roundf(C.float(x)))
+}
+
+func libcFmaf(x, y, z float32) float32 {
+	return float32(C.fmaf(C.float(x), C.float(y), C.float(z)))
+}
+
+func libcFpclassify(x float64) int {
+	return int(C.c4go_fpclassify(C.double(x)))
+}
+
+func libcFpclassifyf(x float32) int {
+	return int(C.c4go_fpclassifyf(C.float(x)))
+}
+
+func libcIsinf(x float64) int {
+	return int(C.c4go_isinf(C.double(x)))
+}
+
+func libcIsnormalf(x float32) int {
+	return int(C.c4go_isnormalf(C.float(x)))
+}
+
+// The functions below return the first n values of pseudo-random generators
+// after seeding.
+
+func libcRand(seed uint32, n int) (values []int) {
+	C.srand(C.uint(seed))
+	for i := 0; i < n; i++ {
+		values = append(values, int(C.rand()))
+	}
+	return
+}
+
+func libcRandom(seed uint32, n int) (values []int32) {
+	C.srandom(C.uint(seed))
+	for i := 0; i < n; i++ {
+		values = append(values, int32(C.random()))
+	}
+	return
+}
+
+func libcRandR(seed uint32, n int) (values []int) {
+	s := C.uint(seed)
+	for i := 0; i < n; i++ {
+		values = append(values, int(C.rand_r(&s)))
+	}
+	return
+}
+
+func libcDrand48(seed int32, n int) (values []float64) {
+	C.srand48(C.long(seed))
+	for i := 0; i < n; i++ {
+		values = append(values, float64(C.drand48()))
+	}
+	return
+}
+
+func libcLrand48(seed int32, n int) (values []int32) {
+	C.srand48(C.long(seed))
+	for i := 0; i < n; i++ {
+		values = append(values, int32(C.lrand48()))
+	}
+	return
+}
+
+func libcMrand48(seed int32, n int) (values []int32) {
+	C.srand48(C.long(seed))
+	for i := 0; i < n; i++ {
+		values = append(values, int32(C.mrand48()))
+	}
+	return
+}
+
+func libcNrand48(xsubi [3]uint16, n int) (values []int32) {
+	x := [3]C.ushort{C.ushort(xsubi[0]), C.ushort(xsubi[1]), C.ushort(xsubi[2])}
+	for i := 0; i < n; i++ {
+		values = append(values, int32(C.nrand48(&x[0])))
+	}
+	return
+}
+
+func libcJrand48(xsubi [3]uint16, n int) (values []int32) {
+	x := [3]C.ushort{C.ushort(xsubi[0]), C.ushort(xsubi[1]), C.ushort(xsubi[2])}
+	for i := 0; i < n; i++ {
+		values = append(values, int32(C.jrand48(&x[0])))
+	}
+	return
+}
+
+func libcErand48(xsubi [3]uint16, n int) (values []float64) {
+	x := [3]C.ushort{C.ushort(xsubi[0]), C.ushort(xsubi[1]), C.ushort(xsubi[2])}
+	for i := 0; i < n; i++ {
+		values = append(values, float64(C.erand48(&x[0])))
+	}
+	return
+}
+
+func libcSeed48(seed [3]uint16, n int) (values []float64) {
+	x := [3]C.ushort{C.ushort(seed[0]), C.ushort(seed[1]), C.ushort(seed[2])}
+	C.seed48(&x[0])
+	for i := 0; i < n; i++ {
+		values = append(values, float64(C.drand48()))
+	}
+	return
+}
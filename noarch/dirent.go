@@ -0,0 +1,221 @@
+package noarch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// Types of file in Dirent.DType
+const (
+	dtUnknown = 0
+	dtFifo    = 1
+	dtChr     = 2
+	dtDir     = 4
+	dtBlk     = 6
+	dtReg     = 8
+	dtLnk     = 10
+	dtSock    = 12
+)
+
+// Dirent represents the C structure "struct dirent" from dirent.h:
+//
+//     struct dirent {
+//         ino_t          d_ino;
+//         off_t          d_off;
+//         unsigned short d_reclen;
+//         unsigned char  d_type;
+//         char           d_name[256];
+//     };
+type Dirent struct {
+	DIno    uint64
+	DOff    int64
+	DReclen uint16
+	DType   uint8
+	DName   []byte
+}
+
+// Dir represents the C type DIR from dirent.h - a directory stream.
+type Dir struct {
+	path     string
+	entries  []Dirent
+	position int
+
+	// Entry returned by Readdir. C function readdir() returns a pointer to
+	// the same static entry, so it is overwritten by next calls.
+	entry []Dirent
+}
+
+// inode returns the inode of file if it is available for the platform.
+func inode(info os.FileInfo) uint64 {
+	return sysUint(info, "Ino")
+}
+
+// sysUint returns the unsigned value of field from the system specific
+// information of file. If field is not found, then zero is returned.
+func sysUint(info os.FileInfo, field string) uint64 {
+	sys := reflect.ValueOf(info.Sys())
+	if sys.Kind() == reflect.Ptr && !sys.IsNil() {
+		sys = sys.Elem()
+	}
+	if sys.Kind() != reflect.Struct {
+		return 0
+	}
+	v := sys.FieldByName(field)
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return v.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return uint64(v.Int())
+	}
+	return 0
+}
+
+// fileType returns the value of Dirent.DType for the file mode.
+func fileType(mode os.FileMode) uint8 {
+	switch {
+	case mode.IsRegular():
+		return dtReg
+	case mode&os.ModeDir != 0:
+		return dtDir
+	case mode&os.ModeSymlink != 0:
+		return dtLnk
+	case mode&os.ModeNamedPipe != 0:
+		return dtFifo
+	case mode&os.ModeSocket != 0:
+		return dtSock
+	case mode&os.ModeCharDevice != 0:
+		return dtChr
+	case mode&os.ModeDevice != 0:
+		return dtBlk
+	}
+	return dtUnknown
+}
+
+// direntHeaderSize is the offset of field d_name in struct dirent of Linux.
+const direntHeaderSize = 8 + 8 + 2 + 1
+
+// direntReclen returns the size of record of struct dirent of Linux for the
+// name, with terminating null, aligned to 8 bytes.
+func direntReclen(name string) uint16 {
+	size := direntHeaderSize + len(name) + 1
+	return uint16((size + 7) &^ 7)
+}
+
+func newDirent(name string, info os.FileInfo, offset int) Dirent {
+	d := Dirent{
+		DOff:    int64(offset),
+		DReclen: direntReclen(name),
+		DName:   StringToCString(name),
+	}
+	if info != nil {
+		d.DIno = inode(info)
+		d.DType = fileType(info.Mode())
+	}
+	return d
+}
+
+// read reads all entries of directory, included "." and "..".
+func (d *Dir) read() error {
+	infos, err := ioutil.ReadDir(d.path)
+	if err != nil {
+		return err
+	}
+	d.entries = d.entries[:0]
+	for _, name := range []string{".", ".."} {
+		info, _ := os.Stat(filepath.Join(d.path, name))
+		d.entries = append(d.entries, newDirent(name, info, len(d.entries)+1))
+	}
+	for _, info := range infos {
+		d.entries = append(d.entries,
+			newDirent(info.Name(), info, len(d.entries)+1))
+	}
+	d.position = 0
+	return nil
+}
+
+// Opendir handles opendir().
+//
+// Opens a directory stream corresponding to the directory name, and returns
+// a pointer to the directory stream. The stream is positioned at the first
+// entry in the directory. On error, NULL is returned.
+func Opendir(name []byte) *Dir {
+	path := CStringToString(name)
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return nil
+	}
+	d := &Dir{path: path, entry: make([]Dirent, 1)}
+	if d.read() != nil {
+		return nil
+	}
+	return d
+}
+
+// Readdir handles readdir().
+//
+// Returns a pointer to a dirent structure representing the next directory
+// entry in the directory stream pointed to by dirp. It returns NULL on
+// reaching the end of the directory stream or if an error occurred.
+//
+// The data returned by readdir() may be overwritten by subsequent calls to
+// readdir() for the same directory stream.
+func Readdir(dirp *Dir) []Dirent {
+	if dirp == nil || dirp.position >= len(dirp.entries) {
+		return nil
+	}
+	dirp.entry[0] = dirp.entries[dirp.position]
+	dirp.position++
+	return dirp.entry
+}
+
+// ReaddirR handles readdir_r().
+//
+// Works like readdir(), but the next entry is copied in the buffer entry
+// and a pointer to the returned entry is placed in result. If the end of the
+// directory stream is reached, then NULL is placed in result. On success,
+// returns 0.
+func ReaddirR(dirp *Dir, entry []Dirent, result [][]Dirent) int {
+	if dirp == nil {
+		return 9 // EBADF
+	}
+	if dirp.position >= len(dirp.entries) {
+		result[0] = nil
+		return 0
+	}
+	entry[0] = dirp.entries[dirp.position]
+	dirp.position++
+	result[0] = entry
+	return 0
+}
+
+// Closedir handles closedir().
+//
+// Closes the directory stream associated with dirp. A successful call to
+// closedir() also closes the underlying file descriptor associated with
+// dirp. Returns 0 on success. On error, -1 is returned.
+func Closedir(dirp *Dir) int {
+	if dirp == nil {
+		return -1
+	}
+	dirp.entries = nil
+	dirp.position = 0
+	return 0
+}
+
+// Rewinddir handles rewinddir().
+//
+// Resets the position of the directory stream dirp to the beginning of the
+// directory. The content of directory is read again.
+func Rewinddir(dirp *Dir) {
+	if dirp == nil {
+		return
+	}
+	if dirp.read() != nil {
+		dirp.entries = nil
+		dirp.position = 0
+	}
+}
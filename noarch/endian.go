@@ -0,0 +1,74 @@
+package noarch
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"reflect"
+	"unsafe"
+)
+
+// hostByteOrder is the byte order of values in memory of the host. The
+// byte order of the C program is the same, so the bytes of integers seen
+// by C code, the functions of byte order conversion and the decoding of
+// byte buffers use the same convention.
+var hostByteOrder binary.ByteOrder = binary.LittleEndian
+
+func init() {
+	v := uint16(1)
+	if *(*byte)(unsafe.Pointer(&v)) == 0 {
+		hostByteOrder = binary.BigEndian
+	}
+}
+
+// Bswap16 handles __builtin_bswap16().
+//
+// Returns x with the order of bytes reversed.
+func Bswap16(x uint16) uint16 {
+	return bits.ReverseBytes16(x)
+}
+
+// Bswap32 handles __builtin_bswap32().
+//
+// Returns x with the order of bytes reversed.
+func Bswap32(x uint32) uint32 {
+	return bits.ReverseBytes32(x)
+}
+
+// Bswap64 handles __builtin_bswap64().
+//
+// Returns x with the order of bytes reversed.
+func Bswap64(x uint64) uint64 {
+	return bits.ReverseBytes64(x)
+}
+
+// SliceBytes handles the cast of pointer of integer or floating-point type
+// to pointer of char in C, for example:
+//
+//     unsigned int x = 1;
+//     unsigned char *b = (unsigned char *)&x;
+//
+// Returns the bytes of memory of elements of slice in the host byte order
+// without copy, so changes of bytes are seen in the values of slice. Result
+// is nil for empty slice or not slice of fixed size values.
+func SliceBytes(slice interface{}) []byte {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return nil
+	}
+	switch v.Type().Elem().Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Interface,
+		reflect.Ptr, reflect.Func, reflect.Chan:
+		return nil
+	}
+	n := int(v.Type().Elem().Size())
+	if n == 0 {
+		return nil
+	}
+	// size of view is limited by the size of array type
+	const maxSize = 1 << 30
+	size := maxSize
+	if v.Len() < maxSize/n {
+		size = v.Len() * n
+	}
+	return (*[maxSize]byte)(unsafe.Pointer(v.Pointer()))[:size:size]
+}
@@ -0,0 +1,73 @@
+package noarch
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+func TestBswap(t *testing.T) {
+	if v := Bswap16(0x0102); v != 0x0201 {
+		t.Errorf("bswap16: %x", v)
+	}
+	if v := Bswap32(0x01020304); v != 0x04030201 {
+		t.Errorf("bswap32: %x", v)
+	}
+	if v := Bswap64(0x0102030405060708); v != 0x0807060504030201 {
+		t.Errorf("bswap64: %x", v)
+	}
+}
+
+func TestByteOrder(t *testing.T) {
+	// bytes of value in network byte order are in order of significance
+	v := []uint32{Htonl(0x01020304)}
+	b := SliceBytes(v)
+	if len(b) != 4 || b[0] != 1 || b[1] != 2 || b[2] != 3 || b[3] != 4 {
+		t.Errorf("htonl: % x", b)
+	}
+	if Ntohl(v[0]) != 0x01020304 {
+		t.Errorf("ntohl: %x", Ntohl(v[0]))
+	}
+	s := []uint16{Htons(0x0102)}
+	if b := SliceBytes(s); b[0] != 1 || b[1] != 2 || Ntohs(s[0]) != 0x0102 {
+		t.Errorf("htons: % x", b)
+	}
+
+	a := ipToInAddr(net.IPv4(192, 168, 0, 1))
+	if a.SAddr != Htonl(0xc0a80001) {
+		t.Errorf("address: %x", a.SAddr)
+	}
+	if ip := inAddrToIP(a); !ip.Equal(net.IPv4(192, 168, 0, 1)) {
+		t.Errorf("address: %v", ip)
+	}
+}
+
+func TestSliceBytes(t *testing.T) {
+	v := []uint32{0x01020304, 0x05060708}
+	b := SliceBytes(v[1:])
+	if len(b) != 4 || hostByteOrder.Uint32(b) != 0x05060708 {
+		t.Errorf("bytes: % x", b)
+	}
+	// changes are shared
+	b[0], b[1], b[2], b[3] = 0, 0, 0, 0
+	if v[1] != 0 {
+		t.Errorf("value: %x", v[1])
+	}
+
+	f := []float64{1}
+	if u := hostByteOrder.Uint64(SliceBytes(f)); u != 0x3ff0000000000000 {
+		t.Errorf("float64: %x", u)
+	}
+
+	if hostByteOrder == binary.LittleEndian {
+		u := []uint16{0x0102}
+		if b := SliceBytes(u); b[0] != 2 {
+			t.Errorf("little-endian: % x", b)
+		}
+	}
+
+	if SliceBytes([]int{}) != nil || SliceBytes([][]byte{nil}) != nil ||
+		SliceBytes(42) != nil {
+		t.Errorf("not nil bytes")
+	}
+}
@@ -0,0 +1,127 @@
+package noarch
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+// Environ - array of environment variables in format "NAME=value", the
+// last element of array is nil. It is the C variable "environ" from
+// unistd.h. Array is created again after each change of environment by
+// functions Setenv, Unsetenv, Putenv and Clearenv, as in glibc.
+var Environ [][]byte
+
+// putenvStrings is the strings added to environment by putenv(). Keys are
+// names of variables. C code expects that the string becomes a part of the
+// environment, so the string is used in Environ while the variable keeps
+// the value.
+var putenvStrings = map[string][]byte{}
+
+func init() {
+	updateEnviron()
+}
+
+// updateEnviron creates Environ by environment variables of process.
+func updateEnviron() {
+	env := os.Environ()
+	Environ = make([][]byte, 0, len(env)+1)
+	for _, e := range env {
+		if i := strings.IndexByte(e, '='); i > 0 {
+			s, ok := putenvStrings[e[:i]]
+			if ok && CStringToString(s) == e {
+				Environ = append(Environ, s)
+				continue
+			}
+		}
+		Environ = append(Environ, StringToCString(e))
+	}
+	Environ = append(Environ, nil)
+}
+
+// isEnvName returns true, if the name of environment variable is not empty
+// and has not character '='.
+func isEnvName(name string) bool {
+	return name != "" && !strings.Contains(name, "=")
+}
+
+// Setenv handles setenv().
+//
+// Adds the variable name to the environment with the value value, if name
+// does not already exist. If name does exist in the environment, then its
+// value is changed to value if overwrite is nonzero; if overwrite is zero,
+// then the value of name is not changed. On success, returns 0. On error,
+// -1 is returned.
+func Setenv(name, value []byte, overwrite int) int {
+	key := CStringToString(name)
+	if !isEnvName(key) {
+		return -1
+	}
+	if _, ok := os.LookupEnv(key); ok && overwrite == 0 {
+		return 0
+	}
+	if err := os.Setenv(key, CStringToString(value)); err != nil {
+		return -1
+	}
+	delete(putenvStrings, key)
+	updateEnviron()
+	return 0
+}
+
+// Unsetenv handles unsetenv().
+//
+// Deletes the variable name from the environment. If name does not exist in
+// the environment, then the function succeeds, and the environment is
+// unchanged. On success, returns 0. On error, -1 is returned.
+func Unsetenv(name []byte) int {
+	key := CStringToString(name)
+	if !isEnvName(key) {
+		return -1
+	}
+	if err := os.Unsetenv(key); err != nil {
+		return -1
+	}
+	delete(putenvStrings, key)
+	updateEnviron()
+	return 0
+}
+
+// Putenv handles putenv().
+//
+// Adds or changes the value of environment variable. The argument is of the
+// form "name=value". The string becomes a part of Environ, but later
+// changes of string are not passed to the environment of process. If the
+// string has not character '=', then the variable is deleted from the
+// environment, as in glibc. On success, returns 0. On error, -1 is
+// returned.
+func Putenv(str []byte) int {
+	s := CStringToString(str)
+	i := strings.IndexByte(s, '=')
+	switch {
+	case i < 0:
+		return Unsetenv(str)
+	case i == 0:
+		return -1
+	}
+	if err := os.Setenv(s[:i], s[i+1:]); err != nil {
+		return -1
+	}
+	if bytes.IndexByte(str, 0) < 0 {
+		// string for Environ must be finished by null character
+		str = StringToCString(s)
+	}
+	putenvStrings[s[:i]] = str
+	updateEnviron()
+	return 0
+}
+
+// Clearenv handles clearenv().
+//
+// Clears the environment of all name-value pairs and sets the value of the
+// external variable environ to NULL, as in glibc. On success, returns 0.
+func Clearenv() int {
+	os.Clearenv()
+	putenvStrings = map[string][]byte{}
+	Environ = nil
+	return 0
+}
@@ -0,0 +1,97 @@
+package noarch
+
+import (
+	"os"
+	"sync"
+)
+
+// exitHandlers are functions registered by atexit() and at_quick_exit().
+// Handlers are called in the reverse order of registration.
+var exitHandlers = struct {
+	sync.Mutex
+	normal []func()
+	quick  []func()
+}{}
+
+// popExitHandler returns the last registered handler and removes it from
+// the list. Handlers registered by other handlers are called too.
+func popExitHandler(handlers *[]func()) func() {
+	exitHandlers.Lock()
+	defer exitHandlers.Unlock()
+	if len(*handlers) == 0 {
+		return nil
+	}
+	f := (*handlers)[len(*handlers)-1]
+	*handlers = (*handlers)[:len(*handlers)-1]
+	return f
+}
+
+// runExitHandlers calls handlers in the reverse order of registration.
+func runExitHandlers(handlers *[]func()) {
+	for {
+		f := popExitHandler(handlers)
+		if f == nil {
+			return
+		}
+		f()
+	}
+}
+
+// Atexit handles atexit().
+//
+// Registers the function to be called on normal program termination by
+// exit() or by return from main(). Functions are called in the reverse order
+// of registration. The same function may be registered several times.
+// Returns zero on success.
+func Atexit(f func()) int {
+	if f == nil {
+		return -1
+	}
+	exitHandlers.Lock()
+	defer exitHandlers.Unlock()
+	exitHandlers.normal = append(exitHandlers.normal, f)
+	return 0
+}
+
+// AtQuickExit handles at_quick_exit().
+//
+// Registers the function to be called by quick_exit(). Functions are called
+// in the reverse order of registration. Returns zero on success.
+func AtQuickExit(f func()) int {
+	if f == nil {
+		return -1
+	}
+	exitHandlers.Lock()
+	defer exitHandlers.Unlock()
+	exitHandlers.quick = append(exitHandlers.quick, f)
+	return 0
+}
+
+// Exit handles exit().
+//
+// Terminates the program normally: functions registered by atexit() are
+// called in the reverse order of registration, all open streams are
+// flushed and the status is returned to the host environment.
+func Exit(status int) {
+	runExitHandlers(&exitHandlers.normal)
+	Fflush(nil)
+	os.Exit(status)
+}
+
+// QuickExit handles quick_exit().
+//
+// Terminates the program: functions registered by at_quick_exit() are
+// called in the reverse order of registration, but functions registered by
+// atexit() are not called and streams are not flushed.
+func QuickExit(status int) {
+	runExitHandlers(&exitHandlers.quick)
+	os.Exit(status)
+}
+
+// ExitImmediately handles _exit() and _Exit().
+//
+// Terminates the program immediately: functions registered by atexit() and
+// at_quick_exit() are not called and streams are not flushed.
+func ExitImmediately(status int) {
+	os.Exit(status)
+}
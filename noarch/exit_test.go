@@ -0,0 +1,35 @@
+package noarch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExitHandlers(t *testing.T) {
+	var calls []string
+	handler := func(name string) func() {
+		return func() { calls = append(calls, name) }
+	}
+	Atexit(handler("first"))
+	Atexit(handler("second"))
+	Atexit(func() {
+		calls = append(calls, "register")
+		Atexit(handler("nested"))
+	})
+	AtQuickExit(handler("quick"))
+
+	runExitHandlers(&exitHandlers.normal)
+
+	want := "[register nested second first]"
+	if got := fmt.Sprint(calls); got != want {
+		t.Errorf("Order of handlers: %s, but want %s", got, want)
+	}
+	if len(exitHandlers.quick) != 1 {
+		t.Errorf("Handlers of quick_exit() must not be called")
+	}
+	exitHandlers.quick = nil
+
+	if Atexit(nil) == 0 {
+		t.Errorf("Registration of NULL is not an error")
+	}
+}
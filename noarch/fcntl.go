@@ -0,0 +1,40 @@
+package noarch
+
+import (
+	"os"
+)
+
+// Open handles open().
+//
+// Opens the file specified by pathname. The argument flags must include one
+// of the following access modes: O_RDONLY, O_WRONLY, or O_RDWR. These
+// request opening the file read-only, write-only, or read/write,
+// respectively. If the flag O_CREAT is used, then the file mode bits of a
+// new file are taken from the next argument.
+//
+// The return value of open() is a file descriptor, a small, nonnegative
+// integer. On error, -1 is returned.
+//
+// Values of flags are the same as in the package syscall.
+func Open(pathname []byte, flags int, args ...interface{}) int {
+	var perm os.FileMode
+	if len(args) > 0 {
+		switch v := args[0].(type) {
+		case int:
+			perm = os.FileMode(v)
+		case int32:
+			perm = os.FileMode(v)
+		case int64:
+			perm = os.FileMode(v)
+		case uint32:
+			perm = os.FileMode(v)
+		case uint64:
+			perm = os.FileMode(v)
+		}
+	}
+	f, err := os.OpenFile(CStringToString(pathname), flags, perm)
+	if err != nil {
+		return -1
+	}
+	return newFileDescriptor(f)
+}
@@ -0,0 +1,182 @@
+package noarch
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Rounding modes of fenv.h with the same values as in glibc for x86.
+const (
+	FeTonearest  = 0x000
+	FeDownward   = 0x400
+	FeUpward     = 0x800
+	FeTowardzero = 0xc00
+)
+
+// Floating-point exceptions of fenv.h with the same values as in glibc for
+// x86.
+const (
+	FeInvalid   = 0x01
+	FeDivbyzero = 0x04
+	FeOverflow  = 0x08
+	FeUnderflow = 0x10
+	FeInexact   = 0x20
+	FeAllExcept = FeInvalid | FeDivbyzero | FeOverflow | FeUnderflow | FeInexact
+)
+
+// FexceptT is the representation of floating-point exception flags.
+type FexceptT uint16
+
+// FenvT is the floating-point environment: the rounding mode and the
+// exception flags.
+type FenvT struct {
+	Round  int32
+	Except int32
+}
+
+// Go cannot change the rounding mode of hardware and cannot read the
+// exception flags of hardware, so the floating-point environment is emulated.
+// The rounding mode is used by functions rint(), nearbyint(), lrint() and
+// llrint(). The exception flags are raised by these functions, by lround(),
+// llround() and by feraiseexcept(), but not by arithmetic operations of Go.
+// The environment is shared by all threads.
+var fenvRound, fenvExcept int32
+
+// raiseExcept raises the floating-point exception flags.
+func raiseExcept(excepts int32) {
+	for {
+		old := atomic.LoadInt32(&fenvExcept)
+		if atomic.CompareAndSwapInt32(&fenvExcept, old, old|excepts&FeAllExcept) {
+			return
+		}
+	}
+}
+
+// setExcept changes the floating-point exception flags excepts to values.
+func setExcept(excepts, values int32) {
+	excepts &= FeAllExcept
+	for {
+		old := atomic.LoadInt32(&fenvExcept)
+		if atomic.CompareAndSwapInt32(&fenvExcept, old, old&^excepts|values&excepts) {
+			return
+		}
+	}
+}
+
+// roundInCurrentMode returns the integral value of x in the current rounding
+// mode.
+func roundInCurrentMode(x float64) float64 {
+	switch atomic.LoadInt32(&fenvRound) {
+	case FeDownward:
+		return math.Floor(x)
+	case FeUpward:
+		return math.Ceil(x)
+	case FeTowardzero:
+		return math.Trunc(x)
+	}
+	return math.RoundToEven(x)
+}
+
+// Feclearexcept handles feclearexcept().
+//
+// Clears the floating-point exception flags excepts. Returns zero.
+func Feclearexcept(excepts int) int {
+	setExcept(int32(excepts), 0)
+	return 0
+}
+
+// Feraiseexcept handles feraiseexcept().
+//
+// Raises the floating-point exception flags excepts. Returns zero.
+func Feraiseexcept(excepts int) int {
+	raiseExcept(int32(excepts))
+	return 0
+}
+
+// Fetestexcept handles fetestexcept().
+//
+// Returns the floating-point exception flags excepts, which are raised.
+func Fetestexcept(excepts int) int {
+	return int(atomic.LoadInt32(&fenvExcept) & int32(excepts) & FeAllExcept)
+}
+
+// Fegetexceptflag handles fegetexceptflag().
+//
+// Stores the state of floating-point exception flags excepts in flagp.
+// Returns zero.
+func Fegetexceptflag(flagp []FexceptT, excepts int) int {
+	flagp[0] = FexceptT(atomic.LoadInt32(&fenvExcept) & int32(excepts) & FeAllExcept)
+	return 0
+}
+
+// Fesetexceptflag handles fesetexceptflag().
+//
+// Sets the floating-point exception flags excepts to the state stored in
+// flagp by fegetexceptflag(). Returns zero.
+func Fesetexceptflag(flagp []FexceptT, excepts int) int {
+	setExcept(int32(excepts), int32(flagp[0]))
+	return 0
+}
+
+// Fegetround handles fegetround().
+//
+// Returns the current rounding mode.
+func Fegetround() int {
+	return int(atomic.LoadInt32(&fenvRound))
+}
+
+// Fesetround handles fesetround().
+//
+// Sets the rounding mode. Returns zero on success or non-zero value, if the
+// rounding mode is not valid.
+func Fesetround(round int) int {
+	switch round {
+	case FeTonearest, FeDownward, FeUpward, FeTowardzero:
+		atomic.StoreInt32(&fenvRound, int32(round))
+		return 0
+	}
+	return 1
+}
+
+// Fegetenv handles fegetenv().
+//
+// Stores the current floating-point environment in envp. Returns zero.
+func Fegetenv(envp []FenvT) int {
+	envp[0] = FenvT{
+		Round:  atomic.LoadInt32(&fenvRound),
+		Except: atomic.LoadInt32(&fenvExcept),
+	}
+	return 0
+}
+
+// Fesetenv handles fesetenv().
+//
+// Sets the floating-point environment stored in envp by fegetenv() or
+// feholdexcept(). Returns zero.
+func Fesetenv(envp []FenvT) int {
+	atomic.StoreInt32(&fenvRound, envp[0].Round)
+	atomic.StoreInt32(&fenvExcept, envp[0].Except&FeAllExcept)
+	return 0
+}
+
+// Feholdexcept handles feholdexcept().
+//
+// Stores the current floating-point environment in envp and clears the
+// exception flags. Floating-point exceptions never trap in Go, so the
+// environment is always in the non-stop mode. Returns zero.
+func Feholdexcept(envp []FenvT) int {
+	Fegetenv(envp)
+	atomic.StoreInt32(&fenvExcept, 0)
+	return 0
+}
+
+// Feupdateenv handles feupdateenv().
+//
+// Sets the floating-point environment stored in envp and raises the
+// exception flags, which are raised before the call. Returns zero.
+func Feupdateenv(envp []FenvT) int {
+	excepts := atomic.LoadInt32(&fenvExcept)
+	Fesetenv(envp)
+	raiseExcept(excepts)
+	return 0
+}
@@ -0,0 +1,84 @@
+package noarch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFenvRound(t *testing.T) {
+	defer Fesetround(FeTonearest)
+
+	tcs := []struct {
+		round    int
+		x        float64
+		expected float64
+	}{
+		{FeTonearest, 2.5, 2},
+		{FeTonearest, -3.5, -4},
+		{FeDownward, 2.7, 2},
+		{FeDownward, -2.2, -3},
+		{FeUpward, 2.2, 3},
+		{FeUpward, -2.7, -2},
+		{FeTowardzero, 2.7, 2},
+		{FeTowardzero, -2.7, -2},
+	}
+	for _, tc := range tcs {
+		if Fesetround(tc.round) != 0 {
+			t.Fatalf("Cannot set rounding mode %x", tc.round)
+		}
+		if r := Rint(tc.x); r != tc.expected {
+			t.Errorf("Rint(%v) in mode %x = %v, but want %v",
+				tc.x, tc.round, r, tc.expected)
+		}
+		if r := Llrint(tc.x); r != int64(tc.expected) {
+			t.Errorf("Llrint(%v) in mode %x = %v, but want %v",
+				tc.x, tc.round, r, tc.expected)
+		}
+	}
+
+	if Fesetround(1) == 0 {
+		t.Errorf("Not valid rounding mode is accepted")
+	}
+	if Fegetround() != FeTowardzero {
+		t.Errorf("Rounding mode is changed by not valid value")
+	}
+}
+
+func TestFenvExcept(t *testing.T) {
+	defer Feclearexcept(FeAllExcept)
+
+	Feclearexcept(FeAllExcept)
+	Rint(3)
+	Nearbyint(3.5)
+	if f := Fetestexcept(FeAllExcept); f != 0 {
+		t.Errorf("Exceptions %x are raised for exact result", f)
+	}
+	Rint(3.5)
+	if f := Fetestexcept(FeAllExcept); f != FeInexact {
+		t.Errorf("Exceptions %x, but want FE_INEXACT", f)
+	}
+	Llround(math.NaN())
+	if Fetestexcept(FeInvalid) == 0 {
+		t.Errorf("FE_INVALID is not raised for NaN")
+	}
+
+	flags := make([]FexceptT, 1)
+	Fegetexceptflag(flags, FeInvalid|FeOverflow)
+	Feclearexcept(FeAllExcept)
+	Feraiseexcept(FeDivbyzero)
+	Fesetexceptflag(flags, FeAllExcept)
+	if f := Fetestexcept(FeAllExcept); f != FeInvalid {
+		t.Errorf("Exceptions %x, but want FE_INVALID", f)
+	}
+
+	env := make([]FenvT, 1)
+	Feholdexcept(env)
+	if f := Fetestexcept(FeAllExcept); f != 0 {
+		t.Errorf("Exceptions %x are not cleared by feholdexcept()", f)
+	}
+	Feraiseexcept(FeUnderflow)
+	Feupdateenv(env)
+	if f := Fetestexcept(FeAllExcept); f != FeInvalid|FeUnderflow {
+		t.Errorf("Exceptions %x are not merged by feupdateenv()", f)
+	}
+}
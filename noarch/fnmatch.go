@@ -0,0 +1,195 @@
+package noarch
+
+import "unicode"
+
+// Values of fnmatch.h constants
+const (
+	fnmPathname   = 1 << 0
+	fnmNoescape   = 1 << 1
+	fnmPeriod     = 1 << 2
+	fnmLeadingDir = 1 << 3
+	fnmCasefold   = 1 << 4
+
+	fnmNomatch = 1
+)
+
+// Fnmatch handles fnmatch().
+//
+// Checks whether the string argument matches the pattern argument, which is
+// a shell wildcard pattern. Flags:
+//
+//     FNM_PATHNAME    - wildcards do not match slash in string
+//     FNM_NOESCAPE    - backslash is an ordinary character
+//     FNM_PERIOD      - leading period in string must be matched by period
+//     FNM_LEADING_DIR - pattern may match an initial segment of string,
+//                       which is followed by slash
+//     FNM_CASEFOLD    - case of letters is ignored
+//
+// Returns zero, if string matches pattern, otherwise FNM_NOMATCH.
+func Fnmatch(pattern, str []byte, flags int) int {
+	if fnmatch(pattern[:Strlen(pattern)], str[:Strlen(str)], flags, true) {
+		return 0
+	}
+	return fnmNomatch
+}
+
+// fnmatch returns true, if the string matches the pattern. Argument start
+// is true at the beginning of string or after slash with FNM_PATHNAME.
+func fnmatch(pattern, str []byte, flags int, start bool) bool {
+	// leading period of string must be matched explicitly
+	leadingPeriod := func(s []byte, start bool) bool {
+		return flags&fnmPeriod != 0 && start && len(s) > 0 && s[0] == '.'
+	}
+	for len(pattern) > 0 {
+		switch c := pattern[0]; c {
+		case '?':
+			if len(str) == 0 || leadingPeriod(str, start) ||
+				(flags&fnmPathname != 0 && str[0] == '/') {
+				return false
+			}
+			pattern, str = pattern[1:], str[1:]
+			start = false
+
+		case '*':
+			for len(pattern) > 0 && pattern[0] == '*' {
+				pattern = pattern[1:]
+			}
+			if leadingPeriod(str, start) {
+				return false
+			}
+			for i := 0; i <= len(str); i++ {
+				if fnmatch(pattern, str[i:], flags, i == 0 && start) {
+					return true
+				}
+				if i < len(str) && flags&fnmPathname != 0 && str[i] == '/' {
+					break
+				}
+			}
+			return false
+
+		case '[':
+			if len(str) == 0 || leadingPeriod(str, start) ||
+				(flags&fnmPathname != 0 && str[0] == '/') {
+				return false
+			}
+			matched, size, ok := matchBracket(pattern, str[0], flags)
+			if !ok {
+				// not closed bracket is an ordinary character
+				if !equalFold(c, str[0], flags) {
+					return false
+				}
+				pattern, str = pattern[1:], str[1:]
+				start = false
+				continue
+			}
+			if !matched {
+				return false
+			}
+			pattern, str = pattern[size:], str[1:]
+			start = false
+
+		default:
+			if c == '\\' && flags&fnmNoescape == 0 && len(pattern) > 1 {
+				pattern = pattern[1:]
+				c = pattern[0]
+			}
+			if len(str) == 0 || !equalFold(c, str[0], flags) {
+				return false
+			}
+			pattern, str = pattern[1:], str[1:]
+			start = c == '/' && flags&fnmPathname != 0
+		}
+	}
+	if len(str) > 0 && flags&fnmLeadingDir != 0 && str[0] == '/' {
+		return true
+	}
+	return len(str) == 0
+}
+
+// equalFold compares characters with flag FNM_CASEFOLD.
+func equalFold(a, b byte, flags int) bool {
+	if flags&fnmCasefold != 0 {
+		return unicode.ToLower(rune(a)) == unicode.ToLower(rune(b))
+	}
+	return a == b
+}
+
+// fnmClasses - character classes of bracket expressions, like [[:alpha:]]
+var fnmClasses = map[string]func(rune) bool{
+	"alnum": func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) },
+	"alpha": unicode.IsLetter,
+	"blank": func(r rune) bool { return r == ' ' || r == '\t' },
+	"cntrl": unicode.IsControl,
+	"digit": unicode.IsDigit,
+	"graph": func(r rune) bool { return unicode.IsGraphic(r) && r != ' ' },
+	"lower": unicode.IsLower,
+	"print": unicode.IsPrint,
+	"punct": unicode.IsPunct,
+	"space": unicode.IsSpace,
+	"upper": unicode.IsUpper,
+	"xdigit": func(r rune) bool {
+		return unicode.IsDigit(r) || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')
+	},
+}
+
+// matchBracket matches the character with bracket expression at the
+// beginning of pattern, like "[a-z]", "[!0-9]" or "[[:alpha:]_]". Returns the
+// size of bracket expression. If the bracket is not closed, then ok is
+// false.
+func matchBracket(pattern []byte, c byte, flags int) (matched bool, size int, ok bool) {
+	i := 1
+	negate := i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^')
+	if negate {
+		i++
+	}
+	lower := func(b byte) rune {
+		if flags&fnmCasefold != 0 {
+			return unicode.ToLower(rune(b))
+		}
+		return rune(b)
+	}
+	r := lower(c)
+	for first := true; i < len(pattern); first = false {
+		if pattern[i] == ']' && !first {
+			return matched != negate, i + 1, true
+		}
+		// character class
+		if pattern[i] == '[' && i+1 < len(pattern) && pattern[i+1] == ':' {
+			end := -1
+			for j := i + 2; j+1 < len(pattern); j++ {
+				if pattern[j] == ':' && pattern[j+1] == ']' {
+					end = j
+					break
+				}
+			}
+			if end > 0 {
+				if class, found := fnmClasses[string(pattern[i+2:end])]; found &&
+					(class(rune(c)) || (flags&fnmCasefold != 0 &&
+						(class(unicode.ToLower(rune(c))) || class(unicode.ToUpper(rune(c)))))) {
+					matched = true
+				}
+				i = end + 2
+				continue
+			}
+		}
+		lo := pattern[i]
+		if lo == '\\' && flags&fnmNoescape == 0 && i+1 < len(pattern) {
+			i++
+			lo = pattern[i]
+		}
+		i++
+		hi := lo
+		if i+1 < len(pattern) && pattern[i] == '-' && pattern[i+1] != ']' {
+			hi = pattern[i+1]
+			i += 2
+			if hi == '\\' && flags&fnmNoescape == 0 && i < len(pattern) {
+				hi = pattern[i]
+				i++
+			}
+		}
+		if lower(lo) <= r && r <= lower(hi) {
+			matched = true
+		}
+	}
+	return false, 0, false
+}
@@ -0,0 +1,183 @@
+package noarch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFnmatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		str     string
+		flags   int
+		want    int
+	}{
+		{"*.c", "main.c", 0, 0},
+		{"*.c", "main.h", 0, fnmNomatch},
+		{"m??n.c", "main.c", 0, 0},
+		{"*", "", 0, 0},
+		{"?", "", 0, fnmNomatch},
+		{"[a-c]x", "bx", 0, 0},
+		{"[!a-c]x", "bx", 0, fnmNomatch},
+		{"[^a-c]x", "dx", 0, 0},
+		{"[]]", "]", 0, 0},
+		{"[!]]", "a", 0, 0},
+		{"[[:digit:]_]*", "7z", 0, 0},
+		{"[[:upper:]]", "a", 0, fnmNomatch},
+		{"[[:upper:]]", "a", fnmCasefold, 0},
+		{"[ab", "[ab", 0, 0},
+		{"*/*.c", "src/main.c", 0, 0},
+		{"*.c", "src/main.c", 0, 0},
+		{"*.c", "src/main.c", fnmPathname, fnmNomatch},
+		{"src/?ain.c", "src/main.c", fnmPathname, 0},
+		{"src?main.c", "src/main.c", fnmPathname, fnmNomatch},
+		{"src[/]main.c", "src/main.c", fnmPathname, fnmNomatch},
+		{"\\*", "*", 0, 0},
+		{"\\*", "a", 0, fnmNomatch},
+		{"\\*", "*", fnmNoescape, fnmNomatch},
+		{"\\*", "\\abc", fnmNoescape, 0},
+		{"[\\]]", "]", 0, 0},
+		{"MAIN.C", "main.c", 0, fnmNomatch},
+		{"MAIN.C", "main.c", fnmCasefold, 0},
+		{"[A-Z]*", "main", fnmCasefold, 0},
+		{"*", ".hidden", 0, 0},
+		{"*", ".hidden", fnmPeriod, fnmNomatch},
+		{".*", ".hidden", fnmPeriod, 0},
+		{"?hidden", ".hidden", fnmPeriod, fnmNomatch},
+		{"src/*", "src/.hidden", fnmPeriod | fnmPathname, fnmNomatch},
+		{"src/*", "src/.hidden", fnmPeriod, 0},
+		{"src", "src/main.c", fnmLeadingDir, 0},
+		{"s*", "src/main.c", fnmLeadingDir | fnmPathname, 0},
+	}
+	for _, tt := range tests {
+		got := Fnmatch([]byte(tt.pattern+"\x00"), []byte(tt.str+"\x00"), tt.flags)
+		if got != tt.want {
+			t.Errorf("Fnmatch(%q, %q, %d) = %d, want %d",
+				tt.pattern, tt.str, tt.flags, got, tt.want)
+		}
+	}
+}
+
+func TestGlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-glob-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{
+		"a.c", "b.c", "c.h", ".hidden.c", "src/d.c", "src/e.h", "lib/f.c",
+	} {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := func(g GlobT) string {
+		var names []string
+		for i := 0; i < len(g.GlPathv)-1; i++ {
+			if g.GlPathv[i] == nil {
+				names = append(names, "NULL")
+				continue
+			}
+			name := string(g.GlPathv[i][:Strlen(g.GlPathv[i])])
+			names = append(names, strings.TrimPrefix(name, dir+"/"))
+		}
+		if g.GlPathv[len(g.GlPathv)-1] != nil {
+			t.Errorf("list of paths is not terminated by nil")
+		}
+		return strings.Join(names, " ")
+	}
+
+	tests := []struct {
+		pattern string
+		flags   int
+		want    string
+		result  int
+	}{
+		{"*.c", 0, "a.c b.c", 0},
+		{"*.c", globPeriod, ".hidden.c a.c b.c", 0},
+		{"*/*.c", 0, "lib/f.c src/d.c", 0},
+		{"*", globMark, "a.c b.c c.h lib/ src/", 0},
+		{"*", globOnlydir, "lib src", 0},
+		{"*/", 0, "lib/ src/", 0},
+		{"src/[de].?", 0, "src/d.c src/e.h", 0},
+		{"src/d.c", 0, "src/d.c", 0},
+		{"*.go", 0, "", globNomatch},
+		{"*.go", globNocheck, "*.go", 0},
+		{"none/*.c", 0, "", globNomatch},
+	}
+	for _, tt := range tests {
+		g := make([]GlobT, 1)
+		pattern := filepath.Join(dir, tt.pattern)
+		if strings.HasSuffix(tt.pattern, "/") {
+			pattern += "/"
+		}
+		result := Glob([]byte(pattern+"\x00"), tt.flags, nil, g)
+		if result != tt.result {
+			t.Errorf("Glob(%q) = %d, want %d", tt.pattern, result, tt.result)
+			continue
+		}
+		if result != 0 {
+			if g[0].GlPathc != 0 || g[0].GlPathv != nil {
+				t.Errorf("Glob(%q): paths are not empty", tt.pattern)
+			}
+			continue
+		}
+		got := paths(g[0])
+		if tt.flags&globNocheck != 0 {
+			got = strings.TrimPrefix(got, dir+"/")
+		}
+		if got != tt.want {
+			t.Errorf("Glob(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+		if int(g[0].GlPathc) != len(strings.Fields(tt.want)) {
+			t.Errorf("Glob(%q): gl_pathc = %d", tt.pattern, g[0].GlPathc)
+		}
+	}
+
+	t.Run("append and offs", func(t *testing.T) {
+		g := make([]GlobT, 1)
+		g[0].GlOffs = 2
+		Glob([]byte(filepath.Join(dir, "*.h")+"\x00"), globDooffs, nil, g)
+		Glob([]byte(filepath.Join(dir, "src/*.c")+"\x00"),
+			globDooffs|globAppend, nil, g)
+		if got := paths(g[0]); got != "NULL NULL c.h src/d.c" {
+			t.Errorf("paths = %q", got)
+		}
+		if g[0].GlPathc != 2 {
+			t.Errorf("gl_pathc = %d", g[0].GlPathc)
+		}
+		Globfree(g)
+		if g[0].GlPathc != 0 || g[0].GlPathv != nil {
+			t.Errorf("paths are not freed")
+		}
+	})
+
+	t.Run("errfunc", func(t *testing.T) {
+		if os.Getuid() == 0 {
+			t.Skip("directory is readable by root")
+		}
+		locked := filepath.Join(dir, "locked")
+		if err := os.Mkdir(locked, 0); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chmod(locked, 0755)
+		var calls int
+		errfunc := func(path []byte, errno int) int {
+			calls++
+			return 1
+		}
+		g := make([]GlobT, 1)
+		result := Glob([]byte(filepath.Join(locked, "*")+"\x00"), 0, errfunc, g)
+		if result != globAborted || calls != 1 {
+			t.Errorf("result = %d, calls = %d", result, calls)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package noarch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Values of ftw.h constants
+const (
+	// type flags of callback
+	ftwF   = 0
+	ftwD   = 1
+	ftwDNR = 2
+	ftwNS  = 3
+	ftwSL  = 4
+	ftwDP  = 5
+	ftwSLN = 6
+
+	// flags of nftw
+	ftwPhys  = 1 << 0
+	ftwMount = 1 << 1
+	ftwChdir = 1 << 2
+	ftwDepth = 1 << 3
+)
+
+// FTW represents the C structure "struct FTW" from ftw.h:
+//
+//     struct FTW {
+//         int base;
+//         int level;
+//     };
+//
+// Base is the offset of the file name in the path and Level is the depth of
+// path relative to the root of tree.
+type FTW struct {
+	Base  int
+	Level int
+}
+
+// Ftw handles ftw().
+//
+// Walks through the directory tree located under the directory dirpath and
+// calls fn once for each entry in the tree. Directories are reported before
+// their contents, symbolic links are followed. The walk is stopped, if fn
+// returns a nonzero value, and this value is returned. Argument nopenfd is
+// ignored. Returns zero after the walk of tree or -1 on error.
+func Ftw(dirpath []byte, fn func([]byte, []StatT, int) int, nopenfd int) int {
+	return Nftw(dirpath, func(path []byte, sb []StatT, typeflag int, _ []FTW) int {
+		if typeflag == ftwSLN {
+			typeflag = ftwNS
+		}
+		return fn(path, sb, typeflag)
+	}, nopenfd, 0)
+}
+
+// Nftw handles nftw().
+//
+// Works like ftw(), but fn gets the additional structure FTW and the walk
+// is controlled by flags:
+//
+//     FTW_PHYS  - symbolic links are not followed and reported as FTW_SL
+//     FTW_MOUNT - walk stays within the same file system
+//     FTW_DEPTH - directories are reported after their contents as FTW_DP
+//
+// Flag FTW_CHDIR is ignored.
+func Nftw(dirpath []byte, fn func([]byte, []StatT, int, []FTW) int,
+	nopenfd int, flags int) int {
+	root := CStringToString(dirpath)
+	info, typeflag := ftwStat(root, flags)
+	if typeflag == ftwNS || (flags&ftwPhys == 0 && typeflag == ftwSLN) {
+		return -1
+	}
+	w := &ftwWalker{
+		fn:      fn,
+		flags:   flags,
+		dev:     sysUint(info, "Dev"),
+		visited: map[[2]uint64]bool{},
+	}
+	if err := w.walk(root, 0); err != nil {
+		if r, ok := err.(ftwResult); ok {
+			return int(r)
+		}
+		return -1
+	}
+	return 0
+}
+
+// ftwResult is the nonzero value returned by callback, which stops the walk.
+type ftwResult int
+
+func (r ftwResult) Error() string {
+	return "walk is stopped by callback"
+}
+
+// ftwWalker - state of walk through the directory tree for nftw().
+type ftwWalker struct {
+	fn      func([]byte, []StatT, int, []FTW) int
+	flags   int
+	dev     uint64
+	visited map[[2]uint64]bool
+}
+
+// ftwStat returns the information about file and the type flag of callback.
+func ftwStat(path string, flags int) (os.FileInfo, int) {
+	if flags&ftwPhys == 0 {
+		if info, err := os.Stat(path); err == nil {
+			if info.IsDir() {
+				return info, ftwD
+			}
+			return info, ftwF
+		}
+	}
+	info, err := os.Lstat(path)
+	switch {
+	case err != nil:
+		return nil, ftwNS
+	case info.Mode()&os.ModeSymlink != 0:
+		if flags&ftwPhys == 0 {
+			// stat() of symbolic link is failed
+			return info, ftwSLN
+		}
+		return info, ftwSL
+	case info.IsDir():
+		return info, ftwD
+	}
+	return info, ftwF
+}
+
+// call calls the callback for the path.
+func (w *ftwWalker) call(path string, info os.FileInfo, typeflag, level int) error {
+	var sb StatT
+	if info != nil {
+		sb = fileInfoToStat(info)
+	}
+	base := strings.LastIndex(strings.TrimRight(path, "/"), "/") + 1
+	r := w.fn([]byte(path+"\x00"), []StatT{sb}, typeflag,
+		[]FTW{{Base: base, Level: level}})
+	if r != 0 {
+		return ftwResult(r)
+	}
+	return nil
+}
+
+// walk walks through the tree with the root at the path on the level.
+// Symbolic links to directories are walked by recursive calls.
+func (w *ftwWalker) walk(root string, level int) error {
+	// directories, which are waiting for the report with FTW_DEPTH
+	type pending struct {
+		path  string
+		info  os.FileInfo
+		level int
+	}
+	var stack []pending
+	flush := func(path string) error {
+		for len(stack) > 0 {
+			last := stack[len(stack)-1]
+			if path != "" && strings.HasPrefix(path, strings.TrimRight(last.path, "/")+"/") {
+				break
+			}
+			stack = stack[:len(stack)-1]
+			if err := w.call(last.path, last.info, ftwDP, last.level); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := root
+	if info, err := os.Lstat(root); err == nil &&
+		info.Mode()&os.ModeSymlink != 0 && w.flags&ftwPhys == 0 {
+		// WalkDir follows the link only with slash at the end
+		start = root + "/"
+	}
+	err := filepath.WalkDir(start, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			// directory cannot be read
+			return filepath.SkipDir
+		}
+		// paths are reported with the root as is, like in C
+		l := level
+		if rel, _ := filepath.Rel(start, path); rel != "." {
+			path = strings.TrimRight(root, "/") + "/" + rel
+			l += strings.Count(rel, "/") + 1
+		} else {
+			path = root
+		}
+		if err := flush(path); err != nil {
+			return err
+		}
+
+		info, typeflag := ftwStat(path, w.flags)
+		if info != nil && w.flags&ftwMount != 0 && sysUint(info, "Dev") != w.dev {
+			return ftwSkip(d)
+		}
+		if typeflag != ftwD {
+			return w.call(path, info, typeflag, l)
+		}
+
+		if !d.IsDir() {
+			// symbolic link to directory
+			return w.walk(path, l)
+		}
+
+		// each directory is walked once
+		id := [2]uint64{sysUint(info, "Dev"), inode(info)}
+		if w.visited[id] {
+			return ftwSkip(d)
+		}
+		w.visited[id] = true
+
+		f, err := os.Open(path)
+		if err != nil {
+			if err := w.call(path, info, ftwDNR, l); err != nil {
+				return err
+			}
+			return ftwSkip(d)
+		}
+		f.Close()
+
+		if w.flags&ftwDepth != 0 {
+			stack = append(stack, pending{path: path, info: info, level: l})
+			return nil
+		}
+		return w.call(path, info, ftwD, l)
+	})
+	if err != nil {
+		return err
+	}
+	return flush("")
+}
+
+// ftwSkip returns the value of WalkDir callback for skipping of entry.
+func ftwSkip(d os.DirEntry) error {
+	if d.IsDir() {
+		return filepath.SkipDir
+	}
+	return nil
+}
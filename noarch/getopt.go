@@ -0,0 +1,409 @@
+package noarch
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// Optarg - pointer to the argument of the last found option. It is the
+// C variable "optarg" from unistd.h.
+var Optarg []byte
+
+// Optind - index of the next element of argv to be processed. It is the
+// C variable "optind" from unistd.h. Setting Optind to 0 resets the state
+// of the parser.
+var Optind = 1
+
+// Opterr - if it is not zero, then Getopt prints the error messages to
+// stderr. It is the C variable "opterr" from unistd.h.
+var Opterr = 1
+
+// Optopt - the option character which caused the error. It is the C
+// variable "optopt" from unistd.h.
+var Optopt = int('?')
+
+// Option represents the C structure "struct option" from getopt.h:
+//
+//     struct option {
+//         const char *name;
+//         int         has_arg;
+//         int        *flag;
+//         int         val;
+//     };
+//
+// The last element of the array of options must be filled with zeros.
+type Option struct {
+	Name   []byte
+	HasArg int
+	Flag   []int
+	Val    int
+}
+
+// Values of Option.HasArg. Optional argument has value 2.
+const (
+	noArgument       = 0
+	requiredArgument = 1
+)
+
+// getoptState is internal state of parser between calls of Getopt.
+type getoptState struct {
+	initialized bool
+
+	// The rest of the current element of argv with short options.
+	nextchar []byte
+
+	// Indexes of the skipped non-option elements of argv. They are moved to
+	// the end of argv in GNU mode.
+	firstNonopt int
+	lastNonopt  int
+}
+
+var getopt getoptState
+
+// Getopt handles getopt().
+//
+// Parses the command-line arguments. An element of argv that starts with '-'
+// (and is not exactly "-" or "--") is an option element. The characters of
+// this element (aside from the initial '-') are option characters. If
+// Getopt() is called repeatedly, it returns successively each of the option
+// characters from each of the option elements.
+//
+// If there are no more option characters, Getopt() returns -1. Then Optind
+// is the index in argv of the first element that is not an option.
+func Getopt(argc int, argv [][]byte, optstring []byte) int {
+	return getoptInternal(argc, argv, optstring, nil, nil, false)
+}
+
+// GetoptLong handles getopt_long().
+//
+// Works like Getopt() except that it also accepts long options, started with
+// two dashes. Long option names may be abbreviated if the abbreviation is
+// unique or is an exact match for some defined option. A long option may
+// take a parameter, of the form --arg=param or --arg param.
+func GetoptLong(argc int, argv [][]byte, optstring []byte,
+	longopts []Option, longindex []int) int {
+	return getoptInternal(argc, argv, optstring, longopts, longindex, false)
+}
+
+// GetoptLongOnly handles getopt_long_only().
+//
+// Works like GetoptLong(), but '-' as well as "--" can indicate a long
+// option. If an option that starts with '-' (not "--") doesn't match a long
+// option, but does match a short option, it is parsed as a short option
+// instead.
+func GetoptLongOnly(argc int, argv [][]byte, optstring []byte,
+	longopts []Option, longindex []int) int {
+	return getoptInternal(argc, argv, optstring, longopts, longindex, true)
+}
+
+// cStringBytes returns the bytes of C string without the null character.
+func cStringBytes(s []byte) []byte {
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// sameIntPointer returns true if both C pointers point to the same value.
+func sameIntPointer(a, b []int) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	return &a[0] == &b[0]
+}
+
+func getoptError(argv [][]byte, format string, a ...interface{}) {
+	var program string
+	if len(argv) > 0 {
+		program = string(cStringBytes(argv[0]))
+	}
+	fmt.Fprintf(Stderr.OsFile, "%s: "+format, append([]interface{}{program}, a...)...)
+}
+
+// exchange moves the skipped non-option elements of argv after the
+// already processed options.
+func (g *getoptState) exchange(argv [][]byte) {
+	bottom, middle, top := g.firstNonopt, g.lastNonopt, Optind
+	rotated := make([][]byte, 0, top-bottom)
+	rotated = append(rotated, argv[middle:top]...)
+	rotated = append(rotated, argv[bottom:middle]...)
+	copy(argv[bottom:top], rotated)
+
+	g.firstNonopt += top - middle
+	g.lastNonopt = top
+}
+
+func getoptInternal(argc int, argv [][]byte, optstring []byte,
+	longopts []Option, longindex []int, longOnly bool) int {
+
+	if argc > len(argv) {
+		argc = len(argv)
+	}
+	optstring = cStringBytes(optstring)
+
+	// ordering of arguments
+	const (
+		permute = iota
+		requireOrder
+		returnInOrder
+	)
+	ordering := permute
+	if len(optstring) > 0 && optstring[0] == '-' {
+		ordering = returnInOrder
+		optstring = optstring[1:]
+	} else if len(optstring) > 0 && optstring[0] == '+' {
+		ordering = requireOrder
+		optstring = optstring[1:]
+	} else if _, ok := os.LookupEnv("POSIXLY_CORRECT"); ok {
+		ordering = requireOrder
+	}
+	printErrors := Opterr != 0
+	colon := len(optstring) > 0 && optstring[0] == ':'
+	if colon {
+		printErrors = false
+	}
+
+	g := &getopt
+	Optarg = nil
+
+	if Optind == 0 || !g.initialized {
+		if Optind == 0 {
+			Optind = 1
+		}
+		g.firstNonopt = Optind
+		g.lastNonopt = Optind
+		g.nextchar = nil
+		g.initialized = true
+	}
+
+	isNonoption := func(arg []byte) bool {
+		arg = cStringBytes(arg)
+		return len(arg) < 2 || arg[0] != '-'
+	}
+
+	if len(g.nextchar) == 0 {
+		if g.lastNonopt > Optind {
+			g.lastNonopt = Optind
+		}
+		if g.firstNonopt > Optind {
+			g.firstNonopt = Optind
+		}
+
+		if ordering == permute {
+			if g.firstNonopt != g.lastNonopt && g.lastNonopt != Optind {
+				g.exchange(argv)
+			} else if g.lastNonopt != Optind {
+				g.firstNonopt = Optind
+			}
+			for Optind < argc && isNonoption(argv[Optind]) {
+				Optind++
+			}
+			g.lastNonopt = Optind
+		}
+
+		// The special element "--" means premature end of options.
+		if Optind != argc && string(cStringBytes(argv[Optind])) == "--" {
+			Optind++
+			if g.firstNonopt != g.lastNonopt && g.lastNonopt != Optind {
+				g.exchange(argv)
+			} else if g.firstNonopt == g.lastNonopt {
+				g.firstNonopt = Optind
+			}
+			g.lastNonopt = argc
+			Optind = argc
+		}
+
+		if Optind == argc {
+			// Set the next element to scan to the first skipped
+			// non-option element.
+			if g.firstNonopt != g.lastNonopt {
+				Optind = g.firstNonopt
+			}
+			return -1
+		}
+
+		if isNonoption(argv[Optind]) {
+			if ordering == requireOrder {
+				return -1
+			}
+			Optarg = argv[Optind]
+			Optind++
+			return 1
+		}
+
+		arg := cStringBytes(argv[Optind])
+		if longopts != nil && (arg[1] == '-' || (longOnly &&
+			(len(arg) > 2 || bytes.IndexByte(optstring, arg[1]) < 0))) {
+			if result, ok := g.processLong(argc, argv, optstring,
+				longopts, longindex, longOnly, printErrors, colon); ok {
+				return result
+			}
+		}
+
+		g.nextchar = argv[Optind][1:]
+	}
+
+	// Look at and handle the next short option character.
+	c := g.nextchar[0]
+	g.nextchar = g.nextchar[1:]
+	if len(cStringBytes(g.nextchar)) == 0 {
+		g.nextchar = nil
+		Optind++
+	}
+
+	index := bytes.IndexByte(optstring, c)
+	if index < 0 || c == ':' || c == ';' {
+		if printErrors {
+			getoptError(argv, "invalid option -- '%c'\n", c)
+		}
+		Optopt = int(c)
+		return '?'
+	}
+
+	if index+1 < len(optstring) && optstring[index+1] == ':' {
+		if index+2 < len(optstring) && optstring[index+2] == ':' {
+			// argument is optional
+			if g.nextchar != nil {
+				Optarg = g.nextchar
+				Optind++
+			}
+		} else {
+			// argument is required
+			if g.nextchar != nil {
+				Optarg = g.nextchar
+				Optind++
+			} else if Optind == argc {
+				if printErrors {
+					getoptError(argv,
+						"option requires an argument -- '%c'\n", c)
+				}
+				Optopt = int(c)
+				if colon {
+					return ':'
+				}
+				return '?'
+			} else {
+				Optarg = argv[Optind]
+				Optind++
+			}
+		}
+		g.nextchar = nil
+	}
+	return int(c)
+}
+
+// processLong parses the long option argv[Optind]. If the option is not
+// long, then returned flag is false.
+func (g *getoptState) processLong(argc int, argv [][]byte, optstring []byte,
+	longopts []Option, longindex []int, longOnly, printErrors, colon bool) (
+	result int, ok bool) {
+
+	arg := cStringBytes(argv[Optind])
+	prefix := "-"
+	if arg[1] == '-' {
+		prefix = "--"
+	}
+	name := arg[len(prefix):]
+	var value []byte
+	hasValue := false
+	if i := bytes.IndexByte(name, '='); i >= 0 {
+		value = argv[Optind][len(prefix)+i+1:]
+		hasValue = true
+		name = name[:i]
+	}
+
+	// find the option
+	found := -1
+	ambiguous := false
+	for i := range longopts {
+		optName := cStringBytes(longopts[i].Name)
+		if len(optName) == 0 {
+			break
+		}
+		if !bytes.HasPrefix(optName, name) {
+			continue
+		}
+		if len(optName) == len(name) {
+			// exact match
+			found = i
+			ambiguous = false
+			break
+		}
+		if found < 0 {
+			found = i
+		} else if longOnly ||
+			longopts[i].HasArg != longopts[found].HasArg ||
+			!sameIntPointer(longopts[i].Flag, longopts[found].Flag) ||
+			longopts[i].Val != longopts[found].Val {
+			ambiguous = true
+		}
+	}
+
+	if ambiguous {
+		if printErrors {
+			getoptError(argv, "option '%s%s' is ambiguous\n", prefix, name)
+		}
+		g.nextchar = nil
+		Optind++
+		Optopt = 0
+		return '?', true
+	}
+
+	if found < 0 {
+		// Can't find it as a long option. If this is not getopt_long_only,
+		// or the option starts with "--" or is not a valid short option,
+		// then it's an error.
+		if !longOnly || prefix == "--" ||
+			bytes.IndexByte(optstring, arg[1]) < 0 {
+			if printErrors {
+				getoptError(argv, "unrecognized option '%s%s'\n", prefix, name)
+			}
+			g.nextchar = nil
+			Optind++
+			Optopt = 0
+			return '?', true
+		}
+		return 0, false
+	}
+
+	option := longopts[found]
+	g.nextchar = nil
+	Optind++
+
+	if hasValue {
+		if option.HasArg == noArgument {
+			if printErrors {
+				getoptError(argv, "option '%s%s' doesn't allow an argument\n",
+					prefix, cStringBytes(option.Name))
+			}
+			Optopt = option.Val
+			return '?', true
+		}
+		Optarg = value
+	} else if option.HasArg == requiredArgument {
+		if Optind < argc {
+			Optarg = argv[Optind]
+			Optind++
+		} else {
+			if printErrors {
+				getoptError(argv, "option '%s%s' requires an argument\n",
+					prefix, cStringBytes(option.Name))
+			}
+			Optopt = option.Val
+			if colon {
+				return ':', true
+			}
+			return '?', true
+		}
+	}
+
+	if len(longindex) > 0 {
+		longindex[0] = found
+	}
+	if option.Flag != nil {
+		option.Flag[0] = option.Val
+		return 0, true
+	}
+	return option.Val, true
+}
@@ -0,0 +1,227 @@
+package noarch
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// Values of glob.h constants
+const (
+	globErr      = 1 << 0
+	globMark     = 1 << 1
+	globNosort   = 1 << 2
+	globDooffs   = 1 << 3
+	globNocheck  = 1 << 4
+	globAppend   = 1 << 5
+	globNoescape = 1 << 6
+	globPeriod   = 1 << 7
+	globOnlydir  = 1 << 13
+
+	globAborted = 2
+	globNomatch = 3
+)
+
+// GlobT represents the C type glob_t from glob.h:
+//
+//     typedef struct {
+//         size_t   gl_pathc;
+//         char   **gl_pathv;
+//         size_t   gl_offs;
+//         ...
+//     } glob_t;
+//
+// The list of paths GlPathv is terminated by nil, as in C.
+type GlobT struct {
+	GlPathc uint32
+	GlPathv [][]byte
+	GlOffs  uint32
+	GlFlags int
+}
+
+// Glob handles glob().
+//
+// Searches for all the paths matching pattern according to the rules used
+// by the shell. The found paths are stored in the structure pointed to by
+// pglob. Flags GLOB_ERR, GLOB_MARK, GLOB_NOSORT, GLOB_DOOFFS, GLOB_NOCHECK,
+// GLOB_APPEND, GLOB_NOESCAPE, GLOB_PERIOD and GLOB_ONLYDIR are supported.
+// If errfunc is not nil, then it is called for directories, which cannot
+// be read. Returns zero on success, GLOB_ABORTED on read error or
+// GLOB_NOMATCH, if no paths are found.
+func Glob(pattern []byte, flags int, errfunc func([]byte, int) int,
+	pglob []GlobT) int {
+	g := &pglob[0]
+	p := string(pattern[:Strlen(pattern)])
+
+	fnmFlags := fnmPathname | fnmPeriod
+	if flags&globNoescape != 0 {
+		fnmFlags |= fnmNoescape
+	}
+	if flags&globPeriod != 0 {
+		fnmFlags &^= fnmPeriod
+	}
+
+	var aborted bool
+	readDir := func(dir string) []os.FileInfo {
+		name := dir
+		if name == "" {
+			name = "."
+		}
+		infos, err := ioutil.ReadDir(name)
+		if err != nil {
+			errno := int(syscall.EIO)
+			if pe, ok := err.(*os.PathError); ok {
+				if e, ok := pe.Err.(syscall.Errno); ok {
+					errno = int(e)
+				}
+			}
+			if (errfunc != nil && errfunc([]byte(name+"\x00"), errno) != 0) ||
+				flags&globErr != 0 {
+				aborted = true
+			}
+		}
+		return infos
+	}
+
+	// match path components one by one
+	paths := []string{""}
+	if strings.HasPrefix(p, "/") {
+		paths = []string{"/"}
+	}
+	components := strings.FieldsFunc(p, func(r rune) bool { return r == '/' })
+	for i, component := range components {
+		last := i == len(components)-1
+		var next []string
+		for _, dir := range paths {
+			if !hasGlobMagic(component, flags) {
+				name := filepath.Join(dir, unescapeGlob(component, flags))
+				if dir == "" {
+					name = unescapeGlob(component, flags)
+				}
+				if info, err := os.Lstat(name); err == nil &&
+					(last || info.IsDir() || isDirLink(name)) {
+					next = append(next, name)
+				}
+				continue
+			}
+			for _, info := range readDir(dir) {
+				if !fnmatch([]byte(component), []byte(info.Name()), fnmFlags, true) {
+					continue
+				}
+				name := filepath.Join(dir, info.Name())
+				if dir == "" {
+					name = info.Name()
+				}
+				if !last && !info.IsDir() && !isDirLink(name) {
+					continue
+				}
+				next = append(next, name)
+			}
+			if aborted {
+				return globAborted
+			}
+		}
+		paths = next
+	}
+	if len(components) == 0 && p != "/" {
+		paths = nil
+	}
+
+	// pattern with slash at the end matches only directories
+	trailing := len(components) > 0 && strings.HasSuffix(p, "/")
+
+	var found []string
+	for _, path := range paths {
+		isDir := isDirLink(path)
+		if (flags&globOnlydir != 0 || trailing) && !isDir {
+			continue
+		}
+		if (flags&globMark != 0 || trailing) && isDir &&
+			!strings.HasSuffix(path, "/") {
+			path += "/"
+		}
+		found = append(found, path)
+	}
+	if flags&globNosort == 0 {
+		sort.Strings(found)
+	}
+
+	if len(found) == 0 {
+		if flags&globNocheck == 0 {
+			if flags&globAppend == 0 {
+				Globfree(pglob)
+			}
+			return globNomatch
+		}
+		found = []string{p}
+	}
+
+	// list of paths with nil at the end
+	if flags&globAppend == 0 || g.GlPathv == nil {
+		var offs uint32
+		if flags&globDooffs != 0 {
+			offs = g.GlOffs
+		}
+		g.GlPathv = make([][]byte, offs, int(offs)+len(found)+1)
+		g.GlPathc = 0
+	} else {
+		g.GlPathv = g.GlPathv[:len(g.GlPathv)-1]
+	}
+	for _, path := range found {
+		g.GlPathv = append(g.GlPathv, []byte(path+"\x00"))
+	}
+	g.GlPathv = append(g.GlPathv, nil)
+	g.GlPathc += uint32(len(found))
+	g.GlFlags = flags
+	return 0
+}
+
+// Globfree handles globfree().
+//
+// Frees the memory of paths from a previous call to glob().
+func Globfree(pglob []GlobT) {
+	pglob[0].GlPathc = 0
+	pglob[0].GlPathv = nil
+}
+
+// hasGlobMagic returns true, if the component of pattern has wildcards.
+func hasGlobMagic(component string, flags int) bool {
+	for i := 0; i < len(component); i++ {
+		switch component[i] {
+		case '*', '?', '[':
+			return true
+		case '\\':
+			if flags&globNoescape == 0 {
+				i++
+			}
+		}
+	}
+	return false
+}
+
+// unescapeGlob removes backslashes from the component of pattern without
+// wildcards.
+func unescapeGlob(component string, flags int) string {
+	if flags&globNoescape != 0 {
+		return component
+	}
+	var b bytes.Buffer
+	for i := 0; i < len(component); i++ {
+		if component[i] == '\\' && i+1 < len(component) {
+			i++
+		}
+		b.WriteByte(component[i])
+	}
+	return b.String()
+}
+
+// isDirLink returns true, if the path is a directory or a symbolic link to
+// directory.
+func isDirLink(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,66 @@
+package noarch
+
+import "sync"
+
+// Guard is the reentrant mutex, which guards the access to global variables
+// of transpiled code used from several goroutines. The transpiler (option
+// "-guard") locks the guard around each statement with the access to the
+// selected global variables. Guard is reentrant, because the guarded
+// statement may call the function with other guarded statements. Example of
+// Go code:
+//
+//     var c4goGuard noarch.Guard
+//
+//     func increment() {
+//         c4goGuard.Lock()
+//         counter++
+//         c4goGuard.Unlock()
+//     }
+//
+// Guard is based on sync.Mutex, so the race detector of Go knows about the
+// synchronization. The zero value of Guard is an unlocked guard.
+type Guard struct {
+	mu    sync.Mutex // locked by owner of guard
+	state sync.Mutex // protects owner and count
+	owner uint64
+	count int
+}
+
+// Lock locks the guard. If the guard is already locked by the current
+// goroutine, then the count of locks is incremented.
+func (g *Guard) Lock() {
+	id := goroutineID()
+	g.state.Lock()
+	if g.count > 0 && g.owner == id {
+		g.count++
+		g.state.Unlock()
+		return
+	}
+	g.state.Unlock()
+
+	g.mu.Lock()
+
+	g.state.Lock()
+	g.owner = id
+	g.count = 1
+	g.state.Unlock()
+}
+
+// Unlock unlocks the guard. The guard is released for other goroutines,
+// then it is unlocked as many times as it was locked.
+func (g *Guard) Unlock() {
+	g.state.Lock()
+	if g.count == 0 || g.owner != goroutineID() {
+		g.state.Unlock()
+		panic("noarch: unlock of guard, which is not locked by goroutine")
+	}
+	g.count--
+	if g.count > 0 {
+		g.state.Unlock()
+		return
+	}
+	g.owner = 0
+	g.state.Unlock()
+
+	g.mu.Unlock()
+}
@@ -0,0 +1,187 @@
+package noarch
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+	"golang.org/x/text/transform"
+)
+
+// iconvState is the conversion from one character set to another. The
+// input is decoded to UTF-8 and then encoded to the target character set.
+type iconvState struct {
+	decoder transform.Transformer
+	encoder transform.Transformer
+}
+
+// IconvT represents the C type iconv_t from iconv.h. The value
+// (iconv_t)-1 of failed iconv_open() is nil in Go code.
+type IconvT *iconvState
+
+// iconvError is the value (size_t)-1, which is returned by iconv() on
+// error.
+const iconvError = ^uint32(0)
+
+// iconvAliases - names of character sets, which are not known by IANA
+// index. Names are in upper case without "-" and "_".
+var iconvAliases = map[string]encoding.Encoding{
+	"UTF8":    unicode.UTF8,
+	"UTF16":   unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+	"UTF16LE": unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+	"UTF16BE": unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"UCS2":    unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+	"UCS2LE":  unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+	"UCS2BE":  unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"UTF32":   utf32.UTF32(utf32.BigEndian, utf32.UseBOM),
+	"UTF32LE": utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM),
+	"UTF32BE": utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM),
+	"UCS4":    utf32.UTF32(utf32.BigEndian, utf32.UseBOM),
+	"UCS4LE":  utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM),
+	"UCS4BE":  utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM),
+}
+
+// iconvEncoding returns the encoding of character set by name, like
+// "UTF-8", "ISO-8859-1", "latin1", "UTF-16LE" or "CP1251". Suffix
+// "//TRANSLIT" is returned as flag translit, other suffixes are ignored.
+func iconvEncoding(name string) (e encoding.Encoding, translit bool, ok bool) {
+	if i := strings.Index(name, "//"); i >= 0 {
+		translit = strings.Contains(strings.ToUpper(name[i:]), "TRANSLIT")
+		name = name[:i]
+	}
+	key := strings.ToUpper(strings.NewReplacer("-", "", "_", "").Replace(name))
+	if e, ok := iconvAliases[key]; ok {
+		return e, translit, true
+	}
+	switch {
+	case key == "ASCII":
+		name = "US-ASCII"
+	case strings.HasPrefix(key, "CP") && len(key) > 2:
+		name = "windows-" + key[2:]
+	}
+	e, err := ianaindex.IANA.Encoding(name)
+	if err != nil || e == nil {
+		return nil, false, false
+	}
+	return e, translit, true
+}
+
+// IconvOpen handles iconv_open().
+//
+// Allocates a conversion descriptor suitable for converting byte sequences
+// from character encoding fromcode to character encoding tocode. With
+// suffix "//TRANSLIT" of tocode the characters, which cannot be
+// represented in the target character set, are replaced by a similar
+// character. On error, (iconv_t)-1 is returned.
+func IconvOpen(tocode, fromcode []byte) IconvT {
+	to, translit, ok := iconvEncoding(CStringToString(tocode))
+	if !ok {
+		return nil
+	}
+	from, _, ok := iconvEncoding(CStringToString(fromcode))
+	if !ok {
+		return nil
+	}
+	encoder := to.NewEncoder()
+	if translit {
+		encoder = encoding.ReplaceUnsupported(encoder)
+	}
+	return &iconvState{decoder: from.NewDecoder(), encoder: encoder}
+}
+
+// Iconv handles iconv().
+//
+// Converts a sequence of characters in one character encoding to a
+// sequence of characters in another character encoding. Reads the input
+// from *inbuf with *inbytesleft bytes and writes the output to *outbuf with
+// place for *outbytesleft bytes. The pointers and the amounts of bytes are
+// updated for the converted input and output. If inbuf or *inbuf is NULL,
+// then the conversion state is written to the output and is reset.
+//
+// Returns zero on success. On error, (size_t)-1 is returned, if the output
+// buffer has no more room, the input ends with an incomplete multibyte
+// sequence or the character cannot be converted.
+func Iconv(cd IconvT, inbuf [][]byte, inbytesleft []uint32,
+	outbuf [][]byte, outbytesleft []uint32) uint32 {
+	if cd == nil {
+		return iconvError
+	}
+	var dst []byte
+	if len(outbuf) > 0 && len(outbytesleft) > 0 {
+		dst = outbuf[0]
+		if int(outbytesleft[0]) < len(dst) {
+			dst = dst[:outbytesleft[0]]
+		}
+	}
+	written := 0
+	defer func() {
+		if dst != nil {
+			outbuf[0] = outbuf[0][written:]
+			outbytesleft[0] -= uint32(written)
+		}
+	}()
+
+	if len(inbuf) == 0 || inbuf[0] == nil {
+		// write the rest of output and reset the state
+		nDst, _, err := cd.encoder.Transform(dst, nil, true)
+		written = nDst
+		if err != nil {
+			return iconvError
+		}
+		cd.decoder.Reset()
+		cd.encoder.Reset()
+		return 0
+	}
+
+	src := inbuf[0]
+	if int(inbytesleft[0]) < len(src) {
+		src = src[:inbytesleft[0]]
+	}
+	read := 0
+	defer func() {
+		inbuf[0] = inbuf[0][read:]
+		inbytesleft[0] -= uint32(read)
+	}()
+
+	// characters are converted one by one, so the input stops at the
+	// character, which cannot be converted
+	var r [utf8.UTFMax]byte
+	for read < len(src) {
+		// decode one character: the smallest output, which has place for
+		// the first decoded character
+		var nr, ns int
+		var err error
+		for size := 1; size <= len(r); size++ {
+			nr, ns, err = cd.decoder.Transform(r[:size], src[read:], false)
+			if nr > 0 || ns > 0 || err != transform.ErrShortDst {
+				break
+			}
+		}
+		if nr == 0 && ns == 0 {
+			// incomplete or not valid multibyte sequence
+			return iconvError
+		}
+		nDst, _, err := cd.encoder.Transform(dst[written:], r[:nr], false)
+		if err != nil {
+			// output has no more room or character cannot be converted
+			return iconvError
+		}
+		read += ns
+		written += nDst
+	}
+	return 0
+}
+
+// IconvClose handles iconv_close().
+//
+// Deallocates the conversion descriptor cd. On success, zero is returned.
+// On error, -1 is returned.
+func IconvClose(cd IconvT) int {
+	if cd == nil {
+		return -1
+	}
+	return 0
+}
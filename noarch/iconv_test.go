@@ -0,0 +1,112 @@
+package noarch
+
+import (
+	"bytes"
+	"testing"
+)
+
+// iconvString converts the string and returns the output and the result of
+// iconv() with the output buffer of size.
+func iconvString(cd IconvT, input string, size int) (string, uint32, int) {
+	inbuf := [][]byte{[]byte(input)}
+	inleft := []uint32{uint32(len(input))}
+	out := make([]byte, size)
+	outbuf := [][]byte{out}
+	outleft := []uint32{uint32(size)}
+	r := Iconv(cd, inbuf, inleft, outbuf, outleft)
+	if len(outbuf[0]) != int(outleft[0]) || len(inbuf[0]) != int(inleft[0]) {
+		return "", 0, -1
+	}
+	return string(out[:size-int(outleft[0])]), r, int(inleft[0])
+}
+
+func TestIconv(t *testing.T) {
+	tests := []struct {
+		to, from string
+		input    string
+		output   string
+	}{
+		{"ISO-8859-1", "UTF-8", "café", "caf\xe9"},
+		{"UTF-8", "latin1", "caf\xe9", "café"},
+		{"utf8", "UTF-8", "мир", "мир"},
+		{"UTF-16LE", "UTF-8", "aé", "a\x00\xe9\x00"},
+		{"UTF-16BE", "UTF-8", "aé", "\x00a\x00\xe9"},
+		{"UTF-16", "UTF-8", "a", "\xfe\xff\x00a"},
+		{"UTF-8", "UTF-16", "\xff\xfea\x00", "a"},
+		{"UTF-8", "UTF-16", "\x00a", "a"},
+		{"UTF-32LE", "UTF-8", "a", "a\x00\x00\x00"},
+		{"CP1251", "UTF-8", "мир", "\xec\xe8\xf0"},
+		{"ASCII//TRANSLIT", "UTF-8", "café", "caf\x1a"},
+	}
+	for _, tt := range tests {
+		cd := IconvOpen([]byte(tt.to+"\x00"), []byte(tt.from+"\x00"))
+		if cd == nil {
+			t.Errorf("iconv_open(%q, %q) is failed", tt.to, tt.from)
+			continue
+		}
+		output, r, left := iconvString(cd, tt.input, 32)
+		if r != 0 || left != 0 || output != tt.output {
+			t.Errorf("iconv %s to %s: %q, %d, %d", tt.from, tt.to, output, r, left)
+		}
+		if IconvClose(cd) != 0 {
+			t.Errorf("iconv_close")
+		}
+	}
+
+	if IconvOpen([]byte("UTF-8\x00"), []byte("NOT-EXIST\x00")) != nil ||
+		IconvOpen([]byte("NOT-EXIST\x00"), []byte("UTF-8\x00")) != nil {
+		t.Errorf("not valid character set is opened")
+	}
+	if Iconv(nil, nil, nil, nil, nil) != iconvError || IconvClose(nil) != -1 {
+		t.Errorf("not valid descriptor is used")
+	}
+}
+
+func TestIconvErrors(t *testing.T) {
+	cd := IconvOpen([]byte("ISO-8859-1\x00"), []byte("UTF-8\x00"))
+
+	// not representable character
+	output, r, left := iconvString(cd, "aмb", 32)
+	if output != "a" || r != iconvError || left != 3 {
+		t.Errorf("not representable: %q, %x, %d", output, r, left)
+	}
+	Iconv(cd, nil, nil, nil, nil)
+
+	// output buffer is too small
+	output, r, left = iconvString(cd, "café", 2)
+	if output != "ca" || r != iconvError || left == 0 {
+		t.Errorf("small output: %q, %x, %d", output, r, left)
+	}
+	Iconv(cd, nil, nil, nil, nil)
+
+	// incomplete multibyte sequence is kept for the next call
+	output, r, left = iconvString(cd, "caf\xc3", 32)
+	if output != "caf" || r != iconvError || left != 1 {
+		t.Errorf("incomplete: %q, %x, %d", output, r, left)
+	}
+	output, r, left = iconvString(cd, "\xc3\xa9", 32)
+	if output != "\xe9" || r != 0 || left != 0 {
+		t.Errorf("continue: %q, %x, %d", output, r, left)
+	}
+
+	// conversion by small parts of output
+	var b bytes.Buffer
+	in := [][]byte{[]byte("été café")}
+	inleft := []uint32{uint32(len(in[0]))}
+	for inleft[0] > 0 {
+		out := make([]byte, 3)
+		outbuf := [][]byte{out}
+		outleft := []uint32{3}
+		Iconv(cd, in, inleft, outbuf, outleft)
+		b.Write(out[:3-outleft[0]])
+	}
+	out := make([]byte, 3)
+	outleft := []uint32{3}
+	if Iconv(cd, nil, nil, [][]byte{out}, outleft) != 0 {
+		t.Errorf("reset")
+	}
+	b.Write(out[:3-outleft[0]])
+	if b.String() != "\xe9t\xe9 caf\xe9" {
+		t.Errorf("parts: %q", b.String())
+	}
+}
@@ -0,0 +1,38 @@
+package noarch
+
+// ImaxdivT is the representation of "imaxdiv_t". It is used by imaxdiv().
+type ImaxdivT struct {
+	Quot int64 // quotient
+	Rem  int64 // remainder
+}
+
+// Imaxabs returns the absolute value of parameter n.
+//
+// This is the intmax_t version of abs.
+func Imaxabs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// Imaxdiv returns the integral quotient and remainder of the division of
+// numer by denom ( numer/denom ) as a structure of type imaxdiv_t, which has
+// two members: quot and rem.
+func Imaxdiv(numer, denom int64) ImaxdivT {
+	return ImaxdivT{
+		Quot: numer / denom,
+		Rem:  numer % denom,
+	}
+}
+
+// Strtoimax works the same way as Strtol but returns an intmax_t.
+func Strtoimax(str []byte, endptr [][]byte, radix int) int64 {
+	return Strtoll(str, endptr, radix)
+}
+
+// Strtoumax works the same way as Strtol but returns a uintmax_t.
+func Strtoumax(str []byte, endptr [][]byte, radix int) uint64 {
+	return Strtoull(str, endptr, radix)
+}
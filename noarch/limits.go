@@ -0,0 +1,67 @@
+package noarch
+
+import "math"
+
+// Limits of integer types of "limits.h" for Go types, which are used by c4go
+// for C types: "char" is byte, "long" is int32 and "long long" is int64. So
+// LONG_MAX is the limit of int32 even if C compiler has 64-bit "long". Type
+// "int" keeps 32-bit limits of C.
+const (
+	CharBit   = 8
+	ScharMin  = math.MinInt8
+	ScharMax  = math.MaxInt8
+	UcharMax  = math.MaxUint8
+	CharMin   = 0
+	CharMax   = math.MaxUint8
+	ShrtMin   = math.MinInt16
+	ShrtMax   = math.MaxInt16
+	UshrtMax  = math.MaxUint16
+	IntMin    = math.MinInt32
+	IntMax    = math.MaxInt32
+	UintMax   = math.MaxUint32
+	LongMin   = math.MinInt32
+	LongMax   = math.MaxInt32
+	UlongMax  = math.MaxUint32
+	LlongMin  = math.MinInt64
+	LlongMax  = math.MaxInt64
+	UllongMax = math.MaxUint64
+)
+
+// Characteristics of floating types of "float.h". The type "long double" is
+// float64 in Go code, so limits of "long double" are limits of "double".
+const (
+	FltRadix = 2
+
+	FltMantDig  = 24
+	FltDig      = 6
+	FltMinExp   = -125
+	FltMaxExp   = 128
+	FltMin10Exp = -37
+	FltMax10Exp = 38
+	FltMax      = math.MaxFloat32
+	FltMin      = 1.17549435082228750796873653722224568e-38 // 0x1p-126
+	FltEpsilon  = 1.1920928955078125e-07                    // 0x1p-23
+	FltTrueMin  = math.SmallestNonzeroFloat32
+
+	DblMantDig  = 53
+	DblDig      = 15
+	DblMinExp   = -1021
+	DblMaxExp   = 1024
+	DblMin10Exp = -307
+	DblMax10Exp = 308
+	DblMax      = math.MaxFloat64
+	DblMin      = 2.22507385850720138309023271733240406e-308 // 0x1p-1022
+	DblEpsilon  = 2.220446049250313080847263336181640625e-16 // 0x1p-52
+	DblTrueMin  = math.SmallestNonzeroFloat64
+
+	LdblMantDig  = DblMantDig
+	LdblDig      = DblDig
+	LdblMinExp   = DblMinExp
+	LdblMaxExp   = DblMaxExp
+	LdblMin10Exp = DblMin10Exp
+	LdblMax10Exp = DblMax10Exp
+	LdblMax      = DblMax
+	LdblMin      = DblMin
+	LdblEpsilon  = DblEpsilon
+	LdblTrueMin  = DblTrueMin
+)
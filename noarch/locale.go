@@ -0,0 +1,274 @@
+package noarch
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Categories of locale.h. Values are the same as in glibc.
+const (
+	LcCtype          = 0
+	LcNumeric        = 1
+	LcTime           = 2
+	LcCollate        = 3
+	LcMonetary       = 4
+	LcMessages       = 5
+	LcAll            = 6
+	LcPaper          = 7
+	LcName           = 8
+	LcAddress        = 9
+	LcTelephone      = 10
+	LcMeasurement    = 11
+	LcIdentification = 12
+)
+
+// lcNames - names of categories, where index is category.
+var lcNames = [...]string{
+	"LC_CTYPE", "LC_NUMERIC", "LC_TIME", "LC_COLLATE", "LC_MONETARY",
+	"LC_MESSAGES", "LC_ALL", "LC_PAPER", "LC_NAME", "LC_ADDRESS",
+	"LC_TELEPHONE", "LC_MEASUREMENT", "LC_IDENTIFICATION",
+}
+
+// Lconv is struct lconv of locale.h with the numeric and monetary
+// conventions of locale.
+type Lconv struct {
+	DecimalPoint    []byte
+	ThousandsSep    []byte
+	Grouping        []byte
+	IntCurrSymbol   []byte
+	CurrencySymbol  []byte
+	MonDecimalPoint []byte
+	MonThousandsSep []byte
+	MonGrouping     []byte
+	PositiveSign    []byte
+	NegativeSign    []byte
+	IntFracDigits   byte
+	FracDigits      byte
+	PCsPrecedes     byte
+	PSepBySpace     byte
+	NCsPrecedes     byte
+	NSepBySpace     byte
+	PSignPosn       byte
+	NSignPosn       byte
+	IntPCsPrecedes  byte
+	IntPSepBySpace  byte
+	IntNCsPrecedes  byte
+	IntNSepBySpace  byte
+	IntPSignPosn    byte
+	IntNSignPosn    byte
+}
+
+// Locale is the locale of program, the type locale_t of C is a pointer to
+// Locale (a slice in Go code). Locale keeps the name of locale for each category.
+type Locale struct {
+	names [len(lcNames)]string
+}
+
+// LocaleData is struct __locale_data of glibc with the data of one category
+// of locale. The data of locale is not available in Go code.
+type LocaleData struct{}
+
+// supportedLocales - locales, which are supported by c4go. All of them have
+// the conventions of the "C" locale. The locale "POSIX" is the "C" locale.
+var supportedLocales = map[string]string{
+	"C":       "C",
+	"POSIX":   "C",
+	"C.UTF-8": "C.UTF-8",
+	"C.utf8":  "C.utf8",
+}
+
+// globalLocale - locale of program, which is changed by setlocale(). Any C
+// program starts with the "C" locale.
+var globalLocale = newLocale("C")
+
+func newLocale(name string) (l Locale) {
+	for i := range l.names {
+		l.names[i] = name
+	}
+	return
+}
+
+// name returns the name of locale of category. The name of LC_ALL is the
+// name of all categories, if all of them are the same, or the list of names
+// of categories as in glibc:
+//
+//     LC_CTYPE=C;LC_NUMERIC=C.UTF-8;LC_TIME=C;...
+//
+func (l Locale) name(category int) string {
+	if category != LcAll {
+		return l.names[category]
+	}
+	same := true
+	var list []string
+	for i := range l.names {
+		if i == LcAll {
+			continue
+		}
+		same = same && l.names[i] == l.names[LcCtype]
+		list = append(list, lcNames[i]+"="+l.names[i])
+	}
+	if same {
+		return l.names[LcCtype]
+	}
+	return strings.Join(list, ";")
+}
+
+// localeFromEnvironment returns the name of locale of category from the
+// environment variables LC_ALL, LC_* and LANG.
+func localeFromEnvironment(category int) string {
+	for _, env := range []string{"LC_ALL", lcNames[category], "LANG"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return "C"
+}
+
+// Setlocale handles setlocale().
+//
+// Sets the locale of category, if locale is not a null pointer, and returns
+// the name of locale of category. The empty name is the locale of the
+// environment. Only the "C", "POSIX" and "C.UTF-8" locales are supported, for
+// other locales a null pointer is returned and the locale is not changed.
+func Setlocale(category int, locale []byte) []byte {
+	if category < 0 || category >= len(lcNames) {
+		return nil
+	}
+	if locale == nil {
+		return []byte(globalLocale.name(category) + "\x00")
+	}
+
+	l := globalLocale
+	name := CStringToString(locale)
+	categories := []int{category}
+	if category == LcAll {
+		categories = nil
+		for c := range lcNames {
+			if c != LcAll {
+				categories = append(categories, c)
+			}
+		}
+		if strings.Contains(name, "=") {
+			// list of names of categories from setlocale(LC_ALL, NULL)
+			parts := strings.Split(name, ";")
+			if len(parts) != len(categories) {
+				return nil
+			}
+			for i, c := range categories {
+				if !strings.HasPrefix(parts[i], lcNames[c]+"=") {
+					return nil
+				}
+				n, ok := supportedLocales[parts[i][len(lcNames[c])+1:]]
+				if !ok {
+					return nil
+				}
+				l.names[c] = n
+			}
+			globalLocale = l
+			return []byte(l.name(LcAll) + "\x00")
+		}
+	}
+	for _, c := range categories {
+		n := name
+		if n == "" {
+			n = localeFromEnvironment(c)
+		}
+		n, ok := supportedLocales[n]
+		if !ok {
+			return nil
+		}
+		l.names[c] = n
+	}
+	globalLocale = l
+	return []byte(l.name(category) + "\x00")
+}
+
+// cLconv - conventions of the "C" locale. Values CHAR_MAX mean, that values
+// are not available in the locale.
+var cLconv = Lconv{
+	DecimalPoint:    []byte(".\x00"),
+	ThousandsSep:    []byte("\x00"),
+	Grouping:        []byte("\x00"),
+	IntCurrSymbol:   []byte("\x00"),
+	CurrencySymbol:  []byte("\x00"),
+	MonDecimalPoint: []byte("\x00"),
+	MonThousandsSep: []byte("\x00"),
+	MonGrouping:     []byte("\x00"),
+	PositiveSign:    []byte("\x00"),
+	NegativeSign:    []byte("\x00"),
+	IntFracDigits:   127,
+	FracDigits:      127,
+	PCsPrecedes:     127,
+	PSepBySpace:     127,
+	NCsPrecedes:     127,
+	NSepBySpace:     127,
+	PSignPosn:       127,
+	NSignPosn:       127,
+	IntPCsPrecedes:  127,
+	IntPSepBySpace:  127,
+	IntNCsPrecedes:  127,
+	IntNSepBySpace:  127,
+	IntPSignPosn:    127,
+	IntNSignPosn:    127,
+}
+
+// localeconvResult - result of localeconv(), which is overwritten by each
+// call of function as in C.
+var localeconvResult = make([]Lconv, 1)
+
+// Localeconv handles localeconv().
+//
+// Returns the numeric and monetary conventions of current locale. All
+// supported locales have the conventions of the "C" locale.
+func Localeconv() []Lconv {
+	localeconvResult[0] = cLconv
+	return localeconvResult
+}
+
+// decimalPoint returns the decimal-point character of category LC_NUMERIC
+// of current locale, which is used by printf() and strtod().
+func decimalPoint() string {
+	return CStringToString(Localeconv()[0].DecimalPoint)
+}
+
+// localFloat is a floating-point argument of printf(), which is formatted
+// with the decimal-point character of locale.
+type localFloat struct {
+	value        interface{}
+	decimalPoint string
+}
+
+// Format implements the interface fmt.Formatter.
+func (f localFloat) Format(s fmt.State, verb rune) {
+	format := "%"
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			format += string(flag)
+		}
+	}
+	if w, ok := s.Width(); ok {
+		format += fmt.Sprint(w)
+	}
+	if p, ok := s.Precision(); ok {
+		format += "." + fmt.Sprint(p)
+	}
+	result := fmt.Sprintf(format+string(verb), f.value)
+	fmt.Fprint(s, strings.Replace(result, ".", f.decimalPoint, 1))
+}
+
+// localizeFloats returns arguments of printf(), where floating-point values
+// are formatted with the decimal-point character of locale.
+func localizeFloats(args []interface{}) []interface{} {
+	dp := decimalPoint()
+	if dp == "." {
+		return args
+	}
+	for i := range args {
+		switch args[i].(type) {
+		case float32, float64:
+			args[i] = localFloat{value: args[i], decimalPoint: dp}
+		}
+	}
+	return args
+}
@@ -0,0 +1,109 @@
+package noarch
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestSetlocale(t *testing.T) {
+	defer func() {
+		globalLocale = newLocale("C")
+	}()
+	for _, env := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if v, ok := os.LookupEnv(env); ok {
+			defer os.Setenv(env, v)
+		} else {
+			defer os.Unsetenv(env)
+		}
+		os.Unsetenv(env)
+	}
+
+	// results of glibc
+	tcs := []struct {
+		category int
+		locale   interface{}
+		env      string
+		result   interface{}
+	}{
+		{LcAll, nil, "", "C"},
+		{LcAll, "POSIX", "", "C"},
+		{LcNumeric, "C.UTF-8", "", "C.UTF-8"},
+		{LcAll, nil, "", "LC_CTYPE=C;LC_NUMERIC=C.UTF-8;LC_TIME=C;LC_COLLATE=C;" +
+			"LC_MONETARY=C;LC_MESSAGES=C;LC_PAPER=C;LC_NAME=C;LC_ADDRESS=C;" +
+			"LC_TELEPHONE=C;LC_MEASUREMENT=C;LC_IDENTIFICATION=C"},
+		{LcNumeric, nil, "", "C.UTF-8"},
+		{LcAll, "C.utf8", "", "C.utf8"},
+		{LcAll, "de_DE", "", nil},
+		{LcAll, nil, "", "C.utf8"},
+		{LcAll, "", "", "C"},
+		{99, "C", "", nil},
+		{-1, nil, "", nil},
+		{LcIdentification, nil, "", "C"},
+		{LcAll, "", "fr_FR", nil},
+		{LcAll, "", "C.UTF-8", "C.UTF-8"},
+		{LcAll, "LC_CTYPE=C;LC_NUMERIC=C.UTF-8", "", nil},
+		{LcAll, "LC_CTYPE=C;LC_NUMERIC=POSIX;LC_TIME=C;LC_COLLATE=C;" +
+			"LC_MONETARY=C;LC_MESSAGES=C;LC_PAPER=C;LC_NAME=C;LC_ADDRESS=C;" +
+			"LC_TELEPHONE=C;LC_MEASUREMENT=C;LC_IDENTIFICATION=C", "", "C"},
+		{LcCtype, "C", "", "C"},
+	}
+	for i, tc := range tcs {
+		os.Setenv("LANG", tc.env)
+		var locale []byte
+		if tc.locale != nil {
+			locale = []byte(tc.locale.(string) + "\x00")
+		}
+		result := Setlocale(tc.category, locale)
+		if tc.result == nil {
+			if result != nil {
+				t.Errorf("Case %d: expected null pointer, got %q", i, result)
+			}
+			continue
+		}
+		if r := CStringToString(result); r != tc.result {
+			t.Errorf("Case %d: expected %q, got %q", i, tc.result, r)
+		}
+	}
+}
+
+func TestLocaleconv(t *testing.T) {
+	l := &Localeconv()[0]
+	if s := CStringToString(l.DecimalPoint); s != "." {
+		t.Errorf("Not expected decimal point: %q", s)
+	}
+	if s := CStringToString(l.ThousandsSep); s != "" {
+		t.Errorf("Not expected thousands separator: %q", s)
+	}
+	if l.FracDigits != 127 || l.IntNSignPosn != 127 {
+		t.Errorf("Not expected values of char: %d %d", l.FracDigits, l.IntNSignPosn)
+	}
+
+	// result is overwritten by the next call as in C
+	l.DecimalPoint = []byte(",\x00")
+	if s := CStringToString(Localeconv()[0].DecimalPoint); s != "." {
+		t.Errorf("Not expected decimal point after change: %q", s)
+	}
+}
+
+func TestLocalFloat(t *testing.T) {
+	tcs := []struct {
+		format string
+		value  interface{}
+		result string
+	}{
+		{"%f", 1.5, "1,500000"},
+		{"%+8.2f", float32(-2.25), "   -2,25"},
+		{"%-6.1e|", 12.5, "1,2e+01|"},
+		{"%g", 100.0, "100"},
+	}
+	for _, tc := range tcs {
+		result := fmt.Sprintf(tc.format, localFloat{value: tc.value, decimalPoint: ","})
+		if result != tc.result {
+			t.Errorf("Format %q: expected %q, got %q", tc.format, tc.result, result)
+		}
+	}
+	if args := localizeFloats([]interface{}{1.5}); args[0] != 1.5 {
+		t.Errorf("Float is changed in the \"C\" locale: %v", args[0])
+	}
+}
@@ -2,8 +2,14 @@ package noarch
 
 import (
 	"math"
+	"strconv"
 )
 
+// Functions of math.h. Functions with type "long double" are the same as
+// functions with type "double", because "long double" is float64 in Go.
+// Functions with type "float" are calculated in float64, that is exact for
+// the correctly rounded operations like sqrtf(), fmodf() and ldexpf().
+
 // Signbitf ...
 func Signbitf(x float32) int {
 	return BoolToInt(math.Signbit(float64(x)))
@@ -24,35 +30,170 @@ func IsNaN(x float64) int {
 	return BoolToInt(math.IsNaN(x))
 }
 
-// Fma returns x*y+z.
+// Classification macros of math.h are type-generic, so each function has
+// the variant for argument of type "float", because the subnormal float
+// value is the normal double value.
+
+// Categories of floating-point values of fpclassify() with the same values
+// as in glibc.
+const (
+	FpNan       = 0
+	FpInfinite  = 1
+	FpZero      = 2
+	FpSubnormal = 3
+	FpNormal    = 4
+)
+
+// IsNaNf handles isnan() for argument of type float.
+func IsNaNf(x float32) int {
+	return BoolToInt(math.IsNaN(float64(x)))
+}
+
+// Isinf handles isinf(). Returns 1 for positive and negative infinity.
+func Isinf(x float64) int {
+	return BoolToInt(math.IsInf(x, 0))
+}
+
+// Isinff handles isinf() for argument of type float.
+func Isinff(x float32) int {
+	return Isinf(float64(x))
+}
+
+// IsinfSign handles __builtin_isinf_sign(). Returns 1 for positive infinity
+// and -1 for negative infinity.
+func IsinfSign(x float64) int {
+	switch {
+	case math.IsInf(x, 1):
+		return 1
+	case math.IsInf(x, -1):
+		return -1
+	}
+	return 0
+}
+
+// IsinfSignf handles __builtin_isinf_sign() for argument of type float.
+func IsinfSignf(x float32) int {
+	return IsinfSign(float64(x))
+}
+
+// Isfinite handles isfinite(). Returns 1, if x is not infinity or NaN.
+func Isfinite(x float64) int {
+	return BoolToInt(!math.IsInf(x, 0) && !math.IsNaN(x))
+}
+
+// Isfinitef handles isfinite() for argument of type float.
+func Isfinitef(x float32) int {
+	return Isfinite(float64(x))
+}
+
+// Isnormal handles isnormal(). Returns 1, if x is not zero, subnormal,
+// infinity or NaN.
+func Isnormal(x float64) int {
+	return BoolToInt(Fpclassify(x) == FpNormal)
+}
+
+// Isnormalf handles isnormal() for argument of type float.
+func Isnormalf(x float32) int {
+	return BoolToInt(Fpclassifyf(x) == FpNormal)
+}
+
+// Fpclassify handles fpclassify(). Returns the category of x: FP_NAN,
+// FP_INFINITE, FP_ZERO, FP_SUBNORMAL or FP_NORMAL.
+func Fpclassify(x float64) int {
+	return fpclassify(x, 0x1p-1022)
+}
+
+// Fpclassifyf handles fpclassify() for argument of type float.
+func Fpclassifyf(x float32) int {
+	return fpclassify(float64(x), 0x1p-126)
+}
+
+// fpclassify returns the category of x for the smallest normal value.
+func fpclassify(x, minNormal float64) int {
+	switch {
+	case math.IsNaN(x):
+		return FpNan
+	case math.IsInf(x, 0):
+		return FpInfinite
+	case x == 0:
+		return FpZero
+	case math.Abs(x) < minNormal:
+		return FpSubnormal
+	}
+	return FpNormal
+}
+
+// BuiltinFpclassify handles __builtin_fpclassify(). Returns one of the
+// first arguments for the category of x, as macro fpclassify() is defined
+// in glibc:
+//
+//     __builtin_fpclassify(FP_NAN, FP_INFINITE, FP_NORMAL, FP_SUBNORMAL,
+//                          FP_ZERO, x)
+//
+func BuiltinFpclassify(nan, infinite, normal, subnormal, zero int, x float64) int {
+	return builtinFpclassify(Fpclassify(x), nan, infinite, normal, subnormal, zero)
+}
+
+// BuiltinFpclassifyf handles __builtin_fpclassify() for argument of type
+// float.
+func BuiltinFpclassifyf(nan, infinite, normal, subnormal, zero int, x float32) int {
+	return builtinFpclassify(Fpclassifyf(x), nan, infinite, normal, subnormal, zero)
+}
+
+func builtinFpclassify(category, nan, infinite, normal, subnormal, zero int) int {
+	switch category {
+	case FpNan:
+		return nan
+	case FpInfinite:
+		return infinite
+	case FpZero:
+		return zero
+	case FpSubnormal:
+		return subnormal
+	}
+	return normal
+}
+
+// Fma returns x*y+z, computed with only one rounding.
 func Fma(x, y, z float64) float64 {
-	return x*y + z
+	return math.FMA(x, y, z)
 }
 
-// Fmaf returns x*y+z.
+// Fmaf returns x*y+z, computed with only one rounding.
 func Fmaf(x, y, z float32) float32 {
-	return x*y + z
+	return float32(math.FMA(float64(x), float64(y), float64(z)))
 }
 
-// Fmin returns the smaller of its arguments: either x or y.
+// Fmin returns the smaller of its arguments: either x or y. If one of
+// arguments is NaN, then other argument is returned. For equal arguments,
+// like 0 and -0, x is returned as in glibc.
 func Fmin(x, y float64) float64 {
-	if x < y {
+	switch {
+	case math.IsNaN(x):
+		return y
+	case math.IsNaN(y):
 		return x
+	case y < x:
+		return y
 	}
-	return y
+	return x
 }
 
 // Fminf returns the smaller of its arguments: either x or y.
 func Fminf(x, y float32) float32 {
-	if x < y {
-		return x
-	}
-	return y
+	return float32(Fmin(float64(x), float64(y)))
 }
 
-// Fmax returns the larger of its arguments: either x or y.
+// Fmax returns the larger of its arguments: either x or y. If one of
+// arguments is NaN, then other argument is returned. For equal arguments,
+// like 0 and -0, x is returned as in glibc.
 func Fmax(x, y float64) float64 {
-	if x < y {
+	switch {
+	case math.IsNaN(x):
+		return y
+	case math.IsNaN(y):
+		return x
+	case y > x:
 		return y
 	}
 	return x
@@ -60,10 +201,7 @@ func Fmax(x, y float64) float64 {
 
 // Fmaxf returns the larger of its arguments: either x or y.
 func Fmaxf(x, y float32) float32 {
-	if x < y {
-		return y
-	}
-	return x
+	return float32(Fmax(float64(x), float64(y)))
 }
 
 // Expm1f returns e raised to the power x minus one: e^x-1
@@ -79,7 +217,10 @@ func Exp2f(x float32) float32 {
 
 // Fdim returns the positive difference between x and y.
 func Fdim(x, y float64) float64 {
-	if x > y {
+	switch {
+	case math.IsNaN(x) || math.IsNaN(y):
+		return x + y
+	case x > y:
 		return x - y
 	}
 	return 0
@@ -87,10 +228,7 @@ func Fdim(x, y float64) float64 {
 
 // Fdimf returns the positive difference between x and y.
 func Fdimf(x, y float32) float32 {
-	if x > y {
-		return x - y
-	}
-	return 0
+	return float32(Fdim(float64(x), float64(y)))
 }
 
 // Log2f returns the binary (base-2) logarithm of x.
@@ -112,3 +250,533 @@ func Coshf(a float32) float32 {
 func Tanhf(a float32) float32 {
 	return float32(math.Tanh(float64(a)))
 }
+
+// Asinhf compute area hyperbolic sine
+func Asinhf(a float32) float32 {
+	return float32(math.Asinh(float64(a)))
+}
+
+// Acoshf compute area hyperbolic cosine
+func Acoshf(a float32) float32 {
+	return float32(math.Acosh(float64(a)))
+}
+
+// Atanhf compute area hyperbolic tangent
+func Atanhf(a float32) float32 {
+	return float32(math.Atanh(float64(a)))
+}
+
+// Cosf returns the cosine of x radians.
+func Cosf(x float32) float32 {
+	return float32(math.Cos(float64(x)))
+}
+
+// Sinf returns the sine of x radians.
+func Sinf(x float32) float32 {
+	return float32(math.Sin(float64(x)))
+}
+
+// Tanf returns the tangent of x radians.
+func Tanf(x float32) float32 {
+	return float32(math.Tan(float64(x)))
+}
+
+// Acosf returns the arc cosine of x in radians.
+func Acosf(x float32) float32 {
+	return float32(math.Acos(float64(x)))
+}
+
+// Asinf returns the arc sine of x in radians.
+func Asinf(x float32) float32 {
+	return float32(math.Asin(float64(x)))
+}
+
+// Atanf returns the arc tangent of x in radians.
+func Atanf(x float32) float32 {
+	return float32(math.Atan(float64(x)))
+}
+
+// Atan2f returns the arc tangent of y/x in radians, using the signs of both
+// arguments to determine the quadrant.
+func Atan2f(y, x float32) float32 {
+	return float32(math.Atan2(float64(y), float64(x)))
+}
+
+// Expf returns e raised to the power x.
+func Expf(x float32) float32 {
+	return float32(math.Exp(float64(x)))
+}
+
+// Log returns the natural logarithm of x. Subnormal values are scaled
+// before the calculation, because math.Log is not correct for them on some
+// architectures.
+func Log(x float64) float64 {
+	if x > 0 && x < 0x1p-1022 {
+		return math.Log(x*0x1p54) - 54*math.Ln2
+	}
+	return math.Log(x)
+}
+
+// Log10 returns the decimal logarithm of x. See Log.
+func Log10(x float64) float64 {
+	if x > 0 && x < 0x1p-1022 {
+		return Log(x) * (1 / math.Ln10)
+	}
+	return math.Log10(x)
+}
+
+// Logf returns the natural logarithm of x.
+func Logf(x float32) float32 {
+	return float32(math.Log(float64(x)))
+}
+
+// Log10f returns the decimal logarithm of x.
+func Log10f(x float32) float32 {
+	return float32(math.Log10(float64(x)))
+}
+
+// Log1pf returns the natural logarithm of 1+x.
+func Log1pf(x float32) float32 {
+	return float32(math.Log1p(float64(x)))
+}
+
+// Logbf returns the binary exponent of x as float.
+func Logbf(x float32) float32 {
+	return float32(math.Logb(float64(x)))
+}
+
+// Powf returns x raised to the power y.
+func Powf(x, y float32) float32 {
+	return float32(math.Pow(float64(x), float64(y)))
+}
+
+// Sqrtf returns the square root of x.
+func Sqrtf(x float32) float32 {
+	return float32(math.Sqrt(float64(x)))
+}
+
+// Cbrtf returns the cubic root of x.
+func Cbrtf(x float32) float32 {
+	return float32(math.Cbrt(float64(x)))
+}
+
+// Hypotf returns sqrt(x*x + y*y) without overflow and underflow of
+// intermediate results.
+func Hypotf(x, y float32) float32 {
+	return float32(math.Hypot(float64(x), float64(y)))
+}
+
+// Erff returns the error function of x.
+func Erff(x float32) float32 {
+	return float32(math.Erf(float64(x)))
+}
+
+// Erfcf returns the complementary error function of x.
+func Erfcf(x float32) float32 {
+	return float32(math.Erfc(float64(x)))
+}
+
+// Tgammaf returns the gamma function of x.
+func Tgammaf(x float32) float32 {
+	return float32(math.Gamma(float64(x)))
+}
+
+// Lgamma returns the natural logarithm of the absolute value of the gamma
+// function of x.
+func Lgamma(x float64) float64 {
+	switch {
+	case math.IsInf(x, -1):
+		// lgamma(-Inf) is +Inf in C, but -Inf in Go
+		return math.Inf(1)
+	case x != 0 && math.Abs(x) < 0x1p-70:
+		// gamma(x) is 1/x for tiny x, but Go overflows for subnormal x
+		return -Log(math.Abs(x))
+	}
+	lgamma, _ := math.Lgamma(x)
+	return lgamma
+}
+
+// Lgammaf returns the natural logarithm of the absolute value of the gamma
+// function of x.
+func Lgammaf(x float32) float32 {
+	return float32(Lgamma(float64(x)))
+}
+
+// LgammaR returns the natural logarithm of the absolute value of the gamma
+// function of x and stores the sign of the gamma function in sign[0].
+func LgammaR(x float64, sign []int) float64 {
+	_, sign[0] = math.Lgamma(x)
+	if x == 0 && math.Signbit(x) {
+		// gamma(-0) is -Inf in C
+		sign[0] = -1
+	}
+	return Lgamma(x)
+}
+
+// LgammafR returns the natural logarithm of the absolute value of the gamma
+// function of x and stores the sign of the gamma function in sign[0].
+func LgammafR(x float32, sign []int) float32 {
+	return float32(LgammaR(float64(x), sign))
+}
+
+// J0 returns the Bessel function of the first kind of order 0.
+func J0(x float64) float64 {
+	return math.J0(x)
+}
+
+// J0f returns the Bessel function of the first kind of order 0.
+func J0f(x float32) float32 {
+	return float32(J0(float64(x)))
+}
+
+// J1 returns the Bessel function of the first kind of order 1.
+func J1(x float64) float64 {
+	if math.Signbit(x) {
+		// J1 is odd, but Go loses the sign of tiny, zero and infinite x
+		return -math.J1(-x)
+	}
+	return math.J1(x)
+}
+
+// J1f returns the Bessel function of the first kind of order 1.
+func J1f(x float32) float32 {
+	return float32(J1(float64(x)))
+}
+
+// Jn returns the Bessel function of the first kind of order n.
+func Jn(n int, x float64) float64 {
+	if n < 0 {
+		n, x = -n, -x
+	}
+	if math.Signbit(x) && n%2 == 1 {
+		return -Jn(n, -x)
+	}
+	if n == 1 {
+		return J1(x)
+	}
+	return math.Jn(n, x)
+}
+
+// Jnf returns the Bessel function of the first kind of order n.
+func Jnf(n int, x float32) float32 {
+	return float32(Jn(n, float64(x)))
+}
+
+// Y0 returns the Bessel function of the second kind of order 0.
+func Y0(x float64) float64 {
+	return math.Y0(x)
+}
+
+// Y0f returns the Bessel function of the second kind of order 0.
+func Y0f(x float32) float32 {
+	return float32(Y0(float64(x)))
+}
+
+// Y1 returns the Bessel function of the second kind of order 1.
+func Y1(x float64) float64 {
+	return math.Y1(x)
+}
+
+// Y1f returns the Bessel function of the second kind of order 1.
+func Y1f(x float32) float32 {
+	return float32(Y1(float64(x)))
+}
+
+// Yn returns the Bessel function of the second kind of order n.
+func Yn(n int, x float64) float64 {
+	if math.IsInf(x, 1) && n < 0 && n%2 != 0 {
+		// Yn(-n, x) is -Yn(n, x) for odd n, but Go returns +0
+		return math.Copysign(0, -1)
+	}
+	return math.Yn(n, x)
+}
+
+// Ynf returns the Bessel function of the second kind of order n.
+func Ynf(n int, x float32) float32 {
+	return float32(Yn(n, float64(x)))
+}
+
+// Fabsf returns the absolute value of x.
+func Fabsf(x float32) float32 {
+	return float32(math.Abs(float64(x)))
+}
+
+// Ceilf returns the smallest integral value not less than x.
+func Ceilf(x float32) float32 {
+	return float32(math.Ceil(float64(x)))
+}
+
+// Floorf returns the largest integral value not greater than x.
+func Floorf(x float32) float32 {
+	return float32(math.Floor(float64(x)))
+}
+
+// Truncf returns the integral value nearest to x not larger in magnitude.
+func Truncf(x float32) float32 {
+	return float32(math.Trunc(float64(x)))
+}
+
+// Roundf returns the integral value nearest to x, rounding half-way cases
+// away from zero.
+func Roundf(x float32) float32 {
+	return float32(math.Round(float64(x)))
+}
+
+// Rint returns the integral value nearest to x in the current rounding mode
+// of fenv.h. The exception FE_INEXACT is raised, if the result is not x.
+func Rint(x float64) float64 {
+	r := roundInCurrentMode(x)
+	if r != x && !math.IsNaN(x) {
+		raiseExcept(FeInexact)
+	}
+	return r
+}
+
+// Rintf returns the integral value nearest to x. See Rint.
+func Rintf(x float32) float32 {
+	return float32(Rint(float64(x)))
+}
+
+// Nearbyint returns the integral value nearest to x in the current rounding
+// mode of fenv.h without the exception FE_INEXACT.
+func Nearbyint(x float64) float64 {
+	return roundInCurrentMode(x)
+}
+
+// Nearbyintf returns the integral value nearest to x. See Nearbyint.
+func Nearbyintf(x float32) float32 {
+	return float32(roundInCurrentMode(float64(x)))
+}
+
+// toInt64 converts the integral value to int64. If x is NaN or out of range,
+// then the exception FE_INVALID is raised and the minimal value of int64 is
+// returned, as the conversion of x86.
+func toInt64(x float64) int64 {
+	if math.IsNaN(x) || x >= 1<<63 || x < -1<<63 {
+		raiseExcept(FeInvalid)
+		return math.MinInt64
+	}
+	return int64(x)
+}
+
+// Lround returns the integral value nearest to x, rounding half-way cases
+// away from zero.
+func Lround(x float64) int32 {
+	return int32(toInt64(math.Round(x)))
+}
+
+// Lroundf returns the integral value nearest to x. See Lround.
+func Lroundf(x float32) int32 {
+	return Lround(float64(x))
+}
+
+// Llround returns the integral value nearest to x, rounding half-way cases
+// away from zero.
+func Llround(x float64) int64 {
+	return toInt64(math.Round(x))
+}
+
+// Llroundf returns the integral value nearest to x. See Llround.
+func Llroundf(x float32) int64 {
+	return Llround(float64(x))
+}
+
+// Lrint returns the integral value nearest to x. See Llrint.
+func Lrint(x float64) int32 {
+	return int32(Llrint(x))
+}
+
+// Lrintf returns the integral value nearest to x. See Llrint.
+func Lrintf(x float32) int32 {
+	return Lrint(float64(x))
+}
+
+// Llrint returns the integral value nearest to x in the current rounding
+// mode of fenv.h. The exception FE_INEXACT is raised, if the result is not x.
+func Llrint(x float64) int64 {
+	r := roundInCurrentMode(x)
+	if math.IsNaN(r) || r >= 1<<63 || r < -1<<63 {
+		return toInt64(r)
+	}
+	if r != x {
+		raiseExcept(FeInexact)
+	}
+	return int64(r)
+}
+
+// Llrintf returns the integral value nearest to x. See Llrint.
+func Llrintf(x float32) int64 {
+	return Llrint(float64(x))
+}
+
+// Fmodf returns the floating-point remainder of x/y, rounded toward zero.
+func Fmodf(x, y float32) float32 {
+	return float32(math.Mod(float64(x), float64(y)))
+}
+
+// Remainderf returns the IEEE 754 floating-point remainder of x/y.
+func Remainderf(x, y float32) float32 {
+	return float32(math.Remainder(float64(x), float64(y)))
+}
+
+// Remquo returns the IEEE 754 floating-point remainder of x/y as remainder()
+// and stores in quo the sign and 3 lowest bits of the integral quotient, as
+// glibc does. If the result is NaN, then quo is not changed.
+func Remquo(x, y float64, quo []int) float64 {
+	r := math.Remainder(x, y)
+	if math.IsNaN(r) {
+		return r
+	}
+	// the quotient of reduced value is less than 8, so it is exact
+	ax, ay := math.Abs(x), math.Abs(y)
+	a := math.Mod(ax, 8*ay)
+	q := int(math.RoundToEven((a - math.Remainder(a, ay)) / ay))
+	q &= 7
+	if math.Signbit(x) != math.Signbit(y) {
+		q = -q
+	}
+	quo[0] = q
+	return r
+}
+
+// Remquof returns the remainder of x/y and the quotient. See Remquo.
+func Remquof(x, y float32, quo []int) float32 {
+	return float32(Remquo(float64(x), float64(y), quo))
+}
+
+// Copysignf returns the value with magnitude of x and sign of y.
+func Copysignf(x, y float32) float32 {
+	return float32(math.Copysign(float64(x), float64(y)))
+}
+
+// Frexp breaks x into the normalized fraction in the interval [0.5, 1) and
+// the power of two, which is stored in exp.
+func Frexp(x float64, exp []int) float64 {
+	frac, e := math.Frexp(x)
+	exp[0] = e
+	return frac
+}
+
+// Frexpf breaks x into the normalized fraction and the power of two. See
+// Frexp.
+func Frexpf(x float32, exp []int) float32 {
+	return float32(Frexp(float64(x), exp))
+}
+
+// Ldexpf returns x multiplied by 2 raised to the power exp.
+func Ldexpf(x float32, exp int) float32 {
+	return float32(math.Ldexp(float64(x), exp))
+}
+
+// Scalbln returns x multiplied by 2 raised to the power exp.
+func Scalbln(x float64, exp int32) float64 {
+	return math.Ldexp(x, int(exp))
+}
+
+// Scalblnf returns x multiplied by 2 raised to the power exp.
+func Scalblnf(x float32, exp int32) float32 {
+	return float32(math.Ldexp(float64(x), int(exp)))
+}
+
+// Modf breaks x into the integral part, which is stored in iptr, and the
+// fractional part with the same sign as x, which is returned.
+func Modf(x float64, iptr []float64) float64 {
+	if math.IsInf(x, 0) {
+		// fractional part of infinity is zero in C, but NaN in Go
+		iptr[0] = x
+		return math.Copysign(0, x)
+	}
+	integral, frac := math.Modf(x)
+	iptr[0] = integral
+	return frac
+}
+
+// Modff breaks x into the integral and fractional parts. See Modf.
+func Modff(x float32, iptr []float32) float32 {
+	var integral [1]float64
+	frac := Modf(float64(x), integral[:])
+	iptr[0] = float32(integral[0])
+	return float32(frac)
+}
+
+// Ilogb returns the binary exponent of x as integer. Values FP_ILOGB0 and
+// FP_ILOGBNAN are INT_MIN, as in glibc for x86.
+func Ilogb(x float64) int {
+	switch {
+	case x == 0 || math.IsNaN(x):
+		return math.MinInt32
+	case math.IsInf(x, 0):
+		return math.MaxInt32
+	}
+	return math.Ilogb(x)
+}
+
+// Ilogbf returns the binary exponent of x as integer. See Ilogb.
+func Ilogbf(x float32) int {
+	return Ilogb(float64(x))
+}
+
+// Nan returns the quiet NaN. The string tagp is the payload of NaN, as for
+// strtod("NAN(tagp)").
+func Nan(tagp []byte) float64 {
+	return math.Float64frombits(0x7FF8000000000000 |
+		nanPayload(tagp)&(1<<51-1))
+}
+
+// Nanf returns the quiet NaN. See Nan.
+func Nanf(tagp []byte) float32 {
+	return math.Float32frombits(0x7FC00000 |
+		uint32(nanPayload(tagp))&(1<<22-1))
+}
+
+// nanPayload returns the payload of NaN from the string, which is parsed as
+// the integer constant: decimal, octal with prefix "0" or hexadecimal with
+// prefix "0x". Payload of incorrect string is zero.
+func nanPayload(tagp []byte) uint64 {
+	s := CStringToString(tagp)
+	base := 10
+	switch {
+	case len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X"):
+		s, base = s[2:], 16
+	case len(s) > 1 && s[0] == '0':
+		s, base = s[1:], 8
+	}
+	payload, err := strconv.ParseUint(s, base, 64)
+	if err != nil {
+		return 0
+	}
+	return payload
+}
+
+// Nextafter returns the next representable value after x in the direction
+// of y. If x is equal to y, then y is returned, so the sign of zero is
+// the sign of y.
+func Nextafter(x, y float64) float64 {
+	if x == y {
+		return y
+	}
+	return math.Nextafter(x, y)
+}
+
+// Nextafterf returns the next representable value after x in the direction
+// of y. See Nextafter.
+func Nextafterf(x, y float32) float32 {
+	if x == y {
+		return y
+	}
+	return math.Nextafter32(x, y)
+}
+
+// Nexttowardf returns the next representable value after x in the direction
+// of y.
+func Nexttowardf(x float32, y float64) float32 {
+	switch {
+	case math.IsNaN(float64(x)) || math.IsNaN(y):
+		return float32(math.NaN())
+	case float64(x) == y:
+		return float32(y)
+	case float64(x) < y:
+		return math.Nextafter32(x, float32(math.Inf(1)))
+	}
+	return math.Nextafter32(x, float32(math.Inf(-1)))
+}
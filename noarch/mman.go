@@ -0,0 +1,101 @@
+package noarch
+
+import (
+	"reflect"
+	"sync"
+	"syscall"
+)
+
+// mappings is the table of memory mappings created by mmap(). Keys are
+// addresses of the first byte of mappings.
+var mappings = struct {
+	sync.Mutex
+	m map[uintptr][]byte
+}{
+	m: map[uintptr][]byte{},
+}
+
+// address returns the address of the first element of slice, which is
+// used in C code as pointer.
+func address(addr interface{}) (uintptr, bool) {
+	if addr == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(addr)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return 0, false
+	}
+	return v.Pointer(), true
+}
+
+// Mmap handles mmap().
+//
+// Creates a new mapping of length bytes of the file referred to by the file
+// descriptor fd, starting at offset. The protection (PROT_READ, PROT_WRITE)
+// and flags (MAP_SHARED, MAP_PRIVATE, MAP_ANONYMOUS) are passed to operation
+// system as is. Mappings with flag MAP_SHARED are visible to other
+// processes and are written back to the file. The hint addr is ignored,
+// because Go cannot place the mapping at a given address. On success,
+// returns the slice of mapped memory. On error, MAP_FAILED is returned,
+// which is nil in Go code.
+func Mmap(addr interface{}, length uint32, prot, flags, fd int,
+	offset int32) []byte {
+	systemFd := -1
+	if fd >= 0 {
+		var ok bool
+		if systemFd, ok = getSystemFd(fd); !ok {
+			return nil
+		}
+	}
+	b, err := syscall.Mmap(systemFd, int64(offset), int(length), prot, flags)
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+	a, _ := address(b)
+	mappings.Lock()
+	mappings.m[a] = b
+	mappings.Unlock()
+	return b
+}
+
+// Munmap handles munmap().
+//
+// Deletes the mapping created by mmap(). The addr must be the pointer
+// returned by mmap(), the mapping is deleted fully. On success, returns 0.
+// On error, -1 is returned.
+func Munmap(addr interface{}, length uint32) int {
+	a, ok := address(addr)
+	if !ok || length == 0 {
+		return -1
+	}
+	mappings.Lock()
+	defer mappings.Unlock()
+	b, ok := mappings.m[a]
+	if !ok {
+		return -1
+	}
+	if err := syscall.Munmap(b); err != nil {
+		return -1
+	}
+	delete(mappings.m, a)
+	return 0
+}
+
+// Msync handles msync().
+//
+// Flushes changes made to the file mapped by mmap() back to the file. The
+// flags are MS_ASYNC or MS_SYNC and optional MS_INVALIDATE. The addr must be
+// aligned to page boundary, as in C. On success, returns 0. On error, -1 is
+// returned.
+func Msync(addr interface{}, length uint32, flags int) int {
+	a, ok := address(addr)
+	if !ok {
+		return -1
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, a, uintptr(length),
+		uintptr(flags))
+	if errno != 0 {
+		return -1
+	}
+	return 0
+}
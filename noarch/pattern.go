@@ -0,0 +1,15 @@
+package noarch
+
+import "container/list"
+
+// List is the doubly linked list of package container/list, which replaces
+// hand-rolled C linked lists by option "-pattern". The alias is used instead
+// of import of container/list in transpiled code, because the name of
+// package is often the name of C struct. Example of Go code:
+//
+//     type queue struct {
+//         c4goList noarch.List
+//     }
+//
+// The zero value of List is an empty list ready to use.
+type List = list.List
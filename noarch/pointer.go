@@ -0,0 +1,105 @@
+package noarch
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// The pointers of C are slices in Go. The first element of slice is the
+// element pointed to by the pointer and the capacity of slice reaches the
+// end of array, so the position of pointer in the array is known by the
+// capacity of slice:
+//
+//     int a[5];         ->  a := make([]int, 5)   // cap 5
+//     int *p = a + 2;   ->  p := a[2:]            // cap 3
+//     p - a             ->  cap(a) - cap(p) == 2
+//
+// The pointer to the end of array (one past the last element) is the slice
+// with zero capacity, Go does not keep the address of such slices. So the
+// pointers to the end of array are equal and greater than other pointers
+// into the same array.
+
+// PointerAdd handles the pointer arithmetic `ptr + offset` of C, where the
+// offset may be negative. Returns the slice of the same array.
+func PointerAdd[T any](ptr []T, offset int) []T {
+	if ptr == nil {
+		return nil
+	}
+	if 0 <= offset && offset <= len(ptr) {
+		return ptr[offset:]
+	}
+
+	// The elements before the first element of slice are not visible for
+	// Go, so the slice is created by the address of element.
+	length, capacity := len(ptr)-offset, cap(ptr)-offset
+	if capacity < 0 {
+		length, capacity = 0, 0
+	}
+	if length < 0 {
+		length = 0
+	}
+	var zero T
+	// conversion to pointer of array is the address of first element of
+	// slice, also for the slice with zero capacity
+	data := unsafe.Add(unsafe.Pointer((*[0]T)(ptr)),
+		offset*int(unsafe.Sizeof(zero)))
+	return unsafe.Slice((*T)(data), capacity)[:length]
+}
+
+// PointerDiff handles the difference of pointers `a - b` of C. Returns the
+// amount of elements between the pointers into the same array.
+func PointerDiff(a, b interface{}) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Slice && vb.Kind() == reflect.Slice {
+		return vb.Cap() - va.Cap()
+	}
+	return int(pointerAddress(va) - pointerAddress(vb))
+}
+
+// PointerCompare handles the comparison of pointers of C. Returns -1, 0 or
+// +1 if the address of a is less than, equal to or greater than the
+// address of b. The NULL pointer is less than other pointers.
+func PointerCompare(a, b interface{}) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != reflect.Slice || vb.Kind() != reflect.Slice ||
+		va.IsNil() || vb.IsNil() {
+		return compareAddress(pointerAddress(va), pointerAddress(vb))
+	}
+	if va.Cap() == 0 || vb.Cap() == 0 || arrayEnd(va) == arrayEnd(vb) {
+		// pointers into the same array
+		switch {
+		case va.Cap() > vb.Cap():
+			return -1
+		case va.Cap() < vb.Cap():
+			return 1
+		}
+		return 0
+	}
+	return compareAddress(va.Pointer(), vb.Pointer())
+}
+
+// pointerAddress returns the address of value pointed to by the pointer, or
+// zero for the NULL pointer.
+func pointerAddress(v reflect.Value) uintptr {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Ptr, reflect.UnsafePointer, reflect.Func,
+		reflect.Map, reflect.Chan:
+		return v.Pointer()
+	}
+	return 0
+}
+
+// arrayEnd returns the address of the end of array of slice.
+func arrayEnd(v reflect.Value) uintptr {
+	return v.Pointer() + uintptr(v.Cap())*v.Type().Elem().Size()
+}
+
+func compareAddress(a, b uintptr) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package noarch
+
+import "testing"
+
+func TestPointerAdd(t *testing.T) {
+	a := []int{10, 20, 30, 40, 50}
+
+	p := PointerAdd(a, 3)
+	if p[0] != 40 || len(p) != 2 || cap(p) != 2 {
+		t.Fatalf("forward: %v", p)
+	}
+	q := PointerAdd(p, -2)
+	if q[0] != 20 || len(q) != 4 || cap(q) != 4 {
+		t.Fatalf("backward: %v", q)
+	}
+	q[0] = 21
+	if a[1] != 21 {
+		t.Errorf("slice is not the same memory: %v", a)
+	}
+	if e := PointerAdd(a, 5); len(e) != 0 {
+		t.Errorf("end of array: %v", e)
+	}
+	var null []int
+	if v := PointerAdd(null, 1); v != nil {
+		t.Errorf("NULL pointer: %v", v)
+	}
+}
+
+func TestPointerDiff(t *testing.T) {
+	a := make([]float64, 30)
+	tests := []struct {
+		a, b interface{}
+		diff int
+	}{
+		{a[20:], a, 20},
+		{a, a[20:], -20},
+		{a[30:], a[1:], 29},
+		{PointerAdd(a[10:], -3), a[2:], 5},
+		{a[4:], a[4:], 0},
+	}
+	for i, tc := range tests {
+		if d := PointerDiff(tc.a, tc.b); d != tc.diff {
+			t.Errorf("%d: %d != %d", i, d, tc.diff)
+		}
+	}
+}
+
+func TestPointerCompare(t *testing.T) {
+	a := make([]byte, 10)
+	b := make([]byte, 10)
+	var null []byte
+	tests := []struct {
+		a, b   interface{}
+		result int
+	}{
+		{a, a[1:], -1},
+		{a[5:], a[1:], 1},
+		{a[5:], PointerAdd(a[6:], -1), 0},
+		{a[10:], a[9:], 1},
+		{a[9:], a[10:], -1},
+		{a[10:], PointerAdd(a, 10), 0},
+		{null, a, -1},
+		{a, null, 1},
+		{null, null, 0},
+		{nil, nil, 0},
+	}
+	for i, tc := range tests {
+		if r := PointerCompare(tc.a, tc.b); r != tc.result {
+			t.Errorf("%d: %d != %d", i, r, tc.result)
+		}
+	}
+	if PointerCompare(a, b) == 0 {
+		t.Errorf("pointers of different arrays are equal")
+	}
+}
@@ -0,0 +1,684 @@
+package noarch
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Error codes of pthread functions
+const (
+	errPerm     = 1
+	errSrch     = 3
+	errBusy     = 16
+	errInval    = 22
+	errDeadlk   = 35
+	errTimedout = 110
+)
+
+// Values of pthread.h constants
+const (
+	pthreadCreateJoinable = 0
+	pthreadCreateDetached = 1
+
+	pthreadMutexNormal     = 0
+	pthreadMutexRecursive  = 1
+	pthreadMutexErrorcheck = 2
+)
+
+// PthreadT represents the C type pthread_t from pthread.h - a thread ID.
+// Each thread is a goroutine and the thread ID is the ID of goroutine.
+type PthreadT uint32
+
+// PthreadAttrT represents the C type pthread_attr_t from pthread.h.
+type PthreadAttrT struct {
+	detachState int
+}
+
+// PthreadMutexattrT represents the C type pthread_mutexattr_t from
+// pthread.h.
+type PthreadMutexattrT struct {
+	kind int
+}
+
+// PthreadMutexT represents the C type pthread_mutex_t from pthread.h. Zero
+// value is an unlocked mutex, so PTHREAD_MUTEX_INITIALIZER is not needed.
+type PthreadMutexT struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	locked bool
+	kind   int
+	owner  uint64
+	count  int
+}
+
+// PthreadCondattrT represents the C type pthread_condattr_t from pthread.h.
+type PthreadCondattrT struct{}
+
+// PthreadCondT represents the C type pthread_cond_t from pthread.h. Zero
+// value is ready for use, so PTHREAD_COND_INITIALIZER is not needed.
+type PthreadCondT struct {
+	mu      sync.Mutex
+	waiters []chan struct{}
+}
+
+// PthreadOnceT represents the C type pthread_once_t from pthread.h. Zero
+// value is equal to PTHREAD_ONCE_INIT.
+type PthreadOnceT struct {
+	once sync.Once
+}
+
+// PthreadKeyT represents the C type pthread_key_t from pthread.h - a key of
+// thread-specific data.
+type PthreadKeyT uint32
+
+// thread is the state of thread created by PthreadCreate.
+type thread struct {
+	done     chan struct{}
+	result   interface{}
+	detached bool
+}
+
+// threads is the list of created and not joined threads.
+var threads = struct {
+	sync.Mutex
+	m  map[PthreadT]*thread
+	wg sync.WaitGroup
+}{
+	m: map[PthreadT]*thread{},
+}
+
+// keys is the thread-specific data.
+var keys = struct {
+	sync.Mutex
+	next        PthreadKeyT
+	destructors map[PthreadKeyT]func(interface{})
+	values      map[PthreadT]map[PthreadKeyT]interface{}
+}{
+	destructors: map[PthreadKeyT]func(interface{}){},
+	values:      map[PthreadT]map[PthreadKeyT]interface{}{},
+}
+
+// goroutineID returns the ID of current goroutine. Go does not provide the
+// ID, so it is taken from the header of stack trace: "goroutine 18 [...".
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	s := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if i := bytes.IndexByte(s, ' '); i > 0 {
+		id, _ := strconv.ParseUint(string(s[:i]), 10, 64)
+		return id
+	}
+	return 0
+}
+
+// PthreadCreate handles pthread_create().
+//
+// Starts a new thread in the calling process. The new thread starts
+// execution by invoking startRoutine(); arg is passed as the sole argument
+// of startRoutine(). The ID of the new thread is stored in the buffer
+// pointed to by thread. On success, returns 0.
+func PthreadCreate(thread []PthreadT, attr []PthreadAttrT,
+	startRoutine func(interface{}) interface{}, arg interface{}) int {
+	if startRoutine == nil {
+		return errInval
+	}
+	t := newThread(attr)
+	ids := make(chan PthreadT)
+	threads.wg.Add(1)
+	go func() {
+		id := PthreadSelf()
+		threads.Lock()
+		threads.m[id] = t
+		threads.Unlock()
+		ids <- id
+
+		defer exitThread(id, t)
+		t.result = startRoutine(arg)
+	}()
+	id := <-ids
+	if len(thread) > 0 {
+		thread[0] = id
+	}
+	return 0
+}
+
+func newThread(attr []PthreadAttrT) *thread {
+	t := &thread{done: make(chan struct{})}
+	if len(attr) > 0 && attr[0].detachState == pthreadCreateDetached {
+		t.detached = true
+	}
+	return t
+}
+
+// exitThread calls the destructors of thread-specific data and wakes up
+// threads, waiting the thread in PthreadJoin.
+func exitThread(id PthreadT, t *thread) {
+	keys.Lock()
+	values := keys.values[id]
+	delete(keys.values, id)
+	destructors := map[PthreadKeyT]func(interface{}){}
+	for k, d := range keys.destructors {
+		destructors[k] = d
+	}
+	keys.Unlock()
+	for k, v := range values {
+		if d := destructors[k]; d != nil && v != nil {
+			d(v)
+		}
+	}
+
+	removeThreadLocals(id)
+
+	threads.Lock()
+	if t.detached {
+		delete(threads.m, id)
+	}
+	threads.Unlock()
+	close(t.done)
+	threads.wg.Done()
+}
+
+// PthreadSelf handles pthread_self().
+//
+// Returns the ID of the calling thread.
+func PthreadSelf() PthreadT {
+	return PthreadT(goroutineID())
+}
+
+// PthreadEqual handles pthread_equal().
+//
+// Compares two thread identifiers. If the two thread IDs are equal, returns
+// a nonzero value; otherwise, it returns 0.
+func PthreadEqual(t1, t2 PthreadT) int {
+	if t1 == t2 {
+		return 1
+	}
+	return 0
+}
+
+// PthreadExit handles pthread_exit().
+//
+// Terminates the calling thread and returns a value via retval that is
+// available to another thread that calls pthread_join(). If the main
+// thread calls pthread_exit(), then the process waits all other threads
+// and exits with status 0 as exit().
+func PthreadExit(retval interface{}) {
+	threads.Lock()
+	t, ok := threads.m[PthreadSelf()]
+	threads.Unlock()
+	if !ok {
+		threads.wg.Wait()
+		Exit(0)
+	}
+	t.result = retval
+	runtime.Goexit()
+}
+
+// PthreadJoin handles pthread_join().
+//
+// Waits for the thread specified by thread to terminate. If retval is not
+// NULL, then the exit status of the target thread is copied into the
+// location pointed to by retval. On success, returns 0.
+func PthreadJoin(thread PthreadT, retval []interface{}) int {
+	if thread == PthreadSelf() {
+		return errDeadlk
+	}
+	threads.Lock()
+	t, ok := threads.m[thread]
+	threads.Unlock()
+	if !ok {
+		return errSrch
+	}
+	if t.detached {
+		return errInval
+	}
+	<-t.done
+	threads.Lock()
+	delete(threads.m, thread)
+	threads.Unlock()
+	if len(retval) > 0 {
+		retval[0] = t.result
+	}
+	return 0
+}
+
+// PthreadDetach handles pthread_detach().
+//
+// Marks the thread identified by thread as detached. When a detached thread
+// terminates, its resources are automatically released. On success,
+// returns 0.
+func PthreadDetach(thread PthreadT) int {
+	threads.Lock()
+	defer threads.Unlock()
+	t, ok := threads.m[thread]
+	if !ok {
+		return errSrch
+	}
+	if t.detached {
+		return errInval
+	}
+	t.detached = true
+	select {
+	case <-t.done:
+		delete(threads.m, thread)
+	default:
+	}
+	return 0
+}
+
+// PthreadAttrInit handles pthread_attr_init().
+//
+// Initializes the thread attributes object pointed to by attr with default
+// attribute values. On success, returns 0.
+func PthreadAttrInit(attr []PthreadAttrT) int {
+	attr[0] = PthreadAttrT{detachState: pthreadCreateJoinable}
+	return 0
+}
+
+// PthreadAttrDestroy handles pthread_attr_destroy().
+//
+// Destroys the thread attributes object. On success, returns 0.
+func PthreadAttrDestroy(attr []PthreadAttrT) int {
+	return 0
+}
+
+// PthreadAttrSetdetachstate handles pthread_attr_setdetachstate().
+//
+// Sets the detach state attribute of the thread attributes object to the
+// value PTHREAD_CREATE_DETACHED or PTHREAD_CREATE_JOINABLE. On success,
+// returns 0.
+func PthreadAttrSetdetachstate(attr []PthreadAttrT, detachstate int) int {
+	if detachstate != pthreadCreateJoinable &&
+		detachstate != pthreadCreateDetached {
+		return errInval
+	}
+	attr[0].detachState = detachstate
+	return 0
+}
+
+// PthreadAttrGetdetachstate handles pthread_attr_getdetachstate().
+//
+// Returns the detach state attribute of the thread attributes object in
+// the buffer pointed to by detachstate. On success, returns 0.
+func PthreadAttrGetdetachstate(attr []PthreadAttrT, detachstate []int) int {
+	detachstate[0] = attr[0].detachState
+	return 0
+}
+
+// PthreadMutexattrInit handles pthread_mutexattr_init().
+//
+// Initializes the mutex attributes object with default values. On success,
+// returns 0.
+func PthreadMutexattrInit(attr []PthreadMutexattrT) int {
+	attr[0] = PthreadMutexattrT{kind: pthreadMutexNormal}
+	return 0
+}
+
+// PthreadMutexattrDestroy handles pthread_mutexattr_destroy().
+//
+// Destroys the mutex attributes object. On success, returns 0.
+func PthreadMutexattrDestroy(attr []PthreadMutexattrT) int {
+	return 0
+}
+
+// PthreadMutexattrSettype handles pthread_mutexattr_settype().
+//
+// Sets the mutex type attribute: PTHREAD_MUTEX_NORMAL,
+// PTHREAD_MUTEX_RECURSIVE, PTHREAD_MUTEX_ERRORCHECK or
+// PTHREAD_MUTEX_DEFAULT. On success, returns 0.
+func PthreadMutexattrSettype(attr []PthreadMutexattrT, kind int) int {
+	switch kind {
+	case pthreadMutexNormal, pthreadMutexRecursive, pthreadMutexErrorcheck:
+		attr[0].kind = kind
+		return 0
+	}
+	return errInval
+}
+
+// PthreadMutexattrGettype handles pthread_mutexattr_gettype().
+//
+// Returns the mutex type attribute in the buffer pointed to by kind. On
+// success, returns 0.
+func PthreadMutexattrGettype(attr []PthreadMutexattrT, kind []int) int {
+	kind[0] = attr[0].kind
+	return 0
+}
+
+// PthreadMutexInit handles pthread_mutex_init().
+//
+// Initializes the mutex referenced by mutex with attributes specified by
+// attr. If attr is NULL, the default mutex attributes are used. On
+// success, returns 0.
+func PthreadMutexInit(mutex []PthreadMutexT, attr []PthreadMutexattrT) int {
+	if len(attr) > 0 {
+		return mutex[0].init(attr[0].kind)
+	}
+	return mutex[0].init(pthreadMutexNormal)
+}
+
+// PthreadMutexDestroy handles pthread_mutex_destroy().
+//
+// Destroys the mutex object. It is safe to destroy an initialized mutex
+// that is unlocked. On success, returns 0.
+func PthreadMutexDestroy(mutex []PthreadMutexT) int {
+	m := &mutex[0]
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locked {
+		return errBusy
+	}
+	return 0
+}
+
+// PthreadMutexLock handles pthread_mutex_lock().
+//
+// Locks the mutex. If the mutex is already locked, the calling thread
+// blocks until the mutex becomes available. On success, returns 0.
+func PthreadMutexLock(mutex []PthreadMutexT) int {
+	return mutex[0].lock(false, nil)
+}
+
+// PthreadMutexTrylock handles pthread_mutex_trylock().
+//
+// Works like pthread_mutex_lock(), except that if the mutex is currently
+// locked, the call returns immediately with EBUSY.
+func PthreadMutexTrylock(mutex []PthreadMutexT) int {
+	return mutex[0].lock(true, nil)
+}
+
+// PthreadMutexUnlock handles pthread_mutex_unlock().
+//
+// Releases the mutex. On success, returns 0.
+func PthreadMutexUnlock(mutex []PthreadMutexT) int {
+	return mutex[0].unlock()
+}
+
+func (m *PthreadMutexT) init(kind int) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.locked = false
+	m.owner = 0
+	m.count = 0
+	m.kind = kind
+	return 0
+}
+
+func (m *PthreadMutexT) condition() *sync.Cond {
+	if m.cond == nil {
+		m.cond = sync.NewCond(&m.mu)
+	}
+	return m.cond
+}
+
+// lock locks the mutex. If try is true, then the mutex is not waited. If
+// deadline is not nil, then the mutex is waited until the deadline.
+func (m *PthreadMutexT) lock(try bool, deadline *time.Time) int {
+	var id uint64
+	if m.kind != pthreadMutexNormal {
+		id = goroutineID()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locked && m.kind != pthreadMutexNormal && m.owner == id {
+		if m.kind == pthreadMutexRecursive {
+			m.count++
+			return 0
+		}
+		return errDeadlk
+	}
+	if try && m.locked {
+		return errBusy
+	}
+	if deadline != nil && m.locked {
+		// wake up the waiting at the deadline
+		timer := time.AfterFunc(deadline.Sub(time.Now()), func() {
+			m.mu.Lock()
+			m.condition().Broadcast()
+			m.mu.Unlock()
+		})
+		defer timer.Stop()
+	}
+	for m.locked {
+		if deadline != nil && !time.Now().Before(*deadline) {
+			return errTimedout
+		}
+		m.condition().Wait()
+	}
+	m.locked = true
+	m.owner = id
+	m.count = 1
+	return 0
+}
+
+func (m *PthreadMutexT) unlock() int {
+	var id uint64
+	if m.kind != pthreadMutexNormal {
+		id = goroutineID()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.kind != pthreadMutexNormal && (!m.locked || m.owner != id) {
+		return errPerm
+	}
+	if m.count--; m.count > 0 {
+		return 0
+	}
+	m.locked = false
+	m.condition().Broadcast()
+	return 0
+}
+
+// release fully unlocks the mutex and returns the count of recursive locks.
+func (m *PthreadMutexT) release() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := m.count
+	m.locked = false
+	m.count = 0
+	m.condition().Broadcast()
+	return count
+}
+
+// acquire locks the mutex again after release.
+func (m *PthreadMutexT) acquire(count int) {
+	var id uint64
+	if m.kind != pthreadMutexNormal {
+		id = goroutineID()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.locked {
+		m.condition().Wait()
+	}
+	m.locked = true
+	m.owner = id
+	m.count = count
+}
+
+// PthreadCondInit handles pthread_cond_init().
+//
+// Initializes the condition variable. On success, returns 0.
+func PthreadCondInit(cond []PthreadCondT, attr []PthreadCondattrT) int {
+	return cond[0].init()
+}
+
+// PthreadCondDestroy handles pthread_cond_destroy().
+//
+// Destroys the condition variable. On success, returns 0.
+func PthreadCondDestroy(cond []PthreadCondT) int {
+	c := &cond[0]
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.waiters) > 0 {
+		return errBusy
+	}
+	return 0
+}
+
+// PthreadCondWait handles pthread_cond_wait().
+//
+// Atomically releases mutex and causes the calling thread to block on the
+// condition variable. Before return, the mutex is locked again. On success,
+// returns 0.
+func PthreadCondWait(cond []PthreadCondT, mutex []PthreadMutexT) int {
+	return cond[0].wait(&mutex[0], nil)
+}
+
+// PthreadCondTimedwait handles pthread_cond_timedwait().
+//
+// Works like pthread_cond_wait(), except that an error ETIMEDOUT is
+// returned if the absolute time specified by abstime passes before the
+// condition is signaled.
+func PthreadCondTimedwait(cond []PthreadCondT, mutex []PthreadMutexT,
+	abstime []Timespec) int {
+	deadline := timespecToTime(abstime[0])
+	return cond[0].wait(&mutex[0], &deadline)
+}
+
+// PthreadCondSignal handles pthread_cond_signal().
+//
+// Unblocks at least one of the threads that are blocked on the condition
+// variable. On success, returns 0.
+func PthreadCondSignal(cond []PthreadCondT) int {
+	return cond[0].signal(false)
+}
+
+// PthreadCondBroadcast handles pthread_cond_broadcast().
+//
+// Unblocks all threads currently blocked on the condition variable. On
+// success, returns 0.
+func PthreadCondBroadcast(cond []PthreadCondT) int {
+	return cond[0].signal(true)
+}
+
+func (c *PthreadCondT) init() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waiters = nil
+	return 0
+}
+
+// signal unblocks one or all waiting threads.
+func (c *PthreadCondT) signal(all bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if all {
+		for _, w := range c.waiters {
+			close(w)
+		}
+		c.waiters = nil
+		return 0
+	}
+	if len(c.waiters) > 0 {
+		close(c.waiters[0])
+		c.waiters = c.waiters[1:]
+	}
+	return 0
+}
+
+func (c *PthreadCondT) wait(m *PthreadMutexT, deadline *time.Time) (
+	result int) {
+	w := make(chan struct{})
+	c.mu.Lock()
+	c.waiters = append(c.waiters, w)
+	c.mu.Unlock()
+
+	count := m.release()
+	defer m.acquire(count)
+
+	if deadline == nil {
+		<-w
+		return 0
+	}
+	timer := time.NewTimer(deadline.Sub(time.Now()))
+	defer timer.Stop()
+	select {
+	case <-w:
+		return 0
+	case <-timer.C:
+	}
+
+	// the condition may be signaled at the same time
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.waiters {
+		if c.waiters[i] == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return errTimedout
+		}
+	}
+	return 0
+}
+
+// PthreadOnce handles pthread_once().
+//
+// The first call of pthread_once() with a given onceControl calls
+// initRoutine with no arguments. Subsequent calls do not call initRoutine.
+// On success, returns 0.
+func PthreadOnce(onceControl []PthreadOnceT, initRoutine func()) int {
+	onceControl[0].once.Do(initRoutine)
+	return 0
+}
+
+// PthreadKeyCreate handles pthread_key_create().
+//
+// Creates a thread-specific data key visible to all threads in the process.
+// An optional destructor function may be associated with each key value.
+// At thread exit, if a key value has a non-NULL destructor pointer, and the
+// thread has a non-NULL value associated with that key, the destructor is
+// called with the value. On success, returns 0.
+func PthreadKeyCreate(key []PthreadKeyT, destructor func(interface{})) int {
+	keys.Lock()
+	defer keys.Unlock()
+	keys.next++
+	keys.destructors[keys.next] = destructor
+	key[0] = keys.next
+	return 0
+}
+
+// PthreadKeyDelete handles pthread_key_delete().
+//
+// Deletes a thread-specific data key. Destructor functions are not called.
+// On success, returns 0.
+func PthreadKeyDelete(key PthreadKeyT) int {
+	keys.Lock()
+	defer keys.Unlock()
+	if _, ok := keys.destructors[key]; !ok {
+		return errInval
+	}
+	delete(keys.destructors, key)
+	for _, values := range keys.values {
+		delete(values, key)
+	}
+	return 0
+}
+
+// PthreadGetspecific handles pthread_getspecific().
+//
+// Returns the value currently bound to the specified key on behalf of the
+// calling thread. If no value is associated with key, returns NULL.
+func PthreadGetspecific(key PthreadKeyT) interface{} {
+	keys.Lock()
+	defer keys.Unlock()
+	return keys.values[PthreadSelf()][key]
+}
+
+// PthreadSetspecific handles pthread_setspecific().
+//
+// Associates a thread-specific value with a key. On success, returns 0.
+func PthreadSetspecific(key PthreadKeyT, value interface{}) int {
+	keys.Lock()
+	defer keys.Unlock()
+	if _, ok := keys.destructors[key]; !ok {
+		return errInval
+	}
+	id := PthreadSelf()
+	if keys.values[id] == nil {
+		keys.values[id] = map[PthreadKeyT]interface{}{}
+	}
+	keys.values[id][key] = value
+	return 0
+}
@@ -0,0 +1,121 @@
+package noarch
+
+import (
+	"bufio"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+)
+
+// Passwd represents the C structure "struct passwd" from pwd.h:
+//
+//     struct passwd {
+//         char  *pw_name;
+//         char  *pw_passwd;
+//         uid_t  pw_uid;
+//         gid_t  pw_gid;
+//         char  *pw_gecos;
+//         char  *pw_dir;
+//         char  *pw_shell;
+//     };
+type Passwd struct {
+	PwName   []byte
+	PwPasswd []byte
+	PwUID    uint32
+	PwGID    uint32
+	PwGecos  []byte
+	PwDir    []byte
+	PwShell  []byte
+}
+
+// Getuid handles getuid().
+//
+// Returns the real user ID of the calling process.
+func Getuid() uint32 {
+	return uint32(os.Getuid())
+}
+
+// Geteuid handles geteuid().
+//
+// Returns the effective user ID of the calling process.
+func Geteuid() uint32 {
+	return uint32(os.Geteuid())
+}
+
+// Getgid handles getgid().
+//
+// Returns the real group ID of the calling process.
+func Getgid() uint32 {
+	return uint32(os.Getgid())
+}
+
+// Getegid handles getegid().
+//
+// Returns the effective group ID of the calling process.
+func Getegid() uint32 {
+	return uint32(os.Getegid())
+}
+
+// Getpwnam handles getpwnam().
+//
+// Returns a structure of type passwd with the fields of the record in the
+// user database that matches the user name. If the user is not found, NULL
+// is returned.
+func Getpwnam(name []byte) []Passwd {
+	u, err := user.Lookup(CStringToString(name))
+	if err != nil {
+		return nil
+	}
+	return userToPasswd(u)
+}
+
+// Getpwuid handles getpwuid().
+//
+// Returns a structure of type passwd with the fields of the record in the
+// user database that matches the user ID uid. If the user is not found,
+// NULL is returned.
+func Getpwuid(uid uint32) []Passwd {
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return nil
+	}
+	return userToPasswd(u)
+}
+
+// userToPasswd converts the Go user to the structure passwd. Package
+// os/user does not provide the login shell, so it is taken from file
+// /etc/passwd, if it is possible.
+func userToPasswd(u *user.User) []Passwd {
+	uid, _ := strconv.ParseUint(u.Uid, 10, 32)
+	gid, _ := strconv.ParseUint(u.Gid, 10, 32)
+	return []Passwd{{
+		PwName:   []byte(u.Username + "\x00"),
+		PwPasswd: []byte("x\x00"),
+		PwUID:    uint32(uid),
+		PwGID:    uint32(gid),
+		PwGecos:  []byte(u.Name + "\x00"),
+		PwDir:    []byte(u.HomeDir + "\x00"),
+		PwShell:  []byte(loginShell(u.Username) + "\x00"),
+	}}
+}
+
+// loginShell returns the login shell of user from file /etc/passwd. If the
+// user is not found, then the default shell "/bin/sh" is returned.
+func loginShell(name string) string {
+	shell := "/bin/sh"
+	f, err := os.Open("/etc/passwd")
+	if err != nil {
+		return shell
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// name:password:UID:GID:GECOS:directory:shell
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) == 7 && fields[0] == name && fields[6] != "" {
+			return fields[6]
+		}
+	}
+	return shell
+}
@@ -0,0 +1,263 @@
+package noarch
+
+import "sync"
+
+// Pseudo-random generators of glibc. Programs, tests of which depend on the
+// specific pseudo-random sequences, have the same sequences after
+// transpiling.
+//
+// Functions rand() and random() share the same additive feedback generator
+// with 31 words of state:
+//
+//     r[i] = (16807 * r[i-1]) % 2147483647,  for i = 1 ... 30, r[0] = seed
+//     r[i] = r[i-31] + r[i-3],               for i >= 31
+//
+// The first 310 values are discarded and the result is r[i] >> 1, so the
+// maximal value is RAND_MAX = 2147483647. Without seeding the state is the
+// same as after srand(1).
+//
+// Functions of drand48() family use the linear congruential generator with
+// 48 bits of state:
+//
+//     X[n+1] = (a * X[n] + c) mod 2^48, a = 0x5DEECE66D, c = 0xB
+//
+// Without seeding the state is zero, as in glibc.
+
+const (
+	randDegree     = 31
+	randSeparation = 3
+
+	rand48A = 0x5DEECE66D
+	rand48C = 0xB
+	rand48M = 1<<48 - 1
+)
+
+var randState = struct {
+	sync.Mutex
+	seeded bool
+	r      [randDegree]int32
+	f, b   int
+}{}
+
+var rand48State = struct {
+	sync.Mutex
+	x    uint64
+	a    uint64
+	c    uint64
+	init bool
+
+	// old seed, returned by seed48()
+	old [3]uint16
+}{}
+
+// seedRandom initializes the state of random() by the seed. It must be
+// called with locked randState.
+func seedRandom(seed uint32) {
+	if seed == 0 {
+		seed = 1
+	}
+	s := &randState
+	s.r[0] = int32(seed)
+	word := int32(seed)
+	for i := 1; i < randDegree; i++ {
+		// avoid overflowing 31 bits
+		hi := int64(word) / 127773
+		lo := int64(word) % 127773
+		word = int32(16807*lo - 2836*hi)
+		if word < 0 {
+			word += 2147483647
+		}
+		s.r[i] = word
+	}
+	s.f, s.b = randSeparation, 0
+	s.seeded = true
+	for i := 0; i < 10*randDegree; i++ {
+		nextRandom()
+	}
+}
+
+// nextRandom returns the next value of random(). It must be called with
+// locked randState.
+func nextRandom() int32 {
+	s := &randState
+	if !s.seeded {
+		seedRandom(1)
+	}
+	s.r[s.f] = int32(uint32(s.r[s.f]) + uint32(s.r[s.b]))
+	result := int32(uint32(s.r[s.f]) >> 1)
+	s.f = (s.f + 1) % randDegree
+	s.b = (s.b + 1) % randDegree
+	return result
+}
+
+// Rand handles rand().
+//
+// Returns a pseudo-random integer between 0 and RAND_MAX. The sequence is
+// the same as in glibc.
+func Rand() int {
+	return int(Random())
+}
+
+// Srand handles srand().
+//
+// Initializes the pseudo-random generator of rand() and random() by the
+// seed. The same seed gives the same sequence.
+func Srand(seed uint32) {
+	Srandom(seed)
+}
+
+// Random handles random().
+//
+// Returns a pseudo-random integer between 0 and RAND_MAX. See Rand.
+func Random() int32 {
+	randState.Lock()
+	defer randState.Unlock()
+	return nextRandom()
+}
+
+// Srandom handles srandom().
+//
+// Initializes the pseudo-random generator of rand() and random() by the
+// seed. See Srand.
+func Srandom(seed uint32) {
+	randState.Lock()
+	defer randState.Unlock()
+	seedRandom(seed)
+}
+
+// RandR handles rand_r().
+//
+// Returns a pseudo-random integer between 0 and RAND_MAX. The state of
+// generator is the value pointed by seed, so the function is reentrant.
+func RandR(seed []uint32) int {
+	next := seed[0]
+	step := func() uint32 {
+		next = next*1103515245 + 12345
+		return next / 65536
+	}
+	result := step() % 2048
+	result = result<<10 ^ step()%1024
+	result = result<<10 ^ step()%1024
+	seed[0] = next
+	return int(result)
+}
+
+// iterate48 returns the next state of drand48() family for the state xsubi.
+// It must be called with locked rand48State.
+func iterate48(x uint64) uint64 {
+	s := &rand48State
+	if !s.init {
+		s.a, s.c, s.init = rand48A, rand48C, true
+	}
+	return (s.a*x + s.c) & rand48M
+}
+
+func load48(xsubi []uint16) uint64 {
+	return uint64(xsubi[2])<<32 | uint64(xsubi[1])<<16 | uint64(xsubi[0])
+}
+
+func store48(xsubi []uint16, x uint64) {
+	xsubi[0], xsubi[1], xsubi[2] = uint16(x), uint16(x>>16), uint16(x>>32)
+}
+
+// next48 returns the next state of drand48() family. If xsubi is nil, then
+// the internal state is used.
+func next48(xsubi []uint16) uint64 {
+	s := &rand48State
+	s.Lock()
+	defer s.Unlock()
+	if xsubi == nil {
+		s.x = iterate48(s.x)
+		return s.x
+	}
+	x := iterate48(load48(xsubi))
+	store48(xsubi, x)
+	return x
+}
+
+// Drand48 handles drand48().
+//
+// Returns a pseudo-random double in the interval [0.0, 1.0).
+func Drand48() float64 {
+	return float64(next48(nil)) / (1 << 48)
+}
+
+// Erand48 handles erand48().
+//
+// Returns a pseudo-random double in the interval [0.0, 1.0) as Drand48,
+// but the state of generator is xsubi.
+func Erand48(xsubi []uint16) float64 {
+	return float64(next48(xsubi)) / (1 << 48)
+}
+
+// Lrand48 handles lrand48().
+//
+// Returns a pseudo-random non-negative long integer between 0 and 2^31.
+func Lrand48() int32 {
+	return int32(next48(nil) >> 17)
+}
+
+// Nrand48 handles nrand48().
+//
+// Returns a pseudo-random non-negative long integer as Lrand48, but the
+// state of generator is xsubi.
+func Nrand48(xsubi []uint16) int32 {
+	return int32(next48(xsubi) >> 17)
+}
+
+// Mrand48 handles mrand48().
+//
+// Returns a pseudo-random signed long integer between -2^31 and 2^31.
+func Mrand48() int32 {
+	return int32(uint32(next48(nil) >> 16))
+}
+
+// Jrand48 handles jrand48().
+//
+// Returns a pseudo-random signed long integer as Mrand48, but the state of
+// generator is xsubi.
+func Jrand48(xsubi []uint16) int32 {
+	return int32(uint32(next48(xsubi) >> 16))
+}
+
+// Srand48 handles srand48().
+//
+// Initializes the state of drand48() family by the 32 bits of seed and the
+// low bits 0x330E. Multiplier and addend are set to default values.
+func Srand48(seed int32) {
+	s := &rand48State
+	s.Lock()
+	defer s.Unlock()
+	s.x = uint64(uint32(seed))<<16 | 0x330E
+	s.a, s.c, s.init = rand48A, rand48C, true
+}
+
+// Seed48 handles seed48().
+//
+// Initializes the state of drand48() family by 48 bits of seed16v.
+// Multiplier and addend are set to default values. Returns the previous
+// state, which is overwritten by the next call.
+func Seed48(seed16v []uint16) []uint16 {
+	s := &rand48State
+	s.Lock()
+	defer s.Unlock()
+	store48(s.old[:], s.x)
+	s.x = load48(seed16v)
+	s.a, s.c, s.init = rand48A, rand48C, true
+	return s.old[:]
+}
+
+// Lcong48 handles lcong48().
+//
+// Initializes the state, multiplier and addend of drand48() family by
+// param: param[0:3] is the state, param[3:6] is multiplier and param[6] is
+// addend.
+func Lcong48(param []uint16) {
+	s := &rand48State
+	s.Lock()
+	defer s.Unlock()
+	s.x = load48(param[0:3])
+	s.a = load48(param[3:6])
+	s.c = uint64(param[6])
+	s.init = true
+}
@@ -0,0 +1,540 @@
+package noarch
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Values of regex.h flags of regcomp() as in glibc
+const (
+	regExtended = 0x1
+	regIcase    = 0x2
+	regNewline  = 0x4
+	regNosub    = 0x8
+)
+
+// Values of regex.h flags of regexec() as in glibc
+const (
+	regNotbol   = 0x1
+	regNoteol   = 0x2
+	regStartend = 0x4
+)
+
+// Values of regex.h error codes as in glibc
+const (
+	regNomatch  = 1
+	regBadpat   = 2
+	regEcollate = 3
+	regEctype   = 4
+	regEescape  = 5
+	regEsubreg  = 6
+	regEbrack   = 7
+	regEparen   = 8
+	regEbrace   = 9
+	regBadbr    = 10
+	regErange   = 11
+	regEspace   = 12
+	regBadrpt   = 13
+	regEend     = 14
+	regEsize    = 15
+	regErparen  = 16
+)
+
+// regErrors is messages of regerror() as in glibc.
+var regErrors = []string{
+	"Success",
+	"No match",
+	"Invalid regular expression",
+	"Invalid collation character",
+	"Invalid character class name",
+	"Trailing backslash",
+	"Invalid back reference",
+	"Unmatched [, [^, [:, [., or [=",
+	"Unmatched ( or \\(",
+	"Unmatched \\{",
+	"Invalid content of \\{\\}",
+	"Invalid range end",
+	"Memory exhausted",
+	"Invalid preceding regular expression",
+	"Premature end of regular expression",
+	"Regular expression too big",
+	"Unmatched ) or \\)",
+}
+
+// RegexT represents the C type "regex_t" from regex.h.
+//
+// The POSIX regular expression is translated to the syntax of package
+// regexp with leftmost-longest matching. Flags REG_NOTBOL and REG_NOTEOL of
+// regexec() change the meaning of anchors "^" and "$", so the expression is
+// compiled for each combination of that flags.
+type RegexT struct {
+	ReNsub uint32
+
+	pattern string
+	cflags  int
+	re      [4]*regexp.Regexp
+}
+
+// RegmatchT represents the C type "regmatch_t" from regex.h.
+type RegmatchT struct {
+	RmSo int
+	RmEo int
+}
+
+// regexNever is the Go regular expression, which never matches.
+const regexNever = `[^\x00-\x{10FFFF}]`
+
+// regexClasses is the names of character classes of POSIX.
+var regexClasses = map[string]bool{
+	"alnum": true, "alpha": true, "blank": true, "cntrl": true,
+	"digit": true, "graph": true, "lower": true, "print": true,
+	"punct": true, "space": true, "upper": true, "xdigit": true,
+}
+
+// regexTranslator converts POSIX basic (BRE) or extended (ERE) regular
+// expression to the syntax of package regexp.
+type regexTranslator struct {
+	rs       []rune
+	ext      bool
+	newline  bool
+	sentinel bool
+	bol, eol string
+	out      strings.Builder
+}
+
+// regexTranslate returns the Go regular expression for the POSIX regular
+// expression. On error, returns the error code of regex.h.
+//
+// Without REG_NEWLINE the anchors are the begin and the end of string, so
+// with REG_NOTBOL or REG_NOTEOL they never match. With REG_NEWLINE the
+// anchors also match around newlines and regexec() adds the character NUL
+// before or after the string, so the begin or the end of string is not the
+// begin or the end of line. That character is never matched.
+func regexTranslate(pattern string, cflags, eflags int) (string, int) {
+	t := regexTranslator{
+		rs:      []rune(pattern),
+		ext:     cflags&regExtended != 0,
+		newline: cflags&regNewline != 0,
+		bol:     "^",
+		eol:     "$",
+	}
+	if t.newline {
+		t.bol, t.eol = "(?m:^)", "(?m:$)"
+		t.sentinel = eflags&(regNotbol|regNoteol) != 0
+	} else {
+		if eflags&regNotbol != 0 {
+			t.bol = regexNever
+		}
+		if eflags&regNoteol != 0 {
+			t.eol = regexNever
+		}
+	}
+	if cflags&regIcase != 0 {
+		t.out.WriteString("(?i)")
+	}
+	if !t.newline {
+		t.out.WriteString("(?s)")
+	}
+	if code := t.translate(); code != 0 {
+		return "", code
+	}
+	return t.out.String(), 0
+}
+
+func (t *regexTranslator) translate() int {
+	// start is true at the begin of expression, where "*" is a literal
+	// and "^" is an anchor of BRE
+	start := true
+	for i := 0; i < len(t.rs); i++ {
+		c := t.rs[i]
+		atStart := start
+		start = false
+		switch c {
+		case '[':
+			n, code := t.bracket(t.rs[i:])
+			if code != 0 {
+				return code
+			}
+			i += n - 1
+		case '\\':
+			if i+1 == len(t.rs) {
+				return regEescape
+			}
+			i++
+			n, code := t.escape(t.rs[i:])
+			if code != 0 {
+				return code
+			}
+			i += n - 1
+			start = t.rs[i] == '(' || t.rs[i] == '|'
+		case '.':
+			if t.sentinel {
+				t.out.WriteString(`[^\n\x00]`)
+			} else {
+				t.out.WriteString(".")
+			}
+		case '^':
+			if t.ext || atStart {
+				t.out.WriteString(t.bol)
+				start = !t.ext
+			} else {
+				t.out.WriteString(`\^`)
+			}
+		case '$':
+			if t.ext || i+1 == len(t.rs) ||
+				(i+2 < len(t.rs) && t.rs[i+1] == '\\' &&
+					(t.rs[i+2] == ')' || t.rs[i+2] == '|')) {
+				t.out.WriteString(t.eol)
+			} else {
+				t.out.WriteString(`\$`)
+			}
+		case '*':
+			if atStart && !t.ext {
+				t.out.WriteString(`\*`)
+			} else {
+				t.out.WriteRune(c)
+			}
+		case '(':
+			if !t.ext {
+				t.out.WriteString(`\(`)
+				break
+			}
+			if i+1 < len(t.rs) && t.rs[i+1] == '?' {
+				// "(?" is not an extension of syntax in POSIX
+				return regBadrpt
+			}
+			t.out.WriteRune(c)
+			start = true
+		case '|':
+			if !t.ext {
+				t.out.WriteString(`\|`)
+				break
+			}
+			t.out.WriteRune(c)
+			start = true
+		case ')', '+', '?':
+			if t.ext {
+				t.out.WriteRune(c)
+			} else {
+				t.out.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		case '{':
+			if !t.ext {
+				t.out.WriteString(`\{`)
+				break
+			}
+			n, code := t.interval(t.rs[i+1:], "}")
+			if code != 0 {
+				// not an interval, so it is a literal
+				t.out.WriteString(`\{`)
+				break
+			}
+			i += n
+		default:
+			t.out.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return 0
+}
+
+// escape translates the character after backslash and returns the amount
+// of used characters.
+func (t *regexTranslator) escape(rs []rune) (int, int) {
+	c := rs[0]
+	if !t.ext {
+		switch c {
+		case '(', ')', '|', '+', '?':
+			t.out.WriteRune(c)
+			return 1, 0
+		case '{':
+			n, code := t.interval(rs[1:], `\}`)
+			if code != 0 {
+				return 0, code
+			}
+			return n + 1, 0
+		}
+	}
+	switch {
+	case '1' <= c && c <= '9':
+		// back-references are not supported by package regexp
+		return 0, regEsubreg
+	case c == '<' || c == '>':
+		t.out.WriteString(`\b`)
+	case c == '`':
+		t.out.WriteString(`\A`)
+	case c == '\'':
+		t.out.WriteString(`\z`)
+	case strings.ContainsRune("wWsSbB", c):
+		t.out.WriteRune('\\')
+		t.out.WriteRune(c)
+	default:
+		t.out.WriteString(regexp.QuoteMeta(string(c)))
+	}
+	return 1, 0
+}
+
+// interval translates the interval "{m}", "{m,}" or "{m,n}" without the
+// first brace and returns the amount of used characters.
+func (t *regexTranslator) interval(rs []rune, end string) (int, int) {
+	s := string(rs)
+	index := strings.Index(s, end)
+	if index < 0 {
+		return 0, regEbrace
+	}
+	content := s[:index]
+	parts := strings.Split(content, ",")
+	if len(parts) > 2 || parts[0] == "" {
+		return 0, regBadbr
+	}
+	for _, part := range parts {
+		for _, c := range part {
+			if c < '0' || '9' < c {
+				return 0, regBadbr
+			}
+		}
+	}
+	t.out.WriteString("{" + content + "}")
+	return len([]rune(content + end)), 0
+}
+
+// bracket translates the bracket expression and returns the amount of used
+// characters.
+func (t *regexTranslator) bracket(rs []rune) (int, int) {
+	quote := func(c rune) string {
+		if strings.ContainsRune(`\[]^-`, c) {
+			return `\` + string(c)
+		}
+		return string(c)
+	}
+
+	var items []string
+	i := 1
+	negate := i < len(rs) && rs[i] == '^'
+	if negate {
+		i++
+	}
+	for first := true; ; first = false {
+		if i >= len(rs) {
+			return 0, regEbrack
+		}
+		c := rs[i]
+		if c == ']' && !first {
+			i++
+			break
+		}
+		i++
+		if c == '[' && i < len(rs) && strings.ContainsRune(":.=", rs[i]) {
+			kind := rs[i]
+			rest := string(rs[i+1:])
+			end := strings.Index(rest, string(kind)+"]")
+			if end < 0 {
+				return 0, regEbrack
+			}
+			// "[:alpha:]" - skip name, kind and closing bracket
+			name := []rune(rest[:end])
+			i += len(name) + 3
+			if kind == ':' {
+				if !regexClasses[string(name)] {
+					return 0, regEctype
+				}
+				items = append(items, "[:"+string(name)+":]")
+				continue
+			}
+			if len(name) != 1 {
+				return 0, regEcollate
+			}
+			c = name[0]
+		}
+		if i+1 < len(rs) && rs[i] == '-' && rs[i+1] != ']' {
+			hi := rs[i+1]
+			i += 2
+			if hi < c {
+				return 0, regErange
+			}
+			items = append(items, quote(c)+"-"+quote(hi))
+			continue
+		}
+		items = append(items, quote(c))
+	}
+	if negate {
+		if t.newline {
+			items = append(items, `\n`)
+		}
+		if t.sentinel {
+			items = append(items, `\x00`)
+		}
+		t.out.WriteString("[^" + strings.Join(items, "") + "]")
+	} else {
+		t.out.WriteString("[" + strings.Join(items, "") + "]")
+	}
+	return i, 0
+}
+
+// regexErrorCode returns the error code of regex.h for the error of
+// package regexp.
+func regexErrorCode(err error) int {
+	e, ok := err.(*syntax.Error)
+	if !ok {
+		return regBadpat
+	}
+	switch e.Code {
+	case syntax.ErrMissingBracket:
+		return regEbrack
+	case syntax.ErrMissingParen:
+		return regEparen
+	case syntax.ErrUnexpectedParen:
+		return regErparen
+	case syntax.ErrInvalidRepeatSize:
+		return regBadbr
+	case syntax.ErrMissingRepeatArgument, syntax.ErrInvalidRepeatOp:
+		return regBadrpt
+	case syntax.ErrTrailingBackslash, syntax.ErrInvalidEscape:
+		return regEescape
+	case syntax.ErrInvalidCharRange:
+		return regErange
+	case syntax.ErrInvalidCharClass:
+		return regEctype
+	}
+	return regBadpat
+}
+
+// compile returns the compiled regular expression for flags REG_NOTBOL and
+// REG_NOTEOL of regexec().
+func (r *RegexT) compile(eflags int) (*regexp.Regexp, int) {
+	eflags &= regNotbol | regNoteol
+	if r.re[eflags] != nil {
+		return r.re[eflags], 0
+	}
+	s, code := regexTranslate(r.pattern, r.cflags, eflags)
+	if code != 0 {
+		return nil, code
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, regexErrorCode(err)
+	}
+	re.Longest()
+	r.re[eflags] = re
+	return re, 0
+}
+
+// Regcomp handles regcomp().
+//
+// Compiles the regular expression pattern into preg. The cflags may be
+// REG_EXTENDED for POSIX extended syntax (otherwise basic syntax is used),
+// REG_ICASE for ignoring case, REG_NOSUB for not reporting the position of
+// matches and REG_NEWLINE for matching of anchors around newlines.
+// Back-references are not supported. On success, returns zero. On error,
+// the error code is returned.
+func Regcomp(preg []RegexT, pattern []byte, cflags int) int {
+	r := RegexT{pattern: CStringToString(pattern), cflags: cflags}
+	re, code := r.compile(0)
+	if code != 0 {
+		return code
+	}
+	r.ReNsub = uint32(re.NumSubexp())
+	preg[0] = r
+	return 0
+}
+
+// Regexec handles regexec().
+//
+// Matches the string against the compiled regular expression preg. If
+// nmatch is not zero and REG_NOSUB was not used, then positions of the
+// match and of the parenthesized subexpressions are stored in pmatch, with
+// -1 for not matched subexpressions. The eflags may be REG_NOTBOL,
+// REG_NOTEOL and REG_STARTEND. On success, returns zero. If there is no
+// match, then REG_NOMATCH is returned.
+func Regexec(preg []RegexT, str []byte, nmatch uint32, pmatch []RegmatchT,
+	eflags int) int {
+	if len(preg) == 0 || preg[0].re[0] == nil {
+		return regBadpat
+	}
+	r := &preg[0]
+	re, code := r.compile(eflags)
+	if code != 0 {
+		return code
+	}
+
+	var s string
+	var offset int
+	if eflags&regStartend != 0 && len(pmatch) > 0 {
+		so, eo := pmatch[0].RmSo, pmatch[0].RmEo
+		if so < 0 || eo < so || eo > len(str) {
+			return regNomatch
+		}
+		s, offset = string(str[so:eo]), so
+	} else {
+		s = CStringToString(str)
+	}
+
+	var prefix, suffix int
+	if r.cflags&regNewline != 0 {
+		if eflags&regNotbol != 0 {
+			s, prefix = "\x00"+s, 1
+		}
+		if eflags&regNoteol != 0 {
+			s, suffix = s+"\x00", 1
+		}
+	}
+
+	var loc []int
+	if prefix == 0 && suffix == 0 {
+		loc = re.FindStringSubmatchIndex(s)
+	} else {
+		for _, l := range re.FindAllStringSubmatchIndex(s, -1) {
+			if l[0] >= prefix && l[1] <= len(s)-suffix {
+				loc = l
+				break
+			}
+		}
+	}
+	if loc == nil {
+		return regNomatch
+	}
+
+	if r.cflags&regNosub != 0 {
+		return 0
+	}
+	for i := 0; i < int(nmatch) && i < len(pmatch); i++ {
+		if 2*i+1 < len(loc) && loc[2*i] >= 0 {
+			pmatch[i].RmSo = loc[2*i] - prefix + offset
+			pmatch[i].RmEo = loc[2*i+1] - prefix + offset
+		} else {
+			pmatch[i].RmSo, pmatch[i].RmEo = -1, -1
+		}
+	}
+	return 0
+}
+
+// Regerror handles regerror().
+//
+// Turns the error code returned by regcomp() or regexec() into the message
+// and stores it in errbuf with terminating null byte, truncated to
+// errbufSize bytes. Returns the size of buffer needed for the full message.
+func Regerror(errcode int, preg []RegexT, errbuf []byte,
+	errbufSize uint32) uint32 {
+	msg := "Unknown error"
+	if 0 <= errcode && errcode < len(regErrors) {
+		msg = regErrors[errcode]
+	}
+	size := int(errbufSize)
+	if size > len(errbuf) {
+		size = len(errbuf)
+	}
+	if size > 0 {
+		n := copy(errbuf[:size-1], msg)
+		errbuf[n] = 0
+	}
+	return uint32(len(msg) + 1)
+}
+
+// Regfree handles regfree().
+//
+// Frees memory allocated by regcomp() for preg.
+func Regfree(preg []RegexT) {
+	if len(preg) > 0 {
+		preg[0] = RegexT{}
+	}
+}
@@ -0,0 +1,127 @@
+package noarch
+
+import (
+	"syscall"
+	"time"
+)
+
+// Rusage represents the C structure "struct rusage" from sys/resource.h:
+//
+//     struct rusage {
+//         struct timeval ru_utime;
+//         struct timeval ru_stime;
+//         long   ru_maxrss;
+//         long   ru_ixrss;
+//         long   ru_idrss;
+//         long   ru_isrss;
+//         long   ru_minflt;
+//         long   ru_majflt;
+//         long   ru_nswap;
+//         long   ru_inblock;
+//         long   ru_oublock;
+//         long   ru_msgsnd;
+//         long   ru_msgrcv;
+//         long   ru_nsignals;
+//         long   ru_nvcsw;
+//         long   ru_nivcsw;
+//     };
+type Rusage struct {
+	RuUtime    Timeval
+	RuStime    Timeval
+	RuMaxrss   int32
+	RuIxrss    int32
+	RuIdrss    int32
+	RuIsrss    int32
+	RuMinflt   int32
+	RuMajflt   int32
+	RuNswap    int32
+	RuInblock  int32
+	RuOublock  int32
+	RuMsgsnd   int32
+	RuMsgrcv   int32
+	RuNsignals int32
+	RuNvcsw    int32
+	RuNivcsw   int32
+}
+
+// Getrusage handles getrusage().
+//
+// Returns the resource usage of the calling process (who is RUSAGE_SELF)
+// or of its terminated children (who is RUSAGE_CHILDREN) in the structure
+// pointed to by usage. On success, zero is returned. On error, -1 is
+// returned.
+func Getrusage(who int, usage []Rusage) int {
+	var r syscall.Rusage
+	if len(usage) == 0 || syscall.Getrusage(who, &r) != nil {
+		return -1
+	}
+	usage[0] = Rusage{
+		RuUtime:    Timeval{TvSec: int32(r.Utime.Sec), TvUsec: int32(r.Utime.Usec)},
+		RuStime:    Timeval{TvSec: int32(r.Stime.Sec), TvUsec: int32(r.Stime.Usec)},
+		RuMaxrss:   int32(r.Maxrss),
+		RuIxrss:    int32(r.Ixrss),
+		RuIdrss:    int32(r.Idrss),
+		RuIsrss:    int32(r.Isrss),
+		RuMinflt:   int32(r.Minflt),
+		RuMajflt:   int32(r.Majflt),
+		RuNswap:    int32(r.Nswap),
+		RuInblock:  int32(r.Inblock),
+		RuOublock:  int32(r.Oublock),
+		RuMsgsnd:   int32(r.Msgsnd),
+		RuMsgrcv:   int32(r.Msgrcv),
+		RuNsignals: int32(r.Nsignals),
+		RuNvcsw:    int32(r.Nvcsw),
+		RuNivcsw:   int32(r.Nivcsw),
+	}
+	return 0
+}
+
+// Tms represents the C structure "struct tms" from sys/times.h:
+//
+//     struct tms {
+//         clock_t tms_utime;
+//         clock_t tms_stime;
+//         clock_t tms_cutime;
+//         clock_t tms_cstime;
+//     };
+type Tms struct {
+	TmsUtime  ClockT
+	TmsStime  ClockT
+	TmsCutime ClockT
+	TmsCstime ClockT
+}
+
+// clkTck is the value of sysconf(_SC_CLK_TCK) - the amount of clock ticks
+// per second of times().
+const clkTck = 100
+
+// startTime is the start of program. The elapsed time of times() is
+// counted from this time.
+var startTime = time.Now()
+
+// Times handles times().
+//
+// Stores the processor times of the calling process and its terminated
+// children in the structure pointed to by buf. Returns the elapsed real
+// time in clock ticks since an arbitrary point in the past, which is the
+// start of program. The amount of clock ticks per second is
+// sysconf(_SC_CLK_TCK). On error, -1 is returned.
+func Times(buf []Tms) ClockT {
+	var self, children syscall.Rusage
+	if syscall.Getrusage(syscall.RUSAGE_SELF, &self) != nil ||
+		syscall.Getrusage(syscall.RUSAGE_CHILDREN, &children) != nil {
+		return -1
+	}
+	ticks := func(tv syscall.Timeval) ClockT {
+		return ClockT(tv.Nano() / int64(time.Second/clkTck))
+	}
+	if len(buf) > 0 {
+		buf[0] = Tms{
+			TmsUtime:  ticks(self.Utime),
+			TmsStime:  ticks(self.Stime),
+			TmsCutime: ticks(children.Utime),
+			TmsCstime: ticks(children.Stime),
+		}
+	}
+	return ClockT(time.Since(startTime)/(time.Second/clkTck)) + 1
+}
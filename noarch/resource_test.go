@@ -0,0 +1,72 @@
+package noarch
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestSysconf(t *testing.T) {
+	tcs := []struct {
+		name     int
+		expected int32
+	}{
+		{scPagesize, int32(os.Getpagesize())},
+		{scNprocessorsOnln, int32(runtime.NumCPU())},
+		{scNprocessorsConf, int32(runtime.NumCPU())},
+		{scClkTck, clkTck},
+		{scLineMax, 2048},
+		{-2, -1},
+	}
+	for _, tc := range tcs {
+		if actual := Sysconf(tc.name); actual != tc.expected {
+			t.Errorf("sysconf(%d): expected %d, got %d",
+				tc.name, tc.expected, actual)
+		}
+	}
+	if v := Sysconf(scOpenMax); v == 0 || v < -1 {
+		t.Errorf("sysconf(_SC_OPEN_MAX): unexpected %d", v)
+	}
+	if v := Sysconf(scPhysPages); v <= 0 {
+		t.Errorf("sysconf(_SC_PHYS_PAGES): unexpected %d", v)
+	}
+}
+
+func TestGetrusage(t *testing.T) {
+	// spend some processor time
+	s := 0
+	for i := 0; i < 10000000; i++ {
+		s += i % 7
+	}
+	_ = s
+
+	usage := make([]Rusage, 1)
+	if r := Getrusage(0, usage); r != 0 {
+		t.Fatalf("getrusage: expected 0, got %d", r)
+	}
+	u := usage[0]
+	if u.RuUtime.TvSec == 0 && u.RuUtime.TvUsec == 0 &&
+		u.RuStime.TvSec == 0 && u.RuStime.TvUsec == 0 {
+		t.Errorf("getrusage: processor time is zero")
+	}
+	if u.RuMaxrss <= 0 {
+		t.Errorf("getrusage: maximal resident set size is %d", u.RuMaxrss)
+	}
+	if r := Getrusage(0, nil); r != -1 {
+		t.Errorf("getrusage with NULL: expected -1, got %d", r)
+	}
+}
+
+func TestTimes(t *testing.T) {
+	buf := make([]Tms, 1)
+	first := Times(buf)
+	if first <= 0 {
+		t.Fatalf("times: expected positive value, got %d", first)
+	}
+	if buf[0].TmsUtime < 0 || buf[0].TmsStime < 0 {
+		t.Errorf("times: negative processor time %v", buf[0])
+	}
+	if second := Times(nil); second < first {
+		t.Errorf("times: elapsed time decreased from %d to %d", first, second)
+	}
+}
@@ -0,0 +1,336 @@
+package noarch
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Values of search.h enumerations ACTION and VISIT
+const (
+	searchFind  = 0
+	searchEnter = 1
+
+	visitPreorder  = 0
+	visitPostorder = 1
+	visitEndorder  = 2
+	visitLeaf      = 3
+)
+
+// Entry represents the C structure "ENTRY" from search.h:
+//
+//     typedef struct entry {
+//         char *key;
+//         void *data;
+//     } ENTRY;
+type Entry struct {
+	Key  []byte
+	Data interface{}
+}
+
+// hsearchTable - the hash table of hcreate(), hsearch() and hdestroy().
+// Values of map are the entries returned to C code, so the changes of
+// field data are saved in the table.
+var hsearchTable = struct {
+	sync.Mutex
+	entries map[string][]Entry
+	size    int
+}{}
+
+// Hcreate handles hcreate().
+//
+// Creates the hash table with nel entries. Only one hash table can be used
+// at a time, so if the table already exists, then zero is returned. On
+// success, a nonzero value is returned.
+func Hcreate(nel uint32) int {
+	hsearchTable.Lock()
+	defer hsearchTable.Unlock()
+	if hsearchTable.entries != nil {
+		return 0
+	}
+	hsearchTable.entries = map[string][]Entry{}
+	hsearchTable.size = int(nel)
+	return 1
+}
+
+// Hsearch handles hsearch().
+//
+// Searches the hash table for an item with the same key as item. If the
+// action is ENTER and the item is not found, then the item is inserted into
+// the table. Returns a pointer to the entry of table, or NULL if the item is
+// not found or the table is full.
+func Hsearch(item Entry, action int) []Entry {
+	hsearchTable.Lock()
+	defer hsearchTable.Unlock()
+	if hsearchTable.entries == nil || item.Key == nil {
+		return nil
+	}
+	key := CStringToString(item.Key)
+	if e, ok := hsearchTable.entries[key]; ok {
+		return e
+	}
+	if action != searchEnter ||
+		len(hsearchTable.entries) >= hsearchTable.size {
+		return nil
+	}
+	e := []Entry{item}
+	hsearchTable.entries[key] = e
+	return e
+}
+
+// Hdestroy handles hdestroy().
+//
+// Frees the hash table created by hcreate(). The keys and data of entries
+// are not freed.
+func Hdestroy() {
+	hsearchTable.Lock()
+	defer hsearchTable.Unlock()
+	hsearchTable.entries = nil
+	hsearchTable.size = 0
+}
+
+// tnode is the node of binary search tree of tsearch(). The tree is a
+// treap, so it is balanced by the random priorities of nodes.
+type tnode struct {
+	key         interface{}
+	slot        interface{}
+	left, right *tnode
+	priority    uint64
+}
+
+// tnodeCounter - sequence of created nodes for priorities of nodes.
+var tnodeCounter uint64
+
+// newTnode returns the new node of tree with key. The value of node for C
+// code is a pointer to the key, so it is a slice with the key.
+func newTnode(key interface{}) *tnode {
+	// mix of sequence number as in splitmix64
+	z := atomic.AddUint64(&tnodeCounter, 1) * 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	n := &tnode{key: key, priority: z ^ (z >> 31)}
+	if key == nil {
+		n.slot = []interface{}{nil}
+		return n
+	}
+	slot := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(key)), 1, 1)
+	slot.Index(0).Set(reflect.ValueOf(key))
+	n.slot = slot.Interface()
+	return n
+}
+
+// treeRoot returns the root of tree from rootp.
+func treeRoot(rootp []interface{}) *tnode {
+	if len(rootp) == 0 {
+		return nil
+	}
+	root, _ := rootp[0].(*tnode)
+	return root
+}
+
+// setTreeRoot saves the root of tree. Empty tree is saved as NULL.
+func setTreeRoot(rootp []interface{}, root *tnode) {
+	if root == nil {
+		rootp[0] = nil
+		return
+	}
+	rootp[0] = root
+}
+
+// treeInsert inserts the key into tree, if it is not found. Returns the new
+// root of tree and the node with key.
+func treeInsert(root *tnode, key interface{},
+	compar func(interface{}, interface{}) int) (_ *tnode, found *tnode) {
+	if root == nil {
+		n := newTnode(key)
+		return n, n
+	}
+	c := compar(key, root.key)
+	switch {
+	case c < 0:
+		root.left, found = treeInsert(root.left, key, compar)
+		if root.left.priority > root.priority {
+			root = rotateRight(root)
+		}
+	case c > 0:
+		root.right, found = treeInsert(root.right, key, compar)
+		if root.right.priority > root.priority {
+			root = rotateLeft(root)
+		}
+	default:
+		found = root
+	}
+	return root, found
+}
+
+func rotateRight(n *tnode) *tnode {
+	l := n.left
+	n.left, l.right = l.right, n
+	return l
+}
+
+func rotateLeft(n *tnode) *tnode {
+	r := n.right
+	n.right, r.left = r.left, n
+	return r
+}
+
+// Tsearch handles tsearch().
+//
+// Searches the tree for the key, the variable pointed to by rootp is the
+// root of tree, it is NULL for empty tree. If the key is not found, then it
+// is added to the tree. Function compar compares two keys and returns
+// negative, zero or positive value. Returns a pointer to the node of tree,
+// the first field of node is a pointer to the key.
+func Tsearch(key interface{}, rootp []interface{},
+	compar func(interface{}, interface{}) int) interface{} {
+	if len(rootp) == 0 {
+		return nil
+	}
+	root, found := treeInsert(treeRoot(rootp), key, compar)
+	setTreeRoot(rootp, root)
+	return found.slot
+}
+
+// Tfind handles tfind().
+//
+// Works like tsearch(), but if the key is not found, then NULL is returned
+// and the tree is not changed.
+func Tfind(key interface{}, rootp []interface{},
+	compar func(interface{}, interface{}) int) interface{} {
+	for n := treeRoot(rootp); n != nil; {
+		c := compar(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.slot
+		}
+	}
+	return nil
+}
+
+// Tdelete handles tdelete().
+//
+// Deletes the key from the tree. Returns a pointer to the parent of the
+// deleted node, or an unspecified non-NULL pointer if the root was deleted.
+// If the key is not found, then NULL is returned.
+func Tdelete(key interface{}, rootp []interface{},
+	compar func(interface{}, interface{}) int) interface{} {
+	// find the node and the link to it from parent
+	root := treeRoot(rootp)
+	var parent *tnode
+	ref := &root
+	for *ref != nil {
+		c := compar(key, (*ref).key)
+		if c == 0 {
+			break
+		}
+		parent = *ref
+		if c < 0 {
+			ref = &(*ref).left
+		} else {
+			ref = &(*ref).right
+		}
+	}
+	if *ref == nil {
+		return nil
+	}
+
+	// rotate the node down, until it has at most one child
+	n := *ref
+	for n.left != nil && n.right != nil {
+		if n.left.priority > n.right.priority {
+			*ref = rotateRight(n)
+			parent = *ref
+			ref = &parent.right
+		} else {
+			*ref = rotateLeft(n)
+			parent = *ref
+			ref = &parent.left
+		}
+	}
+	if n.left != nil {
+		*ref = n.left
+	} else {
+		*ref = n.right
+	}
+	setTreeRoot(rootp, root)
+
+	if parent == nil {
+		return rootp
+	}
+	return parent.slot
+}
+
+// Twalk handles twalk().
+//
+// Traverses the tree from root. Function action is called three times for
+// each internal node: before visiting the left child (preorder), after the
+// left child (postorder) and after the right child (endorder), and once for
+// each leaf (leaf). The depth of root is zero.
+func Twalk(root interface{}, action func(interface{}, int, int)) {
+	n, _ := root.(*tnode)
+	if n == nil || action == nil {
+		return
+	}
+	twalk(n, action, 0)
+}
+
+func twalk(n *tnode, action func(interface{}, int, int), depth int) {
+	if n.left == nil && n.right == nil {
+		action(n.slot, visitLeaf, depth)
+		return
+	}
+	action(n.slot, visitPreorder, depth)
+	if n.left != nil {
+		twalk(n.left, action, depth+1)
+	}
+	action(n.slot, visitPostorder, depth)
+	if n.right != nil {
+		twalk(n.right, action, depth+1)
+	}
+	action(n.slot, visitEndorder, depth)
+}
+
+// Lfind handles lfind().
+//
+// Performs a linear search for the key in the array base of nmemb elements
+// of size bytes. Returns a pointer to the matching element, or NULL if the
+// key is not found.
+func Lfind(key interface{}, base interface{}, nmemb []uint32, size uint32,
+	compar func(interface{}, interface{}) int) interface{} {
+	arr := reflect.ValueOf(base)
+	if arr.Kind() != reflect.Slice {
+		return nil
+	}
+	for i := 0; i < int(nmemb[0]) && i < arr.Len(); i++ {
+		element := arr.Slice(i, arr.Len()).Interface()
+		if compar(key, element) == 0 {
+			return element
+		}
+	}
+	return nil
+}
+
+// Lsearch handles lsearch().
+//
+// Works like lfind(), but if the key is not found, then it is added to the
+// end of array and the value pointed to by nmemb is incremented. The array
+// must have space for the new element.
+func Lsearch(key interface{}, base interface{}, nmemb []uint32, size uint32,
+	compar func(interface{}, interface{}) int) interface{} {
+	if element := Lfind(key, base, nmemb, size, compar); element != nil {
+		return element
+	}
+	arr := reflect.ValueOf(base)
+	n := int(nmemb[0])
+	if arr.Kind() != reflect.Slice || n >= arr.Len() {
+		return nil
+	}
+	reflect.Copy(arr.Slice(n, n+1), reflect.ValueOf(key))
+	nmemb[0]++
+	return arr.Slice(n, arr.Len()).Interface()
+}
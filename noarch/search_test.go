@@ -0,0 +1,140 @@
+package noarch
+
+import "testing"
+
+func compareInt(a, b interface{}) int {
+	return a.([]int)[0] - b.([]int)[0]
+}
+
+func TestHsearch(t *testing.T) {
+	if Hsearch(Entry{Key: []byte("a\x00")}, searchFind) != nil {
+		t.Fatalf("search without table")
+	}
+	if Hcreate(2) == 0 {
+		t.Fatalf("cannot create table")
+	}
+	defer Hdestroy()
+	if Hcreate(2) != 0 {
+		t.Errorf("second table is created")
+	}
+
+	e := Hsearch(Entry{Key: []byte("one\x00"), Data: 1}, searchEnter)
+	if e == nil || e[0].Data != 1 {
+		t.Fatalf("enter: %v", e)
+	}
+	e[0].Data = 11
+	if e := Hsearch(Entry{Key: []byte("one\x00xyz")}, searchFind); e == nil ||
+		e[0].Data != 11 {
+		t.Errorf("find: %v", e)
+	}
+	// existed item is not changed
+	if e := Hsearch(Entry{Key: []byte("one\x00"), Data: 2}, searchEnter); e == nil ||
+		e[0].Data != 11 {
+		t.Errorf("enter existed: %v", e)
+	}
+	if Hsearch(Entry{Key: []byte("two\x00")}, searchFind) != nil {
+		t.Errorf("found not existed item")
+	}
+	if Hsearch(Entry{Key: []byte("two\x00"), Data: 2}, searchEnter) == nil {
+		t.Errorf("enter second item")
+	}
+	if Hsearch(Entry{Key: []byte("three\x00"), Data: 3}, searchEnter) != nil {
+		t.Errorf("table is full")
+	}
+}
+
+func TestTsearch(t *testing.T) {
+	root := []interface{}{nil}
+	for _, v := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		r := Tsearch([]int{v}, root, compareInt)
+		if r.([][]int)[0][0] != v {
+			t.Fatalf("tsearch of %d: %v", v, r)
+		}
+	}
+	key := []int{4}
+	r := Tsearch(key, root, compareInt)
+	if r.([][]int)[0][0] != 4 || &r.([][]int)[0][0] == &key[0] {
+		t.Errorf("tsearch of existed key: %v", r)
+	}
+	if r := Tfind([]int{7}, root, compareInt); r == nil || r.([][]int)[0][0] != 7 {
+		t.Errorf("tfind: %v", r)
+	}
+	if Tfind([]int{10}, root, compareInt) != nil {
+		t.Errorf("tfind of not existed key")
+	}
+
+	walk := func() (keys []int) {
+		Twalk(root[0], func(node interface{}, which, depth int) {
+			if which == visitPostorder || which == visitLeaf {
+				keys = append(keys, node.([][]int)[0][0])
+			}
+		})
+		return
+	}
+	keys := walk()
+	if len(keys) != 9 {
+		t.Fatalf("twalk: %v", keys)
+	}
+	for i := range keys {
+		if keys[i] != i+1 {
+			t.Fatalf("twalk: %v", keys)
+		}
+	}
+
+	if Tdelete([]int{10}, root, compareInt) != nil {
+		t.Errorf("tdelete of not existed key")
+	}
+	for _, v := range []int{5, 1, 9, 3, 7, 2, 8, 4, 6} {
+		if Tdelete([]int{v}, root, compareInt) == nil {
+			t.Fatalf("tdelete of %d", v)
+		}
+		if Tfind([]int{v}, root, compareInt) != nil {
+			t.Fatalf("key %d is not deleted", v)
+		}
+	}
+	if root[0] != nil || len(walk()) != 0 {
+		t.Errorf("tree is not empty: %v", root[0])
+	}
+}
+
+func TestTsearchBalance(t *testing.T) {
+	root := []interface{}{nil}
+	size := 1 << 12
+	for i := 0; i < size; i++ {
+		Tsearch([]int{i}, root, compareInt)
+	}
+	maxDepth := 0
+	Twalk(root[0], func(node interface{}, which, depth int) {
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	})
+	if maxDepth > 60 {
+		t.Errorf("tree is not balanced, depth is %d", maxDepth)
+	}
+}
+
+func TestLsearch(t *testing.T) {
+	base := make([]int, 4)
+	nmemb := []uint32{0}
+	for _, v := range []int{3, 1, 3, 2} {
+		r := Lsearch([]int{v}, base, nmemb, 8, compareInt)
+		if r == nil || r.([]int)[0] != v {
+			t.Fatalf("lsearch of %d: %v", v, r)
+		}
+	}
+	if nmemb[0] != 3 || base[0] != 3 || base[1] != 1 || base[2] != 2 {
+		t.Errorf("array %v, %d", base, nmemb[0])
+	}
+	if r := Lfind([]int{1}, base, nmemb, 8, compareInt); r == nil ||
+		&r.([]int)[0] != &base[1] {
+		t.Errorf("lfind: %v", r)
+	}
+	if Lfind([]int{0}, base, nmemb, 8, compareInt) != nil {
+		t.Errorf("lfind of not existed key")
+	}
+	Lsearch([]int{4}, base, nmemb, 8, compareInt)
+	if Lsearch([]int{5}, base, nmemb, 8, compareInt) != nil || nmemb[0] != 4 {
+		t.Errorf("array is full")
+	}
+}
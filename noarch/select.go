@@ -0,0 +1,219 @@
+package noarch
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// Values of poll.h events as in Linux and macOS
+const (
+	pollIn   = 0x1
+	pollPri  = 0x2
+	pollOut  = 0x4
+	pollErr  = 0x8
+	pollHup  = 0x10
+	pollNval = 0x20
+)
+
+// Pollfd represents the C type "struct pollfd" from poll.h.
+type Pollfd struct {
+	Fd      int
+	Events  int16
+	Revents int16
+}
+
+// systemPollfd is "struct pollfd" of operation system. It is the same on
+// all platforms.
+type systemPollfd struct {
+	fd      int32
+	events  int16
+	revents int16
+}
+
+// Timeval represents the C type "struct timeval" from sys/time.h.
+//
+//     struct timeval {
+//         time_t      tv_sec;
+//         suseconds_t tv_usec;
+//     };
+type Timeval struct {
+	TvSec  int32
+	TvUsec int32
+}
+
+// FdSet represents the C type "fd_set" from sys/select.h.
+//
+// Macros FD_ZERO, FD_SET, FD_CLR and FD_ISSET are expanded by preprocessor
+// in operations with bits of the field __fds_bits, which is an array of C
+// type long. In Go the type long is int32, but the index of element and the
+// position of bit are calculated with the C size of long. So, the file
+// descriptor fd is the bit fd%64 of the element fd/64 and only file
+// descriptors with fd%64 < 32 are stored. File descriptors of C program are
+// the lowest free indexes in the table of opened files, so it is enough for
+// programs with less than 32 opened files.
+type FdSet struct {
+	FdsBits [16]int32
+}
+
+// fdSetBits is the amount of bits in element of FdSet.FdsBits in C.
+const fdSetBits = 64
+
+// fdIsSet returns true, if the file descriptor fd is in the set.
+func fdIsSet(set []FdSet, fd int) bool {
+	if len(set) == 0 || fd/fdSetBits >= len(set[0].FdsBits) ||
+		fd%fdSetBits >= 32 {
+		return false
+	}
+	return set[0].FdsBits[fd/fdSetBits]&(1<<uint(fd%fdSetBits)) != 0
+}
+
+// fdClear removes the file descriptor fd from the set.
+func fdClear(set []FdSet, fd int) {
+	if !fdIsSet(set, fd) {
+		return
+	}
+	set[0].FdsBits[fd/fdSetBits] &^= 1 << uint(fd%fdSetBits)
+}
+
+// systemPoll calls poll() of operation system. Calls interrupted by signals
+// of Go runtime are repeated with the rest of timeout.
+func systemPoll(fds []systemPollfd, timeout int) (int, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(time.Duration(timeout) * time.Millisecond)
+	}
+	var p unsafe.Pointer
+	if len(fds) > 0 {
+		p = unsafe.Pointer(&fds[0])
+	}
+	for {
+		n, _, errno := syscall.Syscall(syscall.SYS_POLL,
+			uintptr(p), uintptr(len(fds)), uintptr(timeout))
+		if errno == syscall.EINTR {
+			if timeout > 0 {
+				timeout = int(time.Until(deadline) / time.Millisecond)
+				if timeout < 0 {
+					timeout = 0
+				}
+			}
+			continue
+		}
+		if errno != 0 {
+			return -1, errno
+		}
+		return int(n), nil
+	}
+}
+
+// Poll handles poll().
+//
+// Waits for one of a set of file descriptors to become ready to perform I/O.
+// The field Fd of each element of fds is the file descriptor, the field
+// Events is the events of interest and the field Revents is filled by the
+// events that actually occurred. The timeout is the number of milliseconds
+// and a negative value means an infinite timeout. On success, returns the
+// number of elements with nonzero Revents, zero indicates a timeout. On
+// error, -1 is returned.
+func Poll(fds []Pollfd, nfds uint32, timeout int) int {
+	if int(nfds) > len(fds) {
+		return -1
+	}
+	system := make([]systemPollfd, nfds)
+	for i := range system {
+		// negative file descriptors are ignored by poll()
+		system[i].fd = -1
+		fds[i].Revents = 0
+		if fds[i].Fd < 0 {
+			continue
+		}
+		fd, ok := getSystemFd(fds[i].Fd)
+		if !ok {
+			fds[i].Revents = pollNval
+			timeout = 0
+			continue
+		}
+		system[i].fd = int32(fd)
+		system[i].events = fds[i].Events
+	}
+	if _, err := systemPoll(system, timeout); err != nil {
+		return -1
+	}
+	var amount int
+	for i := range system {
+		if system[i].fd >= 0 {
+			fds[i].Revents = system[i].revents
+		}
+		if fds[i].Revents != 0 {
+			amount++
+		}
+	}
+	return amount
+}
+
+// Select handles select().
+//
+// Allows a program to monitor multiple file descriptors less than nfds,
+// waiting until one or more of the file descriptors become ready for reading
+// (readfds), writing (writefds) or have an exceptional condition pending
+// (exceptfds). On return, the sets are modified in place to indicate which
+// file descriptors are ready. If timeout is NULL, select() blocks
+// indefinitely. On success, returns the number of file descriptors contained
+// in the three returned sets, zero indicates a timeout. On error, -1 is
+// returned.
+func Select(nfds int, readfds, writefds, exceptfds []FdSet,
+	timeout []Timeval) int {
+	var fds []Pollfd
+	for fd := 0; fd < nfds; fd++ {
+		var events int16
+		if fdIsSet(readfds, fd) {
+			events |= pollIn
+		}
+		if fdIsSet(writefds, fd) {
+			events |= pollOut
+		}
+		if fdIsSet(exceptfds, fd) {
+			events |= pollPri
+		}
+		if events != 0 {
+			fds = append(fds, Pollfd{Fd: fd, Events: events})
+		}
+	}
+
+	ms := -1
+	if len(timeout) > 0 {
+		ms = int(timeout[0].TvSec)*1000 + (int(timeout[0].TvUsec)+999)/1000
+	}
+	if Poll(fds, uint32(len(fds)), ms) < 0 {
+		return -1
+	}
+
+	var amount int
+	for _, f := range fds {
+		if f.Revents&pollNval != 0 {
+			return -1
+		}
+		if f.Events&pollIn != 0 {
+			if f.Revents&(pollIn|pollHup|pollErr) != 0 {
+				amount++
+			} else {
+				fdClear(readfds, f.Fd)
+			}
+		}
+		if f.Events&pollOut != 0 {
+			if f.Revents&(pollOut|pollErr) != 0 {
+				amount++
+			} else {
+				fdClear(writefds, f.Fd)
+			}
+		}
+		if f.Events&pollPri != 0 {
+			if f.Revents&pollPri != 0 {
+				amount++
+			} else {
+				fdClear(exceptfds, f.Fd)
+			}
+		}
+	}
+	return amount
+}
@@ -0,0 +1,676 @@
+package noarch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// Values of netdb.h error codes of getaddrinfo() as in glibc
+const (
+	eaiBadflags = -1
+	eaiNoname   = -2
+	eaiFamily   = -6
+	eaiSocktype = -7
+	eaiService  = -8
+)
+
+// Values of netdb.h flags of getaddrinfo()
+const (
+	aiPassive     = 0x1
+	aiCanonname   = 0x2
+	aiNumerichost = 0x4
+)
+
+// InAddr represents the C type "struct in_addr" from netinet/in.h. The
+// address is stored in network byte order.
+type InAddr struct {
+	SAddr uint32
+}
+
+// In6Addr represents the C type "struct in6_addr" from netinet/in.h.
+type In6Addr struct {
+	S6Addr [16]byte
+}
+
+// Sockaddr represents the C types "struct sockaddr", "struct sockaddr_in",
+// "struct sockaddr_in6" and "struct sockaddr_storage" from sys/socket.h and
+// netinet/in.h. In C these structs are casted to each other, so in Go all of
+// them are the same type and cast of pointers is not needed. The fields with
+// the same meaning are shared, for example "sin_port" and "sin6_port" are
+// the field Port.
+type Sockaddr struct {
+	Family   uint16
+	Port     uint16
+	Addr     InAddr
+	Zero     [8]byte
+	Flowinfo uint32
+	Addr6    In6Addr
+	ScopeID  uint32
+	Data     [14]byte
+}
+
+// Addrinfo represents the C type "struct addrinfo" from netdb.h.
+type Addrinfo struct {
+	AiFlags     int
+	AiFamily    int
+	AiSocktype  int
+	AiProtocol  int
+	AiAddrlen   uint32
+	AiAddr      []Sockaddr
+	AiCanonname []byte
+	AiNext      []Addrinfo
+}
+
+// Hostent represents the C type "struct hostent" from netdb.h.
+type Hostent struct {
+	HName     []byte
+	HAliases  [][]byte
+	HAddrtype int
+	HLength   int
+	HAddrList [][]byte
+}
+
+// Htonl handles htonl().
+//
+// Converts the unsigned integer hostlong from host byte order to network
+// byte order.
+func Htonl(hostlong uint32) uint32 {
+	if hostByteOrder == binary.BigEndian {
+		return hostlong
+	}
+	return Bswap32(hostlong)
+}
+
+// Htons handles htons().
+//
+// Converts the unsigned short integer hostshort from host byte order to
+// network byte order.
+func Htons(hostshort uint16) uint16 {
+	if hostByteOrder == binary.BigEndian {
+		return hostshort
+	}
+	return Bswap16(hostshort)
+}
+
+// Ntohl handles ntohl().
+//
+// Converts the unsigned integer netlong from network byte order to host byte
+// order.
+func Ntohl(netlong uint32) uint32 {
+	return Htonl(netlong)
+}
+
+// Ntohs handles ntohs().
+//
+// Converts the unsigned short integer netshort from network byte order to
+// host byte order.
+func Ntohs(netshort uint16) uint16 {
+	return Htons(netshort)
+}
+
+// inAddrToIP converts the address in network byte order to Go IP. The bytes
+// of address in memory are in network byte order.
+func inAddrToIP(a InAddr) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	hostByteOrder.PutUint32(ip, a.SAddr)
+	return ip
+}
+
+// ipToInAddr converts Go IPv4 address to the address in network byte order.
+func ipToInAddr(ip net.IP) InAddr {
+	return InAddr{SAddr: hostByteOrder.Uint32(ip.To4())}
+}
+
+// toSyscallSockaddr converts the C socket address to the socket address of
+// package syscall.
+func toSyscallSockaddr(addr []Sockaddr) (syscall.Sockaddr, bool) {
+	if len(addr) == 0 {
+		return nil, false
+	}
+	a := addr[0]
+	switch int(a.Family) {
+	case syscall.AF_INET:
+		sa := &syscall.SockaddrInet4{Port: int(Ntohs(a.Port))}
+		copy(sa.Addr[:], inAddrToIP(a.Addr))
+		return sa, true
+	case syscall.AF_INET6:
+		sa := &syscall.SockaddrInet6{Port: int(Ntohs(a.Port)), ZoneId: a.ScopeID}
+		sa.Addr = a.Addr6.S6Addr
+		return sa, true
+	}
+	return nil, false
+}
+
+// fromSyscallSockaddr converts the socket address of package syscall to the
+// C socket address. Returns the length of C struct.
+func fromSyscallSockaddr(sa syscall.Sockaddr) (a Sockaddr, length uint32) {
+	switch v := sa.(type) {
+	case *syscall.SockaddrInet4:
+		a.Family = syscall.AF_INET
+		a.Port = Htons(uint16(v.Port))
+		a.Addr = ipToInAddr(net.IP(v.Addr[:]))
+		return a, 16
+	case *syscall.SockaddrInet6:
+		a.Family = syscall.AF_INET6
+		a.Port = Htons(uint16(v.Port))
+		a.Addr6.S6Addr = v.Addr
+		a.ScopeID = v.ZoneId
+		return a, 28
+	}
+	return a, 0
+}
+
+// Socket handles socket().
+//
+// Creates an endpoint for communication and returns a file descriptor that
+// refers to that endpoint. The file descriptor may be used with read(),
+// write() and close(). On error, -1 is returned.
+func Socket(domain, typ, protocol int) int {
+	fd, err := syscall.Socket(domain, typ, protocol)
+	if err != nil {
+		return -1
+	}
+	return newFileDescriptor(os.NewFile(uintptr(fd), "socket"))
+}
+
+// Socketpair handles socketpair().
+//
+// Creates a pair of connected sockets. The file descriptors used in
+// referencing the new sockets are returned in sv[0] and sv[1]. On success,
+// zero is returned. On error, -1 is returned.
+func Socketpair(domain, typ, protocol int, sv []int) int {
+	if len(sv) < 2 {
+		return -1
+	}
+	fds, err := syscall.Socketpair(domain, typ, protocol)
+	if err != nil {
+		return -1
+	}
+	sv[0] = newFileDescriptor(os.NewFile(uintptr(fds[0]), "socket"))
+	sv[1] = newFileDescriptor(os.NewFile(uintptr(fds[1]), "socket"))
+	return 0
+}
+
+// Bind handles bind().
+//
+// Assigns the address specified by addr to the socket referred to by the
+// file descriptor sockfd. On success, zero is returned. On error, -1 is
+// returned.
+func Bind(sockfd int, addr []Sockaddr, addrlen uint32) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	sa, ok := toSyscallSockaddr(addr)
+	if !ok {
+		return -1
+	}
+	if syscall.Bind(fd, sa) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Listen handles listen().
+//
+// Marks the socket referred to by sockfd as a passive socket, that is, as a
+// socket that will be used to accept incoming connection requests using
+// accept(). On success, zero is returned. On error, -1 is returned.
+func Listen(sockfd, backlog int) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	if syscall.Listen(fd, backlog) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Accept handles accept().
+//
+// Extracts the first connection request on the queue of pending connections
+// for the listening socket sockfd, creates a new connected socket, and
+// returns a new file descriptor referring to that socket. If addr is not
+// NULL, the address of the peer socket is put to addr and the length of
+// address to addrlen. On error, -1 is returned.
+func Accept(sockfd int, addr []Sockaddr, addrlen []uint32) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	nfd, sa, err := syscall.Accept(fd)
+	if err != nil {
+		return -1
+	}
+	if len(addr) > 0 {
+		var length uint32
+		addr[0], length = fromSyscallSockaddr(sa)
+		if len(addrlen) > 0 {
+			addrlen[0] = length
+		}
+	}
+	return newFileDescriptor(os.NewFile(uintptr(nfd), "socket"))
+}
+
+// Connect handles connect().
+//
+// Connects the socket referred to by the file descriptor sockfd to the
+// address specified by addr. On success, zero is returned. On error, -1 is
+// returned.
+func Connect(sockfd int, addr []Sockaddr, addrlen uint32) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	sa, ok := toSyscallSockaddr(addr)
+	if !ok {
+		return -1
+	}
+	if syscall.Connect(fd, sa) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Getsockname handles getsockname().
+//
+// Returns the current address to which the socket sockfd is bound, in the
+// buffer pointed to by addr. On success, zero is returned. On error, -1 is
+// returned.
+func Getsockname(sockfd int, addr []Sockaddr, addrlen []uint32) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	sa, err := syscall.Getsockname(fd)
+	if err != nil {
+		return -1
+	}
+	var length uint32
+	addr[0], length = fromSyscallSockaddr(sa)
+	if len(addrlen) > 0 {
+		addrlen[0] = length
+	}
+	return 0
+}
+
+// Send handles send().
+//
+// Transmits a message to the connected socket. On success, returns the
+// number of bytes sent. On error, -1 is returned.
+func Send(sockfd int, buf []byte, length int, flags int) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	if length > len(buf) {
+		length = len(buf)
+	}
+	if syscall.Sendto(fd, buf[:length], flags, nil) != nil {
+		return -1
+	}
+	return length
+}
+
+// Recv handles recv().
+//
+// Receives a message from the connected socket. Returns the number of bytes
+// received, zero if the peer has performed an orderly shutdown. On error, -1
+// is returned.
+func Recv(sockfd int, buf []byte, length int, flags int) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	if length > len(buf) {
+		length = len(buf)
+	}
+	n, _, err := syscall.Recvfrom(fd, buf[:length], flags)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// Shutdown handles shutdown().
+//
+// Causes all or part of a full-duplex connection on the socket associated
+// with sockfd to be shut down. On success, zero is returned. On error, -1 is
+// returned.
+func Shutdown(sockfd, how int) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	if syscall.Shutdown(fd, how) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Setsockopt handles setsockopt().
+//
+// Sets the option optname at the protocol level for the socket referred to
+// by the file descriptor sockfd. Only integer options are supported. On
+// success, zero is returned. On error, -1 is returned.
+func Setsockopt(sockfd, level, optname int, optval interface{},
+	optlen uint32) int {
+	fd, ok := getSystemFd(sockfd)
+	if !ok {
+		return -1
+	}
+	var value int
+	switch v := optval.(type) {
+	case []int:
+		value = v[0]
+	case []int32:
+		value = int(v[0])
+	case []uint32:
+		value = int(v[0])
+	case []byte:
+		value = int(v[0])
+	default:
+		return -1
+	}
+	if syscall.SetsockoptInt(fd, level, optname, value) != nil {
+		return -1
+	}
+	return 0
+}
+
+// lookupIP returns addresses of the node.
+func lookupIP(node string, flags int) ([]net.IP, bool) {
+	if ip := net.ParseIP(node); ip != nil {
+		return []net.IP{ip}, true
+	}
+	if flags&aiNumerichost != 0 {
+		return nil, false
+	}
+	ips, err := net.LookupIP(node)
+	if err != nil {
+		return nil, false
+	}
+	return ips, true
+}
+
+// Getaddrinfo handles getaddrinfo().
+//
+// Given node and service, which identify an Internet host and a service,
+// returns one or more addrinfo structures, each of which contains an
+// Internet address that can be specified in a call to bind() or connect().
+// The list of structures is put to res. Returns 0 if it succeeds, or one of
+// the nonzero error codes.
+func Getaddrinfo(node, service []byte, hints []Addrinfo,
+	res [][]Addrinfo) int {
+	var h Addrinfo
+	if len(hints) > 0 {
+		h = hints[0]
+	}
+	switch h.AiFamily {
+	case syscall.AF_UNSPEC, syscall.AF_INET, syscall.AF_INET6:
+	default:
+		return eaiFamily
+	}
+	if node == nil && service == nil {
+		return eaiNoname
+	}
+
+	// port of service
+	var port int
+	if service != nil {
+		var err error
+		s := CStringToString(service)
+		if port, err = strconv.Atoi(s); err != nil {
+			network := "tcp"
+			if h.AiSocktype == syscall.SOCK_DGRAM {
+				network = "udp"
+			}
+			if port, err = net.LookupPort(network, s); err != nil {
+				return eaiService
+			}
+		}
+	}
+
+	// addresses of node
+	var ips []net.IP
+	if node == nil {
+		if h.AiFlags&aiPassive != 0 {
+			ips = []net.IP{net.IPv6unspecified, net.IPv4zero}
+		} else {
+			ips = []net.IP{net.IPv6loopback, net.IPv4(127, 0, 0, 1)}
+		}
+	} else {
+		var ok bool
+		if ips, ok = lookupIP(CStringToString(node), h.AiFlags); !ok {
+			return eaiNoname
+		}
+	}
+
+	socktypes := []int{syscall.SOCK_STREAM, syscall.SOCK_DGRAM, syscall.SOCK_RAW}
+	switch h.AiSocktype {
+	case 0:
+	case syscall.SOCK_STREAM, syscall.SOCK_DGRAM, syscall.SOCK_RAW:
+		socktypes = []int{h.AiSocktype}
+	default:
+		return eaiSocktype
+	}
+
+	var list []Addrinfo
+	for _, ip := range ips {
+		family := syscall.AF_INET6
+		if ip.To4() != nil {
+			family = syscall.AF_INET
+		}
+		if h.AiFamily != syscall.AF_UNSPEC && h.AiFamily != family {
+			continue
+		}
+		var sa syscall.Sockaddr
+		if family == syscall.AF_INET {
+			sa4 := &syscall.SockaddrInet4{Port: port}
+			copy(sa4.Addr[:], ip.To4())
+			sa = sa4
+		} else {
+			sa6 := &syscall.SockaddrInet6{Port: port}
+			copy(sa6.Addr[:], ip.To16())
+			sa = sa6
+		}
+		addr, length := fromSyscallSockaddr(sa)
+		for _, socktype := range socktypes {
+			protocol := h.AiProtocol
+			if protocol == 0 {
+				switch socktype {
+				case syscall.SOCK_STREAM:
+					protocol = syscall.IPPROTO_TCP
+				case syscall.SOCK_DGRAM:
+					protocol = syscall.IPPROTO_UDP
+				}
+			}
+			list = append(list, Addrinfo{
+				AiFlags:    h.AiFlags,
+				AiFamily:   family,
+				AiSocktype: socktype,
+				AiProtocol: protocol,
+				AiAddrlen:  length,
+				AiAddr:     []Sockaddr{addr},
+			})
+		}
+	}
+	if len(list) == 0 {
+		return eaiNoname
+	}
+	if h.AiFlags&aiCanonname != 0 && node != nil {
+		list[0].AiCanonname = []byte(CStringToString(node) + "\x00")
+	}
+
+	// linked list
+	for i := len(list) - 2; i >= 0; i-- {
+		list[i].AiNext = list[i+1 : i+2]
+	}
+	res[0] = list
+	return 0
+}
+
+// Freeaddrinfo handles freeaddrinfo().
+//
+// Frees the memory that was allocated for the dynamically allocated linked
+// list res.
+func Freeaddrinfo(res []Addrinfo) {
+}
+
+// GaiStrerror handles gai_strerror().
+//
+// Translates the error codes of getaddrinfo() into a human readable string.
+func GaiStrerror(errcode int) []byte {
+	var msg string
+	switch errcode {
+	case 0:
+		msg = "Success"
+	case eaiBadflags:
+		msg = "Bad value for ai_flags"
+	case eaiNoname:
+		msg = "Name or service not known"
+	case eaiFamily:
+		msg = "ai_family not supported"
+	case eaiSocktype:
+		msg = "ai_socktype not supported"
+	case eaiService:
+		msg = "Servname not supported for ai_socktype"
+	default:
+		msg = "Unknown error"
+	}
+	return []byte(msg + "\x00")
+}
+
+// Gethostbyname handles gethostbyname().
+//
+// Returns a structure of type hostent for the given host name with IPv4
+// addresses. If the host is not found, NULL is returned.
+func Gethostbyname(name []byte) []Hostent {
+	ips, ok := lookupIP(CStringToString(name), 0)
+	if !ok {
+		return nil
+	}
+	h := Hostent{
+		HName:     []byte(CStringToString(name) + "\x00"),
+		HAliases:  [][]byte{nil},
+		HAddrtype: syscall.AF_INET,
+		HLength:   4,
+	}
+	for _, ip := range ips {
+		if ip4 := ip.To4(); ip4 != nil {
+			h.HAddrList = append(h.HAddrList, []byte(ip4))
+		}
+	}
+	if len(h.HAddrList) == 0 {
+		return nil
+	}
+	h.HAddrList = append(h.HAddrList, nil)
+	return []Hostent{h}
+}
+
+// InetPton handles inet_pton().
+//
+// Converts the character string src into a network address structure in the
+// af address family, then copies the network address structure to dst.
+// Returns 1 on success, 0 if src does not contain a character string
+// representing a valid network address in the specified address family. If
+// af does not contain a valid address family, -1 is returned.
+func InetPton(af int, src []byte, dst interface{}) int {
+	ip := net.ParseIP(CStringToString(src))
+	switch af {
+	case syscall.AF_INET:
+		if ip == nil || ip.To4() == nil {
+			return 0
+		}
+		a := ipToInAddr(ip)
+		switch v := dst.(type) {
+		case []InAddr:
+			v[0] = a
+		case []uint32:
+			v[0] = a.SAddr
+		case []byte:
+			copy(v, ip.To4())
+		default:
+			return -1
+		}
+		return 1
+	case syscall.AF_INET6:
+		if ip == nil || ip.To16() == nil {
+			return 0
+		}
+		switch v := dst.(type) {
+		case []In6Addr:
+			copy(v[0].S6Addr[:], ip.To16())
+		case []byte:
+			copy(v, ip.To16())
+		default:
+			return -1
+		}
+		return 1
+	}
+	return -1
+}
+
+// InetNtop handles inet_ntop().
+//
+// Converts the network address structure src in the af address family into
+// a character string. The resulting string is copied to the buffer pointed
+// to by dst with size. Returns dst on success, or NULL if there was an
+// error.
+func InetNtop(af int, src interface{}, dst []byte, size uint32) []byte {
+	var ip net.IP
+	switch af {
+	case syscall.AF_INET:
+		switch v := src.(type) {
+		case []InAddr:
+			ip = inAddrToIP(v[0])
+		case []uint32:
+			ip = inAddrToIP(InAddr{SAddr: v[0]})
+		case []byte:
+			ip = net.IP(v[:4])
+		}
+	case syscall.AF_INET6:
+		switch v := src.(type) {
+		case []In6Addr:
+			ip = net.IP(v[0].S6Addr[:])
+		case []byte:
+			ip = net.IP(v[:16])
+		}
+	}
+	if ip == nil {
+		return nil
+	}
+	s := ip.String()
+	if uint32(len(s)) >= size || len(s) >= len(dst) {
+		return nil
+	}
+	copy(dst, s)
+	dst[len(s)] = 0
+	return dst
+}
+
+// InetAddr handles inet_addr().
+//
+// Converts the Internet host address cp from IPv4 numbers-and-dots notation
+// into binary data in network byte order. If the input is invalid,
+// INADDR_NONE (usually -1) is returned.
+func InetAddr(cp []byte) uint32 {
+	ip := net.ParseIP(CStringToString(cp))
+	if ip == nil || ip.To4() == nil {
+		return 0xffffffff
+	}
+	return ipToInAddr(ip).SAddr
+}
+
+// InetNtoa handles inet_ntoa().
+//
+// Converts the Internet host address in, given in network byte order, to a
+// string in IPv4 dotted-decimal notation.
+func InetNtoa(in InAddr) []byte {
+	return []byte(fmt.Sprintf("%s\x00", inAddrToIP(in)))
+}
@@ -0,0 +1,257 @@
+package noarch
+
+import (
+	"os"
+	"reflect"
+	"time"
+)
+
+// Bits of file mode StatT.StMode
+const (
+	sIfmt   = 0170000
+	sIfsock = 0140000
+	sIflnk  = 0120000
+	sIfreg  = 0100000
+	sIfblk  = 0060000
+	sIfdir  = 0040000
+	sIfchr  = 0020000
+	sIfifo  = 0010000
+	sIsuid  = 0004000
+	sIsgid  = 0002000
+	sIsvtx  = 0001000
+)
+
+// StatT represents the C structure "struct stat" from sys/stat.h:
+//
+//     struct stat {
+//         dev_t     st_dev;
+//         ino_t     st_ino;
+//         nlink_t   st_nlink;
+//         mode_t    st_mode;
+//         uid_t     st_uid;
+//         gid_t     st_gid;
+//         dev_t     st_rdev;
+//         off_t     st_size;
+//         blksize_t st_blksize;
+//         blkcnt_t  st_blocks;
+//         struct timespec st_atim;
+//         struct timespec st_mtim;
+//         struct timespec st_ctim;
+//     };
+//
+// The fields st_atime, st_mtime, st_ctime are macros for the fields tv_sec
+// of st_atim, st_mtim, st_ctim.
+type StatT struct {
+	StDev     uint32
+	StIno     uint32
+	StNlink   uint32
+	StMode    uint32
+	StUID     uint32
+	StGID     uint32
+	StRdev    uint32
+	StSize    int32
+	StBlksize int32
+	StBlocks  int32
+	StAtim    Timespec
+	StMtim    Timespec
+	StCtim    Timespec
+}
+
+// fileModeToC converts Go file mode to the POSIX file mode.
+func fileModeToC(mode os.FileMode) uint32 {
+	m := uint32(mode.Perm())
+	switch {
+	case mode&os.ModeDir != 0:
+		m |= sIfdir
+	case mode&os.ModeSymlink != 0:
+		m |= sIflnk
+	case mode&os.ModeNamedPipe != 0:
+		m |= sIfifo
+	case mode&os.ModeSocket != 0:
+		m |= sIfsock
+	case mode&os.ModeCharDevice != 0:
+		m |= sIfchr
+	case mode&os.ModeDevice != 0:
+		m |= sIfblk
+	default:
+		m |= sIfreg
+	}
+	if mode&os.ModeSetuid != 0 {
+		m |= sIsuid
+	}
+	if mode&os.ModeSetgid != 0 {
+		m |= sIsgid
+	}
+	if mode&os.ModeSticky != 0 {
+		m |= sIsvtx
+	}
+	return m
+}
+
+// fileModeFromC converts the POSIX permission bits to Go file mode.
+func fileModeFromC(mode uint32) os.FileMode {
+	m := os.FileMode(mode & 0777)
+	if mode&sIsuid != 0 {
+		m |= os.ModeSetuid
+	}
+	if mode&sIsgid != 0 {
+		m |= os.ModeSetgid
+	}
+	if mode&sIsvtx != 0 {
+		m |= os.ModeSticky
+	}
+	return m
+}
+
+// sysTime returns the time from the system specific information of file.
+// The names of fields are different for platforms, so all names are
+// checked. If field is not found, then the modification time is returned.
+func sysTime(info os.FileInfo, fields ...string) time.Time {
+	sys := reflect.ValueOf(info.Sys())
+	if sys.Kind() == reflect.Ptr && !sys.IsNil() {
+		sys = sys.Elem()
+	}
+	if sys.Kind() == reflect.Struct {
+		for _, field := range fields {
+			v := sys.FieldByName(field)
+			if v.Kind() != reflect.Struct {
+				continue
+			}
+			sec, nsec := v.FieldByName("Sec"), v.FieldByName("Nsec")
+			if sec.Kind() == reflect.Int64 && nsec.Kind() == reflect.Int64 {
+				return time.Unix(sec.Int(), nsec.Int())
+			}
+		}
+	}
+	return info.ModTime()
+}
+
+// fileInfoToStat converts Go information about file to StatT.
+func fileInfoToStat(info os.FileInfo) StatT {
+	return StatT{
+		StDev:     uint32(sysUint(info, "Dev")),
+		StIno:     uint32(inode(info)),
+		StNlink:   uint32(sysUint(info, "Nlink")),
+		StMode:    fileModeToC(info.Mode()),
+		StUID:     uint32(sysUint(info, "Uid")),
+		StGID:     uint32(sysUint(info, "Gid")),
+		StRdev:    uint32(sysUint(info, "Rdev")),
+		StSize:    int32(info.Size()),
+		StBlksize: int32(sysUint(info, "Blksize")),
+		StBlocks:  int32(sysUint(info, "Blocks")),
+		StAtim:    timeToTimespec(sysTime(info, "Atim", "Atimespec")),
+		StMtim:    timeToTimespec(info.ModTime()),
+		StCtim:    timeToTimespec(sysTime(info, "Ctim", "Ctimespec")),
+	}
+}
+
+// Stat handles stat().
+//
+// Retrieves information about the file pointed to by pathname and fills
+// the structure buf. On success, zero is returned. On error, -1 is
+// returned.
+func Stat(pathname []byte, buf []StatT) int {
+	info, err := os.Stat(CStringToString(pathname))
+	if err != nil {
+		return -1
+	}
+	buf[0] = fileInfoToStat(info)
+	return 0
+}
+
+// Lstat handles lstat().
+//
+// Works like stat(), except that if pathname is a symbolic link, then it
+// returns information about the link itself, not the file that it refers
+// to.
+func Lstat(pathname []byte, buf []StatT) int {
+	info, err := os.Lstat(CStringToString(pathname))
+	if err != nil {
+		return -1
+	}
+	buf[0] = fileInfoToStat(info)
+	return 0
+}
+
+// Fstat handles fstat().
+//
+// Works like stat(), except that the file about which information is to be
+// retrieved is specified by the file descriptor fd.
+func Fstat(fd int, buf []StatT) int {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return -1
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return -1
+	}
+	buf[0] = fileInfoToStat(info)
+	return 0
+}
+
+// Mkdir handles mkdir().
+//
+// Attempts to create a directory named pathname. The argument mode
+// specifies the permissions to use. Returns zero on success, or -1 if an
+// error occurred.
+func Mkdir(pathname []byte, mode uint32) int {
+	if os.Mkdir(CStringToString(pathname), fileModeFromC(mode)) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Chmod handles chmod().
+//
+// Changes the permissions of the file specified by pathname. On success,
+// zero is returned. On error, -1 is returned.
+func Chmod(pathname []byte, mode uint32) int {
+	if os.Chmod(CStringToString(pathname), fileModeFromC(mode)) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Rmdir handles rmdir().
+//
+// Deletes a directory, which must be empty. On success, zero is returned.
+// On error, -1 is returned.
+func Rmdir(pathname []byte) int {
+	path := CStringToString(pathname)
+	info, err := os.Lstat(path)
+	if err != nil || !info.IsDir() {
+		return -1
+	}
+	if os.Remove(path) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Unlink handles unlink().
+//
+// Deletes a name from the filesystem. Directories are not deleted by
+// unlink(). On success, zero is returned. On error, -1 is returned.
+func Unlink(pathname []byte) int {
+	path := CStringToString(pathname)
+	info, err := os.Lstat(path)
+	if err != nil || info.IsDir() {
+		return -1
+	}
+	if os.Remove(path) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Symlink handles symlink().
+//
+// Creates a symbolic link named linkpath which contains the string target.
+// On success, zero is returned. On error, -1 is returned.
+func Symlink(target, linkpath []byte) int {
+	if os.Symlink(CStringToString(target), CStringToString(linkpath)) != nil {
+		return -1
+	}
+	return 0
+}
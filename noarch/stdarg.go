@@ -0,0 +1,111 @@
+package noarch
+
+import "reflect"
+
+// VaList is the list of arguments of variadic function from stdarg.h. The
+// variadic C function is transpiled to the Go function with the last
+// argument "c4goArgs ...interface{}", which is the source of VaList.
+//
+// The type va_list is an array of one element in C, so a variable of type
+// va_list is passed into functions by pointer. The same is true for Go code:
+// a variable is "*noarch.VaList" and the list of arguments is shared with the
+// called functions, for example vsnprintf().
+type VaList struct {
+	args     []interface{}
+	position int
+}
+
+// VaStart handles va_start().
+//
+// Initializes the list ap for access to the variadic arguments args of
+// function.
+func VaStart(ap *VaList, args []interface{}) {
+	ap.args = args
+	ap.position = 0
+}
+
+// VaEnd handles va_end().
+//
+// Releases the list of arguments initialized by VaStart or VaCopy.
+func VaEnd(ap *VaList) {
+	ap.args = nil
+	ap.position = 0
+}
+
+// VaCopy handles va_copy().
+//
+// Initializes dst as a copy of src, including the position of the next
+// argument. Later calls of va_arg() for one list do not change another.
+func VaCopy(dst, src *VaList) {
+	*dst = *src
+}
+
+// Arg handles va_arg() for types without conversion, for example pointers
+// and structs. Returns the next argument of the list. If the list has no
+// arguments, then nil is returned.
+func (ap *VaList) Arg() interface{} {
+	if ap.position >= len(ap.args) {
+		return nil
+	}
+	arg := ap.args[ap.position]
+	ap.position++
+	return arg
+}
+
+// Int64 handles va_arg() for signed integer types. Returns the next argument
+// of the list converted to int64, so the argument may have any numerical Go
+// type as in C, where the argument is promoted to int or double.
+func (ap *VaList) Int64() int64 {
+	return vaInt64(reflect.ValueOf(ap.Arg()))
+}
+
+func vaInt64(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return int64(v.Float())
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Uint64 handles va_arg() for unsigned integer types. See Int64.
+func (ap *VaList) Uint64() uint64 {
+	v := reflect.ValueOf(ap.Arg())
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return uint64(v.Float())
+	}
+	return uint64(vaInt64(v))
+}
+
+// Float64 handles va_arg() for floating types. See Int64.
+func (ap *VaList) Float64() float64 {
+	v := reflect.ValueOf(ap.Arg())
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	}
+	return float64(vaInt64(v))
+}
+
+// rest returns the arguments of the list, which are not taken by va_arg().
+func (ap *VaList) rest() []interface{} {
+	if ap == nil || ap.position >= len(ap.args) {
+		return nil
+	}
+	return ap.args[ap.position:]
+}
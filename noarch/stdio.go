@@ -1,11 +1,14 @@
 package noarch
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"reflect"
+	"strings"
+	"syscall"
 )
 
 // Programs generated by c4go will reference noarch.Stdin instead of os.Stdin
@@ -25,6 +28,10 @@ type File struct {
 	// calls in Go.
 	OsFile *os.File
 
+	// eof is the end-of-file indicator of stream, which is set by functions
+	// of reading.
+	eof bool
+
 	// unsigned char *_p;
 	// int _r;
 	// int _w;
@@ -102,6 +109,14 @@ func Fopen(filePath, mode []byte) *File {
 // Even if the call fails, the stream passed as parameter will no longer be
 // associated with the file nor its buffers.
 func Fclose(f *File) int {
+	// the file descriptors of stream are closed too
+	for fd, osFile := range fileDescriptors {
+		if osFile == f.OsFile {
+			delete(fileDescriptors, fd)
+		}
+	}
+	delete(openStreams, f)
+
 	err := f.OsFile.Close()
 	if err != nil {
 		// Is this the correct error code?
@@ -217,30 +232,106 @@ func Tmpfile() *File {
 // stream argument, but also allows to specify the maximum size of str and
 // includes in the string any ending newline character.
 func Fgets(str []byte, num int, stream *File) []byte {
-	buf := make([]byte, num)
-	n, err := stream.OsFile.Read(buf)
+	if num <= 0 || len(str) == 0 {
+		return nil
+	}
+	if num > len(str) {
+		num = len(str)
+	}
+	var n int
+	for n < num-1 {
+		c, ok := readByte(stream)
+		if !ok {
+			break
+		}
+		str[n] = c
+		n++
+		if c == '\n' {
+			break
+		}
+	}
+	// If the end-of-file is encountered and no characters have been read,
+	// the contents of str remain unchanged and a null pointer is returned.
+	if n == 0 && num > 1 {
+		return nil
+	}
+	str[n] = 0
+	return str
+}
 
-	// FIXME: Is this the right thing to do in this case?
-	if err != nil {
-		return []byte{}
+// readByte reads one byte of stream. The stream is not buffered, so bytes
+// after the byte are not read from file as in C. At the end of file the
+// end-of-file indicator of stream is set.
+func readByte(stream *File) (c byte, ok bool) {
+	var buf [1]byte
+	n, err := stream.OsFile.Read(buf[:])
+	if n == 1 {
+		return buf[0], true
+	}
+	if err == nil || err == io.EOF {
+		stream.eof = true
 	}
+	return 0, false
+}
 
-	// TODO: Allow arguments to be passed by reference.
-	// https://github.com/Konstantin8105/c4go/issues/90
-	// This appears in multiple locations.
+// Getline handles getline().
+//
+// Reads an entire line from stream and stores the address of the buffer
+// containing the text into lineptr. The buffer is null-terminated and
+// includes the newline character, if one was found.
+//
+// If lineptr is a null pointer, then the buffer is allocated. Otherwise the
+// buffer of size n is reallocated as needed. In either case, on a successful
+// call, lineptr and n will be updated to reflect the buffer address and
+// allocated size respectively.
+//
+// Returns the number of characters read, including the delimiter character,
+// but not including the terminating null character, or -1 on failure to read
+// a line (including end-of-file condition).
+func Getline(lineptr [][]byte, n []uint32, stream *File) int32 {
+	return Getdelim(lineptr, n, '\n', stream)
+}
 
-	// Be careful to crop the buffer to the real number of bytes read.
-	//
-	// We do not trim off the NULL characters because we do not know if the file
-	// we are reading is in binary mode.
-	if n == num {
-		// If it is the case that we have read the entire buffer with this read
-		// we need to make sure we leave room for what would be the NULL
-		// character at the end of the string in C.
-		return buf[:n-1]
+// Getdelim handles getdelim().
+//
+// Works like getline, except that a line delimiter other than newline can be
+// specified as the delimiter argument.
+func Getdelim(lineptr [][]byte, n []uint32, delimiter int, stream *File) int32 {
+	if len(lineptr) == 0 || len(n) == 0 || stream == nil {
+		return -1
 	}
-
-	return buf[:n]
+	line := lineptr[0]
+	if line == nil {
+		// initial size of buffer is the same as in glibc
+		line = make([]byte, 120)
+	}
+	var length int
+	for {
+		c, ok := readByte(stream)
+		if !ok {
+			break
+		}
+		// place for character and the terminating null character
+		if length+2 > len(line) {
+			buf := make([]byte, 2*len(line)+2)
+			copy(buf, line)
+			line = buf
+		}
+		line[length] = c
+		length++
+		if c == byte(delimiter) {
+			break
+		}
+	}
+	if len(lineptr[0]) != len(line) {
+		lineptr[0] = line
+		n[0] = uint32(len(line))
+	}
+	if length == 0 {
+		return -1
+	}
+	line[length] = 0
+	return int32(length)
 }
 
 // Rewind handles rewind().
@@ -255,6 +346,7 @@ func Fgets(str []byte, num int, stream *File) []byte {
 // On streams open for update (read+write), a call to rewind allows to switch
 // between reading and writing.
 func Rewind(stream *File) {
+	stream.eof = false
 	stream.OsFile.Seek(0, 0)
 }
 
@@ -274,6 +366,10 @@ func Rewind(stream *File) {
 // freopen. Although if the position indicator is not repositioned by such a
 // call, the next i/o operation is likely to set the indicator again.
 func Feof(stream *File) int {
+	if stream.eof {
+		return 1
+	}
+
 	// FIXME: This is a really bad way of doing this. Basically try and peek
 	// ahead to test for EOF.
 	buf := make([]byte, 1)
@@ -290,11 +386,51 @@ func Feof(stream *File) int {
 	return result
 }
 
+// openStreams is the set of streams, which are not closed. Streams are
+// flushed by fflush(NULL) and at the normal termination of program.
+var openStreams = map[*File]bool{}
+
 // NewFile creates a File pointer from a Go file pointer.
 func NewFile(f *os.File) *File {
-	return &File{
+	stream := &File{
 		OsFile: f,
 	}
+	openStreams[stream] = true
+	return stream
+}
+
+// Fileno handles fileno().
+//
+// Examines the argument stream and returns the integer file descriptor used
+// to implement this stream. On error, -1 is returned.
+func Fileno(stream *File) int {
+	if stream == nil || stream.OsFile == nil {
+		return -1
+	}
+	result := -1
+	for fd, f := range fileDescriptors {
+		if f == stream.OsFile && (result < 0 || fd < result) {
+			result = fd
+		}
+	}
+	if result < 0 {
+		result = newFileDescriptor(stream.OsFile)
+	}
+	return result
+}
+
+// Fdopen handles fdopen().
+//
+// Associates a stream with the existing file descriptor fd. The mode of the
+// stream must be compatible with the mode of the file descriptor. The file
+// descriptor is not duplicated, and will be closed when the stream created
+// by fdopen() is closed. On error, NULL is returned.
+func Fdopen(fd int, mode []byte) *File {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return nil
+	}
+	return NewFile(f)
 }
 
 // Tmpnam handles tmpnam().
@@ -339,8 +475,7 @@ func Tmpnam(str []byte) []byte {
 // the last i/o operation was an output operation) any unwritten data in its
 // output buffer is written to the file.
 //
-// If stream is a null pointer, all such streams should be flushed, but this is
-// currently not supported.
+// If stream is a null pointer, all open streams are flushed.
 //
 // The stream remains open after this call.
 //
@@ -348,7 +483,21 @@ func Tmpnam(str []byte) []byte {
 // program terminates, all the buffers associated with it are automatically
 // flushed.
 func Fflush(stream *File) int {
+	if stream == nil {
+		result := 0
+		for s := range openStreams {
+			if Fflush(s) != 0 {
+				result = 1
+			}
+		}
+		return result
+	}
+
 	err := stream.OsFile.Sync()
+	if e, ok := err.(*os.PathError); ok && e.Err == syscall.EINVAL {
+		// terminals and pipes are not buffered
+		err = nil
+	}
 	if err != nil {
 		return 1
 	}
@@ -378,7 +527,7 @@ func Fprintf(f *File, format []byte, args ...interface{}) int {
 		}
 	}
 
-	n, err := fmt.Fprintf(f.OsFile, CStringToString(format), realArgs...)
+	n, err := fmt.Fprintf(f.OsFile, goFormat(format), localizeFloats(realArgs)...)
 	if err != nil {
 		return -1
 	}
@@ -438,16 +587,6 @@ func prepareArgsForScanf(args []interface{}) []interface{} {
 	return realArgs
 }
 
-func getc(f *os.File) int {
-	buffer := make([]byte, 1)
-	_, err := f.Read(buffer)
-	if err != nil {
-		return -1
-	}
-
-	return int(buffer[0])
-}
-
 // Fgetc handles fgetc().
 //
 // Returns the character currently pointed by the internal file position
@@ -463,7 +602,11 @@ func getc(f *os.File) int {
 // fgetc and getc are equivalent, except that getc may be implemented as a macro
 // in some libraries.
 func Fgetc(stream *File) int {
-	return getc(stream.OsFile)
+	c, ok := readByte(stream)
+	if !ok {
+		return -1
+	}
+	return int(c)
 }
 
 // Fputc handles fputc().
@@ -487,7 +630,7 @@ func Fputc(c int, f *File) int {
 //
 // It is equivalent to calling getc with stdin as argument.
 func Getchar() int {
-	return getc(Stdin.OsFile)
+	return Fgetc(Stdin)
 }
 
 // Fseek handles fseek().
@@ -511,6 +654,7 @@ func Getchar() int {
 // On streams open for update (read+write), a call to fseek allows to switch
 // between reading and writing.
 func Fseek(f *File, offset int32, origin int) int {
+	f.eof = false
 	n, err := f.OsFile.Seek(int64(offset), origin)
 	if err != nil {
 		return -1
@@ -643,7 +787,7 @@ func Printf(format []byte, args ...interface{}) int {
 		}
 	}
 
-	n, _ := fmt.Printf(CStringToString(format), realArgs...)
+	n, _ := fmt.Fprintf(Stdout.OsFile, goFormat(format), localizeFloats(realArgs)...)
 
 	return n
 }
@@ -661,7 +805,7 @@ func Printf(format []byte, args ...interface{}) int {
 // destination, but it also appends a newline character at the end automatically
 // (which fputs does not).
 func Puts(str []byte) int {
-	n, _ := fmt.Println(CStringToString(str))
+	n, _ := fmt.Fprintln(Stdout.OsFile, CStringToString(str))
 
 	return n
 }
@@ -679,7 +823,7 @@ func Scanf(format []byte, args ...interface{}) int {
 
 	// We cannot use fmt.Scanf() here because that would use the real stdin
 	// which does not work under test. See docs for noarch.Stdin.
-	n, _ := fmt.Fscanf(Stdin.OsFile, CStringToString(format), realArgs...)
+	n, _ := fmt.Fscanf(Stdin.OsFile, goFormat(format), realArgs...)
 	finalizeArgsForScanf(realArgs, args)
 
 	return n
@@ -691,7 +835,7 @@ func Scanf(format []byte, args ...interface{}) int {
 //
 // It is equivalent to calling putc with stdout as second argument.
 func Putchar(character int) {
-	fmt.Printf("%c", character)
+	fmt.Fprintf(Stdout.OsFile, "%c", character)
 }
 
 // Sprintf handles sprintf().
@@ -705,7 +849,7 @@ func Sprintf(buffer, format []byte, args ...interface{}) int {
 
 	realArgs = append(realArgs, convert(args)...)
 
-	result := fmt.Sprintf(CStringToString(format), realArgs...)
+	result := fmt.Sprintf(goFormat(format), localizeFloats(realArgs)...)
 	for i := range []byte(result) {
 		buffer[i] = result[i]
 	}
@@ -717,41 +861,41 @@ func Sprintf(buffer, format []byte, args ...interface{}) int {
 
 // Vsprintf handles vsprintf().
 //
-// Writes the C string pointed by format to the standard output (stdout). If
-// format includes format specifiers (subsequences beginning with %), the
-// additional arguments following format are formatted and inserted in the
-// resulting string replacing their respective specifiers.
-func Vsprintf(buffer, format []byte, varList ...interface{}) int {
-	realArgs := []interface{}{}
-
-	if len(varList) > 1 {
-		// TODO : I don`t found the situation with more 1 size
-		return 0
-	}
-
-	realArgs = append(realArgs, convert(varList)...)
-
-	result := fmt.Sprintf(CStringToString(format), realArgs...)
-	for i := range []byte(result) {
-		buffer[i] = result[i]
-	}
-	buffer[len(result)] = '\x00'
-
-	n := len(result)
-	return n
+// Writes the C string pointed by format to the buffer as Sprintf, but the
+// additional arguments are taken from the list ap.
+func Vsprintf(buffer, format []byte, ap *VaList) int {
+	return Sprintf(buffer, format, ap.rest()...)
 }
 
 // Snprintf handles snprintf().
 //
-// Writes the C string pointed by format to the standard output (stdout). If
-// format includes format specifiers (subsequences beginning with %), the
-// additional arguments following format are formatted and inserted in the
-// resulting string replacing their respective specifiers.
+// Writes the C string pointed by format to the buffer. If format includes
+// format specifiers (subsequences beginning with %), the additional arguments
+// following format are formatted and inserted in the resulting string
+// replacing their respective specifiers. No more than n bytes are written,
+// including the terminating null character, so the truncated string is
+// always terminated. Returns the length of the whole resulting string, which
+// would have been written, if n had been sufficiently large. The length is
+// used for the size of buffer:
+//
+//     int len = snprintf(NULL, 0, format, ...);
+//     char *buffer = malloc(len + 1);
+//     snprintf(buffer, len + 1, format, ...);
+//
 func Snprintf(buffer []byte, n int, format []byte, args ...interface{}) int {
-	return Vsnprintf(buffer, n, format, args)
+	result := fmt.Sprintf(goFormat(format), localizeFloats(convert(args))...)
+	// slice cannot be written out of length
+	if n > len(buffer) {
+		n = len(buffer)
+	}
+	if n > 0 {
+		m := copy(buffer[:n-1], result)
+		buffer[m] = '\x00'
+	}
+	return len(result)
 }
 
-// convert - convert va_list
+// convert - convert arguments of function for package fmt
 func convert(arg interface{}) (result []interface{}) {
 	typeOfByteSlice := reflect.TypeOf([]byte(nil))
 	if reflect.TypeOf(arg) == typeOfByteSlice {
@@ -769,29 +913,106 @@ func convert(arg interface{}) (result []interface{}) {
 
 // Vsnprintf handles vsnprintf().
 //
-// Writes the C string pointed by format to the standard output (stdout). If
-// format includes format specifiers (subsequences beginning with %), the
-// additional arguments following format are formatted and inserted in the
-// resulting string replacing their respective specifiers.
-func Vsnprintf(buffer []byte, n int, format []byte, varList ...interface{}) int {
-	realArgs := []interface{}{}
+// Writes the C string pointed by format to the buffer as Snprintf, but the
+// additional arguments are taken from the list ap.
+func Vsnprintf(buffer []byte, n int, format []byte, ap *VaList) int {
+	return Snprintf(buffer, n, format, ap.rest()...)
+}
 
-	if len(varList) > 1 {
-		// TODO : I don`t found the situation with more 1 size
-		return 0
-	}
+// Vprintf handles vprintf().
+//
+// Writes the C string pointed by format to the standard output as Printf,
+// but the additional arguments are taken from the list ap.
+func Vprintf(format []byte, ap *VaList) int {
+	return Printf(format, ap.rest()...)
+}
 
-	realArgs = append(realArgs, convert(varList)...)
+// Vfprintf handles vfprintf().
+//
+// Writes the C string pointed by format to the stream as Fprintf, but the
+// additional arguments are taken from the list ap.
+func Vfprintf(f *File, format []byte, ap *VaList) int {
+	return Fprintf(f, format, ap.rest()...)
+}
 
-	result := fmt.Sprintf(CStringToString(format), realArgs...)
-	if len(result) > n {
-		result = result[:n]
+// Dprintf handles dprintf().
+//
+// Writes the C string pointed by format to the file descriptor fd as Fprintf.
+// On error, -1 is returned.
+func Dprintf(fd int, format []byte, args ...interface{}) int {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return -1
 	}
-	for i := range []byte(result) {
-		buffer[i] = result[i]
+	n, err := fmt.Fprintf(f, goFormat(format), localizeFloats(convert(args))...)
+	if err != nil {
+		return -1
 	}
-	buffer[len(result)] = '\x00'
-
-	n = len(result)
 	return n
 }
+
+// Vdprintf handles vdprintf().
+//
+// Writes the C string pointed by format to the file descriptor fd as Dprintf,
+// but the additional arguments are taken from the list ap.
+func Vdprintf(fd int, format []byte, ap *VaList) int {
+	return Dprintf(fd, format, ap.rest()...)
+}
+
+// Asprintf handles asprintf().
+//
+// Writes the C string pointed by format to the new allocated buffer, which is
+// stored in strp. Returns the length of the resulting string.
+func Asprintf(strp [][]byte, format []byte, args ...interface{}) int {
+	result := fmt.Sprintf(goFormat(format), localizeFloats(convert(args))...)
+	strp[0] = append([]byte(result), '\x00')
+	return len(result)
+}
+
+// Vasprintf handles vasprintf().
+//
+// Writes the C string pointed by format to the new allocated buffer as
+// Asprintf, but the additional arguments are taken from the list ap.
+func Vasprintf(strp [][]byte, format []byte, ap *VaList) int {
+	return Asprintf(strp, format, ap.rest()...)
+}
+
+// goFormat returns the format of package fmt for the format of printf() and
+// scanf(). Length modifiers of C, for example "%ld" or "%zu", are removed,
+// because the size of value is known from the type of argument in Go.
+// Conversions "%i" and "%u" are "%d" in Go.
+func goFormat(format []byte) string {
+	f := CStringToString(format)
+	if strings.IndexByte(f, '%') < 0 {
+		return f
+	}
+	var buf bytes.Buffer
+	for i := 0; i < len(f); i++ {
+		buf.WriteByte(f[i])
+		if f[i] != '%' {
+			continue
+		}
+		// flags, width and precision
+		i++
+		for i < len(f) && strings.IndexByte("-+ #0123456789.*", f[i]) >= 0 {
+			buf.WriteByte(f[i])
+			i++
+		}
+		// length modifiers: hh, h, l, ll, L, q, j, z, t
+		for i < len(f) && strings.IndexByte("hlLqjzt", f[i]) >= 0 {
+			i++
+		}
+		if i == len(f) {
+			break
+		}
+		switch f[i] {
+		case 'i', 'u':
+			buf.WriteByte('d')
+		case 'F':
+			buf.WriteByte('f')
+		default:
+			buf.WriteByte(f[i])
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,153 @@
+package noarch
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestGoFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"hello", "hello"},
+		{"%d %s", "%d %s"},
+		{"%ld %lu %lld %llu", "%d %d %d %d"},
+		{"%zu %zd %jd %td", "%d %d %d %d"},
+		{"%hd %hhu %i %u", "%d %d %d %d"},
+		{"%5.2lf %-8Lf %F", "%5.2f %-8f %f"},
+		{"%+05ld %*d %.*s", "%+05d %*d %.*s"},
+		{"100%% %lx %c", "100%% %x %c"},
+		{"%l", "%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := goFormat([]byte(tt.format + "\x00")); got != tt.want {
+				t.Errorf("goFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// tempStream returns the stream of temporary file with content and function
+// for removing of the file.
+func tempStream(t *testing.T, content string) (*File, func()) {
+	f, err := ioutil.TempFile("", "c4go-stdio-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	remove := func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+	if _, err = f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	return &File{OsFile: f}, remove
+}
+
+func TestFgets(t *testing.T) {
+	stream, remove := tempStream(t, "first\nsecond line\nlast")
+	defer remove()
+	str := []byte("XXXXXXXXXXXXXXXX")
+	tests := []struct {
+		num  int
+		want string
+	}{
+		{16, "first\n"},
+		{7, "second"},
+		{16, " line\n"},
+		{1, ""},
+		{16, "last"},
+	}
+	for _, tt := range tests {
+		if got := Fgets(str, tt.num, stream); got == nil ||
+			CStringToString(got) != tt.want {
+			t.Errorf("Fgets(%d) = %q, want %q", tt.num, got, tt.want)
+		}
+	}
+	if Feof(stream) == 0 {
+		t.Errorf("End-of-file is not reached")
+	}
+	if got := Fgets(str, 16, stream); got != nil {
+		t.Errorf("Fgets at end-of-file = %q", got)
+	}
+	if CStringToString(str) != "last" {
+		t.Errorf("Buffer is changed at end-of-file: %q", str)
+	}
+	if got := Fgets(str, 0, stream); got != nil {
+		t.Errorf("Fgets with zero size = %q", got)
+	}
+}
+
+func TestGetline(t *testing.T) {
+	long := string(make([]byte, 300))
+	stream, remove := tempStream(t, "a\n"+long+"\n\nb;c")
+	defer remove()
+	lineptr := [][]byte{nil}
+	n := []uint32{0}
+	tests := []struct {
+		delimiter int
+		want      string
+	}{
+		{'\n', "a\n"},
+		{'\n', long + "\n"},
+		{'\n', "\n"},
+		{';', "b;"},
+		{';', "c"},
+	}
+	for _, tt := range tests {
+		got := Getdelim(lineptr, n, tt.delimiter, stream)
+		if got != int32(len(tt.want)) ||
+			string(lineptr[0][:got]) != tt.want || lineptr[0][got] != 0 {
+			t.Errorf("Getdelim(%c) = %d, want %q", tt.delimiter, got, tt.want)
+		}
+		if n[0] != uint32(len(lineptr[0])) || n[0] <= uint32(got) {
+			t.Errorf("Not valid size of buffer: %d", n[0])
+		}
+	}
+	if got := Getline(lineptr, n, stream); got != -1 {
+		t.Errorf("Getline at end-of-file = %d", got)
+	}
+}
+
+func TestSnprintf(t *testing.T) {
+	format := []byte("%s=%d\x00")
+	tests := []struct {
+		buffer []byte
+		n      int
+		want   string
+	}{
+		{nil, 0, ""},
+		{make([]byte, 4), 0, ""},
+		{make([]byte, 4), 1, ""},
+		{make([]byte, 4), 4, "abc"},
+		{make([]byte, 16), 16, "abc=42"},
+		{make([]byte, 7), 7, "abc=42"},
+		{make([]byte, 6), 6, "abc=4"},
+		// size is more than the length of buffer
+		{make([]byte, 3), 10, "ab"},
+	}
+	for _, tt := range tests {
+		got := Snprintf(tt.buffer, tt.n, format, []byte("abc\x00"), 42)
+		if got != 6 {
+			t.Errorf("Snprintf(%d) returns %d, want 6", tt.n, got)
+		}
+		if tt.n > 0 && CStringToString(tt.buffer) != tt.want {
+			t.Errorf("Snprintf(%d) writes %q, want %q", tt.n, tt.buffer, tt.want)
+		}
+	}
+
+	// arguments of list are not taken, so the list is used again
+	var ap VaList
+	VaStart(&ap, []interface{}{[]byte("abc\x00"), 42})
+	n := Vsnprintf(nil, 0, format, &ap)
+	buffer := make([]byte, n+1)
+	if Vsnprintf(buffer, n+1, format, &ap) != n || CStringToString(buffer) != "abc=42" {
+		t.Errorf("Vsnprintf writes %q", buffer)
+	}
+}
@@ -3,6 +3,7 @@ package noarch
 import (
 	"math"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -393,11 +394,12 @@ func atof(str []byte) (float64, int) {
 		return 0, 0
 	}
 
-	// 2. Floating-point number?
-	r = util.GetRegex(`^[+-]?\d*(\.\d*)?(e[+-]?\d+)?`)
+	// 2. Floating-point number with the decimal-point character of locale?
+	dp := decimalPoint()
+	r = util.GetRegex(`^[+-]?\d*(` + regexp.QuoteMeta(dp) + `\d*)?(e[+-]?\d+)?`)
 	match = r.FindStringSubmatch(s)
 	if match != nil {
-		f, err := strconv.ParseFloat(match[0], 64)
+		f, err := strconv.ParseFloat(strings.Replace(match[0], dp, ".", 1), 64)
 		if err == nil {
 			return f, whitespaceLength + len(match[0])
 		}
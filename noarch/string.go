@@ -112,3 +112,106 @@ func Memmove(ptr []byte, source []byte, num uint32) []byte {
 	}
 	return ptr
 }
+
+// Strlcpy handles strlcpy().
+//
+// Copies the C string src to dst, which has place for size bytes. At most
+// size-1 characters are copied and the result is always terminated by the
+// null character, if size is not zero. Returns the length of src, so the
+// truncation is detected by the result >= size.
+func Strlcpy(dst, src []byte, size uint32) uint32 {
+	length := Strlen(src)
+	if size > uint32(len(dst)) {
+		size = uint32(len(dst))
+	}
+	if size > 0 {
+		n := copy(dst[:size-1], src[:length])
+		dst[n] = 0
+	}
+	return uint32(length)
+}
+
+// Strlcat handles strlcat().
+//
+// Appends the C string src to the end of C string dst, which has place for
+// size bytes. At most size-strlen(dst)-1 characters are appended and the
+// result is always terminated by the null character. Returns the initial
+// length of dst plus the length of src, so the truncation is detected by
+// the result >= size.
+func Strlcat(dst, src []byte, size uint32) uint32 {
+	if size > uint32(len(dst)) {
+		size = uint32(len(dst))
+	}
+	// length of dst, but not more than size
+	dlen := bytes.IndexByte(dst[:size], 0)
+	if dlen < 0 {
+		return size + uint32(Strlen(src))
+	}
+	return uint32(dlen) + Strlcpy(dst[dlen:], src, size-uint32(dlen))
+}
+
+// Strsep handles strsep().
+//
+// Finds the first token in the C string *stringp, which is delimited by one
+// of the characters of delim. The delimiter is overwritten by the null
+// character and *stringp is updated to point past the token. If no
+// delimiter is found, then *stringp is set to NULL. Returns the token or
+// NULL, if *stringp is NULL.
+func Strsep(stringp [][]byte, delim []byte) []byte {
+	s := stringp[0]
+	if s == nil {
+		return nil
+	}
+	i := bytes.IndexAny(s[:Strlen(s)], CStringToString(delim))
+	if i < 0 {
+		stringp[0] = nil
+		return s
+	}
+	s[i] = 0
+	stringp[0] = s[i+1:]
+	return s
+}
+
+// Bzero handles bzero().
+//
+// Erases the first n bytes of the memory starting at the location pointed
+// to by s by writing zeros.
+func Bzero(s []byte, n uint32) {
+	for i := range s[:n] {
+		s[i] = 0
+	}
+}
+
+// Bcopy handles bcopy().
+//
+// Copies n bytes from src to dest. The memory areas may overlap. Note the
+// order of arguments, which is different from memcpy().
+func Bcopy(src, dest []byte, n uint32) {
+	copy(dest[:n], src[:n])
+}
+
+// Index handles index().
+//
+// Works like strchr(): returns the first occurrence of the character c in
+// the C string s or NULL, if the character is not found.
+func Index(s []byte, c int) []byte {
+	if byte(c) == 0 {
+		return s[Strlen(s):]
+	}
+	return Strchr(s, c)
+}
+
+// Rindex handles rindex().
+//
+// Works like strrchr(): returns the last occurrence of the character c in
+// the C string s or NULL, if the character is not found.
+func Rindex(s []byte, c int) []byte {
+	length := Strlen(s)
+	if byte(c) == 0 {
+		return s[length:]
+	}
+	if i := bytes.LastIndexByte(s[:length], byte(c)); i >= 0 {
+		return s[i:]
+	}
+	return nil
+}
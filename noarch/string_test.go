@@ -0,0 +1,126 @@
+package noarch
+
+import "testing"
+
+func TestStrlcpy(t *testing.T) {
+	tests := []struct {
+		src    string
+		size   uint32
+		dst    string
+		result uint32
+	}{
+		{"hello", 10, "hello", 5},
+		{"hello", 6, "hello", 5},
+		{"hello", 5, "hell", 5},
+		{"hello", 1, "", 5},
+		{"", 4, "", 0},
+	}
+	for _, tt := range tests {
+		dst := []byte("XXXXXXXXXX")
+		result := Strlcpy(dst, []byte(tt.src+"\x00"), tt.size)
+		if result != tt.result || CStringToString(dst) != tt.dst {
+			t.Errorf("strlcpy(%q, %d) = %q, %d", tt.src, tt.size, dst, result)
+		}
+	}
+
+	// nothing is written with zero size
+	dst := []byte("XX")
+	if Strlcpy(dst, []byte("ab\x00"), 0) != 2 || string(dst) != "XX" {
+		t.Errorf("strlcpy with zero size: %q", dst)
+	}
+}
+
+func TestStrlcat(t *testing.T) {
+	tests := []struct {
+		dst    string
+		src    string
+		size   uint32
+		result string
+		length uint32
+	}{
+		{"foo", "bar", 10, "foobar", 6},
+		{"foo", "bar", 7, "foobar", 6},
+		{"foo", "bar", 5, "foob", 6},
+		{"foo", "bar", 4, "foo", 6},
+		{"", "bar", 3, "ba", 3},
+	}
+	for _, tt := range tests {
+		dst := make([]byte, 10)
+		copy(dst, tt.dst)
+		length := Strlcat(dst, []byte(tt.src+"\x00"), tt.size)
+		if length != tt.length || CStringToString(dst) != tt.result {
+			t.Errorf("strlcat(%q, %q, %d) = %q, %d",
+				tt.dst, tt.src, tt.size, dst, length)
+		}
+	}
+
+	// dst is not terminated inside of size
+	dst := []byte("foobar\x00")
+	if Strlcat(dst, []byte("baz\x00"), 3) != 6 || string(dst) != "foobar\x00" {
+		t.Errorf("strlcat of long dst: %q", dst)
+	}
+}
+
+func TestStrsep(t *testing.T) {
+	s := []byte("a,b;;c\x00")
+	stringp := [][]byte{s}
+	var tokens []string
+	for {
+		token := Strsep(stringp, []byte(",;\x00"))
+		if token == nil {
+			break
+		}
+		tokens = append(tokens, CStringToString(token))
+	}
+	expected := []string{"a", "b", "", "c"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("tokens %q", tokens)
+	}
+	for i := range tokens {
+		if tokens[i] != expected[i] {
+			t.Errorf("tokens %q", tokens)
+		}
+	}
+	if string(s) != "a\x00b\x00\x00c\x00" {
+		t.Errorf("string %q", s)
+	}
+}
+
+func TestBzeroBcopy(t *testing.T) {
+	b := []byte("abcdef")
+	Bzero(b[1:], 2)
+	if string(b) != "a\x00\x00def" {
+		t.Errorf("bzero: %q", b)
+	}
+
+	// overlapped memory
+	b = []byte("abcdef")
+	Bcopy(b, b[2:], 4)
+	if string(b) != "ababcd" {
+		t.Errorf("bcopy: %q", b)
+	}
+	b = []byte("abcdef")
+	Bcopy(b[2:], b, 4)
+	if string(b) != "cdefef" {
+		t.Errorf("bcopy: %q", b)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	s := []byte("path/to/file\x00")
+	if r := Index(s, '/'); CStringToString(r) != "/to/file" {
+		t.Errorf("index: %q", r)
+	}
+	if r := Rindex(s, '/'); CStringToString(r) != "/file" {
+		t.Errorf("rindex: %q", r)
+	}
+	if Index(s, 'x') != nil || Rindex(s, 'x') != nil {
+		t.Errorf("not found character")
+	}
+	if r := Rindex(s, 0); len(r) != 1 || r[0] != 0 {
+		t.Errorf("rindex of null character: %q", r)
+	}
+	if r := Index(s, 0); len(r) != 1 || r[0] != 0 {
+		t.Errorf("index of null character: %q", r)
+	}
+}
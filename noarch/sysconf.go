@@ -0,0 +1,60 @@
+package noarch
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// Sysconf handles sysconf().
+//
+// Returns the value of configuration option name at run time, for example
+// the size of page for _SC_PAGESIZE or the amount of online processors for
+// _SC_NPROCESSORS_ONLN. If the option is not supported or has no limit,
+// then -1 is returned.
+func Sysconf(name int) int32 {
+	var value int64 = -1
+	switch name {
+	case scArgMax:
+		value = argMax()
+	case scChildMax:
+		value = rlimit(rlimitNproc)
+	case scClkTck:
+		value = clkTck
+	case scNgroupsMax:
+		value = ngroupsMax
+	case scOpenMax:
+		value = rlimit(syscall.RLIMIT_NOFILE)
+	case scStreamMax:
+		value = streamMax
+	case scPagesize:
+		value = int64(os.Getpagesize())
+	case scLineMax:
+		value = 2048
+	case scIovMax:
+		value = 1024
+	case scHostNameMax:
+		value = hostNameMax
+	case scLoginNameMax:
+		value = loginNameMax
+	case scNprocessorsConf, scNprocessorsOnln:
+		value = int64(runtime.NumCPU())
+	case scPhysPages:
+		value = physPages(false)
+	case scAvphysPages:
+		value = physPages(true)
+	}
+	if value > 1<<31-1 {
+		value = 1<<31 - 1
+	}
+	return int32(value)
+}
+
+// rlimit returns the soft limit of resource, or -1 if there is no limit.
+func rlimit(resource int) int64 {
+	var r syscall.Rlimit
+	if syscall.Getrlimit(resource, &r) != nil || r.Cur == rlimInfinity {
+		return -1
+	}
+	return int64(r.Cur)
+}
@@ -0,0 +1,56 @@
+package noarch
+
+import (
+	"encoding/binary"
+	"syscall"
+)
+
+// Names of options of sysconf() from unistd.h of macOS
+const (
+	scArgMax          = 1
+	scChildMax        = 2
+	scClkTck          = 3
+	scNgroupsMax      = 4
+	scOpenMax         = 5
+	scLineMax         = 15
+	scStreamMax       = 26
+	scPagesize        = 29
+	scIovMax          = 56
+	scNprocessorsConf = 57
+	scNprocessorsOnln = 58
+	scHostNameMax     = 72
+	scLoginNameMax    = 73
+	scPhysPages       = 200
+	// option is not supported by macOS
+	scAvphysPages = -1
+)
+
+// Values of sysconf() options, which are constants in macOS
+const (
+	ngroupsMax   = 16
+	streamMax    = 20
+	hostNameMax  = 255
+	loginNameMax = 255
+)
+
+const (
+	rlimitNproc  = 7
+	rlimInfinity = 1<<63 - 1
+)
+
+// argMax returns the maximal length of arguments of exec().
+func argMax() int64 {
+	return 1048576
+}
+
+// physPages returns the amount of pages of physical memory. The amount of
+// available physical memory is not supported.
+func physPages(available bool) int64 {
+	memsize, err := syscall.Sysctl("hw.memsize")
+	if available || err != nil {
+		return -1
+	}
+	// value of sysctl is uint64 without the last zero byte
+	b := append([]byte(memsize), 0, 0, 0, 0, 0, 0, 0, 0)
+	return int64(binary.LittleEndian.Uint64(b) / uint64(syscall.Getpagesize()))
+}
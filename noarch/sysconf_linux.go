@@ -0,0 +1,59 @@
+package noarch
+
+import "syscall"
+
+// Names of options of sysconf() from bits/confname.h of glibc
+const (
+	scArgMax          = 0
+	scChildMax        = 1
+	scClkTck          = 2
+	scNgroupsMax      = 3
+	scOpenMax         = 4
+	scStreamMax       = 5
+	scPagesize        = 30
+	scLineMax         = 43
+	scIovMax          = 60
+	scLoginNameMax    = 71
+	scNprocessorsConf = 83
+	scNprocessorsOnln = 84
+	scPhysPages       = 85
+	scAvphysPages     = 86
+	scHostNameMax     = 180
+)
+
+// Values of sysconf() options, which are constants in glibc
+const (
+	ngroupsMax   = 65536
+	streamMax    = 16
+	hostNameMax  = 64
+	loginNameMax = 256
+)
+
+const (
+	rlimitNproc  = 6
+	rlimInfinity = ^uint64(0)
+)
+
+// argMax returns the maximal length of arguments of exec() as in glibc: a
+// quarter of the limit of stack size, but not less than 128 KiB.
+func argMax() int64 {
+	const legacyArgMax = 131072
+	if stack := rlimit(syscall.RLIMIT_STACK); stack/4 > legacyArgMax {
+		return stack / 4
+	}
+	return legacyArgMax
+}
+
+// physPages returns the amount of pages of physical memory or of available
+// physical memory.
+func physPages(available bool) int64 {
+	var info syscall.Sysinfo_t
+	if syscall.Sysinfo(&info) != nil {
+		return -1
+	}
+	memory := uint64(info.Totalram)
+	if available {
+		memory = uint64(info.Freeram)
+	}
+	return int64(memory * uint64(info.Unit) / uint64(syscall.Getpagesize()))
+}
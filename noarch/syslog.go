@@ -0,0 +1,119 @@
+package noarch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Values of syslog.h constants
+const (
+	logPrimask = 0x07
+	logFacmask = 0x03f8
+	logUser    = 1 << 3
+
+	// options of openlog
+	logPid    = 0x01
+	logPerror = 0x20
+)
+
+// syslogState - parameters of openlog() and setlogmask()
+var syslogState = struct {
+	sync.Mutex
+	ident    string
+	option   int
+	facility int
+	mask     int
+}{
+	facility: logUser,
+	mask:     0xff,
+}
+
+// Openlog handles openlog().
+//
+// Opens a connection to the system logger. The string ident is prepended to
+// every message, if it is NULL, then the program name is used. Options
+// LOG_PID and LOG_PERROR are supported, the connection is always opened on
+// the first message. Argument facility is the default facility of
+// messages.
+func Openlog(ident []byte, option int, facility int) {
+	syslogState.Lock()
+	defer syslogState.Unlock()
+	syslogClose()
+	syslogState.ident = ""
+	if ident != nil {
+		syslogState.ident = CStringToString(ident)
+	}
+	syslogState.option = option
+	if facility&^logFacmask == 0 && facility != 0 {
+		syslogState.facility = facility
+	}
+}
+
+// Syslog handles syslog().
+//
+// Generates a log message with the priority, which is a combination of
+// facility and level. The message is formatted as printf(). If the system
+// logger is not available, then the message is written to stderr. Format
+// "%m" of errno is not supported.
+func Syslog(priority int, format []byte, args ...interface{}) {
+	syslogState.Lock()
+	defer syslogState.Unlock()
+
+	level := priority & logPrimask
+	if syslogState.mask&(1<<uint(level)) == 0 {
+		return
+	}
+	facility := priority & logFacmask
+	if facility == 0 {
+		facility = syslogState.facility
+	}
+
+	msg := fmt.Sprintf(goFormat(format), localizeFloats(convert(args))...)
+	msg = strings.TrimRight(msg, "\n")
+
+	tag := syslogState.ident
+	if tag == "" {
+		tag = filepath.Base(os.Args[0])
+	}
+	err := syslogWrite(facility, level, tag, msg)
+	if err != nil || syslogState.option&logPerror != 0 {
+		if syslogState.option&logPid != 0 {
+			tag = fmt.Sprintf("%s[%d]", tag, os.Getpid())
+		}
+		fmt.Fprintf(Stderr.OsFile, "%s: %s\n", tag, msg)
+	}
+}
+
+// Vsyslog handles vsyslog().
+//
+// Generates a log message as syslog(), but the additional arguments are
+// taken from the list ap.
+func Vsyslog(priority int, format []byte, ap *VaList) {
+	Syslog(priority, format, ap.rest()...)
+}
+
+// Closelog handles closelog().
+//
+// Closes the connection to the system logger.
+func Closelog() {
+	syslogState.Lock()
+	defer syslogState.Unlock()
+	syslogClose()
+}
+
+// Setlogmask handles setlogmask().
+//
+// Sets the mask of levels, which are logged by syslog(). If the mask is
+// zero, then the current mask is not changed. Returns the previous mask.
+func Setlogmask(mask int) int {
+	syslogState.Lock()
+	defer syslogState.Unlock()
+	old := syslogState.mask
+	if mask != 0 {
+		syslogState.mask = mask
+	}
+	return old
+}
@@ -0,0 +1,14 @@
+// +build windows plan9
+
+package noarch
+
+import "errors"
+
+// syslogWrite returns error, because the system logger is not available on
+// the platform, so messages are written to stderr.
+func syslogWrite(facility, level int, tag, msg string) error {
+	return errors.New("system logger is not available")
+}
+
+// syslogClose is empty, because the system logger is not available.
+func syslogClose() {}
@@ -0,0 +1,47 @@
+package noarch
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSyslog(t *testing.T) {
+	stream, remove := tempStream(t, "")
+	defer remove()
+	stderr := Stderr
+	Stderr = stream
+	defer func() {
+		Stderr = stderr
+		Closelog()
+		Setlogmask(0xff)
+		Openlog(nil, 0, logUser)
+	}()
+
+	// messages are duplicated to stderr with LOG_PERROR
+	Openlog([]byte("c4go-test\x00"), logPerror, logUser)
+	Syslog(3, []byte("value %d, %s\n\x00"), 42, []byte("str\x00"))
+	if old := Setlogmask(1 << 3); old != 0xff {
+		t.Errorf("mask = %x", old)
+	}
+	Syslog(6, []byte("filtered by mask\x00"))
+	Syslog(logUser|3, []byte("error\x00"))
+	if old := Setlogmask(0); old != 1<<3 {
+		t.Errorf("mask = %x", old)
+	}
+	Setlogmask(0xff)
+	Openlog([]byte("c4go-test\x00"), logPerror|logPid, 0)
+	Syslog(7, []byte("debug\x00"))
+	Closelog()
+
+	content, err := ioutil.ReadFile(stream.OsFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := fmt.Sprintf("c4go-test: value 42, str\nc4go-test: error\n"+
+		"c4go-test[%d]: debug\n", os.Getpid())
+	if string(content) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
@@ -0,0 +1,48 @@
+// +build !windows,!plan9
+
+package noarch
+
+import "log/syslog"
+
+// syslogWriters - connections to the system logger by facility
+var syslogWriters = map[int]*syslog.Writer{}
+
+// syslogWrite writes the message to the system logger by package
+// log/syslog. The process ID is always added to the tag by package
+// log/syslog.
+func syslogWrite(facility, level int, tag, msg string) error {
+	w, ok := syslogWriters[facility]
+	if !ok {
+		var err error
+		w, err = syslog.Dial("", "", syslog.Priority(facility)|syslog.LOG_INFO, tag)
+		if err != nil {
+			return err
+		}
+		syslogWriters[facility] = w
+	}
+	switch syslog.Priority(level) {
+	case syslog.LOG_EMERG:
+		return w.Emerg(msg)
+	case syslog.LOG_ALERT:
+		return w.Alert(msg)
+	case syslog.LOG_CRIT:
+		return w.Crit(msg)
+	case syslog.LOG_ERR:
+		return w.Err(msg)
+	case syslog.LOG_WARNING:
+		return w.Warning(msg)
+	case syslog.LOG_NOTICE:
+		return w.Notice(msg)
+	case syslog.LOG_INFO:
+		return w.Info(msg)
+	}
+	return w.Debug(msg)
+}
+
+// syslogClose closes all connections to the system logger.
+func syslogClose() {
+	for facility, w := range syslogWriters {
+		w.Close()
+		delete(syslogWriters, facility)
+	}
+}
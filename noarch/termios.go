@@ -0,0 +1,159 @@
+package noarch
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Termios represents the C structure "struct termios" from termios.h:
+//
+//     struct termios {
+//         tcflag_t c_iflag;
+//         tcflag_t c_oflag;
+//         tcflag_t c_cflag;
+//         tcflag_t c_lflag;
+//         cc_t     c_line;
+//         cc_t     c_cc[NCCS];
+//         speed_t  c_ispeed;
+//         speed_t  c_ospeed;
+//     };
+//
+// The flags and the indexes of c_cc are passed to operation system as is.
+type Termios struct {
+	CIflag  uint32
+	COflag  uint32
+	CCflag  uint32
+	CLflag  uint32
+	CLine   byte
+	CCc     [32]byte
+	CIspeed uint32
+	COspeed uint32
+}
+
+// Winsize represents the C structure "struct winsize" from sys/ioctl.h:
+//
+//     struct winsize {
+//         unsigned short ws_row;
+//         unsigned short ws_col;
+//         unsigned short ws_xpixel;
+//         unsigned short ws_ypixel;
+//     };
+type Winsize struct {
+	WsRow    uint16
+	WsCol    uint16
+	WsXpixel uint16
+	WsYpixel uint16
+}
+
+// Values of termios.h constants
+const (
+	tcsanow   = 0
+	tcsadrain = 1
+	tcsaflush = 2
+)
+
+// ioctl calls the system call ioctl with the pointer argument.
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	systemFd, ok := getSystemFd(fd)
+	if !ok {
+		return syscall.EBADF
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(systemFd),
+		request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Tcgetattr handles tcgetattr().
+//
+// Gets the parameters of the terminal referred by fd and stores them in
+// the structure termios. Returns zero on success, or -1 if fd is not a
+// terminal.
+func Tcgetattr(fd int, termios []Termios) int {
+	var t syscall.Termios
+	if ioctl(fd, ioctlGetTermios, unsafe.Pointer(&t)) != nil {
+		return -1
+	}
+	termios[0] = termiosFromSystem(t)
+	return 0
+}
+
+// Tcsetattr handles tcsetattr().
+//
+// Sets the parameters of the terminal referred by fd from the structure
+// termios. Argument optionalActions specifies, when the changes take
+// effect:
+//
+//     TCSANOW   - the change occurs immediately
+//     TCSADRAIN - the change occurs after all output has been transmitted
+//     TCSAFLUSH - as TCSADRAIN, and all unread input is discarded
+//
+// Returns zero on success, or -1 on error.
+func Tcsetattr(fd int, optionalActions int, termios []Termios) int {
+	if optionalActions < tcsanow || tcsaflush < optionalActions {
+		return -1
+	}
+	t := termiosToSystem(termios[0])
+	request := ioctlSetTermios + uintptr(optionalActions)
+	if ioctl(fd, request, unsafe.Pointer(&t)) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Cfmakeraw handles cfmakeraw().
+//
+// Sets the terminal to raw mode: input is available character by
+// character, echoing is disabled, and all special processing of terminal
+// input and output characters is disabled.
+func Cfmakeraw(termios []Termios) {
+	t := &termios[0]
+	t.CIflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK |
+		syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL |
+		syscall.IXON
+	t.COflag &^= syscall.OPOST
+	t.CLflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON |
+		syscall.ISIG | syscall.IEXTEN
+	t.CCflag &^= syscall.CSIZE | syscall.PARENB
+	t.CCflag |= syscall.CS8
+	t.CCc[syscall.VMIN] = 1
+	t.CCc[syscall.VTIME] = 0
+}
+
+// Isatty handles isatty().
+//
+// Returns 1, if fd is an open file descriptor referring to a terminal,
+// otherwise 0 is returned.
+func Isatty(fd int) int {
+	var t syscall.Termios
+	if ioctl(fd, ioctlGetTermios, unsafe.Pointer(&t)) != nil {
+		return 0
+	}
+	return 1
+}
+
+// Ioctl handles ioctl().
+//
+// Manipulates the parameters of devices. Only the requests of terminal
+// window size TIOCGWINSZ and TIOCSWINSZ with the argument of type
+// "struct winsize *" are supported. Returns zero on success, or -1 on
+// error.
+func Ioctl(fd int, request uint32, args ...interface{}) int {
+	if len(args) != 1 {
+		return -1
+	}
+	ws, ok := args[0].([]Winsize)
+	if !ok || len(ws) == 0 {
+		return -1
+	}
+	switch uintptr(request) {
+	case syscall.TIOCGWINSZ, syscall.TIOCSWINSZ:
+		if ioctl(fd, uintptr(request), unsafe.Pointer(&ws[0])) != nil {
+			return -1
+		}
+		return 0
+	}
+	return -1
+}
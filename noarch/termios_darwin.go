@@ -0,0 +1,38 @@
+package noarch
+
+import "syscall"
+
+// Requests of ioctl for the parameters of terminal. Requests for setting
+// with TCSADRAIN and TCSAFLUSH follow ioctlSetTermios.
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
+
+// termiosFromSystem converts the parameters of terminal to Termios.
+func termiosFromSystem(t syscall.Termios) Termios {
+	termios := Termios{
+		CIflag:  uint32(t.Iflag),
+		COflag:  uint32(t.Oflag),
+		CCflag:  uint32(t.Cflag),
+		CLflag:  uint32(t.Lflag),
+		CIspeed: uint32(t.Ispeed),
+		COspeed: uint32(t.Ospeed),
+	}
+	copy(termios.CCc[:], t.Cc[:])
+	return termios
+}
+
+// termiosToSystem converts Termios to the parameters of terminal.
+func termiosToSystem(termios Termios) syscall.Termios {
+	t := syscall.Termios{
+		Iflag:  uint64(termios.CIflag),
+		Oflag:  uint64(termios.COflag),
+		Cflag:  uint64(termios.CCflag),
+		Lflag:  uint64(termios.CLflag),
+		Ispeed: uint64(termios.CIspeed),
+		Ospeed: uint64(termios.COspeed),
+	}
+	copy(t.Cc[:], termios.CCc[:])
+	return t
+}
@@ -0,0 +1,40 @@
+package noarch
+
+import "syscall"
+
+// Requests of ioctl for the parameters of terminal. Requests for setting
+// with TCSADRAIN and TCSAFLUSH follow ioctlSetTermios.
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
+
+// termiosFromSystem converts the parameters of terminal to Termios.
+func termiosFromSystem(t syscall.Termios) Termios {
+	termios := Termios{
+		CIflag:  t.Iflag,
+		COflag:  t.Oflag,
+		CCflag:  t.Cflag,
+		CLflag:  t.Lflag,
+		CLine:   t.Line,
+		CIspeed: t.Ispeed,
+		COspeed: t.Ospeed,
+	}
+	copy(termios.CCc[:], t.Cc[:])
+	return termios
+}
+
+// termiosToSystem converts Termios to the parameters of terminal.
+func termiosToSystem(termios Termios) syscall.Termios {
+	t := syscall.Termios{
+		Iflag:  termios.CIflag,
+		Oflag:  termios.COflag,
+		Cflag:  termios.CCflag,
+		Lflag:  termios.CLflag,
+		Line:   termios.CLine,
+		Ispeed: termios.CIspeed,
+		Ospeed: termios.COspeed,
+	}
+	copy(t.Cc[:], termios.CCc[:])
+	return t
+}
@@ -0,0 +1,72 @@
+package noarch
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// openPty opens the pseudoterminal and returns file descriptors of C
+// program for the master and the slave.
+func openPty(t *testing.T) (master, slave int, cleanup func()) {
+	m, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("pseudoterminal is not available: %v", err)
+	}
+	master = newFileDescriptor(m)
+	var unlock, n int32
+	if ioctl(master, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)) != nil ||
+		ioctl(master, syscall.TIOCGPTN, unsafe.Pointer(&n)) != nil {
+		releaseFileDescriptor(master)
+		t.Skip("pseudoterminal cannot be unlocked")
+	}
+	s, err := os.OpenFile("/dev/pts/"+strconv.Itoa(int(n)), os.O_RDWR, 0)
+	if err != nil {
+		releaseFileDescriptor(master)
+		t.Skipf("pseudoterminal is not available: %v", err)
+	}
+	slave = newFileDescriptor(s)
+	return master, slave, func() {
+		releaseFileDescriptor(slave)
+		releaseFileDescriptor(master)
+	}
+}
+
+func TestTermiosPty(t *testing.T) {
+	_, slave, cleanup := openPty(t)
+	defer cleanup()
+
+	if Isatty(slave) != 1 {
+		t.Fatalf("pseudoterminal is not terminal")
+	}
+	termios := make([]Termios, 1)
+	if Tcgetattr(slave, termios) != 0 {
+		t.Fatalf("tcgetattr")
+	}
+	if termios[0].CLflag&syscall.ICANON == 0 {
+		t.Errorf("terminal is not in canonical mode by default")
+	}
+	Cfmakeraw(termios)
+	if Tcsetattr(slave, tcsaflush, termios) != 0 {
+		t.Fatalf("tcsetattr")
+	}
+	raw := make([]Termios, 1)
+	Tcgetattr(slave, raw)
+	if raw[0].CLflag&(syscall.ICANON|syscall.ECHO) != 0 || raw[0].CCc[syscall.VMIN] != 1 {
+		t.Errorf("terminal is not in raw mode: %#v", raw[0])
+	}
+	if Tcsetattr(slave, 3, termios) != -1 {
+		t.Errorf("not valid optional actions")
+	}
+
+	ws := []Winsize{{WsRow: 24, WsCol: 80}}
+	if Ioctl(slave, syscall.TIOCSWINSZ, ws) != 0 {
+		t.Fatalf("set window size")
+	}
+	size := make([]Winsize, 1)
+	if Ioctl(slave, syscall.TIOCGWINSZ, size) != 0 || size[0] != ws[0] {
+		t.Errorf("window size is %#v", size[0])
+	}
+}
@@ -0,0 +1,58 @@
+package noarch
+
+import (
+	"io/ioutil"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestTermiosNotTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "c4go-termios-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	fd := newFileDescriptor(f)
+	defer releaseFileDescriptor(fd)
+
+	if Isatty(fd) != 0 {
+		t.Errorf("file is terminal")
+	}
+	termios := make([]Termios, 1)
+	if Tcgetattr(fd, termios) != -1 {
+		t.Errorf("tcgetattr of file")
+	}
+	if Tcsetattr(fd, tcsanow, termios) != -1 {
+		t.Errorf("tcsetattr of file")
+	}
+	if Isatty(100) != 0 {
+		t.Errorf("not opened file descriptor is terminal")
+	}
+	ws := make([]Winsize, 1)
+	if Ioctl(fd, syscall.TIOCGWINSZ, ws) != -1 {
+		t.Errorf("window size of file")
+	}
+	if Ioctl(fd, syscall.TIOCGWINSZ) != -1 || Ioctl(fd, 0, ws) != -1 {
+		t.Errorf("not valid request")
+	}
+}
+
+func TestCfmakeraw(t *testing.T) {
+	termios := []Termios{{
+		CIflag: syscall.ICRNL | syscall.IXON | syscall.IXOFF,
+		COflag: syscall.OPOST,
+		CCflag: syscall.CS7 | syscall.PARENB | syscall.CREAD,
+		CLflag: syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.ECHOE,
+	}}
+	Cfmakeraw(termios)
+	expected := Termios{
+		CIflag: syscall.IXOFF,
+		CCflag: syscall.CS8 | syscall.CREAD,
+		CLflag: syscall.ECHOE,
+	}
+	expected.CCc[syscall.VMIN] = 1
+	if termios[0] != expected {
+		t.Errorf("expected %#v, got %#v", expected, termios[0])
+	}
+}
@@ -0,0 +1,367 @@
+package noarch
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Values of threads.h constants
+const (
+	thrdSuccess  = 0
+	thrdBusy     = 1
+	thrdError    = 2
+	thrdNomem    = 3
+	thrdTimedout = 4
+
+	mtxPlain     = 0
+	mtxRecursive = 1
+	mtxTimed     = 2
+)
+
+// ThrdT represents the C type thrd_t from threads.h - a thread ID. Threads
+// of threads.h are the same as threads of pthread.h.
+type ThrdT uint32
+
+// MtxT represents the C type mtx_t from threads.h. Zero value is an
+// unlocked mutex.
+type MtxT struct {
+	m PthreadMutexT
+}
+
+// CndT represents the C type cnd_t from threads.h.
+type CndT struct {
+	c PthreadCondT
+}
+
+// OnceFlag represents the C type once_flag from threads.h. Zero value is
+// equal to ONCE_FLAG_INIT.
+type OnceFlag struct {
+	once sync.Once
+}
+
+// TssT represents the C type tss_t from threads.h - a key of thread-specific
+// storage.
+type TssT uint32
+
+// thrdResult converts the result of pthread function to the result of
+// threads.h function.
+func thrdResult(result int) int {
+	switch result {
+	case 0:
+		return thrdSuccess
+	case errBusy:
+		return thrdBusy
+	case errTimedout:
+		return thrdTimedout
+	}
+	return thrdError
+}
+
+// ThrdCreate handles thrd_create().
+//
+// Creates a new thread executing the function f. The function is invoked as
+// f(arg). If successful, the object pointed to by thr is set to the
+// identifier of the new thread. Returns thrd_success on success.
+func ThrdCreate(thr []ThrdT, f func(interface{}) int, arg interface{}) int {
+	if f == nil {
+		return thrdError
+	}
+	id := make([]PthreadT, 1)
+	result := PthreadCreate(id, nil, func(arg interface{}) interface{} {
+		return f(arg)
+	}, arg)
+	if result != 0 {
+		return thrdResult(result)
+	}
+	if len(thr) > 0 {
+		thr[0] = ThrdT(id[0])
+	}
+	return thrdSuccess
+}
+
+// ThrdCurrent handles thrd_current().
+//
+// Returns the identifier of the calling thread.
+func ThrdCurrent() ThrdT {
+	return ThrdT(PthreadSelf())
+}
+
+// ThrdEqual handles thrd_equal().
+//
+// Checks whether lhs and rhs refer to the same thread. Returns nonzero
+// value if lhs and rhs refer to the same value, 0 otherwise.
+func ThrdEqual(lhs, rhs ThrdT) int {
+	return PthreadEqual(PthreadT(lhs), PthreadT(rhs))
+}
+
+// ThrdJoin handles thrd_join().
+//
+// Blocks the current thread until the thread identified by thr finishes
+// execution. If res is not a null pointer, the result code of the thread is
+// put to the location pointed to by res. Returns thrd_success on success.
+func ThrdJoin(thr ThrdT, res []int) int {
+	result := make([]interface{}, 1)
+	if r := PthreadJoin(PthreadT(thr), result); r != 0 {
+		return thrdResult(r)
+	}
+	if len(res) > 0 {
+		if v, ok := result[0].(int); ok {
+			res[0] = v
+		}
+	}
+	return thrdSuccess
+}
+
+// ThrdDetach handles thrd_detach().
+//
+// Detaches the thread identified by thr from the current environment. The
+// resources held by the thread will be freed automatically once the thread
+// exits. Returns thrd_success on success.
+func ThrdDetach(thr ThrdT) int {
+	return thrdResult(PthreadDetach(PthreadT(thr)))
+}
+
+// ThrdExit handles thrd_exit().
+//
+// Terminates the calling thread and sets its result code to res.
+func ThrdExit(res int) {
+	PthreadExit(res)
+}
+
+// ThrdSleep handles thrd_sleep().
+//
+// Blocks the execution of the current thread for at least until the
+// time interval pointed to by duration has elapsed. Returns 0 on
+// successful sleep.
+func ThrdSleep(duration []Timespec, remaining []Timespec) int {
+	d := time.Duration(duration[0].TvSec)*time.Second +
+		time.Duration(duration[0].TvNsec)
+	if d < 0 {
+		return -2
+	}
+	time.Sleep(d)
+	if len(remaining) > 0 {
+		remaining[0] = Timespec{}
+	}
+	return 0
+}
+
+// ThrdYield handles thrd_yield().
+//
+// Provides a hint to the implementation to reschedule the execution of
+// threads, allowing other threads to run.
+func ThrdYield() {
+	runtime.Gosched()
+}
+
+// MtxInit handles mtx_init().
+//
+// Creates a new mutex object with type. The type must have one of the
+// following values: mtx_plain, mtx_timed, mtx_plain | mtx_recursive,
+// mtx_timed | mtx_recursive. Returns thrd_success if successful.
+func MtxInit(mutex []MtxT, kind int) int {
+	if kind&mtxRecursive != 0 {
+		return thrdResult(mutex[0].m.init(pthreadMutexRecursive))
+	}
+	return thrdResult(mutex[0].m.init(pthreadMutexNormal))
+}
+
+// MtxLock handles mtx_lock().
+//
+// Blocks the current thread until the mutex is locked. Returns
+// thrd_success if successful.
+func MtxLock(mutex []MtxT) int {
+	return thrdResult(mutex[0].m.lock(false, nil))
+}
+
+// MtxTimedlock handles mtx_timedlock().
+//
+// Blocks the current thread until the mutex is locked or until the time
+// point pointed to by timePoint has been reached. Returns thrd_timedout if
+// the time point was reached.
+func MtxTimedlock(mutex []MtxT, timePoint []Timespec) int {
+	deadline := timespecToTime(timePoint[0])
+	return thrdResult(mutex[0].m.lock(false, &deadline))
+}
+
+// MtxTrylock handles mtx_trylock().
+//
+// Tries to lock the mutex without blocking. Returns immediately if the
+// mutex is already locked. Returns thrd_busy if the mutex has already been
+// locked.
+func MtxTrylock(mutex []MtxT) int {
+	return thrdResult(mutex[0].m.lock(true, nil))
+}
+
+// MtxUnlock handles mtx_unlock().
+//
+// Unlocks the mutex. Returns thrd_success if successful.
+func MtxUnlock(mutex []MtxT) int {
+	return thrdResult(mutex[0].m.unlock())
+}
+
+// MtxDestroy handles mtx_destroy().
+//
+// Destroys the mutex.
+func MtxDestroy(mutex []MtxT) {
+}
+
+// CndInit handles cnd_init().
+//
+// Initializes new condition variable. Returns thrd_success if the
+// condition variable was successfully created.
+func CndInit(cond []CndT) int {
+	return thrdResult(cond[0].c.init())
+}
+
+// CndSignal handles cnd_signal().
+//
+// Unblocks one thread that currently waits on condition variable. Returns
+// thrd_success if successful.
+func CndSignal(cond []CndT) int {
+	return thrdResult(cond[0].c.signal(false))
+}
+
+// CndBroadcast handles cnd_broadcast().
+//
+// Unblocks all thread that currently wait on condition variable. Returns
+// thrd_success if successful.
+func CndBroadcast(cond []CndT) int {
+	return thrdResult(cond[0].c.signal(true))
+}
+
+// CndWait handles cnd_wait().
+//
+// Atomically unlocks the mutex and blocks on the condition variable until
+// the thread is signalled. The mutex is locked again before the function
+// returns. Returns thrd_success if successful.
+func CndWait(cond []CndT, mutex []MtxT) int {
+	return thrdResult(cond[0].c.wait(&mutex[0].m, nil))
+}
+
+// CndTimedwait handles cnd_timedwait().
+//
+// Works like cnd_wait(), except that thrd_timedout is returned if the time
+// point pointed to by timePoint has been reached before the thread is
+// signalled.
+func CndTimedwait(cond []CndT, mutex []MtxT, timePoint []Timespec) int {
+	deadline := timespecToTime(timePoint[0])
+	return thrdResult(cond[0].c.wait(&mutex[0].m, &deadline))
+}
+
+// CndDestroy handles cnd_destroy().
+//
+// Destroys the condition variable.
+func CndDestroy(cond []CndT) {
+}
+
+// CallOnce handles call_once().
+//
+// Calls function func exactly once, even if invoked from several threads.
+// The completion of the function func synchronizes with all previous or
+// subsequent calls to call_once with the same flag variable.
+func CallOnce(flag []OnceFlag, f func()) {
+	flag[0].once.Do(f)
+}
+
+// TssCreate handles tss_create().
+//
+// Creates new thread-specific storage key and stores it in the object
+// pointed to by key. The destructor is called at thread exit for each
+// non-NULL value. Returns thrd_success if successful.
+func TssCreate(key []TssT, destructor func(interface{})) int {
+	k := make([]PthreadKeyT, 1)
+	if r := PthreadKeyCreate(k, destructor); r != 0 {
+		return thrdResult(r)
+	}
+	key[0] = TssT(k[0])
+	return thrdSuccess
+}
+
+// TssGet handles tss_get().
+//
+// Returns the value held in thread-specific storage for the current thread
+// identified by key.
+func TssGet(key TssT) interface{} {
+	return PthreadGetspecific(PthreadKeyT(key))
+}
+
+// TssSet handles tss_set().
+//
+// Sets the value of the thread-specific storage identified by key for the
+// current thread to value. Returns thrd_success if successful.
+func TssSet(key TssT, value interface{}) int {
+	return thrdResult(PthreadSetspecific(PthreadKeyT(key), value))
+}
+
+// TssDelete handles tss_delete().
+//
+// Destroys the thread-specific storage identified by key. The destructors
+// are not invoked.
+func TssDelete(key TssT) {
+	PthreadKeyDelete(PthreadKeyT(key))
+}
+
+// ThreadLocal is a variable with storage class thread_local (_Thread_local)
+// from C11. Each thread has own copy of variable, which is created at the
+// first access. Example of C code:
+//
+//     thread_local int counter = 5;
+//
+// Example of Go code:
+//
+//     var counter = noarch.NewThreadLocal(func() interface{} {
+//         return []int{5}
+//     })
+//
+// Value of variable is an element of slice: counter.Get().([]int)[0]
+type ThreadLocal struct {
+	mu     sync.Mutex
+	init   func() interface{}
+	values map[PthreadT]interface{}
+}
+
+// threadLocals is the list of all thread-local variables.
+var threadLocals = struct {
+	sync.Mutex
+	list []*ThreadLocal
+}{}
+
+// NewThreadLocal creates thread-local variable. The function init returns the
+// slice with initial value of variable.
+func NewThreadLocal(init func() interface{}) *ThreadLocal {
+	t := &ThreadLocal{
+		init:   init,
+		values: map[PthreadT]interface{}{},
+	}
+	threadLocals.Lock()
+	threadLocals.list = append(threadLocals.list, t)
+	threadLocals.Unlock()
+	return t
+}
+
+// Get returns the slice with value of variable for the current thread.
+func (t *ThreadLocal) Get() interface{} {
+	id := PthreadSelf()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	v, ok := t.values[id]
+	if !ok {
+		v = t.init()
+		t.values[id] = v
+	}
+	return v
+}
+
+// removeThreadLocals removes the values of thread-local variables of
+// finished thread.
+func removeThreadLocals(id PthreadT) {
+	threadLocals.Lock()
+	defer threadLocals.Unlock()
+	for _, t := range threadLocals.list {
+		t.mu.Lock()
+		delete(t.values, id)
+		t.mu.Unlock()
+	}
+}
@@ -2,6 +2,7 @@ package noarch
 
 import (
 	"fmt"
+	"syscall"
 	"time"
 )
 
@@ -127,3 +128,101 @@ func Asctime(tm []Tm) []byte {
 		tm[0].TmMin, tm[0].TmSec,
 		1900+tm[0].TmYear))
 }
+
+// Timespec represents the C structure "struct timespec" from time.h:
+//
+//     struct timespec {
+//         time_t tv_sec;
+//         long   tv_nsec;
+//     };
+type Timespec struct {
+	TvSec  int32
+	TvNsec int32
+}
+
+// timeToTimespec converts Go time to Timespec.
+func timeToTimespec(t time.Time) Timespec {
+	return Timespec{
+		TvSec:  int32(t.Unix()),
+		TvNsec: int32(t.Nanosecond()),
+	}
+}
+
+// timespecToTime converts Timespec to Go time.
+func timespecToTime(ts Timespec) time.Time {
+	return time.Unix(int64(ts.TvSec), int64(ts.TvNsec))
+}
+
+// ClockT is the representation of "clock_t" - the processor time in clock
+// ticks.
+type ClockT int32
+
+// clocksPerSec is the value of macro CLOCKS_PER_SEC from time.h of glibc -
+// the amount of clock ticks per second.
+const clocksPerSec = 1000000
+
+// Clock handles clock().
+//
+// Returns the processor time consumed by the program in clock ticks. The
+// value in seconds is clock() / CLOCKS_PER_SEC. If the processor time is
+// not available, returns -1.
+func Clock() ClockT {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return -1
+	}
+	ns := usage.Utime.Nano() + usage.Stime.Nano()
+	return ClockT(ns / (int64(time.Second) / clocksPerSec))
+}
+
+// Nanosleep handles nanosleep().
+//
+// Suspends the execution of the calling thread until at least the time
+// specified in req has elapsed. The sleep is never interrupted by signals,
+// so the remaining time in rem is always zero. If the value of req is
+// invalid, returns -1; otherwise, returns 0.
+func Nanosleep(req []Timespec, rem []Timespec) int {
+	if len(req) == 0 || req[0].TvSec < 0 ||
+		req[0].TvNsec < 0 || req[0].TvNsec > 999999999 {
+		return -1
+	}
+	time.Sleep(time.Duration(req[0].TvSec)*time.Second +
+		time.Duration(req[0].TvNsec))
+	if len(rem) > 0 {
+		rem[0] = Timespec{}
+	}
+	return 0
+}
+
+// Sleep handles sleep().
+//
+// Makes the calling thread sleep until seconds seconds have elapsed.
+// Returns the number of seconds left to sleep, which is always zero.
+func Sleep(seconds uint32) uint32 {
+	time.Sleep(time.Duration(seconds) * time.Second)
+	return 0
+}
+
+// Usleep handles usleep().
+//
+// Suspends execution of the calling thread for at least usec microseconds.
+// Returns 0 on success.
+func Usleep(usec uint32) int {
+	time.Sleep(time.Duration(usec) * time.Microsecond)
+	return 0
+}
+
+// Gettimeofday handles gettimeofday().
+//
+// Gets the time as the number of seconds and microseconds since the Epoch.
+// The obsolete timezone argument tz is ignored. Returns 0 on success.
+func Gettimeofday(tv []Timeval, tz interface{}) int {
+	if len(tv) > 0 {
+		now := time.Now()
+		tv[0] = Timeval{
+			TvSec:  int32(now.Unix()),
+			TvUsec: int32(now.Nanosecond() / int(time.Microsecond)),
+		}
+	}
+	return 0
+}
@@ -0,0 +1,200 @@
+package noarch
+
+import (
+	"io"
+	"os"
+)
+
+// fileDescriptors is the table of opened file descriptors. File descriptors
+// of C program are not real descriptors of operation system - it is only
+// indexes in that table. Duplicated descriptors are shared the same *os.File
+// and so the same offset, as in C.
+var fileDescriptors = map[int]*os.File{
+	0: os.Stdin,
+	1: os.Stdout,
+	2: os.Stderr,
+}
+
+// newFileDescriptor registers the file and returns the lowest free file
+// descriptor.
+func newFileDescriptor(f *os.File) int {
+	fd := 0
+	for {
+		if _, ok := fileDescriptors[fd]; !ok {
+			break
+		}
+		fd++
+	}
+	fileDescriptors[fd] = f
+	return fd
+}
+
+// getFileDescriptor returns the registered file for the file descriptor.
+func getFileDescriptor(fd int) (*os.File, bool) {
+	f, ok := fileDescriptors[fd]
+	return f, ok && f != nil
+}
+
+// getSystemFd returns the descriptor of operation system for the file
+// descriptor of C program.
+func getSystemFd(fd int) (int, bool) {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return -1, false
+	}
+	return int(f.Fd()), true
+}
+
+// releaseFileDescriptor removes the file descriptor from the table. The file
+// is closed only if no other file descriptor shares it.
+func releaseFileDescriptor(fd int) error {
+	f := fileDescriptors[fd]
+	delete(fileDescriptors, fd)
+	for _, other := range fileDescriptors {
+		if other == f {
+			return nil
+		}
+	}
+	return f.Close()
+}
+
+// syncStandardStreams updates the standard streams of stdio after changes of
+// file descriptors 0, 1 and 2. For example, after dup2(fd, 1) the function
+// printf() must write in the file fd.
+func syncStandardStreams() {
+	streams := []*File{Stdin, Stdout, Stderr}
+	for fd := range streams {
+		if f, ok := getFileDescriptor(fd); ok {
+			streams[fd].OsFile = f
+		}
+	}
+}
+
+// Read handles read().
+//
+// Attempts to read up to count bytes from file descriptor fd into the buffer
+// starting at buf. On success, the number of bytes read is returned (zero
+// indicates end of file). On error, -1 is returned.
+func Read(fd int, buf []byte, count int) int {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return -1
+	}
+	if count > len(buf) {
+		count = len(buf)
+	}
+	n, err := f.Read(buf[:count])
+	if err != nil && err != io.EOF {
+		return -1
+	}
+	return n
+}
+
+// Write handles write().
+//
+// Writes up to count bytes from the buffer starting at buf to the file
+// referred to by the file descriptor fd. On success, the number of bytes
+// written is returned. On error, -1 is returned.
+func Write(fd int, buf []byte, count int) int {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return -1
+	}
+	if count > len(buf) {
+		count = len(buf)
+	}
+	n, err := f.Write(buf[:count])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// Close handles close().
+//
+// Closes a file descriptor, so that it no longer refers to any file and may
+// be reused. Returns zero on success. On error, -1 is returned.
+func Close(fd int) int {
+	if _, ok := getFileDescriptor(fd); !ok {
+		return -1
+	}
+	if releaseFileDescriptor(fd) != nil {
+		return -1
+	}
+	return 0
+}
+
+// Lseek handles lseek().
+//
+// Repositions the file offset of the open file description associated with
+// the file descriptor fd to the argument offset according to the directive
+// whence (SEEK_SET, SEEK_CUR or SEEK_END). Upon successful completion,
+// returns the resulting offset location as measured in bytes from the
+// beginning of the file. On error, the value -1 is returned.
+func Lseek(fd int, offset int32, whence int) int32 {
+	f, ok := getFileDescriptor(fd)
+	if !ok {
+		return -1
+	}
+	n, err := f.Seek(int64(offset), whence)
+	if err != nil {
+		return -1
+	}
+	return int32(n)
+}
+
+// Dup handles dup().
+//
+// Creates a copy of the file descriptor oldfd, using the lowest-numbered
+// unused file descriptor for the new descriptor. Both descriptors share file
+// offset. On success, returns the new file descriptor. On error, -1 is
+// returned.
+func Dup(oldfd int) int {
+	f, ok := getFileDescriptor(oldfd)
+	if !ok {
+		return -1
+	}
+	return newFileDescriptor(f)
+}
+
+// Dup2 handles dup2().
+//
+// Works like dup(), but uses the file descriptor number specified in newfd.
+// If the file descriptor newfd was previously open, it is silently closed
+// before being reused. On success, returns the new file descriptor. On
+// error, -1 is returned.
+func Dup2(oldfd, newfd int) int {
+	f, ok := getFileDescriptor(oldfd)
+	if !ok || newfd < 0 {
+		return -1
+	}
+	if oldfd == newfd {
+		return newfd
+	}
+	if _, ok := getFileDescriptor(newfd); ok {
+		_ = releaseFileDescriptor(newfd)
+	}
+	fileDescriptors[newfd] = f
+	syncStandardStreams()
+	return newfd
+}
+
+// Pipe handles pipe().
+//
+// Creates a pipe, a unidirectional data channel that can be used for
+// interprocess communication. The array pipefd is used to return two file
+// descriptors referring to the ends of the pipe. pipefd[0] refers to the
+// read end of the pipe. pipefd[1] refers to the write end of the pipe. On
+// success, zero is returned. On error, -1 is returned.
+func Pipe(pipefd []int) int {
+	if len(pipefd) < 2 {
+		return -1
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return -1
+	}
+	pipefd[0] = newFileDescriptor(r)
+	pipefd[1] = newFileDescriptor(w)
+	return 0
+}
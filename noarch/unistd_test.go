@@ -0,0 +1,120 @@
+package noarch
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileDescriptors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "fd.txt")
+
+	fd := Open([]byte(name+"\x00"), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if fd < 3 {
+		t.Fatalf("Not valid file descriptor: %d", fd)
+	}
+	if n := Write(fd, []byte("hello world"), 11); n != 11 {
+		t.Fatalf("Write: %d", n)
+	}
+	if offset := Lseek(fd, 0, io.SeekEnd); offset != 11 {
+		t.Errorf("Lseek to end: %d", offset)
+	}
+	if offset := Lseek(fd, 0, io.SeekStart); offset != 0 {
+		t.Errorf("Lseek to start: %d", offset)
+	}
+	buf := make([]byte, 5)
+	if n := Read(fd, buf, 5); n != 5 || string(buf) != "hello" {
+		t.Errorf("Read: %d %q", n, buf)
+	}
+
+	// duplicated descriptor shares the offset
+	const newfd = 100
+	if result := Dup2(fd, newfd); result != newfd {
+		t.Fatalf("Dup2: %d", result)
+	}
+	if n := Read(newfd, buf, 5); n != 5 || string(buf) != " worl" {
+		t.Errorf("Read of duplicate: %d %q", n, buf)
+	}
+	if offset := Lseek(fd, 0, io.SeekCurrent); offset != 10 {
+		t.Errorf("Lseek of current: %d", offset)
+	}
+
+	// file is opened, while duplicate is not closed
+	if result := Close(fd); result != 0 {
+		t.Errorf("Close: %d", result)
+	}
+	if offset := Lseek(fd, 0, io.SeekStart); offset != -1 {
+		t.Errorf("Lseek of closed descriptor: %d", offset)
+	}
+	if n := Read(newfd, buf, 5); n != 1 || buf[0] != 'd' {
+		t.Errorf("Read after close of original: %d %q", n, buf[:1])
+	}
+	if result := Close(newfd); result != 0 {
+		t.Errorf("Close of duplicate: %d", result)
+	}
+	if result := Close(newfd); result != -1 {
+		t.Errorf("Close of closed descriptor: %d", result)
+	}
+
+	if fd := Open([]byte(filepath.Join(dir, "absent")+"\x00"), os.O_RDONLY); fd != -1 {
+		t.Errorf("Open of absent file: %d", fd)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	if result := Pipe(make([]int, 1)); result != -1 {
+		t.Errorf("Pipe with short array: %d", result)
+	}
+
+	fds := make([]int, 2)
+	if result := Pipe(fds); result != 0 {
+		t.Fatalf("Pipe: %d", result)
+	}
+	if n := Write(fds[1], []byte("ping"), 4); n != 4 {
+		t.Errorf("Write: %d", n)
+	}
+	buf := make([]byte, 8)
+	if n := Read(fds[0], buf, 8); n != 4 || string(buf[:n]) != "ping" {
+		t.Errorf("Read: %d %q", n, buf)
+	}
+	if result := Close(fds[1]); result != 0 {
+		t.Errorf("Close of write end: %d", result)
+	}
+	// end of file after close of write end
+	if n := Read(fds[0], buf, 8); n != 0 {
+		t.Errorf("Read after close of write end: %d", n)
+	}
+	if result := Close(fds[0]); result != 0 {
+		t.Errorf("Close of read end: %d", result)
+	}
+}
+
+func TestUnknownFileDescriptor(t *testing.T) {
+	const fd = 12345
+	if result := Close(fd); result != -1 {
+		t.Errorf("Close: %d", result)
+	}
+	buf := make([]byte, 1)
+	if n := Read(fd, buf, 1); n != -1 {
+		t.Errorf("Read: %d", n)
+	}
+	if n := Write(fd, buf, 1); n != -1 {
+		t.Errorf("Write: %d", n)
+	}
+	if offset := Lseek(fd, 0, io.SeekStart); offset != -1 {
+		t.Errorf("Lseek: %d", offset)
+	}
+	if result := Dup(fd); result != -1 {
+		t.Errorf("Dup: %d", result)
+	}
+	if result := Dup2(fd, 101); result != -1 {
+		t.Errorf("Dup2: %d", result)
+	}
+}
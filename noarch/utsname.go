@@ -0,0 +1,88 @@
+package noarch
+
+import "os"
+
+// Utsname represents the C structure "struct utsname" from sys/utsname.h:
+//
+//     struct utsname {
+//         char sysname[];
+//         char nodename[];
+//         char release[];
+//         char version[];
+//         char machine[];
+//         char domainname[];
+//     };
+//
+// Field domainname is a GNU extension.
+type Utsname struct {
+	Sysname    []byte
+	Nodename   []byte
+	Release    []byte
+	Version    []byte
+	Machine    []byte
+	Domainname []byte
+}
+
+// Uname handles uname().
+//
+// Stores the name and information about the current kernel in the
+// structure pointed to by buf. On success, zero is returned. On error, -1
+// is returned.
+func Uname(buf []Utsname) int {
+	if len(buf) == 0 {
+		return -1
+	}
+	u, err := uname()
+	if err != nil {
+		return -1
+	}
+	buf[0] = u
+	return 0
+}
+
+// Gethostname handles gethostname().
+//
+// Stores the null-terminated hostname in the array name of length size. If
+// the hostname with the null byte does not fit in the array, then the
+// hostname is truncated and -1 is returned. On success, zero is returned.
+func Gethostname(name []byte, size uint32) int {
+	hostname, err := os.Hostname()
+	if err != nil || name == nil {
+		return -1
+	}
+	n := int(size)
+	if n > len(name) {
+		n = len(name)
+	}
+	if n == 0 {
+		return -1
+	}
+	copied := copy(name[:n-1], hostname)
+	name[copied] = 0
+	if copied < len(hostname) {
+		return -1
+	}
+	return 0
+}
+
+// Sethostname handles sethostname().
+//
+// Sets the hostname to the first length bytes of the array name. The caller
+// must have the privilege to change the hostname. On success, zero is
+// returned. On error, -1 is returned.
+func Sethostname(name []byte, length uint32) int {
+	if int(length) > len(name) {
+		return -1
+	}
+	hostname := name[:length]
+	for i, c := range hostname {
+		if c == 0 {
+			hostname = hostname[:i]
+			break
+		}
+	}
+	if sethostname(hostname) != nil {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package noarch
+
+import "syscall"
+
+// uname returns the information about the kernel from sysctl, as uname()
+// of macOS.
+func uname() (Utsname, error) {
+	var values [5]string
+	for i, name := range []string{
+		"kern.ostype",
+		"kern.hostname",
+		"kern.osrelease",
+		"kern.version",
+		"hw.machine",
+	} {
+		value, err := syscall.Sysctl(name)
+		if err != nil {
+			return Utsname{}, err
+		}
+		values[i] = value
+	}
+	return Utsname{
+		Sysname:    StringToCString(values[0]),
+		Nodename:   StringToCString(values[1]),
+		Release:    StringToCString(values[2]),
+		Version:    StringToCString(values[3]),
+		Machine:    StringToCString(values[4]),
+		Domainname: StringToCString(""),
+	}, nil
+}
+
+// sethostname returns error, because package syscall of macOS cannot change
+// sysctl kern.hostname.
+func sethostname(name []byte) error {
+	return syscall.EPERM
+}
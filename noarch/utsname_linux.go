@@ -0,0 +1,33 @@
+package noarch
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// uname returns the information about the kernel from system call uname.
+func uname() (Utsname, error) {
+	var u syscall.Utsname
+	if err := syscall.Uname(&u); err != nil {
+		return Utsname{}, err
+	}
+	// fields of syscall.Utsname are arrays of int8 or uint8 depending on
+	// architecture, so the arrays are converted by pointer
+	field := func(p unsafe.Pointer) []byte {
+		b := (*[len(u.Sysname)]byte)(p)
+		return StringToCString(CStringToString(b[:]))
+	}
+	return Utsname{
+		Sysname:    field(unsafe.Pointer(&u.Sysname)),
+		Nodename:   field(unsafe.Pointer(&u.Nodename)),
+		Release:    field(unsafe.Pointer(&u.Release)),
+		Version:    field(unsafe.Pointer(&u.Version)),
+		Machine:    field(unsafe.Pointer(&u.Machine)),
+		Domainname: field(unsafe.Pointer(&u.Domainname)),
+	}, nil
+}
+
+// sethostname changes the hostname by system call sethostname.
+func sethostname(name []byte) error {
+	return syscall.Sethostname(name)
+}
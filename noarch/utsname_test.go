@@ -0,0 +1,63 @@
+package noarch
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUname(t *testing.T) {
+	buf := make([]Utsname, 1)
+	if r := Uname(buf); r != 0 {
+		t.Fatalf("uname: expected 0, got %d", r)
+	}
+	u := buf[0]
+	sysname := CStringToString(u.Sysname)
+	if !strings.EqualFold(sysname, runtime.GOOS) {
+		t.Errorf("sysname: expected %q, got %q", runtime.GOOS, sysname)
+	}
+	hostname, _ := os.Hostname()
+	if nodename := CStringToString(u.Nodename); nodename != hostname {
+		t.Errorf("nodename: expected %q, got %q", hostname, nodename)
+	}
+	for _, field := range [][]byte{u.Release, u.Version, u.Machine} {
+		if len(field) < 2 || field[len(field)-1] != 0 {
+			t.Errorf("not valid C string %q", field)
+		}
+	}
+	if r := Uname(nil); r != -1 {
+		t.Errorf("uname with NULL: expected -1, got %d", r)
+	}
+}
+
+func TestGethostname(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		t.Skip("hostname is not available")
+	}
+	tcs := []struct {
+		size     int
+		result   int
+		expected string
+	}{
+		{len(hostname) + 10, 0, hostname},
+		{len(hostname) + 1, 0, hostname},
+		{len(hostname), -1, hostname[:len(hostname)-1]},
+		{1, -1, ""},
+	}
+	for _, tc := range tcs {
+		name := make([]byte, tc.size)
+		if r := Gethostname(name, uint32(tc.size)); r != tc.result {
+			t.Errorf("gethostname(%d): expected %d, got %d",
+				tc.size, tc.result, r)
+		}
+		if actual := CStringToString(name); actual != tc.expected {
+			t.Errorf("gethostname(%d): expected %q, got %q",
+				tc.size, tc.expected, actual)
+		}
+	}
+	if r := Sethostname([]byte("a"), 2); r != -1 {
+		t.Errorf("sethostname with wrong length: expected -1, got %d", r)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// patternConfig is the list of hand-rolled C hash tables and linked lists,
+// which are replaced by Go map and container/list behind the original API.
+// Fields of C struct are replaced by the Go implementation, functions of
+// API are generated in according to roles. Example of JSON file:
+//
+//     {
+//       "maps": [
+//         {
+//           "type": "struct table",
+//           "key": "char *",
+//           "value": "int",
+//           "functions": {"table_new": "new", "table_put": "put",
+//                         "table_get": "get", "table_free": "free"}
+//         }
+//       ],
+//       "lists": [
+//         {
+//           "type": "struct queue",
+//           "value": "struct job *",
+//           "functions": {"queue_push": "push_back", "queue_pop": "pop_front"}
+//         }
+//       ]
+//     }
+//
+type patternConfig struct {
+	// Maps is the list of hash tables.
+	Maps []patternStruct `json:"maps"`
+
+	// Lists is the list of linked lists.
+	Lists []patternStruct `json:"lists"`
+}
+
+type patternStruct struct {
+	// Type is the C struct of data structure.
+	Type string `json:"type"`
+
+	// Key is the C type of keys, only for hash tables.
+	Key string `json:"key"`
+
+	// Value is the C type of values.
+	Value string `json:"value"`
+
+	// Functions is the map of functions of API, where key is name of C
+	// function and value is role of function.
+	Functions map[string]string `json:"functions"`
+}
+
+// loadPatternConfig reads the list of replaced data structures from JSON
+// file.
+func loadPatternConfig(filename string) (
+	patterns map[string]*program.Pattern, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read pattern configuration: %v", err)
+	}
+	var c patternConfig
+	if err = json.Unmarshal(content, &c); err != nil {
+		return nil, fmt.Errorf("Cannot parse pattern configuration: %v", err)
+	}
+	patterns = map[string]*program.Pattern{}
+	functions := map[string]string{}
+	isName := util.GetRegex(`^[a-zA-Z_][a-zA-Z0-9_]*$`).MatchString
+	for _, list := range []struct {
+		kind    program.PatternKind
+		structs []patternStruct
+	}{
+		{program.PatternMap, c.Maps},
+		{program.PatternList, c.Lists},
+	} {
+		for _, s := range list.structs {
+			name := strings.TrimPrefix(strings.TrimSpace(s.Type), "struct ")
+			if !isName(name) {
+				return nil, fmt.Errorf("Type `%s` in pattern configuration "+
+					"is not valid name of struct", s.Type)
+			}
+			if _, ok := patterns[name]; ok {
+				return nil, fmt.Errorf("Struct `%s` is replaced twice "+
+					"in pattern configuration", name)
+			}
+			if list.kind == program.PatternMap && s.Key == "" {
+				return nil, fmt.Errorf("Type of keys of `%s` is not defined "+
+					"in pattern configuration", name)
+			}
+			if list.kind == program.PatternList && s.Key != "" {
+				return nil, fmt.Errorf("Linked list `%s` cannot have keys "+
+					"in pattern configuration", name)
+			}
+			if s.Value == "" {
+				return nil, fmt.Errorf("Type of values of `%s` is not defined "+
+					"in pattern configuration", name)
+			}
+			for f, role := range s.Functions {
+				if !isName(f) {
+					return nil, fmt.Errorf("Name `%s` in pattern configuration "+
+						"is not valid name of function", f)
+				}
+				if !isPatternRole(list.kind, role) {
+					return nil, fmt.Errorf("Role `%s` of function `%s` in "+
+						"pattern configuration is not valid for %s, valid roles: %s",
+						role, f, list.kind,
+						strings.Join(program.PatternRoles[list.kind], ", "))
+				}
+				if other, ok := functions[f]; ok {
+					return nil, fmt.Errorf("Function `%s` is used for `%s` "+
+						"and `%s` in pattern configuration", f, other, name)
+				}
+				functions[f] = name
+			}
+			patterns[name] = &program.Pattern{
+				Kind:      list.kind,
+				Name:      name,
+				Key:       s.Key,
+				Value:     s.Value,
+				Functions: s.Functions,
+			}
+		}
+	}
+	return patterns, nil
+}
+
+// isPatternRole returns true, if the role of function is valid for the kind
+// of data structure.
+func isPatternRole(kind program.PatternKind, role string) bool {
+	for _, r := range program.PatternRoles[kind] {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
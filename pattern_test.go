@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestPatternConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-pattern-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcs := []struct {
+		content  string
+		isError  bool
+		patterns map[string]program.PatternKind
+	}{
+		{
+			`{"maps":[{"type":"struct table","key":"char *","value":"int",
+			"functions":{"table_put":"put","table_get":"get"}}],
+			"lists":[{"type":"queue","value":"struct job *",
+			"functions":{"queue_push":"push_back"}}]}`,
+			false,
+			map[string]program.PatternKind{
+				"table": program.PatternMap,
+				"queue": program.PatternList,
+			},
+		},
+		{`{}`, false, nil},
+		{`{"maps":[{"type":"struct 1table","key":"int","value":"int"}]}`, true, nil},
+		{`{"maps":[{"type":"table","value":"int"}]}`, true, nil},
+		{`{"maps":[{"type":"table","key":"int"}]}`, true, nil},
+		{`{"lists":[{"type":"list","key":"int","value":"int"}]}`, true, nil},
+		{`{"maps":[{"type":"table","key":"int","value":"int",
+			"functions":{"table_put":"push_back"}}]}`, true, nil},
+		{`{"maps":[{"type":"table","key":"int","value":"int",
+			"functions":{"table.put":"put"}}]}`, true, nil},
+		{`{"maps":[{"type":"table","key":"int","value":"int"}],
+			"lists":[{"type":"struct table","value":"int"}]}`, true, nil},
+		{`{"maps":[{"type":"table","key":"int","value":"int",
+			"functions":{"clear":"clear"}}],
+			"lists":[{"type":"list","value":"int",
+			"functions":{"clear":"clear"}}]}`, true, nil},
+		{`not json`, true, nil},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "pattern.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patterns, err := loadPatternConfig(filename)
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if tc.isError {
+			continue
+		}
+		if len(patterns) != len(tc.patterns) {
+			t.Errorf("Case %d: expected %v, got %v", i, tc.patterns, patterns)
+		}
+		for name, kind := range tc.patterns {
+			if pattern, ok := patterns[name]; !ok || pattern.Kind != kind {
+				t.Errorf("Case %d: struct `%s` is not %s", i, name, kind)
+			}
+		}
+	}
+
+	if _, err := loadPatternConfig(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
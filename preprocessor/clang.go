@@ -0,0 +1,176 @@
+package preprocessor
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// Clang is the compiler used for preprocessing of C code and for
+// generating the AST tree.
+type Clang struct {
+	// C is path to clang for C code
+	C string
+
+	// Cpp is path to clang++ for C++ code
+	Cpp string
+
+	// Major and Minor are version of clang
+	Major, Minor int
+}
+
+// Command returns the compiler for C or C++ code.
+func (c Clang) Command(cppCode bool) string {
+	if cppCode {
+		return c.Cpp
+	}
+	return c.C
+}
+
+// Minimal supported version of clang
+const (
+	minClangMajor = 3
+	minClangMinor = 4
+)
+
+// Newest major version of clang, which is searched as "clang-<version>"
+const newestClangMajor = 20
+
+// clangInstallHint is added to all errors of searching clang
+const clangInstallHint = `c4go needs clang for preprocessing and parsing of C code.
+Install clang, for example:
+	Debian, Ubuntu : sudo apt-get install clang
+	Fedora         : sudo dnf install clang
+	macOS          : xcode-select --install
+or download it from http://releases.llvm.org/download.html
+Path to clang can be set by flag: -clang /path/to/clang`
+
+// Functions for working with environment. They are changed in tests for
+// faking different environments.
+var (
+	lookPath  = exec.LookPath
+	runOutput = func(name string, arg ...string) ([]byte, error) {
+		return exec.Command(name, arg...).CombinedOutput()
+	}
+	goos = runtime.GOOS
+)
+
+// FindClang returns clang with supported version. If path is not empty,
+// then only that clang is checked. Otherwise clang is searched in PATH by
+// names "clang", "clang-20", ..., "clang-3.4" and on macOS by "xcrun".
+func FindClang(path string) (c Clang, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot find clang : %v\n\n%s", err, clangInstallHint)
+		}
+	}()
+
+	if path != "" {
+		return checkClang(path)
+	}
+
+	var errs []string
+	for _, name := range clangNames() {
+		full, errLook := lookPath(name)
+		if errLook != nil {
+			continue
+		}
+		c, err = checkClang(full)
+		if err == nil {
+			return
+		}
+		errs = append(errs, err.Error())
+	}
+
+	if goos == "darwin" {
+		out, errRun := runOutput("xcrun", "--find", "clang")
+		if errRun == nil {
+			c, err = checkClang(strings.TrimSpace(string(out)))
+			if err == nil {
+				return
+			}
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) == 0 {
+		err = fmt.Errorf("clang is not found in PATH")
+		return
+	}
+	err = fmt.Errorf("clang with supported version is not found:\n%s",
+		strings.Join(errs, "\n"))
+	return
+}
+
+// clangNames returns names of clang executable in order of searching.
+func clangNames() (names []string) {
+	names = append(names, "clang")
+	for v := newestClangMajor; v >= 7; v-- {
+		names = append(names, fmt.Sprintf("clang-%d", v))
+	}
+	for v := 6; v >= 4; v-- {
+		names = append(names, fmt.Sprintf("clang-%d.0", v))
+	}
+	for v := 9; v >= minClangMinor; v-- {
+		names = append(names, fmt.Sprintf("clang-3.%d", v))
+	}
+	return
+}
+
+// checkClang checks the version of clang.
+func checkClang(path string) (c Clang, err error) {
+	out, err := runOutput(path, "--version")
+	if err != nil {
+		err = fmt.Errorf("cannot run `%s --version` : %v", path, err)
+		return
+	}
+	c.Major, c.Minor, err = parseClangVersion(string(out))
+	if err != nil {
+		err = fmt.Errorf("`%s` : %v", path, err)
+		return
+	}
+	if c.Major < minClangMajor ||
+		(c.Major == minClangMajor && c.Minor < minClangMinor) {
+		err = fmt.Errorf("version %d.%d of `%s` is not supported, "+
+			"minimal version is %d.%d",
+			c.Major, c.Minor, path, minClangMajor, minClangMinor)
+		return
+	}
+	c.C = path
+	c.Cpp = clangCppPath(path)
+	return
+}
+
+// parseClangVersion returns version of clang from output of
+// "clang --version".
+// Examples:
+//	clang version 6.0.0-1ubuntu2 (tags/RELEASE_600/final)
+//	Ubuntu clang version 14.0.0-1ubuntu1
+//	Apple LLVM version 10.0.0 (clang-1000.11.45.5)
+func parseClangVersion(out string) (major, minor int, err error) {
+	match := util.GetRegex(`(?:clang|LLVM) version (\d+)\.(\d+)`).
+		FindStringSubmatch(out)
+	if len(match) != 3 {
+		line := strings.TrimSpace(out)
+		if index := strings.Index(line, "\n"); index >= 0 {
+			line = line[:index]
+		}
+		err = fmt.Errorf("cannot find version of clang in `%s`", line)
+		return
+	}
+	return util.Atoi(match[1]), util.Atoi(match[2]), nil
+}
+
+// clangCppPath returns path to clang++ near clang.
+// Example: /usr/bin/clang-15 -> /usr/bin/clang++-15
+func clangCppPath(path string) string {
+	dir, base := filepath.Split(path)
+	if strings.HasPrefix(base, "clang") && !strings.HasPrefix(base, "clang++") {
+		return dir + "clang++" + base[len("clang"):]
+	}
+	return path
+}
@@ -0,0 +1,172 @@
+package preprocessor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeEnvironment replaces functions of environment by fake functions.
+// Keys of map are paths of clang, values are outputs of "clang --version".
+func fakeEnvironment(os string, clangs map[string]string) (restore func()) {
+	oldLookPath, oldRunOutput, oldGoos := lookPath, runOutput, goos
+	lookPath = func(name string) (string, error) {
+		if _, ok := clangs["/usr/bin/"+name]; ok {
+			return "/usr/bin/" + name, nil
+		}
+		return "", fmt.Errorf("executable file not found in $PATH")
+	}
+	runOutput = func(name string, arg ...string) ([]byte, error) {
+		if name == "xcrun" {
+			if _, ok := clangs["/Library/Developer/usr/bin/clang"]; ok {
+				return []byte("/Library/Developer/usr/bin/clang\n"), nil
+			}
+			return nil, fmt.Errorf("xcrun: error")
+		}
+		if out, ok := clangs[name]; ok {
+			return []byte(out), nil
+		}
+		return nil, fmt.Errorf("no such file or directory")
+	}
+	goos = os
+	return func() {
+		lookPath, runOutput, goos = oldLookPath, oldRunOutput, oldGoos
+	}
+}
+
+func TestFindClang(t *testing.T) {
+	testCases := []struct {
+		name   string
+		os     string
+		clangs map[string]string
+		path   string
+		out    Clang
+		err    string
+	}{
+		{
+			name: "clang",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang":    "clang version 6.0.0-1ubuntu2 (tags/RELEASE_600/final)",
+				"/usr/bin/clang-15": "Ubuntu clang version 15.0.7",
+			},
+			out: Clang{C: "/usr/bin/clang", Cpp: "/usr/bin/clang++", Major: 6},
+		},
+		{
+			name: "versioned clang",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang-3.8": "clang version 3.8.0-2ubuntu4 (tags/RELEASE_380/final)",
+				"/usr/bin/clang-15":  "Ubuntu clang version 15.0.7",
+			},
+			out: Clang{C: "/usr/bin/clang-15", Cpp: "/usr/bin/clang++-15", Major: 15},
+		},
+		{
+			name: "old clang is skipped",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang":     "clang version 3.3 (tags/RELEASE_33/final)",
+				"/usr/bin/clang-3.9": "clang version 3.9.1-4ubuntu3~16.04.2 (tags/RELEASE_391/rc2)",
+			},
+			out: Clang{C: "/usr/bin/clang-3.9", Cpp: "/usr/bin/clang++-3.9", Major: 3, Minor: 9},
+		},
+		{
+			name: "xcrun",
+			os:   "darwin",
+			clangs: map[string]string{
+				"/Library/Developer/usr/bin/clang": "Apple LLVM version 10.0.0 (clang-1000.11.45.5)",
+			},
+			out: Clang{
+				C:     "/Library/Developer/usr/bin/clang",
+				Cpp:   "/Library/Developer/usr/bin/clang++",
+				Major: 10,
+			},
+		},
+		{
+			name: "xcrun only on macOS",
+			os:   "linux",
+			clangs: map[string]string{
+				"/Library/Developer/usr/bin/clang": "Apple LLVM version 10.0.0 (clang-1000.11.45.5)",
+			},
+			err: "clang is not found in PATH",
+		},
+		{
+			name:   "clang is not installed",
+			os:     "linux",
+			clangs: map[string]string{},
+			err:    "clang is not found in PATH",
+		},
+		{
+			name: "only old clang",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang": "clang version 3.3 (tags/RELEASE_33/final)",
+			},
+			err: "version 3.3 of `/usr/bin/clang` is not supported, minimal version is 3.4",
+		},
+		{
+			name: "not clang",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang": "gcc (Ubuntu 7.5.0-3ubuntu1~18.04) 7.5.0",
+			},
+			err: "cannot find version of clang",
+		},
+		{
+			name: "path",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang":          "clang version 6.0.0-1ubuntu2 (tags/RELEASE_600/final)",
+				"/opt/llvm/bin/clang-7.0": "clang version 7.0.1 (tags/RELEASE_701/final)",
+			},
+			path: "/opt/llvm/bin/clang-7.0",
+			out:  Clang{C: "/opt/llvm/bin/clang-7.0", Cpp: "/opt/llvm/bin/clang++-7.0", Major: 7},
+		},
+		{
+			name: "wrong path",
+			os:   "linux",
+			clangs: map[string]string{
+				"/usr/bin/clang": "clang version 6.0.0-1ubuntu2 (tags/RELEASE_600/final)",
+			},
+			path: "/opt/llvm/bin/clang",
+			err:  "cannot run `/opt/llvm/bin/clang --version`",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restore := fakeEnvironment(tc.os, tc.clangs)
+			defer restore()
+
+			c, err := FindClang(tc.path)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("Haven`t error, result is %#v", c)
+				}
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("Error `%v` is not contain `%s`", err, tc.err)
+				}
+				if !strings.Contains(err.Error(), clangInstallHint) {
+					t.Fatalf("Error `%v` is not contain install hint", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if c != tc.out {
+				t.Fatalf("Not same:\n%#v\n%#v", c, tc.out)
+			}
+		})
+	}
+}
+
+func TestClangCommand(t *testing.T) {
+	c := Clang{C: "/usr/bin/clang-15", Cpp: "/usr/bin/clang++-15"}
+	if c.Command(false) != c.C {
+		t.Errorf("Wrong command for C code: %s", c.Command(false))
+	}
+	if c.Command(true) != c.Cpp {
+		t.Errorf("Wrong command for C++ code: %s", c.Command(true))
+	}
+}
@@ -0,0 +1,107 @@
+package preprocessor
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// Embed - position of directive `#embed` of C23 in the user source. Clang
+// expands the directive to the list of bytes of the file, which is found by
+// c4go in the same way as by clang: the directory of source (only for
+// `#embed "file"`) and directories of option "--embed-dir".
+type Embed struct {
+	File     string
+	Line     int
+	Resource string
+}
+
+// findEmbeds returns directives `#embed` of files. Directives with not
+// found files are ignored, because clang fails for them.
+func findEmbeds(files, clangFlags []string) (embeds []Embed, err error) {
+	var dirs []string
+	for i, flag := range clangFlags {
+		switch {
+		case strings.HasPrefix(flag, "--embed-dir="):
+			dirs = append(dirs, strings.TrimPrefix(flag, "--embed-dir="))
+		case flag == "--embed-dir" && i+1 < len(clangFlags):
+			dirs = append(dirs, clangFlags[i+1])
+		}
+	}
+
+	reg := util.GetRegex(`^\s*#\s*embed\s+(?:"([^"]+)"|<([^>]+)>)`)
+	for _, file := range files {
+		var f *os.File
+		f, err = os.Open(file)
+		if err != nil {
+			return
+		}
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			text := scanner.Text()
+			if !strings.Contains(text, "embed") {
+				continue
+			}
+			match := reg.FindStringSubmatch(text)
+			if match == nil {
+				continue
+			}
+			search := dirs
+			name := match[2]
+			if match[1] != "" {
+				name = match[1]
+				search = append([]string{filepath.Dir(file)}, dirs...)
+			}
+			if resource, ok := findEmbedResource(name, search); ok {
+				embeds = append(embeds, Embed{
+					File:     file,
+					Line:     line,
+					Resource: resource,
+				})
+			}
+		}
+		err = scanner.Err()
+		_ = f.Close()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// findEmbedResource returns absolute path of embedded file.
+func findEmbedResource(name string, dirs []string) (string, bool) {
+	if filepath.IsAbs(name) {
+		dirs = []string{""}
+	}
+	for _, dir := range dirs {
+		path, err := filepath.Abs(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// GetEmbed returns the directive `#embed` at the line of file.
+func (f FilePP) GetEmbed(file string, line int) (e Embed, ok bool) {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return
+	}
+	for _, e = range f.embeds {
+		if e.Line != line {
+			continue
+		}
+		if path, err := filepath.Abs(e.File); err == nil && path == file {
+			return e, true
+		}
+	}
+	return Embed{}, false
+}
@@ -0,0 +1,67 @@
+package preprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindEmbeds(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-embed-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	resources := filepath.Join(dir, "resources")
+	if err = os.Mkdir(resources, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"logo.bin":           "\x89PNG",
+		"resources/font.bin": "font",
+		"main.c": `const unsigned char logo[] = {
+#embed "logo.bin"
+};
+const char font[] = {
+  #  embed <font.bin> limit(2)
+};
+// #embed "logo.bin" in comment
+#embed "not_exist.bin"
+`,
+	}
+	for name, content := range files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	source := filepath.Join(dir, "main.c")
+	embeds, err := findEmbeds([]string{source},
+		[]string{"-I", dir, "--embed-dir=" + resources})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Embed{
+		{File: source, Line: 2, Resource: filepath.Join(dir, "logo.bin")},
+		{File: source, Line: 5, Resource: filepath.Join(resources, "font.bin")},
+	}
+	if !reflect.DeepEqual(embeds, expected) {
+		t.Fatalf("Not expected embeds:\n%v\n%v", embeds, expected)
+	}
+
+	f := FilePP{embeds: embeds}
+	if e, ok := f.GetEmbed(source, 5); !ok || e != expected[1] {
+		t.Errorf("Embed is not found: %v %v", e, ok)
+	}
+	if e, ok := f.GetEmbed(source, 3); ok {
+		t.Errorf("Not expected embed: %v", e)
+	}
+
+	if _, err = findEmbeds([]string{filepath.Join(dir, "not_exist.c")}, nil); err == nil {
+		t.Errorf("Error is expected for not exist file")
+	}
+}
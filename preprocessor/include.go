@@ -0,0 +1,62 @@
+package preprocessor
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// IncludeOptions are options of search of header files. The options are the
+// same as options of cc and are passed to clang in order of command line:
+//
+//     -iquote dir     directory for `#include "file"` only
+//     -I dir          directory for `#include "file"` and `#include <file>`
+//     -isystem dir    directory of system headers
+//     -idirafter dir  directory searched after directories of system headers
+//     -include file   file is included before the first line of sources
+//
+// Directories are searched in the same order as by cc: directory of source
+// file (only for `#include "file"`), -iquote, -I, -isystem, directories of
+// system headers and -idirafter.
+type IncludeOptions struct {
+	QuoteDirs     []string
+	Dirs          []string
+	SystemDirs    []string
+	AfterDirs     []string
+	ForceIncludes []string
+}
+
+// Flags returns flags of clang for the options. Relative paths of
+// directories are converted to absolute, because names of headers must be
+// the same in the preprocessed code and in the list of includes. A forced
+// include is searched in the current directory at first, as cc does, and
+// after that in the directories of headers.
+func (o IncludeOptions) Flags() (flags []string, err error) {
+	dirs := []struct {
+		flag  string
+		paths []string
+	}{
+		{"-iquote", o.QuoteDirs},
+		{"-I", o.Dirs},
+		{"-isystem", o.SystemDirs},
+		{"-idirafter", o.AfterDirs},
+	}
+	for _, d := range dirs {
+		for _, path := range d.paths {
+			path, err = filepath.Abs(path)
+			if err != nil {
+				return nil, err
+			}
+			flags = append(flags, d.flag, path)
+		}
+	}
+	for _, file := range o.ForceIncludes {
+		if _, e := os.Stat(file); e == nil {
+			file, err = filepath.Abs(file)
+			if err != nil {
+				return nil, err
+			}
+		}
+		flags = append(flags, "-include", file)
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package preprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIncludeOptionsFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-include-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := filepath.Join(dir, "config.h")
+	if err = ioutil.WriteFile(config, []byte("#define VERSION 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := IncludeOptions{
+		QuoteDirs:     []string{"quote"},
+		Dirs:          []string{"build", dir},
+		SystemDirs:    []string{"/opt/include"},
+		AfterDirs:     []string{"after"},
+		ForceIncludes: []string{config, "not_exist_config.h"},
+	}
+	flags, err := o.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"-iquote", filepath.Join(wd, "quote"),
+		"-I", filepath.Join(wd, "build"),
+		"-I", dir,
+		"-isystem", "/opt/include",
+		"-idirafter", filepath.Join(wd, "after"),
+		"-include", config,
+		"-include", "not_exist_config.h",
+	}
+	if !reflect.DeepEqual(flags, expected) {
+		t.Errorf("Not expected flags:\n%v\n%v", flags, expected)
+	}
+
+	flags, err = IncludeOptions{}.Flags()
+	if err != nil || len(flags) != 0 {
+		t.Errorf("Not expected flags of empty options: %v %v", flags, err)
+	}
+}
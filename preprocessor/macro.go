@@ -0,0 +1,256 @@
+package preprocessor
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// Macro - object-like macro of the user source, like:
+//
+//     #define MAX_USERS 64
+//
+// Macros are expanded by the preprocessor, so the names of macros are not
+// in the clang AST.
+type Macro struct {
+	File  string
+	Line  int
+	Name  string
+	Value string
+}
+
+// findMacros returns object-like macros with not empty values. Macros, which
+// are undefined by `#undef` or are defined several times with different
+// values, have not one value for all code and they are ignored.
+func findMacros(files []string) (macros []Macro, err error) {
+	define := util.GetRegex(`^\s*#\s*define\s+([a-zA-Z_]\w*)(\s+(.*))?$`)
+	undef := util.GetRegex(`^\s*#\s*undef\s+([a-zA-Z_]\w*)`)
+	ignored := map[string]bool{}
+	index := map[string]int{}
+	err = scanLines(files, func(file string, line int, text string) {
+		if !strings.Contains(text, "#") {
+			return
+		}
+		if match := undef.FindStringSubmatch(text); match != nil {
+			ignored[match[1]] = true
+			return
+		}
+		match := define.FindStringSubmatch(text)
+		if match == nil {
+			return
+		}
+		name, value := match[1], strings.TrimSpace(removeComments(match[3]))
+		if value == "" {
+			return
+		}
+		if i, ok := index[name]; ok {
+			if macros[i].Value != value {
+				ignored[name] = true
+			}
+			return
+		}
+		index[name] = len(macros)
+		macros = append(macros, Macro{
+			File:  file,
+			Line:  line,
+			Name:  name,
+			Value: value,
+		})
+	})
+	if err != nil {
+		return
+	}
+
+	result := macros[:0]
+	for _, m := range macros {
+		if !ignored[m.Name] {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// FunctionMacro - function-like macro of the user source, like:
+//
+//     #define SQR(x) ((x)*(x))
+//
+// Value of macro is the body of macro.
+type FunctionMacro struct {
+	Macro
+	Params []string
+
+	// uses - lines of the user source with calls of macro, where key is
+	// the name of file
+	uses map[string]map[int]bool
+}
+
+// IsUsed returns true, if macro is called in the line of file.
+func (m FunctionMacro) IsUsed(file string, line int) bool {
+	return m.uses[file][line]
+}
+
+// findFunctionMacros returns function-like macros with parameters and not
+// empty body. Variadic macros, macros with operators `#` and `##`, and
+// macros, which have not one body for all code, are ignored.
+func findFunctionMacros(files []string) (macros []FunctionMacro, err error) {
+	define := util.GetRegex(`^\s*#\s*define\s+([a-zA-Z_]\w*)\(([^)]*)\)(.*)$`)
+	undef := util.GetRegex(`^\s*#\s*undef\s+([a-zA-Z_]\w*)`)
+	param := util.GetRegex(`^[a-zA-Z_]\w*$`)
+	ignored := map[string]bool{}
+	index := map[string]int{}
+	err = scanLines(files, func(file string, line int, text string) {
+		if !strings.Contains(text, "#") {
+			return
+		}
+		if match := undef.FindStringSubmatch(text); match != nil {
+			ignored[match[1]] = true
+			return
+		}
+		match := define.FindStringSubmatch(text)
+		if match == nil {
+			return
+		}
+		name, value := match[1], strings.TrimSpace(removeComments(match[3]))
+		params := strings.Split(match[2], ",")
+		for i := range params {
+			params[i] = strings.TrimSpace(params[i])
+			if !param.MatchString(params[i]) {
+				ignored[name] = true
+			}
+		}
+		if value == "" || strings.Contains(value, "#") {
+			ignored[name] = true
+		}
+		if i, ok := index[name]; ok {
+			if macros[i].Value != value ||
+				strings.Join(macros[i].Params, ",") != strings.Join(params, ",") {
+				ignored[name] = true
+			}
+			return
+		}
+		index[name] = len(macros)
+		macros = append(macros, FunctionMacro{
+			Macro: Macro{
+				File:  file,
+				Line:  line,
+				Name:  name,
+				Value: value,
+			},
+			Params: params,
+			uses:   map[string]map[int]bool{},
+		})
+	})
+	if err != nil {
+		return
+	}
+
+	result := macros[:0]
+	for _, m := range macros {
+		if !ignored[m.Name] {
+			result = append(result, m)
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	// lines with calls of macros
+	call := util.GetRegex(`\b([a-zA-Z_]\w*)\s*\(`)
+	index = map[string]int{}
+	for i := range result {
+		index[result[i].Name] = i
+	}
+	err = scanLines(files, func(file string, line int, text string) {
+		if define.MatchString(text) {
+			return
+		}
+		for _, match := range call.FindAllStringSubmatch(text, -1) {
+			i, ok := index[match[1]]
+			if !ok {
+				continue
+			}
+			if result[i].uses[file] == nil {
+				result[i].uses[file] = map[int]bool{}
+			}
+			result[i].uses[file][line] = true
+		}
+	})
+	return result, err
+}
+
+// scanLines calls function for each line of files. Continued lines are
+// joined and the number of line is the number of first line.
+func scanLines(files []string, f func(file string, line int, text string)) (
+	err error) {
+	for _, file := range files {
+		var fd *os.File
+		fd, err = os.Open(file)
+		if err != nil {
+			return
+		}
+		scanner := bufio.NewScanner(fd)
+		for line := 0; scanner.Scan(); {
+			line++
+			text := scanner.Text()
+			first := line
+			// continuation of line
+			for strings.HasSuffix(text, "\\") && scanner.Scan() {
+				line++
+				text = text[:len(text)-1] + " " + scanner.Text()
+			}
+			f(file, first, text)
+		}
+		err = scanner.Err()
+		_ = fd.Close()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// removeComments removes comments from the value of macro. Literals are
+// not changed.
+func removeComments(value string) string {
+	var buf strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"' || c == '\'':
+			start := i
+			for i++; i < len(value) && value[i] != c; i++ {
+				if value[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(value) {
+				i = len(value) - 1
+			}
+			buf.WriteString(value[start : i+1])
+		case strings.HasPrefix(value[i:], "//"):
+			return buf.String()
+		case strings.HasPrefix(value[i:], "/*"):
+			end := strings.Index(value[i+2:], "*/")
+			if end < 0 {
+				return buf.String()
+			}
+			buf.WriteByte(' ')
+			i += 2 + end + 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+// GetMacros returns object-like macros of the user source.
+func (f FilePP) GetMacros() []Macro {
+	return f.macros
+}
+
+// GetFunctionMacros returns function-like macros of the user source.
+func (f FilePP) GetFunctionMacros() []FunctionMacro {
+	return f.functionMacros
+}
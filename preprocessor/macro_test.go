@@ -0,0 +1,114 @@
+package preprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindMacros(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-macro-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "main.c")
+	err = ioutil.WriteFile(file, []byte(`#ifndef MAIN_H
+#define MAIN_H
+#define MAX_USERS 64 // maximal amount
+#  define VERSION "1.2 /* not comment */"
+#define SQR(x) ((x)*(x))
+#define LIMIT \
+	(MAX_USERS * 2)
+#define TEMP 1
+#undef TEMP
+#ifdef _WIN32
+#define SEP '\\'
+#else
+#define SEP '/'
+#endif
+#define MAX_USERS 64
+#endif
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	macros, err := findMacros([]string{file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Macro{
+		{File: file, Line: 3, Name: "MAX_USERS", Value: "64"},
+		{File: file, Line: 4, Name: "VERSION", Value: `"1.2 /* not comment */"`},
+		{File: file, Line: 6, Name: "LIMIT", Value: "(MAX_USERS * 2)"},
+	}
+	if !reflect.DeepEqual(macros, expected) {
+		t.Errorf("Not expected macros:\n%#v\nExpected:\n%#v", macros, expected)
+	}
+}
+
+func TestFindFunctionMacros(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-macro-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "main.c")
+	err = ioutil.WriteFile(file, []byte(`#define MAX_USERS 64
+#define SQR(x) ((x)*(x)) /* square */
+#define MUL(a, b) \
+	((a) * (b))
+#define STR(x) #x
+#define LOG(...) printf(__VA_ARGS__)
+#define TEMP(x) (x)
+#undef TEMP
+int f(int a) {
+	return SQR(a) + MUL(a,
+		2) + SQR (3);
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	macros, err := findFunctionMacros([]string{file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(macros) != 2 {
+		t.Fatalf("Not expected macros: %#v", macros)
+	}
+	expected := []Macro{
+		{File: file, Line: 2, Name: "SQR", Value: "((x)*(x))"},
+		{File: file, Line: 3, Name: "MUL", Value: "((a) * (b))"},
+	}
+	params := [][]string{{"x"}, {"a", "b"}}
+	for i := range macros {
+		if !reflect.DeepEqual(macros[i].Macro, expected[i]) ||
+			!reflect.DeepEqual(macros[i].Params, params[i]) {
+			t.Errorf("Not expected macro:\n%#v\nExpected:\n%#v %v",
+				macros[i], expected[i], params[i])
+		}
+	}
+	for _, use := range []struct {
+		macro  int
+		line   int
+		isUsed bool
+	}{
+		{0, 2, false},
+		{0, 10, true},
+		{0, 11, true},
+		{1, 10, true},
+		{1, 11, false},
+	} {
+		if macros[use.macro].IsUsed(file, use.line) != use.isUsed {
+			t.Errorf("Use of macro `%s` in line %d is not %v",
+				macros[use.macro].Name, use.line, use.isUsed)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package preprocessor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// nestedFunction - positions of nested function of GNU C in tokens.
+type nestedFunction struct {
+	start int // first token of return type
+	open  int // opening parenthesis of parameters
+	body  int // opening brace of body
+	end   int // closing brace of body
+}
+
+// rewriteNestedFunctions rewrites the nested functions of GNU C in input
+// files into the blocks of clang, because clang does not support nested
+// functions. The nested function:
+//
+//     int add(int x) {
+//         return x + a;
+//     }
+//
+// is rewritten to the pointer of function, which is initialized by block:
+//
+//     int (*add)(int x) = (int (*)(int x))(void *)^int (int x) {
+//         return x + a;
+//     };
+//
+// Forward declarations of nested functions, like `auto int add(int);`, are
+// removed. Amount of lines is not changed. Returns true, if any nested
+// function is found.
+func rewriteNestedFunctions(entities []entity, inputFiles []string) (
+	found bool, err error) {
+	files, err := inputEntities(entities, inputFiles)
+	if err != nil {
+		return
+	}
+	for _, indexes := range files {
+		tokens := tokenize(entities, indexes)
+		nested, declarations := nestedFunctions(tokens)
+		if len(nested) == 0 {
+			continue
+		}
+		found = true
+
+		type edit struct {
+			from, to int
+			text     string
+		}
+		var edits []edit
+		for _, n := range nested {
+			var ret []string
+			for _, t := range tokens[n.start : n.open-1] {
+				switch t.text {
+				case "auto", "static", "extern", "register", "inline",
+					"__inline", "__inline__":
+					continue
+				}
+				ret = append(ret, t.text)
+			}
+			returnType := strings.Join(ret, " ")
+			params := rawText(entities, tokens[n.open], tokens[n.body-1])
+			edits = append(edits, edit{
+				from: n.start,
+				to:   n.body,
+				text: fmt.Sprintf("%s (*%s)%s = (%s (*)%s)(void *)^%s %s {",
+					returnType, tokens[n.open-1].text, params,
+					returnType, params, returnType, params),
+			}, edit{from: n.end, to: n.end, text: "};"})
+		}
+		for _, d := range declarations {
+			edits = append(edits, edit{from: d[0], to: d[1]})
+		}
+		// texts are replaced from the end, so the positions of other
+		// tokens are not changed
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].from > edits[j].from
+		})
+		for _, e := range edits {
+			replaceText(entities, tokens[e.from], tokens[e.to], e.text)
+		}
+	}
+	return
+}
+
+// nestedFunctions returns the nested functions and the ranges of tokens of
+// forward declarations of nested functions.
+func nestedFunctions(tokens []token) (
+	nested []nestedFunction, declarations [][2]int) {
+	// functions - stack of braces, true for body of function
+	var functions []bool
+	var depth int
+	for i, t := range tokens {
+		switch t.text {
+		case "{":
+			isFunction := false
+			if i > 0 && tokens[i-1].text == ")" {
+				if len(functions) == 0 {
+					isFunction = true
+				} else if depth > 0 {
+					if start, open, ok := nestedHeader(tokens, i); ok {
+						isFunction = true
+						nested = append(nested, nestedFunction{
+							start: start, open: open, body: i, end: -1})
+					}
+				}
+			}
+			functions = append(functions, isFunction)
+			if isFunction {
+				depth++
+			}
+		case "}":
+			if len(functions) == 0 {
+				continue
+			}
+			if functions[len(functions)-1] {
+				depth--
+				for k := len(nested) - 1; k >= 0; k-- {
+					if nested[k].end < 0 {
+						nested[k].end = i
+						break
+					}
+				}
+			}
+			functions = functions[:len(functions)-1]
+		case "auto":
+			if depth == 0 || i == 0 || !isStatementEnd(tokens[i-1].text) {
+				continue
+			}
+			if end, ok := nestedDeclaration(tokens, i); ok {
+				declarations = append(declarations, [2]int{i, end})
+			}
+		}
+	}
+	// function without closing brace is not rewritten
+	for k := len(nested) - 1; k >= 0; k-- {
+		if nested[k].end < 0 {
+			nested = append(nested[:k], nested[k+1:]...)
+		}
+	}
+	return
+}
+
+// isStatementEnd returns true for tokens before the first token of statement.
+func isStatementEnd(s string) bool {
+	return s == ";" || s == "{" || s == "}"
+}
+
+// nestedHeader returns the index of first token of return type and the index
+// of opening parenthesis of parameters for the body of nested function
+// at index i. Only return types of identifiers, keywords and pointers are
+// acceptable, so statements like `if (a) {` are not nested functions.
+func nestedHeader(tokens []token, i int) (start, open int, ok bool) {
+	var paren int
+	for open = i - 1; open >= 0; open-- {
+		if tokens[open].text == ")" {
+			paren++
+		} else if tokens[open].text == "(" {
+			paren--
+			if paren == 0 {
+				break
+			}
+		}
+	}
+	if open < 2 {
+		return
+	}
+	name := tokens[open-1]
+	if !name.isIdent() || cKeywords[name.text] {
+		return
+	}
+	for start = open - 1; start > 0; start-- {
+		prev := tokens[start-1]
+		if isStatementEnd(prev.text) {
+			break
+		}
+		if prev.text != "*" && !prev.isIdent() {
+			return
+		}
+		switch prev.text {
+		case "return", "else", "do", "goto", "case", "default", "typedef":
+			return
+		}
+	}
+	return start, open, start < open-1 &&
+		tokens[start].entity == tokens[i].entity
+}
+
+// nestedDeclaration returns the index of semicolon of forward declaration
+// of nested function `auto int add(int);` at index i.
+func nestedDeclaration(tokens []token, i int) (end int, ok bool) {
+	for end = i + 1; end < len(tokens); end++ {
+		t := tokens[end]
+		if t.text == "(" {
+			break
+		}
+		if t.text != "*" && !t.isIdent() {
+			return
+		}
+	}
+	if end+1 >= len(tokens) || !tokens[end-1].isIdent() ||
+		cKeywords[tokens[end-1].text] {
+		return
+	}
+	end = skipGroup(tokens, end) + 1
+	return end, end < len(tokens) && tokens[end].text == ";"
+}
+
+// rawText returns the source code from the token `from` to the token `to`
+// in one line.
+func rawText(entities []entity, from, to token) string {
+	lines := entities[from.entity].lines
+	if from.line == to.line {
+		return (*lines[from.line])[from.col : to.col+len(to.text)]
+	}
+	var parts []string
+	for l := from.line; l <= to.line; l++ {
+		s := *lines[l]
+		if l == to.line {
+			s = s[:to.col+len(to.text)]
+		}
+		if l == from.line {
+			s = s[from.col:]
+		}
+		if index := strings.Index(s, "//"); index >= 0 {
+			s = s[:index]
+		}
+		parts = append(parts, strings.TrimSpace(s))
+	}
+	return strings.Join(parts, " ")
+}
+
+// replaceText replaces the source code from the token `from` to the token
+// `to` by the text. Other lines of source code are cleared, so the positions
+// of next tokens are not changed.
+func replaceText(entities []entity, from, to token, text string) {
+	lines := entities[from.entity].lines
+	end := to.col + len(to.text)
+	if from.line == to.line {
+		s := *lines[from.line]
+		s = s[:from.col] + text + s[end:]
+		lines[from.line] = &s
+		return
+	}
+	for l := from.line; l <= to.line; l++ {
+		s := *lines[l]
+		switch l {
+		case from.line:
+			s = s[:from.col] + text
+		case to.line:
+			s = strings.Repeat(" ", end) + s[end:]
+		default:
+			s = ""
+		}
+		lines[l] = &s
+	}
+}
@@ -0,0 +1,132 @@
+package preprocessor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRewriteNestedFunctions(t *testing.T) {
+	code := `int sum(int *a, int n) {
+	int s = 0;
+	auto void add(int);
+	void add(int x) { s += x; }
+	static char *name(int i,
+		double d) // comment
+	{
+		if (i) {
+			return "x";
+		}
+		return "y";
+	}
+	for (int i = 0; i < n; i++) {
+		add(a[i]);
+	}
+	return s;
+}`
+	line := `# 1 "/src/sum.c"`
+	e := entity{include: "/src/sum.c", positionInSource: 1, lines: []*string{&line}}
+	for _, l := range strings.Split(code, "\n") {
+		l := l
+		e.lines = append(e.lines, &l)
+	}
+	entities := []entity{e}
+	found, err := rewriteNestedFunctions(entities, []string{"/src/sum.c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatalf("Nested functions are not found")
+	}
+
+	expected := []string{
+		`int sum(int *a, int n) {`,
+		`	int s = 0;`,
+		`	`,
+		`	void (*add)(int x) = (void (*)(int x))(void *)^void (int x) { s += x; };`,
+		`	char * (*name)(int i, double d) = (char * (*)(int i, double d))(void *)^char * (int i, double d) {`,
+		``,
+		`  `,
+		`		if (i) {`,
+		`			return "x";`,
+		`		}`,
+		`		return "y";`,
+		`	};`,
+		`	for (int i = 0; i < n; i++) {`,
+		`		add(a[i]);`,
+		`	}`,
+		`	return s;`,
+		`}`,
+	}
+	var lines []string
+	for _, l := range entities[0].lines[1:] {
+		lines = append(lines, *l)
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Not expected code:\n%s\nExpected:\n%s",
+			strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestRewriteNestedFunctionsWithout(t *testing.T) {
+	code := `struct point { int x; };
+int f(int a) {
+	auto int b = 1;
+	auto int (*g)(int);
+	struct point p = (struct point){1};
+	while (a) { a--; }
+	return a + b + p.x;
+}`
+	e := entity{include: "/src/f.c", positionInSource: 1}
+	for _, l := range append([]string{`# 1 "/src/f.c"`}, strings.Split(code, "\n")...) {
+		l := l
+		e.lines = append(e.lines, &l)
+	}
+	found, err := rewriteNestedFunctions([]entity{e}, []string{"/src/f.c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Errorf("Nested functions are found")
+	}
+	var lines []string
+	for _, l := range e.lines[1:] {
+		lines = append(lines, *l)
+	}
+	if actual := strings.Join(lines, "\n"); actual != code {
+		t.Errorf("Code is changed:\n%s", actual)
+	}
+}
+
+func TestAddBlockStorage(t *testing.T) {
+	var f FilePP
+	for _, e := range []struct {
+		include  string
+		position int
+		code     string
+	}{
+		{"/src/a.h", 1, "int g;"},
+		{"/src/a.c", 3, "int f() {\n\tint a, b; int c;\n\treturn a;\n}"},
+	} {
+		line := `# 1 "` + e.include + `"`
+		en := entity{include: e.include, positionInSource: e.position,
+			lines: []*string{&line}}
+		for _, l := range strings.Split(e.code, "\n") {
+			l := l
+			en.lines = append(en.lines, &l)
+		}
+		f.entities = append(f.entities, en)
+	}
+	f.AddBlockStorage([]BlockStorage{
+		{File: "/src/a.c", Line: 4, Column: 2},
+		{File: "/src/a.c", Line: 4, Column: 12},
+		{File: "/src/a.c", Line: 4, Column: 2},
+		{File: "/src/a.h", Line: 4, Column: 2},
+	})
+	expected := "# 1 \"/src/a.h\"\nint g;\n" +
+		"# 3 \"/src/a.c\"\nint f() {\n" +
+		"\t__block int a, b; __block int c;\n\treturn a;\n}"
+	if actual := string(f.GetSource()); actual != expected {
+		t.Errorf("Not expected code:\n%s\nExpected:\n%s", actual, expected)
+	}
+}
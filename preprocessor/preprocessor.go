@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/scanner"
 	"unicode"
@@ -96,11 +97,19 @@ type FilePP struct {
 	pp       []byte
 	comments []Comment
 	includes []IncludeHeader
+	embeds   []Embed
+	macros   []Macro
+
+	functionMacros []FunctionMacro
+
+	// nestedFunctions is true, if nested functions of GNU C are rewritten
+	// into blocks of clang
+	nestedFunctions bool
 }
 
 // NewFilePP create a struct FilePP with results of analyzing
 // preprocessor C code
-func NewFilePP(inputFiles, clangFlags []string, cppCode bool) (
+func NewFilePP(inputFiles, clangFlags []string, cppCode bool, c Clang) (
 	f FilePP, err error) {
 	defer func() {
 		if err != nil {
@@ -110,7 +119,20 @@ func NewFilePP(inputFiles, clangFlags []string, cppCode bool) (
 
 	var allItems []entity
 
-	allItems, err = analyzeFiles(inputFiles, clangFlags, cppCode)
+	allItems, err = analyzeFiles(inputFiles, clangFlags, cppCode, c)
+	if err != nil {
+		return
+	}
+
+	// All input files are one translation unit, so static names of files
+	// must be unique.
+	err = renameStatics(allItems, inputFiles)
+	if err != nil {
+		return
+	}
+
+	// Nested functions of GNU C are not supported by clang.
+	f.nestedFunctions, err = rewriteNestedFunctions(allItems, inputFiles)
 	if err != nil {
 		return
 	}
@@ -118,12 +140,12 @@ func NewFilePP(inputFiles, clangFlags []string, cppCode bool) (
 	// Generate list of user files
 	userSource := map[string]bool{}
 	var us []string
-	us, err = GetIncludeListWithUserSource(inputFiles, clangFlags, cppCode)
+	us, err = GetIncludeListWithUserSource(inputFiles, clangFlags, cppCode, c)
 	if err != nil {
 		return
 	}
 	var all []string
-	all, err = GetIncludeFullList(inputFiles, clangFlags, cppCode)
+	all, err = GetIncludeFullList(inputFiles, clangFlags, cppCode, c)
 	if err != nil {
 		return
 	}
@@ -134,8 +156,22 @@ func NewFilePP(inputFiles, clangFlags []string, cppCode bool) (
 		userSource[us[j]] = true
 	}
 
+	f.embeds, err = findEmbeds(us, clangFlags)
+	if err != nil {
+		return
+	}
+
+	f.macros, err = findMacros(us)
+	if err != nil {
+		return
+	}
+
+	f.functionMacros, err = findFunctionMacros(us)
+	if err != nil {
+		return
+	}
+
 	// Merge the entities
-	var lines []string
 	for i := range allItems {
 		// If found same part of preprocess code, then
 		// don't include in result buffer for transpiling
@@ -164,27 +200,33 @@ func NewFilePP(inputFiles, clangFlags []string, cppCode bool) (
 			allItems[i].parseComments(&f.comments)
 		}
 
+		f.entities = append(f.entities, allItems[i])
+	}
+	f.pp = joinEntities(f.entities)
+
+	return
+}
+
+// joinEntities returns the preprocessor C code of entities.
+func joinEntities(entities []entity) []byte {
+	var lines []string
+	for i := range entities {
 		// Parameter "other" is not included for avoid like:
 		// ./tests/multi/head.h:4:28: error: invalid line marker flag '2': \
 		// cannot pop empty include stack
 		// # 2 "./tests/multi/main.c" 2
 		//                            ^
 		header := fmt.Sprintf("# %d \"%s\"",
-			allItems[i].positionInSource, allItems[i].include)
+			entities[i].positionInSource, entities[i].include)
 		lines = append(lines, header)
-		if len(allItems[i].lines) > 0 {
-			for ii, l := range allItems[i].lines {
-				if ii == 0 {
-					continue
-				}
-				lines = append(lines, *l)
+		for ii, l := range entities[i].lines {
+			if ii == 0 {
+				continue
 			}
+			lines = append(lines, *l)
 		}
-		f.entities = append(f.entities, allItems[i])
 	}
-	f.pp = ([]byte)(strings.Join(lines, "\n"))
-
-	return
+	return ([]byte)(strings.Join(lines, "\n"))
 }
 
 // GetSource return source of preprocessor C code
@@ -192,6 +234,53 @@ func (f FilePP) GetSource() []byte {
 	return f.pp
 }
 
+// HasNestedFunctions returns true, if the C code has nested functions of
+// GNU C, which are rewritten into blocks of clang. Such code must be parsed
+// by clang with flag `-fblocks`.
+func (f FilePP) HasNestedFunctions() bool {
+	return f.nestedFunctions
+}
+
+// BlockStorage - position of declaration of local variable, which is
+// captured by block and must be declared with storage class `__block`.
+type BlockStorage struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// AddBlockStorage adds the storage class `__block` before declarations of
+// local variables, so the variables may be changed inside blocks like in
+// nested functions of GNU C. Column is started from 1.
+func (f *FilePP) AddBlockStorage(positions []BlockStorage) {
+	// storage class is added from the end of line, so the positions of
+	// other declarations are not changed
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].Column > positions[j].Column
+	})
+	added := map[BlockStorage]bool{}
+	for _, pos := range positions {
+		if added[pos] {
+			continue
+		}
+		added[pos] = true
+		for i := range f.entities {
+			e := &f.entities[i]
+			index := pos.Line + 1 - e.positionInSource
+			if e.include != pos.File || index < 1 || index >= len(e.lines) {
+				continue
+			}
+			s := *e.lines[index]
+			if pos.Column < 1 || len(s) < pos.Column-1 {
+				continue
+			}
+			s = s[:pos.Column-1] + "__block " + s[pos.Column-1:]
+			e.lines[index] = &s
+		}
+	}
+	f.pp = joinEntities(f.entities)
+}
+
 // GetComments return comments in preprocessor C code
 func (f FilePP) GetComments() []Comment {
 	return f.comments
@@ -304,12 +393,12 @@ again:
 }
 
 // analyzeFiles - analyze single file and separation preprocessor code to part
-func analyzeFiles(inputFiles, clangFlags []string, cppCode bool) (
+func analyzeFiles(inputFiles, clangFlags []string, cppCode bool, c Clang) (
 	items []entity, err error) {
 	// See : https://clang.llvm.org/docs/CommandGuide/clang.html
 	// clang -E <file>    Run the preprocessor stage.
 	var out bytes.Buffer
-	out, err = getPreprocessSources(inputFiles, clangFlags, cppCode)
+	out, err = getPreprocessSources(inputFiles, clangFlags, cppCode, c)
 	if err != nil {
 		return
 	}
@@ -354,7 +443,7 @@ func analyzeFiles(inputFiles, clangFlags []string, cppCode bool) (
 
 // See : https://clang.llvm.org/docs/CommandGuide/clang.html
 // clang -E <file>    Run the preprocessor stage.
-func getPreprocessSources(inputFiles, clangFlags []string, cppCode bool) (
+func getPreprocessSources(inputFiles, clangFlags []string, cppCode bool, c Clang) (
 	out bytes.Buffer, err error) {
 	// get temp dir
 	dir, err := ioutil.TempDir("", "c4go-union")
@@ -398,10 +487,10 @@ func getPreprocessSources(inputFiles, clangFlags []string, cppCode bool) (
 	var cmd *exec.Cmd
 	if cppCode {
 		args = append([]string{"-std=c++98"}, args...)
-		cmd = exec.Command("clang++", args...)
+		cmd = exec.Command(c.Cpp, args...)
 	} else {
 		// args = append([]string{"-std=c99"}, args...)
-		cmd = exec.Command("clang", args...)
+		cmd = exec.Command(c.C, args...)
 	}
 	cmd.Stdout = &outFile
 	cmd.Stderr = &stderr
@@ -442,9 +531,9 @@ func generateIncludeList(userList, allList []string) (
 // Example:
 // $ clang  -MM -c exit.c
 // exit.o: exit.c tests.h
-func GetIncludeListWithUserSource(inputFiles, clangFlags []string, cppCode bool) (
+func GetIncludeListWithUserSource(inputFiles, clangFlags []string, cppCode bool, c Clang) (
 	lines []string, err error) {
-	return getIncludeList(inputFiles, clangFlags, "-MM", cppCode)
+	return getIncludeList(inputFiles, clangFlags, "-MM", cppCode, c)
 }
 
 // GetIncludeFullList - Get full list of include files
@@ -456,12 +545,12 @@ func GetIncludeListWithUserSource(inputFiles, clangFlags []string, cppCode bool)
 //   /usr/include/x86_64-linux-gnu/gnu/stubs.h \
 //   /usr/include/x86_64-linux-gnu/gnu/stubs-64.h \
 //   / ........ and other
-func GetIncludeFullList(inputFiles, clangFlags []string, cppCode bool) (
+func GetIncludeFullList(inputFiles, clangFlags []string, cppCode bool, c Clang) (
 	lines []string, err error) {
-	return getIncludeList(inputFiles, clangFlags, "-M", cppCode)
+	return getIncludeList(inputFiles, clangFlags, "-M", cppCode, c)
 }
 
-func getIncludeList(inputFiles, clangFlags []string, flag string, cppCode bool) (
+func getIncludeList(inputFiles, clangFlags []string, flag string, cppCode bool, c Clang) (
 	lines []string, err error) {
 	defer func() {
 		if err != nil {
@@ -483,10 +572,10 @@ func getIncludeList(inputFiles, clangFlags []string, flag string, cppCode bool)
 	var cmd *exec.Cmd
 	if cppCode {
 		args = append([]string{"-std=c++98"}, args...)
-		cmd = exec.Command("clang++", args...)
+		cmd = exec.Command(c.Cpp, args...)
 	} else {
 		// args = append([]string{"-std=c99"}, args...)
-		cmd = exec.Command("clang", args...)
+		cmd = exec.Command(c.C, args...)
 	}
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
@@ -3,14 +3,14 @@ package preprocessor
 import "testing"
 
 func TestNewFilePPFail(t *testing.T) {
-	_, err := NewFilePP([]string{""}, []string{""}, false)
+	_, err := NewFilePP([]string{""}, []string{""}, false, Clang{C: "clang", Cpp: "clang++"})
 	if err == nil {
 		t.Fatalf("Haven`t error")
 	}
 }
 
 func TestgetIncludeListFail(t *testing.T) {
-	_, err := getIncludeList([]string{"@sdf s"}, []string{"wqq4 `?p"}, "w3 fdws", false)
+	_, err := getIncludeList([]string{"@sdf s"}, []string{"wqq4 `?p"}, "w3 fdws", false, Clang{C: "clang", Cpp: "clang++"})
 	if err == nil {
 		t.Fatalf("Haven`t error")
 	}
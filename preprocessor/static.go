@@ -0,0 +1,216 @@
+package preprocessor
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// groupKeywords - keywords followed by the arguments in parentheses, like
+// `__attribute__((unused))`.
+var groupKeywords = map[string]bool{
+	"__attribute__": true, "__attribute": true, "__asm__": true,
+	"__asm": true, "asm": true, "__declspec": true, "_Alignas": true,
+	"alignas": true, "typeof": true, "__typeof__": true, "__typeof": true,
+	"_Atomic": true,
+}
+
+// staticNames returns the names of variables and functions, which are
+// declared with storage class `static` at file scope.
+func staticNames(tokens []token) (names []string) {
+	var depth int
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i].text {
+		case "{":
+			depth++
+			continue
+		case "}":
+			depth--
+			continue
+		}
+		if depth != 0 || tokens[i].text != "static" ||
+			i > 0 && tokens[i-1].text == "[" {
+			// array parameters, like `int a[static 10]`, are not declarations
+			continue
+		}
+		// last - the last found name of declaration
+		var paren int
+		var last string
+	declaration:
+		for i++; i < len(tokens); i++ {
+			t := tokens[i]
+			switch t.text {
+			case ";":
+				break declaration
+			case "{":
+				if tokens[i-1].text == ")" {
+					// body of function
+					i--
+					break declaration
+				}
+				// body of struct, union or enum
+				i = skipGroup(tokens, i)
+			case "[":
+				i = skipGroup(tokens, i)
+			case "=":
+				// initializer is finished by comma or semicolon
+				for i++; i < len(tokens); i++ {
+					switch tokens[i].text {
+					case "(", "[", "{":
+						i = skipGroup(tokens, i)
+						continue
+					case ",":
+					case ";":
+						break declaration
+					default:
+						continue
+					}
+					break
+				}
+			case "(":
+				prev := tokens[i-1].text
+				if prev == ")" || groupKeywords[prev] ||
+					(paren == 0 && prev == last) {
+					// parameters of function or arguments of attribute
+					i = skipGroup(tokens, i)
+					continue
+				}
+				paren++
+			case ")":
+				paren--
+			default:
+				if !t.isIdent() || cKeywords[t.text] || i+1 == len(tokens) {
+					continue
+				}
+				if paren > 0 && tokens[i-1].text != "*" {
+					continue
+				}
+				switch tokens[i+1].text {
+				case "(", "[", "=", ";", ",", ")":
+					last = t.text
+					names = append(names, last)
+				}
+			}
+		}
+	}
+	return
+}
+
+// renameStatics renames the static variables and functions of input files,
+// if the names are used in other input files. All input files are
+// transpiled as one translation unit, so the static names with file scope
+// may collide. Suffix of new name is the name of file:
+//
+//     static int counter;   // in file "list.c"
+//
+// is renamed to:
+//
+//     static int counter_list;
+//
+// Names are not renamed in bodies of structs and unions and after
+// operators `.` and `->`, because these are fields.
+func renameStatics(entities []entity, inputFiles []string) error {
+	files, err := inputEntities(entities, inputFiles)
+	if err != nil {
+		return err
+	}
+
+	tokens := make([][]token, len(files))
+	used := make([]map[string]bool, len(files))
+	all := map[string]bool{}
+	for i := range files {
+		tokens[i] = tokenize(entities, files[i])
+		used[i] = map[string]bool{}
+		for _, t := range tokens[i] {
+			if t.isIdent() {
+				used[i][t.text] = true
+				all[t.text] = true
+			}
+		}
+	}
+
+	for i := range files {
+		renames := map[string]string{}
+		for _, name := range staticNames(tokens[i]) {
+			if _, ok := renames[name]; ok {
+				continue
+			}
+			var collision bool
+			for j := range files {
+				if j != i && used[j][name] {
+					collision = true
+				}
+			}
+			if !collision {
+				continue
+			}
+			newName := name + "_" + fileSuffix(inputFiles[i])
+			for all[newName] {
+				newName += "_"
+			}
+			all[newName] = true
+			renames[name] = newName
+		}
+		if len(renames) == 0 {
+			continue
+		}
+
+		// records - stack of braces, true for body of struct or union
+		var records []bool
+		var inRecord int
+		var found []token
+		for k, t := range tokens[i] {
+			switch t.text {
+			case "{":
+				isRecord := k > 0 && isRecordKeyword(tokens[i][k-1].text) ||
+					k > 1 && isRecordKeyword(tokens[i][k-2].text)
+				records = append(records, isRecord)
+				if isRecord {
+					inRecord++
+				}
+				continue
+			case "}":
+				if len(records) > 0 {
+					if records[len(records)-1] {
+						inRecord--
+					}
+					records = records[:len(records)-1]
+				}
+				continue
+			}
+			if _, ok := renames[t.text]; !ok || inRecord > 0 {
+				continue
+			}
+			if k > 0 && (tokens[i][k-1].text == "." || tokens[i][k-1].text == "->") {
+				continue
+			}
+			found = append(found, t)
+		}
+		// names are replaced from the end of line, so the positions of
+		// other names are not changed
+		for k := len(found) - 1; k >= 0; k-- {
+			t := found[k]
+			line := *entities[t.entity].lines[t.line]
+			line = line[:t.col] + renames[t.text] + line[t.col+len(t.text):]
+			entities[t.entity].lines[t.line] = &line
+		}
+	}
+	return nil
+}
+
+// isRecordKeyword returns true for keywords of struct and union.
+func isRecordKeyword(s string) bool {
+	return s == "struct" || s == "union"
+}
+
+// fileSuffix returns the name of file without extension as C identifier.
+func fileSuffix(file string) string {
+	base := filepath.Base(file)
+	suffix := []byte(strings.TrimSuffix(base, filepath.Ext(base)))
+	for k, c := range suffix {
+		if !(c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') ||
+			('0' <= c && c <= '9')) {
+			suffix[k] = '_'
+		}
+	}
+	return string(suffix)
+}
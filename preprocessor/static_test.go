@@ -0,0 +1,71 @@
+package preprocessor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenameStatics(t *testing.T) {
+	newEntity := func(include, code string) entity {
+		e := entity{include: include, positionInSource: 1}
+		for _, line := range append([]string{`# 1 "` + include + `"`},
+			strings.Split(code, "\n")...) {
+			line := line
+			e.lines = append(e.lines, &line)
+		}
+		return e
+	}
+	entities := []entity{
+		newEntity("/src/list.c", `struct node { int count; };
+static int count = 0, size;
+static void (*handlers[2])(int);
+static __attribute__((unused)) int next(struct node *n) {
+	/* count in comment */
+	printf("count\n");
+	return n->count + count++ + size;
+}
+void f(int a[static 2]);`),
+		newEntity("/usr/include/stdio.h", `int count(void);`),
+		newEntity("/src/main-file.c", `static int count = 1;
+static int handlers;
+int next(void) { return count; }`),
+	}
+	err := renameStatics(entities, []string{"/src/list.c", "/src/main-file.c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{
+		{
+			`struct node { int count; };`,
+			`static int count_list = 0, size;`,
+			`static void (*handlers_list[2])(int);`,
+			`static __attribute__((unused)) int next_list(struct node *n) {`,
+			`	/* count in comment */`,
+			`	printf("count\n");`,
+			`	return n->count + count_list++ + size;`,
+			`}`,
+			`void f(int a[static 2]);`,
+		},
+		{
+			`int count(void);`,
+		},
+		{
+			`static int count_main_file = 1;`,
+			`static int handlers_main_file;`,
+			`int next(void) { return count_main_file; }`,
+		},
+	}
+	for i := range entities {
+		var lines []string
+		for _, line := range entities[i].lines[1:] {
+			lines = append(lines, *line)
+		}
+		if !reflect.DeepEqual(lines, expected[i]) {
+			t.Errorf("Not expected code of %s:\n%s\nExpected:\n%s",
+				entities[i].include, strings.Join(lines, "\n"),
+				strings.Join(expected[i], "\n"))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package preprocessor
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// token - identifier or punctuation of preprocessed C code with position
+// in the entity.
+type token struct {
+	text   string
+	entity int
+	line   int
+	col    int
+}
+
+// isIdent returns true for identifiers and keywords.
+func (t token) isIdent() bool {
+	c := t.text[0]
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// cKeywords - keywords of C and GNU C, which are not names of variables
+// and functions.
+var cKeywords = map[string]bool{
+	"auto": true, "break": true, "case": true, "char": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true,
+	"else": true, "enum": true, "extern": true, "float": true, "for": true,
+	"goto": true, "if": true, "inline": true, "int": true, "long": true,
+	"register": true, "restrict": true, "return": true, "short": true,
+	"signed": true, "sizeof": true, "static": true, "struct": true,
+	"switch": true, "typedef": true, "union": true, "unsigned": true,
+	"void": true, "volatile": true, "while": true, "bool": true,
+	"_Bool": true, "_Complex": true, "_Imaginary": true, "_Alignas": true,
+	"_Alignof": true, "_Atomic": true, "_Noreturn": true,
+	"_Static_assert": true, "_Thread_local": true, "__thread": true,
+	"__attribute__": true, "__attribute": true, "__inline": true,
+	"__inline__": true, "__restrict": true, "__restrict__": true,
+	"__const": true, "__volatile__": true, "__extension__": true,
+	"__asm__": true, "__asm": true, "asm": true, "typeof": true,
+	"__typeof__": true, "__typeof": true, "__signed__": true,
+	"__declspec": true, "alignas": true,
+}
+
+// tokenize returns identifiers and punctuation of entities. Comments,
+// literals, numbers and lines of preprocessor, like `# 1 "file.c"`, are
+// skipped.
+func tokenize(entities []entity, indexes []int) (tokens []token) {
+	var inComment bool
+	for _, e := range indexes {
+		for l, line := range entities[e].lines {
+			if l == 0 {
+				// line marker of entity
+				continue
+			}
+			s := *line
+			if !inComment && strings.HasPrefix(strings.TrimSpace(s), "#") {
+				continue
+			}
+			for i := 0; i < len(s); {
+				c := s[i]
+				switch {
+				case inComment:
+					end := strings.Index(s[i:], "*/")
+					if end < 0 {
+						i = len(s)
+						continue
+					}
+					inComment = false
+					i += end + 2
+				case strings.HasPrefix(s[i:], "//"):
+					i = len(s)
+				case strings.HasPrefix(s[i:], "/*"):
+					inComment = true
+					i += 2
+				case c == '"' || c == '\'':
+					for i++; i < len(s) && s[i] != c; i++ {
+						if s[i] == '\\' {
+							i++
+						}
+					}
+					i++
+				case c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+					start := i
+					for i < len(s) && (s[i] == '_' || ('a' <= s[i] && s[i] <= 'z') ||
+						('A' <= s[i] && s[i] <= 'Z') || ('0' <= s[i] && s[i] <= '9')) {
+						i++
+					}
+					tokens = append(tokens, token{
+						text: s[start:i], entity: e, line: l, col: start})
+				case '0' <= c && c <= '9':
+					for i < len(s) && (s[i] == '_' || s[i] == '.' ||
+						('a' <= s[i] && s[i] <= 'z') || ('A' <= s[i] && s[i] <= 'Z') ||
+						('0' <= s[i] && s[i] <= '9')) {
+						i++
+					}
+				case c == ' ' || c == '\t' || c == '\r':
+					i++
+				default:
+					text := s[i : i+1]
+					if strings.HasPrefix(s[i:], "->") {
+						text = "->"
+					}
+					tokens = append(tokens, token{
+						text: text, entity: e, line: l, col: i})
+					i += len(text)
+				}
+			}
+		}
+	}
+	return
+}
+
+// skipGroup returns the index of closing bracket for the opening bracket
+// at index i.
+func skipGroup(tokens []token, i int) int {
+	var depth int
+	for ; i < len(tokens); i++ {
+		switch tokens[i].text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// inputEntities returns the indexes of entities of each input file.
+func inputEntities(entities []entity, inputFiles []string) (
+	files [][]int, err error) {
+	files = make([][]int, len(inputFiles))
+	for i, in := range inputFiles {
+		var abs string
+		abs, err = filepath.Abs(in)
+		if err != nil {
+			return
+		}
+		for e := range entities {
+			if entities[e].include == abs || entities[e].include == in {
+				files[i] = append(files[i], e)
+			}
+		}
+	}
+	return
+}
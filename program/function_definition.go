@@ -31,6 +31,20 @@ type FunctionDefinition struct {
 	Parameters       []int
 }
 
+// Replacement is the Go function of project, which is called instead of the
+// C function. See option "-replace".
+type Replacement struct {
+	// Function is the Go function with import path, like
+	// "github.com/user/project/cutil.Strlcpy". The name of package must be
+	// the last element of import path.
+	Function string
+
+	// Signature is the Go signature of function, like
+	// "func([]byte, []byte, uint32) uint32". Calls are replaced only if the
+	// signature is the same as the Go signature of C function.
+	Signature string
+}
+
 // Each of the predefined function have a syntax that allows them to be easy to
 // read (and maintain). For example:
 //
@@ -65,8 +79,36 @@ var builtInFunctionDefinitions = map[string][]string{
 	"ctype.h": {
 		// linux/ctype.h
 		"const unsigned short int** __ctype_b_loc() -> linux.CtypeLoc",
+		"const int** __ctype_tolower_loc() -> linux.CtypeTolowerLoc",
+		"const int** __ctype_toupper_loc() -> linux.CtypeToupperLoc",
+		"int isalnum(int) -> linux.IsAlnum",
+		"int isalpha(int) -> linux.IsAlpha",
+		"int isblank(int) -> linux.IsBlank",
+		"int iscntrl(int) -> linux.IsCntrl",
+		"int isdigit(int) -> linux.IsDigit",
+		"int isgraph(int) -> linux.IsGraph",
+		"int islower(int) -> linux.IsLower",
+		"int isprint(int) -> linux.IsPrint",
+		"int ispunct(int) -> linux.IsPunct",
+		"int isspace(int) -> linux.IsSpace",
+		"int isupper(int) -> linux.IsUpper",
+		"int isxdigit(int) -> linux.IsXdigit",
 		"int tolower(int) -> linux.ToLower",
 		"int toupper(int) -> linux.ToUpper",
+		"int isalnum_l(int, void*) -> linux.IsAlnumL",
+		"int isalpha_l(int, void*) -> linux.IsAlphaL",
+		"int isblank_l(int, void*) -> linux.IsBlankL",
+		"int iscntrl_l(int, void*) -> linux.IsCntrlL",
+		"int isdigit_l(int, void*) -> linux.IsDigitL",
+		"int isgraph_l(int, void*) -> linux.IsGraphL",
+		"int islower_l(int, void*) -> linux.IsLowerL",
+		"int isprint_l(int, void*) -> linux.IsPrintL",
+		"int ispunct_l(int, void*) -> linux.IsPunctL",
+		"int isspace_l(int, void*) -> linux.IsSpaceL",
+		"int isupper_l(int, void*) -> linux.IsUpperL",
+		"int isxdigit_l(int, void*) -> linux.IsXdigitL",
+		"int tolower_l(int, void*) -> linux.ToLowerL",
+		"int toupper_l(int, void*) -> linux.ToUpperL",
 	},
 	"math.h": {
 		// linux/math.h
@@ -82,87 +124,419 @@ var builtInFunctionDefinitions = map[string][]string{
 		"int __isinff(float) -> linux.IsInff",
 		"int __isinf(double) -> linux.IsInf",
 		"int __isinfl(long double) -> linux.IsInf",
+		"int __finitef(float) -> noarch.Isfinitef",
+		"int __finite(double) -> noarch.Isfinite",
+		"int __finitel(long double) -> noarch.Isfinite",
+		"int __fpclassifyf(float) -> noarch.Fpclassifyf",
+		"int __fpclassify(double) -> noarch.Fpclassify",
+		"int __fpclassifyl(long double) -> noarch.Fpclassify",
 		"double __builtin_nanf(const char*) -> linux.NaN",
 		"float __builtin_inff() -> linux.Inff",
 
+		// Type-generic builtin functions of classification macros. Calls
+		// with argument of type "float" are transpiled as functions with
+		// suffix "f".
+		"int __builtin_isnan(double) -> noarch.IsNaN",
+		"int __builtin_isnanf(float) -> noarch.IsNaNf",
+		"int __builtin_isinf(double) -> noarch.Isinf",
+		"int __builtin_isinff(float) -> noarch.Isinff",
+		"int __builtin_isinf_sign(double) -> noarch.IsinfSign",
+		"int __builtin_isinf_signf(float) -> noarch.IsinfSignf",
+		"int __builtin_isfinite(double) -> noarch.Isfinite",
+		"int __builtin_isfinitef(float) -> noarch.Isfinitef",
+		"int __builtin_isnormal(double) -> noarch.Isnormal",
+		"int __builtin_isnormalf(float) -> noarch.Isnormalf",
+		"int __builtin_fpclassify(int, int, int, int, int, double) -> noarch.BuiltinFpclassify",
+		"int __builtin_fpclassifyf(int, int, int, int, int, float) -> noarch.BuiltinFpclassifyf",
+
 		// darwin/math.h
 		"int __inline_signbitf(float) -> noarch.Signbitf",
 		"int __inline_signbitd(double) -> noarch.Signbitd",
 		"int __inline_signbitl(long double) -> noarch.Signbitl",
+		"int __inline_isnanf(float) -> noarch.IsNaNf",
+		"int __inline_isnand(double) -> noarch.IsNaN",
+		"int __inline_isnanl(long double) -> noarch.IsNaN",
+		"int __inline_isinff(float) -> noarch.Isinff",
+		"int __inline_isinfd(double) -> noarch.Isinf",
+		"int __inline_isinfl(long double) -> noarch.Isinf",
+		"int __inline_isfinitef(float) -> noarch.Isfinitef",
+		"int __inline_isfinited(double) -> noarch.Isfinite",
+		"int __inline_isfinitel(long double) -> noarch.Isfinite",
+		"int __inline_isnormalf(float) -> noarch.Isnormalf",
+		"int __inline_isnormald(double) -> noarch.Isnormal",
+		"int __inline_isnormall(long double) -> noarch.Isnormal",
+
+		// Function forms of classification macros, which are declared in
+		// glibc for compatibility
+		"int isnan(double) -> noarch.IsNaN",
+		"int isnanf(float) -> noarch.IsNaNf",
+		"int isnanl(long double) -> noarch.IsNaN",
+		"int isinf(double) -> linux.IsInf",
+		"int isinff(float) -> linux.IsInff",
+		"int isinfl(long double) -> linux.IsInf",
+		"int finite(double) -> noarch.Isfinite",
+		"int finitef(float) -> noarch.Isfinitef",
+		"int finitel(long double) -> noarch.Isfinite",
 
 		// math.h
+		// Functions with type "long double" are the same as functions with
+		// type "double".
 		"double acos(double) -> math.Acos",
+		"float acosf(float) -> noarch.Acosf",
+		"long double acosl(long double) -> math.Acos",
+
 		"double asin(double) -> math.Asin",
+		"float asinf(float) -> noarch.Asinf",
+		"long double asinl(long double) -> math.Asin",
+
 		"double atan(double) -> math.Atan",
+		"float atanf(float) -> noarch.Atanf",
+		"long double atanl(long double) -> math.Atan",
+
 		"double atan2(double, double) -> math.Atan2",
-		"double ceil(double) -> math.Ceil",
+		"float atan2f(float, float) -> noarch.Atan2f",
+		"long double atan2l(long double, long double) -> math.Atan2",
+
 		"double cos(double) -> math.Cos",
-		"double cosh(double) -> math.Cosh",
-		"double exp(double) -> math.Exp",
-		"double fabs(double) -> math.Abs",
-		"double floor(double) -> math.Floor",
-		"double fmod(double, double) -> math.Mod",
-		"double ldexp(double, int) -> math.Ldexp",
-		"double log(double) -> math.Log",
-		"double log10(double) -> math.Log10",
-		"double pow(double, double) -> math.Pow",
+		"float cosf(float) -> noarch.Cosf",
+		"long double cosl(long double) -> math.Cos",
+
 		"double sin(double) -> math.Sin",
-		"double sinh(double) -> math.Sinh",
-		"double sqrt(double) -> math.Sqrt",
+		"float sinf(float) -> noarch.Sinf",
+		"long double sinl(long double) -> math.Sin",
+
 		"double tan(double) -> math.Tan",
-		"double tanh(double) -> math.Tanh",
+		"float tanf(float) -> noarch.Tanf",
+		"long double tanl(long double) -> math.Tan",
 
-		"double fma(double, double, double) -> noarch.Fma",
-		"float fmaf(float, float, float) -> noarch.Fmaf",
-		"long double fmal(long double, long double, long double) -> noarch.Fma",
+		"double acosh(double) -> math.Acosh",
+		"float acoshf(float) -> noarch.Acoshf",
+		"long double acoshl(long double) -> math.Acosh",
 
-		"double fmin(double , double ) -> noarch.Fmin",
-		"float fminf(float , float ) -> noarch.Fminf",
-		"long double fminl(long double , long double ) -> noarch.Fmin",
+		"double asinh(double) -> math.Asinh",
+		"float asinhf(float) -> noarch.Asinhf",
+		"long double asinhl(long double) -> math.Asinh",
+
+		"double atanh(double) -> math.Atanh",
+		"float atanhf(float) -> noarch.Atanhf",
+		"long double atanhl(long double) -> math.Atanh",
 
-		"double fmax(double , double ) -> noarch.Fmax",
-		"float fmaxf(float , float ) -> noarch.Fmaxf",
-		"long double fmaxl(long double , long double ) -> noarch.Fmax",
+		"double cosh(double) -> math.Cosh",
+		"float coshf(float) -> noarch.Coshf",
+		"long double coshl(long double) -> math.Cosh",
 
-		"double expm1(double) -> math.Expm1",
-		"float expm1f(float) -> noarch.Expm1f",
-		"long double expm1l(long double) -> math.Expm1",
+		"double sinh(double) -> math.Sinh",
+		"float sinhf(float) -> noarch.Sinhf",
+		"long double sinhl(long double) -> math.Sinh",
+
+		"double tanh(double) -> math.Tanh",
+		"float tanhf(float) -> noarch.Tanhf",
+		"long double tanhl(long double) -> math.Tanh",
+
+		"double exp(double) -> math.Exp",
+		"float expf(float) -> noarch.Expf",
+		"long double expl(long double) -> math.Exp",
 
 		"double exp2(double) -> math.Exp2",
 		"float exp2f(float) -> noarch.Exp2f",
 		"long double exp2l(long double) -> math.Exp2",
 
+		"double expm1(double) -> math.Expm1",
+		"float expm1f(float) -> noarch.Expm1f",
+		"long double expm1l(long double) -> math.Expm1",
+
+		"double frexp(double, int*) -> noarch.Frexp",
+		"float frexpf(float, int*) -> noarch.Frexpf",
+		"long double frexpl(long double, int*) -> noarch.Frexp",
+
+		"int ilogb(double) -> noarch.Ilogb",
+		"int ilogbf(float) -> noarch.Ilogbf",
+		"int ilogbl(long double) -> noarch.Ilogb",
+
+		"double ldexp(double, int) -> math.Ldexp",
+		"float ldexpf(float, int) -> noarch.Ldexpf",
+		"long double ldexpl(long double, int) -> math.Ldexp",
+
+		"double log(double) -> noarch.Log",
+		"float logf(float) -> noarch.Logf",
+		"long double logl(long double) -> noarch.Log",
+
+		"double log10(double) -> noarch.Log10",
+		"float log10f(float) -> noarch.Log10f",
+		"long double log10l(long double) -> noarch.Log10",
+
+		"double log1p(double) -> math.Log1p",
+		"float log1pf(float) -> noarch.Log1pf",
+		"long double log1pl(long double) -> math.Log1p",
+
 		"double log2(double) -> math.Log2",
 		"float log2f(float) -> noarch.Log2f",
 		"long double log2l(long double) -> math.Log2",
 
+		"double logb(double) -> math.Logb",
+		"float logbf(float) -> noarch.Logbf",
+		"long double logbl(long double) -> math.Logb",
+
+		"double modf(double, double*) -> noarch.Modf",
+		"float modff(float, float*) -> noarch.Modff",
+		"long double modfl(long double, long double*) -> noarch.Modf",
+
+		"double scalbn(double, int) -> math.Ldexp",
+		"float scalbnf(float, int) -> noarch.Ldexpf",
+		"long double scalbnl(long double, int) -> math.Ldexp",
+
+		"double scalbln(double, long) -> noarch.Scalbln",
+		"float scalblnf(float, long) -> noarch.Scalblnf",
+		"long double scalblnl(long double, long) -> noarch.Scalbln",
+
+		"double cbrt(double) -> math.Cbrt",
+		"float cbrtf(float) -> noarch.Cbrtf",
+		"long double cbrtl(long double) -> math.Cbrt",
+
+		"double fabs(double) -> math.Abs",
+		"float fabsf(float) -> noarch.Fabsf",
+		"long double fabsl(long double) -> math.Abs",
+
+		"double hypot(double, double) -> math.Hypot",
+		"float hypotf(float, float) -> noarch.Hypotf",
+		"long double hypotl(long double, long double) -> math.Hypot",
+
+		"double pow(double, double) -> math.Pow",
+		"float powf(float, float) -> noarch.Powf",
+		"long double powl(long double, long double) -> math.Pow",
+
+		"double sqrt(double) -> math.Sqrt",
+		"float sqrtf(float) -> noarch.Sqrtf",
+		"long double sqrtl(long double) -> math.Sqrt",
+
+		"double erf(double) -> math.Erf",
+		"float erff(float) -> noarch.Erff",
+		"long double erfl(long double) -> math.Erf",
+
+		"double erfc(double) -> math.Erfc",
+		"float erfcf(float) -> noarch.Erfcf",
+		"long double erfcl(long double) -> math.Erfc",
+
+		"double lgamma(double) -> noarch.Lgamma",
+		"float lgammaf(float) -> noarch.Lgammaf",
+		"long double lgammal(long double) -> noarch.Lgamma",
+		"double lgamma_r(double, int *) -> noarch.LgammaR",
+		"float lgammaf_r(float, int *) -> noarch.LgammafR",
+		"long double lgammal_r(long double, int *) -> noarch.LgammaR",
+
+		"double tgamma(double) -> math.Gamma",
+		"float tgammaf(float) -> noarch.Tgammaf",
+		"long double tgammal(long double) -> math.Gamma",
+
+		"double j0(double) -> noarch.J0",
+		"float j0f(float) -> noarch.J0f",
+		"long double j0l(long double) -> noarch.J0",
+
+		"double j1(double) -> noarch.J1",
+		"float j1f(float) -> noarch.J1f",
+		"long double j1l(long double) -> noarch.J1",
+
+		"double y0(double) -> noarch.Y0",
+		"float y0f(float) -> noarch.Y0f",
+		"long double y0l(long double) -> noarch.Y0",
+
+		"double y1(double) -> noarch.Y1",
+		"float y1f(float) -> noarch.Y1f",
+		"long double y1l(long double) -> noarch.Y1",
+
+		"double jn(int, double) -> noarch.Jn",
+		"float jnf(int, float) -> noarch.Jnf",
+		"long double jnl(int, long double) -> noarch.Jn",
+
+		"double yn(int, double) -> noarch.Yn",
+		"float ynf(int, float) -> noarch.Ynf",
+		"long double ynl(int, long double) -> noarch.Yn",
+
+		"double ceil(double) -> math.Ceil",
+		"float ceilf(float) -> noarch.Ceilf",
+		"long double ceill(long double) -> math.Ceil",
+
+		"double floor(double) -> math.Floor",
+		"float floorf(float) -> noarch.Floorf",
+		"long double floorl(long double) -> math.Floor",
+
+		"double nearbyint(double) -> noarch.Nearbyint",
+		"float nearbyintf(float) -> noarch.Nearbyintf",
+		"long double nearbyintl(long double) -> noarch.Nearbyint",
+
+		"double rint(double) -> noarch.Rint",
+		"float rintf(float) -> noarch.Rintf",
+		"long double rintl(long double) -> noarch.Rint",
+
+		"long lrint(double) -> noarch.Lrint",
+		"long lrintf(float) -> noarch.Lrintf",
+		"long lrintl(long double) -> noarch.Lrint",
+
+		"long long llrint(double) -> noarch.Llrint",
+		"long long llrintf(float) -> noarch.Llrintf",
+		"long long llrintl(long double) -> noarch.Llrint",
+
+		"double round(double) -> math.Round",
+		"float roundf(float) -> noarch.Roundf",
+		"long double roundl(long double) -> math.Round",
+
+		"long lround(double) -> noarch.Lround",
+		"long lroundf(float) -> noarch.Lroundf",
+		"long lroundl(long double) -> noarch.Lround",
+
+		"long long llround(double) -> noarch.Llround",
+		"long long llroundf(float) -> noarch.Llroundf",
+		"long long llroundl(long double) -> noarch.Llround",
+
+		"double trunc(double) -> math.Trunc",
+		"float truncf(float) -> noarch.Truncf",
+		"long double truncl(long double) -> math.Trunc",
+
+		"double fmod(double, double) -> math.Mod",
+		"float fmodf(float, float) -> noarch.Fmodf",
+		"long double fmodl(long double, long double) -> math.Mod",
+
+		"double remainder(double, double) -> math.Remainder",
+		"float remainderf(float, float) -> noarch.Remainderf",
+		"long double remainderl(long double, long double) -> math.Remainder",
+
+		"double remquo(double, double, int*) -> noarch.Remquo",
+		"float remquof(float, float, int*) -> noarch.Remquof",
+		"long double remquol(long double, long double, int*) -> noarch.Remquo",
+
+		"double copysign(double, double) -> math.Copysign",
+		"float copysignf(float, float) -> noarch.Copysignf",
+		"long double copysignl(long double, long double) -> math.Copysign",
+
+		"double nan(const char*) -> noarch.Nan",
+		"float nanf(const char*) -> noarch.Nanf",
+		"long double nanl(const char*) -> noarch.Nan",
+
+		"double nextafter(double, double) -> noarch.Nextafter",
+		"float nextafterf(float, float) -> noarch.Nextafterf",
+		"long double nextafterl(long double, long double) -> noarch.Nextafter",
+
+		"double nexttoward(double, long double) -> noarch.Nextafter",
+		"float nexttowardf(float, long double) -> noarch.Nexttowardf",
+		"long double nexttowardl(long double, long double) -> noarch.Nextafter",
+
 		"double fdim(double, double) -> noarch.Fdim",
 		"float fdimf(float, float) -> noarch.Fdimf",
 		"long double fdiml(long double, long double) -> noarch.Fdim",
 
-		"double asinh(double) -> math.Asinh",
-		"float asinhf(float) -> noarch.Asinhf",
-		"long double asinhl(long double) -> math.Asinh",
+		"double fmax(double, double) -> noarch.Fmax",
+		"float fmaxf(float, float) -> noarch.Fmaxf",
+		"long double fmaxl(long double, long double) -> noarch.Fmax",
 
-		"double acosh(double) -> math.Acosh",
-		"float acoshf(float) -> noarch.Acoshf",
-		"long double acoshl(long double) -> math.Acosh",
+		"double fmin(double, double) -> noarch.Fmin",
+		"float fminf(float, float) -> noarch.Fminf",
+		"long double fminl(long double, long double) -> noarch.Fmin",
 
-		"double atanh(double) -> math.Atanh",
-		"float atanhf(float) -> noarch.Atanhf",
-		"long double atanhl(long double) -> math.Atanh",
+		"double fma(double, double, double) -> noarch.Fma",
+		"float fmaf(float, float, float) -> noarch.Fmaf",
+		"long double fmal(long double, long double, long double) -> noarch.Fma",
+	},
+	"fenv.h": {
+		"int feclearexcept(int) -> noarch.Feclearexcept",
+		"int fegetexceptflag(fexcept_t*, int) -> noarch.Fegetexceptflag",
+		"int feraiseexcept(int) -> noarch.Feraiseexcept",
+		"int fesetexceptflag(const fexcept_t*, int) -> noarch.Fesetexceptflag",
+		"int fetestexcept(int) -> noarch.Fetestexcept",
+		"int fegetround() -> noarch.Fegetround",
+		"int fesetround(int) -> noarch.Fesetround",
+		"int fegetenv(fenv_t*) -> noarch.Fegetenv",
+		"int feholdexcept(fenv_t*) -> noarch.Feholdexcept",
+		"int fesetenv(const fenv_t*) -> noarch.Fesetenv",
+		"int feupdateenv(const fenv_t*) -> noarch.Feupdateenv",
+	},
+	"complex.h": {
+		"double creal(_Complex double) -> noarch.Creal",
+		"float crealf(_Complex float) -> noarch.Crealf",
+		"long double creall(_Complex long double) -> noarch.Creal",
 
-		"double sinh(double) -> math.Sinh",
-		"float sinhf(float) -> noarch.Sinhf",
-		"long double sinhl(long double) -> math.Sinh",
+		"double cimag(_Complex double) -> noarch.Cimag",
+		"float cimagf(_Complex float) -> noarch.Cimagf",
+		"long double cimagl(_Complex long double) -> noarch.Cimag",
 
-		"double cosh(double) -> math.Cosh",
-		"float coshf(float) -> noarch.Coshf",
-		"long double coshl(long double) -> math.Cosh",
+		"double cabs(_Complex double) -> noarch.Cabs",
+		"float cabsf(_Complex float) -> noarch.Cabsf",
+		"long double cabsl(_Complex long double) -> noarch.Cabs",
 
-		"double tanh(double) -> math.Tanh",
-		"float tanhf(float) -> noarch.Tanhf",
-		"long double tanhl(long double) -> math.Tanh",
+		"double carg(_Complex double) -> noarch.Carg",
+		"float cargf(_Complex float) -> noarch.Cargf",
+		"long double cargl(_Complex long double) -> noarch.Carg",
+
+		"_Complex double conj(_Complex double) -> noarch.Conj",
+		"_Complex float conjf(_Complex float) -> noarch.Conjf",
+		"_Complex long double conjl(_Complex long double) -> noarch.Conj",
+
+		"_Complex double cproj(_Complex double) -> noarch.Cproj",
+		"_Complex float cprojf(_Complex float) -> noarch.Cprojf",
+		"_Complex long double cprojl(_Complex long double) -> noarch.Cproj",
+
+		"_Complex double cpow(_Complex double, _Complex double) -> noarch.Cpow",
+		"_Complex float cpowf(_Complex float, _Complex float) -> noarch.Cpowf",
+		"_Complex long double cpowl(_Complex long double, _Complex long double) -> noarch.Cpow",
+
+		"_Complex double cexp(_Complex double) -> noarch.Cexp",
+		"_Complex float cexpf(_Complex float) -> noarch.Cexpf",
+		"_Complex long double cexpl(_Complex long double) -> noarch.Cexp",
+
+		"_Complex double clog(_Complex double) -> noarch.Clog",
+		"_Complex float clogf(_Complex float) -> noarch.Clogf",
+		"_Complex long double clogl(_Complex long double) -> noarch.Clog",
+
+		"_Complex double csqrt(_Complex double) -> noarch.Csqrt",
+		"_Complex float csqrtf(_Complex float) -> noarch.Csqrtf",
+		"_Complex long double csqrtl(_Complex long double) -> noarch.Csqrt",
+
+		"_Complex double csin(_Complex double) -> noarch.Csin",
+		"_Complex float csinf(_Complex float) -> noarch.Csinf",
+		"_Complex long double csinl(_Complex long double) -> noarch.Csin",
+
+		"_Complex double ccos(_Complex double) -> noarch.Ccos",
+		"_Complex float ccosf(_Complex float) -> noarch.Ccosf",
+		"_Complex long double ccosl(_Complex long double) -> noarch.Ccos",
+
+		"_Complex double ctan(_Complex double) -> noarch.Ctan",
+		"_Complex float ctanf(_Complex float) -> noarch.Ctanf",
+		"_Complex long double ctanl(_Complex long double) -> noarch.Ctan",
+
+		"_Complex double casin(_Complex double) -> noarch.Casin",
+		"_Complex float casinf(_Complex float) -> noarch.Casinf",
+		"_Complex long double casinl(_Complex long double) -> noarch.Casin",
+
+		"_Complex double cacos(_Complex double) -> noarch.Cacos",
+		"_Complex float cacosf(_Complex float) -> noarch.Cacosf",
+		"_Complex long double cacosl(_Complex long double) -> noarch.Cacos",
+
+		"_Complex double catan(_Complex double) -> noarch.Catan",
+		"_Complex float catanf(_Complex float) -> noarch.Catanf",
+		"_Complex long double catanl(_Complex long double) -> noarch.Catan",
+
+		"_Complex double csinh(_Complex double) -> noarch.Csinh",
+		"_Complex float csinhf(_Complex float) -> noarch.Csinhf",
+		"_Complex long double csinhl(_Complex long double) -> noarch.Csinh",
+
+		"_Complex double ccosh(_Complex double) -> noarch.Ccosh",
+		"_Complex float ccoshf(_Complex float) -> noarch.Ccoshf",
+		"_Complex long double ccoshl(_Complex long double) -> noarch.Ccosh",
+
+		"_Complex double ctanh(_Complex double) -> noarch.Ctanh",
+		"_Complex float ctanhf(_Complex float) -> noarch.Ctanhf",
+		"_Complex long double ctanhl(_Complex long double) -> noarch.Ctanh",
+
+		"_Complex double casinh(_Complex double) -> noarch.Casinh",
+		"_Complex float casinhf(_Complex float) -> noarch.Casinhf",
+		"_Complex long double casinhl(_Complex long double) -> noarch.Casinh",
+
+		"_Complex double cacosh(_Complex double) -> noarch.Cacosh",
+		"_Complex float cacoshf(_Complex float) -> noarch.Cacoshf",
+		"_Complex long double cacoshl(_Complex long double) -> noarch.Cacosh",
+
+		"_Complex double catanh(_Complex double) -> noarch.Catanh",
+		"_Complex float catanhf(_Complex float) -> noarch.Catanhf",
+		"_Complex long double catanhl(_Complex long double) -> noarch.Catanh",
 	},
 	"stdio.h": {
 
@@ -182,6 +556,8 @@ var builtInFunctionDefinitions = map[string][]string{
 		"int fputs(const char*, FILE*) -> noarch.Fputs",
 		"FILE* tmpfile() -> noarch.Tmpfile",
 		"char* fgets(char*, int, FILE*) -> noarch.Fgets",
+		"long getline(char**, unsigned long*, FILE*) -> noarch.Getline",
+		"long getdelim(char**, unsigned long*, int, FILE*) -> noarch.Getdelim",
 		"void rewind(FILE*) -> noarch.Rewind",
 		"int feof(FILE*) -> noarch.Feof",
 		"char* tmpnam(char*) -> noarch.Tmpnam",
@@ -201,8 +577,16 @@ var builtInFunctionDefinitions = map[string][]string{
 		"int fsetpos(FILE*, int*) -> noarch.Fsetpos",
 		"int sprintf(char*, const char *, ...) -> noarch.Sprintf",
 		"int snprintf(char*, int, const char *, ...) -> noarch.Snprintf",
-		"int vsprintf(char*, const char *, ...) -> noarch.Vsprintf",
-		"int vsnprintf(char*, int, const char *, ...) -> noarch.Vsnprintf",
+		"int vsprintf(char*, const char *, va_list) -> noarch.Vsprintf",
+		"int vsnprintf(char*, int, const char *, va_list) -> noarch.Vsnprintf",
+		"int vprintf(const char *, va_list) -> noarch.Vprintf",
+		"int vfprintf(FILE*, const char *, va_list) -> noarch.Vfprintf",
+		"int dprintf(int, const char *, ...) -> noarch.Dprintf",
+		"int vdprintf(int, const char *, va_list) -> noarch.Vdprintf",
+		"int asprintf(char**, const char *, ...) -> noarch.Asprintf",
+		"int vasprintf(char**, const char *, va_list) -> noarch.Vasprintf",
+		"int fileno(FILE*) -> noarch.Fileno",
+		"FILE* fdopen(int, const char *) -> noarch.Fdopen",
 	},
 	"string.h": {
 		// string.h
@@ -222,27 +606,55 @@ var builtInFunctionDefinitions = map[string][]string{
 
 		"char * memset(char *, char, unsigned int) -> noarch.Memset",
 		"char * memmove(char *, char *, unsigned int) -> noarch.Memmove",
+
+		// BSD extensions
+		"unsigned long strlcpy(char*, const char*, unsigned long) -> noarch.Strlcpy",
+		"unsigned long strlcat(char*, const char*, unsigned long) -> noarch.Strlcat",
+		"char* strsep(char**, const char*) -> noarch.Strsep",
+	},
+	"strings.h": {
+		// strings.h
+		"void bzero(char*, unsigned long) -> noarch.Bzero",
+		"void bcopy(const char*, char*, unsigned long) -> noarch.Bcopy",
+		"char* index(const char*, int) -> noarch.Index",
+		"char* rindex(const char*, int) -> noarch.Rindex",
 	},
 	"stdlib.h": {
 		// stdlib.h
 		"int abs(int) -> noarch.Abs",
+		"void abort() -> linux.Abort",
 		"double atof(const char *) -> noarch.Atof",
 		"int atoi(const char*) -> noarch.Atoi",
 		"long int atol(const char*) -> noarch.Atol",
 		"long long int atoll(const char*) -> noarch.Atoll",
 		"div_t div(int, int) -> noarch.Div",
-		"void exit(int) -> os.Exit",
+		"void exit(int) -> noarch.Exit",
+		"void quick_exit(int) -> noarch.QuickExit",
+		"void _Exit(int) -> noarch.ExitImmediately",
 		"void free(void*) -> noarch.Free",
 		"char* getenv(const char *) -> noarch.Getenv",
+		"int setenv(const char *, const char *, int) -> noarch.Setenv",
+		"int unsetenv(const char *) -> noarch.Unsetenv",
+		"int putenv(char *) -> noarch.Putenv",
+		"int clearenv() -> noarch.Clearenv",
 		"long int labs(long int) -> noarch.Labs",
 		"ldiv_t ldiv(long int, long int) -> noarch.Ldiv",
 		"long long int llabs(long long int) -> noarch.Llabs",
 		"lldiv_t lldiv(long long int, long long int) -> noarch.Lldiv",
-		"int rand() -> math/rand.Int",
-		// The real definition is srand(unsigned int) however the type would be
-		// different. It's easier to change the definition than create a proxy
-		// function in stdlib.go.
-		"void srand(long long) -> math/rand.Seed",
+		"int rand() -> noarch.Rand",
+		"void srand(unsigned int) -> noarch.Srand",
+		"int rand_r(unsigned int *) -> noarch.RandR",
+		"long random() -> noarch.Random",
+		"void srandom(unsigned int) -> noarch.Srandom",
+		"double drand48() -> noarch.Drand48",
+		"double erand48(unsigned short *) -> noarch.Erand48",
+		"long lrand48() -> noarch.Lrand48",
+		"long nrand48(unsigned short *) -> noarch.Nrand48",
+		"long mrand48() -> noarch.Mrand48",
+		"long jrand48(unsigned short *) -> noarch.Jrand48",
+		"void srand48(long) -> noarch.Srand48",
+		"unsigned short * seed48(unsigned short *) -> noarch.Seed48",
+		"void lcong48(unsigned short *) -> noarch.Lcong48",
 		"double strtod(const char *, char **) -> noarch.Strtod",
 		"float strtof(const char *, char **) -> noarch.Strtof",
 		"long strtol(const char *, char **, int) -> noarch.Strtol",
@@ -252,6 +664,18 @@ var builtInFunctionDefinitions = map[string][]string{
 		"long long unsigned int strtoull(const char *, char **, int) -> noarch.Strtoull",
 		"void free(void*) -> _",
 	},
+	"locale.h": {
+		// noarch/locale.go
+		"char* setlocale(int, const char*) -> noarch.Setlocale",
+		"struct lconv* localeconv() -> noarch.Localeconv",
+	},
+	"inttypes.h": {
+		// inttypes.h
+		"intmax_t imaxabs(intmax_t) -> noarch.Imaxabs",
+		"imaxdiv_t imaxdiv(intmax_t, intmax_t) -> noarch.Imaxdiv",
+		"intmax_t strtoimax(const char *, char **, int) -> noarch.Strtoimax",
+		"uintmax_t strtoumax(const char *, char **, int) -> noarch.Strtoumax",
+	},
 	"time.h": {
 		// time.h
 		"time_t time(time_t *) -> noarch.Time",
@@ -260,12 +684,252 @@ var builtInFunctionDefinitions = map[string][]string{
 		"struct tm * gmtime(const time_t *) -> noarch.Gmtime",
 		"time_t mktime(struct tm *) -> noarch.Mktime",
 		"char * asctime(struct tm *) -> noarch.Asctime",
+		"clock_t clock() -> noarch.Clock",
+		"int nanosleep(const struct timespec*, struct timespec*) -> noarch.Nanosleep",
+	},
+	"sys/time.h": {
+		// sys/time.h
+		"int gettimeofday(struct timeval*, void*) -> noarch.Gettimeofday",
+	},
+	"unistd.h": {
+		// unistd.h
+		"void _exit(int) -> noarch.ExitImmediately",
+		"int getopt(int, char**, const char*) -> noarch.Getopt",
+		"int read(int, char*, int) -> noarch.Read",
+		"int write(int, char*, int) -> noarch.Write",
+		"int close(int) -> noarch.Close",
+		"long lseek(int, long, int) -> noarch.Lseek",
+		"int dup(int) -> noarch.Dup",
+		"int dup2(int, int) -> noarch.Dup2",
+		"int pipe(int*) -> noarch.Pipe",
+		"int rmdir(const char*) -> noarch.Rmdir",
+		"int unlink(const char*) -> noarch.Unlink",
+		"int symlink(const char*, const char*) -> noarch.Symlink",
+		"unsigned int sleep(unsigned int) -> noarch.Sleep",
+		"int usleep(unsigned int) -> noarch.Usleep",
+		"int isatty(int) -> noarch.Isatty",
+		"unsigned int getuid() -> noarch.Getuid",
+		"unsigned int geteuid() -> noarch.Geteuid",
+		"unsigned int getgid() -> noarch.Getgid",
+		"unsigned int getegid() -> noarch.Getegid",
+		"long sysconf(int) -> noarch.Sysconf",
+		"int gethostname(char*, unsigned long) -> noarch.Gethostname",
+		"int sethostname(const char*, unsigned long) -> noarch.Sethostname",
+	},
+	"sys/utsname.h": {
+		// sys/utsname.h
+		"int uname(struct utsname*) -> noarch.Uname",
+	},
+	"sys/resource.h": {
+		// sys/resource.h
+		"int getrusage(int, struct rusage*) -> noarch.Getrusage",
+	},
+	"sys/times.h": {
+		// sys/times.h
+		"clock_t times(struct tms*) -> noarch.Times",
+	},
+	"sys/stat.h": {
+		// sys/stat.h
+		"int stat(const char*, struct stat*) -> noarch.Stat",
+		"int lstat(const char*, struct stat*) -> noarch.Lstat",
+		"int fstat(int, struct stat*) -> noarch.Fstat",
+		"int mkdir(const char*, unsigned int) -> noarch.Mkdir",
+		"int chmod(const char*, unsigned int) -> noarch.Chmod",
+	},
+	"dirent.h": {
+		// dirent.h
+		"DIR* opendir(const char*) -> noarch.Opendir",
+		"struct dirent* readdir(DIR*) -> noarch.Readdir",
+		"int readdir_r(DIR*, struct dirent*, struct dirent**) -> noarch.ReaddirR",
+		"int closedir(DIR*) -> noarch.Closedir",
+		"void rewinddir(DIR*) -> noarch.Rewinddir",
+	},
+	"fnmatch.h": {
+		// fnmatch.h
+		"int fnmatch(const char*, const char*, int) -> noarch.Fnmatch",
+	},
+	"glob.h": {
+		// glob.h
+		// Function with argument of function type: glob is transpiled in
+		// package transpiler.
+		"void globfree(glob_t*) -> noarch.Globfree",
+	},
+	"search.h": {
+		// search.h
+		// Functions with argument of function type: tsearch, tfind,
+		// tdelete, twalk, lfind, lsearch are transpiled in package
+		// transpiler.
+		"int hcreate(unsigned long) -> noarch.Hcreate",
+		"ENTRY* hsearch(ENTRY, int) -> noarch.Hsearch",
+		"void hdestroy() -> noarch.Hdestroy",
+	},
+	"pwd.h": {
+		// pwd.h
+		"struct passwd* getpwnam(const char*) -> noarch.Getpwnam",
+		"struct passwd* getpwuid(unsigned int) -> noarch.Getpwuid",
+	},
+	"termios.h": {
+		// termios.h
+		"int tcgetattr(int, struct termios*) -> noarch.Tcgetattr",
+		"int tcsetattr(int, int, const struct termios*) -> noarch.Tcsetattr",
+		"void cfmakeraw(struct termios*) -> noarch.Cfmakeraw",
+	},
+	"sys/ioctl.h": {
+		// sys/ioctl.h
+		"int ioctl(int, unsigned long, ...) -> noarch.Ioctl",
+	},
+	"iconv.h": {
+		// iconv.h
+		"iconv_t iconv_open(const char*, const char*) -> noarch.IconvOpen",
+		"unsigned long iconv(iconv_t, char**, unsigned long*, char**, unsigned long*) -> noarch.Iconv",
+		"int iconv_close(iconv_t) -> noarch.IconvClose",
+	},
+	"syslog.h": {
+		// syslog.h
+		"void openlog(const char*, int, int) -> noarch.Openlog",
+		"void syslog(int, const char*, ...) -> noarch.Syslog",
+		"void vsyslog(int, const char*, va_list) -> noarch.Vsyslog",
+		"void closelog() -> noarch.Closelog",
+		"int setlogmask(int) -> noarch.Setlogmask",
+	},
+	"pthread.h": {
+		// pthread.h
+		// Functions with arguments of function type: pthread_create,
+		// pthread_once, pthread_key_create are transpiled in package
+		// transpiler.
+		"pthread_t pthread_self() -> noarch.PthreadSelf",
+		"int pthread_equal(pthread_t, pthread_t) -> noarch.PthreadEqual",
+		"void pthread_exit(void*) -> noarch.PthreadExit",
+		"int pthread_join(pthread_t, void**) -> noarch.PthreadJoin",
+		"int pthread_detach(pthread_t) -> noarch.PthreadDetach",
+		"int pthread_attr_init(pthread_attr_t*) -> noarch.PthreadAttrInit",
+		"int pthread_attr_destroy(pthread_attr_t*) -> noarch.PthreadAttrDestroy",
+		"int pthread_attr_setdetachstate(pthread_attr_t*, int) -> noarch.PthreadAttrSetdetachstate",
+		"int pthread_attr_getdetachstate(const pthread_attr_t*, int*) -> noarch.PthreadAttrGetdetachstate",
+		"int pthread_mutexattr_init(pthread_mutexattr_t*) -> noarch.PthreadMutexattrInit",
+		"int pthread_mutexattr_destroy(pthread_mutexattr_t*) -> noarch.PthreadMutexattrDestroy",
+		"int pthread_mutexattr_settype(pthread_mutexattr_t*, int) -> noarch.PthreadMutexattrSettype",
+		"int pthread_mutexattr_gettype(const pthread_mutexattr_t*, int*) -> noarch.PthreadMutexattrGettype",
+		"int pthread_mutex_init(pthread_mutex_t*, const pthread_mutexattr_t*) -> noarch.PthreadMutexInit",
+		"int pthread_mutex_destroy(pthread_mutex_t*) -> noarch.PthreadMutexDestroy",
+		"int pthread_mutex_lock(pthread_mutex_t*) -> noarch.PthreadMutexLock",
+		"int pthread_mutex_trylock(pthread_mutex_t*) -> noarch.PthreadMutexTrylock",
+		"int pthread_mutex_unlock(pthread_mutex_t*) -> noarch.PthreadMutexUnlock",
+		"int pthread_cond_init(pthread_cond_t*, const pthread_condattr_t*) -> noarch.PthreadCondInit",
+		"int pthread_cond_destroy(pthread_cond_t*) -> noarch.PthreadCondDestroy",
+		"int pthread_cond_wait(pthread_cond_t*, pthread_mutex_t*) -> noarch.PthreadCondWait",
+		"int pthread_cond_timedwait(pthread_cond_t*, pthread_mutex_t*, const struct timespec*) -> noarch.PthreadCondTimedwait",
+		"int pthread_cond_signal(pthread_cond_t*) -> noarch.PthreadCondSignal",
+		"int pthread_cond_broadcast(pthread_cond_t*) -> noarch.PthreadCondBroadcast",
+		"int pthread_key_delete(pthread_key_t) -> noarch.PthreadKeyDelete",
+		"void* pthread_getspecific(pthread_key_t) -> noarch.PthreadGetspecific",
+		"int pthread_setspecific(pthread_key_t, const void*) -> noarch.PthreadSetspecific",
+	},
+	"threads.h": {
+		// threads.h
+		// Functions with arguments of function type: thrd_create,
+		// call_once, tss_create are transpiled in package transpiler.
+		"thrd_t thrd_current() -> noarch.ThrdCurrent",
+		"int thrd_equal(thrd_t, thrd_t) -> noarch.ThrdEqual",
+		"int thrd_join(thrd_t, int*) -> noarch.ThrdJoin",
+		"int thrd_detach(thrd_t) -> noarch.ThrdDetach",
+		"void thrd_exit(int) -> noarch.ThrdExit",
+		"int thrd_sleep(const struct timespec*, struct timespec*) -> noarch.ThrdSleep",
+		"void thrd_yield() -> noarch.ThrdYield",
+		"int mtx_init(mtx_t*, int) -> noarch.MtxInit",
+		"int mtx_lock(mtx_t*) -> noarch.MtxLock",
+		"int mtx_timedlock(mtx_t*, const struct timespec*) -> noarch.MtxTimedlock",
+		"int mtx_trylock(mtx_t*) -> noarch.MtxTrylock",
+		"int mtx_unlock(mtx_t*) -> noarch.MtxUnlock",
+		"void mtx_destroy(mtx_t*) -> noarch.MtxDestroy",
+		"int cnd_init(cnd_t*) -> noarch.CndInit",
+		"int cnd_signal(cnd_t*) -> noarch.CndSignal",
+		"int cnd_broadcast(cnd_t*) -> noarch.CndBroadcast",
+		"int cnd_wait(cnd_t*, mtx_t*) -> noarch.CndWait",
+		"int cnd_timedwait(cnd_t*, mtx_t*, const struct timespec*) -> noarch.CndTimedwait",
+		"void cnd_destroy(cnd_t*) -> noarch.CndDestroy",
+		"void* tss_get(tss_t) -> noarch.TssGet",
+		"int tss_set(tss_t, void*) -> noarch.TssSet",
+		"void tss_delete(tss_t) -> noarch.TssDelete",
+	},
+	"sys/socket.h": {
+		// sys/socket.h
+		"int socket(int, int, int) -> noarch.Socket",
+		"int socketpair(int, int, int, int*) -> noarch.Socketpair",
+		"int bind(int, const struct sockaddr*, socklen_t) -> noarch.Bind",
+		"int listen(int, int) -> noarch.Listen",
+		"int accept(int, struct sockaddr*, socklen_t*) -> noarch.Accept",
+		"int connect(int, const struct sockaddr*, socklen_t) -> noarch.Connect",
+		"int getsockname(int, struct sockaddr*, socklen_t*) -> noarch.Getsockname",
+		"int send(int, char*, int, int) -> noarch.Send",
+		"int recv(int, char*, int, int) -> noarch.Recv",
+		"int shutdown(int, int) -> noarch.Shutdown",
+		"int setsockopt(int, int, int, const void*, socklen_t) -> noarch.Setsockopt",
+	},
+	"netinet/in.h": {
+		// netinet/in.h
+		"uint32_t htonl(uint32_t) -> noarch.Htonl",
+		"uint16_t htons(uint16_t) -> noarch.Htons",
+		"uint32_t ntohl(uint32_t) -> noarch.Ntohl",
+		"uint16_t ntohs(uint16_t) -> noarch.Ntohs",
+	},
+	"byteswap.h": {
+		// bits/byteswap.h is included by byteswap.h, endian.h, stdlib.h and
+		// netinet/in.h
+		"unsigned short __builtin_bswap16(unsigned short) -> noarch.Bswap16",
+		"unsigned int __builtin_bswap32(unsigned int) -> noarch.Bswap32",
+		"unsigned long long __builtin_bswap64(unsigned long long) -> noarch.Bswap64",
+	},
+	"arpa/inet.h": {
+		// arpa/inet.h
+		"int inet_pton(int, const char*, void*) -> noarch.InetPton",
+		"const char* inet_ntop(int, const void*, char*, socklen_t) -> noarch.InetNtop",
+		"in_addr_t inet_addr(const char*) -> noarch.InetAddr",
+		"char* inet_ntoa(struct in_addr) -> noarch.InetNtoa",
+	},
+	"netdb.h": {
+		// netdb.h
+		"int getaddrinfo(const char*, const char*, const struct addrinfo*, struct addrinfo**) -> noarch.Getaddrinfo",
+		"void freeaddrinfo(struct addrinfo*) -> noarch.Freeaddrinfo",
+		"const char* gai_strerror(int) -> noarch.GaiStrerror",
+		"struct hostent* gethostbyname(const char*) -> noarch.Gethostbyname",
+	},
+	"sys/select.h": {
+		// sys/select.h
+		"int select(int, fd_set*, fd_set*, fd_set*, struct timeval*) -> noarch.Select",
+	},
+	"regex.h": {
+		// regex.h
+		"int regcomp(regex_t*, const char*, int) -> noarch.Regcomp",
+		"int regexec(const regex_t*, const char*, size_t, regmatch_t*, int) -> noarch.Regexec",
+		"size_t regerror(int, const regex_t*, char*, size_t) -> noarch.Regerror",
+		"void regfree(regex_t*) -> noarch.Regfree",
+	},
+	"sys/mman.h": {
+		// sys/mman.h
+		"char* mmap(void*, size_t, int, int, int, long) -> noarch.Mmap",
+		"int munmap(void*, size_t) -> noarch.Munmap",
+		"int msync(void*, size_t, int) -> noarch.Msync",
+	},
+	"poll.h": {
+		// poll.h
+		"int poll(struct pollfd*, nfds_t, int) -> noarch.Poll",
+	},
+	"fcntl.h": {
+		// fcntl.h
+		"int open(const char*, int, ...) -> noarch.Open",
+	},
+	"getopt.h": {
+		// getopt.h
+		"int getopt(int, char**, const char*) -> noarch.Getopt",
+		"int getopt_long(int, char**, const char*, const struct option*, int*) -> noarch.GetoptLong",
+		"int getopt_long_only(int, char**, const char*, const struct option*, int*) -> noarch.GetoptLongOnly",
 	},
 }
 
 // GetIncludeFileNameByFunctionSignature - return name of C include header
 // in according to function name and type signature
-func (p *Program) GetIncludeFileNameByFunctionSignature(
+func (s *SymbolTable) GetIncludeFileNameByFunctionSignature(
 	functionName, cType string) (includeFileName string, err error) {
 
 	for k, functionList := range builtInFunctionDefinitions {
@@ -315,10 +979,10 @@ func (p *Program) GetIncludeFileNameByFunctionSignature(
 
 // GetFunctionDefinition will return nil if the function does not exist (is not
 // registered).
-func (p *Program) GetFunctionDefinition(functionName string) *FunctionDefinition {
-	p.loadFunctionDefinitions()
+func (s *SymbolTable) GetFunctionDefinition(functionName string) *FunctionDefinition {
+	s.loadFunctionDefinitions()
 
-	if f, ok := p.functionDefinitions[functionName]; ok {
+	if f, ok := s.functionDefinitions[functionName]; ok {
 		return &f
 	}
 
@@ -327,10 +991,10 @@ func (p *Program) GetFunctionDefinition(functionName string) *FunctionDefinition
 
 // AddFunctionDefinition registers a function definition. If the definition
 // already exists it will be replaced.
-func (p *Program) AddFunctionDefinition(f FunctionDefinition) {
-	p.loadFunctionDefinitions()
+func (s *SymbolTable) AddFunctionDefinition(f FunctionDefinition) {
+	s.loadFunctionDefinitions()
 
-	p.functionDefinitions[f.Name] = f
+	s.functionDefinitions[f.Name] = f
 }
 
 // dollarArgumentsToIntSlice converts a list of dollar arguments, like "$1, &2"
@@ -358,16 +1022,16 @@ func dollarArgumentsToIntSlice(s string) []int {
 	return r
 }
 
-func (p *Program) loadFunctionDefinitions() {
-	if p.builtInFunctionDefinitionsHaveBeenLoaded {
+func (s *SymbolTable) loadFunctionDefinitions() {
+	if s.builtInFunctionDefinitionsHaveBeenLoaded {
 		return
 	}
 
-	p.functionDefinitions = map[string]FunctionDefinition{}
-	p.builtInFunctionDefinitionsHaveBeenLoaded = true
+	s.functionDefinitions = map[string]FunctionDefinition{}
+	s.builtInFunctionDefinitionsHaveBeenLoaded = true
 
 	for k, v := range builtInFunctionDefinitions {
-		if !p.IncludeHeaderIsExists(k) {
+		if !s.includeHeaderIsExists(k) {
 			continue
 		}
 
@@ -408,7 +1072,7 @@ func (p *Program) loadFunctionDefinitions() {
 				substitution = "github.com/Konstantin8105/c4go/" + substitution
 			}
 
-			p.AddFunctionDefinition(FunctionDefinition{
+			s.AddFunctionDefinition(FunctionDefinition{
 				Name:             match[2],
 				ReturnType:       match[1],
 				ArgumentTypes:    argumentTypes,
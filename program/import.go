@@ -5,14 +5,36 @@ import (
 	"strings"
 )
 
+// Importer is the set of Go imports required for the transpiled code.
+type Importer interface {
+	Imports() []string
+	AddImport(importPath string)
+	AddImports(importPaths ...string)
+	AddBlankImport(importPath string)
+	ImportName(quotedImportPath string) string
+	ImportType(name string) string
+}
+
+// ImportSet contains all of the Go import paths required for the program.
+// ImportSet implements the interface Importer.
+type ImportSet struct {
+	imports []string
+	blank   map[string]bool
+}
+
+// NewImportSet creates a new empty set of imports.
+func NewImportSet() *ImportSet {
+	return &ImportSet{imports: []string{}, blank: map[string]bool{}}
+}
+
 // Imports returns all of the Go imports for this program.
-func (p *Program) Imports() []string {
+func (p *ImportSet) Imports() []string {
 	return p.imports
 }
 
 // AddImport will append an absolute import if it is unique to the list of
 // imports for this program.
-func (p *Program) AddImport(importPath string) {
+func (p *ImportSet) AddImport(importPath string) {
 	quotedImportPath := strconv.Quote(importPath)
 
 	if len(importPath) == 0 {
@@ -30,12 +52,28 @@ func (p *Program) AddImport(importPath string) {
 }
 
 // AddImports is a convienience method for adding multiple imports.
-func (p *Program) AddImports(importPaths ...string) {
+func (p *ImportSet) AddImports(importPaths ...string) {
 	for _, importPath := range importPaths {
 		p.AddImport(importPath)
 	}
 }
 
+// AddBlankImport will append an import, which is imported only for its side
+// effects, for example package "embed" for the directive `//go:embed`.
+func (p *ImportSet) AddBlankImport(importPath string) {
+	p.AddImport(importPath)
+	p.blank[strconv.Quote(importPath)] = true
+}
+
+// ImportName returns the name of import, it is "_" for blank imports and
+// empty for other imports.
+func (p *ImportSet) ImportName(quotedImportPath string) string {
+	if p.blank[quotedImportPath] {
+		return "_"
+	}
+	return ""
+}
+
 // ImportType imports a package for a fully qualified type and returns the local
 // type name. For example:
 //
@@ -43,7 +81,7 @@ func (p *Program) AddImports(importPaths ...string) {
 //
 // Will import "github.com/Konstantin8105/c4go/darwin" and return (value of t)
 // "darwin.CtRuneT".
-func (p *Program) ImportType(name string) string {
+func (p *ImportSet) ImportType(name string) string {
 	if strings.Contains(name, ".") {
 		parts := strings.Split(name, ".")
 		p.AddImport(strings.Join(parts[:len(parts)-1], "."))
@@ -0,0 +1,21 @@
+package program
+
+import (
+	goast "go/ast"
+
+	"github.com/Konstantin8105/c4go/preprocessor"
+)
+
+// MacroFunction - Go function of the function-like macro of the user source.
+// Expressions of macro are transpiled as calls of function. See option
+// "-macro-func".
+type MacroFunction struct {
+	Macro preprocessor.FunctionMacro
+
+	// Types - Go types of parameters and result of function in order of
+	// first call. Function with several types is generic.
+	Types []string
+
+	// Calls - calls of function in the Go code with Go types of calls
+	Calls map[*goast.CallExpr]string
+}
@@ -0,0 +1,54 @@
+package program
+
+// PatternKind is the kind of Go implementation of C data structure.
+type PatternKind string
+
+// Kinds of Go implementations of C data structures.
+const (
+	// PatternMap - hash table is implemented by Go map.
+	PatternMap PatternKind = "map"
+
+	// PatternList - linked list is implemented by container/list.
+	PatternList PatternKind = "list"
+)
+
+// PatternRoles - roles of functions of API for each kind of data structure.
+var PatternRoles = map[PatternKind][]string{
+	PatternMap: {"new", "init", "clear", "free",
+		"put", "get", "has", "remove", "len"},
+	PatternList: {"new", "init", "clear", "free",
+		"push_back", "push_front", "pop_front", "pop_back",
+		"front", "back", "get", "len"},
+}
+
+// Pattern is the hand-rolled C data structure, which is replaced by the Go
+// implementation behind the original API. See option "-pattern".
+type Pattern struct {
+	// Kind of Go implementation.
+	Kind PatternKind
+
+	// Name of C struct without prefix "struct ", like "table".
+	Name string
+
+	// Key is the C type of keys of hash table, like "char *".
+	Key string
+
+	// Value is the C type of values, like "int".
+	Value string
+
+	// Functions - a map of functions of API, where key is name of C
+	// function and value is role of function, like "put" or "len".
+	Functions map[string]string
+}
+
+// PatternFunction returns the data structure and the role of function of
+// API for the name of C function.
+func (p *Program) PatternFunction(name string) (
+	pattern *Pattern, role string, ok bool) {
+	for _, pattern = range p.Patterns {
+		if role, ok = pattern.Functions[name]; ok {
+			return
+		}
+	}
+	return nil, "", false
+}
@@ -17,86 +17,141 @@ import (
 	"github.com/Konstantin8105/c4go/util"
 )
 
-// StructRegistry is a map of Struct for struct types and union type
-type StructRegistry map[string]*Struct
-
-// HasType method check if type exists
-func (sr StructRegistry) HasType(typename string) bool {
-	_, exists := sr[typename]
-
-	return exists
-}
-
 // Program contains all of the input, output and transpition state of a C
 // program to a Go program.
+//
+// The state of transpilation is split into phase artifacts, which are
+// embedded into Program, so the methods and fields of artifacts are
+// available directly:
+//
+//     ImportSet    - Go imports, see interface Importer;
+//     TypeRegistry - C structs, unions, typedefs and enums, see interface
+//                    TypeResolver;
+//     SymbolTable  - functions, global variables and generated identifiers,
+//                    see interface SymbolResolver;
+//     MessageLog   - warnings and errors, see interface Messenger.
 type Program struct {
-	// All of the Go import paths required for this program.
-	imports []string
+	*ImportSet
+	*TypeRegistry
+	*SymbolTable
+	*MessageLog
 
 	// These are for the output Go AST.
 	FileSet *token.FileSet
 	File    *goast.File
 
-	// One a type is defined it will be ignored if a future type of the same
-	// name appears.
-	typesAlreadyDefined []string
-
 	// Contains the current function name during the transpilation.
 	Function *ast.FunctionDecl
 
-	functionDefinitions                      map[string]FunctionDefinition
-	builtInFunctionDefinitionsHaveBeenLoaded bool
-
 	// These are used to setup the runtime before the application begins. An
 	// example would be to setup globals with stdin file pointers on certain
 	// platforms.
 	startupStatements []goast.Stmt
 
-	// This is used to generate globally unique names for temporary variables
-	// and other generated code. See GetNextIdentifier().
-	nextUniqueIdentifier int
-
-	// The definitions for defined structs.
-	// TODO: This field should be protected through proper getters and setters.
-	Structs StructRegistry
-	Unions  StructRegistry
-
 	// If verbose is on progress messages will be printed immediately as code
 	// comments (so that they do not interfere with the program output).
 	Verbose bool
 
-	// Contains the messages (for example, "// Warning") generated when
-	// transpiling the AST. These messages, which are code comments, are
-	// appended to the very top of the output file. See AddMessage().
-	messages []string
-
-	// messagePosition - position of slice messages, added like a comment
-	// in output Go code
-	messagePosition int
-
-	// A map of all the global variables (variables that exist outside of a
-	// function) and their types.
-	GlobalVariables map[string]string
-
 	// This option is not available through the command line. It is to allow the
 	// internal integration testing to generate the output in the form of a
 	// Go-test rather than a standalone Go file.
 	OutputAsTest bool
 
-	// EnumConstantToEnum - a map with key="EnumConstant" and value="enum type"
-	// clang don`t show enum constant with enum type,
-	// so we have to use hack for repair the type
-	EnumConstantToEnum map[string]string
-
-	// EnumTypedefName - a map with key="Name of typedef enum" and
-	// value="exist ot not"
-	EnumTypedefName map[string]bool
-
-	// TypedefType - map for type alias, for example:
-	// C  : typedef int INT;
-	// Map: key = INT, value = int
-	// Important: key and value are C types
-	TypedefType map[string]string
+	// PreserveOrder - if true, then Go declarations are kept in order of
+	// the original C source files.
+	PreserveOrder bool
+
+	// GuardedVariables - a map of names of global variables, access to
+	// which is guarded by the reentrant mutex for using the transpiled code
+	// from several goroutines. See option "-guard".
+	GuardedVariables map[string]bool
+
+	// UnsafeByteCast - if true, then the cast of byte buffer to pointer of
+	// struct is the view of buffer by package unsafe without copy, otherwise
+	// the buffer is decoded in according to C layout of struct. See option
+	// "-byte-cast".
+	UnsafeByteCast bool
+
+	// ByteCasts - a map of Go declarations of functions for cast of byte
+	// buffers, where key is name of function
+	ByteCasts map[string][]goast.Decl
+
+	// Replacements - a map of C functions, calls of which are replaced by
+	// calls of Go functions of project, where key is name of C function.
+	// See option "-replace".
+	Replacements map[string]Replacement
+
+	// RefCounting - manual reference counting of C code, which is removed
+	// from Go code. See option "-refcount".
+	RefCounting RefCounting
+
+	// Patterns - a map of C data structures, which are replaced by Go
+	// implementations, where key is name of C struct. See option "-pattern".
+	Patterns map[string]*Pattern
+
+	// ExitHandlers - if true, then functions are registered by atexit() in
+	// the C code, so return from main() is transpiled as call of exit() for
+	// calling of these functions.
+	ExitHandlers bool
+
+	// Threads - if true, then threads are created by pthread_create() or
+	// thrd_create() in the C code, so each thread has own value of variables
+	// with storage class thread_local. Otherwise these variables are plain
+	// global variables.
+	Threads bool
+
+	// PooledBuffers - a map of local variables of current recursive
+	// function, where key is address of variable declaration. Buffers of
+	// char allocated by alloca() or as variable length arrays for these
+	// variables are taken from the pool of package noarch for decreasing of
+	// garbage in deep recursion.
+	PooledBuffers map[ast.Address]bool
+
+	// LabelAddresses - numbers of labels of current function, which
+	// addresses are taken by operator `&&` of GNU C. Addresses of labels are
+	// the numbers in Go and computed goto is the switch on number.
+	LabelAddresses map[string]int
+
+	// StaticDecls - Go declarations of package-level variables for static
+	// local variables of current function. Declarations are placed after
+	// the function.
+	StaticDecls []goast.Decl
+
+	// NestedFunctions - stack of C return types of nested functions of GNU
+	// C, which are transpiled as Go closures. Statements `return` inside
+	// closures use the return type of the last nested function.
+	NestedFunctions []string
+
+	// FunctionMacros - if true, then expressions of simple function-like
+	// macros of the user source are transpiled as calls of Go functions.
+	// See option "-macro-func".
+	FunctionMacros bool
+
+	// MacroFunctions - a map of Go functions of function-like macros, where
+	// key is name of macro
+	MacroFunctions map[string]*MacroFunction
+
+	// UnsignedChar - if true, then type char is unsigned as by flag
+	// "-funsigned-char" of clang, otherwise type char is signed and values
+	// of char greater than 127 are negative after conversion to int.
+	UnsignedChar bool
+
+	// GoVersion - minor version of Go 1.x, features of which may be used in
+	// the Go code, or zero for the code compatible with old versions of Go.
+	// See option "-golang".
+	GoVersion int
+
+	// OutputDir - directory of the Go code. Files of directives `#embed`
+	// inside of the directory are embedded by the directive `//go:embed`.
+	OutputDir string
+
+	// BuildConstraint - lines of build constraint, which are written at the
+	// top of Go code. See option "-build-tag".
+	BuildConstraint string
+
+	// License - comments with licenses of C code and the provenance note,
+	// which are written at the top of Go code. See option "-license".
+	License string
 
 	// commentLine - a map with:
 	// key    - filename
@@ -109,101 +164,32 @@ type Program struct {
 
 // NewProgram creates a new blank program.
 func NewProgram() (p *Program) {
-	defer func() {
-		// Need for "stdbool.h"
-		p.TypedefType["_Bool"] = "int"
-	}()
-	return &Program{
-		imports:             []string{},
-		typesAlreadyDefined: []string{},
-		startupStatements:   []goast.Stmt{},
-		Structs: StructRegistry(map[string]*Struct{
-			// Structs without implementations inside system C headers
-			// Example node for adding:
-			// &ast.TypedefDecl{ ... Type:"struct __locale_struct *" ... }
-
-			"struct __va_list_tag [1]": {
-				Name: "struct __va_list_tag [1]",
-				Type: StructType,
-			},
-
-			// Pos:ast.Position{File:"/usr/include/xlocale.h", Line:27
-			"struct __locale_struct *": {
-				Name: "struct __locale_struct *",
-				Type: StructType,
-			},
-
-			// Pos:ast.Position{File:"/usr/include/x86_64-linux-gnu/sys/time.h", Line:61
-			"struct timezone *__restrict": {
-				Name: "struct timezone *__restrict",
-				Type: StructType,
-			},
-		}),
-		Unions:                                   make(StructRegistry),
-		Verbose:                                  false,
-		messages:                                 []string{},
-		GlobalVariables:                          map[string]string{},
-		EnumConstantToEnum:                       map[string]string{},
-		EnumTypedefName:                          map[string]bool{},
-		TypedefType:                              map[string]string{},
-		commentLine:                              map[string]int{},
-		functionDefinitions:                      map[string]FunctionDefinition{},
-		builtInFunctionDefinitionsHaveBeenLoaded: false,
+	p = &Program{
+		ImportSet:         NewImportSet(),
+		TypeRegistry:      NewTypeRegistry(),
+		MessageLog:        NewMessageLog(),
+		startupStatements: []goast.Stmt{},
+		Verbose:           false,
+		PooledBuffers:     map[ast.Address]bool{},
+		LabelAddresses:    map[string]int{},
+		GuardedVariables:  map[string]bool{},
+		Replacements:      map[string]Replacement{},
+		Patterns:          map[string]*Pattern{},
+		ByteCasts:         map[string][]goast.Decl{},
+		MacroFunctions:    map[string]*MacroFunction{},
+		commentLine:       map[string]int{},
 	}
+	p.SymbolTable = NewSymbolTable(p.IncludeHeaderIsExists)
+	return p
 }
 
-// AddMessage adds a message (such as a warning or error) comment to the output
-// file. Usually the message is generated from one of the Generate functions in
-// the ast package.
-//
-// It is expected that the message already have the comment ("//") prefix.
-//
-// The message will not be appended if it is blank. This is because the Generate
-// functions return a blank string conditionally when there is no error.
-//
-// The return value will be true if a message was added, otherwise false.
-func (p *Program) AddMessage(message string) bool {
-	if message == "" {
-		return false
-	}
-
-	p.messages = append(p.messages, message)
-
-	// Compactizarion warnings stack
-	if len(p.messages) > 1 {
-		var (
-			new  = len(p.messages) - 1
-			last = len(p.messages) - 2
-		)
-		// Warning collapsing for minimaze warnings
-		warning := "// Warning"
-		if strings.HasPrefix(p.messages[last], warning) {
-			l := p.messages[last][len(warning):]
-			if strings.HasSuffix(p.messages[new], l) {
-				p.messages[last] = p.messages[new]
-				p.messages = p.messages[0:new]
-			}
-		}
-	}
-
-	return true
-}
-
-// GetMessageComments - get messages "Warnings", "Error" like a comment
-// Location of comments only NEAR of error or warning and
-// don't show directly location
-func (p *Program) GetMessageComments() (_ *goast.CommentGroup) {
-	var group goast.CommentGroup
-	if p.messagePosition < len(p.messages) {
-		for i := p.messagePosition; i < len(p.messages); i++ {
-			group.List = append(group.List, &goast.Comment{
-				Text: p.messages[i],
-			})
-		}
-		p.messagePosition = len(p.messages)
-	}
-	return &group
-}
+// Program implements the interfaces of all phase artifacts.
+var (
+	_ Importer       = (*Program)(nil)
+	_ TypeResolver   = (*Program)(nil)
+	_ SymbolResolver = (*Program)(nil)
+	_ Messenger      = (*Program)(nil)
+)
 
 // GetComments - return comments
 func (p *Program) GetComments(n ast.Position) (out []*goast.Comment) {
@@ -233,92 +219,10 @@ func (p *Program) GetComments(n ast.Position) (out []*goast.Comment) {
 	return
 }
 
-// GetStruct returns a struct object (representing struct type or union type) or
-// nil if doesn't exist. This method can get struct or union in the same way and
-// distinguish only by the IsUnion field. `name` argument is the C like
-// `struct a_struct`, it allow pointer type like `union a_union *`. Pointer
-// types used in a DeclRefExpr in the case a deferenced structure by using `->`
-// operator to access to a field like this: a_struct->member .
-//
-// This method is used in collaboration with the field
-// "c4go/program".*Struct.IsUnion to simplify the code like in function
-// "c4go/transpiler".transpileMemberExpr() where the same *Struct value returned
-// by this method is used in the 2 cases, in the case where the value has a
-// struct type and in the case where the value has an union type.
-func (p *Program) GetStruct(name string) *Struct {
-	if name == "" {
-		return nil
-	}
-
-	last := len(name) - 1
-
-	// That allow to get struct from pointer type
-	if name[last] == '*' {
-		name = name[:last]
-	}
-
-	name = strings.TrimSpace(name)
-
-	res, ok := p.Structs[name]
-	if ok {
-		return res
-	}
-
-	return p.Unions[name]
-}
-
-// IsTypeAlreadyDefined will return true if the typeName has already been
-// defined.
-//
-// A type could be defined:
-//
-// 1. Initially. That is, before the transpilation starts (hard-coded).
-// 2. By calling DefineType throughout the transpilation.
-func (p *Program) IsTypeAlreadyDefined(typeName string) bool {
-	return util.InStrings(typeName, p.typesAlreadyDefined)
-}
-
-// DefineType will record a type as having already been defined. The purpose for
-// this is to not generate Go for a type more than once. C allows variables and
-// other entities (such as function prototypes) to be defined more than once in
-// some cases. An example of this would be static variables or functions.
-func (p *Program) DefineType(typeName string) {
-	p.typesAlreadyDefined = append(p.typesAlreadyDefined, typeName)
-}
-
-// UndefineType undefine defined type
-func (p *Program) UndefineType(typeName string) {
-check_again:
-	for i := range p.typesAlreadyDefined {
-		if typeName == p.typesAlreadyDefined[i] {
-			if len(p.typesAlreadyDefined) == 1 {
-				p.typesAlreadyDefined = make([]string, 0)
-			} else if i == len(p.typesAlreadyDefined)-1 {
-				p.typesAlreadyDefined = p.typesAlreadyDefined[:len(p.typesAlreadyDefined)-1]
-			} else {
-				p.typesAlreadyDefined = append(
-					p.typesAlreadyDefined[:i],
-					p.typesAlreadyDefined[i+1:]...)
-			}
-			goto check_again
-		}
-	}
-}
-
-// GetNextIdentifier generates a new globally unique identifier name. This can
-// be used for variables and functions in generated code.
-//
-// The value of prefix is only useful for readability in the code. If the prefix
-// is an empty string then the prefix "__temp" will be used.
-func (p *Program) GetNextIdentifier(prefix string) string {
-	if prefix == "" {
-		prefix = "temp"
-	}
-
-	identifierName := fmt.Sprintf("%s%d", prefix, p.nextUniqueIdentifier)
-	p.nextUniqueIdentifier++
-
-	return identifierName
+// IsGoVersion returns true, if features of Go 1.minor may be used in the Go
+// code.
+func (p *Program) IsGoVersion(minor int) bool {
+	return p.GoVersion >= minor
 }
 
 // String generates the whole output Go file as a string. This will include the
@@ -326,6 +230,11 @@ func (p *Program) GetNextIdentifier(prefix string) string {
 func (p *Program) String() string {
 	var buf bytes.Buffer
 
+	// build constraint must be before other comments
+	if p.BuildConstraint != "" {
+		buf.WriteString(p.BuildConstraint + "\n\n")
+	}
+	buf.WriteString(p.License)
 	buf.WriteString(fmt.Sprintf(`/*
 	Package main - transpiled by c4go
 
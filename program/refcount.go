@@ -0,0 +1,39 @@
+package program
+
+// RefCountKind is the kind of function of reference counting.
+type RefCountKind string
+
+// Kinds of functions of reference counting.
+const (
+	// RefCountInc - function increments the reference counter and returns
+	// the object, like "obj_ref".
+	RefCountInc RefCountKind = "inc"
+
+	// RefCountDec - function decrements the reference counter and calls the
+	// destructor for the last reference, like "obj_unref".
+	RefCountDec RefCountKind = "dec"
+
+	// RefCountDtor - destructor of object, like "obj_free".
+	RefCountDtor RefCountKind = "dtor"
+)
+
+// RefCounting is the manual reference counting of C code, which is removed
+// from the Go code, because the memory is managed by garbage collector. See
+// option "-refcount".
+type RefCounting struct {
+	// Functions - a map of functions of reference counting, where key is
+	// name of C function.
+	Functions map[string]RefCountKind
+
+	// Fields - names of fields of structs with reference counters.
+	Fields map[string]bool
+
+	// Removed - the report of removed sites of reference counting in
+	// format "file:line: description".
+	Removed []string
+}
+
+// IsEnabled returns true, if the reference counting is removed.
+func (r RefCounting) IsEnabled() bool {
+	return len(r.Functions) > 0 || len(r.Fields) > 0
+}
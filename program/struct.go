@@ -26,6 +26,9 @@ type Struct struct {
 	// Each of the fields and their C type. The field may be a string or an
 	// instance of Struct for nested structures.
 	Fields map[string]interface{}
+
+	// Names of fields in order of declaration.
+	FieldNames []string
 }
 
 // NewStruct creates a new Struct definition from an ast.RecordDecl.
@@ -37,11 +40,13 @@ func NewStruct(n *ast.RecordDecl) (_ *Struct, err error) {
 		}
 	}()
 	fields := make(map[string]interface{})
+	var names []string
 
 	for _, field := range n.Children() {
 		switch f := field.(type) {
 		case *ast.FieldDecl:
 			fields[f.Name] = f.Type
+			names = append(names, f.Name)
 
 		case *ast.IndirectFieldDecl:
 			fields[f.Name] = f.Type
@@ -80,28 +85,29 @@ func NewStruct(n *ast.RecordDecl) (_ *Struct, err error) {
 	}
 
 	return &Struct{
-		Name:   n.Name,
-		Type:   t,
-		Fields: fields,
+		Name:       n.Name,
+		Type:       t,
+		Fields:     fields,
+		FieldNames: names,
 	}, nil
 }
 
 // IsUnion - return true if the cType is 'union' or
 // typedef of union
-func (p *Program) IsUnion(cType string) bool {
+func (r *TypeRegistry) IsUnion(cType string) bool {
 	if strings.HasPrefix(cType, "union ") {
 		return true
 	}
-	if _, ok := p.Unions[cType]; ok {
+	if _, ok := r.Unions[cType]; ok {
 		return true
 	}
-	if _, ok := p.Unions["union "+cType]; ok {
+	if _, ok := r.Unions["union "+cType]; ok {
 		return true
 	}
-	if _, ok := p.GetBaseTypeOfTypedef("union " + cType); ok {
+	if _, ok := r.GetBaseTypeOfTypedef("union " + cType); ok {
 		return true
 	}
-	if t, ok := p.GetBaseTypeOfTypedef(cType); ok {
+	if t, ok := r.GetBaseTypeOfTypedef(cType); ok {
 		if t == cType {
 			panic(fmt.Errorf("Cannot be same name: %s", t))
 		}
@@ -111,16 +117,16 @@ func (p *Program) IsUnion(cType string) bool {
 		if t == "" {
 			panic(fmt.Errorf("Type cannot be empty"))
 		}
-		return p.IsUnion(t)
+		return r.IsUnion(t)
 	}
 	return false
 }
 
 // GetBaseTypeOfTypedef - return typedef type
-func (p *Program) GetBaseTypeOfTypedef(cTypedef string) (
+func (r *TypeRegistry) GetBaseTypeOfTypedef(cTypedef string) (
 	cBase string, ok bool) {
 
-	cBase, ok = p.TypedefType[cTypedef]
+	cBase, ok = r.TypedefType[cTypedef]
 	if cBase == "" && ok {
 		panic(fmt.Errorf("Type cannot be empty"))
 	}
@@ -0,0 +1,75 @@
+package program
+
+import (
+	"fmt"
+
+	"github.com/Konstantin8105/c4go/ast"
+)
+
+// SymbolResolver resolves the global symbols of the program.
+type SymbolResolver interface {
+	GetFunctionDefinition(functionName string) *FunctionDefinition
+	AddFunctionDefinition(f FunctionDefinition)
+	GetVariableSubstitution(name string) string
+	GetNextIdentifier(prefix string) string
+}
+
+// SymbolTable contains the global symbols of the program: functions, global
+// variables and generated identifiers. SymbolTable implements the interface
+// SymbolResolver.
+type SymbolTable struct {
+	// A map of all the global variables (variables that exist outside of a
+	// function) and their types.
+	GlobalVariables map[string]string
+
+	// ThreadLocalVariables - a map of variables with storage class
+	// thread_local, where key is address of variable declaration and
+	// value is Go type of variable
+	ThreadLocalVariables map[ast.Address]string
+
+	// StaticVariables - a map of static local variables, which are
+	// package-level variables in Go, where key is address of variable
+	// declaration and value is unique name of package-level variable
+	StaticVariables map[ast.Address]string
+
+	functionDefinitions                      map[string]FunctionDefinition
+	builtInFunctionDefinitionsHaveBeenLoaded bool
+
+	// includeHeaderIsExists returns true, if C include header is used by
+	// the program. Function definitions and variables of header are
+	// available only in that case.
+	includeHeaderIsExists func(includeHeader string) bool
+
+	// This is used to generate globally unique names for temporary variables
+	// and other generated code. See GetNextIdentifier().
+	nextUniqueIdentifier int
+}
+
+// NewSymbolTable creates a new empty symbol table. Function includeHeaderIsExists
+// is used for loading of symbols of C include headers.
+func NewSymbolTable(includeHeaderIsExists func(string) bool) *SymbolTable {
+	return &SymbolTable{
+		GlobalVariables:                          map[string]string{},
+		ThreadLocalVariables:                     map[ast.Address]string{},
+		StaticVariables:                          map[ast.Address]string{},
+		functionDefinitions:                      map[string]FunctionDefinition{},
+		builtInFunctionDefinitionsHaveBeenLoaded: false,
+		includeHeaderIsExists:                    includeHeaderIsExists,
+	}
+}
+
+// GetNextIdentifier generates a new globally unique identifier name. This can
+// be used for variables and functions in generated code.
+//
+// The value of prefix is only useful for readability in the code. If the prefix
+// is an empty string then the prefix "__temp" will be used.
+func (s *SymbolTable) GetNextIdentifier(prefix string) string {
+	if prefix == "" {
+		prefix = "temp"
+	}
+
+	identifierName := fmt.Sprintf("%s%d", prefix, s.nextUniqueIdentifier)
+	s.nextUniqueIdentifier++
+
+	return identifierName
+}
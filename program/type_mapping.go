@@ -0,0 +1,254 @@
+package program
+
+import "strings"
+
+// builtInTypeMappings - conversion map from simple C types to Go types, which
+// have an exact Go equivalent.
+//
+// TODO: Some of these are based on assumptions that may not be true for all
+// architectures (like the size of an int). At some point in the future we will
+// need to find out the sizes of some of there and pick the most compatible
+// type.
+//
+// Please keep them sorted by name.
+var builtInTypeMappings = map[string]string{
+	"bool":                   "bool",
+	"char *":                 "[]byte",
+	"char":                   "byte",
+	"char*":                  "[]byte",
+	"double":                 "float64",
+	"float":                  "float32",
+	"int":                    "int",
+	"long double":            "float64",
+	"long int":               "int32",
+	"long long":              "int64",
+	"long long int":          "int64",
+	"long long unsigned int": "uint64",
+	"long unsigned int":      "uint32",
+	"long":                   "int32",
+	"short":                  "int16",
+	"signed char":            "int8",
+	"unsigned char":          "uint8",
+	"unsigned int":           "uint32",
+	"unsigned long long":     "uint64",
+	"unsigned long":          "uint32",
+	"unsigned short":         "uint16",
+	"unsigned short int":     "uint16",
+	"void":                   "",
+	"_Bool":                  "bool",
+
+	// complex.h
+	"_Complex float":       "complex64",
+	"_Complex double":      "complex128",
+	"_Complex long double": "complex128",
+
+	// void*
+	"void*":  "interface{}",
+	"void *": "interface{}",
+
+	// type of `nullptr` of C23
+	"nullptr_t": "interface{}",
+
+	// null is a special case (it should probably have a less ambiguos name)
+	// when using the NULL macro.
+	"null": "null",
+
+	// Non platform-specific types.
+	"uint32":     "uint32",
+	"uint64":     "uint64",
+	"__uint16_t": "uint16",
+	"__uint32_t": "uint32",
+	"__uint64_t": "uint64",
+	"__int64_t":  "int64",
+
+	// inttypes.h
+	"intmax_t":  "int64",
+	"uintmax_t": "uint64",
+
+	// sys/types.h
+	"uid_t":   "uint32",
+	"gid_t":   "uint32",
+	"__uid_t": "uint32",
+	"__gid_t": "uint32",
+
+	// search.h
+	"ACTION": "int",
+	"VISIT":  "int",
+
+	// termios.h
+	"tcflag_t": "uint32",
+	"cc_t":     "byte",
+	"speed_t":  "uint32",
+
+	// These are special cases that almost certainly don't work. I've put
+	// them here because for whatever reason there is no suitable type or we
+	// don't need these platform specific things to be implemented yet.
+	"unsigned __int128":  "uint64",
+	"__int128":           "int64",
+	"__mbstate_t":        "int64",
+	"__fd_mask":          "int32",
+	"__sbuf":             "int64",
+	"__sFILEX":           "interface{}",
+	"FILE":               "github.com/Konstantin8105/c4go/noarch.File",
+	"DIR":                "github.com/Konstantin8105/c4go/noarch.Dir",
+	"struct __dirstream": "github.com/Konstantin8105/c4go/noarch.Dir",
+}
+
+// builtInStdStructs - conversion map from C standart library structures to
+// c4go structures
+var builtInStdStructs = map[string]string{
+	"div_t":   "github.com/Konstantin8105/c4go/noarch.DivT",
+	"ldiv_t":  "github.com/Konstantin8105/c4go/noarch.LdivT",
+	"lldiv_t": "github.com/Konstantin8105/c4go/noarch.LldivT",
+
+	// inttypes.h
+	"imaxdiv_t": "github.com/Konstantin8105/c4go/noarch.ImaxdivT",
+
+	// time.h
+	"tm":        "github.com/Konstantin8105/c4go/noarch.Tm",
+	"struct tm": "github.com/Konstantin8105/c4go/noarch.Tm",
+	"time_t":    "github.com/Konstantin8105/c4go/noarch.TimeT",
+	"clock_t":   "github.com/Konstantin8105/c4go/noarch.ClockT",
+	"__clock_t": "github.com/Konstantin8105/c4go/noarch.ClockT",
+
+	"fpos_t": "int",
+
+	// fenv.h
+	"fenv_t":    "github.com/Konstantin8105/c4go/noarch.FenvT",
+	"fexcept_t": "github.com/Konstantin8105/c4go/noarch.FexceptT",
+
+	// getopt.h
+	"struct option": "github.com/Konstantin8105/c4go/noarch.Option",
+
+	// dirent.h
+	"struct dirent": "github.com/Konstantin8105/c4go/noarch.Dirent",
+
+	// ftw.h
+	"struct FTW": "github.com/Konstantin8105/c4go/noarch.FTW",
+
+	// glob.h
+	"glob_t": "github.com/Konstantin8105/c4go/noarch.GlobT",
+
+	// iconv.h
+	"iconv_t": "github.com/Konstantin8105/c4go/noarch.IconvT",
+
+	// search.h
+	"ENTRY":        "github.com/Konstantin8105/c4go/noarch.Entry",
+	"struct entry": "github.com/Konstantin8105/c4go/noarch.Entry",
+
+	// pwd.h
+	"struct passwd": "github.com/Konstantin8105/c4go/noarch.Passwd",
+
+	// sys/stat.h
+	"struct stat":     "github.com/Konstantin8105/c4go/noarch.StatT",
+	"struct timespec": "github.com/Konstantin8105/c4go/noarch.Timespec",
+
+	// pthread.h
+	"pthread_t":            "github.com/Konstantin8105/c4go/noarch.PthreadT",
+	"pthread_attr_t":       "github.com/Konstantin8105/c4go/noarch.PthreadAttrT",
+	"union pthread_attr_t": "github.com/Konstantin8105/c4go/noarch.PthreadAttrT",
+	"pthread_mutex_t":      "github.com/Konstantin8105/c4go/noarch.PthreadMutexT",
+	"pthread_mutexattr_t":  "github.com/Konstantin8105/c4go/noarch.PthreadMutexattrT",
+	"pthread_cond_t":       "github.com/Konstantin8105/c4go/noarch.PthreadCondT",
+	"pthread_condattr_t":   "github.com/Konstantin8105/c4go/noarch.PthreadCondattrT",
+	"pthread_once_t":       "github.com/Konstantin8105/c4go/noarch.PthreadOnceT",
+	"pthread_key_t":        "github.com/Konstantin8105/c4go/noarch.PthreadKeyT",
+
+	// threads.h
+	"thrd_t":    "github.com/Konstantin8105/c4go/noarch.ThrdT",
+	"mtx_t":     "github.com/Konstantin8105/c4go/noarch.MtxT",
+	"cnd_t":     "github.com/Konstantin8105/c4go/noarch.CndT",
+	"once_flag": "github.com/Konstantin8105/c4go/noarch.OnceFlag",
+	"tss_t":     "github.com/Konstantin8105/c4go/noarch.TssT",
+
+	// sys/socket.h, netinet/in.h, netdb.h
+	"struct sockaddr":         "github.com/Konstantin8105/c4go/noarch.Sockaddr",
+	"struct sockaddr_in":      "github.com/Konstantin8105/c4go/noarch.Sockaddr",
+	"struct sockaddr_in6":     "github.com/Konstantin8105/c4go/noarch.Sockaddr",
+	"struct sockaddr_storage": "github.com/Konstantin8105/c4go/noarch.Sockaddr",
+	"struct in_addr":          "github.com/Konstantin8105/c4go/noarch.InAddr",
+	"struct in6_addr":         "github.com/Konstantin8105/c4go/noarch.In6Addr",
+	"struct addrinfo":         "github.com/Konstantin8105/c4go/noarch.Addrinfo",
+	"struct hostent":          "github.com/Konstantin8105/c4go/noarch.Hostent",
+
+	// termios.h, sys/ioctl.h
+	"struct termios": "github.com/Konstantin8105/c4go/noarch.Termios",
+	"struct winsize": "github.com/Konstantin8105/c4go/noarch.Winsize",
+
+	// sys/select.h, poll.h
+	"fd_set":         "github.com/Konstantin8105/c4go/noarch.FdSet",
+	"struct timeval": "github.com/Konstantin8105/c4go/noarch.Timeval",
+	"struct pollfd":  "github.com/Konstantin8105/c4go/noarch.Pollfd",
+
+	// sys/resource.h, sys/times.h
+	"struct rusage": "github.com/Konstantin8105/c4go/noarch.Rusage",
+	"struct tms":    "github.com/Konstantin8105/c4go/noarch.Tms",
+
+	// sys/utsname.h
+	"struct utsname": "github.com/Konstantin8105/c4go/noarch.Utsname",
+
+	// locale.h
+	"struct lconv":           "github.com/Konstantin8105/c4go/noarch.Lconv",
+	"struct __locale_struct": "github.com/Konstantin8105/c4go/noarch.Locale",
+	"struct __locale_data":   "github.com/Konstantin8105/c4go/noarch.LocaleData",
+
+	// regex.h
+	"regex_t":                  "github.com/Konstantin8105/c4go/noarch.RegexT",
+	"struct re_pattern_buffer": "github.com/Konstantin8105/c4go/noarch.RegexT",
+	"regmatch_t":               "github.com/Konstantin8105/c4go/noarch.RegmatchT",
+}
+
+// TypeMapping returns the Go type of simple C type, which has an exact Go
+// equivalent. The Go type is fully qualified, for example:
+//
+//     "FILE" -> "github.com/Konstantin8105/c4go/noarch.File"
+//
+func (r *TypeRegistry) TypeMapping(cType string) (goType string, ok bool) {
+	goType, ok = r.typeMappings[cType]
+	return
+}
+
+// RegisterTypeMapping registers the Go type of simple C type. If the C type
+// is already mapped, then the mapping is replaced. The Go type of package
+// must be fully qualified by import path, for example:
+//
+//     p.RegisterTypeMapping("mpz_t", "github.com/user/bigint.Int")
+//
+// The C type is used as it appears in the AST without qualifiers "const",
+// "volatile" and so on.
+func (r *TypeRegistry) RegisterTypeMapping(cType, goType string) {
+	r.typeMappings[strings.TrimSpace(cType)] = strings.TrimSpace(goType)
+}
+
+// StdStruct returns the Go type of C struct or typedef of library, which is
+// implemented in Go.
+func (r *TypeRegistry) StdStruct(cType string) (goType string, ok bool) {
+	goType, ok = r.stdStructs[cType]
+	return
+}
+
+// RegisterStdStruct registers the Go type of C struct or typedef of library,
+// which is implemented in Go. If the C type is already registered, then the
+// registration is replaced. The Go type must be fully qualified by import
+// path, for example:
+//
+//     p.RegisterStdStruct("struct point", "github.com/user/geom.Point")
+//
+func (r *TypeRegistry) RegisterStdStruct(cType, goType string) {
+	r.stdStructs[strings.TrimSpace(cType)] = strings.TrimSpace(goType)
+}
+
+// RecordResolvedType records the decision of the Go type for the C type. Only
+// the first decision is kept, because the same C type must always have the
+// same Go type.
+func (r *TypeRegistry) RecordResolvedType(cType, goType string) {
+	if _, ok := r.resolvedTypes[cType]; !ok {
+		r.resolvedTypes[cType] = goType
+	}
+}
+
+// ResolvedTypes returns the copy of map of C types to Go types, which are
+// decided during the transpilation.
+func (r *TypeRegistry) ResolvedTypes() map[string]string {
+	return copyMap(r.resolvedTypes)
+}
@@ -0,0 +1,240 @@
+package program
+
+import (
+	"strings"
+
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// StructRegistry is a map of Struct for struct types and union type
+type StructRegistry map[string]*Struct
+
+// HasType method check if type exists
+func (sr StructRegistry) HasType(typename string) bool {
+	_, exists := sr[typename]
+
+	return exists
+}
+
+// TypeResolver resolves the C types defined in the program.
+type TypeResolver interface {
+	GetStruct(name string) *Struct
+	IsUnion(cType string) bool
+	GetBaseTypeOfTypedef(cTypedef string) (cBase string, ok bool)
+	TypeMapping(cType string) (goType string, ok bool)
+	RegisterTypeMapping(cType, goType string)
+	StdStruct(cType string) (goType string, ok bool)
+	RegisterStdStruct(cType, goType string)
+	RecordResolvedType(cType, goType string)
+	ResolvedTypes() map[string]string
+	IsTypeAlreadyDefined(typeName string) bool
+	DefineType(typeName string)
+	UndefineType(typeName string)
+}
+
+// TypeRegistry contains the C types of the program: structs, unions,
+// typedefs and enums. TypeRegistry implements the interface TypeResolver.
+type TypeRegistry struct {
+	// One a type is defined it will be ignored if a future type of the same
+	// name appears.
+	typesAlreadyDefined []string
+
+	// The definitions for defined structs.
+	// TODO: This field should be protected through proper getters and setters.
+	Structs StructRegistry
+	Unions  StructRegistry
+
+	// EnumConstantToEnum - a map with key="EnumConstant" and value="enum type"
+	// clang don`t show enum constant with enum type,
+	// so we have to use hack for repair the type
+	EnumConstantToEnum map[string]string
+
+	// EnumTypedefName - a map with key="Name of typedef enum" and
+	// value="exist ot not"
+	EnumTypedefName map[string]bool
+
+	// TypedefType - map for type alias, for example:
+	// C  : typedef int INT;
+	// Map: key = INT, value = int
+	// Important: key and value are C types
+	TypedefType map[string]string
+
+	// typeMappings - map of simple C types to Go types.
+	// See RegisterTypeMapping().
+	typeMappings map[string]string
+
+	// stdStructs - map of C structs of library to Go types.
+	// See RegisterStdStruct().
+	stdStructs map[string]string
+
+	// resolvedTypes - map of C types to Go types, which are decided during
+	// the transpilation. See RecordResolvedType().
+	resolvedTypes map[string]string
+}
+
+// NewTypeRegistry creates a new registry with the types, which are
+// defined before the transpilation starts.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		typesAlreadyDefined: []string{},
+		Structs: StructRegistry(map[string]*Struct{
+			// Structs without implementations inside system C headers
+			// Example node for adding:
+			// &ast.TypedefDecl{ ... Type:"struct __locale_struct *" ... }
+
+			"struct __va_list_tag [1]": {
+				Name: "struct __va_list_tag [1]",
+				Type: StructType,
+			},
+
+			// Pos:ast.Position{File:"/usr/include/xlocale.h", Line:27
+			"struct __locale_struct *": {
+				Name: "struct __locale_struct *",
+				Type: StructType,
+			},
+
+			// Pos:ast.Position{File:"/usr/include/x86_64-linux-gnu/sys/time.h", Line:61
+			"struct timezone *__restrict": {
+				Name: "struct timezone *__restrict",
+				Type: StructType,
+			},
+
+			// Structs returned by functions from "stdlib.h". They are
+			// implemented in package noarch, so the name of the struct is
+			// not prefixed with "struct ", because it is a typedef.
+			"div_t": {
+				Name: "div_t",
+				Type: StructType,
+				Fields: map[string]interface{}{
+					"quot": "int",
+					"rem":  "int",
+				},
+			},
+			"ldiv_t": {
+				Name: "ldiv_t",
+				Type: StructType,
+				Fields: map[string]interface{}{
+					"quot": "long int",
+					"rem":  "long int",
+				},
+			},
+			"lldiv_t": {
+				Name: "lldiv_t",
+				Type: StructType,
+				Fields: map[string]interface{}{
+					"quot": "long long int",
+					"rem":  "long long int",
+				},
+			},
+
+			// Struct returned by function from "inttypes.h".
+			"imaxdiv_t": {
+				Name: "imaxdiv_t",
+				Type: StructType,
+				Fields: map[string]interface{}{
+					"quot": "intmax_t",
+					"rem":  "intmax_t",
+				},
+			},
+
+			// Type of "sys/select.h" is implemented in package noarch. The
+			// size of struct is needed for the macro FD_ZERO.
+			"struct fd_set": {
+				Name: "struct fd_set",
+				Type: StructType,
+				Fields: map[string]interface{}{
+					"__fds_bits": "long [16]",
+				},
+			},
+		}),
+		Unions:             make(StructRegistry),
+		EnumConstantToEnum: map[string]string{},
+		EnumTypedefName:    map[string]bool{},
+		TypedefType:        map[string]string{},
+		typeMappings:       copyMap(builtInTypeMappings),
+		stdStructs:         copyMap(builtInStdStructs),
+		resolvedTypes:      map[string]string{},
+	}
+}
+
+// copyMap returns the copy of map, so the registrations of one program do
+// not change the built-in maps.
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// GetStruct returns a struct object (representing struct type or union type) or
+// nil if doesn't exist. This method can get struct or union in the same way and
+// distinguish only by the IsUnion field. `name` argument is the C like
+// `struct a_struct`, it allow pointer type like `union a_union *`. Pointer
+// types used in a DeclRefExpr in the case a deferenced structure by using `->`
+// operator to access to a field like this: a_struct->member .
+//
+// This method is used in collaboration with the field
+// "c4go/program".*Struct.IsUnion to simplify the code like in function
+// "c4go/transpiler".transpileMemberExpr() where the same *Struct value returned
+// by this method is used in the 2 cases, in the case where the value has a
+// struct type and in the case where the value has an union type.
+func (r *TypeRegistry) GetStruct(name string) *Struct {
+	if name == "" {
+		return nil
+	}
+
+	last := len(name) - 1
+
+	// That allow to get struct from pointer type
+	if name[last] == '*' {
+		name = name[:last]
+	}
+
+	name = strings.TrimSpace(name)
+
+	res, ok := r.Structs[name]
+	if ok {
+		return res
+	}
+
+	return r.Unions[name]
+}
+
+// IsTypeAlreadyDefined will return true if the typeName has already been
+// defined.
+//
+// A type could be defined:
+//
+// 1. Initially. That is, before the transpilation starts (hard-coded).
+// 2. By calling DefineType throughout the transpilation.
+func (r *TypeRegistry) IsTypeAlreadyDefined(typeName string) bool {
+	return util.InStrings(typeName, r.typesAlreadyDefined)
+}
+
+// DefineType will record a type as having already been defined. The purpose for
+// this is to not generate Go for a type more than once. C allows variables and
+// other entities (such as function prototypes) to be defined more than once in
+// some cases. An example of this would be static variables or functions.
+func (r *TypeRegistry) DefineType(typeName string) {
+	r.typesAlreadyDefined = append(r.typesAlreadyDefined, typeName)
+}
+
+// UndefineType undefine defined type
+func (r *TypeRegistry) UndefineType(typeName string) {
+check_again:
+	for i := range r.typesAlreadyDefined {
+		if typeName == r.typesAlreadyDefined[i] {
+			if len(r.typesAlreadyDefined) == 1 {
+				r.typesAlreadyDefined = make([]string, 0)
+			} else if i == len(r.typesAlreadyDefined)-1 {
+				r.typesAlreadyDefined = r.typesAlreadyDefined[:len(r.typesAlreadyDefined)-1]
+			} else {
+				r.typesAlreadyDefined = append(
+					r.typesAlreadyDefined[:i],
+					r.typesAlreadyDefined[i+1:]...)
+			}
+			goto check_again
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package program
+
+import (
+	"strings"
+)
+
+// builtInVariableDefinitions contains the global variables of C include
+// headers, which must be replaced by variables of c4go packages. C code
+// is allowed to read and to change these variables, so the substitution is
+// a Go variable (not a function). For example:
+//
+//     "optind" -> noarch.Optind
+//
+// The key of map is the C include header.
+var builtInVariableDefinitions = map[string]map[string]string{
+	"unistd.h": {
+		"optarg":  "noarch.Optarg",
+		"optind":  "noarch.Optind",
+		"opterr":  "noarch.Opterr",
+		"optopt":  "noarch.Optopt",
+		"environ": "noarch.Environ",
+	},
+	"getopt.h": {
+		"optarg": "noarch.Optarg",
+		"optind": "noarch.Optind",
+		"opterr": "noarch.Opterr",
+		"optopt": "noarch.Optopt",
+	},
+	"stdlib.h": {
+		// C programs often declare the variable by themselves:
+		// extern char **environ;
+		"environ": "noarch.Environ",
+	},
+}
+
+// GetVariableSubstitution returns the full Go name of variable, which
+// replaces the C global variable from include header. If variable is not
+// registered or the header is not included, then an empty string is
+// returned.
+func (s *SymbolTable) GetVariableSubstitution(name string) string {
+	for header, variables := range builtInVariableDefinitions {
+		substitution, ok := variables[name]
+		if !ok {
+			continue
+		}
+		if !s.includeHeaderIsExists(header) {
+			continue
+		}
+		if strings.HasPrefix(substitution, "linux.") ||
+			strings.HasPrefix(substitution, "noarch.") {
+			substitution = "github.com/Konstantin8105/c4go/" + substitution
+		}
+		return substitution
+	}
+	return ""
+}
@@ -5,11 +5,99 @@ import (
 	"os"
 	"strings"
 
+	goast "go/ast"
+
 	"github.com/Konstantin8105/c4go/ast"
 )
 
+// Messenger collects the messages (warnings, errors) generated when
+// transpiling the AST.
+type Messenger interface {
+	AddMessage(message string) bool
+	GetMessages() []string
+	GetMessageComments() *goast.CommentGroup
+	GenerateWarningMessage(e error, n ast.Node) string
+}
+
+// MessageLog contains the messages generated when transpiling the AST.
+// MessageLog implements the interface Messenger.
+type MessageLog struct {
+	// Contains the messages (for example, "// Warning") generated when
+	// transpiling the AST. These messages, which are code comments, are
+	// appended to the very top of the output file. See AddMessage().
+	messages []string
+
+	// messagePosition - position of slice messages, added like a comment
+	// in output Go code
+	messagePosition int
+}
+
+// NewMessageLog creates a new empty log of messages.
+func NewMessageLog() *MessageLog {
+	return &MessageLog{messages: []string{}}
+}
+
+// AddMessage adds a message (such as a warning or error) comment to the output
+// file. Usually the message is generated from one of the Generate functions in
+// the ast package.
+//
+// It is expected that the message already have the comment ("//") prefix.
+//
+// The message will not be appended if it is blank. This is because the Generate
+// functions return a blank string conditionally when there is no error.
+//
+// The return value will be true if a message was added, otherwise false.
+func (m *MessageLog) AddMessage(message string) bool {
+	if message == "" {
+		return false
+	}
+
+	m.messages = append(m.messages, message)
+
+	// Compactizarion warnings stack
+	if len(m.messages) > 1 {
+		var (
+			new  = len(m.messages) - 1
+			last = len(m.messages) - 2
+		)
+		// Warning collapsing for minimaze warnings
+		warning := "// Warning"
+		if strings.HasPrefix(m.messages[last], warning) {
+			l := m.messages[last][len(warning):]
+			if strings.HasSuffix(m.messages[new], l) {
+				m.messages[last] = m.messages[new]
+				m.messages = m.messages[0:new]
+			}
+		}
+	}
+
+	return true
+}
+
+// GetMessages returns all messages (warnings, errors) generated when
+// transpiling the AST.
+func (m *MessageLog) GetMessages() []string {
+	return append([]string{}, m.messages...)
+}
+
+// GetMessageComments - get messages "Warnings", "Error" like a comment
+// Location of comments only NEAR of error or warning and
+// don't show directly location
+func (m *MessageLog) GetMessageComments() (_ *goast.CommentGroup) {
+	var group goast.CommentGroup
+	if m.messagePosition < len(m.messages) {
+		for i := m.messagePosition; i < len(m.messages); i++ {
+			group.List = append(group.List, &goast.Comment{
+				Text: m.messages[i],
+			})
+		}
+		m.messagePosition = len(m.messages)
+	}
+	return &group
+}
+
 // GenerateWarningMessage - generate warning message
-func (p *Program) GenerateWarningMessage(e error, n ast.Node) string {
+func (m *MessageLog) GenerateWarningMessage(e error, n ast.Node) string {
 	message := "// Warning "
 	if e == nil || len(e.Error()) == 0 {
 		return ""
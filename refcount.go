@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// refCountConfig is the list of functions and fields of manual reference
+// counting in C code. Calls of these functions and changes of these fields
+// are removed from Go code, because the memory is managed by garbage
+// collector. Calls of "inc" functions in expressions are replaced by the
+// first argument. Example of JSON file:
+//
+//     {
+//       "inc":    ["obj_ref"],
+//       "dec":    ["obj_unref"],
+//       "dtor":   ["obj_free"],
+//       "fields": ["refcount"]
+//     }
+//
+type refCountConfig struct {
+	// Inc is the list of functions, which increment the reference counter
+	// and return the object.
+	Inc []string `json:"inc"`
+
+	// Dec is the list of functions, which decrement the reference counter.
+	Dec []string `json:"dec"`
+
+	// Dtor is the list of destructors of objects.
+	Dtor []string `json:"dtor"`
+
+	// Fields is the list of names of fields with reference counters.
+	Fields []string `json:"fields"`
+}
+
+// loadRefCountConfig reads the reference counting from JSON file.
+func loadRefCountConfig(filename string) (r program.RefCounting, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return r, fmt.Errorf("Cannot read refcount configuration: %v", err)
+	}
+	var c refCountConfig
+	if err = json.Unmarshal(content, &c); err != nil {
+		return r, fmt.Errorf("Cannot parse refcount configuration: %v", err)
+	}
+	r.Functions = map[string]program.RefCountKind{}
+	r.Fields = map[string]bool{}
+	isName := util.GetRegex(`^[a-zA-Z_][a-zA-Z0-9_]*$`).MatchString
+	for _, list := range []struct {
+		kind  program.RefCountKind
+		names []string
+	}{
+		{program.RefCountInc, c.Inc},
+		{program.RefCountDec, c.Dec},
+		{program.RefCountDtor, c.Dtor},
+	} {
+		for _, name := range list.names {
+			if !isName(name) {
+				return r, fmt.Errorf("Name `%s` in refcount configuration "+
+					"is not valid name of function", name)
+			}
+			if kind, ok := r.Functions[name]; ok {
+				return r, fmt.Errorf("Function `%s` in refcount configuration "+
+					"is `%s` and `%s` at the same time", name, kind, list.kind)
+			}
+			r.Functions[name] = list.kind
+		}
+	}
+	for _, name := range c.Fields {
+		if !isName(name) {
+			return r, fmt.Errorf("Name `%s` in refcount configuration "+
+				"is not valid name of field", name)
+		}
+		r.Fields[name] = true
+	}
+	return r, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestRefCountConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-refcount-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcs := []struct {
+		content   string
+		isError   bool
+		functions map[string]program.RefCountKind
+		fields    []string
+	}{
+		{
+			`{"inc":["obj_ref"],"dec":["obj_unref"],"dtor":["obj_free"],"fields":["refcount"]}`,
+			false,
+			map[string]program.RefCountKind{
+				"obj_ref":   program.RefCountInc,
+				"obj_unref": program.RefCountDec,
+				"obj_free":  program.RefCountDtor,
+			},
+			[]string{"refcount"},
+		},
+		{`{"dec":["release"]}`, false,
+			map[string]program.RefCountKind{"release": program.RefCountDec}, nil},
+		{`{}`, false, nil, nil},
+		{`{"inc":["obj.ref"]}`, true, nil, nil},
+		{`{"fields":["1count"]}`, true, nil, nil},
+		{`{"inc":["obj_ref"],"dtor":["obj_ref"]}`, true, nil, nil},
+		{`not json`, true, nil, nil},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "refcount.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := loadRefCountConfig(filename)
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if tc.isError {
+			continue
+		}
+		if len(r.Functions) != len(tc.functions) {
+			t.Errorf("Case %d: expected %v, got %v", i, tc.functions, r.Functions)
+		}
+		for name, kind := range tc.functions {
+			if r.Functions[name] != kind {
+				t.Errorf("Case %d: function `%s` is `%s`, but want `%s`",
+					i, name, r.Functions[name], kind)
+			}
+		}
+		if len(r.Fields) != len(tc.fields) {
+			t.Errorf("Case %d: expected %v, got %v", i, tc.fields, r.Fields)
+		}
+		for _, name := range tc.fields {
+			if !r.Fields[name] {
+				t.Errorf("Case %d: field `%s` is not found", i, name)
+			}
+		}
+		if len(tc.functions)+len(tc.fields) > 0 != r.IsEnabled() {
+			t.Errorf("Case %d: not correct IsEnabled", i)
+		}
+	}
+
+	if _, err := loadRefCountConfig(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
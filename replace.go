@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// replaceConfig is the list of C functions, calls of which are replaced by
+// calls of Go functions of project instead of functions of package noarch.
+// Calls are replaced only if the Go signature of C function is the same as
+// the signature of Go function. Example of JSON file:
+//
+//     {
+//       "functions": [
+//         {
+//           "c": "strlcpy",
+//           "go": "github.com/user/project/cutil.Strlcpy",
+//           "signature": "func(dst, src []byte, size uint32) uint32"
+//         }
+//       ]
+//     }
+//
+type replaceConfig struct {
+	Functions []struct {
+		// C is the name of C function.
+		C string `json:"c"`
+
+		// Go is the Go function with import path.
+		Go string `json:"go"`
+
+		// Signature is the Go signature of function.
+		Signature string `json:"signature"`
+	} `json:"functions"`
+}
+
+// loadReplaceConfig reads the list of replaced C functions from JSON file.
+func loadReplaceConfig(filename string) (
+	replacements map[string]program.Replacement, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read replace configuration: %v", err)
+	}
+	var c replaceConfig
+	if err = json.Unmarshal(content, &c); err != nil {
+		return nil, fmt.Errorf("Cannot parse replace configuration: %v", err)
+	}
+	replacements = map[string]program.Replacement{}
+	for _, f := range c.Functions {
+		if !util.GetRegex(`^[a-zA-Z_][a-zA-Z0-9_]*$`).MatchString(f.C) {
+			return nil, fmt.Errorf("Name `%s` in replace configuration "+
+				"is not valid name of function", f.C)
+		}
+		index := strings.LastIndex(f.Go, ".")
+		if index <= 0 || strings.LastIndex(f.Go, "/") > index ||
+			!util.GetRegex(`^[A-Z][a-zA-Z0-9_]*$`).MatchString(f.Go[index+1:]) {
+			return nil, fmt.Errorf("Function `%s` of `%s` in replace "+
+				"configuration is not exported Go function with import path",
+				f.Go, f.C)
+		}
+		expr, err := parser.ParseExpr(f.Signature)
+		if _, ok := expr.(*goast.FuncType); err != nil || !ok {
+			return nil, fmt.Errorf("Signature `%s` of `%s` in replace "+
+				"configuration is not Go function type", f.Signature, f.C)
+		}
+		if _, ok := replacements[f.C]; ok {
+			return nil, fmt.Errorf("Function `%s` is replaced twice "+
+				"in replace configuration", f.C)
+		}
+		replacements[f.C] = program.Replacement{
+			Function:  f.Go,
+			Signature: f.Signature,
+		}
+	}
+	return replacements, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplaceConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-replace-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcs := []struct {
+		content   string
+		isError   bool
+		functions map[string]string
+	}{
+		{`{"functions":[
+			{"c":"strlcpy","go":"example.com/cutil.Strlcpy","signature":"func([]byte, []byte, uint32) uint32"},
+			{"c":"xfree","go":"cutil.Free","signature":"func(interface{})"}]}`,
+			false, map[string]string{
+				"strlcpy": "example.com/cutil.Strlcpy",
+				"xfree":   "cutil.Free",
+			}},
+		{`{"functions":[]}`, false, nil},
+		{`{"functions":[{"c":"a.b","go":"cutil.F","signature":"func()"}]}`, true, nil},
+		{`{"functions":[{"c":"f","go":"F","signature":"func()"}]}`, true, nil},
+		{`{"functions":[{"c":"f","go":"cutil.f","signature":"func()"}]}`, true, nil},
+		{`{"functions":[{"c":"f","go":"example.com/cutil","signature":"func()"}]}`, true, nil},
+		{`{"functions":[{"c":"f","go":"cutil.F","signature":"int"}]}`, true, nil},
+		{`{"functions":[{"c":"f","go":"cutil.F"}]}`, true, nil},
+		{`{"functions":[{"c":"f","go":"cutil.F","signature":"func()"},
+			{"c":"f","go":"cutil.G","signature":"func()"}]}`, true, nil},
+		{`not json`, true, nil},
+	}
+	for i, tc := range tcs {
+		filename := filepath.Join(dir, "replace.json")
+		err := ioutil.WriteFile(filename, []byte(tc.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replacements, err := loadReplaceConfig(filename)
+		if (err != nil) != tc.isError {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if len(replacements) != len(tc.functions) {
+			t.Errorf("Case %d: expected %v, got %v", i, tc.functions, replacements)
+		}
+		for c, function := range tc.functions {
+			if replacements[c].Function != function {
+				t.Errorf("Case %d: function `%s` is replaced by `%s`, expected `%s`",
+					i, c, replacements[c].Function, function)
+			}
+		}
+	}
+
+	if _, err := loadReplaceConfig(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// localStats is the aggregated statistics of problems of transpiling over
+// all runs of user with the flag "-stats". Statistics is saved only in the
+// local file and never sent anywhere, so user can see what blocks the
+// transpiling of their codebase. Example of file:
+//
+//     {
+//       "runs": 2,
+//       "nodes": {"BinaryOperator": 4},
+//       "functions": {"strsep": 1},
+//       "types": {"struct tm *": 3}
+//     }
+//
+type localStats struct {
+	// Runs is amount of transpiling runs.
+	Runs int `json:"runs"`
+
+	// Nodes - amount of warnings and errors for each type of AST node.
+	Nodes map[string]int `json:"nodes"`
+
+	// Functions - amount of calls of C standard library functions without
+	// implementation in package noarch.
+	Functions map[string]int `json:"functions"`
+
+	// Types - amount of C types and casting of types, which cannot be
+	// transpiled.
+	Types map[string]int `json:"types"`
+}
+
+func newLocalStats() localStats {
+	return localStats{
+		Nodes:     map[string]int{},
+		Functions: map[string]int{},
+		Types:     map[string]int{},
+	}
+}
+
+// statsFilename returns the name of statistics file. By default, it is
+// "$HOME/.c4go/stats.json" and may be changed by environment variable
+// C4GO_STATS.
+func statsFilename() string {
+	if filename := os.Getenv("C4GO_STATS"); filename != "" {
+		return filename
+	}
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = os.Getenv("USERPROFILE")
+	}
+	return filepath.Join(home, ".c4go", "stats.json")
+}
+
+// loadStats reads statistics from the file. If file is not exist, then
+// empty statistics is returned.
+func loadStats(filename string) (s localStats, err error) {
+	s = newLocalStats()
+	content, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("Cannot read statistics: %v", err)
+	}
+	if err = json.Unmarshal(content, &s); err != nil {
+		return s, fmt.Errorf("Cannot parse statistics `%s`: %v", filename, err)
+	}
+	for _, m := range []*map[string]int{&s.Nodes, &s.Functions, &s.Types} {
+		if *m == nil {
+			*m = map[string]int{}
+		}
+	}
+	return s, nil
+}
+
+// saveStats writes statistics to the file.
+func saveStats(filename string, s localStats) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("Cannot create folder for statistics: %v", err)
+	}
+	return ioutil.WriteFile(filename, content, 0644)
+}
+
+// add appends the statistics of one run.
+func (s *localStats) add(run localStats) {
+	s.Runs += run.Runs
+	for name, amount := range run.Nodes {
+		s.Nodes[name] += amount
+	}
+	for name, amount := range run.Functions {
+		s.Functions[name] += amount
+	}
+	for name, amount := range run.Types {
+		s.Types[name] += amount
+	}
+}
+
+// collectStats calculates the statistics of one run by the messages of
+// program and the AST tree.
+func collectStats(p *program.Program, tree ast.Node) localStats {
+	s := newLocalStats()
+	s.Runs = 1
+
+	for _, message := range p.GetMessages() {
+		// warnings, for example:
+		// // Warning (*ast.BinaryOperator): file.c:12 :Cannot casting {...}
+		if m := util.GetRegex(`^// Warning \(\*ast\.(\w+)\)`).
+			FindStringSubmatch(message); m != nil {
+			s.Nodes[m[1]]++
+		}
+		// errors of AST parsing
+		if m := util.GetRegex("(?:unknown node type|Cannot parse line): `(\\w+)").
+			FindStringSubmatch(message); m != nil {
+			s.Nodes[m[1]]++
+		}
+		// types. Errors are wrapped, so the last one is the reason.
+		if m := util.GetRegex(`Cannot resolve type '([^']*)'`).
+			FindAllStringSubmatch(message, -1); m != nil {
+			s.Types[m[len(m)-1][1]]++
+		}
+		if m := util.GetRegex(`Cannot casting \{(.*?) -> (.*?)\}`).
+			FindStringSubmatch(message); m != nil {
+			s.Types[m[1]+" -> "+m[2]]++
+		}
+	}
+
+	if tree == nil {
+		return s
+	}
+
+	// C standard library functions are declared in system headers without
+	// body. Functions with implementation in user code are ignored.
+	system := map[string]bool{}
+	implemented := map[string]bool{}
+	var calls []string
+	var walk func(n ast.Node)
+	walk = func(n ast.Node) {
+		if n == nil {
+			return
+		}
+		switch n := n.(type) {
+		case *ast.FunctionDecl:
+			if getFunctionBody(n) != nil {
+				implemented[n.Name] = true
+			} else if !p.PreprocessorFile.IsUserSource(n.Pos.File) {
+				system[n.Name] = true
+			}
+		case *ast.CallExpr:
+			if len(n.Children()) > 0 {
+				if name, ok := calledFunctionName(n.Children()[0]); ok {
+					calls = append(calls, name)
+				}
+			}
+		}
+		for _, c := range n.Children() {
+			walk(c)
+		}
+	}
+	walk(tree)
+
+	for _, name := range calls {
+		if !system[name] || implemented[name] {
+			continue
+		}
+		if f := p.GetFunctionDefinition(name); f != nil && f.Substitution != "" {
+			continue
+		}
+		s.Functions[name]++
+	}
+	return s
+}
+
+// getFunctionBody returns the body of function or nil for prototype.
+func getFunctionBody(n *ast.FunctionDecl) ast.Node {
+	for _, c := range n.Children() {
+		if b, ok := c.(*ast.CompoundStmt); ok {
+			return b
+		}
+	}
+	return nil
+}
+
+// calledFunctionName returns the name of function from the first child of
+// CallExpr.
+func calledFunctionName(n ast.Node) (string, bool) {
+	for {
+		switch v := n.(type) {
+		case *ast.ImplicitCastExpr:
+			if len(v.Children()) == 0 {
+				return "", false
+			}
+			n = v.Children()[0]
+		case *ast.DeclRefExpr:
+			return v.Name, v.For == "Function"
+		default:
+			return "", false
+		}
+	}
+}
+
+// recordStats adds the statistics of run in the statistics file.
+func recordStats(p *program.Program, tree ast.Node) error {
+	filename := statsFilename()
+	s, err := loadStats(filename)
+	if err != nil {
+		return err
+	}
+	s.add(collectStats(p, tree))
+	return saveStats(filename, s)
+}
+
+// writeStats prints statistics as tables with the most often problems.
+// Only first top lines are printed in each table.
+func writeStats(w io.Writer, s localStats, top int) {
+	fmt.Fprintf(w, "Runs: %d\n", s.Runs)
+	tables := []struct {
+		title string
+		m     map[string]int
+	}{
+		{"AST nodes", s.Nodes},
+		{"C standard library functions", s.Functions},
+		{"Types", s.Types},
+	}
+	for _, table := range tables {
+		fmt.Fprintf(w, "\n%s:\n", table.title)
+		if len(table.m) == 0 {
+			fmt.Fprintf(w, "  no problems\n")
+			continue
+		}
+		names := make([]string, 0, len(table.m))
+		for name := range table.m {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if table.m[names[i]] != table.m[names[j]] {
+				return table.m[names[i]] > table.m[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		for i, name := range names {
+			if top > 0 && i >= top {
+				fmt.Fprintf(w, "  ... and %d more\n", len(names)-top)
+				break
+			}
+			fmt.Fprintf(w, "%8d  %s\n", table.m[name], name)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestCollectStats(t *testing.T) {
+	p := program.NewProgram()
+	p.AddMessage("// Warning (*ast.BinaryOperator): file.c:12 :" +
+		"Cannot casting {struct tm * -> int}. err = fail")
+	p.AddMessage("// Warning (*ast.VarDecl): file.c:14 :" +
+		"Cannot resolve type 'struct tm *' : Cannot resolve type 'struct tm' : fail")
+	p.AddMessage("/* AST Error :\nunknown node type: `FooDecl 0x1 <line:1:1>`\n*/")
+	p.AddFunctionDefinition(program.FunctionDefinition{
+		Name:         "strlen",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Strlen",
+	})
+
+	// prototypes from system header and calls of functions
+	tree := &ast.TranslationUnitDecl{}
+	tree.AddChild(&ast.FunctionDecl{Name: "strsep",
+		Pos: ast.Position{File: "/usr/include/string.h"}})
+	tree.AddChild(&ast.FunctionDecl{Name: "strlen",
+		Pos: ast.Position{File: "/usr/include/string.h"}})
+	call := func(name string) ast.Node {
+		c := &ast.CallExpr{}
+		i := &ast.ImplicitCastExpr{}
+		i.AddChild(&ast.DeclRefExpr{Name: name, For: "Function"})
+		c.AddChild(i)
+		return c
+	}
+	body := &ast.CompoundStmt{}
+	body.AddChild(call("strsep"))
+	body.AddChild(call("strsep"))
+	body.AddChild(call("strlen"))
+	body.AddChild(call("user"))
+	f := &ast.FunctionDecl{Name: "main", Pos: ast.Position{File: "file.c"}}
+	f.AddChild(body)
+	tree.AddChild(f)
+
+	s := collectStats(p, tree)
+	if s.Runs != 1 {
+		t.Errorf("Runs: %d", s.Runs)
+	}
+	for name, amount := range map[string]int{
+		"BinaryOperator": 1,
+		"VarDecl":        1,
+		"FooDecl":        1,
+	} {
+		if s.Nodes[name] != amount {
+			t.Errorf("Node %s: expected %d, got %d", name, amount, s.Nodes[name])
+		}
+	}
+	if len(s.Functions) != 1 || s.Functions["strsep"] != 2 {
+		t.Errorf("Functions: %v", s.Functions)
+	}
+	for name, amount := range map[string]int{
+		"struct tm * -> int": 1,
+		"struct tm":          1,
+	} {
+		if s.Types[name] != amount {
+			t.Errorf("Type %s: expected %d, got %d", name, amount, s.Types[name])
+		}
+	}
+}
+
+func TestStatsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-stats-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "folder", "stats.json")
+
+	// statistics file is not exist
+	s, err := loadStats(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Runs != 0 || len(s.Nodes) != 0 {
+		t.Errorf("Statistics is not empty: %v", s)
+	}
+
+	run := newLocalStats()
+	run.Runs = 1
+	run.Nodes["BinaryOperator"] = 2
+	run.Functions["strsep"] = 1
+	for i := 0; i < 2; i++ {
+		s.add(run)
+		if err = saveStats(filename, s); err != nil {
+			t.Fatal(err)
+		}
+		if s, err = loadStats(filename); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Runs != 2 || s.Nodes["BinaryOperator"] != 4 || s.Functions["strsep"] != 2 {
+		t.Errorf("Not correct statistics: %v", s)
+	}
+
+	var buf bytes.Buffer
+	writeStats(&buf, s, 10)
+	for _, expect := range []string{
+		"Runs: 2",
+		"       4  BinaryOperator",
+		"       2  strsep",
+		"Types:\n  no problems",
+	} {
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("Cannot find `%s` in:\n%s", expect, buf.String())
+		}
+	}
+
+	if err = ioutil.WriteFile(filename, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loadStats(filename); err == nil {
+		t.Errorf("Expected error for not valid file")
+	}
+}
@@ -0,0 +1,106 @@
+#include "tests.h"
+#include <alloca.h>
+#include <stdlib.h>
+#include <string.h>
+
+// nesting returns the depth of nested parentheses. The content between the
+// outer parentheses is copied in the variable length array for the next
+// level of recursion.
+int nesting(const char* s, int n)
+{
+    if (n < 2 || s[0] != '(') {
+        return 0;
+    }
+    char inner[n - 1];
+    int i;
+    for (i = 0; i < n - 2; i++) {
+        inner[i] = s[i + 1];
+    }
+    inner[n - 2] = '\0';
+    return 1 + nesting(inner, n - 2);
+}
+
+// sum_digits returns the sum of digits of string. The tail of string is
+// copied in the buffer allocated by alloca() for the next level of recursion.
+int sum_digits(const char* s)
+{
+    int n = strlen(s);
+    if (n == 0) {
+        return 0;
+    }
+    char* rest = alloca(n);
+    int i;
+    for (i = 0; i < n; i++) {
+        rest[i] = s[i + 1];
+    }
+    return (s[0] - '0') + sum_digits(rest);
+}
+
+// sum_squares returns the sum of squares of numbers less than n by the
+// variable length array of int.
+int sum_squares(int n)
+{
+    if (n == 0) {
+        return 0;
+    }
+    int squares[n];
+    int i;
+    for (i = 0; i < n; i++) {
+        squares[i] = i * i;
+    }
+    return squares[n - 1] + sum_squares(n - 1);
+}
+
+// test_loop checks variable length arrays in the loop, which are freed at
+// the end of each iteration.
+void test_loop()
+{
+    int i, j;
+    for (i = 1; i <= 3; i++) {
+        char line[i + 1];
+        for (j = 0; j < i; j++) {
+            line[j] = 'a' + j;
+        }
+        line[i] = '\0';
+        is_eq(strlen(line), i);
+    }
+}
+
+int main()
+{
+    plan(10);
+
+    diag("variable length arrays");
+    is_eq(nesting("", 0), 0);
+    is_eq(nesting("()", 2), 1);
+    is_eq(nesting("((()))", 6), 3);
+    is_eq(sum_squares(10), 285);
+
+    diag("alloca");
+    is_eq(sum_digits("12345"), 15);
+
+    diag("deep recursion");
+    {
+        int depth = 1000;
+        char* deep = malloc(2 * depth + 1);
+        int i;
+        for (i = 0; i < depth; i++) {
+            deep[i] = '(';
+            deep[2 * depth - 1 - i] = ')';
+        }
+        deep[2 * depth] = '\0';
+        is_eq(nesting(deep, 2 * depth), depth);
+
+        for (i = 0; i < depth; i++) {
+            deep[i] = '1';
+        }
+        deep[depth] = '\0';
+        is_eq(sum_digits(deep), depth);
+        free(deep);
+    }
+
+    diag("loop");
+    test_loop();
+
+    done_testing();
+}
@@ -221,6 +221,63 @@ void test_pointer_minus_pointer()
     is_eq(right_ptr - left_ptr, 20);
 }
 
+int sum_backward(int* begin, int* end)
+{
+    int sum = 0;
+    while (end > begin) {
+        end--;
+        sum += *end;
+    }
+    return sum;
+}
+
+void test_pointer_model()
+{
+    int arr[6] = { 1, 2, 3, 4, 5, 6 };
+    int* begin = arr;
+    int* end = arr + 6;
+    int* mid = &arr[3];
+
+    is_eq(end - begin, 6);
+    is_eq(mid - begin, 3);
+    is_eq(begin - mid, -3);
+    is_eq(*(mid - 1), 3);
+    is_eq(mid[-2], 2);
+    is_true(begin < mid);
+    is_true(mid <= end);
+    is_true(end > mid);
+    is_true(mid == begin + 3);
+    is_true(mid != end);
+
+    int* p = mid;
+    p -= 2;
+    is_eq(*p, 2);
+    p--;
+    is_eq(p - begin, 0);
+
+    int n = 0;
+    for (p = begin; p < end; p++) {
+        n++;
+    }
+    is_eq(n, 6);
+    is_true(p == end);
+    is_eq(sum_backward(begin, mid + 1), 10);
+
+    double d[4] = { 1.5, 2.5, 3.5, 4.5 };
+    double* dp = &d[3];
+    is_eq(dp - &d[1], 2);
+    is_eq(*(dp - 3), 1.5);
+
+    // the offset is negative at runtime
+    int back = -2;
+    p = mid + back;
+    is_eq(*p, 2);
+    p = mid;
+    p += back;
+    is_eq(p - begin, 1);
+    is_eq(*(mid + back), 2);
+}
+
 void test_array_to_value_int()
 {
 	int aqq[1][1] = { { 5 } };
@@ -349,7 +406,7 @@ void test_struct_init()
 
 int main()
 {
-    plan(154);
+    plan(174);
 
 	START_TEST(struct_init);
 	START_TEST(array_increment);
@@ -817,6 +874,7 @@ int main()
 
     test_pointer_arith_size_t();
     test_pointer_minus_pointer();
+    test_pointer_model();
 
 	diag("calloc with struct");
 	{
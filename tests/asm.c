@@ -30,11 +30,25 @@ __inline__ unsigned long sqlite3Hwtime3(void)
     return val;
 }
 
+// Compiler barrier has not effect in Go and it is ignored.
+int add_with_barrier(int a, int b)
+{
+    int r = a + b;
+    __asm__ __volatile__(""
+                         :
+                         :
+                         : "memory");
+    return r;
+}
+
 int main()
 {
-    // There are no actual tests in this file because Go does not support inline
-    // assembly. We will have to revisit this in the future.
-    plan(0);
+    // Go does not support inline assembly, so the functions with inline
+    // assembly are transpiled as stubs and they are not called. The rest of
+    // file is transpiled as usual.
+    plan(1);
+
+    is_eq(add_with_barrier(2, 3), 5);
 
     done_testing();
 }
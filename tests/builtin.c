@@ -0,0 +1,63 @@
+// GCC builtin functions are transpiled to Go without functions of C library.
+
+#include "tests.h"
+
+#define likely(x) __builtin_expect(!!(x), 1)
+
+int sign(int x)
+{
+    if (x > 0) {
+        return 1;
+    }
+    if (x < 0) {
+        return -1;
+    }
+    if (x == 0) {
+        return 0;
+    }
+    __builtin_unreachable();
+}
+
+int main()
+{
+    plan(11);
+
+    unsigned int u = 0x00F0;
+    unsigned long long ull = 1;
+
+    diag("bits");
+    is_eq(__builtin_clz(u), 24);
+    is_eq(__builtin_ctz(u), 4);
+    is_eq(__builtin_popcount(u), 4);
+    is_eq(__builtin_clzll(ull), 63);
+    is_eq(__builtin_popcountll(~0ULL), 64);
+
+    diag("expect");
+    if (likely(u > 1)) {
+        pass("%s", "likely");
+    }
+
+    diag("unreachable");
+    is_eq(sign(-5), -1);
+
+    diag("memory");
+    int a[4] = { 1, 2, 3, 4 };
+    int b[4];
+    __builtin_memcpy(b, a, sizeof(a));
+    is_eq(b[3], 4);
+
+    char s[4];
+    __builtin_memset(s, 'x', 3);
+    s[3] = '\0';
+    is_streq(s, "xxx");
+
+    diag("offsetof");
+    struct point {
+        char c;
+        int x;
+    };
+    is_eq(__builtin_offsetof(struct point, x), 4);
+    is_eq(__builtin_offsetof(struct point, c), 0);
+
+    done_testing();
+}
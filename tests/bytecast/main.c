@@ -0,0 +1,10 @@
+#include <stdio.h>
+
+int main()
+{
+    unsigned short values[4] = { 1, 2, 3, 4 };
+    unsigned int* words = (unsigned int*)values;
+    words[0] = 0;
+    printf("%d %d %d", values[0], values[1], values[2]);
+    return 0;
+}
@@ -0,0 +1,88 @@
+#include "tests.h"
+#include <stdio.h>
+
+[[noreturn]] void stop(int code);
+
+[[nodiscard]] int twice(int x)
+{
+    return 2 * x;
+}
+
+[[deprecated("use twice")]] int old_twice(int x)
+{
+    return x + x;
+}
+
+void test_nullptr()
+{
+    diag("nullptr");
+    int* p = nullptr;
+    is_null(p);
+    int a = 5;
+    p = &a;
+    is_not_null(p);
+    p = nullptr;
+    is_true(p == nullptr);
+    char* s = nullptr;
+    is_true(s == NULL);
+}
+
+void test_typeof()
+{
+    diag("typeof");
+    int a = 3;
+    typeof(a) b = a + 1;
+    is_eq(b, 4);
+    typeof(a)* p = &b;
+    is_eq(*p, 4);
+    double d = 1.5;
+    typeof(d * 2) e = d * 2;
+    is_eq(e, 3.0);
+    is_eq(sizeof(e), sizeof(double));
+}
+
+void test_attributes()
+{
+    diag("attributes");
+    [[maybe_unused]] int unused = 1;
+    is_eq(twice(21), 42);
+    int sum = 0;
+    for (int i = 0; i < 3; i++) {
+        switch (i) {
+        case 0:
+            sum += 1;
+            [[fallthrough]];
+        case 1:
+            sum += 10;
+            break;
+        default:
+            sum += 100;
+        }
+    }
+    is_eq(sum, 121);
+}
+
+void test_literals()
+{
+    diag("literals");
+    is_eq(0b1010, 10);
+    is_eq(0B11111111, 255);
+    is_eq(1'000'000, 1000000);
+    is_eq(0xFF'FF, 65535);
+    unsigned long long big = 0b1'0000'0000'0000'0000'0000'0000'0000'0000ULL;
+    is_true(big == 4294967296ULL);
+    double d = 1'234.5'6;
+    is_eq(d, 1234.56);
+}
+
+int main()
+{
+    plan(16);
+
+    test_nullptr();
+    test_typeof();
+    test_attributes();
+    test_literals();
+
+    done_testing();
+}
@@ -0,0 +1,46 @@
+#include "tests.h"
+#include <stdio.h>
+
+static counter = 0;
+
+increment(step)
+{
+    counter += step;
+    return counter;
+}
+
+void test_implicit_int()
+{
+    diag("implicit int");
+    is_eq(increment(2), 2);
+    is_eq(increment(3), 5);
+    is_eq(counter, 5);
+}
+
+void test_implicit_declaration()
+{
+    diag("implicit declaration of function");
+    char c = 4;
+    is_eq(twice(c), 8);
+    is_eq(sum3(1, 2, 3), 6);
+}
+
+int twice(int a)
+{
+    return a * 2;
+}
+
+int sum3(int a, int b, int c)
+{
+    return a + b + c;
+}
+
+int main()
+{
+    plan(5);
+
+    test_implicit_int();
+    test_implicit_declaration();
+
+    done_testing();
+}
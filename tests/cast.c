@@ -50,6 +50,27 @@ void char_overflow()
     }
 }
 
+void char_arithmetic()
+{
+    diag("Multi-character constants and character arithmetic");
+    {
+        int magic = 'ABCD';
+        is_eq(magic, 0x41424344);
+        is_eq('AB', 16706);
+        char c = '7';
+        is_eq(c - '0', 7);
+        char h = 'f';
+        is_eq(h - 'a' + 10, 15);
+        char x = '\xff';
+        is_eq(x, -1);
+        is_eq(x - '0', -49);
+        int i = '\x80';
+        is_eq(i, -128);
+        unsigned char u = '\xff';
+        is_eq(u - '0', 207);
+    }
+}
+
 typedef double* vertex;
 void test_vertex()
 {
@@ -90,15 +111,82 @@ void test_bool_to_int(){
 	is_eq(x,11);
 }
 
+struct packet {
+    char kind;
+    unsigned int length;
+    short flags[2];
+    double value;
+};
+
+void test_byte_buffer()
+{
+    unsigned char buffer[48];
+    for (int i = 0; i < 48; i++) {
+        buffer[i] = 0;
+    }
+    buffer[0] = 7;
+    buffer[4] = 0x34;
+    buffer[5] = 0x12;
+    buffer[8] = 0xFF;
+    buffer[9] = 0xFF;
+    buffer[10] = 3;
+    buffer[23] = 0x40; // 2.0
+    buffer[24] = 9;
+
+    struct packet* p = (struct packet*)buffer;
+    is_eq(p->kind, 7);
+    is_eq(p->length, 0x1234);
+    is_eq(p->flags[0], -1);
+    is_eq(p->flags[1], 3);
+    is_eq(p->value, 2.0);
+    is_eq(p[1].kind, 9);
+
+    int* ints = (int*)(buffer + 4);
+    is_eq(ints[0], 0x1234);
+}
+
+void test_promotion()
+{
+    int x = 5;
+    unsigned char c = 0xF0;
+    unsigned short us = 65535;
+    short s = -300;
+    unsigned int u = 0;
+
+    // signed operand is converted to unsigned
+    is_false((unsigned)x > -1);
+    is_true(u - 1 > 0);
+    is_eq(-7 / 2u, 2147483644);
+    is_true(-1 < 1);
+
+    // char and short are promoted to int
+    is_eq(c << 4, 0xF00);
+    is_eq(us + 1, 65536);
+    is_eq(s * s, 90000);
+    is_eq(~c, -241);
+
+    // result is converted back
+    unsigned char r = ~c >> 4;
+    is_eq(r, 0xF0);
+    unsigned int m = -1;
+    is_eq(m, 4294967295u);
+    signed char sc = (signed char)200;
+    is_eq(sc, -56);
+    unsigned short t = (unsigned short)~0;
+    is_eq(t, 65535);
+}
+
 int main()
 {
-    plan(30);
+    plan(57);
 
 	START_TEST(bool_to_int);
     START_TEST(cast);
     START_TEST(castbool);
     START_TEST(vertex);
+    START_TEST(byte_buffer);
     START_TEST(strCh);
+    START_TEST(promotion);
 
     {
         typedef unsigned int u32;
@@ -180,6 +268,7 @@ int main()
     }
 
     char_overflow();
+    char_arithmetic();
 
     done_testing();
 }
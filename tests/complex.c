@@ -0,0 +1,126 @@
+#include "tests.h"
+#include <complex.h>
+#include <math.h>
+
+void test_parts()
+{
+    diag("parts of complex number");
+    double complex z = 3.0 + 4.0 * I;
+    float complex f = 1.5f - 2.0f * I;
+    long double complex l = 2.0 + 0.5 * I;
+
+    is_eq(creal(z), 3);
+    is_eq(cimag(z), 4);
+    is_eq(crealf(f), 1.5);
+    is_eq(cimagf(f), -2);
+    is_eq(creall(l), 2);
+    is_eq(cimagl(l), 0.5);
+    is_eq(__real__ z, 3);
+    is_eq(__imag__ f, -2);
+
+    is_eq(cabs(z), 5);
+    is_eq(cabsf(f), 2.5);
+    is_eq(carg(z), atan2(4, 3));
+    is_eq(cargf(I), M_PI / 2);
+    is_eq(carg(-1.0), M_PI);
+
+    is_eq(creal(conj(z)), 3);
+    is_eq(cimag(conj(z)), -4);
+    is_eq(cimagf(conjf(f)), 2);
+    is_true(isinf(creal(cproj(INFINITY - 2.0 * I))));
+    is_true(signbit(cimag(cproj(INFINITY - 2.0 * I))));
+    is_eq(cimag(cproj(z)), 4);
+}
+
+void test_arithmetic()
+{
+    diag("arithmetic of complex numbers");
+    double complex a = 1.0 + 2.0 * I;
+    double complex b = 3.0 - 1.0 * I;
+    double complex c;
+    float complex f = a;
+    double r = 2;
+
+    c = a + b;
+    is_eq(creal(c), 4);
+    is_eq(cimag(c), 1);
+    c = a * b;
+    is_eq(creal(c), 5);
+    is_eq(cimag(c), 5);
+    c = a / b;
+    is_eq(creal(c), 0.1);
+    is_eq(cimag(c), 0.7);
+    c = -a * r;
+    is_eq(creal(c), -2);
+    is_eq(cimag(c), -4);
+    is_true(a == f);
+    is_true(a != b);
+    is_eq(creal(f * I), -2);
+    r = a;
+    is_eq(r, 1);
+}
+
+void test_functions()
+{
+    diag("exponential and power functions");
+    double complex z = 1.0 + 1.0 * I;
+    double complex c;
+
+    c = cexp(I * M_PI);
+    is_eq(creal(c), -1);
+    is_eq(cimag(c), 0);
+    c = clog(-1.0);
+    is_eq(creal(c), 0);
+    is_eq(cimag(c), M_PI);
+    c = csqrt(-4.0);
+    is_eq(creal(c), 0);
+    is_eq(cimag(c), 2);
+    c = cpow(z, 2);
+    is_eq(creal(c), 0);
+    is_eq(cimag(c), 2);
+    is_eq(crealf(cexpf(0)), 1);
+    is_eq(cimagf(csqrtf(-1)), 1);
+    is_eq(crealf(cpowf(2, 3)), 8);
+
+    diag("trigonometric and hyperbolic functions");
+    c = csin(z);
+    is_eq(creal(c), sin(1) * cosh(1));
+    is_eq(cimag(c), cos(1) * sinh(1));
+    c = ccos(z);
+    is_eq(creal(c), cos(1) * cosh(1));
+    is_eq(cimag(c), -sin(1) * sinh(1));
+    c = ctan(0.5);
+    is_eq(creal(c), tan(0.5));
+    c = csin(casin(z));
+    is_eq(creal(c), 1);
+    is_eq(cimag(c), 1);
+    c = ccos(cacos(z));
+    is_eq(creal(c), 1);
+    c = ctan(catan(z));
+    is_eq(cimag(c), 1);
+    c = csinh(z);
+    is_eq(creal(c), sinh(1) * cos(1));
+    c = ccosh(z);
+    is_eq(creal(c), cosh(1) * cos(1));
+    c = ctanh(0.5);
+    is_eq(creal(c), tanh(0.5));
+    c = csinh(casinh(z));
+    is_eq(creal(c), 1);
+    c = ccosh(cacosh(z));
+    is_eq(cimag(c), 1);
+    c = ctanh(catanh(z));
+    is_eq(creal(c), 1);
+    is_eq(crealf(csinf(0)), 0);
+    is_eq(crealf(ccoshf(0)), 1);
+}
+
+int main()
+{
+    plan(59);
+
+    test_parts();
+    test_arithmetic();
+    test_functions();
+
+    done_testing();
+}
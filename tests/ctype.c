@@ -26,7 +26,7 @@ char arrnul[] = "this string has a \0 NUL";
 
 int main()
 {
-    plan(112);
+    plan(126);
 
     //              . Lower alpha (a)
     //              |  . Upper alpha (B)
@@ -70,5 +70,25 @@ int main()
     is_eq(toupper('\n'), '\n');
     is_eq(toupper('z'), 'Z');
 
+    diag("EOF and not ASCII characters");
+    is_false(isalpha(EOF));
+    is_false(isspace(EOF));
+    is_eq(toupper(EOF), EOF);
+    is_eq(tolower(EOF), EOF);
+    is_false(isalpha(0xE9));
+    is_false(isprint(0xA0));
+    is_false(isspace(0x85));
+    is_eq(toupper(0xE9), 0xE9);
+    is_eq(tolower(0xC9), 0xC9);
+    signed char sc = -23;
+    is_false(isalpha(sc));
+    is_eq(toupper(sc), 233);
+
+    diag("classification by pointer to function");
+    int (*classify)(int) = isxdigit;
+    is_true(classify('f'));
+    is_false(classify('g'));
+    is_eq(isalpha('a'), (isalpha)('a'));
+
     done_testing();
 }
@@ -0,0 +1,87 @@
+// This file contains tests for the dirent.h functions.
+
+#include "tests.h"
+#include <dirent.h>
+#include <stdio.h>
+#include <string.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_readdir()
+{
+    DIR* dir = opendir("tests");
+    is_not_null(dir);
+
+    int count = 0;
+    int found = 0;
+    int dot = 0;
+    struct dirent* entry;
+    while ((entry = readdir(dir)) != NULL) {
+        count++;
+        if (strcmp(entry->d_name, "dirent.c") == 0) {
+            found++;
+            is_eq(entry->d_type, DT_REG);
+            is_eq(entry->d_reclen, 32);
+        }
+        if (strcmp(entry->d_name, ".") == 0) {
+            dot++;
+            is_eq(entry->d_type, DT_DIR);
+        }
+    }
+    is_true(count > 2);
+    is_eq(found, 1);
+    is_eq(dot, 1);
+
+    // read again after rewind
+    rewinddir(dir);
+    int second = 0;
+    while (readdir(dir) != NULL) {
+        second++;
+    }
+    is_eq(second, count);
+
+    is_eq(closedir(dir), 0);
+}
+
+void test_readdir_r()
+{
+    DIR* dir = opendir("tests");
+    struct dirent entry;
+    struct dirent* result;
+    int found = 0;
+    int errors = 0;
+    for (;;) {
+        if (readdir_r(dir, &entry, &result) != 0) {
+            errors++;
+            break;
+        }
+        if (result == NULL) {
+            break;
+        }
+        if (strcmp(result->d_name, "dirent.c") == 0) {
+            found++;
+        }
+    }
+    is_eq(errors, 0);
+    is_eq(found, 1);
+    closedir(dir);
+}
+
+void test_opendir_fail()
+{
+    is_null(opendir("tests/not_exist_dir"));
+    is_null(opendir("tests/dirent.c"));
+}
+
+int main()
+{
+    plan(13);
+
+    START_TEST(readdir);
+    START_TEST(readdir_r);
+    START_TEST(opendir_fail);
+
+    done_testing();
+}
@@ -0,0 +1,73 @@
+// This file contains tests for the byte order conversion functions and the
+// inspection of bytes of integers.
+
+#include "tests.h"
+#include <arpa/inet.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+int is_little_endian()
+{
+    unsigned int one = 1;
+    unsigned char* b = (unsigned char*)&one;
+    return b[0] == 1;
+}
+
+void test_bswap()
+{
+    is_eq(__builtin_bswap16(0x0102), 0x0201);
+    is_eq(__builtin_bswap32(0x01020304), 0x04030201);
+    is_true(__builtin_bswap64(0x0102030405060708ULL) == 0x0807060504030201ULL);
+}
+
+void test_network_order()
+{
+    uint32_t n = htonl(0x01020304);
+    unsigned char* b = (unsigned char*)&n;
+    is_eq(b[0], 1);
+    is_eq(b[1], 2);
+    is_eq(b[2], 3);
+    is_eq(b[3], 4);
+    is_eq(ntohl(n), 0x01020304);
+
+    uint16_t s = htons(0x0102);
+    b = (unsigned char*)&s;
+    is_eq(b[0], 1);
+    is_eq(b[1], 2);
+    is_eq(ntohs(s), 0x0102);
+}
+
+void test_host_order()
+{
+    unsigned int x = 0x01020304;
+    unsigned char* b = (unsigned char*)&x;
+    if (is_little_endian()) {
+        is_eq(b[0], 4);
+        is_eq(htonl(x), __builtin_bswap32(x));
+    } else {
+        is_eq(b[0], 1);
+        is_eq(htonl(x), x);
+    }
+
+    // bytes are changed in memory of value
+    b[0] = 0;
+    b[1] = 0;
+    b[2] = 0;
+    b[3] = 0x10;
+    is_eq(ntohl(x), 0x10);
+}
+
+int main()
+{
+    plan(14);
+
+    START_TEST(bswap);
+    START_TEST(network_order);
+    START_TEST(host_order);
+
+    done_testing();
+}
@@ -0,0 +1,132 @@
+// This file contains tests for the environment functions from stdlib.h and
+// the variable environ.
+
+#include "tests.h"
+#include <stdlib.h>
+#include <string.h>
+#include <unistd.h>
+
+extern char** environ;
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+// find returns the index of string in environ or -1
+int find(const char* s)
+{
+    int i;
+    for (i = 0; environ[i] != NULL; i++) {
+        if (strcmp(environ[i], s) == 0) {
+            return i;
+        }
+    }
+    return -1;
+}
+
+int size()
+{
+    int i = 0;
+    if (environ == NULL) {
+        return 0;
+    }
+    while (environ[i] != NULL) {
+        i++;
+    }
+    return i;
+}
+
+void test_setenv()
+{
+    is_null(getenv("C4GO_ENV_TEST"));
+    is_eq(setenv("C4GO_ENV_TEST", "first", 0), 0);
+    is_streq(getenv("C4GO_ENV_TEST"), "first");
+    is_true(find("C4GO_ENV_TEST=first") >= 0);
+
+    // without overwrite
+    is_eq(setenv("C4GO_ENV_TEST", "second", 0), 0);
+    is_streq(getenv("C4GO_ENV_TEST"), "first");
+
+    // with overwrite
+    is_eq(setenv("C4GO_ENV_TEST", "second", 1), 0);
+    is_streq(getenv("C4GO_ENV_TEST"), "second");
+    is_eq(find("C4GO_ENV_TEST=first"), -1);
+    is_true(find("C4GO_ENV_TEST=second") >= 0);
+
+    // empty value
+    is_eq(setenv("C4GO_ENV_TEST", "", 1), 0);
+    is_streq(getenv("C4GO_ENV_TEST"), "");
+
+    // wrong names
+    is_eq(setenv("", "value", 1), -1);
+    is_eq(setenv("C4GO=ENV", "value", 1), -1);
+}
+
+void test_unsetenv()
+{
+    int n = size();
+    is_eq(unsetenv("C4GO_ENV_TEST"), 0);
+    is_null(getenv("C4GO_ENV_TEST"));
+    is_eq(size(), n - 1);
+
+    // not exist variable
+    is_eq(unsetenv("C4GO_ENV_TEST"), 0);
+    is_eq(size(), n - 1);
+
+    // wrong names
+    is_eq(unsetenv(""), -1);
+    is_eq(unsetenv("C4GO=ENV"), -1);
+}
+
+void test_putenv()
+{
+    static char s[] = "C4GO_PUTENV=value";
+    int n = size();
+    is_eq(putenv(s), 0);
+    is_streq(getenv("C4GO_PUTENV"), "value");
+    is_eq(size(), n + 1);
+    is_true(find("C4GO_PUTENV=value") >= 0);
+
+    // string is a part of environment
+    is_true(environ[find("C4GO_PUTENV=value")] == s);
+
+    // remove by name without '='
+    is_eq(putenv("C4GO_PUTENV"), 0);
+    is_null(getenv("C4GO_PUTENV"));
+    is_eq(size(), n);
+}
+
+void test_environ()
+{
+    is_not_null(environ);
+    is_true(size() > 0);
+    is_true(find("C4GO_ENVIRON=1") < 0);
+    is_eq(setenv("C4GO_ENVIRON", "1", 1), 0);
+    is_true(find("C4GO_ENVIRON=1") >= 0);
+    is_eq(unsetenv("C4GO_ENVIRON"), 0);
+    is_eq(find("C4GO_ENVIRON=1"), -1);
+}
+
+void test_clearenv()
+{
+    is_eq(clearenv(), 0);
+    is_null(environ);
+    is_eq(size(), 0);
+    is_null(getenv("PATH"));
+    is_eq(setenv("C4GO_ENV_TEST", "value", 0), 0);
+    is_eq(size(), 1);
+    is_streq(environ[0], "C4GO_ENV_TEST=value");
+}
+
+int main()
+{
+    plan(43);
+
+    START_TEST(setenv);
+    START_TEST(unsetenv);
+    START_TEST(putenv);
+    START_TEST(environ);
+    START_TEST(clearenv);
+
+    done_testing();
+}
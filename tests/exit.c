@@ -1,10 +1,65 @@
 #include "tests.h"
 #include <stdlib.h>
 
+void first()
+{
+    printf("first handler\n");
+}
+
+void second()
+{
+    printf("second handler\n");
+}
+
+void nested()
+{
+    printf("nested handler\n");
+}
+
+void registers_nested()
+{
+    printf("handler registers another handler\n");
+    atexit(nested);
+}
+
+void quick()
+{
+    printf("quick handler is not called by exit()\n");
+}
+
+int initialized = 0;
+
+__attribute__((constructor)) void setup()
+{
+    initialized++;
+    printf("constructor %d\n", initialized);
+}
+
+__attribute__((constructor(101))) void setup_first()
+{
+    printf("constructor with priority is called first\n");
+}
+
+__attribute__((destructor)) void cleanup()
+{
+    printf("destructor is called after handlers\n");
+}
+
 int main()
 {
     plan(0);
 
+    printf("main after constructors: %d\n", initialized);
+
+    // handlers are called in the reverse order of registration
+    atexit(first);
+    atexit(second);
+    atexit(registers_nested);
+    atexit(second);
+    at_quick_exit(quick);
+
+    printf("output is flushed at exit");
+
     exit(123);
 
     // done_testing() is not needed because this is unreachable.
@@ -0,0 +1,16 @@
+#include <stdio.h>
+
+// Variable `level` is defined in file main2.c. Variable `counter` has
+// tentative definitions in both files.
+extern int level;
+int counter;
+
+void increment(void);
+
+int main()
+{
+    increment();
+    counter++;
+    printf("%d%d", level, counter);
+    return 0;
+}
@@ -0,0 +1,7 @@
+int level = 3;
+int counter;
+
+void increment(void)
+{
+    counter += 4;
+}
@@ -0,0 +1,131 @@
+#include "tests.h"
+#include <fenv.h>
+#include <math.h>
+
+void test_round()
+{
+    diag("rounding modes");
+    is_eq(fegetround(), FE_TONEAREST);
+    is_eq(rint(2.5), 2);
+    is_eq(lrint(-2.5), -2);
+
+    is_eq(fesetround(FE_TOWARDZERO), 0);
+    is_eq(fegetround(), FE_TOWARDZERO);
+    is_eq(rint(2.7), 2);
+    is_eq(rint(-2.7), -2);
+    is_eq(llrint(-2.7), -2);
+
+    is_eq(fesetround(FE_DOWNWARD), 0);
+    is_eq(nearbyint(2.7), 2);
+    is_eq(lrint(-2.2), -3);
+
+    is_eq(fesetround(FE_UPWARD), 0);
+    is_eq(nearbyint(2.2), 3);
+    is_eq(llrint(-2.7), -2);
+
+    // round() does not depend on the rounding mode
+    is_eq(round(2.5), 3);
+    is_eq(lround(-2.5), -3);
+
+    is_not_eq(fesetround(-1), 0);
+    is_eq(fegetround(), FE_UPWARD);
+    is_eq(fesetround(FE_TONEAREST), 0);
+}
+
+// Flags are tested before checks, because checks of tests.h use the
+// floating-point arithmetic, that raises exceptions in C.
+void test_except()
+{
+    double r;
+    int raised;
+
+    diag("exception flags");
+    feclearexcept(FE_ALL_EXCEPT);
+    raised = fetestexcept(FE_ALL_EXCEPT);
+    is_eq(raised, 0);
+
+    // exact result
+    feclearexcept(FE_ALL_EXCEPT);
+    r = rint(3.0);
+    raised = fetestexcept(FE_INEXACT);
+    is_eq(r, 3);
+    is_eq(raised, 0);
+
+    // inexact result
+    feclearexcept(FE_ALL_EXCEPT);
+    r = rint(3.5);
+    raised = fetestexcept(FE_INEXACT | FE_INVALID);
+    is_eq(r, 4);
+    is_eq(raised, FE_INEXACT);
+
+    // nearbyint() does not raise FE_INEXACT
+    feclearexcept(FE_ALL_EXCEPT);
+    r = nearbyint(3.5);
+    raised = fetestexcept(FE_INEXACT);
+    is_eq(r, 4);
+    is_eq(raised, 0);
+
+    // out of range
+    feclearexcept(FE_ALL_EXCEPT);
+    llrint(NAN);
+    raised = fetestexcept(FE_INVALID);
+    is_eq(raised, FE_INVALID);
+
+    feclearexcept(FE_ALL_EXCEPT);
+    is_eq(feraiseexcept(FE_OVERFLOW | FE_DIVBYZERO), 0);
+    raised = fetestexcept(FE_OVERFLOW | FE_UNDERFLOW | FE_DIVBYZERO);
+    is_eq(raised, FE_OVERFLOW | FE_DIVBYZERO);
+
+    fexcept_t flags;
+    is_eq(fegetexceptflag(&flags, FE_OVERFLOW | FE_INVALID), 0);
+    feclearexcept(FE_ALL_EXCEPT);
+    fesetexceptflag(&flags, FE_OVERFLOW | FE_INVALID);
+    raised = fetestexcept(FE_OVERFLOW | FE_INVALID | FE_DIVBYZERO);
+    is_eq(raised, FE_OVERFLOW);
+    feclearexcept(FE_ALL_EXCEPT);
+}
+
+void test_env()
+{
+    int raised;
+
+    diag("environment");
+    fenv_t env;
+    fesetround(FE_DOWNWARD);
+    feclearexcept(FE_ALL_EXCEPT);
+    feraiseexcept(FE_DIVBYZERO);
+    is_eq(fegetenv(&env), 0);
+
+    fesetround(FE_TONEAREST);
+    feclearexcept(FE_ALL_EXCEPT);
+    is_eq(fesetenv(&env), 0);
+    is_eq(fegetround(), FE_DOWNWARD);
+    raised = fetestexcept(FE_DIVBYZERO);
+    is_eq(raised, FE_DIVBYZERO);
+
+    // feholdexcept() clears flags, feupdateenv() merges them
+    fenv_t saved;
+    feholdexcept(&saved);
+    raised = fetestexcept(FE_ALL_EXCEPT);
+    is_eq(raised, 0);
+    fesetround(FE_UPWARD);
+    feraiseexcept(FE_UNDERFLOW);
+    feupdateenv(&saved);
+    raised = fetestexcept(FE_DIVBYZERO | FE_UNDERFLOW | FE_OVERFLOW);
+    is_eq(raised, FE_DIVBYZERO | FE_UNDERFLOW);
+    is_eq(fegetround(), FE_DOWNWARD);
+
+    fesetround(FE_TONEAREST);
+    feclearexcept(FE_ALL_EXCEPT);
+}
+
+int main()
+{
+    plan(38);
+
+    test_round();
+    test_except();
+    test_env();
+
+    done_testing();
+}
@@ -0,0 +1,168 @@
+// This file contains tests for the ftw.h functions.
+
+#define _XOPEN_SOURCE 500
+
+#include "tests.h"
+#include <ftw.h>
+#include <stdio.h>
+#include <string.h>
+#include <sys/stat.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+#define ROOT "/tmp/c4go_ftw_test"
+
+int files = 0;
+int dirs = 0;
+int post = 0;
+int links = 0;
+int max_level = 0;
+int order_ok = 1;
+int base_ok = 1;
+int size = 0;
+
+int count_entry(const char* path, const struct stat* sb, int type)
+{
+    if (type == FTW_F) {
+        files++;
+        if (strcmp(path, ROOT "/sub/data.txt") == 0) {
+            size = sb->st_size;
+        }
+    }
+    if (type == FTW_D) {
+        dirs++;
+        if (!S_ISDIR(sb->st_mode)) {
+            order_ok = 0;
+        }
+    }
+    return 0;
+}
+
+int walk_entry(const char* path, const struct stat* sb, int type,
+    struct FTW* ftwbuf)
+{
+    if (strcmp(path + ftwbuf->base, "sub") == 0 && ftwbuf->level != 1) {
+        base_ok = 0;
+    }
+    if (ftwbuf->level > max_level) {
+        max_level = ftwbuf->level;
+    }
+    switch (type) {
+    case FTW_F:
+        files++;
+        break;
+    case FTW_D:
+        dirs++;
+        break;
+    case FTW_DP:
+        post++;
+        // root is reported last
+        if (strcmp(path, ROOT) == 0 && files + links != 4) {
+            order_ok = 0;
+        }
+        break;
+    case FTW_SL:
+        links++;
+        break;
+    }
+    return 0;
+}
+
+int stop_entry(const char* path, const struct stat* sb, int type,
+    struct FTW* ftwbuf)
+{
+    if (type == FTW_F) {
+        return 42;
+    }
+    return 0;
+}
+
+int remove_entry(const char* path, const struct stat* sb, int type,
+    struct FTW* ftwbuf)
+{
+    return remove(path);
+}
+
+void write_file(const char* name, const char* content)
+{
+    FILE* f = fopen(name, "w");
+    fputs(content, f);
+    fclose(f);
+}
+
+void reset()
+{
+    files = 0;
+    dirs = 0;
+    post = 0;
+    links = 0;
+    max_level = 0;
+    order_ok = 1;
+    base_ok = 1;
+}
+
+void test_ftw()
+{
+    reset();
+    is_eq(ftw(ROOT, count_entry, 8), 0);
+    // the link to file is followed
+    is_eq(files, 4);
+    is_eq(dirs, 3);
+    is_eq(size, 5);
+    is_true(order_ok);
+
+    is_eq(ftw(ROOT "/not_exist", count_entry, 8), -1);
+}
+
+void test_nftw()
+{
+    reset();
+    is_eq(nftw(ROOT, walk_entry, 8, FTW_PHYS), 0);
+    is_eq(files, 3);
+    is_eq(dirs, 3);
+    is_eq(links, 1);
+    is_eq(post, 0);
+    is_eq(max_level, 3);
+    is_true(base_ok);
+}
+
+void test_nftw_depth()
+{
+    reset();
+    is_eq(nftw(ROOT, walk_entry, 8, FTW_PHYS | FTW_DEPTH), 0);
+    is_eq(dirs, 0);
+    is_eq(post, 3);
+    is_true(order_ok);
+
+    is_eq(nftw(ROOT, stop_entry, 8, FTW_PHYS), 42);
+}
+
+void test_nftw_remove()
+{
+    is_eq(nftw(ROOT, remove_entry, 8, FTW_PHYS | FTW_DEPTH), 0);
+    struct stat st;
+    is_eq(stat(ROOT, &st), -1);
+}
+
+int main()
+{
+    plan(20);
+
+    mkdir(ROOT, 0755);
+    mkdir(ROOT "/sub", 0755);
+    mkdir(ROOT "/sub/deep", 0755);
+    write_file(ROOT "/a.txt", "a");
+    write_file(ROOT "/sub/data.txt", "12345");
+    write_file(ROOT "/sub/deep/b.txt", "b");
+    symlink("a.txt", ROOT "/link.txt");
+
+    START_TEST(ftw);
+    START_TEST(nftw);
+    START_TEST(nftw_depth);
+    START_TEST(nftw_remove);
+
+    done_testing();
+}
@@ -154,6 +154,50 @@ void test_string()
     is_streq(readline("rt", NULL, NULL), "rt");
 }
 
+typedef struct state {
+    int acc;
+} state;
+
+int op_inc(state* s)
+{
+    s->acc += 1;
+    return s->acc;
+}
+
+int op_dec(state* s)
+{
+    s->acc -= 1;
+    return s->acc;
+}
+
+int op_dbl(state* s)
+{
+    s->acc *= 2;
+    return s->acc;
+}
+
+int (*dispatch[16])(state*) = { op_inc, op_dec };
+
+void test_dispatch_table()
+{
+    diag("Arrays of function pointers");
+    state s;
+    s.acc = 0;
+    dispatch[2] = op_dbl;
+    is_eq(dispatch[0](&s), 1);
+    is_eq(dispatch[2](&s), 2);
+    is_eq((*dispatch[1])(&s), 1);
+
+    int (*local[3])(state*) = { op_dbl, op_inc, op_dbl };
+    int k;
+    for (k = 0; k < 3; k++) {
+        local[k](&s);
+    }
+    is_eq(s.acc, 6);
+    local[0] = op_dec;
+    is_eq(local[0](&s), 5);
+}
+
 int run_function(int a, void *v, char ** c,  void (*f)(void) ){
 	(void)(v);
 	(void)(c);
@@ -164,12 +208,74 @@ void test_null_function(){
 	is_eq(run_function(5,   0,   0,   0),5);
 }
 
+int next_id()
+{
+    static int counter = 0;
+    counter++;
+    return counter;
+}
+
+int other_id()
+{
+    static int counter = 100;
+    {
+        static int counter = 10;
+        counter += 2;
+    }
+    counter++;
+    return counter;
+}
+
+const char* history(char c)
+{
+    static char buf[8];
+    static int len;
+    if (len < 7) {
+        buf[len] = c;
+        len++;
+    }
+    return buf;
+}
+
+void test_static_locals()
+{
+    diag("static local variables");
+    is_eq(next_id(), 1);
+    is_eq(next_id(), 2);
+    is_eq(other_id(), 101);
+    is_eq(next_id(), 3);
+    is_eq(other_id(), 102);
+    history('a');
+    history('b');
+    is_streq(history('c'), "abc");
+}
+
+int knr_scale();
+
+void test_knr()
+{
+    diag("old-style function definitions");
+    char c = 3;
+    is_eq(knr_scale(2.5, c, "ab"), 8);
+}
+
+int knr_scale(x, n, s)
+    float x;
+    char n;
+    char *s;
+{
+    return (int)(x * n) + s[1] - s[0];
+}
+
 int main()
 {
-    plan(53);
+    plan(65);
 
     test_string();
+    test_dispatch_table();
 	test_null_function();
+	test_static_locals();
+	test_knr();
 
     pass("%s", "Main function.");
 
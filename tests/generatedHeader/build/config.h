@@ -0,0 +1 @@
+#define HAVE_CONFIG_H 1
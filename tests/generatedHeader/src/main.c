@@ -0,0 +1,11 @@
+#include <stdio.h>
+#include "version.h"
+
+// Headers config.h and version.h are generated in the build folder. File
+// config.h is not included in the source, it is added by option -include.
+int main(){
+#ifdef HAVE_CONFIG_H
+	printf("%s", VERSION);
+#endif
+	return 0;
+}
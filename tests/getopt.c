@@ -0,0 +1,123 @@
+// This file contains tests for the getopt.h and unistd.h functions getopt(),
+// getopt_long() and the global variables optind, optarg, opterr.
+
+#include "tests.h"
+#include <getopt.h>
+#include <stdio.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_getopt()
+{
+    char* args[] = { "prog", "-a", "-b", "value", "-cvalue2", "file" };
+    int argc = 6;
+    int c;
+    int a = 0;
+
+    optind = 0;
+    while ((c = getopt(argc, args, "ab:c:")) != -1) {
+        switch (c) {
+        case 'a':
+            a++;
+            break;
+        case 'b':
+            is_streq(optarg, "value");
+            break;
+        case 'c':
+            is_streq(optarg, "value2");
+            break;
+        default:
+            fail("unknown option");
+        }
+    }
+    is_eq(a, 1);
+    is_eq(optind, 5);
+    is_streq(args[optind], "file");
+}
+
+void test_getopt_errors()
+{
+    char* args[] = { "prog", "-x", "-b" };
+    int argc = 3;
+
+    optind = 0;
+    opterr = 0;
+    is_eq(getopt(argc, args, "b:"), '?');
+    is_eq(optopt, 'x');
+    is_eq(getopt(argc, args, "b:"), '?');
+    is_eq(optopt, 'b');
+    is_eq(getopt(argc, args, "b:"), -1);
+
+    optind = 0;
+    is_eq(getopt(argc, args, ":b:"), '?');
+    is_eq(getopt(argc, args, ":b:"), ':');
+    opterr = 1;
+}
+
+void test_getopt_permute()
+{
+    char* args[] = { "prog", "first", "-a", "second", "--", "-b" };
+    int argc = 6;
+
+    optind = 0;
+    is_eq(getopt(argc, args, "ab"), 'a');
+    is_eq(getopt(argc, args, "ab"), -1);
+    is_eq(optind, 3);
+    is_streq(args[optind], "first");
+    is_streq(args[optind + 1], "second");
+    is_streq(args[optind + 2], "-b");
+}
+
+void test_getopt_long()
+{
+    int flag = 0;
+    int index = -1;
+    struct option long_options[] = {
+        { "verbose", no_argument, &flag, 1 },
+        { "output", required_argument, 0, 'o' },
+        { "level", optional_argument, 0, 'l' },
+        { 0, 0, 0, 0 }
+    };
+    char* args[] = { "prog", "--verbose", "--output=out.txt", "--out",
+        "second.txt", "--level", "--lev=3", "-o", "third.txt" };
+    int argc = 9;
+
+    optind = 0;
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), 0);
+    is_eq(flag, 1);
+    is_eq(index, 0);
+
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), 'o');
+    is_streq(optarg, "out.txt");
+    is_eq(index, 1);
+
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), 'o');
+    is_streq(optarg, "second.txt");
+
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), 'l');
+    is_true(optarg == NULL);
+
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), 'l');
+    is_streq(optarg, "3");
+
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), 'o');
+    is_streq(optarg, "third.txt");
+
+    is_eq(getopt_long(argc, args, "o:l::", long_options, &index), -1);
+    is_eq(optind, argc);
+}
+
+int main()
+{
+    plan(34);
+
+    START_TEST(getopt);
+    START_TEST(getopt_errors);
+    START_TEST(getopt_permute);
+    START_TEST(getopt_long);
+
+    done_testing();
+}
@@ -61,13 +61,150 @@ mylabel:
     is_eq(i, 15);
 }
 
+int cleanup(int n)
+{
+    int result = -1;
+    if (n < 0) {
+        goto fail;
+    }
+    int twice = n * 2;
+    if (twice > 10) {
+        goto fail;
+    }
+    result = twice;
+fail:
+    return result;
+}
+
+void test_goto_cleanup()
+{
+    is_eq(cleanup(-1), -1);
+    is_eq(cleanup(3), 6);
+    is_eq(cleanup(6), -1);
+}
+
+void test_goto_backward()
+{
+    int i = 0;
+again:
+    i++;
+    int square = i * i;
+    if (square < 50) {
+        goto again;
+    }
+    is_eq(i, 8);
+}
+
+void test_goto_unused_label()
+{
+    int i = 0;
+unused:
+    i++;
+    is_eq(i, 1);
+}
+
+int into_loop(int n)
+{
+    int i = 0, sum = 0;
+    goto inside;
+    for (i = 0; i < n; i++) {
+        sum += 10;
+    inside:
+        sum += i;
+    }
+    return sum;
+}
+
+void test_goto_into_loop()
+{
+    is_eq(into_loop(3), 23);
+    is_eq(into_loop(0), 0);
+}
+
+int into_else(int n)
+{
+    int r = 0;
+    if (n > 5) {
+        r = 1;
+        goto second;
+    } else if (n > 3) {
+        r = 2;
+    } else {
+        int x = 3;
+        r += x;
+    second:
+        r += 100;
+    }
+    return r;
+}
+
+void test_goto_into_else()
+{
+    is_eq(into_else(6), 101);
+    is_eq(into_else(4), 2);
+    is_eq(into_else(1), 103);
+}
+
+enum { OP_HALT,
+    OP_INC,
+    OP_DEC,
+    OP_DBL };
+
+int interpret(const int* code)
+{
+    static void* dispatch[] = { &&op_halt, &&op_inc, &&op_dec, &&op_dbl };
+    int acc = 0;
+    int pc = 0;
+    goto *dispatch[code[pc++]];
+op_inc:
+    acc++;
+    goto *dispatch[code[pc++]];
+op_dec:
+    acc--;
+    goto *dispatch[code[pc++]];
+op_dbl:
+    acc *= 2;
+    goto *dispatch[code[pc++]];
+op_halt:
+    return acc;
+}
+
+int label_variable(int n)
+{
+    void* next = &&odd;
+    if (n % 2 == 0) {
+        next = &&even;
+    }
+    goto *next;
+even:
+    return 0;
+odd:
+    return 1;
+}
+
+void test_computed_goto()
+{
+    int code[] = { OP_INC, OP_INC, OP_DBL, OP_DEC, OP_DBL, OP_HALT };
+    is_eq(interpret(code), 6);
+    int empty[] = { OP_HALT };
+    is_eq(interpret(empty), 0);
+    is_eq(label_variable(4), 0);
+    is_eq(label_variable(7), 1);
+}
+
 int main()
 {
-    plan(4);
+    plan(18);
 
     START_TEST(goto1)
     START_TEST(goto2)
     START_TEST(goto_stmt)
+    START_TEST(goto_cleanup)
+    START_TEST(goto_backward)
+    START_TEST(goto_unused_label)
+    START_TEST(goto_into_loop)
+    START_TEST(goto_into_else)
+    START_TEST(computed_goto)
 
     done_testing();
 }
@@ -0,0 +1,103 @@
+// This file contains tests for the iconv.h functions.
+
+#include "tests.h"
+#include <iconv.h>
+#include <stdio.h>
+#include <string.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+// convert returns the amount of bytes of output or -1 on error.
+int convert(const char* to, const char* from, char* input, size_t size,
+    char* output, size_t outsize)
+{
+    iconv_t cd = iconv_open(to, from);
+    if (cd == (iconv_t)-1) {
+        return -1;
+    }
+    char* in = input;
+    char* out = output;
+    size_t inleft = size;
+    size_t outleft = outsize;
+    size_t r = iconv(cd, &in, &inleft, &out, &outleft);
+    iconv_close(cd);
+    if (r == (size_t)-1) {
+        return -1;
+    }
+    return (int)(outsize - outleft);
+}
+
+void test_latin1()
+{
+    char output[16];
+    is_eq(convert("ISO-8859-1", "UTF-8", "caf\xc3\xa9", 5, output, 16), 4);
+    is_true(memcmp(output, "caf\xe9", 4) == 0);
+
+    is_eq(convert("UTF-8", "ISO-8859-1", "caf\xe9", 4, output, 16), 5);
+    is_true(memcmp(output, "caf\xc3\xa9", 5) == 0);
+}
+
+void test_utf16()
+{
+    char output[16];
+    is_eq(convert("UTF-16LE", "UTF-8", "ab", 2, output, 16), 4);
+    is_true(memcmp(output, "a\0b\0", 4) == 0);
+
+    is_eq(convert("UTF-8", "UTF-16BE", "\0a\0b", 4, output, 16), 2);
+    is_true(memcmp(output, "ab", 2) == 0);
+}
+
+void test_errors()
+{
+    char output[16];
+    is_true(iconv_open("UTF-8", "c4go-not-exist") == (iconv_t)-1);
+
+    // character is not in Latin-1
+    is_eq(convert("ISO-8859-1", "UTF-8", "a\xd0\xbc", 3, output, 16), -1);
+
+    // output buffer is too small
+    is_eq(convert("UTF-8", "ISO-8859-1", "caf\xe9", 4, output, 4), -1);
+}
+
+void test_parts()
+{
+    iconv_t cd = iconv_open("ISO-8859-1", "UTF-8");
+    char input[] = "\xc3\xa9t\xc3\xa9";
+    char output[16];
+    char* in = input;
+    char* out = output;
+    size_t inleft = 2;
+    size_t outleft = sizeof(output);
+
+    // the first character
+    is_eq(iconv(cd, &in, &inleft, &out, &outleft), 0);
+    is_eq(inleft, 0);
+    is_eq(sizeof(output) - outleft, 1);
+
+    // incomplete character at the end of input
+    inleft = 2;
+    is_eq(iconv(cd, &in, &inleft, &out, &outleft), (size_t)-1);
+    is_eq(inleft, 1);
+    inleft = 2;
+    is_eq(iconv(cd, &in, &inleft, &out, &outleft), 0);
+    is_eq(sizeof(output) - outleft, 3);
+    is_true(memcmp(output, "\xe9t\xe9", 3) == 0);
+
+    // reset of state
+    is_eq(iconv(cd, NULL, NULL, &out, &outleft), 0);
+    is_eq(iconv_close(cd), 0);
+}
+
+int main()
+{
+    plan(21);
+
+    START_TEST(latin1);
+    START_TEST(utf16);
+    START_TEST(errors);
+    START_TEST(parts);
+
+    done_testing();
+}
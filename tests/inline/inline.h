@@ -0,0 +1,11 @@
+#ifndef INLINE_H
+#define INLINE_H
+
+// C99 inline definition. The external definition is emitted by main2.c.
+inline int twice(int a) { return 2 * a; }
+
+// GNU inline definition is used only for inlining. The external definition
+// is in main2.c.
+extern inline __attribute__((gnu_inline)) int square(int a) { return a * a; }
+
+#endif /* INLINE_H */
@@ -0,0 +1,8 @@
+#include <stdio.h>
+#include "inline.h"
+
+int main() {
+    printf("%d", twice(3));
+    printf("%d", square(4));
+    return 0;
+}
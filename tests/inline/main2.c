@@ -0,0 +1,6 @@
+#include "inline.h"
+
+// Makes the inline definition of the header an external definition.
+extern inline int twice(int a);
+
+int square(int a) { return a * a; }
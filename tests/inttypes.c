@@ -0,0 +1,101 @@
+#include "tests.h"
+#include <inttypes.h>
+#include <stdio.h>
+#include <string.h>
+
+void test_format()
+{
+    diag("format macros");
+    char buf[100];
+    int64_t big = 9007199254740993LL;
+    uint64_t ubig = 18446744073709551615ULL;
+    int32_t small = -42;
+    uint8_t byte = 200;
+    intmax_t max = INTMAX_MAX;
+
+    sprintf(buf, "%" PRId64, big);
+    is_streq(buf, "9007199254740993");
+    sprintf(buf, "%" PRIu64, ubig);
+    is_streq(buf, "18446744073709551615");
+    sprintf(buf, "%" PRIx64, (uint64_t)big);
+    is_streq(buf, "20000000000001");
+    sprintf(buf, "%" PRIX64, (uint64_t)0xABCDEF12345ULL);
+    is_streq(buf, "ABCDEF12345");
+    sprintf(buf, "%" PRId32 " %" PRIi32, small, small);
+    is_streq(buf, "-42 -42");
+    sprintf(buf, "%" PRIu8, byte);
+    is_streq(buf, "200");
+    sprintf(buf, "%8" PRIdMAX, (intmax_t)123);
+    is_streq(buf, "     123");
+    sprintf(buf, "%" PRIdMAX, max);
+    is_streq(buf, "9223372036854775807");
+    sprintf(buf, "%" PRIdPTR, (intptr_t)-7);
+    is_streq(buf, "-7");
+
+    printf("%" PRId64 " %" PRIu64 "\n", big, ubig);
+}
+
+void test_scan()
+{
+    diag("scan macros");
+    int64_t value = 0;
+    is_eq(scanf("%" SCNd64, &value), 1);
+    is_eq(value, 7);
+}
+
+void test_imaxabs()
+{
+    diag("imaxabs");
+    is_eq(imaxabs(-5), 5);
+    is_eq(imaxabs(5), 5);
+    is_eq(imaxabs(0), 0);
+    is_true(imaxabs(-9007199254740993LL) == 9007199254740993LL);
+}
+
+void test_imaxdiv()
+{
+    diag("imaxdiv");
+    imaxdiv_t result = imaxdiv(17, 5);
+    is_eq(result.quot, 3);
+    is_eq(result.rem, 2);
+
+    result = imaxdiv(-17, 5);
+    is_eq(result.quot, -3);
+    is_eq(result.rem, -2);
+
+    result = imaxdiv(9007199254740993LL, 10);
+    is_true(result.quot == 900719925474099LL);
+    is_eq(result.rem, 3);
+
+    is_eq(imaxdiv(17, -5).quot, -3);
+}
+
+void test_strtoimax()
+{
+    diag("strtoimax");
+    char* endptr;
+    is_true(strtoimax("9007199254740993", &endptr, 10) == 9007199254740993LL);
+    is_streq(endptr, "");
+    is_true(strtoimax("  -10 tail", &endptr, 16) == -16);
+    is_streq(endptr, " tail");
+    is_true(strtoimax("777", NULL, 8) == 511);
+
+    diag("strtoumax");
+    is_true(strtoumax("9007199254740993", &endptr, 10) == 9007199254740993ULL);
+    is_streq(endptr, "");
+    is_true(strtoumax("ff!", &endptr, 16) == 255);
+    is_streq(endptr, "!");
+}
+
+int main()
+{
+    plan(31);
+
+    test_format();
+    test_scan();
+    test_imaxabs();
+    test_imaxdiv();
+    test_strtoimax();
+
+    done_testing();
+}
@@ -0,0 +1,68 @@
+#include "tests.h"
+#include <float.h>
+#include <limits.h>
+
+void test_limits()
+{
+    diag("limits.h");
+    is_eq(CHAR_BIT, 8);
+    is_eq(SCHAR_MAX, 127);
+    is_eq(SCHAR_MIN, -128);
+    is_eq(UCHAR_MAX, 255);
+    is_eq(SHRT_MAX, 32767);
+    is_eq(USHRT_MAX, 65535);
+    is_eq(INT_MAX, 2147483647);
+    is_eq(INT_MIN, -2147483648.0);
+    is_eq(UINT_MAX, 4294967295.0);
+    is_true(LLONG_MAX == 9223372036854775807LL);
+    is_true(LLONG_MIN + 1 == -LLONG_MAX);
+    is_true(ULLONG_MAX == 18446744073709551615ULL);
+
+    int i = INT_MAX;
+    is_eq(i, 2147483647);
+    long long ll = LLONG_MIN;
+    is_true(ll < 0);
+
+    // LONG_MAX depends on data model, so only relations are checked
+    long l = LONG_MAX;
+    is_true(l > 0);
+    is_true(LONG_MIN + 1 == -LONG_MAX);
+    is_true(ULONG_MAX > LONG_MAX);
+}
+
+void test_float()
+{
+    diag("float.h");
+    is_eq(FLT_RADIX, 2);
+    is_eq(DBL_MANT_DIG, 53);
+    is_eq(FLT_MANT_DIG, 24);
+    is_eq(DBL_DIG, 15);
+    is_eq(FLT_DIG, 6);
+
+    double e = DBL_EPSILON;
+    is_true(1.0 + e != 1.0);
+    is_true(1.0 + e / 2 == 1.0);
+    is_true(e == 2.220446049250313080847263336181640625e-16);
+
+    float fe = FLT_EPSILON;
+    is_true(fe == 1.1920928955078125e-07f);
+
+    float fm = FLT_MAX;
+    is_true(fm == 3.40282346638528859811704183484516925e+38f);
+    is_true(FLT_MIN == 1.17549435082228750796873653722224568e-38f);
+
+    double dm = DBL_MAX;
+    is_true(dm == 1.79769313486231570814527423731704357e+308);
+    is_true(DBL_MIN == 2.22507385850720138309023271733240406e-308);
+    is_true(DBL_MAX > FLT_MAX);
+}
+
+int main()
+{
+    plan(31);
+
+    test_limits();
+    test_float();
+
+    done_testing();
+}
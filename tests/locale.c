@@ -0,0 +1,65 @@
+// Tests for locale.h.
+
+#include "tests.h"
+#include <locale.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+void test_setlocale()
+{
+    diag("setlocale");
+    is_streq(setlocale(LC_ALL, NULL), "C");
+    is_streq(setlocale(LC_ALL, "POSIX"), "C");
+    is_streq(setlocale(LC_NUMERIC, "C.UTF-8"), "C.UTF-8");
+    is_streq(setlocale(LC_NUMERIC, NULL), "C.UTF-8");
+    is_streq(setlocale(LC_CTYPE, NULL), "C");
+
+    // the name of mixed locale restores the locale
+    char saved[512];
+    strcpy(saved, setlocale(LC_ALL, NULL));
+    is_not_null(strstr(saved, "LC_NUMERIC=C.UTF-8"));
+    is_streq(setlocale(LC_ALL, "C"), "C");
+    is_streq(setlocale(LC_ALL, saved), saved);
+    is_streq(setlocale(LC_NUMERIC, NULL), "C.UTF-8");
+
+    // not supported locale is not changed
+    is_null(setlocale(LC_ALL, "xx_XX.not-exist"));
+    is_streq(setlocale(LC_NUMERIC, NULL), "C.UTF-8");
+    is_streq(setlocale(LC_ALL, "C"), "C");
+}
+
+void test_localeconv()
+{
+    diag("localeconv");
+    struct lconv* lc = localeconv();
+    is_streq(lc->decimal_point, ".");
+    is_streq(lc->thousands_sep, "");
+    is_streq(lc->currency_symbol, "");
+    is_eq(lc->grouping[0], 0);
+    // values are not available in the locale (CHAR_MAX of signed char)
+    is_eq(lc->frac_digits, 127);
+    is_eq(lc->n_sign_posn, 127);
+}
+
+void test_numbers()
+{
+    diag("decimal point of printf and strtod");
+    char buffer[50];
+    sprintf(buffer, "%.2f", 3.25);
+    is_streq(buffer, "3.25");
+    char* end;
+    is_eq(strtod("2.5x", &end), 2.5);
+    is_streq(end, "x");
+}
+
+int main()
+{
+    plan(21);
+
+    test_setlocale();
+    test_localeconv();
+    test_numbers();
+
+    done_testing();
+}
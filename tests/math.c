@@ -12,7 +12,7 @@ unsigned long long ullmax = 18446744073709551615ull;
 
 int main()
 {
-    plan(397);
+    plan(527);
 
 	diag("Sqrt function");
 	double (*f)(double) = sqrt;
@@ -628,5 +628,173 @@ int main()
 		is_eq(atanh(tanh(angle)),angle);
 	}
 
+	diag("frexp, modf");
+	{
+		int e;
+		is_eq(frexp(8, &e), 0.5);
+		is_eq(e, 4);
+		is_eq(frexpf(-3, &e), -0.75);
+		is_eq(e, 2);
+		is_eq(frexp(0, &e), 0);
+		is_eq(e, 0);
+	}
+	{
+		double i;
+		is_eq(modf(3.25, &i), 0.25);
+		is_eq(i, 3);
+		is_negzero(modf(-INFINITY, &i));
+		is_inf(i, -1);
+		float f;
+		is_eq(modff(-2.5, &f), -0.5);
+		is_eq(f, -2);
+	}
+
+	diag("ilogb, logb, scalbn");
+	is_eq(ilogb(1024), 10);
+	is_eq(ilogbf(0.75), -1);
+	is_eq(logb(1024), 10);
+	is_eq(scalbn(3, 4), 48);
+	is_eq(scalbln(3, -1), 1.5);
+	is_eq(ldexpf(0.5, 3), 4);
+
+	diag("rounding");
+	is_eq(round(2.5), 3);
+	is_eq(round(-2.5), -3);
+	is_eq(rint(2.5), 2);
+	is_eq(nearbyint(3.5), 4);
+	is_eq(trunc(-2.7), -2);
+	is_eq(lround(-2.5), -3);
+	is_eq(llround(2.5), 3);
+	is_eq(lrint(2.5), 2);
+	is_eq(llrint(-3.5), -4);
+	is_eq(roundf(1.5), 2);
+	is_eq(truncf(1.5), 1);
+	is_eq(floorf(-1.5), -2);
+	is_eq(ceilf(-1.5), -1);
+	is_negzero(round(-0.4));
+
+	diag("remainder, remquo");
+	{
+		int q;
+		is_eq(remainder(7, 2), -1);
+		is_eq(remquo(7, 2, &q), -1);
+		is_eq(q, 4);
+		is_eq(remquo(-10, 3, &q), -1);
+		is_eq(q, -3);
+		is_eq(fmodf(7, 2), 1);
+		is_eq(remainderf(5, 2), 1);
+	}
+
+	diag("copysign, nextafter, nan");
+	is_eq(copysign(2, -1), -2);
+	is_negzero(copysignf(0, -1));
+	is_true(nextafter(1, 2) > 1);
+	is_true(nextafterf(1, 0) < 1);
+	is_negzero(nextafter(0, -0.0));
+	is_true(nexttoward(1, 2) > 1);
+	is_nan(nan(""));
+	is_nan(nanf("1"));
+
+	diag("fmin, fmax, fdim with NaN");
+	is_eq(fmin(1, NAN), 1);
+	is_eq(fmin(NAN, 1), 1);
+	is_eq(fmax(1, NAN), 1);
+	is_nan(fdim(NAN, 1));
+
+	diag("float functions");
+	is_eq(sqrtf(2.25), 1.5);
+	is_eq(cbrt(27), 3);
+	is_eq(cbrtf(-8), -2);
+	is_eq(hypot(3, 4), 5);
+	is_eq(hypotf(3, 4), 5);
+	is_eq(powf(2, 10), 1024);
+	is_eq(fabsf(-2.5), 2.5);
+	is_eq(expf(0), 1);
+	is_eq(logf(1), 0);
+	is_eq(log10f(1000), 3);
+	is_eq(log1p(0), 0);
+	is_eq(sinf(0), 0);
+	is_eq(cosf(0), 1);
+	is_eq(tanf(0), 0);
+	is_eq(atan2f(1, 1), atan2(1, 1));
+	is_eq(erf(0), 0);
+	is_eq(erfc(0), 1);
+	is_eq(tgamma(5), 24);
+	is_eq(lgamma(1), 0);
+	is_inf(lgamma(0), 1);
+	is_eq(fmaf(2, 3, 4), 10);
+
+	diag("classification macros");
+	{
+		float fs = 1e-40f;
+		float fn = 1.5f;
+		double ds = 1e-310;
+		double dn = fs;
+		long double ld = 2.5;
+		is_true(isnan(NAN));
+		is_false(isnan(fn));
+		is_true(isnan(nanf("")));
+		is_true(isinf(INFINITY));
+		is_true(isinf(-INFINITY));
+		is_false(isinf(dn));
+		is_true(isinf(-HUGE_VALF));
+		is_true(isfinite(fs));
+		is_false(isfinite(NAN));
+		is_false(isfinite(-INFINITY));
+		is_false(isnormal(fs));
+		is_true(isnormal(dn));
+		is_false(isnormal(ds));
+		is_false(isnormal(0.0));
+		is_true(isnormal(ld));
+		is_eq(fpclassify(fs), FP_SUBNORMAL);
+		is_eq(fpclassify(dn), FP_NORMAL);
+		is_eq(fpclassify(ds), FP_SUBNORMAL);
+		is_eq(fpclassify(-0.0), FP_ZERO);
+		is_eq(fpclassify(-INFINITY), FP_INFINITE);
+		is_eq(fpclassify(NAN), FP_NAN);
+		is_eq(fpclassify(ld), FP_NORMAL);
+		is_true(signbit(-fn));
+		is_false(signbit(fn));
+		is_true(signbit(-0.0));
+		is_false(signbit(ld));
+		is_eq(isfinite(1.0) + isnan(2.0) * 2, 1);
+	}
+
+	diag("special functions");
+	{
+		int sign = 0;
+		is_eq(erff(0.5f), erf(0.5));
+		is_eq(erfcf(0.5f), erfc(0.5));
+		is_eq(tgammaf(4), 6);
+		is_eq(lgammaf(3), log(2));
+		is_eq(lgamma_r(3, &sign), log(2));
+		is_eq(sign, 1);
+		is_eq(lgamma_r(-0.5, &sign), log(2 * sqrt(M_PI)));
+		is_eq(sign, -1);
+		is_eq(lgammaf_r(-1.5f, &sign), lgamma(-1.5));
+		is_eq(sign, 1);
+		is_eq(j0(0), 1);
+		is_eq(j1(0), 0);
+		is_eq(j0(1), 0.7651976865579666);
+		is_eq(j1(1), 0.4400505857449335);
+		is_eq(j1(-1), -0.4400505857449335);
+		is_eq(jn(2, 1), 0.1149034849319005);
+		is_eq(jn(-1, 1), -0.4400505857449335);
+		is_eq(jn(3, -1), -jn(3, 1));
+		is_eq(j0f(1), j0(1));
+		is_eq(j1f(1), j1(1));
+		is_eq(jnf(2, 1), jn(2, 1));
+		is_eq(y0(1), 0.08825696421567696);
+		is_eq(y1(1), -0.7812128213002887);
+		is_eq(yn(2, 1), -1.650682606816254);
+		is_eq(yn(-1, 1), 0.7812128213002887);
+		is_inf(y0(0), -1);
+		is_inf(yn(5, 0), -1);
+		is_nan(y1(-1));
+		is_eq(y0f(1), y0(1));
+		is_eq(y1f(1), y1(1));
+		is_eq(ynf(2, 1), yn(2, 1));
+	}
+
     done_testing();
 }
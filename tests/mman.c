@@ -0,0 +1,88 @@
+// This file contains tests for the memory-mapped files from sys/mman.h.
+
+#include "tests.h"
+#include <fcntl.h>
+#include <stdio.h>
+#include <string.h>
+#include <sys/mman.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+const char* filename = "/tmp/c4go_mman_test.txt";
+
+void test_shared()
+{
+    char buf[32];
+    int fd = open(filename, O_RDWR | O_CREAT | O_TRUNC, 0644);
+    is_true(fd >= 0);
+    is_eq(write(fd, "hello mmap", 10), 10);
+
+    char* p = mmap(NULL, 10, PROT_READ | PROT_WRITE, MAP_SHARED, fd, 0);
+    is_true(p != MAP_FAILED);
+    is_eq(p[0], 'h');
+    is_eq(p[9], 'p');
+
+    p[0] = 'H';
+    p[6] = 'M';
+    is_eq(msync(p, 10, MS_SYNC), 0);
+    is_eq(munmap(p, 10), 0);
+
+    is_eq(lseek(fd, 0, SEEK_SET), 0);
+    is_eq(read(fd, buf, 10), 10);
+    buf[10] = '\0';
+    is_streq(buf, "Hello Mmap");
+    is_eq(close(fd), 0);
+}
+
+void test_private()
+{
+    char buf[32];
+    int fd = open(filename, O_RDONLY);
+    is_true(fd >= 0);
+
+    void* addr = mmap(NULL, 10, PROT_READ | PROT_WRITE, MAP_PRIVATE, fd, 0);
+    is_true(addr != MAP_FAILED);
+    char* p = addr;
+    p[0] = 'J';
+    is_eq(p[0], 'J');
+    is_eq(munmap(addr, 10), 0);
+
+    is_eq(read(fd, buf, 10), 10);
+    buf[10] = '\0';
+    is_streq(buf, "Hello Mmap");
+    is_eq(close(fd), 0);
+}
+
+void test_anonymous()
+{
+    char* p = mmap(NULL, 4096, PROT_READ | PROT_WRITE,
+        MAP_PRIVATE | MAP_ANONYMOUS, -1, 0);
+    is_true(p != MAP_FAILED);
+    is_eq(p[100], 0);
+    p[100] = 42;
+    is_eq(p[100], 42);
+    is_eq(munmap(p, 4096), 0);
+}
+
+void test_failed()
+{
+    char* p = mmap(NULL, 10, PROT_READ, MAP_SHARED, 100, 0);
+    is_true(p == MAP_FAILED);
+}
+
+int main()
+{
+    plan(23);
+
+    START_TEST(shared);
+    START_TEST(private);
+    START_TEST(anonymous);
+    START_TEST(failed);
+
+    unlink(filename);
+
+    done_testing();
+}
@@ -0,0 +1,79 @@
+// Nested functions of GNU C are transpiled to Go closures.
+
+#include "tests.h"
+
+int apply(int (*f)(int), int x)
+{
+    return f(x);
+}
+
+int sum(int* a, int n)
+{
+    int s = 0;
+    void add(int x)
+    {
+        s += x;
+    }
+    for (int i = 0; i < n; i++) {
+        add(a[i]);
+    }
+    return s;
+}
+
+int main()
+{
+    plan(7);
+
+    diag("capture of variables");
+    int a[4] = { 1, 2, 3, 4 };
+    is_eq(sum(a, 4), 10);
+
+    int factor = 3;
+    int scale(int x)
+    {
+        return x * factor;
+    }
+    is_eq(scale(2), 6);
+    factor = 5;
+    is_eq(scale(2), 10);
+
+    diag("pointer to nested function");
+    is_eq(apply(scale, 3), 15);
+
+    diag("recursion");
+    int fib(int n)
+    {
+        if (n < 2) {
+            return n;
+        }
+        return fib(n - 1) + fib(n - 2);
+    }
+    is_eq(fib(10), 55);
+
+    diag("nested function inside nested function");
+    int counter = 0;
+    void outer(int n)
+    {
+        void inner(void)
+        {
+            counter++;
+        }
+        for (int i = 0; i < n; i++) {
+            inner();
+        }
+    }
+    outer(3);
+    is_eq(counter, 3);
+
+    diag("pointer result");
+    char* name(int i)
+    {
+        if (i) {
+            return "one";
+        }
+        return "zero";
+    }
+    is_streq(name(1), "one");
+
+    done_testing();
+}
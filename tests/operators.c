@@ -40,9 +40,82 @@ double* return_null()
     return NULL;
 }
 
+#define MAX(x, y)          \
+    ({                     \
+        int _a = (x);      \
+        int _b = (y);      \
+        _a > _b ? _a : _b; \
+    })
+#define MIN(x, y)          \
+    ({                     \
+        int _a = (x);      \
+        int _b = (y);      \
+        _a < _b ? _a : _b; \
+    })
+
+#define TYPE_NAME(x) _Generic((x), \
+    int: "int",                      \
+    double: "double",                \
+    char*: "string",                 \
+    default: "other")
+
+#define ABS(x) _Generic((x), \
+    double: fabs_generic,    \
+    default: abs_generic)(x)
+
+double fabs_generic(double x) { return x < 0 ? -x : x; }
+int abs_generic(int x) { return x < 0 ? -x : x; }
+
+struct point {
+    int x, y;
+};
+
+union number {
+    int i;
+    float f;
+};
+
+static int gnu_calls = 0;
+
+int gnu_value(int v)
+{
+    gnu_calls++;
+    return v;
+}
+
+int comma_return(int* a)
+{
+    return (*a += 2, *a * 10);
+}
+
+unsigned int fnv1a(const char* s)
+{
+    unsigned int hash = 2166136261u;
+    for (; *s; s++) {
+        hash ^= (unsigned char)*s;
+        hash *= 16777619u;
+    }
+    return hash;
+}
+
+unsigned int xorshift32(unsigned int* state)
+{
+    unsigned int x = *state;
+    x ^= x << 13;
+    x ^= x >> 17;
+    x ^= x << 5;
+    *state = x;
+    return x;
+}
+
+unsigned long long lcg64(unsigned long long seed)
+{
+    return seed * 6364136223846793005ull + 1442695040888963407ull;
+}
+
 int main()
 {
-    plan(98);
+    plan(144);
 
     int i = 10;
     signed char j = 1;
@@ -155,6 +228,35 @@ int main()
     int s1 = ({ 2; });
     is_eq(s1, 2);
     is_eq(({ int foo = s1 * 3; foo + 1; }), 7);
+    {
+        int i = 5;
+        int big = MAX(i++, 3);
+        int small = MIN(i, 3);
+        is_eq(big, 5);
+        is_eq(small, 3);
+        is_eq(i, 6);
+        is_eq(MAX(MIN(i, 10), 8), 8);
+        ({ i += 2; });
+        is_eq(i, 8);
+        is_eq(({ i = 1; }), 1);
+        is_eq((long)({ i++; i; }), 2);
+    }
+
+    diag("Generic selection");
+    {
+        int gi = 3;
+        double gd = -2.5;
+        char* gs = "s";
+        float gf = 1.0f;
+        is_streq(TYPE_NAME(gi), "int");
+        is_streq(TYPE_NAME(gd), "double");
+        is_streq(TYPE_NAME(gs), "string");
+        is_streq(TYPE_NAME(gf), "other");
+        is_eq(ABS(gd), 2.5);
+        is_eq(ABS(-gi), 3);
+        is_eq(_Generic(gi++, int: 1, default: 0), 1);
+        is_eq(gi, 3);
+    }
 
     diag("Not allowable var name for Go");
     int type = 42;
@@ -410,5 +512,98 @@ int main()
 		is_streq(v,"ext");
 	}
 
+    diag("conditional operator with aggregates and pointers");
+    {
+        struct point p1 = { 1, 2 }, p2 = { 3, 4 };
+        int c = 0;
+        struct point r = c ? p1 : p2;
+        is_eq(r.x + r.y, 7);
+
+        union number u1, u2;
+        u1.i = 10;
+        u2.i = 20;
+        union number u = c ? u2 : u1;
+        u.i = 30;
+        is_eq(u1.i, 10);
+
+        const char* s = c ? "yes" : "no";
+        is_streq(s, "no");
+
+        int arr[3] = { 5, 6, 7 };
+        int* ip = arr;
+        void* vp = c ? (void*)0 : ip;
+        is_eq(((int*)vp)[1], 6);
+        const int* cp = c ? NULL : ip;
+        is_eq(cp[2], 7);
+
+        char* name = NULL;
+        const char* shown = name ?: "unknown";
+        is_streq(shown, "unknown");
+        int v = gnu_value(3) ?: 10;
+        is_eq(v, 3);
+        is_eq(gnu_calls, 1);
+        v = gnu_value(0) ?: 10;
+        is_eq(v, 10);
+        is_eq(gnu_calls, 2);
+    }
+
+    diag("comma operator in expressions");
+    {
+        int a = 1, b = 0;
+        b = simple_repeat((a++, a + 10));
+        is_eq(b, 12);
+        is_eq(a, 2);
+        b = a > 1 ? (a++, a * 2) : (b++, 100);
+        is_eq(b, 6);
+        b = a > 10 ? (a++, a * 2) : (b++, b + 100);
+        is_eq(b, 107);
+        is_eq(a, 3);
+        if (b == 0 && (a++, a > 0)) {
+            fail("right part of && is evaluated");
+        }
+        if (b != 0 || (a++, a > 0)) {
+            pass("right part of || is not evaluated");
+        }
+        is_eq(a, 3);
+        b = comma_return(&a);
+        is_eq(b, 50);
+    }
+
+    diag("unsigned wraparound");
+    {
+        unsigned int max = 0u - 1;
+        is_eq(max, 4294967295u);
+        is_eq(~0u, 4294967295u);
+        is_eq(-1u, 4294967295u);
+        is_eq(4294967295u + 2u, 1);
+        is_eq(max + 1, 0);
+        is_eq(fnv1a("hello"), 1335831723u);
+        unsigned int state = 2463534242u;
+        xorshift32(&state);
+        unsigned int next = xorshift32(&state);
+        is_eq(next, 2497366906u);
+        is_true(lcg64(1) == 7806831264735756412ull);
+
+        unsigned int one = 1;
+        volatile int n = 33;
+        is_eq(one << n, 2);
+        n = 35;
+        one <<= n;
+        is_eq(one, 8);
+
+        // the shift of promoted type
+        short s = 1;
+        n = 16;
+        s <<= n;
+        is_eq(s, 0);
+        s = 1;
+        s <<= 16;
+        is_eq(s, 0);
+        unsigned char c = 1;
+        n = 8;
+        c <<= n;
+        is_eq(c, 0);
+    }
+
     done_testing();
 }
@@ -0,0 +1,283 @@
+// This file contains tests for the pthread.h functions.
+
+#include "tests.h"
+#include <pthread.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <time.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+#define THREADS 4
+#define ITERATIONS 1000
+
+pthread_mutex_t counter_mutex = PTHREAD_MUTEX_INITIALIZER;
+int counter = 0;
+
+void* increment(void* arg)
+{
+    int* id = (int*)arg;
+    for (int i = 0; i < ITERATIONS; i++) {
+        pthread_mutex_lock(&counter_mutex);
+        counter++;
+        pthread_mutex_unlock(&counter_mutex);
+    }
+    return (void*)id;
+}
+
+void test_create_join()
+{
+    pthread_t threads[THREADS];
+    int ids[THREADS];
+    int errors = 0;
+    for (int i = 0; i < THREADS; i++) {
+        ids[i] = i;
+        if (pthread_create(&threads[i], NULL, increment, (void*)&ids[i]) != 0) {
+            errors++;
+        }
+    }
+    int sum = 0;
+    for (int i = 0; i < THREADS; i++) {
+        void* result;
+        if (pthread_join(threads[i], &result) != 0) {
+            errors++;
+        }
+        sum += *(int*)result;
+    }
+    is_eq(errors, 0);
+    is_eq(counter, THREADS * ITERATIONS);
+    is_eq(sum, 0 + 1 + 2 + 3);
+}
+
+void* self(void* arg)
+{
+    pthread_t* t = (pthread_t*)arg;
+    *t = pthread_self();
+    return NULL;
+}
+
+void test_self()
+{
+    pthread_t thread;
+    pthread_t inside;
+    pthread_create(&thread, NULL, self, (void*)&inside);
+    pthread_join(thread, NULL);
+    is_true(pthread_equal(thread, inside));
+    is_true(!pthread_equal(thread, pthread_self()));
+}
+
+void* exit_thread(void* arg)
+{
+    pthread_exit(arg);
+    return NULL;
+}
+
+void test_exit()
+{
+    pthread_t thread;
+    int value = 42;
+    void* result = NULL;
+    pthread_create(&thread, NULL, exit_thread, (void*)&value);
+    is_eq(pthread_join(thread, &result), 0);
+    is_eq(*(int*)result, 42);
+}
+
+void test_attr()
+{
+    pthread_attr_t attr;
+    int state;
+    is_eq(pthread_attr_init(&attr), 0);
+    pthread_attr_getdetachstate(&attr, &state);
+    is_eq(state, PTHREAD_CREATE_JOINABLE);
+    is_eq(pthread_attr_setdetachstate(&attr, PTHREAD_CREATE_DETACHED), 0);
+    pthread_attr_getdetachstate(&attr, &state);
+    is_eq(state, PTHREAD_CREATE_DETACHED);
+    is_eq(pthread_attr_destroy(&attr), 0);
+}
+
+void test_trylock()
+{
+    pthread_mutex_t m;
+    is_eq(pthread_mutex_init(&m, NULL), 0);
+    is_eq(pthread_mutex_trylock(&m), 0);
+    is_true(pthread_mutex_trylock(&m) != 0);
+    is_eq(pthread_mutex_unlock(&m), 0);
+    is_eq(pthread_mutex_destroy(&m), 0);
+}
+
+void test_recursive()
+{
+    pthread_mutexattr_t attr;
+    pthread_mutex_t m;
+    int kind;
+    pthread_mutexattr_init(&attr);
+    is_eq(pthread_mutexattr_settype(&attr, PTHREAD_MUTEX_RECURSIVE), 0);
+    pthread_mutexattr_gettype(&attr, &kind);
+    is_eq(kind, PTHREAD_MUTEX_RECURSIVE);
+    pthread_mutex_init(&m, &attr);
+    is_eq(pthread_mutex_lock(&m), 0);
+    is_eq(pthread_mutex_lock(&m), 0);
+    is_eq(pthread_mutex_unlock(&m), 0);
+    is_eq(pthread_mutex_unlock(&m), 0);
+    pthread_mutex_destroy(&m);
+    pthread_mutexattr_destroy(&attr);
+}
+
+pthread_mutex_t queue_mutex = PTHREAD_MUTEX_INITIALIZER;
+pthread_cond_t queue_cond = PTHREAD_COND_INITIALIZER;
+int queue = 0;
+int consumed = 0;
+
+void* consumer(void* arg)
+{
+    for (int i = 0; i < 10; i++) {
+        pthread_mutex_lock(&queue_mutex);
+        while (queue == 0) {
+            pthread_cond_wait(&queue_cond, &queue_mutex);
+        }
+        queue--;
+        consumed++;
+        pthread_cond_signal(&queue_cond);
+        pthread_mutex_unlock(&queue_mutex);
+    }
+    return NULL;
+}
+
+void test_cond()
+{
+    pthread_t thread;
+    pthread_create(&thread, NULL, consumer, NULL);
+    for (int i = 0; i < 10; i++) {
+        pthread_mutex_lock(&queue_mutex);
+        while (queue > 0) {
+            pthread_cond_wait(&queue_cond, &queue_mutex);
+        }
+        queue++;
+        pthread_cond_signal(&queue_cond);
+        pthread_mutex_unlock(&queue_mutex);
+    }
+    pthread_join(thread, NULL);
+    is_eq(consumed, 10);
+    is_eq(queue, 0);
+}
+
+void test_timedwait()
+{
+    pthread_mutex_t m;
+    pthread_cond_t c;
+    struct timespec ts;
+    pthread_mutex_init(&m, NULL);
+    pthread_cond_init(&c, NULL);
+    ts.tv_sec = time(NULL) - 1;
+    ts.tv_nsec = 0;
+    pthread_mutex_lock(&m);
+    is_true(pthread_cond_timedwait(&c, &m, &ts) != 0);
+    is_eq(pthread_mutex_unlock(&m), 0);
+    is_eq(pthread_cond_broadcast(&c), 0);
+    is_eq(pthread_cond_destroy(&c), 0);
+    pthread_mutex_destroy(&m);
+}
+
+pthread_once_t once = PTHREAD_ONCE_INIT;
+int once_counter = 0;
+
+void once_routine(void)
+{
+    once_counter++;
+}
+
+void test_once()
+{
+    for (int i = 0; i < 3; i++) {
+        pthread_once(&once, once_routine);
+    }
+    is_eq(once_counter, 1);
+}
+
+pthread_key_t key;
+int destroyed = 0;
+
+void destructor(void* value)
+{
+    destroyed++;
+}
+
+void* specific(void* arg)
+{
+    pthread_setspecific(key, arg);
+    int* v = (int*)pthread_getspecific(key);
+    return (void*)v;
+}
+
+void test_specific()
+{
+    int value = 7;
+    pthread_t thread;
+    void* result = NULL;
+    is_eq(pthread_key_create(&key, destructor), 0);
+    is_null(pthread_getspecific(key));
+    pthread_create(&thread, NULL, specific, (void*)&value);
+    pthread_join(thread, &result);
+    is_eq(*(int*)result, 7);
+    is_eq(destroyed, 1);
+    is_null(pthread_getspecific(key));
+    is_eq(pthread_key_delete(key), 0);
+}
+
+__thread int calls = 3;
+static __thread char name[8];
+int thread_calls[THREADS];
+char thread_names[THREADS];
+
+void* thread_storage(void* arg)
+{
+    int id = *(int*)arg;
+    for (int i = 0; i < id; i++) {
+        calls++;
+    }
+    name[0] = 'a' + id;
+    thread_calls[id] = calls;
+    thread_names[id] = name[0];
+    return NULL;
+}
+
+void test_thread_storage()
+{
+    pthread_t threads[THREADS];
+    int ids[THREADS];
+    int errors = 0;
+    for (int i = 0; i < THREADS; i++) {
+        ids[i] = i;
+        pthread_create(&threads[i], NULL, thread_storage, (void*)&ids[i]);
+    }
+    for (int i = 0; i < THREADS; i++) {
+        pthread_join(threads[i], NULL);
+        if (thread_calls[i] != 3 + i || thread_names[i] != 'a' + i) {
+            errors++;
+        }
+    }
+    is_eq(errors, 0);
+    is_eq(calls, 3);
+    is_eq(name[0], 0);
+}
+
+int main()
+{
+    plan(39);
+
+    START_TEST(create_join);
+    START_TEST(self);
+    START_TEST(exit);
+    START_TEST(attr);
+    START_TEST(trylock);
+    START_TEST(recursive);
+    START_TEST(cond);
+    START_TEST(timedwait);
+    START_TEST(once);
+    START_TEST(specific);
+    START_TEST(thread_storage);
+
+    done_testing();
+}
@@ -0,0 +1,54 @@
+// This file contains tests for the pwd.h functions and the user IDs of
+// unistd.h.
+
+#include "tests.h"
+#include <pwd.h>
+#include <stdio.h>
+#include <string.h>
+#include <sys/types.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_getuid()
+{
+    uid_t uid = getuid();
+    gid_t gid = getgid();
+    is_eq(geteuid(), uid);
+    is_eq(getegid(), gid);
+}
+
+void test_getpwuid()
+{
+    struct passwd* pw = getpwuid(getuid());
+    is_not_null(pw);
+    is_eq(pw->pw_uid, getuid());
+    is_eq(pw->pw_gid, getgid());
+    is_true(strlen(pw->pw_name) > 0);
+    is_true(pw->pw_dir[0] == '/');
+    is_true(pw->pw_shell[0] == '/');
+}
+
+void test_getpwnam()
+{
+    struct passwd* pw = getpwnam("root");
+    is_not_null(pw);
+    is_eq(pw->pw_uid, 0);
+    is_streq(pw->pw_name, "root");
+    is_true(pw->pw_dir[0] == '/');
+
+    is_null(getpwnam("c4go_no_such_user"));
+}
+
+int main()
+{
+    plan(13);
+
+    START_TEST(getuid);
+    START_TEST(getpwuid);
+    START_TEST(getpwnam);
+
+    done_testing();
+}
@@ -0,0 +1,133 @@
+// This file contains tests for the POSIX regular expressions from regex.h.
+
+#include "tests.h"
+#include <regex.h>
+#include <stdio.h>
+#include <string.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+regex_t re;
+regmatch_t m[4];
+
+void test_basic()
+{
+    is_eq(regcomp(&re, "\\(a*\\)b", 0), 0);
+    is_eq(re.re_nsub, 1);
+    is_eq(regexec(&re, "xaab", 2, m, 0), 0);
+    is_eq(m[0].rm_so, 1);
+    is_eq(m[0].rm_eo, 4);
+    is_eq(m[1].rm_so, 1);
+    is_eq(m[1].rm_eo, 3);
+    is_eq(regexec(&re, "xyz", 2, m, 0), REG_NOMATCH);
+    regfree(&re);
+
+    // "+" and "{" are literals in basic syntax
+    is_eq(regcomp(&re, "a+{", 0), 0);
+    is_eq(regexec(&re, "aa+{", 1, m, 0), 0);
+    is_eq(m[0].rm_so, 1);
+    regfree(&re);
+
+    is_eq(regcomp(&re, "^*a\\{2\\}$", 0), 0);
+    is_eq(regexec(&re, "*aa", 0, NULL, 0), 0);
+    is_eq(regexec(&re, "*aaa", 0, NULL, 0), REG_NOMATCH);
+    regfree(&re);
+}
+
+void test_extended()
+{
+    is_eq(regcomp(&re, "([a-z]+)@([a-z]+)\\.(com|org)", REG_EXTENDED), 0);
+    is_eq(re.re_nsub, 3);
+    is_eq(regexec(&re, "mail: user@example.org!", 4, m, 0), 0);
+    is_eq(m[0].rm_so, 6);
+    is_eq(m[0].rm_eo, 22);
+    is_eq(m[2].rm_so, 11);
+    is_eq(m[2].rm_eo, 18);
+    is_eq(m[3].rm_so, 19);
+    regfree(&re);
+
+    // leftmost-longest
+    is_eq(regcomp(&re, "a|ab|abc", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "abcd", 1, m, 0), 0);
+    is_eq(m[0].rm_eo, 3);
+    regfree(&re);
+
+    // not matched subexpression
+    is_eq(regcomp(&re, "(b)|(c)", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "ac", 3, m, 0), 0);
+    is_eq(m[1].rm_so, -1);
+    is_eq(m[2].rm_so, 1);
+    regfree(&re);
+}
+
+void test_brackets()
+{
+    is_eq(regcomp(&re, "[[:digit:]]+[^]a-c]", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "x12]3d", 1, m, 0), 0);
+    is_eq(m[0].rm_so, 1);
+    is_eq(m[0].rm_eo, 3);
+    regfree(&re);
+
+    is_eq(regcomp(&re, "[\\]", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "a\\b", 1, m, 0), 0);
+    is_eq(m[0].rm_so, 1);
+    regfree(&re);
+}
+
+void test_flags()
+{
+    is_eq(regcomp(&re, "hello", REG_EXTENDED | REG_ICASE | REG_NOSUB), 0);
+    is_eq(regexec(&re, "Say HeLLo", 0, NULL, 0), 0);
+    regfree(&re);
+
+    is_eq(regcomp(&re, "^b.*$", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "a\nb", 0, NULL, 0), REG_NOMATCH);
+    regfree(&re);
+
+    is_eq(regcomp(&re, "^b.*$", REG_EXTENDED | REG_NEWLINE), 0);
+    is_eq(regexec(&re, "a\nbc\nd", 1, m, 0), 0);
+    is_eq(m[0].rm_so, 2);
+    is_eq(m[0].rm_eo, 4);
+    regfree(&re);
+
+    is_eq(regcomp(&re, "^a", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "abc", 0, NULL, 0), 0);
+    is_eq(regexec(&re, "abc", 0, NULL, REG_NOTBOL), REG_NOMATCH);
+    regfree(&re);
+
+    is_eq(regcomp(&re, "c$", REG_EXTENDED), 0);
+    is_eq(regexec(&re, "abc", 0, NULL, REG_NOTEOL), REG_NOMATCH);
+    regfree(&re);
+}
+
+void test_errors()
+{
+    char buf[64];
+
+    is_eq(regcomp(&re, "(a", REG_EXTENDED), REG_EPAREN);
+    is_eq(regcomp(&re, "[a", REG_EXTENDED), REG_EBRACK);
+    is_eq(regcomp(&re, "[[:foo:]]", REG_EXTENDED), REG_ECTYPE);
+    is_eq(regcomp(&re, "[z-a]", REG_EXTENDED), REG_ERANGE);
+    is_eq(regcomp(&re, "a\\{2", 0), REG_EBRACE);
+    is_eq(regcomp(&re, "a\\", 0), REG_EESCAPE);
+
+    is_eq(regerror(REG_NOMATCH, &re, buf, sizeof(buf)), 9);
+    is_streq(buf, "No match");
+    is_eq(regerror(REG_EPAREN, &re, buf, 8), 18);
+    is_streq(buf, "Unmatch");
+}
+
+int main()
+{
+    plan(59);
+
+    START_TEST(basic);
+    START_TEST(extended);
+    START_TEST(brackets);
+    START_TEST(flags);
+    START_TEST(errors);
+
+    done_testing();
+}
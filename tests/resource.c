@@ -0,0 +1,73 @@
+// This file contains tests for sysconf() of unistd.h, getrusage() of
+// sys/resource.h and times() of sys/times.h.
+
+#include "tests.h"
+#include <stdio.h>
+#include <stdlib.h>
+#include <sys/resource.h>
+#include <sys/times.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_sysconf()
+{
+    long pagesize = sysconf(_SC_PAGESIZE);
+    is_true(pagesize >= 4096);
+    is_eq(pagesize & (pagesize - 1), 0);
+
+    long cpus = sysconf(_SC_NPROCESSORS_ONLN);
+    is_true(cpus >= 1);
+    is_true(sysconf(_SC_NPROCESSORS_CONF) >= cpus);
+
+    is_true(sysconf(_SC_CLK_TCK) > 0);
+    is_true(sysconf(_SC_OPEN_MAX) != 0);
+    is_true(sysconf(_SC_PHYS_PAGES) > 0);
+
+    // buffer sized by the page size
+    char* buffer = malloc(pagesize);
+    is_not_null(buffer);
+    free(buffer);
+}
+
+void test_getrusage()
+{
+    volatile long sum = 0;
+    for (long i = 0; i < 20000000; i++) {
+        sum += i % 7;
+    }
+
+    struct rusage usage;
+    is_eq(getrusage(RUSAGE_SELF, &usage), 0);
+    is_true(usage.ru_utime.tv_sec > 0 || usage.ru_utime.tv_usec > 0);
+    is_true(usage.ru_stime.tv_sec >= 0);
+    is_true(usage.ru_maxrss > 0);
+
+    struct rusage children;
+    is_eq(getrusage(RUSAGE_CHILDREN, &children), 0);
+    is_true(children.ru_utime.tv_sec >= 0);
+}
+
+void test_times()
+{
+    struct tms buf;
+    clock_t first = times(&buf);
+    is_true(first != (clock_t)-1);
+    is_true(buf.tms_utime >= 0);
+    is_true(buf.tms_stime >= 0);
+    is_true(buf.tms_cutime >= 0);
+    is_true(times(NULL) >= first);
+}
+
+int main()
+{
+    plan(19);
+
+    START_TEST(sysconf);
+    START_TEST(getrusage);
+    START_TEST(times);
+
+    done_testing();
+}
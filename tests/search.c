@@ -0,0 +1,128 @@
+// This file contains tests for the search.h functions.
+
+#include "tests.h"
+#include <search.h>
+#include <stdio.h>
+#include <string.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+int compare_int(const void* a, const void* b)
+{
+    return *(const int*)a - *(const int*)b;
+}
+
+int visited = 0;
+int last = 0;
+int sorted = 1;
+
+void action(const void* nodep, VISIT which, int depth)
+{
+    if (which == postorder || which == leaf) {
+        int value = **(int**)nodep;
+        if (value < last) {
+            sorted = 0;
+        }
+        last = value;
+        visited++;
+    }
+}
+
+void test_hsearch()
+{
+    ENTRY item;
+    ENTRY* found;
+    is_eq(hcreate(10), 1);
+
+    item.key = "one";
+    item.data = "first";
+    found = hsearch(item, ENTER);
+    is_not_null(found);
+
+    item.key = "two";
+    item.data = "second";
+    is_not_null(hsearch(item, ENTER));
+
+    item.key = "two";
+    found = hsearch(item, FIND);
+    is_not_null(found);
+    is_streq(found->key, "two");
+    is_streq((char*)found->data, "second");
+
+    item.key = "three";
+    is_null(hsearch(item, FIND));
+
+    hdestroy();
+}
+
+int values[] = { 5, 3, 8, 1, 4, 7, 9, 2, 6 };
+
+void test_tsearch()
+{
+    void* root = NULL;
+    int i;
+    for (i = 0; i < 9; i++) {
+        int* r = *(int**)tsearch(&values[i], &root, compare_int);
+        is_eq(*r, values[i]);
+    }
+    is_not_null(root);
+
+    int key = 7;
+    void* r = tfind(&key, &root, compare_int);
+    is_not_null(r);
+    is_eq(**(int**)r, 7);
+    key = 10;
+    is_null(tfind(&key, &root, compare_int));
+
+    twalk(root, action);
+    is_eq(visited, 9);
+    is_true(sorted);
+
+    key = 4;
+    is_not_null(tdelete(&key, &root, compare_int));
+    is_null(tfind(&key, &root, compare_int));
+    key = 10;
+    is_null(tdelete(&key, &root, compare_int));
+
+    for (i = 0; i < 9; i++) {
+        tdelete(&values[i], &root, compare_int);
+    }
+    is_null(root);
+}
+
+void test_lsearch()
+{
+    int array[5];
+    size_t n = 0;
+    int keys[] = { 3, 1, 3, 2 };
+    int i;
+    for (i = 0; i < 4; i++) {
+        int* r = lsearch(&keys[i], array, &n, sizeof(int), compare_int);
+        is_eq(*r, keys[i]);
+    }
+    is_eq(n, 3);
+    is_eq(array[0], 3);
+    is_eq(array[1], 1);
+    is_eq(array[2], 2);
+
+    int key = 2;
+    int* found = lfind(&key, array, &n, sizeof(int), compare_int);
+    is_not_null(found);
+    is_true(found == &array[2]);
+    key = 5;
+    is_null(lfind(&key, array, &n, sizeof(int), compare_int));
+    is_eq(n, 3);
+}
+
+int main()
+{
+    plan(38);
+
+    START_TEST(hsearch);
+    START_TEST(tsearch);
+    START_TEST(lsearch);
+
+    done_testing();
+}
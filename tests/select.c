@@ -0,0 +1,125 @@
+// This file contains tests for the select() from sys/select.h and poll()
+// from poll.h.
+
+#include "tests.h"
+#include <poll.h>
+#include <stdio.h>
+#include <sys/select.h>
+#include <sys/socket.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_fd_set()
+{
+    fd_set s;
+    FD_ZERO(&s);
+    is_false(FD_ISSET(3, &s));
+    FD_SET(3, &s);
+    FD_SET(5, &s);
+    is_true(FD_ISSET(3, &s));
+    is_true(FD_ISSET(5, &s));
+    is_false(FD_ISSET(4, &s));
+    FD_CLR(3, &s);
+    is_false(FD_ISSET(3, &s));
+    is_true(FD_ISSET(5, &s));
+}
+
+void test_select_pipe()
+{
+    int p[2];
+    fd_set r;
+    struct timeval tv;
+    char c;
+
+    is_eq(pipe(p), 0);
+
+    FD_ZERO(&r);
+    FD_SET(p[0], &r);
+    tv.tv_sec = 0;
+    tv.tv_usec = 10000;
+    is_eq(select(p[0] + 1, &r, NULL, NULL, &tv), 0);
+    is_false(FD_ISSET(p[0], &r));
+
+    is_eq(write(p[1], "x", 1), 1);
+    FD_ZERO(&r);
+    FD_SET(p[0], &r);
+    tv.tv_sec = 1;
+    tv.tv_usec = 0;
+    is_eq(select(p[0] + 1, &r, NULL, NULL, &tv), 1);
+    is_true(FD_ISSET(p[0], &r));
+    is_eq(read(p[0], &c, 1), 1);
+    is_eq(c, 'x');
+
+    is_eq(close(p[0]), 0);
+    is_eq(close(p[1]), 0);
+}
+
+void test_select_socket()
+{
+    int sv[2];
+    fd_set r;
+    fd_set w;
+    int max;
+
+    is_eq(socketpair(AF_UNIX, SOCK_STREAM, 0, sv), 0);
+    max = sv[0] > sv[1] ? sv[0] : sv[1];
+
+    FD_ZERO(&r);
+    FD_ZERO(&w);
+    FD_SET(sv[0], &r);
+    FD_SET(sv[1], &w);
+    is_eq(select(max + 1, &r, &w, NULL, NULL), 1);
+    is_false(FD_ISSET(sv[0], &r));
+    is_true(FD_ISSET(sv[1], &w));
+
+    is_eq(send(sv[1], "ab", 2, 0), 2);
+    FD_ZERO(&r);
+    FD_SET(sv[0], &r);
+    is_eq(select(max + 1, &r, NULL, NULL, NULL), 1);
+    is_true(FD_ISSET(sv[0], &r));
+
+    is_eq(close(sv[0]), 0);
+    is_eq(close(sv[1]), 0);
+}
+
+void test_poll()
+{
+    int p[2];
+    struct pollfd fds[2];
+
+    is_eq(pipe(p), 0);
+    fds[0].fd = p[0];
+    fds[0].events = POLLIN;
+    fds[1].fd = p[1];
+    fds[1].events = POLLOUT;
+
+    is_eq(poll(fds, 2, 10), 1);
+    is_eq(fds[0].revents, 0);
+    is_true(fds[1].revents & POLLOUT);
+
+    is_eq(write(p[1], "y", 1), 1);
+    is_eq(poll(fds, 1, 1000), 1);
+    is_true(fds[0].revents & POLLIN);
+
+    is_eq(close(p[1]), 0);
+    fds[0].fd = -1;
+    is_eq(poll(fds, 1, 0), 0);
+    is_eq(fds[0].revents, 0);
+
+    is_eq(close(p[0]), 0);
+}
+
+int main()
+{
+    plan(36);
+
+    START_TEST(fd_set);
+    START_TEST(select_pipe);
+    START_TEST(select_socket);
+    START_TEST(poll);
+
+    done_testing();
+}
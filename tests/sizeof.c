@@ -33,6 +33,9 @@ union MyUnion {
     int c;
 };
 
+_Static_assert(sizeof(struct MyStruct) == 16, "size of MyStruct");
+_Static_assert(sizeof(union MyUnion) == sizeof(double), "size of MyUnion");
+
 short a;
 int b;
 
@@ -62,6 +65,7 @@ int main()
     diag("Variables");
     a = 123;
     b = 456;
+    _Static_assert(sizeof(a) < sizeof(b), "short is less than int");
     struct MyStruct s1;
     s1.b = 0;
     union MyUnion u1;
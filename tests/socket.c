@@ -0,0 +1,154 @@
+// This file contains tests for the BSD sockets from sys/socket.h, netdb.h,
+// netinet/in.h and arpa/inet.h.
+
+#include "tests.h"
+#include <arpa/inet.h>
+#include <netdb.h>
+#include <netinet/in.h>
+#include <pthread.h>
+#include <stdio.h>
+#include <string.h>
+#include <sys/socket.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_byte_order()
+{
+    is_eq(ntohs(htons(8080)), 8080);
+    is_true(ntohl(htonl(123456789)) == 123456789);
+    is_true(inet_addr("127.0.0.1") == htonl(INADDR_LOOPBACK));
+}
+
+struct in_addr ip4;
+struct in6_addr ip6;
+
+void test_inet()
+{
+    char buf[INET6_ADDRSTRLEN];
+
+    is_eq(inet_pton(AF_INET, "192.168.1.10", &ip4), 1);
+    is_true(ip4.s_addr == htonl(0xC0A8010A));
+    is_streq(inet_ntop(AF_INET, &ip4, buf, sizeof(buf)), "192.168.1.10");
+    is_streq(inet_ntoa(ip4), "192.168.1.10");
+    is_eq(inet_pton(AF_INET, "300.1.1.1", &ip4), 0);
+
+    is_eq(inet_pton(AF_INET6, "fe80::1", &ip6), 1);
+    is_streq(inet_ntop(AF_INET6, &ip6, buf, sizeof(buf)), "fe80::1");
+
+    is_true(inet_addr("10.0.0.1") == htonl(0x0A000001));
+    is_true(inet_addr("not an address") == INADDR_NONE);
+}
+
+struct addrinfo hints;
+
+void test_getaddrinfo()
+{
+    struct addrinfo* res;
+    struct sockaddr_in* sin;
+
+    hints.ai_family = AF_INET;
+    hints.ai_socktype = SOCK_STREAM;
+    is_eq(getaddrinfo("127.0.0.1", "8080", &hints, &res), 0);
+    is_eq(res->ai_family, AF_INET);
+    is_eq(res->ai_socktype, SOCK_STREAM);
+    sin = (struct sockaddr_in*)res->ai_addr;
+    is_eq(ntohs(sin->sin_port), 8080);
+    is_streq(inet_ntoa(sin->sin_addr), "127.0.0.1");
+    is_null(res->ai_next);
+    freeaddrinfo(res);
+
+    hints.ai_flags = AI_PASSIVE;
+    is_eq(getaddrinfo(NULL, "80", &hints, &res), 0);
+    sin = (struct sockaddr_in*)res->ai_addr;
+    is_eq(ntohs(sin->sin_port), 80);
+    is_streq(inet_ntoa(sin->sin_addr), "0.0.0.0");
+    freeaddrinfo(res);
+
+    is_eq(getaddrinfo("127.0.0.1", "no-such-service", &hints, &res),
+        EAI_SERVICE);
+    is_streq(gai_strerror(EAI_SERVICE),
+        "Servname not supported for ai_socktype");
+}
+
+void test_gethostbyname()
+{
+    char buf[INET6_ADDRSTRLEN];
+    struct hostent* he = gethostbyname("127.0.0.1");
+    is_not_null(he);
+    is_eq(he->h_addrtype, AF_INET);
+    is_eq(he->h_length, 4);
+    is_streq(inet_ntop(AF_INET, he->h_addr_list[0], buf, sizeof(buf)),
+        "127.0.0.1");
+    is_null(he->h_addr_list[1]);
+}
+
+int server_fd;
+struct sockaddr_in server_addr;
+struct sockaddr_in client_addr;
+char received[64];
+
+void* serve(void* arg)
+{
+    (void)arg;
+    socklen_t len = sizeof(client_addr);
+    int fd = accept(server_fd, (struct sockaddr*)&client_addr, &len);
+    int n = recv(fd, received, sizeof(received) - 1, 0);
+    received[n] = '\0';
+    send(fd, "pong", 4, 0);
+    close(fd);
+    return NULL;
+}
+
+void test_client_server()
+{
+    int opt = 1;
+    socklen_t len = sizeof(server_addr);
+    pthread_t t;
+    char buf[64];
+
+    server_fd = socket(AF_INET, SOCK_STREAM, 0);
+    is_true(server_fd >= 0);
+    is_eq(setsockopt(server_fd, SOL_SOCKET, SO_REUSEADDR, &opt, sizeof(opt)),
+        0);
+    server_addr.sin_family = AF_INET;
+    server_addr.sin_addr.s_addr = htonl(INADDR_LOOPBACK);
+    server_addr.sin_port = htons(0);
+    is_eq(bind(server_fd, (struct sockaddr*)&server_addr, sizeof(server_addr)),
+        0);
+    is_eq(getsockname(server_fd, (struct sockaddr*)&server_addr, &len), 0);
+    is_true(ntohs(server_addr.sin_port) > 0);
+    is_eq(listen(server_fd, 1), 0);
+    is_eq(pthread_create(&t, NULL, serve, NULL), 0);
+
+    int client = socket(AF_INET, SOCK_STREAM, 0);
+    is_true(client >= 0);
+    is_eq(connect(client, (struct sockaddr*)&server_addr, sizeof(server_addr)),
+        0);
+    is_eq(send(client, "ping", 4, 0), 4);
+    int n = recv(client, buf, sizeof(buf) - 1, 0);
+    buf[n] = '\0';
+    is_streq(buf, "pong");
+    is_eq(pthread_join(t, NULL), 0);
+
+    is_streq(received, "ping");
+    is_eq(client_addr.sin_family, AF_INET);
+    is_streq(inet_ntoa(client_addr.sin_addr), "127.0.0.1");
+    is_eq(close(client), 0);
+    is_eq(close(server_fd), 0);
+}
+
+int main()
+{
+    plan(45);
+
+    START_TEST(byte_order);
+    START_TEST(inet);
+    START_TEST(getaddrinfo);
+    START_TEST(gethostbyname);
+    START_TEST(client_server);
+
+    done_testing();
+}
@@ -0,0 +1,99 @@
+// This file contains tests for the sys/stat.h functions.
+
+#include "tests.h"
+#include <fcntl.h>
+#include <stdio.h>
+#include <sys/stat.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_stat()
+{
+    FILE* f = fopen("build/stat.txt", "w");
+    fputs("0123456789", f);
+    fclose(f);
+
+    struct stat st;
+    is_eq(stat("build/stat.txt", &st), 0);
+    is_eq(st.st_size, 10);
+    is_true(S_ISREG(st.st_mode));
+    is_true(!S_ISDIR(st.st_mode));
+    is_true(st.st_mtime > 0);
+
+    is_eq(stat("build/not_exist.txt", &st), -1);
+}
+
+void test_lstat()
+{
+    struct stat st;
+    is_eq(lstat("build/stat.txt", &st), 0);
+    is_eq(st.st_size, 10);
+    is_true(S_ISREG(st.st_mode));
+}
+
+void test_fstat()
+{
+    int fd = open("build/stat.txt", O_RDONLY);
+    struct stat st;
+    is_eq(fstat(fd, &st), 0);
+    is_eq(st.st_size, 10);
+    close(fd);
+
+    is_eq(fstat(-1, &st), -1);
+}
+
+void test_chmod()
+{
+    struct stat st;
+    is_eq(chmod("build/stat.txt", 0600), 0);
+    stat("build/stat.txt", &st);
+    is_eq(st.st_mode & 0777, 0600);
+
+    is_eq(chmod("build/stat.txt", 0644), 0);
+    stat("build/stat.txt", &st);
+    is_eq(st.st_mode & 0777, 0644);
+}
+
+void test_mkdir()
+{
+    struct stat st;
+    rmdir("build/stat_dir");
+    is_eq(mkdir("build/stat_dir", 0755), 0);
+    is_eq(mkdir("build/stat_dir", 0755), -1);
+    is_eq(stat("build/stat_dir", &st), 0);
+    is_true(S_ISDIR(st.st_mode));
+    is_true(!S_ISREG(st.st_mode));
+
+    is_eq(unlink("build/stat_dir"), -1);
+    is_eq(rmdir("build/stat_dir"), 0);
+    is_eq(stat("build/stat_dir", &st), -1);
+}
+
+void test_rename()
+{
+    struct stat st;
+    is_eq(rename("build/stat.txt", "build/stat2.txt"), 0);
+    is_eq(stat("build/stat.txt", &st), -1);
+    is_eq(stat("build/stat2.txt", &st), 0);
+
+    is_eq(rmdir("build/stat2.txt"), -1);
+    is_eq(unlink("build/stat2.txt"), 0);
+    is_eq(stat("build/stat2.txt", &st), -1);
+}
+
+int main()
+{
+    plan(30);
+
+    START_TEST(stat);
+    START_TEST(lstat);
+    START_TEST(fstat);
+    START_TEST(chmod);
+    START_TEST(mkdir);
+    START_TEST(rename);
+
+    done_testing();
+}
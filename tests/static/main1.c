@@ -0,0 +1,22 @@
+#include <stdio.h>
+
+// Static names are renamed, because the same names are declared in the
+// file main2.c.
+static int counter = 0;
+
+static void next(void)
+{
+    counter++;
+}
+
+void run_second(void);
+
+int main()
+{
+    next();
+    next();
+    printf("%d", counter);
+    run_second();
+    printf("%d", counter);
+    return 0;
+}
@@ -0,0 +1,14 @@
+#include <stdio.h>
+
+static int counter = 10;
+
+static void next(void)
+{
+    counter += 5;
+}
+
+void run_second(void)
+{
+    next();
+    printf("%d", counter);
+}
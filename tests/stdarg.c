@@ -1,7 +1,9 @@
+#define _GNU_SOURCE
 #include "tests.h"
 #include <assert.h>
 #include <stdarg.h>
 #include <stdio.h>
+#include <stdlib.h>
 
 #define START_TEST(t) \
     diag(#t);         \
@@ -100,13 +102,161 @@ void test_va_list3()
 	is_eq(strange(2, &v1, &v2), 10+2+23+2);
 }
 
+int vsum(int num_args, va_list ap)
+{
+    int val = 0;
+    for (int i = 0; i < num_args; i++) {
+        val += va_arg(ap, int);
+    }
+    return val;
+}
+
+int sum_twice(int num_args, ...)
+{
+    va_list ap, aq;
+    va_start(ap, num_args);
+    va_copy(aq, ap);
+    int val = vsum(num_args, ap);
+    val += vsum(num_args, aq);
+    va_end(aq);
+    va_end(ap);
+    return val;
+}
+
+void test_va_copy()
+{
+    is_eq(sum_twice(3, 10, 20, 30), 120);
+}
+
+int format(char* buffer, const char* fmt, ...)
+{
+    va_list ap;
+    va_start(ap, fmt);
+    int n = vsnprintf(buffer, 8, fmt, ap);
+    va_end(ap);
+    return n;
+}
+
+void print(const char* fmt, ...)
+{
+    va_list ap;
+    va_start(ap, fmt);
+    vprintf(fmt, ap);
+    va_end(ap);
+}
+
+void test_forward()
+{
+    char buffer[8];
+    is_eq(format(buffer, "%s-%d", "abc", 12345), 9);
+    is_streq(buffer, "abc-123");
+    print("# %s %d\n", "forward", 42);
+}
+
+int count_strings(const char* first, ...)
+{
+    int n = 0;
+    va_list ap;
+    va_start(ap, first);
+    for (const char* s = first; s != NULL; s = va_arg(ap, const char*)) {
+        n++;
+    }
+    va_end(ap);
+    return n;
+}
+
+void test_null_terminated()
+{
+    is_eq(count_strings("a", "b", "c", NULL), 3);
+}
+
+void log_message(FILE* stream, const char* fmt, ...)
+{
+    va_list ap;
+    va_start(ap, fmt);
+    fprintf(stream, "# log: ");
+    vfprintf(stream, fmt, ap);
+    va_end(ap);
+}
+
+int format_all(char* buffer, const char* fmt, ...)
+{
+    va_list ap;
+    va_start(ap, fmt);
+    int n = vsprintf(buffer, fmt, ap);
+    va_end(ap);
+    return n;
+}
+
+char* make_message(const char* fmt, ...)
+{
+    char* s = NULL;
+    va_list ap;
+    va_start(ap, fmt);
+    int n = vasprintf(&s, fmt, ap);
+    va_end(ap);
+    if (n < 0) {
+        return NULL;
+    }
+    return s;
+}
+
+int log_fd(int fd, const char* fmt, ...)
+{
+    va_list ap;
+    va_start(ap, fmt);
+    int n = vdprintf(fd, fmt, ap);
+    va_end(ap);
+    return n;
+}
+
+void test_printf_family()
+{
+    char buffer[50];
+    long l = -123456789L;
+    unsigned long u = 42UL;
+    size_t z = 7;
+
+    log_message(stdout, "%ld %lu %zu %i %5.2lf\n", l, u, z, 3, 2.5);
+    is_eq(format_all(buffer, "%ld|%lu|%zu|%hd", l, u, z, 12), 18);
+    is_streq(buffer, "-123456789|42|7|12");
+
+    char* m = make_message("%s=%lld%%", "x", 10LL);
+    is_streq(m, "x=10%");
+    free(m);
+
+    fflush(stdout);
+    is_eq(log_fd(1, "# fd: %d\n", 5), 8);
+}
+
+typedef int (*sum_f)(int, ...);
+
+int apply(sum_f f, int a, int b)
+{
+    return f(2, a, b);
+}
+
+void test_function_pointer()
+{
+    sum_f f = sum;
+    int (*g)(int, ...) = sum;
+    is_eq(f(3, 1, 2, 3), 6);
+    is_eq(apply(sum, 4, 5), 9);
+    is_eq(g(1, 'a'), 97);
+}
+
 int main()
 {
-    plan(6);
+    plan(17);
 
     START_TEST(va_list)
     START_TEST(va_list2)
     START_TEST(va_list3)
+    START_TEST(va_copy)
+    START_TEST(forward)
+    START_TEST(null_terminated)
+    START_TEST(printf_family)
+    START_TEST(function_pointer)
 
     done_testing();
 }
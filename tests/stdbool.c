@@ -7,9 +7,86 @@ _Bool f(_Bool b)
     return b;
 }
 
+typedef _Bool flag_t;
+
+struct flags {
+    bool visible : 1;
+    bool enabled : 1;
+    bool list[3];
+};
+
+void test_conversion()
+{
+    diag("conversion");
+    bool b = 5;
+    is_true(b);
+    is_eq(b, 1);
+    b = 0.5;
+    is_true(b);
+    b = 0;
+    is_false(b);
+    flag_t f = 42;
+    is_eq(f, 1);
+    int i = b + f + true;
+    is_eq(i, 2);
+    is_eq(sizeof(bool), 1);
+    char buf[16];
+    sprintf(buf, "%d %d", f, b);
+    is_streq(buf, "1 0");
+}
+
+void test_operators()
+{
+    diag("operators");
+    bool b = false;
+    b++;
+    is_true(b);
+    b++;
+    is_true(b);
+    b--;
+    is_false(b);
+    b--;
+    is_true(b);
+    b += 2;
+    is_true(b);
+    b -= 1;
+    is_false(b);
+    b |= 4;
+    is_true(b);
+    b &= 2;
+    is_false(b);
+    b ^= true;
+    is_true(b);
+    b *= 0;
+    is_false(b);
+}
+
+void test_arrays()
+{
+    diag("arrays and structs");
+    bool a[4] = { 1, 0, 2 };
+    is_true(a[0]);
+    is_false(a[1]);
+    is_true(a[2]);
+    is_false(a[3]);
+    a[3] = !a[1];
+    is_true(a[3]);
+
+    struct flags fl = { 1, 0, { 0, 1, 0 } };
+    is_true(fl.visible);
+    is_false(fl.enabled);
+    fl.enabled = 3;
+    is_true(fl.enabled == 1);
+    is_eq(fl.visible + fl.enabled + fl.list[1], 3);
+}
+
 int main()
 {
-    plan(12);
+    plan(39);
+
+    test_conversion();
+    test_operators();
+    test_arrays();
 
     bool trueBool = true;
     bool falseBool = false;
@@ -20,6 +20,30 @@ void test_offset(){
 				), 1); is_eq((int)offsetof(struct foo,c),11);
 }
 
+struct bar {
+	char tag;
+	double value;
+	int ids[4];
+	char name[3];
+};
+
+void test_layout()
+{
+	diag("layout of struct");
+	struct bar x;
+	struct bar *p = &x;
+	is_eq((int)offsetof(struct bar, tag), 0);
+	is_eq((int)offsetof(struct bar, value), 8);
+	is_eq((int)offsetof(struct bar, ids), 16);
+	is_eq((int)offsetof(struct bar, ids[2]), 24);
+	is_eq((int)offsetof(struct bar, name), 32);
+	is_eq((int)sizeof(struct bar), 40);
+	is_eq((int)sizeof x, 40);
+	is_eq((int)sizeof *p, 40);
+	is_eq((int)sizeof x.ids, 16);
+	is_eq((int)sizeof(x.ids[0] + x.tag), 4);
+}
+
 void test_ptrdiff_t()
 {
 	diag("ptrdiff_t");
@@ -31,9 +55,10 @@ void test_ptrdiff_t()
 
 int main()
 {
-    plan(6);
+    plan(16);
 
 	test_offset();
+	test_layout();
 	test_ptrdiff_t();
 
     done_testing();
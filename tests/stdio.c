@@ -5,6 +5,7 @@
 #include <assert.h>
 #include <stdarg.h>
 #include <stdio.h>
+#include <stdlib.h>
 #include <string.h>
 
 #define START_TEST(t) \
@@ -12,7 +13,7 @@
     test_##t();
 
 // size of that file
-int filesize = 11360;
+int filesize = 13781;
 
 void test_putchar()
 {
@@ -261,6 +262,63 @@ void test_fgets()
     is_not_null(mystring);
 
     fclose(pFile);
+
+    // newline is retained and the rest of line is read by next call
+    remove("/tmp/fgets.txt");
+    pFile = fopen("/tmp/fgets.txt", "w");
+    fputs("first\nsecond line\nlast", pFile);
+    fclose(pFile);
+    pFile = fopen("/tmp/fgets.txt", "r");
+    char buffer[8] = "XXXXXXX";
+    is_streq(fgets(buffer, 8, pFile), "first\n");
+    is_streq(fgets(buffer, 8, pFile), "second ");
+    is_streq(fgets(buffer, 8, pFile), "line\n");
+    is_streq(fgets(buffer, 8, pFile), "last");
+    is_null(fgets(buffer, 8, pFile));
+    is_streq(buffer, "last");
+    is_true(feof(pFile));
+    fclose(pFile);
+    is_eq(remove("/tmp/fgets.txt"), 0);
+}
+
+void test_getline()
+{
+    FILE* pFile;
+    char* line = NULL;
+    size_t len = 0;
+    ssize_t read;
+    int lines = 0;
+
+    remove("/tmp/getline.txt");
+    pFile = fopen("/tmp/getline.txt", "w");
+    fputs("short\n", pFile);
+    for (int i = 0; i < 200; i++) {
+        fputc('a' + i % 26, pFile);
+    }
+    fputs("\n\nend;of;file", pFile);
+    fclose(pFile);
+
+    pFile = fopen("/tmp/getline.txt", "r");
+    read = getline(&line, &len, pFile);
+    is_eq(read, 6);
+    is_streq(line, "short\n");
+    is_true(len > 6);
+    read = getline(&line, &len, pFile);
+    is_eq(read, 201);
+    is_eq(strlen(line), 201);
+    is_true(len > 201);
+    read = getline(&line, &len, pFile);
+    is_eq(read, 1);
+    is_streq(line, "\n");
+    while ((read = getdelim(&line, &len, ';', pFile)) != -1) {
+        lines++;
+    }
+    is_eq(lines, 3);
+    is_streq(line, "file");
+    is_true(feof(pFile));
+    free(line);
+    fclose(pFile);
+    is_eq(remove("/tmp/getline.txt"), 0);
 }
 
 void test_fputc()
@@ -478,6 +536,23 @@ void test_snprintf()
     n = sprintf(buffer, "%d plus %d is %d", a, b, a + b);
     is_streq(buffer, "5 plus 3 is 8")
         is_eq(n, 13)
+
+    // length of the whole string is returned for truncated string
+    char small[6] = "XXXXX";
+    n = snprintf(small, sizeof(small), "%d plus %d", a, b);
+    is_eq(n, 8);
+    is_streq(small, "5 plu");
+    n = snprintf(small, 1, "%s", "text");
+    is_eq(n, 4);
+    is_streq(small, "");
+
+    // size of buffer
+    n = snprintf(NULL, 0, "%d plus %d is %d", a, b, a + b);
+    is_eq(n, 13);
+    char* dynamic = malloc(n + 1);
+    is_eq(snprintf(dynamic, n + 1, "%d plus %d is %d", a, b, a + b), 13);
+    is_streq(dynamic, "5 plus 3 is 8");
+    free(dynamic);
 }
 
 int PrintFError(const char* format, ...)
@@ -496,6 +571,15 @@ void test_vsprintf()
     is_eq(s, 19 + 8 + 5);
 }
 
+int LengthOfFormat(const char* format, ...)
+{
+    va_list args;
+    va_start(args, format);
+    int s = vsnprintf(NULL, 0, format, args);
+    va_end(args);
+    return s;
+}
+
 int PrintFError2(const char* format, ...)
 {
     char buffer[256];
@@ -510,6 +594,7 @@ void test_vsnprintf()
 {
     int s = PrintFError2("Success function '%s' %.2f", "vsprintf", 3.1415926);
     is_eq(s, 19 + 8 + 5);
+    is_eq(LengthOfFormat("%s %d", "vsnprintf", 42), 12);
 }
 
 void test_eof()
@@ -536,7 +621,7 @@ void test_eof()
 
 int main()
 {
-    plan(61);
+    plan(89);
 
     START_TEST(putchar)
     START_TEST(puts)
@@ -553,6 +638,7 @@ int main()
     START_TEST(fscanf)
     START_TEST(fgetc)
     START_TEST(fgets)
+    START_TEST(getline)
     START_TEST(fputc)
     START_TEST(fputs)
     START_TEST(getc)
@@ -177,6 +177,54 @@ void test_calloc()
     is_eq(d[4], 456);
 }
 
+struct point {
+    int x;
+    int y;
+};
+
+// The amount of elements is calculated from the allocation size.
+void test_alloc_length()
+{
+    diag("alloc_length");
+
+    int i, n = 5;
+
+    int* a = malloc(n * sizeof(int));
+    is_not_null(a) or_return();
+    for (i = 0; i < n; i++)
+        a[i] = i * i;
+    is_eq(a[n - 1], 16);
+    free(a);
+
+    long long* ll = malloc(2 * n * sizeof(*ll));
+    is_not_null(ll) or_return();
+    ll[2 * n - 1] = 42;
+    is_eq(ll[2 * n - 1], 42);
+    free(ll);
+
+    // over-allocation
+    double* d = malloc(sizeof(double) * (n + 1) + 4);
+    is_not_null(d) or_return();
+    d[n] = 1.5;
+    is_eq(d[n], 1.5);
+    free(d);
+
+    struct point* pts = calloc(n, sizeof(struct point));
+    is_not_null(pts) or_return();
+    pts[n - 1].y = 7;
+    is_eq(pts[n - 1].x, 0);
+    is_eq(pts[n - 1].y, 7);
+    free(pts);
+
+    char* s = malloc(n + 1);
+    is_not_null(s) or_return();
+    for (i = 0; i < n; i++)
+        s[i] = 'a' + i;
+    s[n] = '\0';
+    is_streq(s, "abcde");
+    free(s);
+}
+
 void test_free()
 {
     int *buffer1, *buffer2, *buffer3;
@@ -224,9 +272,41 @@ void struct_with_define()
 	is_eq(a.im,12);
 }
 
+// Sequences of pseudo-random generators are the same as in glibc, so they
+// are printed and compared with the output of C program.
+void test_rand_sequence()
+{
+    int i;
+    unsigned int seed = 42;
+    unsigned short xsubi[3] = { 1, 2, 3 };
+
+    srand(42);
+    for (i = 0; i < 5; i++) {
+        printf("rand: %d\n", rand());
+    }
+    srandom(12345);
+    for (i = 0; i < 5; i++) {
+        printf("random: %ld\n", random());
+    }
+    for (i = 0; i < 5; i++) {
+        printf("rand_r: %d\n", rand_r(&seed));
+    }
+    srand48(42);
+    for (i = 0; i < 3; i++) {
+        printf("drand48: %.12f\n", drand48());
+        printf("lrand48: %ld\n", lrand48());
+        printf("mrand48: %ld\n", mrand48());
+    }
+    for (i = 0; i < 3; i++) {
+        printf("erand48: %.12f\n", erand48(xsubi));
+        printf("nrand48: %ld\n", nrand48(xsubi));
+        printf("jrand48: %ld\n", jrand48(xsubi));
+    }
+}
+
 int main()
 {
-    plan(753);
+    plan(773);
 
 	struct_with_define();
 
@@ -324,11 +404,18 @@ int main()
             = div(-17, -5);
         is_eq(result.quot, 3)
             is_eq(result.rem, -2)
+
+        // member of returned struct
+        is_eq(div(17, 5).quot, 3);
+        is_eq((div(-17, 5)).rem, -2);
+        is_eq(div(17, -5).quot + div(17, -5).rem, -1);
     }
 
     diag("calloc");
     test_calloc();
 
+    test_alloc_length();
+
     // exit() is handled in tests/exit.c
 
     // free() is handled with the malloc and calloc tests.
@@ -365,6 +452,11 @@ int main()
             = ldiv(-17, -5);
         is_eq(result.quot, 3)
             is_eq(result.rem, -2)
+
+        // member of returned struct
+        is_eq(ldiv(17, 5).quot, 3);
+        is_eq((ldiv(-17, 5)).rem, -2);
+        is_eq(ldiv(17, -5).quot + ldiv(17, -5).rem, -1);
     }
 
     diag("llabs");
@@ -392,6 +484,11 @@ int main()
             = lldiv(-17, -5);
         is_eq(result.quot, 3)
             is_eq(result.rem, -2)
+
+        // member of returned struct
+        is_eq(lldiv(17, 5).quot, 3);
+        is_eq((lldiv(-17, 5)).rem, -2);
+        is_eq(lldiv(17, -5).quot + lldiv(17, -5).rem, -1);
     }
 
     diag("malloc");
@@ -429,6 +526,9 @@ int main()
     is_eq(a2, b2);
     is_eq(a3, b3);
 
+    diag("glibc sequences of rand / random / drand48");
+    test_rand_sequence();
+
     diag("strtod / strtof / strtold");
     test_strto1("123", is_eq, 123, "");
     test_strto1("1.23", is_eq, 1.23, "");
@@ -1,9 +1,50 @@
 #include "tests.h"
 #include <string.h>
+#include <stddef.h>
+
+void test_literals()
+{
+    diag("string literals");
+    char concat[] = "foo"
+                    "bar";
+    is_streq(concat, "foobar");
+    is_eq(sizeof(concat), 7);
+
+    char escapes[] = "\a\b\f\n\r\t\v\\\'\"\?";
+    is_eq(sizeof(escapes), 12);
+    is_eq(escapes[0], 7);
+    is_eq(escapes[6], 11);
+    is_eq(escapes[8], 39);
+    is_eq(escapes[10], 63);
+
+    char numeric[] = "\101\x42\0C\377";
+    is_eq(sizeof(numeric), 6);
+    is_eq(numeric[0], 'A');
+    is_eq(numeric[1], 'B');
+    is_eq(numeric[2], 0);
+    is_eq(numeric[3], 'C');
+    is_eq((unsigned char)numeric[4], 255);
+    is_eq(strlen(numeric), 2);
+
+    char utf8[] = u8"\u00e9t\u00e9";
+    is_eq(sizeof(utf8), 6);
+    is_eq((unsigned char)utf8[0], 0xc3);
+    is_eq((unsigned char)utf8[1], 0xa9);
+
+    char padded[8] = "ab";
+    is_eq(padded[2], 0);
+    is_eq(padded[7], 0);
+
+    wchar_t wide[] = L"a\u00e9";
+    is_eq(sizeof(wide), 3 * sizeof(wchar_t));
+    is_eq(wide[0], 'a');
+    is_eq(wide[1], 0xe9);
+    is_eq(wide[2], 0);
+}
 
 int main()
 {
-    plan(32);
+    plan(55);
 
     diag("TODO: __builtin_object_size");
     // https://github.com/Konstantin8105/c4go/issues/359
@@ -134,5 +175,7 @@ int main()
 		is_streq(str,"memmove can be very very useful.");
 	}
 
+    test_literals();
+
     done_testing();
 }
@@ -2,6 +2,7 @@
 
 #include "tests.h"
 #include <stdio.h>
+#include <stdlib.h>
 
 typedef void function_t (int args);
 
@@ -523,11 +524,269 @@ void struct_typ2()
 	is_eq(b.i,10);
 }
 
+struct RetIn {
+	int b;
+};
+
+struct RetS {
+	int a;
+	struct RetIn in;
+};
+
+struct RetS ret_struct(int a, int b)
+{
+	struct RetS r;
+	r.a = a;
+	r.in.b = b;
+	return r;
+}
+
+void struct_returned()
+{
+	struct RetS r = ret_struct(1, 2);
+	is_eq(r.a + r.in.b, 3);
+	is_eq(ret_struct(3, 4).a, 3);
+	is_eq((ret_struct(5, 6)).in.b, 6);
+	is_eq(ret_struct(7, 8).a * ret_struct(7, 8).in.b, 56);
+}
+
+struct RetArr {
+	int m[2][3];
+	char name[8];
+};
+
+struct RetArr global_arr;
+
+struct RetArr ret_arr(int v)
+{
+	global_arr.m[1][2] = v;
+	global_arr.name[0] = 'a';
+	return global_arr;
+}
+
+void struct_returned_array()
+{
+	struct RetArr r = ret_arr(5);
+	is_eq(r.m[1][2], 5);
+	r.m[1][2] = 6;
+	r.name[0] = 'b';
+	is_eq(global_arr.m[1][2], 5);
+	is_eq(global_arr.name[0], 'a');
+
+	struct RetArr c = r;
+	c.m[0][1] = 7;
+	is_eq(r.m[0][1], 0);
+	is_eq(c.m[1][2], 6);
+
+	int* row = c.m[1];
+	row[0] = 8;
+	is_eq(c.m[1][0], 8);
+	is_eq(ret_arr(9).m[1][2], 9);
+}
+
+struct CompoundPoint {
+    int x;
+    int y;
+};
+
+int compound_sum(int* arr, int n)
+{
+    int sum = 0;
+    for (int i = 0; i < n; i++) {
+        sum += arr[i];
+    }
+    return sum;
+}
+
+int compound_dist(struct CompoundPoint a)
+{
+    return a.x + a.y;
+}
+
+void compound_move(struct CompoundPoint* a)
+{
+    a->x++;
+}
+
+void struct_compound_literal()
+{
+    diag("Compound literals");
+
+    struct CompoundPoint pt = (struct CompoundPoint){.x = 1, .y = 2 };
+    is_eq(pt.x, 1);
+    is_eq(pt.y, 2);
+
+    pt = (struct CompoundPoint){.y = 5 };
+    is_eq(pt.x, 0);
+    is_eq(pt.y, 5);
+
+    int* arr = (int[]){ 1, 2, 3 };
+    is_eq(arr[2], 3);
+    arr[2] = 4;
+    is_eq(arr[2], 4);
+
+    is_eq(compound_sum((int[]){ 1, 2, 3, 4 }, 4), 10);
+    is_eq(compound_dist((struct CompoundPoint){ 3, 4 }), 7);
+
+    struct CompoundPoint* ptr = &(struct CompoundPoint){.x = 8 };
+    compound_move(ptr);
+    is_eq(ptr->x, 9);
+    is_eq(ptr->y, 0);
+
+    double d = (double){ 5 };
+    is_eq(d, 5);
+}
+
+typedef struct list_node list_node;
+struct list_node {
+    int value;
+    list_node* next;
+};
+
+list_node* list_push(list_node* head, int value)
+{
+    list_node* n = malloc(sizeof(list_node));
+    n->value = value;
+    n->next = head;
+    return n;
+}
+
+void list_append(list_node** pp, int value)
+{
+    while (*pp) {
+        pp = &(*pp)->next;
+    }
+    *pp = malloc(sizeof(**pp));
+    (*pp)->value = value;
+    (*pp)->next = NULL;
+}
+
+int list_sum(list_node* head)
+{
+    int sum = 0;
+    for (; head != NULL; head = head->next) {
+        sum += head->value;
+    }
+    return sum;
+}
+
+list_node* list_reverse(list_node* head)
+{
+    list_node* prev = NULL;
+    while (head) {
+        list_node* next = head->next;
+        head->next = prev;
+        prev = head;
+        head = next;
+    }
+    return prev;
+}
+
+void list_free(list_node* head)
+{
+    while (head != NULL) {
+        list_node* next = head->next;
+        free(head);
+        head = next;
+    }
+}
+
+struct tree_node {
+    int key;
+    struct tree_node *left, *right;
+};
+
+struct tree_node* tree_insert(struct tree_node* root, int key)
+{
+    if (!root) {
+        root = calloc(1, sizeof(struct tree_node));
+        root->key = key;
+        return root;
+    }
+    if (key < root->key) {
+        root->left = tree_insert(root->left, key);
+    } else {
+        root->right = tree_insert(root->right, key);
+    }
+    return root;
+}
+
+int tree_height(struct tree_node* root)
+{
+    if (root == NULL) {
+        return 0;
+    }
+    int l = tree_height(root->left);
+    int r = tree_height(root->right);
+    return 1 + (l > r ? l : r);
+}
+
+void tree_inorder(struct tree_node* root, int* out, int* n)
+{
+    if (root == NULL) {
+        return;
+    }
+    tree_inorder(root->left, out, n);
+    out[(*n)++] = root->key;
+    tree_inorder(root->right, out, n);
+}
+
+void tree_free(struct tree_node* root)
+{
+    if (root) {
+        tree_free(root->left);
+        tree_free(root->right);
+        free(root);
+    }
+}
+
+void recursive_structs()
+{
+    diag("Recursive data structures");
+    list_node* head = NULL;
+    is_true(head == NULL);
+    int i;
+    for (i = 1; i <= 4; i++) {
+        head = list_push(head, i);
+    }
+    is_eq(head->value, 4);
+    is_eq(head->next->next->value, 2);
+    is_eq(list_sum(head), 10);
+    list_append(&head, 5);
+    is_eq(list_sum(head), 15);
+    head = list_reverse(head);
+    is_eq(head->value, 5);
+    is_eq(head->next->value, 1);
+    is_true(head->next->next->next->next->next == NULL);
+    list_free(head);
+
+    struct tree_node* root = NULL;
+    int keys[] = { 5, 3, 8, 1, 4, 9 };
+    for (i = 0; i < 6; i++) {
+        root = tree_insert(root, keys[i]);
+    }
+    is_eq(root->key, 5);
+    is_eq(root->left->right->key, 4);
+    is_eq(tree_height(root), 3);
+    int sorted[6];
+    int n = 0;
+    tree_inorder(root, sorted, &n);
+    is_eq(n, 6);
+    is_eq(sorted[0], 1);
+    is_eq(sorted[5], 9);
+    tree_free(root);
+}
+
 int main()
 {
-    plan(88);
+    plan(139);
+
+    recursive_structs();
 
 	struct_typ2();
+	struct_compound_literal();
+	struct_returned();
+	struct_returned_array();
 	struct_byte_array();
 	test_pointer_member();
 	test_typedef1();
@@ -614,6 +873,37 @@ int main()
     is_eq(p.x, 3);
     is_eq(p.y, 2);
 
+    diag("Designated initializers");
+    {
+        struct Designated {
+            int x;
+            int* ptr;
+            int arr[3];
+            struct Point pt;
+            double d;
+        };
+        struct Designated des = {.d = 2.5, .arr[1] = 7, .pt.y = 4 };
+        is_eq(des.x, 0);
+        is_null(des.ptr);
+        is_eq(des.arr[0], 0);
+        is_eq(des.arr[1], 7);
+        is_eq(des.arr[2], 0);
+        is_eq(des.pt.x, 0);
+        is_eq(des.pt.y, 4);
+        is_eq(des.d, 2.5);
+
+        int sparse[6] = {[1] = 3, [4] = 9 };
+        is_eq(sparse[0], 0);
+        is_eq(sparse[1], 3);
+        is_eq(sparse[4], 9);
+        is_eq(sparse[5], 0);
+
+        struct Point points[3] = {[2].y = 5, [0] = {.x = 1 } };
+        is_eq(points[0].x, 1);
+        is_eq(points[1].y, 0);
+        is_eq(points[2].y, 5);
+    }
+
     diag("ImplicitValueInitExpr");
     {
         typedef struct {
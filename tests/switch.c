@@ -335,9 +335,94 @@ void switch_bool()
 	}
 }
 
+int classify(char c)
+{
+	switch (c) {
+		case '0' ... '9':
+			return 1;
+		case 'a' ... 'z':
+		case 'A' ... 'Z':
+			return 2;
+		case ' ':
+			return 3;
+	}
+	return 0;
+}
+
+void switch_case_range()
+{
+	is_eq(classify('5'), 1);
+	is_eq(classify('q'), 2);
+	is_eq(classify('Q'), 2);
+	is_eq(classify(' '), 3);
+	is_eq(classify('!'), 0);
+
+	int sum = 0;
+	for (int i = 0; i < 10; i++) {
+		switch (i) {
+			case 0 ... 2:
+				sum += 1;
+			case 3:
+				sum += 10;
+				break;
+			case 4 ... 8:
+				sum += 100;
+		}
+	}
+	is_eq(sum, 3 * 11 + 10 + 5 * 100);
+}
+
+int case_declaration(int a)
+{
+	int r = 0;
+	switch (a) {
+		case 1:;
+			int b = 5;
+			r += b;
+			break;
+		case 2:
+			b = 7;
+			r += b;
+			break;
+	}
+	return r;
+}
+
+void switch_case_declaration()
+{
+	is_eq(case_declaration(1), 5);
+	is_eq(case_declaration(2), 7);
+	is_eq(case_declaration(3), 0);
+}
+
+int default_in_middle(int a)
+{
+	int r = 0;
+	switch (a) {
+		case 1:
+			r += 1;
+		default:
+			r += 10;
+		case 2:
+			r += 100;
+			break;
+		case 3:
+			r += 1000;
+	}
+	return r;
+}
+
+void switch_default_in_middle()
+{
+	is_eq(default_in_middle(1), 111);
+	is_eq(default_in_middle(2), 100);
+	is_eq(default_in_middle(3), 1000);
+	is_eq(default_in_middle(4), 110);
+}
+
 int main()
 {
-    plan(28);
+    plan(41);
 
 	switch_bool();
     match_a_single_case();
@@ -360,5 +445,9 @@ int main()
     default_only_switch();
     switch_without_input();
 
+    switch_case_range();
+    switch_case_declaration();
+    switch_default_in_middle();
+
     done_testing();
 }
@@ -0,0 +1,55 @@
+// This file contains tests for variables with storage class thread_local in
+// the program without threads. These variables are plain global variables.
+
+#include "tests.h"
+#include <stdio.h>
+#include <threads.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+thread_local int counter = 5;
+_Thread_local double ratio;
+__thread int calls;
+static __thread char name[8] = "c4go";
+
+int next()
+{
+    calls++;
+    return ++counter;
+}
+
+void test_scalar()
+{
+    is_eq(counter, 5);
+    is_eq(next(), 6);
+    is_eq(next(), 7);
+    is_eq(calls, 2);
+    counter += 3;
+    is_eq(counter, 10);
+    int* p = &counter;
+    *p = 1;
+    is_eq(counter, 1);
+    is_eq(ratio, 0.0);
+    ratio = 0.5;
+    is_eq(ratio * 4, 2.0);
+}
+
+void test_array()
+{
+    is_streq(name, "c4go");
+    name[0] = 'C';
+    is_streq(name, "C4go");
+    is_eq(name[7], 0);
+}
+
+int main()
+{
+    plan(11);
+
+    START_TEST(scalar);
+    START_TEST(array);
+
+    done_testing();
+}
@@ -0,0 +1,301 @@
+// This file contains tests for the threads.h functions and variables with
+// storage class thread_local.
+
+#include "tests.h"
+#include <stdio.h>
+#include <stdlib.h>
+#include <threads.h>
+#include <time.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+#define THREADS 4
+#define ITERATIONS 1000
+
+mtx_t counter_mutex;
+int counter = 0;
+
+int increment(void* arg)
+{
+    int* id = (int*)arg;
+    for (int i = 0; i < ITERATIONS; i++) {
+        mtx_lock(&counter_mutex);
+        counter++;
+        mtx_unlock(&counter_mutex);
+    }
+    return *id * 10;
+}
+
+void test_create_join()
+{
+    thrd_t threads[THREADS];
+    int ids[THREADS];
+    int errors = 0;
+    is_eq(mtx_init(&counter_mutex, mtx_plain), thrd_success);
+    for (int i = 0; i < THREADS; i++) {
+        ids[i] = i;
+        if (thrd_create(&threads[i], increment, (void*)&ids[i]) != thrd_success) {
+            errors++;
+        }
+    }
+    int sum = 0;
+    for (int i = 0; i < THREADS; i++) {
+        int result;
+        if (thrd_join(threads[i], &result) != thrd_success) {
+            errors++;
+        }
+        sum += result;
+    }
+    is_eq(errors, 0);
+    is_eq(counter, THREADS * ITERATIONS);
+    is_eq(sum, 0 + 10 + 20 + 30);
+    mtx_destroy(&counter_mutex);
+}
+
+int current(void* arg)
+{
+    thrd_t* t = (thrd_t*)arg;
+    *t = thrd_current();
+    thrd_yield();
+    return 0;
+}
+
+void test_current()
+{
+    thrd_t t;
+    thrd_t inside;
+    is_eq(thrd_create(&t, current, (void*)&inside), thrd_success);
+    is_eq(thrd_join(t, NULL), thrd_success);
+    is_true(thrd_equal(t, inside));
+    is_false(thrd_equal(thrd_current(), inside));
+    is_true(thrd_equal(thrd_current(), thrd_current()));
+}
+
+int exit_thread(void* arg)
+{
+    (void)arg;
+    thrd_exit(42);
+    return 0;
+}
+
+void test_exit()
+{
+    thrd_t t;
+    int result = 0;
+    is_eq(thrd_create(&t, exit_thread, NULL), thrd_success);
+    is_eq(thrd_join(t, &result), thrd_success);
+    is_eq(result, 42);
+}
+
+void test_mutex()
+{
+    mtx_t m;
+    is_eq(mtx_init(&m, mtx_plain), thrd_success);
+    is_eq(mtx_trylock(&m), thrd_success);
+    is_eq(mtx_trylock(&m), thrd_busy);
+    is_eq(mtx_unlock(&m), thrd_success);
+    mtx_destroy(&m);
+
+    is_eq(mtx_init(&m, mtx_plain | mtx_recursive), thrd_success);
+    is_eq(mtx_lock(&m), thrd_success);
+    is_eq(mtx_lock(&m), thrd_success);
+    is_eq(mtx_unlock(&m), thrd_success);
+    is_eq(mtx_unlock(&m), thrd_success);
+    mtx_destroy(&m);
+}
+
+mtx_t timed_mutex;
+
+int hold(void* arg)
+{
+    (void)arg;
+    struct timespec ts;
+    ts.tv_sec = time(NULL) + 1;
+    ts.tv_nsec = 0;
+    return mtx_timedlock(&timed_mutex, &ts);
+}
+
+void test_timedlock()
+{
+    thrd_t t;
+    int result = -1;
+    is_eq(mtx_init(&timed_mutex, mtx_timed), thrd_success);
+    is_eq(mtx_lock(&timed_mutex), thrd_success);
+    is_eq(thrd_create(&t, hold, NULL), thrd_success);
+    is_eq(thrd_join(t, &result), thrd_success);
+    is_eq(result, thrd_timedout);
+    is_eq(mtx_unlock(&timed_mutex), thrd_success);
+    mtx_destroy(&timed_mutex);
+}
+
+mtx_t queue_mutex;
+cnd_t queue_cond;
+int queue = 0;
+
+int producer(void* arg)
+{
+    int* amount = (int*)arg;
+    for (int i = 0; i < *amount; i++) {
+        mtx_lock(&queue_mutex);
+        queue++;
+        cnd_signal(&queue_cond);
+        mtx_unlock(&queue_mutex);
+    }
+    return 0;
+}
+
+void test_cond()
+{
+    thrd_t t;
+    int amount = 10;
+    int consumed = 0;
+    is_eq(mtx_init(&queue_mutex, mtx_plain), thrd_success);
+    is_eq(cnd_init(&queue_cond), thrd_success);
+    is_eq(thrd_create(&t, producer, (void*)&amount), thrd_success);
+    mtx_lock(&queue_mutex);
+    while (consumed < amount) {
+        while (queue == 0) {
+            cnd_wait(&queue_cond, &queue_mutex);
+        }
+        queue--;
+        consumed++;
+    }
+    mtx_unlock(&queue_mutex);
+    is_eq(thrd_join(t, NULL), thrd_success);
+    is_eq(consumed, amount);
+    is_eq(queue, 0);
+
+    struct timespec ts;
+    ts.tv_sec = time(NULL) - 1;
+    ts.tv_nsec = 0;
+    mtx_lock(&queue_mutex);
+    is_eq(cnd_timedwait(&queue_cond, &queue_mutex, &ts), thrd_timedout);
+    mtx_unlock(&queue_mutex);
+    is_eq(cnd_broadcast(&queue_cond), thrd_success);
+    cnd_destroy(&queue_cond);
+    mtx_destroy(&queue_mutex);
+}
+
+once_flag flag = ONCE_FLAG_INIT;
+int once_counter = 0;
+
+void init_once()
+{
+    once_counter++;
+}
+
+int call_init(void* arg)
+{
+    (void)arg;
+    call_once(&flag, init_once);
+    return 0;
+}
+
+void test_once()
+{
+    thrd_t threads[THREADS];
+    for (int i = 0; i < THREADS; i++) {
+        thrd_create(&threads[i], call_init, NULL);
+    }
+    for (int i = 0; i < THREADS; i++) {
+        thrd_join(threads[i], NULL);
+    }
+    call_once(&flag, init_once);
+    is_eq(once_counter, 1);
+}
+
+tss_t key;
+int destructor_calls = 0;
+
+void destructor(void* value)
+{
+    (void)value;
+    mtx_lock(&counter_mutex);
+    destructor_calls++;
+    mtx_unlock(&counter_mutex);
+}
+
+int specific(void* arg)
+{
+    int* value = (int*)arg;
+    tss_set(key, (void*)value);
+    thrd_yield();
+    return *(int*)tss_get(key);
+}
+
+void test_tss()
+{
+    thrd_t threads[THREADS];
+    int values[THREADS];
+    int errors = 0;
+    mtx_init(&counter_mutex, mtx_plain);
+    is_eq(tss_create(&key, destructor), thrd_success);
+    is_null(tss_get(key));
+    for (int i = 0; i < THREADS; i++) {
+        values[i] = i * 100;
+        thrd_create(&threads[i], specific, (void*)&values[i]);
+    }
+    for (int i = 0; i < THREADS; i++) {
+        int result;
+        thrd_join(threads[i], &result);
+        if (result != i * 100) {
+            errors++;
+        }
+    }
+    is_eq(errors, 0);
+    is_eq(destructor_calls, THREADS);
+    tss_delete(key);
+    mtx_destroy(&counter_mutex);
+}
+
+thread_local int local_counter = 5;
+
+int count_local(void* arg)
+{
+    int* amount = (int*)arg;
+    for (int i = 0; i < *amount; i++) {
+        local_counter++;
+    }
+    return local_counter;
+}
+
+void test_thread_local()
+{
+    thrd_t threads[THREADS];
+    int amounts[THREADS];
+    int errors = 0;
+    for (int i = 0; i < THREADS; i++) {
+        amounts[i] = (i + 1) * 10;
+        thrd_create(&threads[i], count_local, (void*)&amounts[i]);
+    }
+    for (int i = 0; i < THREADS; i++) {
+        int result;
+        thrd_join(threads[i], &result);
+        if (result != 5 + amounts[i]) {
+            errors++;
+        }
+    }
+    is_eq(errors, 0);
+    is_eq(local_counter, 5);
+    local_counter += 2;
+    is_eq(local_counter, 7);
+}
+
+int main()
+{
+    plan(43);
+
+    START_TEST(create_join);
+    START_TEST(current);
+    START_TEST(exit);
+    START_TEST(mutex);
+    START_TEST(timedlock);
+    START_TEST(cond);
+    START_TEST(once);
+    START_TEST(tss);
+    START_TEST(thread_local);
+
+    done_testing();
+}
@@ -1,5 +1,7 @@
 #include <stdio.h>
+#include <sys/time.h>
 #include <time.h>
+#include <unistd.h>
 
 #include "tests.h"
 
@@ -80,16 +82,80 @@ void test_asctime()
     is_streq(asctime(timeinfo), "Thu Jan  1 22:13:20 1970\n");
 }
 
+// elapsed returns the time in microseconds since the time start.
+long elapsed(struct timeval start)
+{
+    struct timeval now;
+    gettimeofday(&now, NULL);
+    return (now.tv_sec - start.tv_sec) * 1000000 + (now.tv_usec - start.tv_usec);
+}
+
+void test_clock()
+{
+    is_eq(CLOCKS_PER_SEC, 1000000);
+    clock_t start = clock();
+    is_true(start >= 0);
+    volatile double sum = 0;
+    while (clock() - start < CLOCKS_PER_SEC / 100) {
+        sum += 1;
+    }
+    double seconds = (double)(clock() - start) / CLOCKS_PER_SEC;
+    is_true(seconds >= 0.01);
+    is_true(seconds < 1);
+}
+
+void test_gettimeofday()
+{
+    struct timeval tv;
+    is_eq(gettimeofday(&tv, NULL), 0);
+    is_true(tv.tv_sec > 946670398);
+    is_true(tv.tv_usec >= 0);
+    is_true(tv.tv_usec < 1000000);
+    is_true(tv.tv_sec - time(NULL) <= 1);
+}
+
+void test_nanosleep()
+{
+    struct timeval start;
+    struct timespec req;
+    struct timespec rem;
+    req.tv_sec = 0;
+    req.tv_nsec = 20000000;
+    gettimeofday(&start, NULL);
+    is_eq(nanosleep(&req, &rem), 0);
+    is_true(elapsed(start) >= 20000);
+    req.tv_nsec = 1000000000;
+    is_eq(nanosleep(&req, NULL), -1);
+    req.tv_nsec = -1;
+    is_eq(nanosleep(&req, NULL), -1);
+}
+
+void test_sleep()
+{
+    struct timeval start;
+    gettimeofday(&start, NULL);
+    is_eq(usleep(20000), 0);
+    is_true(elapsed(start) >= 20000);
+    is_eq(sleep(0), 0);
+    gettimeofday(&start, NULL);
+    is_eq(sleep(1), 0);
+    is_true(elapsed(start) >= 1000000);
+}
+
 int main()
 {
-    plan(19);
+    plan(37);
 
     // sorting in according to :
     // http://www.cplusplus.com/reference/ctime/
     START_TEST(asctime);
+    START_TEST(clock);
     START_TEST(ctime);
+    START_TEST(gettimeofday);
     START_TEST(gmtime);
     START_TEST(mktime);
+    START_TEST(nanosleep);
+    START_TEST(sleep);
     START_TEST(time);
 
     done_testing();
@@ -250,9 +250,82 @@ void union_with_struct()
     is_true(u.l > 0);
 }
 
+struct value_box {
+    union unknown u;
+    union unknown us[2][2];
+    int arr[3];
+};
+
+typedef union unknown unknown_t;
+
+void value_box_change(struct value_box b)
+{
+    b.u.d2 = -1.0;
+    b.us[1][0].d2 = -1.0;
+    b.arr[0] = -1;
+}
+
+struct value_box value_box_get(struct value_box* b)
+{
+    return *b;
+}
+
+unknown_t unknown_twice(unknown_t u)
+{
+    u.d2 *= 2.0;
+    return u;
+}
+
+void union_value_semantics()
+{
+    struct value_box a, b;
+    a.u.d2 = 1.5;
+    a.us[1][0].d2 = 2.5;
+    a.arr[0] = 7;
+
+    diag("assignment");
+    b = a;
+    b.u.d2 = 3.5;
+    b.us[1][0].d2 = 4.5;
+    b.arr[0] = 8;
+    is_eq(a.u.d2, 1.5);
+    is_eq(a.us[1][0].d2, 2.5);
+    is_eq(a.arr[0], 7);
+    is_eq(b.u.d2, 3.5);
+    is_eq(b.us[1][0].d2, 4.5);
+
+    diag("initialization");
+    struct value_box c = a;
+    c.u.i2 = 9.5;
+    is_eq(a.u.d2, 1.5);
+    is_eq(c.u.d2, 9.5);
+
+    diag("pass by value");
+    value_box_change(a);
+    is_eq(a.u.d2, 1.5);
+    is_eq(a.us[1][0].d2, 2.5);
+    is_eq(a.arr[0], 7);
+
+    diag("return by value");
+    struct value_box d = value_box_get(&a);
+    d.u.d2 = 5.5;
+    is_eq(a.u.d2, 1.5);
+    is_eq(d.u.d2, 5.5);
+
+    diag("typedef of union");
+    unknown_t u, v;
+    u.d2 = 6.0;
+    v = unknown_twice(u);
+    is_eq(u.d2, 6.0);
+    is_eq(v.d2, 12.0);
+    v = u;
+    v.d2 = 1.0;
+    is_eq(u.d2, 6.0);
+}
+
 int main()
 {
-    plan(48);
+    plan(63);
 
     union programming variable;
 
@@ -268,6 +341,7 @@ int main()
     union_array();
     union_arr_in_str();
     union_with_struct();
+    union_value_semantics();
 
     done_testing();
 }
@@ -0,0 +1,99 @@
+// This file contains tests for the file descriptor functions of unistd.h
+// and fcntl.h: open, read, write, close, lseek, dup, dup2, pipe and the
+// stdio functions fileno and fdopen.
+
+#include "tests.h"
+#include <fcntl.h>
+#include <stdio.h>
+#include <string.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_open()
+{
+    char buffer[20];
+    int fd = open("build/unistd.txt", O_RDWR | O_CREAT | O_TRUNC, 0644);
+    is_true(fd > 2);
+    is_eq(write(fd, "hello world", 11), 11);
+    is_eq(lseek(fd, 0, SEEK_SET), 0);
+    is_eq(read(fd, buffer, 5), 5);
+    buffer[5] = '\0';
+    is_streq(buffer, "hello");
+    is_eq(lseek(fd, 0, SEEK_END), 11);
+    is_eq(read(fd, buffer, 5), 0);
+    is_eq(close(fd), 0);
+    is_eq(close(fd), -1);
+    is_eq(open("build/not_exist/unistd.txt", O_RDONLY), -1);
+}
+
+void test_dup()
+{
+    char buffer[20];
+    int fd = open("build/unistd.txt", O_RDONLY);
+    int fd2 = dup(fd);
+    is_true(fd2 != fd);
+    is_eq(read(fd, buffer, 6), 6);
+    is_eq(read(fd2, buffer, 5), 5);
+    buffer[5] = '\0';
+    is_streq(buffer, "world");
+    is_eq(close(fd), 0);
+    is_eq(close(fd2), 0);
+}
+
+void test_pipe()
+{
+    char buffer[20];
+    int fds[2];
+    is_eq(pipe(fds), 0);
+    is_eq(write(fds[1], "abc", 3), 3);
+    is_eq(read(fds[0], buffer, 3), 3);
+    buffer[3] = '\0';
+    is_streq(buffer, "abc");
+
+    // redirect stdout into the pipe
+    fflush(stdout);
+    int saved = dup(1);
+    int redirected = dup2(fds[1], 1);
+    printf("redirect");
+    fflush(stdout);
+    int restored = dup2(saved, 1);
+    close(saved);
+    is_eq(redirected, 1);
+    is_eq(restored, 1);
+    is_eq(read(fds[0], buffer, 8), 8);
+    buffer[8] = '\0';
+    is_streq(buffer, "redirect");
+
+    close(fds[0]);
+    close(fds[1]);
+}
+
+void test_stdio()
+{
+    char buffer[20];
+    is_eq(fileno(stdin), 0);
+    is_eq(fileno(stdout), 1);
+    is_eq(fileno(stderr), 2);
+
+    int fd = open("build/unistd.txt", O_RDONLY);
+    FILE* f = fdopen(fd, "r");
+    is_not_null(f);
+    is_not_null(fgets(buffer, 6, f));
+    is_streq(buffer, "hello");
+    fclose(f);
+}
+
+int main()
+{
+    plan(30);
+
+    START_TEST(open);
+    START_TEST(dup);
+    START_TEST(pipe);
+    START_TEST(stdio);
+
+    done_testing();
+}
@@ -0,0 +1,50 @@
+// This file contains tests for uname() of sys/utsname.h and gethostname()
+// of unistd.h.
+
+#include "tests.h"
+#include <stdio.h>
+#include <string.h>
+#include <sys/utsname.h>
+#include <unistd.h>
+
+#define START_TEST(t) \
+    diag(#t);         \
+    test_##t();
+
+void test_uname()
+{
+    struct utsname u;
+    is_eq(uname(&u), 0);
+    is_true(strlen(u.sysname) > 0);
+    is_true(strlen(u.release) > 0);
+    is_true(strlen(u.version) > 0);
+    is_true(strlen(u.machine) > 0);
+
+    char buffer[300];
+    sprintf(buffer, "%s %s", u.sysname, u.machine);
+    is_true(strlen(buffer) > 2);
+}
+
+void test_gethostname()
+{
+    char hostname[256];
+    is_eq(gethostname(hostname, sizeof(hostname)), 0);
+    is_true(strlen(hostname) > 0);
+
+    struct utsname u;
+    is_eq(uname(&u), 0);
+    is_streq(hostname, u.nodename);
+
+    char small[1];
+    is_eq(gethostname(small, sizeof(small)), -1);
+}
+
+int main()
+{
+    plan(11);
+
+    START_TEST(uname);
+    START_TEST(gethostname);
+
+    done_testing();
+}
@@ -0,0 +1,244 @@
+package transpiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// findPooledBuffers returns the local variables of recursive function, for
+// which buffers of char allocated by alloca() or as variable length arrays
+// may be taken from the pool of package noarch. The memory of alloca() is
+// freed at the end of function, so all allocations are pooled. The memory of
+// variable length array is freed at the end of block, so only arrays
+// declared in the function body (but not in loops) are pooled. Example of C
+// code:
+//
+//     void parse(const char *s, int depth)
+//     {
+//         char token[depth + 1];          // pooled
+//         char *tmp = alloca(depth + 1);  // pooled
+//         for (;;) {
+//             char line[depth + 1];       // not pooled
+//             ...
+//             parse(s + 1, depth - 1);
+//         }
+//     }
+//
+func findPooledBuffers(n *ast.FunctionDecl) map[ast.Address]bool {
+	pooled := map[ast.Address]bool{}
+	body := getFunctionBody(n)
+	if body == nil {
+		return pooled
+	}
+
+	var recursive bool
+	for _, node := range ast.GetAllNodesOfType(body,
+		reflect.TypeOf((*ast.DeclRefExpr)(nil))) {
+		if decl := node.(*ast.DeclRefExpr); decl.For == "Function" &&
+			decl.Name == n.Name {
+			recursive = true
+			break
+		}
+	}
+	if !recursive {
+		return pooled
+	}
+
+	for _, node := range body.Children() {
+		decl, ok := node.(*ast.DeclStmt)
+		if !ok {
+			continue
+		}
+		for _, c := range decl.Children() {
+			if v, ok := c.(*ast.VarDecl); ok {
+				if _, size := types.GetVariableArrayTypeAndSize(v.Type); size != "" {
+					pooled[v.Addr] = true
+				}
+			}
+		}
+	}
+
+	for _, node := range ast.GetAllNodesOfType(body,
+		reflect.TypeOf((*ast.VarDecl)(nil))) {
+		v := node.(*ast.VarDecl)
+		if len(v.Children()) == 0 {
+			continue
+		}
+		if call := foundCallExpr(v.Children()[0]); call != nil && isAllocaCall(call) {
+			pooled[v.Addr] = true
+		}
+	}
+
+	return pooled
+}
+
+// isAllocaCall returns true for the call of alloca(). In glibc the function
+// alloca() is the macro of builtin function of compiler.
+func isAllocaCall(call *ast.CallExpr) bool {
+	if len(call.Children()) == 0 {
+		return false
+	}
+	impl, ok := call.Children()[0].(*ast.ImplicitCastExpr)
+	if !ok || len(impl.Children()) == 0 {
+		return false
+	}
+	decl, ok := impl.Children()[0].(*ast.DeclRefExpr)
+	if !ok {
+		return false
+	}
+	return decl.Name == "alloca" || decl.Name == "__builtin_alloca"
+}
+
+// transpileVariableArraySize returns the amount of elements of variable
+// length array. Clang shows the size of array only as the C expression in the
+// type of variable, for example 'char [n + 1]', so the expression is parsed
+// as the Go expression. Only variables, integer literals and arithmetic
+// operators are supported. Variables are converted to int, because they may
+// have any integer type:
+//
+//     char [len * 2 + 1] -> int(len)*2 + 1
+//
+func transpileVariableArraySize(size string) (goast.Expr, error) {
+	// remove suffixes of C integer literals: 10U, 20L, 30UL
+	size = util.GetRegex(`\b(\d+)[uUlL]+\b`).ReplaceAllString(size, "$1")
+
+	expr, err := parser.ParseExpr(size)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse size of variable length array `%s`: %v",
+			size, err)
+	}
+
+	var convert func(e goast.Expr) (goast.Expr, error)
+	convert = func(e goast.Expr) (goast.Expr, error) {
+		switch v := e.(type) {
+		case *goast.Ident:
+			return util.NewCallExpr("int", util.NewIdent(v.Name)), nil
+
+		case *goast.BasicLit:
+			if v.Kind == token.INT {
+				return v, nil
+			}
+
+		case *goast.ParenExpr:
+			x, err := convert(v.X)
+			if err != nil {
+				return nil, err
+			}
+			return &goast.ParenExpr{X: x}, nil
+
+		case *goast.UnaryExpr:
+			if v.Op == token.SUB || v.Op == token.ADD {
+				x, err := convert(v.X)
+				if err != nil {
+					return nil, err
+				}
+				return &goast.UnaryExpr{Op: v.Op, X: x}, nil
+			}
+
+		case *goast.BinaryExpr:
+			switch v.Op {
+			case token.ADD, token.SUB, token.MUL, token.QUO, token.REM,
+				token.SHL, token.SHR:
+				x, err := convert(v.X)
+				if err != nil {
+					return nil, err
+				}
+				y, err := convert(v.Y)
+				if err != nil {
+					return nil, err
+				}
+				return &goast.BinaryExpr{X: x, Op: v.Op, Y: y}, nil
+			}
+		}
+		return nil, fmt.Errorf("size of variable length array `%s` is not supported",
+			size)
+	}
+
+	return convert(expr)
+}
+
+// pooledAlloc returns the allocation of buffer from the pool of package
+// noarch instead of allocation by function make, if the variable is found by
+// findPooledBuffers() and the buffer is slice of bytes:
+//
+//     make([]byte, n)  -> noarch.Alloca(n)
+//
+func pooledAlloc(p *program.Program, n *ast.VarDecl, value goast.Expr) goast.Expr {
+	if !p.PooledBuffers[n.Addr] {
+		return value
+	}
+	call, ok := value.(*goast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return value
+	}
+	if f, ok := call.Fun.(*goast.Ident); !ok || f.Name != "make" {
+		return value
+	}
+	if !isByteSlice(call.Args[0]) {
+		return value
+	}
+	return util.NewCallExpr(
+		p.ImportType("github.com/Konstantin8105/c4go/noarch.Alloca"),
+		call.Args[1])
+}
+
+// isByteSlice returns true for the Go type []byte.
+func isByteSlice(t goast.Expr) bool {
+	switch v := t.(type) {
+	case *goast.Ident:
+		return v.Name == "[]byte"
+	case *goast.ArrayType:
+		if elt, ok := v.Elt.(*goast.Ident); ok {
+			return v.Len == nil && elt.Name == "byte"
+		}
+	}
+	return false
+}
+
+// freePooledBuffers returns the buffers of declarations in the pool at the
+// end of function:
+//
+//     var buf []byte = noarch.Alloca(n)
+//     defer noarch.FreeAlloca(buf)
+//
+func freePooledBuffers(p *program.Program, stmts []goast.Stmt) []goast.Stmt {
+	var result []goast.Stmt
+	for _, stmt := range stmts {
+		result = append(result, stmt)
+		decl, ok := stmt.(*goast.DeclStmt)
+		if !ok {
+			continue
+		}
+		gen, ok := decl.Decl.(*goast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value, ok := spec.(*goast.ValueSpec)
+			if !ok || len(value.Names) != 1 || len(value.Values) != 1 {
+				continue
+			}
+			call, ok := value.Values[0].(*goast.CallExpr)
+			if !ok {
+				continue
+			}
+			if f, ok := call.Fun.(*goast.Ident); !ok || f.Name != "noarch.Alloca" {
+				continue
+			}
+			result = append(result, &goast.DeferStmt{
+				Call: util.NewCallExpr(
+					p.ImportType("github.com/Konstantin8105/c4go/noarch.FreeAlloca"),
+					goast.NewIdent(value.Names[0].Name)),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+// This file contains functions for transpiling the functions with inline
+// assembly of GNU C. Go does not support inline assembly, so the function
+// with inline assembly is transpiled as stub and the rest of C code is
+// transpiled as usual. The body of stub calls the Go implementation of
+// function from option "-replace" or panics:
+//
+//     unsigned long rdtsc(void) { __asm__("rdtsc" : ...); ... }
+//
+// is transpiled to Go code:
+//
+//     func rdtsc() uint32 {
+//         panic("c4go: function `rdtsc` with inline assembly is not transpiled")
+//     }
+//
+// or with replacement {"c": "rdtsc", "go": "github.com/user/project/cutil.Rdtsc"}:
+//
+//     func rdtsc() uint32 {
+//         return cutil.Rdtsc()
+//     }
+//
+// Inline assembly with empty template, like compiler barrier
+// `asm volatile("" ::: "memory")`, has not effect in Go and it is ignored.
+//
+
+package transpiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"reflect"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// transpileAsmFunction returns the body of stub for the function with
+// inline assembly. Warnings are added for each statement of inline assembly,
+// if the function has not replacement.
+func transpileAsmFunction(p *program.Program, n *ast.FunctionDecl,
+	functionBody *ast.CompoundStmt) (body *goast.BlockStmt, ok bool) {
+	if functionBody == nil {
+		return nil, false
+	}
+	var asm []ast.Node
+	for _, node := range ast.GetAllNodesOfType(functionBody,
+		reflect.TypeOf((*ast.GCCAsmStmt)(nil))) {
+		if !isAsmBarrier(p, node.(*ast.GCCAsmStmt)) {
+			asm = append(asm, node)
+		}
+	}
+	if len(asm) == 0 {
+		return nil, false
+	}
+
+	if body, ok = asmReplacementBody(p, n); ok {
+		return body, true
+	}
+	for _, node := range asm {
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"cannot transpile asm, function `%s` is transpiled as stub, "+
+				"Go implementation may be set by option -replace", n.Name), node))
+	}
+	return &goast.BlockStmt{List: []goast.Stmt{
+		&goast.ExprStmt{X: util.NewCallExpr("panic", util.NewStringLit(
+			fmt.Sprintf("\"c4go: function `%s` with inline assembly is not transpiled\"",
+				n.Name)))},
+	}}, true
+}
+
+// isAsmBarrier returns true for inline assembly with empty template, like
+// `__asm__ __volatile__("" ::: "memory")`. Template of inline assembly is
+// not in clang AST, so it is found in the source code.
+func isAsmBarrier(p *program.Program, n *ast.GCCAsmStmt) bool {
+	pos := n.Position()
+	buffer, err := p.PreprocessorFile.GetSnippet(pos.File, pos.Line, 0,
+		pos.Column, 0)
+	if err != nil {
+		return false
+	}
+	return util.GetRegex(`^\w+(\s+\w+)*\s*\(\s*""\s*([:)]|$)`).Match(buffer)
+}
+
+// asmReplacementBody returns the body of function, which calls the
+// replacement of function from option "-replace". If the Go signature of
+// function is not the same as the signature of replacement, then the
+// warning is added.
+func asmReplacementBody(p *program.Program, n *ast.FunctionDecl) (
+	_ *goast.BlockStmt, ok bool) {
+	r, ok := p.Replacements[n.Name]
+	if !ok {
+		return nil, false
+	}
+	def, err := checkReplacement(p, n.Type, n.Name, r)
+	if err == nil {
+		var args []string
+		if f := p.GetFunctionDefinition(n.Name); f != nil {
+			args = f.ArgumentTypes
+		}
+		var fieldList *goast.FieldList
+		fieldList, err = getFieldList(p, n, args)
+		if err == nil {
+			return replacementBody(p, r, def, fieldList), true
+		}
+	}
+	p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+		"function `%s` is not replaced by `%s`: %v", n.Name, r.Function, err), n))
+	return nil, false
+}
+
+// replacementBody returns the body of function with the call of
+// replacement with all arguments of function.
+func replacementBody(p *program.Program, r program.Replacement,
+	def *program.FunctionDefinition, fieldList *goast.FieldList) *goast.BlockStmt {
+	call := &goast.CallExpr{Fun: goast.NewIdent(p.ImportType(r.Function))}
+	for _, field := range fieldList.List {
+		for _, name := range field.Names {
+			call.Args = append(call.Args, goast.NewIdent(name.Name))
+		}
+		if _, ok := field.Type.(*goast.Ellipsis); ok {
+			call.Ellipsis = 1
+		}
+	}
+	if def.ReturnType == "void" {
+		return &goast.BlockStmt{List: []goast.Stmt{&goast.ExprStmt{X: call}}}
+	}
+	return &goast.BlockStmt{List: []goast.Stmt{
+		&goast.ReturnStmt{Results: []goast.Expr{call}},
+	}}
+}
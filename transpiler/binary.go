@@ -6,6 +6,7 @@ import (
 	"fmt"
 	goast "go/ast"
 	"go/token"
+	"strconv"
 	"strings"
 
 	"github.com/Konstantin8105/c4go/ast"
@@ -216,13 +217,9 @@ func transpileBinaryOperator(n *ast.BinaryOperator, p *program.Program, exprIsSt
 			err = fmt.Errorf("cannot transpile expr `token.COMMA` child 1. %v", err)
 			return nil, "unknown51", nil, nil, err
 		}
-		// Theoretically , we don't have any preStmts or postStmts
-		// from n.Children()[1]
-		if len(newPre) > 0 || len(newPost) > 0 {
-			p.AddMessage(p.GenerateWarningMessage(
-				fmt.Errorf("Not support length pre or post stmts: {%d,%d}",
-					len(newPre), len(newPost)), n))
-		}
+		// pre and post statements of right part are evaluated after
+		// the left part, for example: `a = 1, b = a++`
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 		return stmts, st, preStmts, postStmts, nil
 	}
 
@@ -239,13 +236,27 @@ func transpileBinaryOperator(n *ast.BinaryOperator, p *program.Program, exprIsSt
 		err = fmt.Errorf("cannot atomic for right part. %v", err)
 		return nil, "unknown53", nil, nil, err
 	}
-	if types.IsPointer(leftType) && types.IsPointer(rightType) && operator == token.SUB {
-		p.AddImport("unsafe")
-		left, leftType = util.GetUintptrForSlice(left)
-		right, rightType = util.GetUintptrForSlice(right)
+
+	// right part of operators && and || is evaluated only by condition,
+	// so pre and post statements of right part are kept inside of closure,
+	// for example: `x && (a++, a > 1)`
+	var rightPre, rightPost []goast.Stmt
+	if operator == token.LAND || operator == token.LOR {
+		rightPre, rightPost = newPre, newPost
+	} else {
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 	}
 
-	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+	if isPointerOperands(p, leftType, rightType) {
+		switch operator {
+		case token.SUB:
+			expr, err = pointerDifference(p, left, right, n.Type)
+			return expr, n.Type, preStmts, postStmts, err
+		case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ:
+			return pointerComparison(p, left, operator, right),
+				"bool", preStmts, postStmts, nil
+		}
+	}
 
 	returnType := types.ResolveTypeForBinaryOperator(p, n.Operator, leftType, rightType)
 
@@ -265,6 +276,9 @@ func transpileBinaryOperator(n *ast.BinaryOperator, p *program.Program, exprIsSt
 			right = util.NewNil()
 			err = nil
 		}
+		if len(rightPre) > 0 || len(rightPost) > 0 {
+			right = util.NewAnonymousFunction(rightPre, rightPost, right, "bool")
+		}
 
 		resolvedLeftType, err := types.ResolveType(p, leftType)
 		if err != nil {
@@ -286,8 +300,11 @@ func transpileBinaryOperator(n *ast.BinaryOperator, p *program.Program, exprIsSt
 		if right == nil {
 			right = util.NewNil()
 		}
+		right = shiftCount(p, right, leftType)
 
-		return util.NewBinaryExpr(left, operator, right, "uint64", exprIsStmt),
+		return foldUnsigned(p,
+				util.NewBinaryExpr(left, operator, right, "uint64", exprIsStmt),
+				n.Type),
 			leftType, preStmts, postStmts, nil
 	}
 
@@ -398,6 +415,7 @@ func transpileBinaryOperator(n *ast.BinaryOperator, p *program.Program, exprIsSt
 			if p.AddMessage(p.GenerateWarningMessage(err, n)) && right == nil {
 				right = util.NewNil()
 			}
+			right = copyValue(p, right, leftType)
 
 		}
 	}
@@ -440,11 +458,48 @@ func transpileBinaryOperator(n *ast.BinaryOperator, p *program.Program, exprIsSt
 		return nil, "", nil, nil, err
 	}
 
-	return util.NewBinaryExpr(left, operator, right, resolvedLeftType, exprIsStmt),
+	return foldUnsigned(p,
+			util.NewBinaryExpr(left, operator, right, resolvedLeftType, exprIsStmt),
+			n.Type),
 		types.ResolveTypeForBinaryOperator(p, n.Operator, leftType, rightType),
 		preStmts, postStmts, nil
 }
 
+// foldUnsigned returns the constant expression of unsigned C type
+// calculated modulo 2^n, because Go reports the overflow of constants as
+// error. See types.FoldUnsignedConstant.
+func foldUnsigned(p *program.Program, expr goast.Expr, cType string) goast.Expr {
+	goType, err := types.ResolveType(p, cType)
+	if err != nil {
+		return expr
+	}
+	return types.FoldUnsignedConstant(expr, goType)
+}
+
+// shiftCount returns the count of shift masked by the width of shifted C
+// type. The count is taken modulo width by processors, so C programs on x86
+// shift `x << 33` of 32-bit value as `x << 1`, but the result of shift by
+// the count greater or equal than width is zero in Go:
+//
+//     x << n  ->  x << (n & 31)
+//
+// Constant counts are not changed, because shift by the constant count
+// greater than width is reported by compiler of C.
+func shiftCount(p *program.Program, count goast.Expr, cType string) goast.Expr {
+	if types.IsIntegerConstant(count) || !types.IsCInteger(p, cType) {
+		return count
+	}
+	size, err := types.SizeOf(p, cType)
+	if err != nil || size <= 0 || size > 8 {
+		return count
+	}
+	return &goast.ParenExpr{X: &goast.BinaryExpr{
+		X:  count,
+		Op: token.AND,
+		Y:  util.NewIntLit(size*8 - 1),
+	}}
+}
+
 func foundCallExpr(n ast.Node) *ast.CallExpr {
 	switch v := n.(type) {
 	case *ast.ImplicitCastExpr, *ast.CStyleCastExpr:
@@ -476,7 +531,15 @@ func getAllocationSizeNode(p *program.Program, node ast.Node) ast.Node {
 
 	functionName, _ := getNameOfFunctionFromCallExpr(p, expr)
 
-	if functionName == "malloc" {
+	// allocation by function of project, see option "-replace"
+	if _, ok := p.Replacements[functionName]; ok {
+		return nil
+	}
+
+	// memory of alloca() is freed at the end of function, but in Go the
+	// memory is freed by garbage collector
+	if functionName == "malloc" ||
+		functionName == "alloca" || functionName == "__builtin_alloca" {
 		// Is 1 always the body in this case? Might need to be more careful
 		// to find the correct node.
 		return expr.Children()[1]
@@ -505,25 +568,25 @@ func getAllocationSizeNode(p *program.Program, node ast.Node) ast.Node {
 func generateAlloc(p *program.Program, allocSize ast.Node, leftType string) (
 	right goast.Expr, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
 
-	allocSizeExpr, _, newPre, newPost, err := transpileToExpr(allocSize, p, false)
-
-	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
-
+	derefType, err := types.GetDereferenceType(leftType)
 	if err != nil {
 		return nil, preStmts, postStmts, err
 	}
 
-	derefType, err := types.GetDereferenceType(leftType)
+	toType, err := types.ResolveType(p, leftType)
 	if err != nil {
 		return nil, preStmts, postStmts, err
 	}
 
-	toType, err := types.ResolveType(p, leftType)
+	elementSize, err := types.SizeOf(p, derefType)
 	if err != nil {
 		return nil, preStmts, postStmts, err
 	}
 
-	elementSize, err := types.SizeOf(p, derefType)
+	length, newPre, newPost, err := allocLength(p, allocSize, elementSize)
+
+	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+
 	if err != nil {
 		return nil, preStmts, postStmts, err
 	}
@@ -531,7 +594,204 @@ func generateAlloc(p *program.Program, allocSize ast.Node, leftType string) (
 	right = util.NewCallExpr(
 		"make",
 		util.NewTypeIdent(toType),
-		util.NewBinaryExpr(allocSizeExpr, token.QUO, util.NewIntLit(elementSize), "int", false),
+		length,
 	)
 	return
 }
+
+// allocLength returns the amount of elements in the memory allocation of
+// allocSize bytes. Terms of allocation size with operator sizeof are divided
+// by size of element during transpiling, other terms are rounded up to the
+// whole element. Examples for element size 8:
+//
+//     n * sizeof(double)             -> n
+//     sizeof(double) * (n + 1)       -> n + 1
+//     2 * n * sizeof(double)         -> 2 * n
+//     n * sizeof(double) + 4         -> n + 1
+//     sizeof(struct header) + 16 * n -> 1 + (16*n + 7) / 8 (header of 8 bytes)
+//
+func allocLength(p *program.Program, allocSize ast.Node, elementSize int) (
+	length goast.Expr, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
+
+	if elementSize <= 0 {
+		return nil, nil, nil, fmt.Errorf("Not valid size of element: %d",
+			elementSize)
+	}
+
+	// transpile returns the Go expression of type int
+	transpile := func(node ast.Node) (goast.Expr, error) {
+		// integral casts of C are not needed, because all types are
+		// converted to int
+		for {
+			if impl, ok := node.(*ast.ImplicitCastExpr); ok &&
+				impl.Kind == "IntegralCast" && len(impl.Children()) == 1 {
+				node = impl.Children()[0]
+				continue
+			}
+			if paren, ok := node.(*ast.ParenExpr); ok && len(paren.Children()) == 1 {
+				node = paren.Children()[0]
+				continue
+			}
+			break
+		}
+		expr, exprType, newPre, newPost, err := transpileToExpr(node, p, false)
+		if err != nil {
+			return nil, err
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		return types.CastExpr(p, expr, exprType, "int")
+	}
+
+	// amounts of elements and the rest of allocation size in bytes
+	var (
+		elements  []goast.Expr
+		rest      []goast.Expr
+		restBytes int
+	)
+
+	terms := splitAllocSize(allocSize, "+")
+	sizes := make([]int, len(terms))
+	found := false
+	for i, term := range terms {
+		sizes[i] = allocSizeOfTerm(p, term, elementSize)
+		found = found || sizes[i] > 0
+	}
+	if !found {
+		// allocation size is calculated without sizeof of element type
+		terms = []ast.Node{allocSize}
+		sizes = []int{0}
+	}
+
+	for i, term := range terms {
+		if sizes[i] == 0 {
+			if lit, ok := unwrapAllocSize(term).(*ast.IntegerLiteral); ok {
+				if v, errConv := strconv.Atoi(lit.Value); errConv == nil {
+					restBytes += v
+					continue
+				}
+			}
+			expr, err := transpile(term)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			rest = append(rest, expr)
+			continue
+		}
+
+		// term: factor * ... * sizeof(type) * ...
+		var factors []goast.Expr
+		sizeofFound := false
+		for _, factor := range splitAllocSize(term, "*") {
+			if !sizeofFound && isSizeofNode(factor) {
+				sizeofFound = true
+				continue
+			}
+			expr, err := transpile(factor)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			factors = append(factors, expr)
+		}
+		if ratio := sizes[i] / elementSize; ratio != 1 || len(factors) == 0 {
+			factors = append(factors, util.NewIntLit(ratio))
+		}
+		for j := range factors {
+			if _, ok := factors[j].(*goast.BinaryExpr); ok && len(factors) > 1 {
+				factors[j] = &goast.ParenExpr{X: factors[j]}
+			}
+		}
+		elements = append(elements, sumAllocExprs(factors, token.MUL))
+	}
+
+	switch {
+	case elementSize == 1:
+		elements = append(elements, rest...)
+		if restBytes > 0 {
+			elements = append(elements, util.NewIntLit(restBytes))
+		}
+	case len(rest) > 0:
+		// rounding up of the rest to the whole element
+		if restBytes > 0 {
+			rest = append(rest, util.NewIntLit(restBytes))
+		}
+		elements = append(elements, &goast.BinaryExpr{
+			X: &goast.ParenExpr{X: &goast.BinaryExpr{
+				X:  sumAllocExprs(rest, token.ADD),
+				Op: token.ADD,
+				Y:  util.NewIntLit(elementSize - 1),
+			}},
+			Op: token.QUO,
+			Y:  util.NewIntLit(elementSize),
+		})
+	case restBytes > 0:
+		elements = append(elements,
+			util.NewIntLit((restBytes+elementSize-1)/elementSize))
+	}
+
+	if len(elements) == 0 {
+		return util.NewIntLit(0), preStmts, postStmts, nil
+	}
+	return sumAllocExprs(elements, token.ADD), preStmts, postStmts, nil
+}
+
+// sumAllocExprs joins the expressions by operator.
+func sumAllocExprs(exprs []goast.Expr, operator token.Token) goast.Expr {
+	result := exprs[0]
+	for _, expr := range exprs[1:] {
+		result = &goast.BinaryExpr{X: result, Op: operator, Y: expr}
+	}
+	return result
+}
+
+// unwrapAllocSize returns the node without implicit casts and parens.
+func unwrapAllocSize(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.ImplicitCastExpr, *ast.ParenExpr:
+			if len(n.Children()) == 1 && n.Children()[0] != nil {
+				node = n.Children()[0]
+				continue
+			}
+		}
+		return node
+	}
+}
+
+// splitAllocSize splits the allocation size by operator "+" or "*", for
+// example: "a * (b * c)" is splitted by "*" to "a", "b", "c".
+func splitAllocSize(node ast.Node, operator string) []ast.Node {
+	if b, ok := unwrapAllocSize(node).(*ast.BinaryOperator); ok &&
+		b.Operator == operator && len(b.Children()) == 2 {
+		return append(splitAllocSize(b.Children()[0], operator),
+			splitAllocSize(b.Children()[1], operator)...)
+	}
+	return []ast.Node{node}
+}
+
+// isSizeofNode returns true, if the node is operator sizeof.
+func isSizeofNode(node ast.Node) bool {
+	u, ok := unwrapAllocSize(node).(*ast.UnaryExprOrTypeTraitExpr)
+	return ok && u.Function == "sizeof"
+}
+
+// allocSizeOfTerm returns the size of type from the first operator sizeof
+// in the term of allocation size, if the size is multiple of element size.
+// Otherwise, 0 is returned.
+func allocSizeOfTerm(p *program.Program, term ast.Node, elementSize int) int {
+	for _, factor := range splitAllocSize(term, "*") {
+		if !isSizeofNode(factor) {
+			continue
+		}
+		lit, _, _, _, err := transpileUnaryExprOrTypeTraitExpr(
+			unwrapAllocSize(factor).(*ast.UnaryExprOrTypeTraitExpr), p)
+		if err != nil || lit == nil {
+			return 0
+		}
+		size, err := strconv.Atoi(lit.Value)
+		if err != nil || size < elementSize || size%elementSize != 0 {
+			return 0
+		}
+		return size
+	}
+	return 0
+}
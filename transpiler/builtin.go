@@ -0,0 +1,211 @@
+package transpiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// builtinBits is a map of GCC builtin functions for bits of integers, where
+// key is the name of builtin function and value is the function of package
+// math/bits with the C type of argument.
+var builtinBits = map[string][2]string{
+	"__builtin_clz":        {"LeadingZeros32", "unsigned int"},
+	"__builtin_clzl":       {"LeadingZeros64", "unsigned long long"},
+	"__builtin_clzll":      {"LeadingZeros64", "unsigned long long"},
+	"__builtin_ctz":        {"TrailingZeros32", "unsigned int"},
+	"__builtin_ctzl":       {"TrailingZeros64", "unsigned long long"},
+	"__builtin_ctzll":      {"TrailingZeros64", "unsigned long long"},
+	"__builtin_popcount":   {"OnesCount32", "unsigned int"},
+	"__builtin_popcountl":  {"OnesCount64", "unsigned long long"},
+	"__builtin_popcountll": {"OnesCount64", "unsigned long long"},
+}
+
+// transpileBuiltinCall transpiles calls of GCC builtin functions, which are
+// not functions of C library:
+//
+//     __builtin_expect(x, 0)        ->  x
+//     __builtin_clz(x)              ->  int32(bits.LeadingZeros32(x))
+//     __builtin_unreachable()       ->  panic("__builtin_unreachable")
+//     __builtin_memcpy(a, b, n)     ->  copy(a[:n/4], b[:n/4])
+//     __builtin_memset(a, 0, n)     ->  clear(a[:n/4])
+//
+// Calls of __builtin_memcpy() and __builtin_memset() are transpiled only as
+// statements for pointers to the same type. Macro offsetof() is expanded
+// into __builtin_offsetof(), which is not the call in clang AST.
+func transpileBuiltinCall(n *ast.CallExpr, p *program.Program, exprIsStmt bool) (
+	expr goast.Expr, exprType string, preStmts []goast.Stmt,
+	postStmts []goast.Stmt, ok bool, err error) {
+
+	name, err := getNameOfFunctionFromCallExpr(p, n)
+	if err != nil || !strings.HasPrefix(name, "__builtin_") {
+		return nil, "", nil, nil, false, nil
+	}
+	args := n.Children()[1:]
+
+	switch name {
+	case "__builtin_expect", "__builtin_expect_with_probability":
+		// hint for optimization of branches
+		if len(args) < 2 {
+			return
+		}
+		expr, exprType, preStmts, postStmts, err = transpileToExpr(args[0], p, false)
+		return expr, exprType, preStmts, postStmts, true, err
+
+	case "__builtin_unreachable", "__builtin_trap":
+		return util.NewCallExpr("panic", &goast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf("%q", name),
+		}), "void", nil, nil, true, nil
+
+	case "__builtin_memcpy", "__builtin_memmove",
+		"__builtin___memcpy_chk", "__builtin___memmove_chk":
+		if !exprIsStmt || len(args) < 3 {
+			return
+		}
+		dst, src := builtinArg(args[0]), builtinArg(args[1])
+		elementType, size, found := builtinElement(p, dst)
+		srcType, _, srcFound := builtinElement(p, src)
+		if !found || !srcFound || elementType != srcType {
+			return
+		}
+		var dstExpr, srcExpr, count goast.Expr
+		var newPre, newPost []goast.Stmt
+		for _, a := range []struct {
+			expr *goast.Expr
+			node ast.Node
+		}{{&dstExpr, dst}, {&srcExpr, src}, {&count, args[2]}} {
+			*a.expr, _, newPre, newPost, err = transpileToExpr(a.node, p, false)
+			if err != nil {
+				return nil, "", nil, nil, true, err
+			}
+			preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		}
+		count = builtinCount(count, size)
+		return util.NewCallExpr("copy",
+			&goast.SliceExpr{X: dstExpr, High: count},
+			&goast.SliceExpr{X: srcExpr, High: count},
+		), "int", preStmts, postStmts, true, nil
+
+	case "__builtin_memset", "__builtin___memset_chk":
+		if !exprIsStmt || len(args) < 3 {
+			return
+		}
+		dst := builtinArg(args[0])
+		elementType, size, found := builtinElement(p, dst)
+		if !found {
+			return
+		}
+		isBytes := elementType == "char" || elementType == "unsigned char"
+		if !isBytes {
+			// elements of other types are only cleared by function clear()
+			// of Go 1.21
+			value, isLiteral := builtinArg(args[1]).(*ast.IntegerLiteral)
+			if !isLiteral || value.Value != "0" || !p.IsGoVersion(21) {
+				return
+			}
+		}
+		var dstExpr, count goast.Expr
+		var newPre, newPost []goast.Stmt
+		dstExpr, _, preStmts, postStmts, err = transpileToExpr(dst, p, false)
+		if err != nil {
+			return nil, "", nil, nil, true, err
+		}
+		var countType string
+		count, countType, newPre, newPost, err = transpileToExpr(args[2], p, false)
+		if err != nil {
+			return nil, "", nil, nil, true, err
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		if !isBytes {
+			return util.NewCallExpr("clear",
+				&goast.SliceExpr{X: dstExpr, High: builtinCount(count, size)},
+			), "void", preStmts, postStmts, true, nil
+		}
+		var value goast.Expr
+		var valueType string
+		value, valueType, newPre, newPost, err = transpileToExpr(args[1], p, false)
+		if err != nil {
+			return nil, "", nil, nil, true, err
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		value, err = types.CastExpr(p, value, valueType, "unsigned char")
+		if err != nil {
+			return nil, "", nil, nil, true, err
+		}
+		count, err = types.CastExpr(p, count, countType, "unsigned int")
+		if err != nil {
+			return nil, "", nil, nil, true, err
+		}
+		p.AddImport("github.com/Konstantin8105/c4go/noarch")
+		return util.NewCallExpr("noarch.Memset", dstExpr, value, count),
+			"char *", preStmts, postStmts, true, nil
+	}
+
+	f, isBits := builtinBits[name]
+	if !isBits || len(args) != 1 {
+		return
+	}
+	expr, exprType, preStmts, postStmts, err = transpileToExpr(args[0], p, false)
+	if err != nil {
+		return nil, "", nil, nil, true, err
+	}
+	expr, err = types.CastExpr(p, expr, exprType, f[1])
+	if err != nil {
+		return nil, "", nil, nil, true, err
+	}
+	p.AddImport("math/bits")
+	return util.NewCallExpr("int32", util.NewCallExpr("bits."+f[0], expr)),
+		"int", preStmts, postStmts, true, nil
+}
+
+// builtinArg returns the argument of builtin function without implicit casts
+// to types of parameters, like `void *` or `int`.
+func builtinArg(node ast.Node) ast.Node {
+	for {
+		cast, ok := node.(*ast.ImplicitCastExpr)
+		if !ok || len(cast.Children()) != 1 ||
+			(cast.Kind != "BitCast" && cast.Kind != "NoOp" &&
+				cast.Kind != "IntegralCast") {
+			return node
+		}
+		node = cast.Children()[0]
+	}
+}
+
+// builtinElement returns the C type and the size of elements of pointer.
+// Pointers to `void` are not acceptable.
+func builtinElement(p *program.Program, node ast.Node) (
+	elementType string, size int, ok bool) {
+	t := strings.Join(strings.Fields(types.CleanCType(nodeType(node))), " ")
+	if !strings.HasSuffix(t, "*") {
+		return
+	}
+	elementType = strings.TrimSpace(strings.TrimSuffix(t, "*"))
+	if elementType == "void" {
+		return
+	}
+	size, err := types.SizeOf(p, elementType)
+	if err != nil || size <= 0 {
+		return "", 0, false
+	}
+	return elementType, size, true
+}
+
+// builtinCount returns the amount of elements in the amount of bytes.
+func builtinCount(bytes goast.Expr, size int) goast.Expr {
+	if size == 1 {
+		return bytes
+	}
+	return &goast.BinaryExpr{
+		X:  bytes,
+		Op: token.QUO,
+		Y:  util.NewIntLit(size),
+	}
+}
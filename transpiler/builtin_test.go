@@ -0,0 +1,92 @@
+package transpiler
+
+import (
+	"bytes"
+	"go/printer"
+	"go/token"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestBuiltinCall(t *testing.T) {
+	// unsigned int u; int a[4], b[4]; char s[8];
+	// __builtin_clz(u);
+	// __builtin_expect(u, 0);
+	// __builtin_unreachable();
+	// __builtin_memcpy(a, b, sizeof(a));
+	// __builtin_memset(s, 'x', 8);
+	// __builtin_memset(a, 0, 16);
+	tree := parseTree(t, `
+CompoundStmt 0x1 <file.c:1:1, line:8:1>
+|-CallExpr 0x2 <line:2:3, col:18> 'int'
+| |-ImplicitCastExpr 0x3 <col:3> 'int (*)(unsigned int)' <BuiltinFnToFnPtr>
+| | `+"`"+`-DeclRefExpr 0x4 <col:3> '<builtin fn type>' Function 0x40 '__builtin_clz' 'int (unsigned int)'
+| `+"`"+`-ImplicitCastExpr 0x5 <col:17> 'unsigned int' <LValueToRValue>
+|   `+"`"+`-DeclRefExpr 0x6 <col:17> 'unsigned int' lvalue Var 0x60 'u' 'unsigned int'
+|-CallExpr 0x7 <line:3:3, col:24> 'long'
+| |-ImplicitCastExpr 0x8 <col:3> 'long (*)(long, long)' <BuiltinFnToFnPtr>
+| | `+"`"+`-DeclRefExpr 0x9 <col:3> '<builtin fn type>' Function 0x41 '__builtin_expect' 'long (long, long)'
+| |-ImplicitCastExpr 0x10 <col:20> 'long' <IntegralCast>
+| | `+"`"+`-ImplicitCastExpr 0x11 <col:20> 'unsigned int' <LValueToRValue>
+| |   `+"`"+`-DeclRefExpr 0x12 <col:20> 'unsigned int' lvalue Var 0x60 'u' 'unsigned int'
+| `+"`"+`-ImplicitCastExpr 0x13 <col:23> 'long' <IntegralCast>
+|   `+"`"+`-IntegerLiteral 0x14 <col:23> 'int' 0
+|-CallExpr 0x15 <line:4:3, col:25> 'void'
+| `+"`"+`-ImplicitCastExpr 0x16 <col:3> 'void (*)(void) __attribute__((noreturn))' <BuiltinFnToFnPtr>
+|   `+"`"+`-DeclRefExpr 0x17 <col:3> '<builtin fn type>' Function 0x42 '__builtin_unreachable' 'void (void) __attribute__((noreturn))'
+|-CallExpr 0x18 <line:5:3, col:35> 'void *'
+| |-ImplicitCastExpr 0x19 <col:3> 'void *(*)(void *, const void *, unsigned long)' <BuiltinFnToFnPtr>
+| | `+"`"+`-DeclRefExpr 0x20 <col:3> '<builtin fn type>' Function 0x43 '__builtin_memcpy' 'void *(void *, const void *, unsigned long)'
+| |-ImplicitCastExpr 0x21 <col:20> 'void *' <BitCast>
+| | `+"`"+`-ImplicitCastExpr 0x22 <col:20> 'int *' <ArrayToPointerDecay>
+| |   `+"`"+`-DeclRefExpr 0x23 <col:20> 'int [4]' lvalue Var 0x61 'a' 'int [4]'
+| |-ImplicitCastExpr 0x24 <col:23> 'const void *' <BitCast>
+| | `+"`"+`-ImplicitCastExpr 0x25 <col:23> 'int *' <ArrayToPointerDecay>
+| |   `+"`"+`-DeclRefExpr 0x26 <col:23> 'int [4]' lvalue Var 0x62 'b' 'int [4]'
+| `+"`"+`-ImplicitCastExpr 0x27 <col:26> 'unsigned long' <IntegralCast>
+|   `+"`"+`-IntegerLiteral 0x28 <col:26> 'int' 16
+|-CallExpr 0x29 <line:6:3, col:30> 'void *'
+| |-ImplicitCastExpr 0x30 <col:3> 'void *(*)(void *, int, unsigned long)' <BuiltinFnToFnPtr>
+| | `+"`"+`-DeclRefExpr 0x31 <col:3> '<builtin fn type>' Function 0x44 '__builtin_memset' 'void *(void *, int, unsigned long)'
+| |-ImplicitCastExpr 0x32 <col:20> 'void *' <BitCast>
+| | `+"`"+`-ImplicitCastExpr 0x33 <col:20> 'char *' <ArrayToPointerDecay>
+| |   `+"`"+`-DeclRefExpr 0x34 <col:20> 'char [8]' lvalue Var 0x63 's' 'char [8]'
+| |-CharacterLiteral 0x35 <col:23> 'int' 120
+| `+"`"+`-ImplicitCastExpr 0x36 <col:28> 'unsigned long' <IntegralCast>
+|   `+"`"+`-IntegerLiteral 0x37 <col:28> 'int' 8
+`+"`"+`-CallExpr 0x38 <line:7:3, col:28> 'void *'
+  |-ImplicitCastExpr 0x39 <col:3> 'void *(*)(void *, int, unsigned long)' <BuiltinFnToFnPtr>
+  | `+"`"+`-DeclRefExpr 0x40 <col:3> '<builtin fn type>' Function 0x44 '__builtin_memset' 'void *(void *, int, unsigned long)'
+  |-ImplicitCastExpr 0x41 <col:20> 'void *' <BitCast>
+  | `+"`"+`-ImplicitCastExpr 0x42 <col:20> 'int *' <ArrayToPointerDecay>
+  |   `+"`"+`-DeclRefExpr 0x43 <col:20> 'int [4]' lvalue Var 0x61 'a' 'int [4]'
+  |-IntegerLiteral 0x44 <col:23> 'int' 0
+  `+"`"+`-ImplicitCastExpr 0x45 <col:26> 'unsigned long' <IntegralCast>
+    `+"`"+`-IntegerLiteral 0x46 <col:26> 'int' 16
+`)
+
+	p := program.NewProgram()
+	p.GoVersion = 21
+	for i, expected := range []string{
+		"int32(bits.LeadingZeros32(u))",
+		"int32(u)",
+		`panic("__builtin_unreachable")`,
+		"copy(a[:16/4], b[:16/4])",
+		"noarch.Memset(s, uint8('x'), 8)",
+		"clear(a[:16/4])",
+	} {
+		expr, _, _, _, err := transpileToExpr(tree.Children()[i], p, true)
+		if err != nil {
+			t.Fatalf("Expression %d: %v", i, err)
+		}
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != expected {
+			t.Errorf("Expression %d: not expected Go code:\n%s\nExpected:\n%s",
+				i, buf.String(), expected)
+		}
+	}
+}
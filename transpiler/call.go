@@ -5,6 +5,7 @@ package transpiler
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -78,6 +79,14 @@ func getName(p *program.Program, firstChild ast.Node) (name string, err error) {
 	case *ast.CStyleCastExpr:
 		return getName(p, fc.Children()[0])
 
+	case *ast.GenericSelectionExpr:
+		var selected ast.Node
+		selected, err = selectedAssociation(fc)
+		if err != nil {
+			return
+		}
+		return getName(p, selected)
+
 	case *ast.ArraySubscriptExpr:
 		var expr goast.Expr
 		expr, _, _, _, err = transpileArraySubscriptExpr(fc, p)
@@ -179,8 +188,12 @@ func simplificationCallExprPrintf(call *ast.CallExpr, p *program.Program) (
 	   .  }
 	   }
 	*/
+	// string is printed until the first NUL character
+	if i := strings.IndexByte(printfText, 0); i >= 0 {
+		printfText = printfText[:i]
+	}
 	p.AddImport("fmt")
-	printfText = strconv.Quote(printfText)
+	printfText = strconv.Quote(ConvertToGoFlagFormat(printfText))
 	return &goast.CallExpr{
 		Fun: &goast.SelectorExpr{
 			X:   goast.NewIdent("fmt"),
@@ -203,6 +216,55 @@ func simplificationCallExprPrintf(call *ast.CallExpr, p *program.Program) (
 // It returns three arguments; the Go AST expression, the C type (that is
 // returned by the function) and any error. If there is an error returned you
 // can assume the first two arguments will not contain any useful information.
+// typeGenericFunctions is the list of type-generic builtin functions of
+// classification macros of math.h, like isnan() or fpclassify(). The
+// classified value is the last argument.
+var typeGenericFunctions = map[string]bool{
+	"__builtin_isnan":      true,
+	"__builtin_isinf":      true,
+	"__builtin_isinf_sign": true,
+	"__builtin_isfinite":   true,
+	"__builtin_isnormal":   true,
+	"__builtin_fpclassify": true,
+	"__builtin_signbit":    true,
+}
+
+// typeGenericFunctionName returns the name of function with suffix "f" for
+// the call of type-generic function with argument of type "float", because
+// the classification of float value is not the same after conversion to
+// double. Example of C code:
+//
+//     float f;
+//     isnormal(f);
+//
+// Clang AST:
+//
+//     CallExpr 'int'
+//     |-ImplicitCastExpr 'int (*)(...)' <BuiltinFnToFnPtr>
+//     | `-DeclRefExpr '<builtin fn type>' Function '__builtin_isnormal' 'int (...)'
+//     `-ImplicitCastExpr 'float' <LValueToRValue>
+//       `-DeclRefExpr 'float' lvalue Var 'f' 'float'
+//
+// Result:
+//
+//     noarch.Isnormalf(f)
+//
+func typeGenericFunctionName(n *ast.CallExpr, name string) string {
+	if !typeGenericFunctions[name] || len(n.Children()) < 2 {
+		return name
+	}
+	arg := reflect.ValueOf(n.Children()[len(n.Children())-1])
+	if arg.Kind() != reflect.Ptr || arg.IsNil() {
+		return name
+	}
+	t := arg.Elem().FieldByName("Type")
+	if !t.IsValid() || t.Kind() != reflect.String ||
+		strings.TrimSpace(types.CleanCType(t.String())) != "float" {
+		return name
+	}
+	return name + "f"
+}
+
 func transpileCallExpr(n *ast.CallExpr, p *program.Program) (
 	expr *goast.CallExpr, resultType string,
 	preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
@@ -225,29 +287,43 @@ func transpileCallExpr(n *ast.CallExpr, p *program.Program) (
 		}
 	}()
 
-	if functionName == "__builtin_va_start" ||
-		functionName == "__builtin_va_end" {
-		// ignore function __builtin_va_start, __builtin_va_end
-		// see "Variadic functions"
-		return nil, "", nil, nil, nil
+	// va_start, va_end, va_copy from stdarg.h
+	switch functionName {
+	case "__builtin_va_start", "__builtin_va_end", "__builtin_va_copy":
+		expr, preStmts, postStmts, err = transpileVaBuiltin(n, p, functionName)
+		return expr, "void", preStmts, postStmts, err
 	}
 
+	// type-generic functions of classification of floating-point values
+	functionName = typeGenericFunctionName(n, functionName)
+
+	// functions replaced by option "-replace" are not transpiled in
+	// special way
+	replacement, isReplaced := replacementOfCall(p, n, functionName)
+
 	// function "calloc" from stdlib.h
-	if p.IncludeHeaderIsExists("stdlib.h") {
+	if p.IncludeHeaderIsExists("stdlib.h") && !isReplaced {
 		if functionName == "calloc" && len(n.Children()) == 3 {
 			return transpileCallExprCalloc(n, p)
 		}
 	}
 
 	// function "qsort" from stdlib.h
-	if p.IncludeHeaderIsExists("stdlib.h") {
+	if p.IncludeHeaderIsExists("stdlib.h") && !isReplaced {
 		if functionName == "qsort" && len(n.Children()) == 5 {
 			return transpileCallExprQsort(n, p)
 		}
 	}
 
+	// functions with arguments of function type from pthread.h, threads.h,
+	// stdlib.h
+	if f, ok := threadFunctions[functionName]; ok && p.IncludeHeaderIsExists(f.Header) &&
+		!isReplaced {
+		return transpileCallExprThread(n, p, functionName)
+	}
+
 	// function "printf" from stdio.h simplification
-	if p.IncludeHeaderIsExists("stdio.h") {
+	if p.IncludeHeaderIsExists("stdio.h") && !isReplaced {
 		if functionName == "printf" && len(n.Children()) == 2 {
 			if e, ok := simplificationCallExprPrintf(n, p); ok {
 				return e, "int", nil, nil, nil
@@ -315,6 +391,10 @@ func transpileCallExpr(n *ast.CallExpr, p *program.Program) (
 		}
 	}
 
+	if isReplaced {
+		functionDef = replacement
+	}
+
 	if functionDef.Substitution != "" {
 		parts := strings.Split(functionDef.Substitution, ".")
 		importName := strings.Join(parts[:len(parts)-1], ".")
@@ -431,17 +511,7 @@ func transpileCallExpr(n *ast.CallExpr, p *program.Program) (
 			}
 
 			if len(functionDef.ArgumentTypes) > i {
-				if !types.IsPointer(functionDef.ArgumentTypes[i]) {
-					if strings.HasPrefix(functionDef.ArgumentTypes[i], "union ") {
-						a = &goast.CallExpr{
-							Fun: &goast.SelectorExpr{
-								X:   a,
-								Sel: goast.NewIdent("copy"),
-							},
-							Lparen: 1,
-						}
-					}
-				}
+				a = copyValue(p, a, functionDef.ArgumentTypes[i])
 			}
 
 			realArgs = append(realArgs, a)
@@ -570,6 +640,28 @@ func transpileCallExprQsort(n *ast.CallExpr, p *program.Program) (
 			fmt.Errorf("golang ast for variable name have type %T, expect ast.Ident", element[3])
 	}
 
+	if p.IsGoVersion(21) {
+		// package "slices" is added in Go 1.21:
+		//
+		//     slices.SortStableFunc(values, func(a, b int) int {
+		//         return compare([]int{a}, []int{b})
+		//     })
+		//
+		p.AddImport("slices")
+		src := fmt.Sprintf(`package main
+			var %s func(a,b interface{})int
+			var temp = func(a, b %s) int {
+				return %s([]%s{a}, []%s{b})
+			}`, compareFunc, t, compareFunc, t, t)
+		f, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		convertExpr := f.Decls[1].(*goast.GenDecl).Specs[0].(*goast.ValueSpec).Values[0]
+		return util.NewCallExpr("slices.SortStableFunc", element[0], convertExpr),
+			"", preStmts, postStmts, nil
+	}
+
 	p.AddImport("sort")
 	src := fmt.Sprintf(`package main
 		var %s func(a,b interface{})int
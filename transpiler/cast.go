@@ -57,6 +57,12 @@ func transpileImplicitCastExpr(n *ast.ImplicitCastExpr, p *program.Program, expr
 	}
 
 	if len(n.Type) != 0 && len(n.Type2) != 0 && n.Type != n.Type2 && cast {
+		// Go cannot convert numbers to typedef of boolean type
+		if t, _ := types.ResolveType(p, n.Type2); t == "bool" {
+			expr, err = types.CastExpr(p, expr, exprType, n.Type)
+			exprType = n.Type
+			return
+		}
 		var tt string
 		tt, err = types.ResolveType(p, n.Type)
 		expr = &goast.CallExpr{
@@ -92,7 +98,7 @@ func transpileImplicitCastExpr(n *ast.ImplicitCastExpr, p *program.Program, expr
 	//   `-DeclRefExpr 0x3662cf0 <col:17> 'struct s_inp':'struct s_inp' lvalue Var 0x3662c50 's' 'struct s_inp':'struct s_inp'
 	if types.IsCPointer(n.Type) {
 		if len(n.Children()) > 0 {
-			if memb, ok := n.Children()[0].(*ast.MemberExpr); ok && types.IsCArray(memb.Type) {
+			if isStructMemberArray(n.Children()[0]) {
 				expr = &goast.SliceExpr{
 					X:      expr,
 					Lbrack: 1,
@@ -105,6 +111,32 @@ func transpileImplicitCastExpr(n *ast.ImplicitCastExpr, p *program.Program, expr
 	return
 }
 
+// isStructMemberArray returns true for struct member array and for array
+// element of multidimensional struct member array. Both of them are Go arrays.
+// Example:
+// MemberExpr 0x35 <col:5, col:7> 'int [2][3]' lvalue .m 0x11
+// ArraySubscriptExpr 0x33 <col:5, col:10> 'int [3]' lvalue
+// |-ImplicitCastExpr 0x34 <col:5, col:7> 'int (*)[3]' <ArrayToPointerDecay>
+// | `-MemberExpr 0x35 <col:5, col:7> 'int [2][3]' lvalue .m 0x11
+// `-IntegerLiteral 0x37 <col:9> 'int' 1
+func isStructMemberArray(node ast.Node) bool {
+	switch v := node.(type) {
+	case *ast.MemberExpr:
+		// member of struct returned by function is not addressable
+		// and not need to convert
+		return types.IsCArray(v.Type) && v.IsLvalue
+	case *ast.ArraySubscriptExpr:
+		if !types.IsCArray(v.Type) || len(v.Children()) == 0 {
+			return false
+		}
+		if impl, ok := v.Children()[0].(*ast.ImplicitCastExpr); ok &&
+			len(impl.Children()) > 0 {
+			return isStructMemberArray(impl.Children()[0])
+		}
+	}
+	return false
+}
+
 func transpileCStyleCastExpr(n *ast.CStyleCastExpr, p *program.Program, exprIsStmt bool) (
 	expr goast.Expr,
 	exprType string,
@@ -120,6 +152,12 @@ func transpileCStyleCastExpr(n *ast.CStyleCastExpr, p *program.Program, exprIsSt
 	n.Type = types.GenerateCorrectType(n.Type)
 	n.Type2 = types.GenerateCorrectType(n.Type2)
 
+	// nested function of GNU C is the block cast to pointer of function
+	if block, ok := blockOfCast(n); ok {
+		expr, _, err = transpileBlockExpr(block, p)
+		return expr, n.Type, nil, nil, err
+	}
+
 	// Char overflow
 	// example for byte(-1)
 	// CStyleCastExpr 0x365f628 <col:12, col:23> 'char' <IntegralCast>
@@ -156,6 +194,18 @@ func transpileCStyleCastExpr(n *ast.CStyleCastExpr, p *program.Program, exprIsSt
 		return
 	}
 
+	// Integer value cannot be used as pointer in Go, so it is nil.
+	// Example of C code:
+	//   #define MAP_FAILED ((void *) -1)
+	//   (iconv_t) -1, where iconv_t is typedef of void*
+	if n.Kind == ast.CStyleCastExprIntegralToPointer &&
+		(types.CleanCType(n.Type) == "void *" ||
+			types.CleanCType(n.Type2) == "void *") {
+		expr = goast.NewIdent("nil")
+		exprType = types.NullPointer
+		return
+	}
+
 	expr, exprType, preStmts, postStmts, err = transpileToExpr(
 		n.Children()[0], p, exprIsStmt)
 	if err != nil {
@@ -0,0 +1,87 @@
+// This file contains functions for transpiling the functions with attributes
+// constructor and destructor of GNU C. Constructors are called by function
+// init() of Go and destructors are registered in function init() as
+// functions of atexit():
+//
+//     __attribute__((constructor)) void setup(void);   ->  setup()
+//     __attribute__((destructor)) void cleanup(void);  ->  noarch.Atexit(cleanup)
+//
+
+package transpiler
+
+import (
+	"sort"
+
+	goast "go/ast"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// attributedFunction is the function with attribute constructor or
+// destructor.
+type attributedFunction struct {
+	name     string
+	priority int
+}
+
+// findAttributedFunctions returns the defined functions with attributes
+// constructor and destructor sorted by priority.
+func findAttributedFunctions(root ast.Node) (
+	constructors, destructors []attributedFunction) {
+
+	// attributes of function may be on any declaration of function
+	constructorPriority := map[string]int{}
+	destructorPriority := map[string]int{}
+	var defined []string
+	for _, node := range root.Children() {
+		f, ok := node.(*ast.FunctionDecl)
+		if !ok {
+			continue
+		}
+		for _, c := range f.Children() {
+			switch attr := c.(type) {
+			case *ast.ConstructorAttr:
+				constructorPriority[f.Name] = attr.Priority
+			case *ast.DestructorAttr:
+				destructorPriority[f.Name] = attr.Priority
+			}
+		}
+		if getFunctionBody(f) != nil {
+			defined = append(defined, f.Name)
+		}
+	}
+
+	for _, name := range defined {
+		if priority, ok := constructorPriority[name]; ok {
+			constructors = append(constructors, attributedFunction{name, priority})
+		}
+		if priority, ok := destructorPriority[name]; ok {
+			destructors = append(destructors, attributedFunction{name, priority})
+		}
+	}
+	for _, functions := range [][]attributedFunction{constructors, destructors} {
+		sort.SliceStable(functions, func(i, j int) bool {
+			return functions[i].priority < functions[j].priority
+		})
+	}
+	return
+}
+
+// appendAttributedFunctions adds the calls of constructors and the
+// registration of destructors in the startup statements. Destructors are
+// registered in order of priority, so the destructors with smaller priority
+// are called later.
+func appendAttributedFunctions(p *program.Program,
+	constructors, destructors []attributedFunction) {
+	for _, f := range destructors {
+		p.AppendStartupExpr(util.NewCallExpr(
+			p.ImportType("github.com/Konstantin8105/c4go/noarch.Atexit"),
+			goast.NewIdent(util.ConvertFunctionNameFromCtoGo(f.name))))
+	}
+	for _, f := range constructors {
+		p.AppendStartupExpr(util.NewCallExpr(
+			util.ConvertFunctionNameFromCtoGo(f.name)))
+	}
+}
@@ -0,0 +1,167 @@
+// This file contains functions for the value semantics of structs and
+// unions. The structs of C are structs of Go and the arrays inside structs
+// are arrays of Go, so the assignment of struct copies all values. But the
+// union is the pointer to memory, see transpileUnion, so the unions and the
+// structs with unions are copied by the generated method copy():
+//
+//     u1 = u2      ->  u1 = u2.copy()
+//     s1 = s2      ->  s1 = s2.copy()    // struct with field of union
+//     f(s)         ->  f(s.copy())
+//     return s     ->  return s.copy()
+//
+
+package transpiler
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"text/template"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+// needCopy returns true, if the value of C type shares the memory of union
+// after assignment in Go.
+func needCopy(p *program.Program, cType string) bool {
+	cType = strings.TrimSpace(types.CleanCType(cType))
+	if elementType, size := types.GetArrayTypeAndSize(cType); size != -1 {
+		return needCopy(p, elementType)
+	}
+	if cType == "" || strings.ContainsAny(cType, "*[(") {
+		return false
+	}
+	if p.IsUnion(cType) {
+		return true
+	}
+	if t, ok := p.GetBaseTypeOfTypedef(cType); ok && t != cType {
+		return needCopy(p, t)
+	}
+	s := p.GetStruct(cType)
+	if s == nil {
+		s = p.GetStruct("struct " + cType)
+	}
+	return structNeedCopy(p, s)
+}
+
+// structNeedCopy returns true, if the struct has fields of union.
+func structNeedCopy(p *program.Program, s *program.Struct) bool {
+	if s == nil {
+		return false
+	}
+	if s.Type == program.UnionType {
+		return true
+	}
+	for _, field := range s.Fields {
+		switch f := field.(type) {
+		case string:
+			if needCopy(p, f) {
+				return true
+			}
+		case *program.Struct:
+			if structNeedCopy(p, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyValue returns the copy of value of C type, if the value shares the
+// memory of union. The results of function calls are not copied, because
+// they are copied by return statement.
+func copyValue(p *program.Program, expr goast.Expr, cType string) goast.Expr {
+	if !isAddressable(expr) || !needCopy(p, cType) {
+		return expr
+	}
+	return &goast.CallExpr{
+		Fun: &goast.SelectorExpr{
+			X:   expr,
+			Sel: goast.NewIdent("copy"),
+		},
+	}
+}
+
+// isAddressable returns true, if the method with pointer receiver may be
+// called for the expression.
+func isAddressable(expr goast.Expr) bool {
+	switch v := expr.(type) {
+	case *goast.ParenExpr:
+		return isAddressable(v.X)
+	case *goast.Ident:
+		return v.Name != "nil"
+	case *goast.SelectorExpr, *goast.IndexExpr, *goast.StarExpr:
+		return true
+	}
+	return false
+}
+
+// copyField is the field of struct, which is copied by method copy(). Field
+// Dims is the amount of dimensions of array field.
+type copyField struct {
+	Name string
+	Dims int
+}
+
+// transpileStructCopy returns the method copy() of struct with fields of
+// unions.
+func transpileStructCopy(name string, fields []copyField) (
+	_ []goast.Decl, err error) {
+
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot transpileStructCopy : err = %v", err)
+		}
+	}()
+
+	type field struct {
+		Loops []string
+		Value string
+	}
+
+	src := `package main
+
+func (structVar *{{ .Name }}) copy() {{ .Name }} {
+	newStruct := *structVar
+{{ range .Fields }}{{ range .Loops }}	{{ . }} {
+{{ end }}	newStruct.{{ .Value }} = structVar.{{ .Value }}.copy()
+{{ range .Loops }}	}
+{{ end }}{{ end }}	return newStruct
+}
+`
+	var data struct {
+		Name   string
+		Fields []field
+	}
+	data.Name = name
+	for _, f := range fields {
+		value := f.Name
+		var loops []string
+		for i := 0; i < f.Dims; i++ {
+			index := fmt.Sprintf("i%d", i)
+			loops = append(loops, fmt.Sprintf("for %s := range newStruct.%s",
+				index, value))
+			value += "[" + index + "]"
+		}
+		data.Fields = append(data.Fields, field{Loops: loops, Value: value})
+	}
+
+	tmpl := template.Must(template.New("").Parse(src))
+	var source bytes.Buffer
+	if err = tmpl.Execute(&source, data); err != nil {
+		return
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), "", source.String(), 0)
+	if err != nil {
+		err = fmt.Errorf("cannot parse source \"%s\" : %v",
+			source.String(), err)
+		return
+	}
+
+	return f.Decls, nil
+}
@@ -81,11 +81,11 @@ func transpileFieldDecl(p *program.Program, n *ast.FieldDecl) (
 		name += "_"
 	}
 
-	arrayType, arraySize := types.GetArrayTypeAndSize(n.Type)
-	if arraySize != -1 {
-		fieldType, err = types.ResolveType(p, arrayType)
+	// All dimensions of array are Go arrays, so the struct is copied
+	// by value together with the arrays inside as in C.
+	if _, arraySize := types.GetArrayTypeAndSize(n.Type); arraySize != -1 {
+		fieldType, err = resolveGoArrayType(p, n.Type)
 		p.AddMessage(p.GenerateWarningMessage(err, n))
-		fieldType = fmt.Sprintf("[%d]%s", arraySize, fieldType)
 	}
 
 	return &goast.Field{
@@ -94,6 +94,36 @@ func transpileFieldDecl(p *program.Program, n *ast.FieldDecl) (
 	}, nil
 }
 
+// resolveGoArrayType returns the Go array type of C array, all dimensions
+// of array are Go arrays. Example: int [2][3] -> [2][3]int
+func resolveGoArrayType(p *program.Program, cType string) (string, error) {
+	var sizes string
+	arrayType, arraySize := types.GetArrayTypeAndSize(cType)
+	for arraySize != -1 {
+		sizes += fmt.Sprintf("[%d]", arraySize)
+		arrayType, arraySize = types.GetArrayTypeAndSize(arrayType)
+	}
+	goType, err := types.ResolveType(p, arrayType)
+	return sizes + goType, err
+}
+
+// isRecordType returns true, if the C type is the named struct or union,
+// but not pointer or array.
+func isRecordType(cType string) bool {
+	return util.GetRegex(`^(struct|union) \w+$`).MatchString(cType)
+}
+
+// arrayDimensions returns the amount of dimensions of C array type, for
+// example: 3 for type "int [2][3][4]".
+func arrayDimensions(cType string) (dims int) {
+	cType, size := types.GetArrayTypeAndSize(cType)
+	for size != -1 {
+		dims++
+		cType, size = types.GetArrayTypeAndSize(cType)
+	}
+	return
+}
+
 func transpileRecordDecl(p *program.Program, n *ast.RecordDecl) (
 	decls []goast.Decl, err error) {
 
@@ -152,6 +182,7 @@ func transpileRecordDecl(p *program.Program, n *ast.RecordDecl) (
 	}
 
 	var fields []*goast.Field
+	var copies []copyField
 
 	// repair name for anonymous RecordDecl
 	for pos := range n.Children() {
@@ -208,6 +239,12 @@ func transpileRecordDecl(p *program.Program, n *ast.RecordDecl) (
 					f.Names[0].Name += strconv.Itoa(pos)
 				}
 				fields = append(fields, f)
+				if needCopy(p, field.Type) {
+					copies = append(copies, copyField{
+						Name: f.Names[0].Name,
+						Dims: arrayDimensions(field.Type),
+					})
+				}
 			}
 
 		case *ast.IndirectFieldDecl:
@@ -335,6 +372,12 @@ func transpileRecordDecl(p *program.Program, n *ast.RecordDecl) (
 		}
 
 	case program.StructType:
+		if pattern, ok := patternOfStruct(p, name); ok {
+			fields, err = patternFields(p, pattern)
+			if err != nil {
+				return
+			}
+		}
 		d = append(d, &goast.GenDecl{
 			Tok: token.TYPE,
 			Specs: []goast.Spec{
@@ -348,6 +391,14 @@ func transpileRecordDecl(p *program.Program, n *ast.RecordDecl) (
 				},
 			},
 		})
+		if len(copies) > 0 {
+			var copyDecls []goast.Decl
+			copyDecls, err = transpileStructCopy(name, copies)
+			if err != nil {
+				return
+			}
+			d = append(d, copyDecls...)
+		}
 
 	default:
 		err = fmt.Errorf("Undefine type of struct : %v", s.Type)
@@ -707,30 +758,6 @@ func transpileTypedefDecl(p *program.Program, n *ast.TypedefDecl) (
 		return
 	}
 
-	if name == "div_t" || name == "ldiv_t" || name == "lldiv_t" {
-		intType := "int"
-		if name == "ldiv_t" {
-			intType = "long int"
-		} else if name == "lldiv_t" {
-			intType = "long long int"
-		}
-
-		// I don't know to extract the correct fields from the typedef to create
-		// the internal definition. This is used in the noarch package
-		// (stdio.go).
-		//
-		// The name of the struct is not prefixed with "struct " because it is a
-		// typedef.
-		p.Structs[name] = &program.Struct{
-			Name: name,
-			Type: program.StructType,
-			Fields: map[string]interface{}{
-				"quot": intType,
-				"rem":  intType,
-			},
-		}
-	}
-
 	err = nil
 	if resolvedType == "" {
 		resolvedType = "interface{}"
@@ -739,6 +766,7 @@ func transpileTypedefDecl(p *program.Program, n *ast.TypedefDecl) (
 	if v, ok := p.Structs["struct "+resolvedType]; ok {
 		// Registration "typedef struct" with non-empty name of struct
 		p.Structs["struct "+name] = v
+		p.TypedefType[n.Name] = n.Type
 	} else if v, ok := p.EnumConstantToEnum["enum "+resolvedType]; ok {
 		// Registration "enum constants"
 		p.EnumConstantToEnum["enum "+resolvedType] = v
@@ -747,19 +775,71 @@ func transpileTypedefDecl(p *program.Program, n *ast.TypedefDecl) (
 		p.TypedefType[n.Name] = n.Type
 	}
 
+	spec := &goast.TypeSpec{
+		Name: util.NewIdent(name),
+		Type: util.NewTypeIdent(resolvedType),
+	}
+	// The typedef of struct is the alias of struct, so the pointers to
+	// typedef and to struct are the same slices, like in the linked list:
+	//
+	//     typedef struct node Node;
+	//     struct node { int value; Node *next; };
+	//
+	if isRecordType(n.Type) {
+		spec.Assign = 1
+	}
 	decls = append(decls, &goast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []goast.Spec{
-			&goast.TypeSpec{
-				Name: util.NewIdent(name),
-				Type: util.NewTypeIdent(resolvedType),
-			},
-		},
+		Tok:   token.TYPE,
+		Specs: []goast.Spec{spec},
 	})
 
 	return
 }
 
+// transpileStaticLocalVarDecl transpiles the static local variable as the
+// package-level variable with unique name, so the value of variable is kept
+// between calls of function. The declaration is placed after the function,
+// see Program.StaticDecls.
+// Example of C code:
+//
+//     int next_id() {
+//         static int counter = 0;
+//         counter++;
+//         return counter;
+//     }
+//
+// Result:
+//
+//     func next_id() int {
+//         c4goStaticNext_idCounter += 1
+//         return c4goStaticNext_idCounter
+//     }
+//
+//     var c4goStaticNext_idCounter int
+//
+func transpileStaticLocalVarDecl(p *program.Program, n *ast.VarDecl) (
+	decls []goast.Decl, theType string, err error) {
+	name, ok := p.StaticVariables[n.Addr]
+	if !ok {
+		name = "c4goStatic" + util.Ucfirst(p.Function.Name) + util.Ucfirst(n.Name)
+		if _, ok := p.GlobalVariables[name]; ok {
+			name = p.GetNextIdentifier(name)
+		}
+		p.StaticVariables[n.Addr] = name
+	}
+	n.Name = name
+
+	// initializer of static variable is constant, so the variable is
+	// transpiled as global variable
+	f := p.Function
+	p.Function = nil
+	decls, theType, err = transpileVarDecl(p, n)
+	p.Function = f
+
+	p.StaticDecls = append(p.StaticDecls, decls...)
+	return nil, theType, err
+}
+
 func transpileVarDecl(p *program.Program, n *ast.VarDecl) (
 	decls []goast.Decl, theType string, err error) {
 	defer func() {
@@ -771,6 +851,7 @@ func transpileVarDecl(p *program.Program, n *ast.VarDecl) (
 	n.Name = types.GenerateCorrectType(n.Name)
 	n.Type = types.GenerateCorrectType(n.Type)
 	n.Type2 = types.GenerateCorrectType(n.Type2)
+	n.ChildNodes = removeBlocksAttr(n.ChildNodes)
 
 	// There may be some startup code for this global variable.
 	if p.Function == nil {
@@ -831,22 +912,26 @@ func transpileVarDecl(p *program.Program, n *ast.VarDecl) (
 		return
 	}
 
-	if strings.Contains(n.Type, "va_list") &&
-		strings.Contains(n.Type2, "va_list_tag") {
+	if p.Function != nil && n.IsStatic && !n.IsExtern {
+		return transpileStaticLocalVarDecl(p, n)
+	}
+
+	if types.IsVaList(n.Type) {
 		// variable for va_list. see "variadic function"
 		// header : <stdarg.h>
 		// Example :
 		// DeclStmt 0x2fd87e0 <line:442:2, col:14>
 		// `-VarDecl 0x2fd8780 <col:2, col:10> col:10 used args 'va_list':'struct __va_list_tag [1]'
 		// Result:
-		// ... - convert to - c4goArgs ...interface{}
-		// var args = c4goArgs
+		// var args *noarch.VaList = new(noarch.VaList)
+		t := p.ImportType("github.com/Konstantin8105/c4go/noarch.VaList")
 		return []goast.Decl{&goast.GenDecl{
 			Tok: token.VAR,
 			Specs: []goast.Spec{
 				&goast.ValueSpec{
 					Names:  []*goast.Ident{util.NewIdent(n.Name)},
-					Values: []goast.Expr{util.NewIdent("c4goArgs")},
+					Type:   util.NewTypeIdent("*" + t),
+					Values: []goast.Expr{util.NewCallExpr("new", goast.NewIdent(t))},
 				},
 			},
 		}}, "", nil
@@ -925,10 +1010,20 @@ func transpileVarDecl(p *program.Program, n *ast.VarDecl) (
 		return
 	}
 
-	defaultValue, _, newPre, newPost, err := getDefaultValueForVar(p, n)
-	if err != nil {
-		p.AddMessage(p.GenerateWarningMessage(err, n))
-		err = nil // Error is ignored
+	if decl, ok := transpileEmbedVarDecl(p, n); ok {
+		return []goast.Decl{decl}, theType, nil
+	}
+
+	var defaultValue []goast.Expr
+	var newPre, newPost []goast.Stmt
+	// Static initializers of some types from C standard library are
+	// ignored, because zero value in Go is the same.
+	if !isZeroValueType(n.Type) {
+		defaultValue, _, newPre, newPost, err = getDefaultValueForVar(p, n)
+		if err != nil {
+			p.AddMessage(p.GenerateWarningMessage(err, n))
+			err = nil // Error is ignored
+		}
 	}
 	// for ignore zero value. example:
 	// int i = 0;
@@ -962,10 +1057,47 @@ func transpileVarDecl(p *program.Program, n *ast.VarDecl) (
 
 	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 
-	// Allocate slice so that it operates like a fixed size array.
+	// Allocate slice for variable length array.
+	// C code : char buf[n + 1];
+	// Go code: var buf []byte = make([]byte, int(n)+1)
+	arrayType, variableSize := types.GetVariableArrayTypeAndSize(n.Type)
+	if variableSize != "" && defaultValue == nil {
+		var goArrayType string
+		goArrayType, err = types.ResolveType(p, arrayType)
+		if err != nil {
+			p.AddMessage(p.GenerateWarningMessage(err, n))
+			err = nil // Error is ignored
+		}
+
+		var length goast.Expr
+		length, err = transpileVariableArraySize(variableSize)
+		if err != nil {
+			p.AddMessage(p.GenerateWarningMessage(err, n))
+			err = nil // Error is ignored
+		} else {
+			defaultValue = []goast.Expr{
+				util.NewCallExpr(
+					"make",
+					&goast.ArrayType{
+						Elt: util.NewTypeIdent(goArrayType),
+					},
+					length,
+				),
+			}
+		}
+	}
+
+	// Buffers of recursive functions are taken from the pool.
+	if len(defaultValue) == 1 && defaultValue[0] != nil {
+		defaultValue[0] = pooledAlloc(p, n, defaultValue[0])
+	}
+
+	// Allocate slice so that it operates like a fixed size array. Arrays of
+	// function pointers are Go arrays, so the zero value is used.
 	arrayType, arraySize := types.GetArrayTypeAndSize(n.Type)
 
-	if arraySize != -1 && defaultValue == nil {
+	if arraySize != -1 && variableSize == "" && defaultValue == nil &&
+		!types.IsFunction(arrayType) {
 		var goArrayType string
 		goArrayType, err = types.ResolveType(p, arrayType)
 		if err != nil {
@@ -987,6 +1119,13 @@ func transpileVarDecl(p *program.Program, n *ast.VarDecl) (
 		}
 	}
 
+	// Variables with storage class thread_local are plain global variables,
+	// if the program does not create threads.
+	if n.IsTLS && p.Threads {
+		decls, err = transpileThreadLocalVarDecl(p, n, defaultValue)
+		return decls, "", err
+	}
+
 	if len(preStmts) != 0 || len(postStmts) != 0 {
 		p.AddMessage(p.GenerateWarningMessage(
 			fmt.Errorf("Not acceptable length of Stmt : pre(%d), post(%d)",
@@ -0,0 +1,103 @@
+// This file contains functions for transpiling of arrays initialized by the
+// directive `#embed` of C23.
+
+package transpiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+
+	goast "go/ast"
+	"go/token"
+)
+
+// transpileEmbedVarDecl returns the declaration of global array of bytes,
+// which is initialized by the directive `#embed` of C23, for example:
+//
+//     static const unsigned char logo[] = {
+//     #embed "logo.png"
+//     };
+//
+// Clang expands the directive to the list of bytes. If the list is the same
+// as the file and the file is inside of directory of the Go code, then the
+// array is embedded by the directive of Go 1.16:
+//
+//     //go:embed logo.png
+//     var logo []uint8
+//
+// Otherwise the array is initialized by the list of bytes.
+func transpileEmbedVarDecl(p *program.Program, n *ast.VarDecl) (
+	decl goast.Decl, ok bool) {
+	if p.Function != nil || !p.IsGoVersion(16) || len(n.Children()) == 0 {
+		return
+	}
+	il, ok := n.Children()[0].(*ast.InitListExpr)
+	if !ok || len(il.Children()) == 0 || il.Children()[0] == nil {
+		return nil, false
+	}
+	pos := il.Children()[0].Position()
+	embed, ok := p.PreprocessorFile.GetEmbed(pos.File, pos.Line)
+	if !ok {
+		return nil, false
+	}
+
+	elementType, size := types.GetArrayTypeAndSize(n.Type)
+	if t, err := types.ResolveType(p, elementType); err != nil ||
+		(t != "byte" && t != "uint8") {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(embed.Resource)
+	if err != nil || len(data) != size || len(il.Children()) != size {
+		return nil, false
+	}
+	for i, c := range il.Children() {
+		lit, isLit := unwrapAllocSize(c).(*ast.IntegerLiteral)
+		if !isLit {
+			return nil, false
+		}
+		if v, err := strconv.Atoi(lit.Value); err != nil || v != int(data[i]) {
+			return nil, false
+		}
+	}
+
+	dir, err := filepath.Abs(p.OutputDir)
+	if err != nil {
+		return nil, false
+	}
+	path, err := filepath.Rel(dir, embed.Resource)
+	if err != nil || strings.HasPrefix(path, "..") {
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"file `%s` of #embed is outside of directory of Go code",
+			embed.Resource), n))
+		return nil, false
+	}
+	path = filepath.ToSlash(path)
+	if strings.ContainsAny(path, " \"'`") {
+		path = strconv.Quote(path)
+	}
+
+	goType, err := types.ResolveType(p, n.Type)
+	if err != nil {
+		return nil, false
+	}
+	p.GlobalVariables[n.Name] = n.Type
+	p.AddBlankImport("embed")
+
+	return &goast.GenDecl{
+		Doc: &goast.CommentGroup{
+			List: []*goast.Comment{{Text: "//go:embed " + path}},
+		},
+		Tok: token.VAR,
+		Specs: []goast.Spec{&goast.ValueSpec{
+			Names: []*goast.Ident{goast.NewIdent(n.Name)},
+			Type:  goast.NewIdent(goType),
+		}},
+	}, true
+}
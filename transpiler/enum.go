@@ -91,8 +91,13 @@ func transpileEnumConstantDecl(p *program.Program, n *ast.EnumConstantDecl) (
 		valueType = "uint16"
 	default:
 		if len(n.Children()) > 0 {
+			// values of enum constants are Go constants, so the
+			// expressions of macros are not calls of functions
+			functionMacros := p.FunctionMacros
+			p.FunctionMacros = false
 			var err error
 			value, _, preStmts, postStmts, err = transpileToExpr(n.Children()[0], p, false)
+			p.FunctionMacros = functionMacros
 			if err != nil {
 				panic(err)
 			}
@@ -107,6 +112,32 @@ func transpileEnumConstantDecl(p *program.Program, n *ast.EnumConstantDecl) (
 	}, preStmts, postStmts
 }
 
+// enumSystemHeaders - list of system headers with enums, which must be
+// transpiled, because the enum constants are used by C code. For example:
+// DT_DIR from dirent.h.
+var enumSystemHeaders = []string{
+	"ctype.h",
+	"dirent.h",
+	"pthread.h",
+	"threads.h",
+	"socket.h",
+	"socket_type.h",
+	"netinet/in.h",
+	"netdb.h",
+	"search.h",
+	"confname.h",
+	"resource.h",
+}
+
+func isEnumOfSystemHeaderAllowed(file string) bool {
+	for _, header := range enumSystemHeaders {
+		if strings.Contains(file, header) {
+			return true
+		}
+	}
+	return false
+}
+
 func transpileEnumDecl(p *program.Program, n *ast.EnumDecl) (
 	decls []goast.Decl, err error) {
 	defer func() {
@@ -116,7 +147,7 @@ func transpileEnumDecl(p *program.Program, n *ast.EnumDecl) (
 	}()
 
 	if !p.PreprocessorFile.IsUserSource(n.Pos.File) &&
-		!strings.Contains(n.Pos.File, "ctype.h") {
+		!isEnumOfSystemHeaderAllowed(n.Pos.File) {
 		return
 	}
 
@@ -0,0 +1,83 @@
+package transpiler
+
+import (
+	"fmt"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+// resolveVariableDefinitions returns declarations of global variables that
+// must not be transpiled. In project mode the translation units are merged,
+// so the same variable may be declared in several files:
+//
+//     // main.c
+//     extern int level;
+//     int counter;
+//     // util.c
+//     int level = 3;
+//     int counter;
+//
+// In C the linker binds all declarations to the single definition. For each
+// variable only one declaration is transpiled: the declaration with
+// initializer, otherwise the first tentative definition without `extern`.
+// If the variable of user source has only `extern` declarations, then the
+// definition is not found in the C files and the Go variable with zero value
+// is created for the first declaration with warning.
+func resolveVariableDefinitions(p *program.Program, decls []ast.Node) (
+	skip map[*ast.VarDecl]bool) {
+	skip = map[*ast.VarDecl]bool{}
+	var names []string
+	all := map[string][]*ast.VarDecl{}
+	for _, decl := range decls {
+		vd, ok := decl.(*ast.VarDecl)
+		if !ok {
+			continue
+		}
+		if _, ok := all[vd.Name]; !ok {
+			names = append(names, vd.Name)
+		}
+		all[vd.Name] = append(all[vd.Name], vd)
+	}
+
+	for _, name := range names {
+		var chosen *ast.VarDecl
+		for _, vd := range all[name] {
+			isDefinition := hasInitializer(vd)
+			isTentative := !vd.IsExtern && !isDefinition
+			switch {
+			case chosen == nil && (isDefinition || isTentative):
+				chosen = vd
+			case isDefinition && !hasInitializer(chosen):
+				chosen = vd
+			}
+		}
+		if chosen == nil {
+			first := all[name][0]
+			if !p.PreprocessorFile.IsUserSource(first.Pos.File) ||
+				p.GetVariableSubstitution(name) != "" {
+				// variables of libraries, like `extern char **environ`,
+				// are defined in libraries
+				continue
+			}
+			p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+				"definition of extern variable `%s` is not found, "+
+					"variable with zero value is created", name), first))
+			first.IsExtern = false
+			chosen = first
+		}
+		for _, vd := range all[name] {
+			if vd != chosen {
+				skip[vd] = true
+			}
+		}
+	}
+	return
+}
+
+// hasInitializer returns true, if the variable is declared with initializer.
+// Attributes of variable, like `__attribute__((aligned(8)))`, are children of
+// declaration too, so only the initializer of `cinit` declaration is counted.
+func hasInitializer(vd *ast.VarDecl) bool {
+	return vd.IsCInit && len(vd.Children()) > 0
+}
@@ -0,0 +1,45 @@
+package transpiler
+
+import (
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestResolveVariableDefinitions(t *testing.T) {
+	// extern int level;
+	// int counter;
+	// extern char **environ;
+	// int level = 3;
+	// int counter;
+	// int total __attribute__((aligned(8)));
+	// int total = 7;
+	tree := parseTree(t, `
+TranslationUnitDecl 0x1 <<invalid sloc>> <invalid sloc>
+|-VarDecl 0x2 <file.c:1:1, col:12> col:12 used level 'int' extern
+|-VarDecl 0x3 <line:2:1, col:5> col:5 used counter 'int'
+|-VarDecl 0x4 <line:3:1, col:15> col:15 environ 'char **' extern
+|-VarDecl 0x5 prev 0x2 <line:4:1, col:13> col:5 used level 'int' cinit
+| `+"`"+`-IntegerLiteral 0x6 <col:13> 'int' 3
+|-VarDecl 0x7 prev 0x3 <line:5:1, col:5> col:5 used counter 'int'
+|-VarDecl 0x8 <line:6:1, col:38> col:5 total 'int'
+| `+"`"+`-AlignedAttr 0x9 <col:20, col:37> aligned
+|   `+"`"+`-IntegerLiteral 0x10 <col:35> 'int' 8
+`+"`"+`-VarDecl 0x11 prev 0x8 <line:7:1, col:13> col:5 total 'int' cinit
+  `+"`"+`-IntegerLiteral 0x12 <col:13> 'int' 7
+`)
+
+	p := program.NewProgram()
+	skip := resolveVariableDefinitions(p, tree.Children())
+	for i, expected := range []bool{true, false, false, false, true, true, false} {
+		vd := tree.Children()[i].(*ast.VarDecl)
+		if skip[vd] != expected {
+			t.Errorf("Declaration %d of `%s`: skip is %v, expected %v",
+				i, vd.Name, skip[vd], expected)
+		}
+	}
+	if messages := p.GetMessages(); len(messages) > 0 {
+		t.Errorf("Not expected warnings: %v", messages)
+	}
+}
@@ -0,0 +1,192 @@
+package transpiler
+
+import (
+	"fmt"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+// reportUseAfterFree adds warnings for pointers, which are used or freed
+// again after the call of free() in the function body. In Go free() does
+// nothing and the memory is kept by garbage collector, so such C code
+// "works" after transpiling, but the behavior differs from C code:
+//
+//     q = p;
+//     free(p);
+//     printf("%d", p->value);   // warning: use after free
+//     free(q);                  // warning: double free through alias
+//
+// Statements are checked in the order of C code. Copies of pointer, like
+// `q = p` or `int *q = p`, are aliases of the same memory, so uses and
+// frees through any copy are reported. The pointer is not freed after
+// assignment of new value. Frees in the block, which ends by return, break,
+// continue or goto, are forgotten at the end of block.
+func reportUseAfterFree(p *program.Program, n *ast.FunctionDecl) {
+	body := getFunctionBody(n)
+	if body == nil {
+		return
+	}
+	// memory - numbers of memory for addresses of declarations of pointers,
+	// aliases have the same number
+	memory := map[ast.Address]int{}
+	amount := 0
+	memoryOf := func(addr ast.Address) int {
+		if _, ok := memory[addr]; !ok {
+			amount++
+			memory[addr] = amount
+		}
+		return memory[addr]
+	}
+	assign := func(addr ast.Address, value ast.Node) {
+		if src, ok := pointerVariable(value); ok {
+			memory[addr] = memoryOf(ast.ParseAddress(src.Address2))
+			return
+		}
+		// new value is the new memory
+		amount++
+		memory[addr] = amount
+	}
+	// freed - free() calls for numbers of freed memory
+	type freeCall struct {
+		line int
+		name string
+	}
+	freed := map[int]freeCall{}
+	// through returns the explanation of free() through alias
+	through := func(f freeCall, name string) string {
+		if f.name == name {
+			return ""
+		}
+		return fmt.Sprintf(" through `%s`", f.name)
+	}
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		if node == nil {
+			return
+		}
+		switch v := node.(type) {
+		case *ast.UnaryExprOrTypeTraitExpr:
+			// sizeof(*p) does not read the pointer
+			return
+
+		case *ast.CompoundStmt:
+			beforeFreed := map[int]freeCall{}
+			for k, f := range freed {
+				beforeFreed[k] = f
+			}
+			beforeMemory := map[ast.Address]int{}
+			for k, m := range memory {
+				beforeMemory[k] = m
+			}
+			for _, c := range v.Children() {
+				walk(c)
+			}
+			if len(v.Children()) > 0 && isJumpStmt(v.Children()[len(v.Children())-1]) {
+				freed, memory = beforeFreed, beforeMemory
+			}
+			return
+
+		case *ast.VarDecl:
+			for _, c := range v.Children() {
+				walk(c)
+			}
+			if v.IsCInit && len(v.Children()) > 0 {
+				// attributes, like `__block`, are after the initializer
+				assign(v.Addr, v.Children()[0])
+			}
+			return
+
+		case *ast.BinaryOperator:
+			if v.Operator == "=" && len(v.Children()) == 2 {
+				if ref, ok := v.Children()[0].(*ast.DeclRefExpr); ok {
+					walk(v.Children()[1])
+					assign(ast.ParseAddress(ref.Address2), v.Children()[1])
+					return
+				}
+			}
+
+		case *ast.CallExpr:
+			ref, ok := freedPointer(v)
+			if !ok {
+				break
+			}
+			m := memoryOf(ast.ParseAddress(ref.Address2))
+			if f, ok := freed[m]; ok {
+				p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+					"double free of pointer `%s`, freed at line %d%s, "+
+						"in Go free() does nothing",
+					ref.Name, f.line, through(f, ref.Name)), v))
+			}
+			freed[m] = freeCall{line: v.Position().Line, name: ref.Name}
+			return
+
+		case *ast.DeclRefExpr:
+			m := memoryOf(ast.ParseAddress(v.Address2))
+			if f, ok := freed[m]; ok {
+				p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+					"pointer `%s` is used after free() at line %d%s, "+
+						"in Go the memory is kept by garbage collector",
+					v.Name, f.line, through(f, v.Name)), v))
+				// only first use is reported
+				delete(freed, m)
+			}
+			return
+		}
+		for _, c := range node.Children() {
+			walk(c)
+		}
+	}
+	walk(body)
+}
+
+// freedPointer returns the variable, which is the argument of call free().
+func freedPointer(call *ast.CallExpr) (ref *ast.DeclRefExpr, ok bool) {
+	if len(call.Children()) != 2 {
+		return nil, false
+	}
+	if name, ok := calledName(call.Children()[0]); !ok || name != "free" {
+		return nil, false
+	}
+	return pointerVariable(call.Children()[1])
+}
+
+// pointerVariable returns the variable, if the expression is the value of
+// variable without changes, like `p` or `(char *)p`.
+func pointerVariable(arg ast.Node) (ref *ast.DeclRefExpr, ok bool) {
+	for {
+		switch v := arg.(type) {
+		case *ast.ImplicitCastExpr, *ast.CStyleCastExpr, *ast.ParenExpr:
+			if len(v.Children()) != 1 {
+				return nil, false
+			}
+			arg = v.Children()[0]
+			continue
+		case *ast.DeclRefExpr:
+			return v, v.For == "Var" || v.For == "ParmVar"
+		}
+		return nil, false
+	}
+}
+
+// calledName returns the name of called function.
+func calledName(n ast.Node) (name string, ok bool) {
+	if impl, ok := n.(*ast.ImplicitCastExpr); ok && len(impl.Children()) == 1 {
+		n = impl.Children()[0]
+	}
+	ref, ok := n.(*ast.DeclRefExpr)
+	if !ok || ref.For != "Function" {
+		return "", false
+	}
+	return ref.Name, true
+}
+
+// isJumpStmt returns true for statements, after which the next statement of
+// block is not executed.
+func isJumpStmt(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.ReturnStmt, *ast.BreakStmt, *ast.ContinueStmt, *ast.GotoStmt:
+		return true
+	}
+	return false
+}
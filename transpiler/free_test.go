@@ -0,0 +1,161 @@
+package transpiler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+// parseTree returns the tree of AST nodes from clang output.
+func parseTree(t *testing.T, text string) ast.Node {
+	var stack []ast.Node
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		trimmed := strings.TrimLeft(line, "|\\- `")
+		node, err := ast.Parse(trimmed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		level := (len(line) - len(trimmed)) / 2
+		stack = stack[:level]
+		if level > 0 {
+			stack[level-1].AddChild(node)
+		}
+		stack = append(stack, node)
+	}
+	ast.FixPositions(stack[:1])
+	return stack[0]
+}
+
+func TestUseAfterFree(t *testing.T) {
+	// void f(int *p, int *q) {
+	//   free(p);
+	//   *p = 1;
+	//   if (q) { free(q); return; }
+	//   free(q);
+	//   q = 0;
+	//   free(q);
+	//   free(q);
+	// }
+	tree := parseTree(t, `
+FunctionDecl 0x1 <file.c:1:1, line:9:1> line:1:6 f 'void (int *, int *)'
+|-ParmVarDecl 0x11 <col:8, col:13> col:13 used p 'int *'
+|-ParmVarDecl 0x12 <col:16, col:21> col:21 used q 'int *'
+`+"`"+`-CompoundStmt 0x2 <col:24, line:9:1>
+  |-CallExpr 0x3 <line:2:3, col:9> 'void'
+  | |-ImplicitCastExpr 0x4 <col:3> 'void (*)(void *)' <FunctionToPointerDecay>
+  | | `+"`"+`-DeclRefExpr 0x5 <col:3> 'void (void *)' Function 0x50 'free' 'void (void *)'
+  | `+"`"+`-ImplicitCastExpr 0x6 <col:8> 'void *' <BitCast>
+  |   `+"`"+`-ImplicitCastExpr 0x7 <col:8> 'int *' <LValueToRValue>
+  |     `+"`"+`-DeclRefExpr 0x8 <col:8> 'int *' lvalue ParmVar 0x11 'p' 'int *'
+  |-BinaryOperator 0x9 <line:3:3, col:8> 'int' '='
+  | |-UnaryOperator 0x10 <col:3, col:4> 'int' lvalue prefix '*'
+  | | `+"`"+`-ImplicitCastExpr 0x13 <col:4> 'int *' <LValueToRValue>
+  | |   `+"`"+`-DeclRefExpr 0x14 <col:4> 'int *' lvalue ParmVar 0x11 'p' 'int *'
+  | `+"`"+`-IntegerLiteral 0x15 <col:8> 'int' 1
+  |-IfStmt 0x16 <line:4:3, col:29>
+  | |-ImplicitCastExpr 0x17 <col:7> 'int *' <LValueToRValue>
+  | | `+"`"+`-DeclRefExpr 0x18 <col:7> 'int *' lvalue ParmVar 0x12 'q' 'int *'
+  | `+"`"+`-CompoundStmt 0x19 <col:10, col:29>
+  |   |-CallExpr 0x20 <col:12, col:18> 'void'
+  |   | |-ImplicitCastExpr 0x21 <col:12> 'void (*)(void *)' <FunctionToPointerDecay>
+  |   | | `+"`"+`-DeclRefExpr 0x22 <col:12> 'void (void *)' Function 0x50 'free' 'void (void *)'
+  |   | `+"`"+`-ImplicitCastExpr 0x23 <col:17> 'void *' <BitCast>
+  |   |   `+"`"+`-ImplicitCastExpr 0x24 <col:17> 'int *' <LValueToRValue>
+  |   |     `+"`"+`-DeclRefExpr 0x25 <col:17> 'int *' lvalue ParmVar 0x12 'q' 'int *'
+  |   `+"`"+`-ReturnStmt 0x26 <col:21>
+  |-CallExpr 0x27 <line:5:3, col:9> 'void'
+  | |-ImplicitCastExpr 0x28 <col:3> 'void (*)(void *)' <FunctionToPointerDecay>
+  | | `+"`"+`-DeclRefExpr 0x29 <col:3> 'void (void *)' Function 0x50 'free' 'void (void *)'
+  | `+"`"+`-ImplicitCastExpr 0x30 <col:8> 'void *' <BitCast>
+  |   `+"`"+`-ImplicitCastExpr 0x31 <col:8> 'int *' <LValueToRValue>
+  |     `+"`"+`-DeclRefExpr 0x32 <col:8> 'int *' lvalue ParmVar 0x12 'q' 'int *'
+  |-BinaryOperator 0x33 <line:6:3, col:7> 'int *' '='
+  | |-DeclRefExpr 0x34 <col:3> 'int *' lvalue ParmVar 0x12 'q' 'int *'
+  | `+"`"+`-ImplicitCastExpr 0x35 <col:7> 'int *' <NullToPointer>
+  |   `+"`"+`-IntegerLiteral 0x36 <col:7> 'int' 0
+  |-CallExpr 0x37 <line:7:3, col:9> 'void'
+  | |-ImplicitCastExpr 0x38 <col:3> 'void (*)(void *)' <FunctionToPointerDecay>
+  | | `+"`"+`-DeclRefExpr 0x39 <col:3> 'void (void *)' Function 0x50 'free' 'void (void *)'
+  | `+"`"+`-ImplicitCastExpr 0x40 <col:8> 'void *' <BitCast>
+  |   `+"`"+`-ImplicitCastExpr 0x41 <col:8> 'int *' <LValueToRValue>
+  |     `+"`"+`-DeclRefExpr 0x42 <col:8> 'int *' lvalue ParmVar 0x12 'q' 'int *'
+  `+"`"+`-CallExpr 0x43 <line:8:3, col:9> 'void'
+    |-ImplicitCastExpr 0x44 <col:3> 'void (*)(void *)' <FunctionToPointerDecay>
+    | `+"`"+`-DeclRefExpr 0x45 <col:3> 'void (void *)' Function 0x50 'free' 'void (void *)'
+    `+"`"+`-ImplicitCastExpr 0x46 <col:8> 'void *' <BitCast>
+      `+"`"+`-ImplicitCastExpr 0x47 <col:8> 'int *' <LValueToRValue>
+        `+"`"+`-DeclRefExpr 0x48 <col:8> 'int *' lvalue ParmVar 0x12 'q' 'int *'
+`)
+
+	p := program.NewProgram()
+	reportUseAfterFree(p, tree.(*ast.FunctionDecl))
+	expected := []string{
+		"// Warning (*ast.DeclRefExpr):  file.c:3 :pointer `p` is used " +
+			"after free() at line 2, in Go the memory is kept by garbage collector",
+		"// Warning (*ast.CallExpr):  file.c:8 :double free of pointer `q`, " +
+			"freed at line 7, in Go free() does nothing",
+	}
+	if messages := p.GetMessages(); !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Not expected warnings:\n%s\nExpected:\n%s",
+			strings.Join(messages, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestUseAfterFreeThroughAlias(t *testing.T) {
+	// void g(int *p) {
+	//   int *q = p;
+	//   int *r;
+	//   r = q;
+	//   free(p);
+	//   free(r);
+	//   *q = 1;
+	// }
+	tree := parseTree(t, `
+FunctionDecl 0x1 <file.c:1:1, line:8:1> line:1:6 g 'void (int *)'
+|-ParmVarDecl 0x11 <col:8, col:13> col:13 used p 'int *'
+`+"`"+`-CompoundStmt 0x2 <col:16, line:8:1>
+  |-DeclStmt 0x3 <line:2:3, col:14>
+  | `+"`"+`-VarDecl 0x12 <col:3, col:13> col:8 used q 'int *' cinit
+  |   `+"`"+`-ImplicitCastExpr 0x4 <col:13> 'int *' <LValueToRValue>
+  |     `+"`"+`-DeclRefExpr 0x5 <col:13> 'int *' lvalue ParmVar 0x11 'p' 'int *'
+  |-DeclStmt 0x6 <line:3:3, col:9>
+  | `+"`"+`-VarDecl 0x13 <col:3, col:8> col:8 used r 'int *'
+  |-BinaryOperator 0x7 <line:4:3, col:7> 'int *' '='
+  | |-DeclRefExpr 0x8 <col:3> 'int *' lvalue Var 0x13 'r' 'int *'
+  | `+"`"+`-ImplicitCastExpr 0x9 <col:7> 'int *' <LValueToRValue>
+  |   `+"`"+`-DeclRefExpr 0x10 <col:7> 'int *' lvalue Var 0x12 'q' 'int *'
+  |-CallExpr 0x14 <line:5:3, col:9> 'void'
+  | |-ImplicitCastExpr 0x15 <col:3> 'void (*)(void *)' <FunctionToPointerDecay>
+  | | `+"`"+`-DeclRefExpr 0x16 <col:3> 'void (void *)' Function 0x50 'free' 'void (void *)'
+  | `+"`"+`-ImplicitCastExpr 0x17 <col:8> 'void *' <BitCast>
+  |   `+"`"+`-ImplicitCastExpr 0x18 <col:8> 'int *' <LValueToRValue>
+  |     `+"`"+`-DeclRefExpr 0x19 <col:8> 'int *' lvalue ParmVar 0x11 'p' 'int *'
+  |-CallExpr 0x20 <line:6:3, col:9> 'void'
+  | |-ImplicitCastExpr 0x21 <col:3> 'void (*)(void *)' <FunctionToPointerDecay>
+  | | `+"`"+`-DeclRefExpr 0x22 <col:3> 'void (void *)' Function 0x50 'free' 'void (void *)'
+  | `+"`"+`-ImplicitCastExpr 0x23 <col:8> 'void *' <BitCast>
+  |   `+"`"+`-ImplicitCastExpr 0x24 <col:8> 'int *' <LValueToRValue>
+  |     `+"`"+`-DeclRefExpr 0x25 <col:8> 'int *' lvalue Var 0x13 'r' 'int *'
+  `+"`"+`-BinaryOperator 0x26 <line:7:3, col:8> 'int' '='
+    |-UnaryOperator 0x27 <col:3, col:4> 'int' lvalue prefix '*'
+    | `+"`"+`-ImplicitCastExpr 0x28 <col:4> 'int *' <LValueToRValue>
+    |   `+"`"+`-DeclRefExpr 0x29 <col:4> 'int *' lvalue Var 0x12 'q' 'int *'
+    `+"`"+`-IntegerLiteral 0x30 <col:8> 'int' 1
+`)
+
+	p := program.NewProgram()
+	reportUseAfterFree(p, tree.(*ast.FunctionDecl))
+	expected := []string{
+		"// Warning (*ast.CallExpr):  file.c:6 :double free of pointer `r`, " +
+			"freed at line 5 through `p`, in Go free() does nothing",
+		"// Warning (*ast.DeclRefExpr):  file.c:7 :pointer `q` is used " +
+			"after free() at line 6 through `r`, in Go the memory is kept by garbage collector",
+	}
+	if messages := p.GetMessages(); !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Not expected warnings:\n%s\nExpected:\n%s",
+			strings.Join(messages, "\n"), strings.Join(expected, "\n"))
+	}
+}
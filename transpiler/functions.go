@@ -6,6 +6,7 @@ package transpiler
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/Konstantin8105/c4go/ast"
@@ -33,6 +34,138 @@ func getFunctionBody(n *ast.FunctionDecl) *ast.CompoundStmt {
 	return nil
 }
 
+// knrFunctionType returns the type of function with old-style (K&R)
+// definition, where the types of parameters are declared after the list of
+// names of parameters:
+//
+//     int f(a, b) int a; char *b; { ... }
+//
+// The type of such function in clang AST has not the parameters, like
+// 'int ()', so the types of parameters are taken from ParmVarDecl nodes.
+func knrFunctionType(n *ast.FunctionDecl) (string, bool) {
+	if !strings.HasSuffix(n.Type, "()") || getFunctionBody(n) == nil {
+		return "", false
+	}
+	var params []string
+	for _, c := range n.Children() {
+		if v, ok := c.(*ast.ParmVarDecl); ok {
+			params = append(params, v.Type)
+		}
+	}
+	if len(params) == 0 {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimSuffix(n.Type, "()")) +
+		" (" + strings.Join(params, ", ") + ")", true
+}
+
+// registerKnrFunctions replaces the types of old-style function definitions
+// by prototypes and registers the functions before transpiling, because
+// the calls of such functions may be placed before definitions and the
+// arguments of calls are promoted as for function without prototype.
+func registerKnrFunctions(p *program.Program, root ast.Node) {
+	for _, node := range root.Children() {
+		f, ok := node.(*ast.FunctionDecl)
+		if !ok {
+			continue
+		}
+		t, ok := knrFunctionType(f)
+		if !ok {
+			continue
+		}
+		f.Type = t
+		_, args, returns, err := types.ParseFunction(t)
+		if err != nil {
+			p.AddMessage(p.GenerateWarningMessage(err, f))
+			continue
+		}
+		p.AddFunctionDefinition(program.FunctionDefinition{
+			Name:          util.ConvertFunctionNameFromCtoGo(f.Name),
+			ReturnType:    returns[0],
+			ArgumentTypes: args,
+		})
+	}
+}
+
+// registerImplicitFunctions registers the prototypes of functions, which
+// are called before declaration as in C89. Clang declares such functions
+// implicitly with type 'int ()', so the prototype is taken from the
+// declaration of function later in the file or it is inferred from the
+// types of arguments of the first call:
+//
+//     int main() { return f(1, 2.5); }   ->  int f(int, double)
+//
+func registerImplicitFunctions(p *program.Program, root ast.Node) {
+	var implicit []*ast.FunctionDecl
+	declared := map[string]string{}
+	for _, node := range root.Children() {
+		f, ok := node.(*ast.FunctionDecl)
+		if !ok {
+			continue
+		}
+		if f.IsImplicit && f.Type == "int ()" {
+			implicit = append(implicit, f)
+			continue
+		}
+		if _, ok := declared[f.Name]; !ok && !strings.HasSuffix(f.Type, "()") {
+			declared[f.Name] = f.Type
+		}
+	}
+	if len(implicit) == 0 {
+		return
+	}
+
+	calls := ast.GetAllNodesOfType(root, reflect.TypeOf((*ast.CallExpr)(nil)))
+	for _, f := range implicit {
+		name := util.ConvertFunctionNameFromCtoGo(f.Name)
+		if p.GetFunctionDefinition(name) != nil {
+			continue
+		}
+
+		t, ok := declared[f.Name]
+		if !ok {
+			t = "int (" + strings.Join(firstCallArgumentTypes(calls, f.Name), ", ") + ")"
+		}
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"implicit declaration of function `%s`, prototype is `%s`",
+			f.Name, t), f))
+
+		_, args, returns, err := types.ParseFunction(t)
+		if err != nil {
+			p.AddMessage(p.GenerateWarningMessage(err, f))
+			continue
+		}
+		p.AddFunctionDefinition(program.FunctionDefinition{
+			Name:          name,
+			ReturnType:    returns[0],
+			ArgumentTypes: args,
+		})
+	}
+}
+
+// firstCallArgumentTypes returns the types of arguments of the first call of
+// function.
+func firstCallArgumentTypes(calls []ast.Node, name string) (argTypes []string) {
+	for _, node := range calls {
+		call := node.(*ast.CallExpr)
+		if len(call.Children()) == 0 {
+			continue
+		}
+		impl, ok := call.Children()[0].(*ast.ImplicitCastExpr)
+		if !ok || len(impl.Children()) == 0 {
+			continue
+		}
+		if decl, ok := impl.Children()[0].(*ast.DeclRefExpr); !ok || decl.Name != name {
+			continue
+		}
+		for _, arg := range call.Children()[1:] {
+			argTypes = append(argTypes, nodeType(arg))
+		}
+		return
+	}
+	return
+}
+
 // transpileFunctionDecl transpiles the function prototype.
 //
 // The function prototype may also have a body. If it does have a body the whole
@@ -72,9 +205,15 @@ func transpileFunctionDecl(n *ast.FunctionDecl, p *program.Program) (
 	// therefore be able to lookup what the real return type should be. I'm sure
 	// there is a much better way of doing this.
 	p.Function = n
+	p.PooledBuffers = findPooledBuffers(n)
+	p.LabelAddresses = findLabelAddresses(n)
+	p.StaticDecls = nil
 	defer func() {
 		// Reset the function name when we go out of scope.
 		p.Function = nil
+		p.PooledBuffers = map[ast.Address]bool{}
+		p.LabelAddresses = map[string]int{}
+		p.StaticDecls = nil
 	}()
 
 	n.Name = util.ConvertFunctionNameFromCtoGo(n.Name)
@@ -110,7 +249,15 @@ func transpileFunctionDecl(n *ast.FunctionDecl, p *program.Program) (
 	// is a CompoundStmt (since it is not valid to have a function body without
 	// curly brackets).
 	functionBody := getFunctionBody(n)
-	if functionBody != nil {
+	// Functions of API of data structures replaced by option "-pattern"
+	// have the Go implementation instead of C body.
+	if patternBody, ok := transpilePatternFunction(p, n); ok && functionBody != nil {
+		body = patternBody
+	} else if asmBody, ok := transpileAsmFunction(p, n, functionBody); ok {
+		// Functions with inline assembly are transpiled as stubs.
+		body = asmBody
+	} else if functionBody != nil {
+		reportUseAfterFree(p, n)
 		var pre, post []goast.Stmt
 		body, pre, post, err = transpileToBlockStmt(functionBody, p)
 		if err != nil || len(pre) > 0 || len(post) > 0 {
@@ -119,6 +266,8 @@ func transpileFunctionDecl(n *ast.FunctionDecl, p *program.Program) (
 					n.Name, err), n))
 			err = nil // Error is ignored
 		}
+		gotoBody(p, n, body)
+		guardBody(p, body)
 	}
 
 	if functionBody != nil {
@@ -197,6 +346,14 @@ func transpileFunctionDecl(n *ast.FunctionDecl, p *program.Program) (
 			// Prepend statements for main().
 			body.List = append(prependStmtsInMain, body.List...)
 
+			// Functions registered by atexit() are called at the end of
+			// main() too.
+			if p.ExitHandlers && !isExitCall(body.List) {
+				body.List = append(body.List, util.NewExprStmt(util.NewCallExpr(
+					p.ImportType("github.com/Konstantin8105/c4go/noarch.Exit"),
+					util.NewIntLit(0))))
+			}
+
 			// The main() function does not have arguments or a return value.
 			fieldList = &goast.FieldList{}
 		}
@@ -222,35 +379,14 @@ func transpileFunctionDecl(n *ast.FunctionDecl, p *program.Program) (
 			}
 		}
 
-		// for function argument: ...
-		// added for "variadic function"
-		if strings.Contains(n.Type, "...") {
-			body.List = append([]goast.Stmt{
-				&goast.DeclStmt{
-					Decl: &goast.GenDecl{
-						Tok: token.VAR,
-						Specs: []goast.Spec{&goast.ValueSpec{
-							Names: []*goast.Ident{util.NewIdent("c4goVaListPosition")},
-							Type:  goast.NewIdent("int"),
-							Values: []goast.Expr{&goast.BasicLit{
-								Kind:  token.INT,
-								Value: "0",
-							}},
-						}},
-					}},
-				&goast.AssignStmt{
-					Lhs: []goast.Expr{goast.NewIdent("_")},
-					Tok: token.ASSIGN,
-					Rhs: []goast.Expr{util.NewIdent("c4goVaListPosition")},
-				},
-			}, body.List...)
-		}
-
 		decls = append(decls, &goast.FuncDecl{
 			Name: util.NewIdent(n.Name),
 			Type: util.NewFuncType(fieldList, t, addReturnName),
 			Body: body,
 		})
+		// static local variables are declared after the function, because
+		// the comments of function are added to the first declaration
+		decls = append(decls, p.StaticDecls...)
 	}
 
 	err = nil
@@ -284,7 +420,7 @@ func getFieldList(p *program.Program, f *ast.FunctionDecl, fieldTypes []string)
 	// for function argument: ...
 	if strings.Contains(f.Type, "...") {
 		r = append(r, &goast.Field{
-			Names: []*goast.Ident{util.NewIdent("c4goArgs")},
+			Names: []*goast.Ident{util.NewIdent(vaArgsName)},
 			Type: &goast.Ellipsis{
 				Ellipsis: 1,
 				Elt: &goast.InterfaceType{
@@ -326,18 +462,30 @@ func transpileReturnStmt(n *ast.ReturnStmt, p *program.Program) (
 		return nil, nil, nil, fmt.Errorf("Expr is nil")
 	}
 
-	f := p.GetFunctionDefinition(p.Function.Name)
+	returnType := p.GetFunctionDefinition(p.Function.Name).ReturnType
+	if len(p.NestedFunctions) > 0 {
+		// return from the Go closure of nested function
+		returnType = p.NestedFunctions[len(p.NestedFunctions)-1]
+	}
 
-	t, err := types.CastExpr(p, e, eType, f.ReturnType)
+	t, err := types.CastExpr(p, e, eType, returnType)
 	if p.AddMessage(p.GenerateWarningMessage(err, n)) {
 		t = util.NewNil()
 	}
+	t = copyValue(p, t, returnType)
 
 	results := []goast.Expr{t}
 
 	// main() function is not allowed to return a result. Use os.Exit if
 	// non-zero.
-	if p.Function != nil && p.Function.Name == "main" {
+	if p.Function != nil && p.Function.Name == "main" &&
+		len(p.NestedFunctions) == 0 {
+		// functions registered by atexit() must be called
+		if p.ExitHandlers {
+			return util.NewExprStmt(util.NewCallExpr(
+				p.ImportType("github.com/Konstantin8105/c4go/noarch.Exit"),
+				results...)), preStmts, postStmts, nil
+		}
 		litExpr, isLiteral := e.(*goast.BasicLit)
 		if !isLiteral || (isLiteral && litExpr.Value != "0") {
 			p.AddImport("os")
@@ -353,3 +501,20 @@ func transpileReturnStmt(n *ast.ReturnStmt, p *program.Program) (
 		Results: results,
 	}, preStmts, postStmts, nil
 }
+
+// isExitCall returns true, if the last statement is the call of exit().
+func isExitCall(stmts []goast.Stmt) bool {
+	if len(stmts) == 0 {
+		return false
+	}
+	e, ok := stmts[len(stmts)-1].(*goast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := e.X.(*goast.CallExpr)
+	if !ok {
+		return false
+	}
+	f, ok := call.Fun.(*goast.Ident)
+	return ok && f.Name == "noarch.Exit"
+}
@@ -1,10 +1,28 @@
-// This file contains functions for transpiling goto/label statements.
+// This file contains functions for transpiling goto/label statements. The
+// addresses of labels of GNU C are the numbers of labels and the computed
+// goto is the switch on number:
+//
+//     void *next = &&done;    ->  var next interface{} = 1
+//     goto *next;             ->  switch next {
+//                                 case 1:
+//                                     goto done
+//                                 default:
+//                                     panic("...")
+//                                 }
+//
+// Operator goto of C may jump into blocks and over declarations of
+// variables, but Go does not allow it, so the function body with operators
+// goto is restructured after transpiling, see gotoBody.
+//
 
 package transpiler
 
 import (
+	"fmt"
 	goast "go/ast"
 	"go/token"
+	"reflect"
+	"sort"
 
 	"github.com/Konstantin8105/c4go/ast"
 	"github.com/Konstantin8105/c4go/program"
@@ -35,3 +53,658 @@ func transpileGotoStmt(n *ast.GotoStmt, p *program.Program) (*goast.BranchStmt,
 		Tok:   token.GOTO,
 	}, nil
 }
+
+// findLabelAddresses returns the numbers of labels of function, which
+// addresses are taken. Labels are numbered from 1, so the NULL pointer is not
+// the address of label.
+func findLabelAddresses(n *ast.FunctionDecl) map[string]int {
+	labels := map[string]int{}
+	body := getFunctionBody(n)
+	if body == nil {
+		return labels
+	}
+	for _, node := range ast.GetAllNodesOfType(body,
+		reflect.TypeOf((*ast.AddrLabelExpr)(nil))) {
+		name := node.(*ast.AddrLabelExpr).Name
+		if _, ok := labels[name]; !ok {
+			labels[name] = len(labels) + 1
+		}
+	}
+	return labels
+}
+
+func transpileAddrLabelExpr(n *ast.AddrLabelExpr, p *program.Program) (
+	goast.Expr, string, error) {
+	number, ok := p.LabelAddresses[n.Name]
+	if !ok {
+		return nil, "", fmt.Errorf("address of label `%s` is not found", n.Name)
+	}
+	return util.NewIntLit(number), n.Type, nil
+}
+
+// transpileIndirectGotoStmt transpiles the computed goto to the switch on
+// the number of label. The switch has the cases for all labels of function,
+// which addresses are taken.
+func transpileIndirectGotoStmt(n *ast.IndirectGotoStmt, p *program.Program) (
+	_ goast.Stmt, preStmts, postStmts []goast.Stmt, err error) {
+	if len(n.Children()) != 1 {
+		err = fmt.Errorf("unexpected amount of children of computed goto: %d",
+			len(n.Children()))
+		return
+	}
+	if len(p.LabelAddresses) == 0 {
+		err = fmt.Errorf("computed goto without addresses of labels " +
+			"in function is not supported")
+		return
+	}
+	tag, _, preStmts, postStmts, err := transpileToExpr(n.Children()[0], p, false)
+	if err != nil {
+		return
+	}
+
+	var labels []string
+	for label := range p.LabelAddresses {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return p.LabelAddresses[labels[i]] < p.LabelAddresses[labels[j]]
+	})
+	body := &goast.BlockStmt{}
+	for _, label := range labels {
+		body.List = append(body.List, &goast.CaseClause{
+			List: []goast.Expr{util.NewIntLit(p.LabelAddresses[label])},
+			Body: []goast.Stmt{&goast.BranchStmt{
+				Tok:   token.GOTO,
+				Label: util.NewIdent(label),
+			}},
+		})
+	}
+	// address of label is taken only in the same function, so the
+	// other addresses are not possible
+	body.List = append(body.List, &goast.CaseClause{
+		Body: []goast.Stmt{&goast.ExprStmt{X: util.NewCallExpr("panic",
+			util.NewStringLit(`"c4go: computed goto to unknown address"`))}},
+	})
+	return &goast.SwitchStmt{Tag: tag, Body: body}, preStmts, postStmts, nil
+}
+
+// Prefixes of names of variables and labels, which are generated for the
+// operators goto.
+const (
+	gotoFlagPrefix  = "c4goGoto"
+	gotoEnterPrefix = "c4goEnter"
+)
+
+// gotoBody makes the operators goto of function body valid in Go. Operator
+// goto of Go cannot jump into a block and cannot jump over declarations of
+// variables, and labels must be used, so the body is restructured:
+//
+//   - operator goto into a block of IF, FOR or a compound statement is
+//     replaced by the jump to the label before that statement and the
+//     flag, which forces the way to the label, as in next example.
+//   - declarations of variables, which are jumped over, are moved to the
+//     begin of block and are replaced by assignments.
+//   - labels, which are not used, are removed.
+//
+// Example of C code:
+//
+//     goto inside;
+//     while (i < n) {
+//         i++;
+//     inside:
+//         sum += i;
+//     }
+//
+// Result:
+//
+//     var c4goGotoinside bool
+//     c4goGotoinside = true
+//     goto c4goEnterinside1
+//     c4goEnterinside1:
+//     for c4goGotoinside || (i < n) {
+//         if c4goGotoinside {
+//             c4goGotoinside = false
+//             goto inside
+//         }
+//         i++
+//     inside:
+//         sum += i
+//     }
+//
+func gotoBody(p *program.Program, n ast.Node, body *goast.BlockStmt) {
+	if body == nil {
+		return
+	}
+
+	// function literals have own labels
+	goast.Inspect(body, func(node goast.Node) bool {
+		if f, ok := node.(*goast.FuncLit); ok {
+			gotoBody(p, n, f.Body)
+			return false
+		}
+		return true
+	})
+
+	scope := newGotoScope(body)
+	for _, jump := range scope.jumps {
+		if err := scope.enterBlock(body, jump); err != nil {
+			p.AddMessage(p.GenerateWarningMessage(err, n))
+		}
+	}
+
+	scope = newGotoScope(body)
+	for _, err := range scope.hoistDeclarations() {
+		p.AddMessage(p.GenerateWarningMessage(err, n))
+	}
+
+	newGotoScope(body).removeUnusedLabels()
+}
+
+// gotoFrame is the statement of list of statements on the path from the
+// function body to the operator goto or the label.
+type gotoFrame struct {
+	list *[]goast.Stmt
+	stmt goast.Stmt
+}
+
+// gotoJump is the operator goto and the path to it.
+type gotoJump struct {
+	branch *goast.BranchStmt
+	path   []gotoFrame
+}
+
+// gotoScope is the operators goto and the labels of function body.
+type gotoScope struct {
+	jumps  []gotoJump
+	labels map[string][]gotoFrame
+	// used labels by operators goto, break and continue
+	used map[string]bool
+
+	// generated labels before statements for operators goto into blocks
+	enters    map[gotoEnterKey]string
+	flags     map[string]bool
+	dispatchs map[gotoDispatchKey]bool
+}
+
+type gotoEnterKey struct {
+	stmt  goast.Stmt
+	label string
+}
+
+type gotoDispatchKey struct {
+	list  *[]goast.Stmt
+	label string
+}
+
+func newGotoScope(body *goast.BlockStmt) *gotoScope {
+	s := &gotoScope{
+		labels:    map[string][]gotoFrame{},
+		used:      map[string]bool{},
+		enters:    map[gotoEnterKey]string{},
+		flags:     map[string]bool{},
+		dispatchs: map[gotoDispatchKey]bool{},
+	}
+	s.walkList(&body.List, nil)
+	return s
+}
+
+func (s *gotoScope) walkList(list *[]goast.Stmt, path []gotoFrame) {
+	for _, stmt := range *list {
+		frame := append(path[:len(path):len(path)],
+			gotoFrame{list: list, stmt: stmt})
+		s.walkStmt(stmt, frame)
+	}
+}
+
+func (s *gotoScope) walkStmt(stmt goast.Stmt, path []gotoFrame) {
+	switch v := stmt.(type) {
+	case *goast.BranchStmt:
+		if v.Label == nil {
+			break
+		}
+		s.used[v.Label.Name] = true
+		if v.Tok == token.GOTO {
+			s.jumps = append(s.jumps, gotoJump{branch: v, path: path})
+		}
+	case *goast.LabeledStmt:
+		s.labels[v.Label.Name] = path
+		s.walkStmt(v.Stmt, path)
+	case *goast.BlockStmt:
+		s.walkList(&v.List, path)
+	case *goast.IfStmt:
+		s.walkList(&v.Body.List, path)
+		if v.Else != nil {
+			s.walkStmt(v.Else, path)
+		}
+	case *goast.ForStmt:
+		s.walkList(&v.Body.List, path)
+	case *goast.RangeStmt:
+		s.walkList(&v.Body.List, path)
+	case *goast.SwitchStmt:
+		s.walkStmt(v.Body, path)
+	case *goast.TypeSwitchStmt:
+		s.walkStmt(v.Body, path)
+	case *goast.SelectStmt:
+		s.walkStmt(v.Body, path)
+	case *goast.CaseClause:
+		s.walkList(&v.Body, path)
+	case *goast.CommClause:
+		s.walkList(&v.Body, path)
+	}
+}
+
+// commonDepth returns the index of frame of the deepest list of statements,
+// which contains both paths.
+func commonDepth(a, b []gotoFrame) (k int) {
+	for k+1 < len(a) && k+1 < len(b) &&
+		a[k].stmt == b[k].stmt && a[k+1].list == b[k+1].list {
+		k++
+	}
+	return
+}
+
+// unlabel returns the statement without labels.
+func unlabel(stmt goast.Stmt) goast.Stmt {
+	for {
+		l, ok := stmt.(*goast.LabeledStmt)
+		if !ok {
+			return stmt
+		}
+		stmt = l.Stmt
+	}
+}
+
+// indexOfStmt returns the index of statement in the list. The statement
+// may be labeled in the list.
+func indexOfStmt(list []goast.Stmt, stmt goast.Stmt) int {
+	for i, s := range list {
+		for {
+			if s == stmt {
+				return i
+			}
+			l, ok := s.(*goast.LabeledStmt)
+			if !ok {
+				break
+			}
+			s = l.Stmt
+		}
+	}
+	return -1
+}
+
+func insertStmts(list *[]goast.Stmt, index int, stmts ...goast.Stmt) {
+	result := make([]goast.Stmt, 0, len(*list)+len(stmts))
+	result = append(result, (*list)[:index]...)
+	result = append(result, stmts...)
+	*list = append(result, (*list)[index:]...)
+}
+
+// enterStep checks the way from the statement into the list of statements.
+func enterStep(stmt goast.Stmt, list *[]goast.Stmt) error {
+	switch v := unlabel(stmt).(type) {
+	case *goast.BlockStmt:
+		if &v.List == list {
+			return nil
+		}
+	case *goast.IfStmt:
+		for v != nil {
+			if v.Init != nil {
+				return fmt.Errorf("goto into IF with initialization " +
+					"is not supported")
+			}
+			if &v.Body.List == list {
+				return nil
+			}
+			switch e := v.Else.(type) {
+			case *goast.BlockStmt:
+				if &e.List == list {
+					return nil
+				}
+				v = nil
+			case *goast.IfStmt:
+				v = e
+			default:
+				v = nil
+			}
+		}
+	case *goast.ForStmt:
+		if &v.Body.List != list {
+			break
+		}
+		if a, ok := v.Init.(*goast.AssignStmt); ok && a.Tok == token.DEFINE {
+			return fmt.Errorf("goto into FOR with declaration " +
+				"is not supported")
+		}
+		return nil
+	default:
+		return fmt.Errorf("goto into %T is not supported", v)
+	}
+	return fmt.Errorf("cannot find the way of goto into %T", stmt)
+}
+
+// enterBlock replaces the operator goto into a block by the jump to the
+// label before the outer statement of that block. The flag forces the way
+// from the statement to the label of goto.
+func (s *gotoScope) enterBlock(body *goast.BlockStmt, jump gotoJump) error {
+	label := jump.branch.Label.Name
+	path, ok := s.labels[label]
+	if !ok {
+		return nil
+	}
+	k := commonDepth(jump.path, path)
+	if k == len(path)-1 {
+		// label is in the same block or in the outer block
+		return nil
+	}
+	for j := k; j < len(path)-1; j++ {
+		if err := enterStep(path[j].stmt, path[j+1].list); err != nil {
+			return fmt.Errorf("cannot transpile goto %s: %v", label, err)
+		}
+	}
+
+	flag := gotoFlagPrefix + label
+	if !s.flags[flag] {
+		s.flags[flag] = true
+		insertStmts(&body.List, 0, &goast.DeclStmt{Decl: &goast.GenDecl{
+			Tok: token.VAR,
+			Specs: []goast.Spec{&goast.ValueSpec{
+				Names: []*goast.Ident{goast.NewIdent(flag)},
+				Type:  goast.NewIdent("bool"),
+			}},
+		}})
+	}
+
+	var enter string
+	for j := len(path) - 2; j >= k; j-- {
+		target := label
+		if j+1 < len(path)-1 {
+			target = enter
+		}
+		enter = s.enterStmt(path[j], label, flag)
+		s.dispatch(path[j+1].list, label, flag, target, j+1 == len(path)-1)
+	}
+
+	// replace goto by the flag and the jump to the label before statement
+	setFlag := &goast.AssignStmt{
+		Lhs: []goast.Expr{goast.NewIdent(flag)},
+		Tok: token.ASSIGN,
+		Rhs: []goast.Expr{goast.NewIdent("true")},
+	}
+	last := jump.path[len(jump.path)-1]
+	jump.branch.Label = goast.NewIdent(enter)
+	if index := indexOfStmt(*last.list, jump.branch); (*last.list)[index] ==
+		jump.branch {
+		insertStmts(last.list, index, setFlag)
+	} else {
+		// labeled goto
+		l := (*last.list)[index].(*goast.LabeledStmt)
+		for l.Stmt != jump.branch {
+			l = l.Stmt.(*goast.LabeledStmt)
+		}
+		l.Stmt = &goast.BlockStmt{List: []goast.Stmt{setFlag, jump.branch}}
+	}
+	return nil
+}
+
+// enterStmt adds the label before the statement and the flag to the
+// conditions of statement, so the way into the block of label is chosen.
+// Returns the name of label before the statement.
+func (s *gotoScope) enterStmt(frame gotoFrame, label, flag string) string {
+	key := gotoEnterKey{stmt: frame.stmt, label: label}
+	if name, ok := s.enters[key]; ok {
+		return name
+	}
+	name := fmt.Sprintf("%s%s%d", gotoEnterPrefix, label, len(s.enters)+1)
+	s.enters[key] = name
+
+	index := indexOfStmt(*frame.list, frame.stmt)
+	switch v := unlabel(frame.stmt).(type) {
+	case *goast.IfStmt:
+		// condition of branch with the label is true and
+		// conditions of other branches are false
+		for v != nil {
+			if containsStmt(v.Body, s.labels[label]) {
+				v.Cond = gotoCond(flag, token.LOR, v.Cond)
+				break
+			}
+			v.Cond = gotoCond(flag, token.LAND, v.Cond)
+			v, _ = v.Else.(*goast.IfStmt)
+		}
+	case *goast.ForStmt:
+		if v.Cond != nil {
+			v.Cond = gotoCond(flag, token.LOR, v.Cond)
+		}
+		// initialization of loop is not executed by goto
+		if v.Init != nil {
+			insertStmts(frame.list, index, v.Init)
+			index++
+			v.Init = nil
+		}
+	}
+	(*frame.list)[index] = &goast.LabeledStmt{
+		Label: goast.NewIdent(name),
+		Stmt:  (*frame.list)[index],
+	}
+	return name
+}
+
+// containsStmt returns true, if the block is on the path.
+func containsStmt(block *goast.BlockStmt, path []gotoFrame) bool {
+	for _, frame := range path {
+		if frame.list == &block.List {
+			return true
+		}
+	}
+	return false
+}
+
+// gotoCond returns the condition with the flag of goto:
+//
+//     flag || (cond)
+//     !flag && (cond)
+//
+func gotoCond(flag string, op token.Token, cond goast.Expr) goast.Expr {
+	var x goast.Expr = goast.NewIdent(flag)
+	if op == token.LAND {
+		x = &goast.UnaryExpr{Op: token.NOT, X: x}
+	}
+	return &goast.BinaryExpr{X: x, Op: op, Y: &goast.ParenExpr{X: cond}}
+}
+
+// dispatch adds the jump to the target at the begin of list of statements,
+// if the flag of goto is set. Flag is reset before the jump to the label
+// of goto.
+func (s *gotoScope) dispatch(list *[]goast.Stmt, label, flag, target string,
+	last bool) {
+	key := gotoDispatchKey{list: list, label: label}
+	if s.dispatchs[key] {
+		return
+	}
+	s.dispatchs[key] = true
+
+	var stmts []goast.Stmt
+	if last {
+		stmts = append(stmts, &goast.AssignStmt{
+			Lhs: []goast.Expr{goast.NewIdent(flag)},
+			Tok: token.ASSIGN,
+			Rhs: []goast.Expr{goast.NewIdent("false")},
+		})
+	}
+	stmts = append(stmts, &goast.BranchStmt{
+		Tok:   token.GOTO,
+		Label: goast.NewIdent(target),
+	})
+	insertStmts(list, 0, &goast.IfStmt{
+		Cond: goast.NewIdent(flag),
+		Body: &goast.BlockStmt{List: stmts},
+	})
+}
+
+// hoistDeclarations moves declarations of variables, which are jumped over
+// by operators goto, to the begin of block. Declarations are replaced by
+// assignments of initial values.
+func (s *gotoScope) hoistDeclarations() (errs []error) {
+	var lists []*[]goast.Stmt
+	hoisted := map[*[]goast.Stmt]map[goast.Stmt]bool{}
+	for _, jump := range s.jumps {
+		path, ok := s.labels[jump.branch.Label.Name]
+		if !ok {
+			continue
+		}
+		k := commonDepth(jump.path, path)
+		if k != len(path)-1 {
+			continue
+		}
+		list := path[k].list
+		from := indexOfStmt(*list, jump.path[k].stmt)
+		to := indexOfStmt(*list, path[k].stmt)
+		for i := from + 1; i < to; i++ {
+			if !isVarDecl((*list)[i]) {
+				continue
+			}
+			if hoisted[list] == nil {
+				hoisted[list] = map[goast.Stmt]bool{}
+				lists = append(lists, list)
+			}
+			hoisted[list][(*list)[i]] = true
+		}
+	}
+	for _, list := range lists {
+		errs = append(errs, hoistVars(list, hoisted[list])...)
+	}
+	return
+}
+
+func isVarDecl(stmt goast.Stmt) bool {
+	d, ok := stmt.(*goast.DeclStmt)
+	if !ok {
+		return false
+	}
+	g, ok := d.Decl.(*goast.GenDecl)
+	return ok && g.Tok == token.VAR
+}
+
+func hoistVars(list *[]goast.Stmt, hoisted map[goast.Stmt]bool) (
+	errs []error) {
+	var decls, result []goast.Stmt
+	for i, stmt := range *list {
+		if !hoisted[stmt] {
+			result = append(result, stmt)
+			continue
+		}
+		specs := stmt.(*goast.DeclStmt).Decl.(*goast.GenDecl).Specs
+		if err := checkHoist(specs, (*list)[:i]); err != nil {
+			errs = append(errs, err)
+			result = append(result, stmt)
+			continue
+		}
+		d, assigns := splitVarDecl(specs)
+		decls = append(decls, d...)
+		result = append(result, assigns...)
+	}
+	*list = append(decls, result...)
+	return
+}
+
+// splitVarDecl splits the declaration of variables on the declarations
+// without initial values and the assignments of initial values.
+func splitVarDecl(specs []goast.Spec) (decls, assigns []goast.Stmt) {
+	for _, spec := range specs {
+		v := spec.(*goast.ValueSpec)
+		decls = append(decls, &goast.DeclStmt{Decl: &goast.GenDecl{
+			Tok: token.VAR,
+			Specs: []goast.Spec{&goast.ValueSpec{
+				Names: v.Names,
+				Type:  v.Type,
+			}},
+		}})
+		if len(v.Values) == 0 {
+			continue
+		}
+		var lhs []goast.Expr
+		for _, name := range v.Names {
+			lhs = append(lhs, goast.NewIdent(name.Name))
+		}
+		assigns = append(assigns, &goast.AssignStmt{
+			Lhs: lhs,
+			Tok: token.ASSIGN,
+			Rhs: v.Values,
+		})
+	}
+	return
+}
+
+// checkHoist returns error, if the declaration cannot be moved before the
+// statements. Type of variable must be known and the name of variable must
+// not be used by statements, because it may be variable of outer block.
+func checkHoist(specs []goast.Spec, stmts []goast.Stmt) error {
+	for _, spec := range specs {
+		v, ok := spec.(*goast.ValueSpec)
+		if !ok || v.Type == nil {
+			return fmt.Errorf("cannot move declaration without type")
+		}
+		for _, name := range v.Names {
+			if usesName(stmts, name.Name) {
+				return fmt.Errorf("cannot move declaration of `%s`, "+
+					"because the name is used before", name.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// usesName returns true, if the identifier is used in the statements.
+// Names of fields are not identifiers of variables.
+func usesName(stmts []goast.Stmt, name string) (found bool) {
+	var inspect func(goast.Node) bool
+	inspect = func(node goast.Node) bool {
+		switch v := node.(type) {
+		case *goast.Ident:
+			if v.Name == name {
+				found = true
+			}
+		case *goast.SelectorExpr:
+			goast.Inspect(v.X, inspect)
+			return false
+		}
+		return !found
+	}
+	for _, stmt := range stmts {
+		goast.Inspect(stmt, inspect)
+	}
+	return
+}
+
+// removeUnusedLabels removes the labels, which are not used by operators
+// goto, break and continue, because unused labels are not valid in Go.
+func (s *gotoScope) removeUnusedLabels() {
+	for label, path := range s.labels {
+		if !s.used[label] {
+			removeLabel(path[len(path)-1].list, label)
+		}
+	}
+}
+
+// removeLabel removes the label from the list of statements. Statement of
+// label is kept.
+func removeLabel(list *[]goast.Stmt, label string) {
+	for i, stmt := range *list {
+		var parent *goast.LabeledStmt
+		for l, ok := stmt.(*goast.LabeledStmt); ok; l, ok = l.Stmt.(*goast.LabeledStmt) {
+			if l.Label.Name != label {
+				parent = l
+				continue
+			}
+			_, empty := l.Stmt.(*goast.EmptyStmt)
+			switch {
+			case parent != nil:
+				parent.Stmt = l.Stmt
+			case empty:
+				*list = append((*list)[:i], (*list)[i+1:]...)
+			default:
+				(*list)[i] = l.Stmt
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package transpiler
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestGotoBody(t *testing.T) {
+	tcs := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "forward over declaration",
+			body: `
+	if n < 0 {
+		goto fail
+	}
+	var a int = n * 2
+	var b, c int = a, a
+	if b > 10 {
+		goto fail
+	}
+	return b + c
+fail:
+	return -1`,
+		},
+		{
+			name: "backward",
+			body: `
+	var i int = 0
+again:
+	i++
+	if i < n {
+		goto again
+	}
+	return i`,
+		},
+		{
+			name: "unused label",
+			body: `
+unused:
+	;
+	var sum int = n
+other:
+	sum++
+	return sum`,
+		},
+		{
+			name: "into loop",
+			body: `
+	var i int
+	var sum int
+	i = 0
+	goto inside
+	for i = 0; i < n; i++ {
+		sum += 10
+	inside:
+		sum += i
+	}
+	return sum`,
+		},
+		{
+			name: "into else",
+			body: `
+	var r int
+	if n > 5 {
+		r = 1
+		goto second
+	} else if n > 3 {
+		r = 2
+	} else {
+		var x int = 3
+		r += x
+	second:
+		r += 100
+	}
+	return r`,
+		},
+		{
+			name: "into nested blocks",
+			body: `
+	var r int
+	if n == 0 {
+		goto deep
+	}
+	for n > 0 {
+		n--
+		if n%2 == 0 {
+			var y int = n
+			r += y
+		deep:
+			r++
+		}
+	}
+	return r`,
+		},
+		{
+			name: "computed goto",
+			body: `
+	var next interface{} = 1
+	if n%2 == 0 {
+		next = 2
+	}
+	switch next {
+	case 1:
+		goto odd
+	case 2:
+		goto even
+	default:
+		panic("c4go: computed goto to unknown address")
+	}
+	var r int = 5
+	return r
+even:
+	return 0
+odd:
+	return 1`,
+		},
+		{
+			name: "function literal",
+			body: `
+	f := func() int {
+		goto end
+		var z int = 1
+		return z
+	end:
+		return 0
+	}
+	return f()`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			src := "package main\n\nfunc f(n int) int {" + tc.body + "\n}\n"
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := file.Decls[0].(*goast.FuncDecl).Body
+			p := program.NewProgram()
+			gotoBody(p, nil, body)
+			if m := p.GetMessages(); len(m) > 0 {
+				t.Errorf("unexpected warnings: %v", m)
+			}
+
+			var buf bytes.Buffer
+			if err = printer.Fprint(&buf, token.NewFileSet(), file); err != nil {
+				t.Fatal(err)
+			}
+			out := buf.String()
+			file, err = parser.ParseFile(fset, "", out, 0)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+			conf := types.Config{Importer: importer.Default()}
+			if _, err = conf.Check("main", fset, []*goast.File{file}, nil); err != nil {
+				t.Errorf("%v\n%s", err, out)
+			}
+			if strings.Contains(out, "unused") {
+				t.Errorf("unused label is not removed\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package transpiler
+
+import (
+	goast "go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// guardName is the name of reentrant mutex, which guards the access to
+// global variables from option "-guard".
+const guardName = "c4goGuard"
+
+// guardDecl returns the declaration of reentrant mutex:
+//
+//     var c4goGuard noarch.Guard
+//
+func guardDecl(p *program.Program) goast.Decl {
+	return &goast.GenDecl{
+		Tok: token.VAR,
+		Specs: []goast.Spec{
+			&goast.ValueSpec{
+				Names: []*goast.Ident{goast.NewIdent(guardName)},
+				Type: goast.NewIdent(p.ImportType(
+					"github.com/Konstantin8105/c4go/noarch.Guard")),
+			},
+		},
+	}
+}
+
+// guardBody guards the access to global variables from option "-guard" in
+// the function body. Each statement with access to the variable is placed
+// between lock and unlock of the reentrant mutex. Conditions of operators
+// IF and FOR are guarded by closures, so the mutex is not locked during
+// the loop. Example of C code:
+//
+//     counter++;
+//     while (counter < limit) {
+//         step();
+//     }
+//
+// Result:
+//
+//     c4goGuard.Lock()
+//     counter++
+//     c4goGuard.Unlock()
+//     for func() bool {
+//         c4goGuard.Lock()
+//         defer c4goGuard.Unlock()
+//         return counter < limit
+//     }() {
+//         step()
+//     }
+//
+func guardBody(p *program.Program, body *goast.BlockStmt) {
+	if body == nil || len(p.GuardedVariables) == 0 {
+		return
+	}
+	body.List = guardStmts(p, body.List)
+}
+
+func guardStmts(p *program.Program, stmts []goast.Stmt) (result []goast.Stmt) {
+	for _, s := range stmts {
+		result = append(result, guardStmt(p, s)...)
+	}
+	return
+}
+
+func guardStmt(p *program.Program, stmt goast.Stmt) []goast.Stmt {
+	switch s := stmt.(type) {
+	case *goast.BlockStmt:
+		s.List = guardStmts(p, s.List)
+
+	case *goast.LabeledStmt:
+		// label must be before the lock of mutex for operator goto
+		stmts := guardStmt(p, s.Stmt)
+		if len(stmts) == 1 {
+			s.Stmt = stmts[0]
+		} else {
+			s.Stmt = &goast.BlockStmt{List: stmts}
+		}
+
+	case *goast.CaseClause:
+		s.Body = guardStmts(p, s.Body)
+
+	case *goast.IfStmt:
+		if guardAccess(p, s.Cond) {
+			s.Cond = guardExpr(s.Cond)
+		}
+		guardStmt(p, s.Body)
+		if s.Else != nil {
+			guardStmt(p, s.Else)
+		}
+
+	case *goast.ForStmt:
+		if s.Init != nil && guardAccess(p, s.Init) {
+			s.Init = util.NewExprStmt(guardClosure(s.Init))
+		}
+		if s.Cond != nil && guardAccess(p, s.Cond) {
+			s.Cond = guardExpr(s.Cond)
+		}
+		if s.Post != nil && guardAccess(p, s.Post) {
+			s.Post = util.NewExprStmt(guardClosure(s.Post))
+		}
+		guardStmt(p, s.Body)
+
+	case *goast.RangeStmt:
+		guardStmt(p, s.Body)
+
+	case *goast.SwitchStmt:
+		guardStmt(p, s.Body)
+		if s.Tag == nil || !guardAccess(p, s.Tag) {
+			break
+		}
+		// value of switch is calculated before operator switch:
+		//
+		//     {
+		//         c4goGuard.Lock()
+		//         c4goGuard0 := counter
+		//         c4goGuard.Unlock()
+		//         switch c4goGuard0 {
+		//         ...
+		//     }
+		//
+		name := p.GetNextIdentifier(guardName)
+		value := &goast.AssignStmt{
+			Lhs: []goast.Expr{goast.NewIdent(name)},
+			Tok: token.DEFINE,
+			Rhs: []goast.Expr{s.Tag},
+		}
+		s.Tag = goast.NewIdent(name)
+		return []goast.Stmt{&goast.BlockStmt{List: []goast.Stmt{
+			util.NewExprStmt(guardCall("Lock")),
+			value,
+			util.NewExprStmt(guardCall("Unlock")),
+			s,
+		}}}
+
+	case *goast.ReturnStmt:
+		if guardAccess(p, s) {
+			// mutex is unlocked after calculation of return values
+			return []goast.Stmt{
+				util.NewExprStmt(guardCall("Lock")),
+				&goast.DeferStmt{Call: guardCall("Unlock")},
+				s,
+			}
+		}
+
+	default:
+		if guardAccess(p, s) {
+			return []goast.Stmt{
+				util.NewExprStmt(guardCall("Lock")),
+				s,
+				util.NewExprStmt(guardCall("Unlock")),
+			}
+		}
+	}
+	return []goast.Stmt{stmt}
+}
+
+// guardCall returns the call of method of reentrant mutex, for example:
+//
+//     c4goGuard.Lock()
+//
+func guardCall(method string) *goast.CallExpr {
+	return &goast.CallExpr{
+		Fun: &goast.SelectorExpr{
+			X:   goast.NewIdent(guardName),
+			Sel: goast.NewIdent(method),
+		},
+	}
+}
+
+// guardExpr returns the closure with guarded calculation of condition.
+func guardExpr(cond goast.Expr) goast.Expr {
+	return util.NewFuncClosure("bool",
+		util.NewExprStmt(guardCall("Lock")),
+		&goast.DeferStmt{Call: guardCall("Unlock")},
+		&goast.ReturnStmt{Results: []goast.Expr{cond}})
+}
+
+// guardClosure returns the closure with guarded statement.
+func guardClosure(stmt goast.Stmt) *goast.CallExpr {
+	return util.NewFuncClosure("",
+		util.NewExprStmt(guardCall("Lock")),
+		&goast.DeferStmt{Call: guardCall("Unlock")},
+		stmt)
+}
+
+// guardAccess returns true, if the node has the access to global variables
+// from option "-guard". Names of struct fields are not checked.
+func guardAccess(p *program.Program, node goast.Node) (found bool) {
+	goast.Inspect(node, func(n goast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *goast.SelectorExpr:
+			found = guardAccess(p, n.X)
+			return false
+		case *goast.KeyValueExpr:
+			if _, ok := n.Key.(*goast.Ident); !ok {
+				found = guardAccess(p, n.Key)
+			}
+			found = found || guardAccess(p, n.Value)
+			return false
+		case *goast.Ident:
+			found = guardIdent(p, n.Name)
+		}
+		return true
+	})
+	return
+}
+
+// guardIdent returns true, if the Go identifier is the global variable from
+// option "-guard". The identifier may be an expression, for example:
+// "counter[0]".
+func guardIdent(p *program.Program, name string) bool {
+	for variable := range p.GuardedVariables {
+		if util.IsGoKeyword(variable) {
+			variable += "_"
+		}
+		if !strings.HasPrefix(name, variable) {
+			continue
+		}
+		if len(name) == len(variable) ||
+			strings.ContainsRune(".[(", rune(name[len(variable)])) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package transpiler
+
+import (
+	"github.com/Konstantin8105/c4go/ast"
+)
+
+// Kinds of function definition by rules of linkage of C99 and GNU C. Only
+// one Go function is emitted for all C definitions with the same name.
+const (
+	// inline definition of `extern inline` function with attribute
+	// gnu_inline. That definition is never an external definition.
+	inlineOnlyGNU = iota
+
+	// inline definition of C99 `inline` function. That definition is an
+	// external definition only if the same translation unit has an
+	// external declaration of the function.
+	inlineOnly
+
+	// external definition: function without `inline`, function with
+	// `extern inline` in C99 or `inline` function with gnu_inline.
+	externalDefinition
+)
+
+// inlineKind returns the kind of function definition by rules of C99 and
+// GNU C inline functions.
+func inlineKind(n *ast.FunctionDecl) int {
+	if !n.IsInline || n.IsStatic {
+		return externalDefinition
+	}
+	var isGNU bool
+	for _, ch := range n.Children() {
+		if _, ok := ch.(*ast.GNUInlineAttr); ok {
+			isGNU = true
+			break
+		}
+	}
+	switch {
+	case isGNU && n.IsExtern:
+		return inlineOnlyGNU
+	case isGNU || n.IsExtern:
+		return externalDefinition
+	}
+	return inlineOnly
+}
+
+// resolveInlineDefinitions returns definitions of functions that must not be
+// transpiled. In project mode the translation units are merged, so the same
+// function may have several definitions:
+//
+//     // util.h
+//     extern inline __attribute__((gnu_inline)) int twice(int a) { ... }
+//     // util.c
+//     int twice(int a) { ... }
+//
+// In C the linker chooses the external definition and inline definitions
+// are used only for inlining. For each function the most external
+// definition is transpiled, so all references in Go code bind to the
+// single Go function.
+func resolveInlineDefinitions(decls []ast.Node) (skip map[*ast.FunctionDecl]bool) {
+	skip = map[*ast.FunctionDecl]bool{}
+	chosen := map[string]*ast.FunctionDecl{}
+	for _, decl := range decls {
+		fd, ok := decl.(*ast.FunctionDecl)
+		if !ok || getFunctionBody(fd) == nil {
+			continue
+		}
+		prev, ok := chosen[fd.Name]
+		if !ok {
+			chosen[fd.Name] = fd
+			continue
+		}
+		if inlineKind(fd) > inlineKind(prev) {
+			skip[prev] = true
+			chosen[fd.Name] = fd
+		} else {
+			skip[fd] = true
+		}
+	}
+	return
+}
@@ -9,6 +9,9 @@ import (
 	"go/token"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	goast "go/ast"
 
@@ -20,10 +23,77 @@ import (
 	"github.com/Konstantin8105/c4go/util"
 )
 
-func transpileFloatingLiteral(n *ast.FloatingLiteral) *goast.BasicLit {
+// limitConstants are constants of package noarch for literals of macros of
+// "limits.h" and "float.h". Macros are expanded by preprocessor, so the
+// literal is found by C type and value. Limits of types "long" and
+// "long double" in C are not limits of Go types, for example:
+//
+//     C  : long l = LONG_MAX;
+//     Go : var l int32 = noarch.LongMax
+//
+var limitConstants = map[string]string{
+	// limits.h
+	"int 2147483647":                          "IntMax",
+	"unsigned int 4294967295":                 "UintMax",
+	"long 9223372036854775807":                "LongMax",
+	"unsigned long 18446744073709551615":      "UlongMax",
+	"long long 9223372036854775807":           "LlongMax",
+	"unsigned long long 18446744073709551615": "UllongMax",
+
+	// float.h
+	"float 3.4028235e+38":                "FltMax",
+	"float 1.1754944e-38":                "FltMin",
+	"float 1.1920929e-07":                "FltEpsilon",
+	"double 1.7976931348623157e+308":     "DblMax",
+	"double 2.2250738585072014e-308":     "DblMin",
+	"double 2.220446049250313e-16":       "DblEpsilon",
+	"long double +Inf":                   "LdblMax",
+	"long double 1.0842021724855044e-19": "LdblEpsilon",
+}
+
+// limitConstant returns the constant of package noarch for the literal of
+// macro of "limits.h" or "float.h".
+func limitConstant(p *program.Program, cType, value string) (goast.Expr, bool) {
+	name, ok := limitConstants[cType+" "+value]
+	if !ok {
+		return nil, false
+	}
+	return goast.NewIdent(
+		p.ImportType("github.com/Konstantin8105/c4go/noarch." + name)), true
+}
+
+func transpileFloatingLiteral(n *ast.FloatingLiteral, p *program.Program) goast.Expr {
+	value := strconv.FormatFloat(n.Value, 'g', -1, 64)
+	if n.Type == "float" {
+		value = strconv.FormatFloat(float64(float32(n.Value)), 'g', -1, 32)
+	}
+	if c, ok := limitConstant(p, n.Type, value); ok {
+		return c
+	}
 	return util.NewFloatLit(n.Value)
 }
 
+// transpileImaginaryLiteral transpiles the imaginary literal of "complex.h"
+// to the complex number with zero real part, for example:
+//
+//     C  : 2.0i
+//     Go : complex(0, 2)
+//
+func transpileImaginaryLiteral(n *ast.ImaginaryLiteral, p *program.Program) (
+	_ goast.Expr, exprType string, preStmts, postStmts []goast.Stmt, err error) {
+	if len(n.Children()) != 1 {
+		err = fmt.Errorf("imaginary literal must have one child, but have %d",
+			len(n.Children()))
+		return
+	}
+	value, _, preStmts, postStmts, err := transpileToExpr(n.Children()[0], p, false)
+	if err != nil {
+		return
+	}
+	return util.NewCallExpr("complex", util.NewIntLit(0), value),
+		n.Type, preStmts, postStmts, nil
+}
+
 var regexpUnsigned *regexp.Regexp
 var regexpLongDouble *regexp.Regexp
 
@@ -54,13 +124,14 @@ func ConvertToGoFlagFormat(str string) string {
 func transpileStringLiteral(p *program.Program, n *ast.StringLiteral, arrayToArray bool) (
 	expr goast.Expr, exprType string, err error) {
 
-	// Convert format flags
-	n.Value = ConvertToGoFlagFormat(n.Value)
+	if n.Prefix != "" && n.Prefix != "u8" {
+		return transpileWideStringLiteral(p, n, arrayToArray)
+	}
 
 	// Example:
 	// StringLiteral 0x280b918 <col:29> 'char [30]' lvalue "%0"
 	baseType := types.GetBaseType(n.Type)
-	if baseType != "char" {
+	if baseType != "char" && baseType != "unsigned char" {
 		err = fmt.Errorf("Type is not `char` : %v", n.Type)
 		p.AddMessage(p.GenerateWarningMessage(err, n))
 		return
@@ -90,53 +161,188 @@ func transpileStringLiteral(p *program.Program, n *ast.StringLiteral, arrayToArr
 	// 	return b
 	// }()}
 	expr = goast.NewIdent(fmt.Sprintf(
-		"func() (b [%v]byte) { copy(b[:],\"%s\" );return }()",
-		s, n.Value))
+		"func() (b [%v]byte) { copy(b[:],%s );return }()",
+		s, strconv.Quote(n.Value)))
 	exprType = n.Type
 	return
 }
 
-func transpileIntegerLiteral(n *ast.IntegerLiteral) *goast.BasicLit {
+// wideCharTypes - types of characters of wide string literals by prefix
+var wideCharTypes = map[string]string{
+	"L": "wchar_t",
+	"u": "char16_t",
+	"U": "char32_t",
+}
+
+// transpileWideStringLiteral transpiles the string literals with prefixes
+// "L", "u" and "U" to slices of characters. Characters of literal with
+// prefix "u" are UTF-16 code units:
+//
+//     L"ab"  ->  []int{'a', 'b', 0}
+//     u"\U0001F600"  ->  []uint16{55357, 56832, 0}
+//
+func transpileWideStringLiteral(p *program.Program, n *ast.StringLiteral,
+	arrayToArray bool) (expr goast.Expr, exprType string, err error) {
+	elementType, size := types.GetArrayTypeAndSize(n.Type)
+	// characters of literals have types of typedefs of standard headers,
+	// if typedef is declared
+	if t, ok := wideCharTypes[n.Prefix]; ok {
+		if base, ok := p.GetBaseTypeOfTypedef(t); ok && base == elementType {
+			elementType = t
+		}
+	}
+	goType, err := types.ResolveType(p, elementType)
+	if err != nil {
+		return
+	}
+
+	var units []rune
+	if n.Prefix == "u" {
+		for _, u := range utf16.Encode([]rune(n.Value)) {
+			units = append(units, rune(u))
+		}
+	} else {
+		units = []rune(n.Value)
+	}
+	units = append(units, 0)
+	for len(units) < size {
+		units = append(units, 0)
+	}
+
+	lit := &goast.CompositeLit{}
+	for _, u := range units {
+		if u < utf8.RuneSelf && unicode.IsPrint(u) {
+			lit.Elts = append(lit.Elts, &goast.BasicLit{
+				Kind:  token.CHAR,
+				Value: strconv.QuoteRune(u),
+			})
+			continue
+		}
+		lit.Elts = append(lit.Elts, util.NewIntLit(int(u)))
+	}
+
+	if arrayToArray {
+		lit.Type = &goast.ArrayType{
+			Len: util.NewIntLit(len(units)),
+			Elt: goast.NewIdent(goType),
+		}
+		return lit, n.Type, nil
+	}
+	lit.Type = &goast.ArrayType{Elt: goast.NewIdent(goType)}
+	return lit, elementType + " *", nil
+}
+
+func transpileIntegerLiteral(n *ast.IntegerLiteral, p *program.Program) goast.Expr {
+	if c, ok := limitConstant(p, n.Type, n.Value); ok {
+		return c
+	}
 	return &goast.BasicLit{
 		Kind:  token.INT,
 		Value: n.Value,
 	}
 }
 
+// transpileCharacterLiteral transpiles the character literal to rune
+// literal of Go. Multi-character constants and characters, which are not
+// valid runes, are integer literals:
+//
+//     'a'     ->  'a'
+//     'ABCD'  ->  1094861636
+//     '\xff'  ->  -1
+//
 func transpileCharacterLiteral(n *ast.CharacterLiteral) *goast.BasicLit {
+	if n.Value < 0 || !utf8.ValidRune(rune(n.Value)) {
+		return &goast.BasicLit{
+			Kind:  token.INT,
+			Value: strconv.Itoa(n.Value),
+		}
+	}
 	return &goast.BasicLit{
 		Kind:  token.CHAR,
-		Value: fmt.Sprintf("%q", n.Value),
+		Value: fmt.Sprintf("%q", rune(n.Value)),
 	}
 }
 
+// transpileBoolLiteral transpiles the keywords `true` and `false` of C23,
+// which are the same in Go.
+func transpileBoolLiteral(n *ast.CXXBoolLiteralExpr) *goast.Ident {
+	return goast.NewIdent(fmt.Sprintf("%v", n.Value))
+}
+
 func transpilePredefinedExpr(n *ast.PredefinedExpr, p *program.Program) (goast.Expr, string, error) {
 	// A predefined expression is a literal that is not given a value until
-	// compile time.
+	// compile time. Clang shows the value as a child node, for example:
+	//
+	//     PredefinedExpr 0x2a0e0f8 <col:10> 'const char [25]' lvalue __PRETTY_FUNCTION__
+	//     `-StringLiteral 0x2a0e0d8 <col:10> 'const char [25]' lvalue "void print_number(int *)"
 	//
-	// TODO: Predefined expressions are not evaluated
-	// https://github.com/Konstantin8105/c4go/issues/81
+	var value string
+	if len(n.Children()) > 0 {
+		if str, ok := n.Children()[0].(*ast.StringLiteral); ok {
+			value = str.Value
+		}
+	}
 
 	switch n.Name {
 	case "__PRETTY_FUNCTION__":
-		return util.NewCallExpr(
-			"[]byte",
-			util.NewStringLit(`"void print_number(int *)"`),
-		), "const char*", nil
+		if value == "" {
+			value = prettyFunction(p.Function)
+		}
 
-	case "__func__":
-		return util.NewCallExpr(
-			"[]byte",
-			util.NewStringLit(strconv.Quote(p.Function.Name)),
-		), "const char*", nil
+	case "__func__", "__FUNCTION__":
+		if value == "" {
+			value = p.Function.Name
+		}
 
 	default:
 		// There are many more.
 		panic(fmt.Sprintf("unknown PredefinedExpr: %s", n.Name))
 	}
+
+	return util.NewCallExpr(
+		"[]byte",
+		util.NewStringLit(strconv.Quote(value)),
+	), "const char*", nil
 }
 
+// prettyFunction returns the signature of function as in clang, for example:
+// "char *f(int, double *)".
+func prettyFunction(f *ast.FunctionDecl) string {
+	if f == nil {
+		return ""
+	}
+	index := strings.Index(f.Type, "(")
+	if index < 0 {
+		return f.Name
+	}
+	returnType := strings.TrimSpace(f.Type[:index])
+	if !strings.HasSuffix(returnType, "*") {
+		returnType += " "
+	}
+	return returnType + f.Name + f.Type[index:]
+}
+
+// transpileCompoundLiteralExpr transpiles the compound literal of C99 to
+// the composite literal of Go, for example:
+//
+//     (struct point){.x = 1, .y = 2}  ->  point{x: 1, y: 2}
+//     (int[]){1, 2, 3}                ->  []int{1, 2, 3}
+//     (int){5}                        ->  int(5)
+//
+// The address of compound literal is transpiled in
+// transpileUnaryOperatorAmpersant.
 func transpileCompoundLiteralExpr(n *ast.CompoundLiteralExpr, p *program.Program) (goast.Expr, string, error) {
+	// initialization list of scalar type has only one value
+	if il, ok := n.Children()[0].(*ast.InitListExpr); ok &&
+		len(il.Children()) == 1 && (types.IsCInteger(p, n.Type1) ||
+		types.IsCFloat(p, n.Type1) || types.IsCPointer(n.Type1)) {
+		expr, t, _, _, err := transpileToExpr(il.Children()[0], p, false)
+		if err != nil {
+			return nil, "", err
+		}
+		expr, err = types.CastExpr(p, expr, t, n.Type1)
+		return expr, n.Type1, err
+	}
 	expr, t, _, _, err := transpileToExpr(n.Children()[0], p, false)
 	return expr, t, err
 }
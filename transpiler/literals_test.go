@@ -2,6 +2,7 @@ package transpiler
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"unicode/utf8"
@@ -10,6 +11,7 @@ import (
 	"go/token"
 
 	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
 )
 
 var chartests = []struct {
@@ -58,6 +60,25 @@ func TestCharacterLiterals(t *testing.T) {
 	}
 }
 
+func TestIntegerCharacterLiterals(t *testing.T) {
+	for _, tt := range []struct {
+		in  int
+		out string
+	}{
+		{-1, "-1"},
+		{-128, "-128"},
+		{1094861636, "1094861636"}, // 'ABCD'
+	} {
+		expected := &goast.BasicLit{Kind: token.INT, Value: tt.out}
+		actual := transpileCharacterLiteral(&ast.CharacterLiteral{Value: tt.in})
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("input: %v", tt.in)
+			t.Errorf("  expected: %v", expected)
+			t.Errorf("  actual:   %v", actual)
+		}
+	}
+}
+
 func TestFormatFlag(t *testing.T) {
 	tcs := []struct {
 		in, out string
@@ -125,3 +146,42 @@ func TestFormatFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestLimitConstants(t *testing.T) {
+	tcs := []struct {
+		node ast.Node
+		out  string
+	}{
+		{&ast.IntegerLiteral{Type: "int", Value: "2147483647"}, "noarch.IntMax"},
+		{&ast.IntegerLiteral{Type: "long", Value: "9223372036854775807"}, "noarch.LongMax"},
+		{&ast.IntegerLiteral{Type: "unsigned long long", Value: "18446744073709551615"}, "noarch.UllongMax"},
+		{&ast.IntegerLiteral{Type: "int", Value: "42"}, "42"},
+		{&ast.FloatingLiteral{Type: "float", Value: 3.40282347e+38}, "noarch.FltMax"},
+		{&ast.FloatingLiteral{Type: "float", Value: 1.19209290e-7}, "noarch.FltEpsilon"},
+		{&ast.FloatingLiteral{Type: "double", Value: 2.2204460492503131e-16}, "noarch.DblEpsilon"},
+		{&ast.FloatingLiteral{Type: "long double", Value: math.Inf(1)}, "noarch.LdblMax"},
+		{&ast.FloatingLiteral{Type: "double", Value: 1.5}, "1.5"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.out, func(t *testing.T) {
+			p := program.NewProgram()
+			var expr goast.Expr
+			switch n := tc.node.(type) {
+			case *ast.IntegerLiteral:
+				expr = transpileIntegerLiteral(n, p)
+			case *ast.FloatingLiteral:
+				expr = transpileFloatingLiteral(n, p)
+			}
+			var out string
+			switch e := expr.(type) {
+			case *goast.Ident:
+				out = e.Name
+			case *goast.BasicLit:
+				out = e.Value
+			}
+			if out != tc.out {
+				t.Errorf("Not same '%v' != '%v'", out, tc.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package transpiler
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/preprocessor"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// macroConstDecl returns the declaration of Go constants for object-like
+// macros of the user source, which are constant expressions:
+//
+//     #define MAX_USERS 64
+//     #define VERSION   "1.2"
+//     #define LIMIT     (MAX_USERS * 2UL)
+//
+// Result:
+//
+//     const (
+//         MAX_USERS = 64
+//         VERSION   = "1.2"
+//         LIMIT     = (MAX_USERS * 2)
+//     )
+//
+// Constants are untyped as the macros. Macros with casts, calls of
+// functions, comparisons or names of Go code are not constants.
+func macroConstDecl(p *program.Program) goast.Decl {
+	macros := p.PreprocessorFile.GetMacros()
+	if len(macros) == 0 {
+		return nil
+	}
+
+	// names of Go code cannot be names of constants
+	used := goNames(p)
+
+	decl := &goast.GenDecl{Tok: token.CONST, Lparen: 1}
+	decl.Specs = macroConstSpecs(macros, used)
+	if len(decl.Specs) == 0 {
+		return nil
+	}
+	return decl
+}
+
+// goNames returns names of Go declarations, imports and predeclared
+// identifiers of Go.
+func goNames(p *program.Program) map[string]bool {
+	used := map[string]bool{}
+	for _, name := range goPredeclared {
+		used[name] = true
+	}
+	for _, quotedImportPath := range p.Imports() {
+		name := p.ImportName(quotedImportPath)
+		if name == "" {
+			name = path.Base(strings.Trim(quotedImportPath, "\""))
+		}
+		used[name] = true
+	}
+	for _, decl := range p.File.Decls {
+		switch d := decl.(type) {
+		case *goast.FuncDecl:
+			if d.Recv == nil {
+				used[d.Name.Name] = true
+			}
+		case *goast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *goast.ValueSpec:
+					for _, name := range s.Names {
+						used[name.Name] = true
+					}
+				case *goast.TypeSpec:
+					used[s.Name.Name] = true
+				}
+			}
+		}
+	}
+	return used
+}
+
+// macroConstSpecs returns the Go constants of macros, which are constant
+// expressions. Names of map used are not names of constants.
+func macroConstSpecs(macros []preprocessor.Macro, used map[string]bool) (
+	specs []goast.Spec) {
+	exprs := map[string]goast.Expr{}
+	for _, m := range macros {
+		if used[m.Name] || token.IsKeyword(m.Name) {
+			continue
+		}
+		if expr, ok := macroExpr(m.Value); ok {
+			exprs[m.Name] = expr
+		}
+	}
+
+	// Macros may use other macros, so constants are evaluated while new
+	// constants are found.
+	pkg := types.NewPackage("macro", "macro")
+	fset := token.NewFileSet()
+	for found := true; found; {
+		found = false
+		for name, expr := range exprs {
+			if pkg.Scope().Lookup(name) != nil || !isMacroConstExpr(pkg, expr) {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, fset, expr); err != nil {
+				continue
+			}
+			tv, err := types.Eval(fset, pkg, token.NoPos, buf.String())
+			if err != nil || tv.Value == nil {
+				continue
+			}
+			if basic, ok := tv.Type.(*types.Basic); !ok ||
+				basic.Info()&(types.IsNumeric|types.IsString) == 0 {
+				// comparisons are int in C, but bool in Go
+				continue
+			}
+			pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, name, tv.Type, tv.Value))
+			found = true
+		}
+	}
+
+	for _, m := range macros {
+		if pkg.Scope().Lookup(m.Name) == nil {
+			continue
+		}
+		if _, ok := exprs[m.Name]; !ok {
+			continue
+		}
+		specs = append(specs, &goast.ValueSpec{
+			Names:  []*goast.Ident{goast.NewIdent(m.Name)},
+			Values: []goast.Expr{exprs[m.Name]},
+		})
+		// constant is declared only once
+		delete(exprs, m.Name)
+	}
+	return
+}
+
+// macroExpr returns the Go expression of the value of macro. Suffixes of C
+// literals are removed and operator `~` is replaced by `^`. Unsigned values
+// of C are wrapped, like `-1U`, but Go constants are not wrapped, so such
+// macros are not constants.
+func macroExpr(value string) (goast.Expr, bool) {
+	if util.GetRegex(`\b(0[xX][0-9a-fA-F]+|[0-9]+)[lL]*[uU]`).MatchString(value) &&
+		strings.ContainsAny(value, "-~") {
+		return nil, false
+	}
+	isWord := func(c byte) bool {
+		return c == '_' || c == '.' || ('a' <= c && c <= 'z') ||
+			('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+	}
+	var buf strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"' || c == '\'':
+			start := i
+			for i++; i < len(value) && value[i] != c; i++ {
+				if value[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(value) {
+				return nil, false
+			}
+			buf.WriteString(value[start : i+1])
+		case ('0' <= c && c <= '9') || (c == '.' && i+1 < len(value) &&
+			'0' <= value[i+1] && value[i+1] <= '9'):
+			start := i
+			for i+1 < len(value) && isWord(value[i+1]) {
+				i++
+				// sign of exponent, like `1e-5`
+				if (value[i] == 'e' || value[i] == 'E') && i+1 < len(value) &&
+					(value[i+1] == '+' || value[i+1] == '-') &&
+					!strings.HasPrefix(strings.ToLower(value[start:]), "0x") {
+					i++
+				}
+			}
+			literal, ok := cNumberToGo(value[start : i+1])
+			if !ok {
+				return nil, false
+			}
+			buf.WriteString(literal)
+		case isWord(c):
+			// identifier
+			start := i
+			for i+1 < len(value) && isWord(value[i+1]) {
+				i++
+			}
+			buf.WriteString(value[start : i+1])
+		case c == '~':
+			buf.WriteByte('^')
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	expr, err := parser.ParseExpr(buf.String())
+	if err != nil {
+		return nil, false
+	}
+	return expr, true
+}
+
+// cNumberToGo returns Go literal of C integer or floating literal without
+// suffixes, like `64UL` or `1.5f`.
+func cNumberToGo(literal string) (string, bool) {
+	lower := strings.ToLower(literal)
+	isHex := strings.HasPrefix(lower, "0x")
+	if strings.ContainsAny(lower, ".p") || !isHex && strings.Contains(lower, "e") {
+		literal = strings.TrimRight(literal, "fFlL")
+		_, err := strconv.ParseFloat(literal, 64)
+		return literal, err == nil
+	}
+	literal = strings.TrimRight(literal, "uUlL")
+	_, err := strconv.ParseUint(literal, 0, 64)
+	return literal, err == nil
+}
+
+// isMacroConstExpr returns true for expression with literals, operators
+// and names of constants of package.
+func isMacroConstExpr(pkg *types.Package, expr goast.Expr) bool {
+	ok := true
+	goast.Inspect(expr, func(n goast.Node) bool {
+		switch n := n.(type) {
+		case nil, *goast.BasicLit, *goast.ParenExpr, *goast.UnaryExpr,
+			*goast.BinaryExpr:
+		case *goast.Ident:
+			if pkg.Scope().Lookup(n.Name) == nil {
+				ok = false
+			}
+		default:
+			ok = false
+		}
+		return ok
+	})
+	return ok
+}
+
+// goPredeclared - predeclared identifiers of Go.
+var goPredeclared = []string{
+	"any", "append", "bool", "byte", "cap", "clear", "close", "comparable",
+	"complex", "complex64", "complex128", "copy", "delete", "error", "false",
+	"float32", "float64", "imag", "int", "int8", "int16", "int32", "int64",
+	"iota", "len", "make", "max", "min", "new", "nil", "panic", "print",
+	"println", "real", "recover", "rune", "string", "true", "uint", "uint8",
+	"uint16", "uint32", "uint64", "uintptr", "init", "main",
+}
@@ -0,0 +1,442 @@
+package transpiler
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/constant"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	gotypes "go/types"
+	"reflect"
+	"sort"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/preprocessor"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+// transpileMacroCall returns the call of Go function of function-like macro,
+// if the expression is the expansion of simple macro with arguments of the
+// type of expression:
+//
+//     #define SQR(x) ((x)*(x))
+//     b = SQR(a + 1);
+//
+// Result:
+//
+//     b = SQR(a + 1)
+//
+// Function of macro with several types is generic for Go 1.18 and later,
+// otherwise expressions with other types are not changed.
+func transpileMacroCall(p *program.Program, node ast.Node) (
+	expr goast.Expr, exprType string, preStmts, postStmts []goast.Stmt,
+	ok bool) {
+	if !p.FunctionMacros || p.Function == nil {
+		return
+	}
+	pos := node.Position()
+	for _, m := range p.PreprocessorFile.GetFunctionMacros() {
+		if !m.IsUsed(pos.File, pos.Line) {
+			continue
+		}
+		expr, exprType, preStmts, postStmts, ok = macroCall(p, m, node)
+		if ok {
+			return
+		}
+	}
+	return
+}
+
+// macroCall returns the call of Go function of macro, if the expression is
+// the expansion of macro.
+func macroCall(p *program.Program, m preprocessor.FunctionMacro, node ast.Node) (
+	expr goast.Expr, exprType string, preStmts, postStmts []goast.Stmt,
+	ok bool) {
+	body, uses, ok := macroFunctionBody(m)
+	if !ok {
+		return
+	}
+	args := map[string]ast.Node{}
+	if !matchMacro(body, node, args, true) {
+		return
+	}
+
+	// arguments are converted to the type of result, so all parameters of
+	// function have one type
+	exprType = nodeType(node)
+	for _, param := range m.Params {
+		if nodeType(args[param]) != exprType {
+			return nil, "", nil, nil, false
+		}
+		// arguments of function are calculated only once
+		if uses[param] > 1 && hasSideEffects(args[param]) {
+			return nil, "", nil, nil, false
+		}
+	}
+	goType, err := types.ResolveType(p, exprType)
+	if err != nil || !isMacroFunctionType(goType) {
+		return nil, "", nil, nil, false
+	}
+
+	f, found := p.MacroFunctions[m.Name]
+	if !found {
+		f = &program.MacroFunction{
+			Macro: m,
+			Calls: map[*goast.CallExpr]string{},
+		}
+	}
+	isNewType := true
+	for _, t := range f.Types {
+		if t == goType {
+			isNewType = false
+		}
+	}
+	if isNewType {
+		if len(f.Types) > 0 && !p.IsGoVersion(18) {
+			// generics are added in Go 1.18
+			return nil, "", nil, nil, false
+		}
+		if !isValidMacroFunction(m, body, goType) {
+			return nil, "", nil, nil, false
+		}
+	}
+
+	call := &goast.CallExpr{Fun: goast.NewIdent(m.Name)}
+	for _, param := range m.Params {
+		arg, argType, newPre, newPost, err := transpileToExpr(args[param], p, false)
+		if err != nil {
+			return nil, "", nil, nil, false
+		}
+		arg, err = types.CastExpr(p, arg, argType, exprType)
+		if err != nil {
+			return nil, "", nil, nil, false
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		call.Args = append(call.Args, unparen(arg))
+	}
+
+	if isNewType {
+		f.Types = append(f.Types, goType)
+	}
+	f.Calls[call] = goType
+	p.MacroFunctions[m.Name] = f
+	return call, exprType, preStmts, postStmts, true
+}
+
+// macroFunctionBody returns the Go expression of body of macro and amount
+// of uses of parameters in the body. Body is simple, if it has only
+// parameters, numbers and arithmetic operators.
+func macroFunctionBody(m preprocessor.FunctionMacro) (
+	body goast.Expr, uses map[string]int, ok bool) {
+	body, ok = macroExpr(m.Value)
+	if !ok {
+		return
+	}
+	uses = map[string]int{}
+	for _, param := range m.Params {
+		if token.IsKeyword(param) {
+			return nil, nil, false
+		}
+		uses[param] = 0
+	}
+	goast.Inspect(body, func(n goast.Node) bool {
+		switch n := n.(type) {
+		case nil, *goast.ParenExpr:
+		case *goast.Ident:
+			if _, found := uses[n.Name]; !found {
+				ok = false
+			}
+			uses[n.Name]++
+		case *goast.BasicLit:
+			if n.Kind != token.INT && n.Kind != token.FLOAT {
+				ok = false
+			}
+		case *goast.UnaryExpr:
+			switch n.Op {
+			case token.ADD, token.SUB, token.XOR:
+			default:
+				ok = false
+			}
+		case *goast.BinaryExpr:
+			switch n.Op {
+			case token.ADD, token.SUB, token.MUL, token.QUO, token.REM,
+				token.AND, token.OR, token.XOR, token.SHL, token.SHR:
+			default:
+				// comparisons are int in C, but bool in Go
+				ok = false
+			}
+		default:
+			ok = false
+		}
+		return ok
+	})
+	for _, param := range m.Params {
+		if uses[param] == 0 {
+			// arguments of unused parameters are not calculated
+			ok = false
+		}
+	}
+	if _, isParam := unparenExpr(body).(*goast.Ident); isParam {
+		ok = false
+	}
+	if !ok {
+		return nil, nil, false
+	}
+	return
+}
+
+// matchMacro returns true, if the node is the expansion of the body of
+// macro. Arguments of macro are added in the map, where key is name of
+// parameter. Implicit casts of clang are ignored, except the casts of
+// arguments.
+func matchMacro(body goast.Expr, node ast.Node, args map[string]ast.Node,
+	isTop bool) bool {
+	if param, ok := unparenExpr(body).(*goast.Ident); ok {
+		if arg, ok := args[param.Name]; ok {
+			return macroNodeKey(arg) == macroNodeKey(node)
+		}
+		args[param.Name] = node
+		return true
+	}
+	if !isTop {
+		for {
+			cast, ok := node.(*ast.ImplicitCastExpr)
+			if !ok || len(cast.Children()) != 1 {
+				break
+			}
+			node = cast.Children()[0]
+		}
+	}
+	switch b := body.(type) {
+	case *goast.ParenExpr:
+		n, ok := node.(*ast.ParenExpr)
+		return ok && len(n.Children()) == 1 &&
+			matchMacro(b.X, n.Children()[0], args, false)
+
+	case *goast.BinaryExpr:
+		n, ok := node.(*ast.BinaryOperator)
+		return ok && n.Operator == b.Op.String() && len(n.Children()) == 2 &&
+			matchMacro(b.X, n.Children()[0], args, false) &&
+			matchMacro(b.Y, n.Children()[1], args, false)
+
+	case *goast.UnaryExpr:
+		operator := b.Op.String()
+		if b.Op == token.XOR {
+			operator = "~"
+		}
+		n, ok := node.(*ast.UnaryOperator)
+		return ok && n.IsPrefix && n.Operator == operator &&
+			len(n.Children()) == 1 &&
+			matchMacro(b.X, n.Children()[0], args, false)
+
+	case *goast.BasicLit:
+		value := constant.MakeFromLiteral(b.Value, b.Kind, 0)
+		switch n := node.(type) {
+		case *ast.IntegerLiteral:
+			literal := constant.MakeFromLiteral(n.Value, token.INT, 0)
+			return b.Kind == token.INT && literal.Kind() == constant.Int &&
+				constant.Compare(value, token.EQL, literal)
+		case *ast.FloatingLiteral:
+			f, _ := constant.Float64Val(value)
+			return b.Kind == token.FLOAT && f == n.Value
+		}
+	}
+	return false
+}
+
+// macroNodeKey returns the string with all fields of node and children
+// without addresses and positions. Equal expressions have equal keys.
+func macroNodeKey(node ast.Node) string {
+	var buf bytes.Buffer
+	v := reflect.ValueOf(node)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	fmt.Fprintf(&buf, "%T{", node)
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			switch field.Name {
+			case "Addr", "Pos", "ChildNodes":
+				continue
+			}
+			fmt.Fprintf(&buf, "%v;", v.Field(i).Interface())
+		}
+	}
+	for _, child := range node.Children() {
+		if child != nil {
+			buf.WriteString(macroNodeKey(child))
+		}
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// hasSideEffects returns true, if expression has assignments, increments,
+// decrements or calls of functions.
+func hasSideEffects(node ast.Node) (has bool) {
+	if node == nil {
+		return false
+	}
+	switch n := node.(type) {
+	case *ast.CallExpr, *ast.CompoundAssignOperator, *ast.StmtExpr:
+		return true
+	case *ast.BinaryOperator:
+		if n.Operator == "=" {
+			return true
+		}
+	case *ast.UnaryOperator:
+		if n.Operator == "++" || n.Operator == "--" {
+			return true
+		}
+	}
+	for _, child := range node.Children() {
+		if hasSideEffects(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMacroFunctionType returns true for numeric types of Go.
+func isMacroFunctionType(goType string) bool {
+	switch goType {
+	case "int8", "int16", "int32", "int64", "int",
+		"uint8", "uint16", "uint32", "uint64", "uint", "byte",
+		"float32", "float64":
+		return true
+	}
+	return false
+}
+
+// isValidMacroFunction returns true, if the Go function of macro with the
+// type is compiled by Go. For example, constants of macro are not
+// overflowed.
+func isValidMacroFunction(m preprocessor.FunctionMacro, body goast.Expr,
+	goType string) bool {
+	var buf bytes.Buffer
+	buf.WriteString("package macro\n")
+	fset := token.NewFileSet()
+	decl := macroFunctionDecl(m.Name, m.Params, body, []string{goType})
+	if err := printer.Fprint(&buf, fset, decl); err != nil {
+		return false
+	}
+	f, err := parser.ParseFile(fset, "", buf.String(), 0)
+	if err != nil {
+		return false
+	}
+	var conf gotypes.Config
+	_, err = conf.Check("macro", fset, []*goast.File{f}, nil)
+	return err == nil
+}
+
+// macroFunctionDecl returns the Go function of macro. Function with several
+// types is generic:
+//
+//     func SQR[T int32 | float64](x T) T {
+//         return ((x) * (x))
+//     }
+func macroFunctionDecl(name string, params []string, body goast.Expr,
+	goTypes []string) *goast.FuncDecl {
+	decl := &goast.FuncDecl{
+		Name: goast.NewIdent(name),
+		Type: &goast.FuncType{Params: &goast.FieldList{}},
+		Body: &goast.BlockStmt{List: []goast.Stmt{
+			&goast.ReturnStmt{Results: []goast.Expr{body}},
+		}},
+	}
+	var typ goast.Expr = goast.NewIdent(goTypes[0])
+	if len(goTypes) > 1 {
+		typeParam := "T"
+		for isParam := true; isParam; {
+			isParam = false
+			for _, param := range params {
+				if param == typeParam {
+					typeParam += "_"
+					isParam = true
+				}
+			}
+		}
+		var constraint goast.Expr = goast.NewIdent(goTypes[0])
+		for _, t := range goTypes[1:] {
+			constraint = &goast.BinaryExpr{
+				X:  constraint,
+				Op: token.OR,
+				Y:  goast.NewIdent(t),
+			}
+		}
+		decl.Type.TypeParams = &goast.FieldList{List: []*goast.Field{{
+			Names: []*goast.Ident{goast.NewIdent(typeParam)},
+			Type:  constraint,
+		}}}
+		typ = goast.NewIdent(typeParam)
+	}
+	field := &goast.Field{Type: typ}
+	for _, param := range params {
+		field.Names = append(field.Names, goast.NewIdent(param))
+	}
+	decl.Type.Params.List = []*goast.Field{field}
+	decl.Type.Results = &goast.FieldList{List: []*goast.Field{{Type: typ}}}
+	return decl
+}
+
+// macroFunctionDecls returns the Go functions of macros, which are called in
+// the Go code. Functions of macros are renamed, if names are used by other
+// Go code.
+func macroFunctionDecls(p *program.Program) (decls []goast.Decl) {
+	var names []string
+	for name := range p.MacroFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	used := goNames(p)
+	for _, name := range names {
+		f := p.MacroFunctions[name]
+		goName := name
+		for used[goName] {
+			goName += "_macro"
+		}
+		used[goName] = true
+
+		for call, goType := range f.Calls {
+			call.Fun = goast.NewIdent(goName)
+			if len(f.Types) > 1 {
+				call.Fun = &goast.IndexExpr{
+					X:     goast.NewIdent(goName),
+					Index: goast.NewIdent(goType),
+				}
+			}
+		}
+		body, _, _ := macroFunctionBody(f.Macro)
+		decls = append(decls, macroFunctionDecl(goName, f.Macro.Params, body, f.Types))
+	}
+	return
+}
+
+// unparen returns expression without parentheses. Parentheses of the
+// argument of conversion are removed too.
+func unparen(expr goast.Expr) goast.Expr {
+	expr = unparenExpr(expr)
+	if call, ok := expr.(*goast.CallExpr); ok && len(call.Args) == 1 {
+		call.Args[0] = unparenExpr(call.Args[0])
+	}
+	return expr
+}
+
+// unparenExpr returns expression without outer parentheses.
+func unparenExpr(expr goast.Expr) goast.Expr {
+	for {
+		paren, ok := expr.(*goast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
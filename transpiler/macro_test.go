@@ -0,0 +1,189 @@
+package transpiler
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/preprocessor"
+)
+
+func TestMacroConstSpecs(t *testing.T) {
+	var macros []preprocessor.Macro
+	for _, m := range [][2]string{
+		{"MAX_USERS", "64"},
+		{"LIMIT", "(MAX_USERS * 2UL)"},
+		{"VERSION", `"1.2"`},
+		{"RATIO", "1.5e-3f"},
+		{"MASK", "~0x0F"},
+		{"UNSIGNED", "-1U"},
+		{"MODE", "0755"},
+		{"LETTER", "'a'"},
+		{"DEPENDENT", "LATER + 1"},
+		{"LATER", "10"},
+		{"IS_BIG", "(MAX_USERS > 10)"},
+		{"CAST", "((int)5)"},
+		{"CALL", "len(VERSION)"},
+		{"ZERO", "(1 / 0)"},
+		{"FUNC", "helper"},
+		{"len", "5"},
+		{"USED", "5"},
+	} {
+		macros = append(macros, preprocessor.Macro{Name: m[0], Value: m[1]})
+	}
+
+	var buf bytes.Buffer
+	decl := &goast.GenDecl{Tok: token.CONST, Lparen: 1,
+		Specs: macroConstSpecs(macros, map[string]bool{"len": true, "USED": true})}
+	if err := printer.Fprint(&buf, token.NewFileSet(), decl); err != nil {
+		t.Fatal(err)
+	}
+	expected := `const (
+	MAX_USERS	= 64
+	LIMIT		= (MAX_USERS * 2)
+	VERSION		= "1.2"
+	RATIO		= 1.5e-3
+	MASK		= ^0x0F
+	MODE		= 0755
+	LETTER		= 'a'
+	DEPENDENT	= LATER + 1
+	LATER		= 10
+)`
+	if strings.TrimSpace(buf.String()) != expected {
+		t.Errorf("Not expected constants:\n%s\nExpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestMatchMacro(t *testing.T) {
+	sqr := preprocessor.FunctionMacro{
+		Macro:  preprocessor.Macro{Name: "SQR", Value: "((x)*(x))"},
+		Params: []string{"x"},
+	}
+	body, uses, ok := macroFunctionBody(sqr)
+	if !ok || uses["x"] != 2 {
+		t.Fatalf("Body of macro is not simple: %v %v", body, uses)
+	}
+
+	// char c; int a, b;
+	// SQR(c); SQR(a++); ((a)*(b));
+	tree := parseTree(t, `
+CompoundStmt 0x1 <file.c:1:1, line:4:1>
+|-ParenExpr 0x2 <line:2:3, col:11> 'int'
+| `+"`"+`-BinaryOperator 0x3 <col:4, col:10> 'int' '*'
+|   |-ImplicitCastExpr 0x4 <col:4, col:6> 'int' <IntegralCast>
+|   | `+"`"+`-ImplicitCastExpr 0x5 <col:4, col:6> 'char' <LValueToRValue>
+|   |   `+"`"+`-ParenExpr 0x6 <col:4, col:6> 'char' lvalue
+|   |     `+"`"+`-DeclRefExpr 0x7 <col:5> 'char' lvalue Var 0x70 'c' 'char'
+|   `+"`"+`-ImplicitCastExpr 0x8 <col:8, col:10> 'int' <IntegralCast>
+|     `+"`"+`-ImplicitCastExpr 0x9 <col:8, col:10> 'char' <LValueToRValue>
+|       `+"`"+`-ParenExpr 0x10 <col:8, col:10> 'char' lvalue
+|         `+"`"+`-DeclRefExpr 0x11 <col:9> 'char' lvalue Var 0x70 'c' 'char'
+|-ParenExpr 0x12 <line:3:3, col:15> 'int'
+| `+"`"+`-BinaryOperator 0x13 <col:4, col:14> 'int' '*'
+|   |-ParenExpr 0x14 <col:4, col:8> 'int'
+|   | `+"`"+`-UnaryOperator 0x15 <col:5, col:6> 'int' postfix '++'
+|   |   `+"`"+`-DeclRefExpr 0x16 <col:5> 'int' lvalue Var 0x71 'a' 'int'
+|   `+"`"+`-ParenExpr 0x17 <col:10, col:14> 'int'
+|     `+"`"+`-UnaryOperator 0x18 <col:11, col:12> 'int' postfix '++'
+|       `+"`"+`-DeclRefExpr 0x19 <col:11> 'int' lvalue Var 0x71 'a' 'int'
+`+"`"+`-ParenExpr 0x20 <line:4:3, col:11> 'int'
+  `+"`"+`-BinaryOperator 0x21 <col:4, col:10> 'int' '*'
+    |-ImplicitCastExpr 0x22 <col:4, col:6> 'int' <LValueToRValue>
+    | `+"`"+`-ParenExpr 0x23 <col:4, col:6> 'int' lvalue
+    |   `+"`"+`-DeclRefExpr 0x24 <col:5> 'int' lvalue Var 0x71 'a' 'int'
+    `+"`"+`-ImplicitCastExpr 0x25 <col:8, col:10> 'int' <LValueToRValue>
+      `+"`"+`-ParenExpr 0x26 <col:8, col:10> 'int' lvalue
+        `+"`"+`-DeclRefExpr 0x27 <col:9> 'int' lvalue Var 0x72 'b' 'int'
+`)
+
+	for i, expected := range []struct {
+		match          bool
+		argType        string
+		hasSideEffects bool
+	}{
+		{true, "int", false},
+		{true, "int", true},
+		{false, "", false},
+	} {
+		args := map[string]ast.Node{}
+		match := matchMacro(body, tree.Children()[i], args, true)
+		if match != expected.match {
+			t.Errorf("Expression %d: match is %v", i, match)
+			continue
+		}
+		if !match {
+			continue
+		}
+		if argType := nodeType(args["x"]); argType != expected.argType {
+			t.Errorf("Expression %d: type of argument is %s", i, argType)
+		}
+		if has := hasSideEffects(args["x"]); has != expected.hasSideEffects {
+			t.Errorf("Expression %d: side effects of argument is %v", i, has)
+		}
+	}
+}
+
+func TestMacroFunctionDecl(t *testing.T) {
+	for _, tc := range []struct {
+		macro    preprocessor.FunctionMacro
+		goTypes  []string
+		expected string
+	}{
+		{
+			macro: preprocessor.FunctionMacro{
+				Macro:  preprocessor.Macro{Name: "SQR", Value: "((x)*(x))"},
+				Params: []string{"x"},
+			},
+			goTypes: []string{"int32"},
+			expected: `func SQR(x int32) int32 {
+	return ((x) * (x))
+}`,
+		},
+		{
+			macro: preprocessor.FunctionMacro{
+				Macro:  preprocessor.Macro{Name: "MASK", Value: "(~(T) & 0xFFL)"},
+				Params: []string{"T"},
+			},
+			goTypes: []string{"int32", "uint64"},
+			expected: `func MASK[T_ int32 | uint64](T T_) T_ {
+	return (^(T) & 0xFF)
+}`,
+		},
+	} {
+		body, _, ok := macroFunctionBody(tc.macro)
+		if !ok {
+			t.Errorf("Body of macro %s is not simple", tc.macro.Name)
+			continue
+		}
+		var buf bytes.Buffer
+		decl := macroFunctionDecl(tc.macro.Name, tc.macro.Params, body, tc.goTypes)
+		if err := printer.Fprint(&buf, token.NewFileSet(), decl); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("Not expected function:\n%s\nExpected:\n%s", buf.String(), tc.expected)
+		}
+	}
+
+	// constant is overflowed for int8
+	m := preprocessor.FunctionMacro{
+		Macro:  preprocessor.Macro{Name: "SCALE", Value: "((x) * 1000)"},
+		Params: []string{"x"},
+	}
+	body, _, _ := macroFunctionBody(m)
+	if !isValidMacroFunction(m, body, "int32") || isValidMacroFunction(m, body, "int8") {
+		t.Errorf("Not expected validation of function of macro %s", m.Name)
+	}
+
+	// body is not simple
+	for _, value := range []string{"(x)", "((x) > 0)", "f(x)", "((x) + y)", "2"} {
+		m.Value = value
+		if _, _, ok := macroFunctionBody(m); ok {
+			t.Errorf("Body of macro `%s` is simple", value)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package transpiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// transpileBlockExpr transpiles the block of clang into the Go closure.
+// Nested functions of GNU C are rewritten by preprocessor into the blocks,
+// which are cast to pointers of functions:
+//
+//     int (*add)(int x) = (int (*)(int x))(void *)^int (int x) {
+//         return x + a;
+//     };
+//
+// Result:
+//
+//     var add func(int32) int32
+//     add = func(x int32) int32 {
+//         return x + a
+//     }
+//
+// Captured variables of enclosing function are the variables of Go closure.
+func transpileBlockExpr(n *ast.BlockExpr, p *program.Program) (
+	_ goast.Expr, exprType string, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot transpileBlockExpr. err = %v", err)
+		}
+	}()
+
+	exprType = strings.Replace(n.Type, "(^)", "(*)", 1)
+	_, _, returns, err := types.ParseFunction(exprType)
+	if err != nil {
+		return
+	}
+	returnType := returns[0]
+
+	if len(n.Children()) == 0 {
+		err = fmt.Errorf("block without declaration")
+		return
+	}
+	decl, ok := n.Children()[0].(*ast.BlockDecl)
+	if !ok {
+		err = fmt.Errorf("not expected node of block %T", n.Children()[0])
+		return
+	}
+
+	fieldList := &goast.FieldList{}
+	var body *ast.CompoundStmt
+	for _, child := range decl.Children() {
+		switch c := child.(type) {
+		case *ast.ParmVarDecl:
+			var t string
+			t, err = types.ResolveType(p, c.Type)
+			p.AddMessage(p.GenerateWarningMessage(err, c))
+			err = nil
+			fieldList.List = append(fieldList.List, &goast.Field{
+				Names: []*goast.Ident{util.NewIdent(c.Name)},
+				Type:  util.NewTypeIdent(t),
+			})
+		case *ast.CompoundStmt:
+			body = c
+		}
+	}
+	if decl.IsVariadic {
+		fieldList.List = append(fieldList.List, &goast.Field{
+			Names: []*goast.Ident{util.NewIdent(vaArgsName)},
+			Type:  &goast.Ellipsis{Elt: &goast.InterfaceType{Methods: &goast.FieldList{}}},
+		})
+	}
+	if body == nil {
+		err = fmt.Errorf("block without body")
+		return
+	}
+
+	p.NestedFunctions = append(p.NestedFunctions, returnType)
+	goBody, pre, post, err := transpileToBlockStmt(body, p)
+	p.NestedFunctions = p.NestedFunctions[:len(p.NestedFunctions)-1]
+	if err != nil || len(pre) > 0 || len(post) > 0 {
+		p.AddMessage(p.GenerateWarningMessage(
+			fmt.Errorf("Not correct result in nested function: err = %v",
+				err), n))
+		err = nil // Error is ignored
+	}
+
+	t, err := types.ResolveType(p, returnType)
+	p.AddMessage(p.GenerateWarningMessage(err, n))
+	err = nil
+
+	// Each closure with return type MUST have "ReturnStmt"
+	var addReturnName bool
+	if t != "" {
+		if len(goBody.List) == 0 {
+			goBody.List = append(goBody.List, &goast.ReturnStmt{})
+			addReturnName = true
+		} else if _, ok := goBody.List[len(goBody.List)-1].(*goast.ReturnStmt); !ok {
+			goBody.List = append(goBody.List, &goast.ReturnStmt{})
+			addReturnName = true
+		}
+	}
+
+	return &goast.FuncLit{
+		Type: util.NewFuncType(fieldList, t, addReturnName),
+		Body: goBody,
+	}, exprType, nil
+}
+
+// blockOfCast returns the block of clang, which is cast to the pointer of
+// function, like `(int (*)(int))(void *)^int (int x) {...}`.
+func blockOfCast(n *ast.CStyleCastExpr) (block *ast.BlockExpr, ok bool) {
+	var node ast.Node = n
+	for {
+		switch v := node.(type) {
+		case *ast.BlockExpr:
+			return v, true
+		case *ast.CStyleCastExpr, *ast.ImplicitCastExpr, *ast.ParenExpr:
+			if len(v.Children()) != 1 {
+				return nil, false
+			}
+			node = v.Children()[0]
+		default:
+			return nil, false
+		}
+	}
+}
+
+// removeBlocksAttr removes the attribute of storage class `__block` of
+// variables captured by blocks. Variables of Go closures are captured by
+// reference without any attribute.
+func removeBlocksAttr(nodes []ast.Node) []ast.Node {
+	var result []ast.Node
+	for _, node := range nodes {
+		if _, ok := node.(*ast.BlocksAttr); ok {
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// splitClosures splits the declarations of variables initialized by Go
+// closures into declaration and assignment, so the nested functions of
+// GNU C may be recursive:
+//
+//     var f func(int32) int32 = func(x int32) int32 { return f(x - 1) }
+//
+// Result:
+//
+//     var f func(int32) int32
+//     f = func(x int32) int32 { return f(x - 1) }
+func splitClosures(stmts []goast.Stmt) (result []goast.Stmt) {
+	for _, stmt := range stmts {
+		result = append(result, stmt)
+		decl, ok := stmt.(*goast.DeclStmt)
+		if !ok {
+			continue
+		}
+		gen, ok := decl.Decl.(*goast.GenDecl)
+		if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+			continue
+		}
+		spec, ok := gen.Specs[0].(*goast.ValueSpec)
+		if !ok || spec.Type == nil || len(spec.Names) != 1 ||
+			len(spec.Values) != 1 {
+			continue
+		}
+		closure, ok := spec.Values[0].(*goast.FuncLit)
+		if !ok {
+			continue
+		}
+		spec.Values = nil
+		result = append(result, &goast.AssignStmt{
+			Lhs: []goast.Expr{spec.Names[0]},
+			Tok: token.ASSIGN,
+			Rhs: []goast.Expr{closure},
+		})
+	}
+	return
+}
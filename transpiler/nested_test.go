@@ -0,0 +1,91 @@
+package transpiler
+
+import (
+	"bytes"
+	"go/printer"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestNestedFunction(t *testing.T) {
+	// int sum(int n) {
+	//   __block int s = 0;
+	//   __block int (*add)(int x) = (int (*)(int x))(void *)^int (int x) {
+	//     s += x;
+	//     return x > 0 ? add(x - 1) : s;
+	//   };
+	//   return add(n);
+	// }
+	tree := parseTree(t, `
+FunctionDecl 0x1 <file.c:1:1, line:8:1> line:1:5 sum 'int (int)'
+|-ParmVarDecl 0x2 <col:9, col:13> col:13 used n 'int'
+`+"`"+`-CompoundStmt 0x3 <col:16, line:8:1>
+  |-DeclStmt 0x4 <line:2:3, col:20>
+  | `+"`"+`-VarDecl 0x5 <col:3, col:19> col:15 used s 'int' cinit
+  |   |-IntegerLiteral 0x6 <col:19> 'int' 0
+  |   `+"`"+`-BlocksAttr 0x7 <col:3> ByRef
+  |-DeclStmt 0x8 <line:3:3, line:6:4>
+  | `+"`"+`-VarDecl 0x9 <line:3:3, line:6:3> line:3:18 used add 'int (*)(int)' cinit
+  |   |-CStyleCastExpr 0x10 <col:31, line:6:3> 'int (*)(int)' <BitCast>
+  |   | `+"`"+`-CStyleCastExpr 0x11 <line:3:48, line:6:3> 'void *' <BitCast>
+  |   |   `+"`"+`-BlockExpr 0x12 <line:3:56, line:6:3> 'int (^)(int)'
+  |   |     `+"`"+`-BlockDecl 0x13 <line:3:56, line:6:3> line:3:56
+  |   |       |-ParmVarDecl 0x14 <col:62, col:66> col:66 used x 'int'
+  |   |       |-capture byref Var 0x5 's' 'int'
+  |   |       |-capture byref Var 0x9 'add' 'int (*)(int)'
+  |   |       `+"`"+`-CompoundStmt 0x15 <col:69, line:6:3>
+  |   |         |-CompoundAssignOperator 0x16 <line:4:5, col:10> 'int' '+=' ComputeLHSTy='int' ComputeResultTy='int'
+  |   |         | |-DeclRefExpr 0x17 <col:5> 'int' lvalue Var 0x5 's' 'int'
+  |   |         | `+"`"+`-ImplicitCastExpr 0x18 <col:10> 'int' <LValueToRValue>
+  |   |         |   `+"`"+`-DeclRefExpr 0x19 <col:10> 'int' lvalue ParmVar 0x14 'x' 'int'
+  |   |         `+"`"+`-ReturnStmt 0x32 <line:5:5, col:33>
+  |   |           `+"`"+`-ConditionalOperator 0x20 <col:12, col:33> 'int'
+  |   |             |-BinaryOperator 0x21 <col:12, col:16> 'int' '>'
+  |   |             | |-ImplicitCastExpr 0x22 <col:12> 'int' <LValueToRValue>
+  |   |             | | `+"`"+`-DeclRefExpr 0x23 <col:12> 'int' lvalue ParmVar 0x14 'x' 'int'
+  |   |             | `+"`"+`-IntegerLiteral 0x24 <col:16> 'int' 0
+  |   |             |-CallExpr 0x25 <col:20, col:29> 'int'
+  |   |             | |-ImplicitCastExpr 0x26 <col:20> 'int (*)(int)' <LValueToRValue>
+  |   |             | | `+"`"+`-DeclRefExpr 0x27 <col:20> 'int (*)(int)' lvalue Var 0x9 'add' 'int (*)(int)'
+  |   |             | `+"`"+`-BinaryOperator 0x28 <col:24, col:28> 'int' '-'
+  |   |             |   |-ImplicitCastExpr 0x29 <col:24> 'int' <LValueToRValue>
+  |   |             |   | `+"`"+`-DeclRefExpr 0x30 <col:24> 'int' lvalue ParmVar 0x14 'x' 'int'
+  |   |             |   `+"`"+`-IntegerLiteral 0x31 <col:28> 'int' 1
+  |   |             `+"`"+`-ImplicitCastExpr 0x33 <col:33> 'int' <LValueToRValue>
+  |   |               `+"`"+`-DeclRefExpr 0x34 <col:33> 'int' lvalue Var 0x5 's' 'int'
+  |   `+"`"+`-BlocksAttr 0x35 <line:3:3> ByRef
+  `+"`"+`-ReturnStmt 0x36 <line:7:3, col:15>
+    `+"`"+`-CallExpr 0x37 <col:10, col:15> 'int'
+      |-ImplicitCastExpr 0x38 <col:10> 'int (*)(int)' <LValueToRValue>
+      | `+"`"+`-DeclRefExpr 0x39 <col:10> 'int (*)(int)' lvalue Var 0x9 'add' 'int (*)(int)'
+      `+"`"+`-ImplicitCastExpr 0x40 <col:14> 'int' <LValueToRValue>
+        `+"`"+`-DeclRefExpr 0x41 <col:14> 'int' lvalue ParmVar 0x2 'n' 'int'
+`)
+
+	p := program.NewProgram()
+	decls, err := transpileFunctionDecl(tree.(*ast.FunctionDecl), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), decls[0]); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{
+		"var s int\n",
+		// declaration and assignment for recursive closure
+		"var add func(int)(int)\n",
+		"add = func(x int) int {",
+		"s += x",
+		"return add(x - 1)",
+		"return add(n)",
+	} {
+		if !strings.Contains(buf.String(), part) {
+			t.Errorf("Not found `%s` in code:\n%s", part, buf.String())
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/Konstantin8105/c4go/ast"
 	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
 )
 
 func transpileOffsetOfExpr(n *ast.OffsetOfExpr, p *program.Program) (
@@ -18,9 +20,47 @@ func transpileOffsetOfExpr(n *ast.OffsetOfExpr, p *program.Program) (
 		}
 	}()
 
-	var buffer []byte
+	structName, field, err := offsetOfArguments(n, p)
+	if err != nil {
+		return
+	}
+
+	// offset of field in according to the C layout of struct
+	offset, err := offsetOf(p, structName, field)
+	if err == nil {
+		return util.NewIntLit(offset), n.Type, nil
+	}
+	p.AddMessage(p.GenerateWarningMessage(err, n))
+	err = nil
+
+	p.AddImport("unsafe")
+	expr = &goast.CallExpr{
+		Fun: &goast.SelectorExpr{
+			X:   goast.NewIdent("unsafe"),
+			Sel: goast.NewIdent("Offsetof"),
+		},
+		Lparen: 1,
+		Args: []goast.Expr{
+			&goast.SelectorExpr{
+				X: &goast.CompositeLit{
+					Type:   goast.NewIdent(structName),
+					Lbrace: 1,
+				},
+				Sel: goast.NewIdent(field),
+			},
+		},
+	}
+
+	exprType = n.Type
+	return
+}
+
+// offsetOfArguments returns the name of struct and the field of macro
+// offsetof from the source code, because clang AST has not the arguments.
+func offsetOfArguments(n *ast.OffsetOfExpr, p *program.Program) (
+	structName, field string, err error) {
 	pos := n.Position()
-	buffer, err = p.PreprocessorFile.GetSnippet(pos.File,
+	buffer, err := p.PreprocessorFile.GetSnippet(pos.File,
 		pos.Line, pos.LineEnd,
 		pos.Column, pos.ColumnEnd)
 	if err != nil {
@@ -59,24 +99,14 @@ func transpileOffsetOfExpr(n *ast.OffsetOfExpr, p *program.Program) (
 		arguments[0] = arguments[0][len("struct "):]
 	}
 
-	p.AddImport("unsafe")
-	expr = &goast.CallExpr{
-		Fun: &goast.SelectorExpr{
-			X:   goast.NewIdent("unsafe"),
-			Sel: goast.NewIdent("Offsetof"),
-		},
-		Lparen: 1,
-		Args: []goast.Expr{
-			&goast.SelectorExpr{
-				X: &goast.CompositeLit{
-					Type:   goast.NewIdent(string(arguments[0])),
-					Lbrace: 1,
-				},
-				Sel: goast.NewIdent(string(arguments[1])),
-			},
-		},
-	}
+	return string(arguments[0]), string(arguments[1]), nil
+}
 
-	exprType = n.Type
-	return
+// offsetOf returns the offset of field of struct in bytes. The name of
+// struct may be without the keyword `struct`.
+func offsetOf(p *program.Program, structName, field string) (int, error) {
+	if p.GetStruct(structName) == nil && p.GetStruct("struct "+structName) != nil {
+		structName = "struct " + structName
+	}
+	return types.OffsetOf(p, structName, field)
 }
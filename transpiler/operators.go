@@ -4,13 +4,8 @@
 package transpiler
 
 import (
-	"bytes"
 	"fmt"
-	"go/parser"
-	"go/printer"
 	"go/token"
-	"html/template"
-	"strings"
 
 	goast "go/ast"
 
@@ -63,37 +58,34 @@ func transpileConditionalOperator(n *ast.ConditionalOperator, p *program.Program
 	}
 
 	// b - body
-	b, bType, newPre, newPost, err := transpileToExpr(n.Children()[1], p, false)
+	// pre and post statements of branches are evaluated only inside of
+	// branch, for example: `c ? (a++, a) : b`
+	b, bType, bPre, bPost, err := transpileToExpr(n.Children()[1], p, false)
 	if err != nil {
 		return
 	}
-	// Theorephly, length is must be zero
-	if len(newPre) > 0 || len(newPost) > 0 {
-		p.AddMessage(p.GenerateWarningMessage(
-			fmt.Errorf("length of pre or post in body must be zero. {%d,%d}", len(newPre), len(newPost)), n))
-	}
-	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 
 	if n.Type != "void" {
 		b, err = types.CastExpr(p, b, bType, n.Type)
 		if err != nil {
 			return
 		}
+		b = copyValue(p, b, n.Type)
 		bType = n.Type
 	}
 
 	// c - else body
-	c, cType, newPre, newPost, err := transpileToExpr(n.Children()[2], p, false)
+	c, cType, cPre, cPost, err := transpileToExpr(n.Children()[2], p, false)
 	if err != nil {
 		return nil, "", nil, nil, err
 	}
-	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 
 	if n.Type != "void" {
 		c, err = types.CastExpr(p, c, cType, n.Type)
 		if err != nil {
 			return
 		}
+		c = copyValue(p, c, n.Type)
 		cType = n.Type
 	}
 
@@ -106,41 +98,20 @@ func transpileConditionalOperator(n *ast.ConditionalOperator, p *program.Program
 		}
 	}
 
+	// macros MIN and MAX are builtin functions since Go 1.21
+	if f := minMaxFunction(n); f != "" && p.IsGoVersion(21) &&
+		types.IsCInteger(p, n.Type) &&
+		len(bPre)+len(bPost)+len(cPre)+len(cPost) == 0 {
+		return util.NewCallExpr(f, b, c), n.Type, preStmts, postStmts, nil
+	}
+
 	var bod, els goast.BlockStmt
 
 	bod.Lbrace = 1
-	if bType != types.ToVoid {
-		if n.Type != "void" {
-			bod.List = []goast.Stmt{
-				&goast.ReturnStmt{
-					Results: []goast.Expr{b},
-				},
-			}
-		} else {
-			bod.List = []goast.Stmt{
-				&goast.ExprStmt{
-					X: b,
-				},
-			}
-		}
-	}
+	bod.List = conditionalBranch(b, bType, bPre, bPost, n.Type, returnType)
 
 	els.Lbrace = 1
-	if cType != types.ToVoid {
-		if n.Type != "void" {
-			els.List = []goast.Stmt{
-				&goast.ReturnStmt{
-					Results: []goast.Expr{c},
-				},
-			}
-		} else {
-			els.List = []goast.Stmt{
-				&goast.ExprStmt{
-					X: c,
-				},
-			}
-		}
-	}
+	els.List = conditionalBranch(c, cType, cPre, cPost, n.Type, returnType)
 
 	stmts := append([]goast.Stmt{}, &goast.IfStmt{
 		Cond: a,
@@ -162,6 +133,191 @@ func transpileConditionalOperator(n *ast.ConditionalOperator, p *program.Program
 		stmts...), n.Type, preStmts, postStmts, nil
 }
 
+// transpileBinaryConditionalOperator transpiles the conditional operator of
+// GNU C without middle operand. The common expression is evaluated only
+// once, so it is stored in the variable of closure:
+//
+//     f() ?: y  ->  func() int {
+//                       tempVar := f()
+//                       if tempVar != 0 {
+//                           return tempVar
+//                       }
+//                       return y
+//                   }()
+//
+func transpileBinaryConditionalOperator(n *ast.BinaryConditionalOperator,
+	p *program.Program) (
+	_ *goast.CallExpr, theType string, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot transpile BinaryConditionalOperator : err = %v", err)
+		}
+	}()
+
+	if len(n.Children()) != 5 {
+		err = fmt.Errorf("expected 5 children, got %d", len(n.Children()))
+		return
+	}
+
+	// common expression
+	e, eType, newPre, newPost, err := transpileToExpr(n.Children()[0], p, false)
+	if err != nil {
+		return
+	}
+	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+
+	var stmts []goast.Stmt
+	value := e
+	if _, ok := e.(*goast.Ident); !ok {
+		value = util.NewIdent("tempVar")
+		stmts = append(stmts, &goast.AssignStmt{
+			Lhs: []goast.Expr{value},
+			Tok: token.DEFINE,
+			Rhs: []goast.Expr{e},
+		})
+	}
+
+	cond, err := types.CastExpr(p, value, eType, "bool")
+	if err != nil {
+		return
+	}
+
+	var returnType string
+	if n.Type != "void" {
+		value, err = types.CastExpr(p, value, eType, n.Type)
+		if err != nil {
+			return
+		}
+		value = copyValue(p, value, n.Type)
+		eType = n.Type
+		returnType, err = types.ResolveType(p, n.Type)
+		if err != nil {
+			return
+		}
+	}
+
+	// value if condition is false
+	c, cType, cPre, cPost, err := transpileToExpr(n.Children()[4], p, false)
+	if err != nil {
+		return
+	}
+	if n.Type != "void" {
+		c, err = types.CastExpr(p, c, cType, n.Type)
+		if err != nil {
+			return
+		}
+		c = copyValue(p, c, n.Type)
+		cType = n.Type
+	}
+
+	if n.Type == "void" {
+		stmts = append(stmts, &goast.IfStmt{
+			Cond: &goast.UnaryExpr{Op: token.NOT, X: &goast.ParenExpr{X: cond}},
+			Body: &goast.BlockStmt{
+				List: conditionalBranch(c, cType, cPre, cPost, n.Type, returnType),
+			},
+		})
+	} else {
+		stmts = append(stmts, &goast.IfStmt{
+			Cond: cond,
+			Body: &goast.BlockStmt{
+				List: []goast.Stmt{&goast.ReturnStmt{Results: []goast.Expr{value}}},
+			},
+		})
+		stmts = append(stmts,
+			conditionalBranch(c, cType, cPre, cPost, n.Type, returnType)...)
+	}
+
+	return util.NewFuncClosure(returnType, stmts...), n.Type, preStmts, postStmts, nil
+}
+
+// conditionalBranch returns the statements of branch of conditional
+// operator. Post statements of branch are deferred, because they are
+// evaluated after the value of branch.
+func conditionalBranch(expr goast.Expr, exprType string,
+	preStmts, postStmts []goast.Stmt, cType, returnType string) []goast.Stmt {
+	stmts := append([]goast.Stmt{}, preStmts...)
+	switch {
+	case exprType == types.ToVoid:
+		stmts = append(stmts, postStmts...)
+	case cType == "void":
+		stmts = append(stmts, &goast.ExprStmt{X: expr})
+		stmts = append(stmts, postStmts...)
+	default:
+		if len(postStmts) > 0 {
+			expr = util.NewAnonymousFunction(nil, postStmts, expr, returnType)
+		}
+		stmts = append(stmts, &goast.ReturnStmt{
+			Results: []goast.Expr{expr},
+		})
+	}
+	return stmts
+}
+
+// minMaxFunction returns "min" or "max", if the conditional operator is
+// the expansion of macro MIN or MAX, for example:
+//
+//     ((a) < (b) ? (a) : (b))
+//
+// Operands must be variables or literals without side effects, because
+// they are evaluated twice in C.
+func minMaxFunction(n *ast.ConditionalOperator) string {
+	cond, ok := operand(n.Children()[0]).(*ast.BinaryOperator)
+	if !ok || len(cond.Children()) != 2 {
+		return ""
+	}
+	var less bool
+	switch cond.Operator {
+	case "<", "<=":
+		less = true
+	case ">", ">=":
+	default:
+		return ""
+	}
+	x, y := cond.Children()[0], cond.Children()[1]
+	b, c := n.Children()[1], n.Children()[2]
+	switch {
+	case sameOperand(x, b) && sameOperand(y, c):
+	case sameOperand(x, c) && sameOperand(y, b):
+		less = !less
+	default:
+		return ""
+	}
+	if less {
+		return "min"
+	}
+	return "max"
+}
+
+// operand returns the node without parentheses and implicit casts.
+func operand(node ast.Node) ast.Node {
+	for {
+		switch v := node.(type) {
+		case *ast.ParenExpr, *ast.ImplicitCastExpr:
+			if len(v.Children()) != 1 {
+				return node
+			}
+			node = v.Children()[0]
+		default:
+			return node
+		}
+	}
+}
+
+// sameOperand returns true, if both nodes are the same variable or literal.
+func sameOperand(a, b ast.Node) bool {
+	switch x := operand(a).(type) {
+	case *ast.DeclRefExpr:
+		y, ok := operand(b).(*ast.DeclRefExpr)
+		return ok && (x.For == "Var" || x.For == "ParmVar") &&
+			x.Name == y.Name && x.Address2 == y.Address2
+	case *ast.IntegerLiteral:
+		y, ok := operand(b).(*ast.IntegerLiteral)
+		return ok && x.Value == y.Value
+	}
+	return false
+}
+
 // transpileParenExpr transpiles an expression that is wrapped in parentheses.
 // There is a special case where "(0)" is treated as a NULL (since that's what
 // the macro expands to). We have to return the type as "null" since we don't
@@ -205,114 +361,47 @@ func transpileParenExpr(n *ast.ParenExpr, p *program.Program) (
 	return
 }
 
-// pointerArithmetic - operations between 'int' and pointer
-// Example C code : ptr += i
-// ptr = (*(*[1]int)(unsafe.Pointer(uintptr(unsafe.Pointer(&ptr[0])) + (i)*unsafe.Sizeof(ptr[0]))))[:]
-// , where i  - right
-//        '+' - operator
-//      'ptr' - left
-//      'int' - leftType transpiled in Go type
-// Note:
-// 1) rigthType MUST be 'int'
-// 2) pointerArithmetic - implemented ONLY right part of formula
-// 3) right is MUST be positive value, because impossible multiply uintptr to (-1)
-func pointerArithmetic(p *program.Program,
-	left goast.Expr, leftType string,
-	right goast.Expr, rightType string,
-	operator token.Token) (
+// transpileGenericSelectionExpr transpiles the generic selection `_Generic`
+// of C11. The association is selected by clang in according to the type of
+// controlling expression, so only the expression of selected association is
+// transpiled. The controlling expression is not evaluated in C.
+// Example of AST:
+//
+//     GenericSelectionExpr 0x55f1b4f0a3d8 <col:20, col:63> 'int'
+//     |-ImplicitCastExpr 0x55f1b4f0a3a0 <col:29> 'double' <LValueToRValue>
+//     | `-DeclRefExpr 0x55f1b4f0a380 <col:29> 'double' lvalue Var 0x55f1b4f0a2e8 'x' 'double'
+//     |-BuiltinType 0x55f1b4ec14c0 'double'
+//     |-case 'int'
+//     | |-BuiltinType 0x55f1b4ec1480 'int'
+//     | `-IntegerLiteral 0x55f1b4f0a400 <col:37> 'int' 1
+//     |-case 'double' selected
+//     | |-BuiltinType 0x55f1b4ec14c0 'double'
+//     | `-IntegerLiteral 0x55f1b4f0a420 <col:50> 'int' 2
+//     `-default
+//       `-IntegerLiteral 0x55f1b4f0a440 <col:62> 'int' 3
+//
+func transpileGenericSelectionExpr(n *ast.GenericSelectionExpr,
+	p *program.Program, exprIsStmt bool) (
 	_ goast.Expr, _ string, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("Cannot transpile pointerArithmetic. err = %v", err)
-		}
-	}()
-
-	if bl, ok := right.(*goast.BasicLit); ok && operator == token.ADD {
-		if bl.Value == "1" && bl.Kind == token.INT {
-			return &goast.SliceExpr{
-				X:      left,
-				Lbrack: 1,
-				Low: &goast.BasicLit{
-					Kind:  token.INT,
-					Value: "1",
-				},
-				Slice3: false,
-			}, leftType, preStmts, postStmts, nil
-		}
-	}
-
-	if !(types.IsCInteger(p, rightType) || rightType == "bool") {
-		err = fmt.Errorf("right type is not C integer type : '%s'", rightType)
-		return
-	}
-	if !types.IsPointer(leftType) {
-		err = fmt.Errorf("left type is not a pointer : '%s'", leftType)
-		return
-	}
-	right, err = types.CastExpr(p, right, rightType, "int")
+	selected, err := selectedAssociation(n)
 	if err != nil {
-		return
-	}
-
-	resolvedLeftType, err := types.ResolveType(p, leftType)
-	if err != nil {
-		return
-	}
-
-	type pA struct {
-		Name      string // name of variable: 'ptr'
-		Type      string // type of variable: 'int','double'
-		Condition string // condition : '-1' ,'(-1+2-2)'
-		Operator  string // operator : '+', '-'
-	}
-
-	var s pA
-
-	{
-		var buf bytes.Buffer
-		_ = printer.Fprint(&buf, token.NewFileSet(), left)
-		s.Name = buf.String()
-	}
-	{
-		var buf bytes.Buffer
-		_ = printer.Fprint(&buf, token.NewFileSet(), right)
-		s.Condition = buf.String()
-	}
-	s.Type = resolvedLeftType[2:]
-
-	s.Operator = "+"
-	if operator == token.SUB {
-		s.Operator = "-"
-	}
-
-	src := `package main
-func main(){
-	a := (*(*[1000000000]{{ .Type }})(unsafe.Pointer(uintptr(
-			unsafe.Pointer(&{{ .Name }}[0])) {{ .Operator }}
-			(uintptr)({{ .Condition }})*unsafe.Sizeof({{ .Name }}[0]))))[:]
-}`
-	tmpl := template.Must(template.New("").Parse(src))
-	var source bytes.Buffer
-	err = tmpl.Execute(&source, s)
-	if err != nil {
-		err = fmt.Errorf("Cannot execute template. err = %v", err)
-		return
+		return nil, "", nil, nil, err
 	}
+	return transpileToExpr(selected, p, exprIsStmt)
+}
 
-	// Create the AST by parsing src.
-	fset := token.NewFileSet() // positions are relative to fset
-	body := strings.Replace(source.String(), "&#43;", "+", -1)
-	body = strings.Replace(body, "&amp;", "&", -1)
-	f, err := parser.ParseFile(fset, "", body, 0)
-	if err != nil {
-		err = fmt.Errorf("Cannot parse file. err = %v", err)
-		return
+// selectedAssociation returns the expression of selected association of
+// generic selection `_Generic`.
+func selectedAssociation(n *ast.GenericSelectionExpr) (ast.Node, error) {
+	for _, c := range n.Children() {
+		a, ok := c.(*ast.GenericAssociation)
+		if !ok || !a.IsSelected || len(a.Children()) == 0 {
+			continue
+		}
+		// the expression is the last child of association
+		return a.Children()[len(a.Children())-1], nil
 	}
-
-	p.AddImport("unsafe")
-
-	return f.Decls[0].(*goast.FuncDecl).Body.List[0].(*goast.AssignStmt).Rhs[0],
-		leftType, preStmts, postStmts, nil
+	return nil, fmt.Errorf("Cannot find selected association of _Generic")
 }
 
 func transpileCompoundAssignOperator(
@@ -376,6 +465,13 @@ func transpileCompoundAssignOperator(
 		if right == nil {
 			right = util.NewNil()
 		}
+		// the count is masked by the width of promoted type, for example
+		// `short s; s <<= 16` is the shift of int
+		computationType := n.ComputationLHSType
+		if computationType == "" {
+			computationType = leftType
+		}
+		right = shiftCount(p, right, computationType)
 	}
 
 	resolvedLeftType, err := types.ResolveType(p, leftType)
@@ -383,6 +479,32 @@ func transpileCompoundAssignOperator(
 		p.AddMessage(p.GenerateWarningMessage(err, n))
 	}
 
+	// Go has no arithmetic of boolean values, so the compound assignment
+	// is calculated in the computation type:
+	//
+	//     b += 2 -> b = noarch.BoolToInt(b)+2 != 0
+	//
+	if resolvedLeftType == "bool" && n.ComputationLHSType != "" {
+		var l, r, v goast.Expr
+		l, err = types.CastExpr(p, left, leftType, n.ComputationLHSType)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		r, err = types.CastExpr(p, right, rightType, n.ComputationLHSType)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		v, err = types.CastExpr(p,
+			util.NewBinaryExpr(l, convertToWithoutAssign(operator), r,
+				n.ComputationResultType, false),
+			n.ComputationResultType, leftType)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		return util.NewBinaryExpr(left, token.ASSIGN, v, resolvedLeftType, exprIsStmt),
+			n.Type, preStmts, postStmts, nil
+	}
+
 	if right == nil {
 		err = fmt.Errorf("Right part is nil. err = %v", err)
 		return nil, "", nil, nil, err
@@ -494,10 +616,34 @@ func convertToWithoutAssign(operator token.Token) token.Token {
 		return token.MUL
 	case token.QUO_ASSIGN: // "/="
 		return token.QUO
+	case token.REM_ASSIGN: // "%="
+		return token.REM
+	case token.AND_ASSIGN: // "&="
+		return token.AND
+	case token.OR_ASSIGN: // "|="
+		return token.OR
+	case token.XOR_ASSIGN: // "^="
+		return token.XOR
+	case token.SHL_ASSIGN: // "<<="
+		return token.SHL
+	case token.SHR_ASSIGN: // ">>="
+		return token.SHR
 	}
 	panic(fmt.Sprintf("not support operator: %v", operator))
 }
 
+// isAssignOperator returns true for operators of assignment, for example
+// "=" or "|=".
+func isAssignOperator(operator token.Token) bool {
+	switch operator {
+	case token.ASSIGN, token.ADD_ASSIGN, token.SUB_ASSIGN, token.MUL_ASSIGN,
+		token.QUO_ASSIGN, token.REM_ASSIGN, token.AND_ASSIGN, token.OR_ASSIGN,
+		token.XOR_ASSIGN, token.SHL_ASSIGN, token.SHR_ASSIGN:
+		return true
+	}
+	return false
+}
+
 func findUnaryWithInteger(node ast.Node) (*ast.UnaryOperator, bool) {
 	switch n := node.(type) {
 	case *ast.UnaryOperator:
@@ -524,7 +670,7 @@ func atomicOperation(n ast.Node, p *program.Program) (
 	switch v := n.(type) {
 	case *ast.UnaryOperator:
 		switch v.Operator {
-		case "&", "*", "!", "-":
+		case "&", "*", "!", "-", "+", "~", "__extension__", "__real", "__imag":
 			return
 		}
 		// UnaryOperator 0x252d798 <col:17, col:18> 'double' prefix '-'
@@ -857,6 +1003,15 @@ func atomicOperation(n ast.Node, p *program.Program) (
 			}
 			exprType = v.Type
 		}
+
+		// Convert from struct member array to slice
+		if types.IsCPointer(v.Type) && isStructMemberArray(v.Children()[0]) {
+			expr = &goast.SliceExpr{
+				X:      expr,
+				Lbrack: 1,
+				Slice3: false,
+			}
+		}
 		return
 
 	case *ast.BinaryOperator:
@@ -879,8 +1034,6 @@ func atomicOperation(n ast.Node, p *program.Program) (
 			//   | `-IntegerLiteral 0x3c423b8 <col:30> 'int' 0
 			//   `-ImplicitCastExpr 0x3c42428 <col:32> 'int' <LValueToRValue>
 			//     `-DeclRefExpr 0x3c42400 <col:32> 'int' lvalue Var 0x3c3cf60 'iterator' 'int'
-			varName := "tempVar"
-
 			expr, exprType, preStmts, postStmts, err = transpileToExpr(v.Children()[0], p, false)
 			if err != nil {
 				return
@@ -903,14 +1056,11 @@ func atomicOperation(n ast.Node, p *program.Program) (
 			body := append(inBody, preStmts...)
 			preStmts = nil
 
-			body = append(body, &goast.AssignStmt{
-				Lhs: []goast.Expr{util.NewIdent(varName)},
-				Tok: token.DEFINE,
-				Rhs: []goast.Expr{&goast.UnaryExpr{
-					Op: token.AND,
-					X:  expr,
-				}},
-			})
+			// value of right part is not addressable, for example: `a > 1`
+			expr, err = types.CastExpr(p, expr, exprType, v.Type)
+			if err != nil {
+				return
+			}
 
 			var exprResolveType string
 			exprResolveType, err = types.ResolveType(p, v.Type)
@@ -918,12 +1068,10 @@ func atomicOperation(n ast.Node, p *program.Program) (
 				return
 			}
 
-			expr = util.NewAnonymousFunction(body, postStmts,
-				&goast.UnaryExpr{
-					Op: token.MUL,
-					X:  util.NewIdent(varName),
-				},
-				exprResolveType)
+			if len(postStmts) == 0 {
+				postStmts = nil
+			}
+			expr = util.NewAnonymousFunction(body, postStmts, expr, exprResolveType)
 			preStmts = nil
 			postStmts = nil
 			exprType = v.Type
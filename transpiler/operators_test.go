@@ -0,0 +1,53 @@
+package transpiler
+
+import (
+	"bytes"
+	"go/printer"
+	"go/token"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/program"
+)
+
+func TestCompoundShift(t *testing.T) {
+	// short s; unsigned char c; unsigned int u; int n;
+	// s <<= n;
+	// c <<= n;
+	// u >>= n;
+	tree := parseTree(t, `
+CompoundStmt 0x1 <file.c:1:1, line:5:1>
+|-CompoundAssignOperator 0x2 <line:2:3, col:10> 'short' '<<=' ComputeLHSTy='int' ComputeResultTy='int'
+| |-DeclRefExpr 0x3 <col:3> 'short' lvalue Var 0x10 's' 'short'
+| `+"`"+`-ImplicitCastExpr 0x4 <col:10> 'int' <LValueToRValue>
+|   `+"`"+`-DeclRefExpr 0x5 <col:10> 'int' lvalue Var 0x13 'n' 'int'
+|-CompoundAssignOperator 0x6 <line:3:3, col:10> 'unsigned char' '<<=' ComputeLHSTy='int' ComputeResultTy='int'
+| |-DeclRefExpr 0x7 <col:3> 'unsigned char' lvalue Var 0x11 'c' 'unsigned char'
+| `+"`"+`-ImplicitCastExpr 0x8 <col:10> 'int' <LValueToRValue>
+|   `+"`"+`-DeclRefExpr 0x9 <col:10> 'int' lvalue Var 0x13 'n' 'int'
+`+"`"+`-CompoundAssignOperator 0x20 <line:4:3, col:10> 'unsigned int' '>>=' ComputeLHSTy='unsigned int' ComputeResultTy='unsigned int'
+  |-DeclRefExpr 0x21 <col:3> 'unsigned int' lvalue Var 0x12 'u' 'unsigned int'
+  `+"`"+`-ImplicitCastExpr 0x22 <col:10> 'int' <LValueToRValue>
+    `+"`"+`-DeclRefExpr 0x23 <col:10> 'int' lvalue Var 0x13 'n' 'int'
+`)
+
+	p := program.NewProgram()
+	for i, expected := range []string{
+		// the count is masked by the width of int, not short
+		"s <<= (uint64(n) & 31)",
+		"c <<= (uint64(n) & 31)",
+		"u >>= (uint64(n) & 31)",
+	} {
+		expr, _, _, _, err := transpileToExpr(tree.Children()[i], p, true)
+		if err != nil {
+			t.Fatalf("Expression %d: %v", i, err)
+		}
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != expected {
+			t.Errorf("Expression %d: not expected Go code:\n%s\nExpected:\n%s",
+				i, buf.String(), expected)
+		}
+	}
+}
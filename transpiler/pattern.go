@@ -0,0 +1,348 @@
+package transpiler
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"text/template"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// Names of fields of Go implementations of C data structures.
+const (
+	patternMapField  = "c4goMap"
+	patternListField = "c4goList"
+)
+
+// patternRole is the function of API of data structure. Types of arguments
+// and result are:
+//
+//     'T' - pointer to struct of data structure;
+//     'K' - key of hash table;
+//     'V' - value;
+//     'I' - integer;
+//     '?' - integer or void (only for result);
+//     ' ' - void (only for result).
+//
+type patternRole struct {
+	args   string
+	result byte
+	body   string
+}
+
+// patternRoles is the Go implementations of functions of API. Integer
+// results of functions, which change the data structure, are zero.
+var patternRoles = map[program.PatternKind]map[string]patternRole{
+	program.PatternMap: {
+		"new": {"", 'T', `return {{.Result}}{{"{{"}}c4goMap: map[{{.Key}}]{{.Value}}{}}}`},
+		"init": {"T", '?', `{{.S}}[0].c4goMap = map[{{.Key}}]{{.Value}}{}
+			{{if .Result}}return 0{{end}}`},
+		"clear": {"T", '?', `{{.S}}[0].c4goMap = map[{{.Key}}]{{.Value}}{}
+			{{if .Result}}return 0{{end}}`},
+		"free": {"T", ' ', ``},
+		"put": {"TKV", '?', `if {{.S}}[0].c4goMap == nil {
+				{{.S}}[0].c4goMap = map[{{.Key}}]{{.Value}}{}
+			}
+			{{.S}}[0].c4goMap[{{.K}}] = {{.V}}
+			{{if .Result}}return 0{{end}}`},
+		"get": {"TK", 'V', `return {{.S}}[0].c4goMap[{{.K}}]`},
+		"has": {"TK", 'I', `if _, ok := {{.S}}[0].c4goMap[{{.K}}]; ok {
+				return 1
+			}
+			return 0`},
+		"remove": {"TK", '?', `{{if .Result}}if _, ok := {{.S}}[0].c4goMap[{{.K}}]; !ok {
+				return 0
+			}
+			{{end}}delete({{.S}}[0].c4goMap, {{.K}})
+			{{if .Result}}return 1{{end}}`},
+		"len": {"T", 'I', `return {{.Result}}(len({{.S}}[0].c4goMap))`},
+	},
+	program.PatternList: {
+		"new":   {"", 'T', `return make({{.Result}}, 1)`},
+		"init":  {"T", '?', `{{.S}}[0].c4goList.Init(){{if .Result}}; return 0{{end}}`},
+		"clear": {"T", '?', `{{.S}}[0].c4goList.Init(){{if .Result}}; return 0{{end}}`},
+		"free":  {"T", ' ', ``},
+		"push_back": {"TV", '?',
+			`{{.S}}[0].c4goList.PushBack({{.V}}){{if .Result}}; return 0{{end}}`},
+		"push_front": {"TV", '?',
+			`{{.S}}[0].c4goList.PushFront({{.V}}){{if .Result}}; return 0{{end}}`},
+		"pop_front": {"T", 'V', `if c4goElem := {{.S}}[0].c4goList.Front(); c4goElem != nil {
+				return {{.S}}[0].c4goList.Remove(c4goElem).({{.Value}})
+			}
+			var c4goZero {{.Value}}
+			return c4goZero`},
+		"pop_back": {"T", 'V', `if c4goElem := {{.S}}[0].c4goList.Back(); c4goElem != nil {
+				return {{.S}}[0].c4goList.Remove(c4goElem).({{.Value}})
+			}
+			var c4goZero {{.Value}}
+			return c4goZero`},
+		"front": {"T", 'V', `if c4goElem := {{.S}}[0].c4goList.Front(); c4goElem != nil {
+				return c4goElem.Value.({{.Value}})
+			}
+			var c4goZero {{.Value}}
+			return c4goZero`},
+		"back": {"T", 'V', `if c4goElem := {{.S}}[0].c4goList.Back(); c4goElem != nil {
+				return c4goElem.Value.({{.Value}})
+			}
+			var c4goZero {{.Value}}
+			return c4goZero`},
+		"get": {"TI", 'V', `c4goIndex := 0
+			for c4goElem := {{.S}}[0].c4goList.Front(); c4goElem != nil; c4goElem = c4goElem.Next() {
+				if c4goIndex == int({{.I}}) {
+					return c4goElem.Value.({{.Value}})
+				}
+				c4goIndex++
+			}
+			var c4goZero {{.Value}}
+			return c4goZero`},
+		"len": {"T", 'I', `return {{.Result}}({{.S}}[0].c4goList.Len())`},
+	},
+}
+
+// patternOfStruct returns the data structure of option "-pattern" for the
+// name of C struct.
+func patternOfStruct(p *program.Program, name string) (*program.Pattern, bool) {
+	pattern, ok := p.Patterns[strings.TrimPrefix(name, "struct ")]
+	return pattern, ok
+}
+
+// patternKeyType returns the Go type of keys of hash table. Keys of type
+// "char *" are Go strings.
+func patternKeyType(p *program.Program, pattern *program.Pattern) (
+	goType string, isString bool, err error) {
+	if patternCType(p, pattern.Key) == "char *" {
+		return "string", true, nil
+	}
+	goType, err = types.ResolveType(p, pattern.Key)
+	if err != nil {
+		return
+	}
+	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "func") {
+		err = fmt.Errorf("key of type `%s` is not comparable in Go", pattern.Key)
+	}
+	return
+}
+
+// patternFields returns the field of Go struct, which replaces all fields
+// of C struct of data structure. For example, C code:
+//
+//     struct table {
+//         struct entry **buckets;
+//         int size;
+//     };
+//
+// with the configuration:
+//
+//     {"type": "struct table", "key": "char *", "value": "int", ...}
+//
+// is transpiled to Go code:
+//
+//     type table struct {
+//         c4goMap map[string]int32
+//     }
+//
+// Linked lists are replaced by noarch.List.
+func patternFields(p *program.Program, pattern *program.Pattern) (
+	fields []*goast.Field, err error) {
+	value, err := types.ResolveType(p, pattern.Value)
+	if err != nil {
+		return
+	}
+	switch pattern.Kind {
+	case program.PatternMap:
+		var key string
+		key, _, err = patternKeyType(p, pattern)
+		if err != nil {
+			return
+		}
+		fields = append(fields, &goast.Field{
+			Names: []*goast.Ident{util.NewIdent(patternMapField)},
+			Type: &goast.MapType{
+				Key:   util.NewTypeIdent(key),
+				Value: util.NewTypeIdent(value),
+			},
+		})
+	case program.PatternList:
+		fields = append(fields, &goast.Field{
+			Names: []*goast.Ident{util.NewIdent(patternListField)},
+			Type: util.NewTypeIdent(p.ImportType(
+				"github.com/Konstantin8105/c4go/noarch.List")),
+		})
+	default:
+		err = fmt.Errorf("undefined kind of data structure `%s`", pattern.Kind)
+	}
+	return
+}
+
+// patternMemberError returns the error for access to fields of C struct of
+// data structure, because these fields are removed from Go struct.
+func patternMemberError(p *program.Program, structType *program.Struct,
+	n *ast.MemberExpr) error {
+	if structType == nil {
+		return nil
+	}
+	pattern, ok := patternOfStruct(p, structType.Name)
+	if !ok {
+		return nil
+	}
+	return fmt.Errorf("field `%s` of struct `%s` is removed by option -pattern, "+
+		"use functions of API of %s", n.Name, pattern.Name, pattern.Kind)
+}
+
+// patternCType returns the C type without qualifiers and typedefs.
+func patternCType(p *program.Program, cType string) string {
+	cType = strings.Join(strings.Fields(types.CleanCType(cType)), " ")
+	return strings.Join(strings.Fields(types.CleanCType(baseTypedef(p, cType))), " ")
+}
+
+// isPatternPointer returns true, if the C type is pointer to struct of data
+// structure.
+func isPatternPointer(p *program.Program, cType string,
+	pattern *program.Pattern) bool {
+	cType = patternCType(p, cType)
+	if !strings.HasSuffix(cType, " *") {
+		return false
+	}
+	name := strings.TrimPrefix(
+		patternCType(p, strings.TrimSuffix(cType, " *")), "struct ")
+	if name == pattern.Name {
+		return true
+	}
+	s := p.GetStruct("struct " + name)
+	return s != nil && strings.TrimPrefix(s.Name, "struct ") == pattern.Name
+}
+
+// isPatternType returns true, if the C type is the type of role.
+func isPatternType(p *program.Program, cType string, kind byte,
+	pattern *program.Pattern) bool {
+	switch kind {
+	case 'T':
+		return isPatternPointer(p, cType, pattern)
+	case 'K':
+		return patternCType(p, cType) == patternCType(p, pattern.Key)
+	case 'V':
+		return patternCType(p, cType) == patternCType(p, pattern.Value)
+	case 'I':
+		return types.IsCInteger(p, patternCType(p, cType))
+	case '?':
+		return cType == "void" || types.IsCInteger(p, patternCType(p, cType))
+	case ' ':
+		return cType == "void"
+	}
+	return false
+}
+
+// transpilePatternFunction returns the Go implementation of function of API
+// of data structure in according to option "-pattern". Signature of C
+// function is not changed. For example, C function:
+//
+//     int table_get(struct table *t, const char *key) { ... }
+//
+// is transpiled to Go code:
+//
+//     func table_get(t []table, key []byte) int32 {
+//         return t[0].c4goMap[noarch.CStringToString(key)]
+//     }
+//
+// If the signature is not the same as expected for the role of function,
+// then the warning is added and the C body is transpiled.
+func transpilePatternFunction(p *program.Program, n *ast.FunctionDecl) (
+	body *goast.BlockStmt, ok bool) {
+	pattern, role, ok := p.PatternFunction(n.Name)
+	if !ok {
+		return nil, false
+	}
+	body, err := patternFunctionBody(p, n, pattern, role)
+	if err != nil {
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"function `%s` is not replaced by `%s` of %s: %v",
+			n.Name, role, pattern.Kind, err), n))
+		return nil, false
+	}
+	return body, true
+}
+
+func patternFunctionBody(p *program.Program, n *ast.FunctionDecl,
+	pattern *program.Pattern, role string) (_ *goast.BlockStmt, err error) {
+	r, ok := patternRoles[pattern.Kind][role]
+	if !ok {
+		return nil, fmt.Errorf("role is not supported")
+	}
+	f := p.GetFunctionDefinition(n.Name)
+	args := f.ArgumentTypes
+	if len(args) == 1 && args[0] == "void" {
+		args = nil
+	}
+	fieldList, err := getFieldList(p, n, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(r.args) || len(fieldList.List) != len(r.args) {
+		return nil, fmt.Errorf("amount of arguments is %d, but expected %d",
+			len(args), len(r.args))
+	}
+
+	var data struct {
+		S, K, V, I string // names of arguments
+		Key, Value string // Go types of key and value
+		Result     string // Go type of result
+	}
+	for i := range args {
+		if !isPatternType(p, args[i], r.args[i], pattern) {
+			return nil, fmt.Errorf("argument %d has not valid type `%s`",
+				i+1, args[i])
+		}
+		name := fieldList.List[i].Names[0].Name
+		switch r.args[i] {
+		case 'T':
+			data.S = name
+		case 'K':
+			data.K = name
+		case 'V':
+			data.V = name
+		case 'I':
+			data.I = name
+		}
+	}
+	if !isPatternType(p, f.ReturnType, r.result, pattern) {
+		return nil, fmt.Errorf("result has not valid type `%s`", f.ReturnType)
+	}
+	if f.ReturnType != "void" {
+		if data.Result, err = types.ResolveType(p, f.ReturnType); err != nil {
+			return nil, err
+		}
+	}
+	if data.Value, err = types.ResolveType(p, pattern.Value); err != nil {
+		return nil, err
+	}
+	if pattern.Kind == program.PatternMap {
+		var isString bool
+		data.Key, isString, err = patternKeyType(p, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if isString && data.K != "" {
+			data.K = fmt.Sprintf("%s(%s)", p.ImportType(
+				"github.com/Konstantin8105/c4go/noarch.CStringToString"), data.K)
+		}
+	}
+
+	var source bytes.Buffer
+	tmpl := template.Must(template.New("").Parse(r.body))
+	if err = tmpl.Execute(&source, data); err != nil {
+		return nil, err
+	}
+	src := fmt.Sprintf("package main\nfunc main() {\n%s\n}", source.String())
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse source \"%s\" : %v", src, err)
+	}
+	return file.Decls[0].(*goast.FuncDecl).Body, nil
+}
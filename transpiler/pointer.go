@@ -0,0 +1,164 @@
+// This file contains functions for transpiling the pointer arithmetic. The
+// pointers of C are slices in Go, the model of pointers is described in
+// package noarch, see noarch.PointerAdd. All operations of pointers are
+// transpiled by the functions of this file:
+//
+//     p + 2, p++, p += 2    ->  p[2:]
+//     p + n, p += n         ->  noarch.PointerAdd(p, n)
+//     p - n, p--, p -= n    ->  noarch.PointerAdd(p, -n)
+//     *(p - 1)              ->  noarch.PointerAdd(p, -1)[0]
+//     &a[2]                 ->  a[2:]
+//     &a[i]                 ->  noarch.PointerAdd(a, i)
+//     p - q                 ->  noarch.PointerDiff(p, q)
+//     p < q, p == q         ->  noarch.PointerCompare(p, q) < 0
+//
+
+package transpiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// pointerArithmetic - operations between 'int' and pointer
+// Example C code : ptr += i
+// , where i  - right
+//        '+' - operator
+//      'ptr' - left
+// Note: pointerArithmetic - implemented ONLY right part of formula
+func pointerArithmetic(p *program.Program,
+	left goast.Expr, leftType string,
+	right goast.Expr, rightType string,
+	operator token.Token) (
+	_ goast.Expr, _ string, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot transpile pointerArithmetic. err = %v", err)
+		}
+	}()
+
+	if !(types.IsCInteger(p, rightType) || rightType == "bool") {
+		err = fmt.Errorf("right type is not C integer type : '%s'", rightType)
+		return
+	}
+	if !types.IsPointer(leftType) {
+		err = fmt.Errorf("left type is not a pointer : '%s'", leftType)
+		return
+	}
+	right, err = types.CastExpr(p, right, rightType, "int")
+	if err != nil {
+		return
+	}
+
+	resolvedLeftType, err := types.ResolveType(p, leftType)
+	if err != nil {
+		return
+	}
+	if !strings.HasPrefix(resolvedLeftType, "[]") {
+		err = fmt.Errorf("pointer arithmetic is not supported for type : '%s'",
+			leftType)
+		return
+	}
+
+	if operator == token.SUB {
+		switch v := right.(type) {
+		case *goast.BasicLit:
+			right = &goast.BasicLit{Kind: v.Kind, Value: "-" + v.Value}
+		case *goast.Ident, *goast.CallExpr, *goast.ParenExpr:
+			right = &goast.UnaryExpr{Op: token.SUB, X: right}
+		default:
+			right = &goast.UnaryExpr{Op: token.SUB, X: &goast.ParenExpr{X: right}}
+		}
+	}
+
+	return pointerOffset(p, left, right),
+		leftType, preStmts, postStmts, nil
+}
+
+// pointerOffset returns the pointer moved by offset elements. The pointer
+// is moved backward by the array before the pointer, it is not visible for
+// Go, so only the constant non-negative offsets are slicing of pointer and
+// other offsets, which may be negative at runtime, are moved by
+// noarch.PointerAdd.
+func pointerOffset(p *program.Program, pointer, offset goast.Expr) goast.Expr {
+	if isNonNegativeConstant(offset) {
+		return &goast.SliceExpr{
+			X:   pointer,
+			Low: offset,
+		}
+	}
+	p.AddImport("github.com/Konstantin8105/c4go/noarch")
+	return util.NewCallExpr("noarch.PointerAdd", pointer, offset)
+}
+
+// isNonNegativeConstant returns true, if the integer expression is the
+// constant, which is not negative, like `1` or `(2)`.
+func isNonNegativeConstant(expr goast.Expr) bool {
+	switch v := expr.(type) {
+	case *goast.ParenExpr:
+		return isNonNegativeConstant(v.X)
+	case *goast.BasicLit:
+		return v.Kind == token.INT && !strings.HasPrefix(v.Value, "-")
+	}
+	return false
+}
+
+// isNegative returns true, if the integer expression is explicitly
+// negative, like `-n`, `-1` or `0 - n` of pointer arithmetic `*(p - n)`.
+func isNegative(expr goast.Expr) bool {
+	switch v := expr.(type) {
+	case *goast.ParenExpr:
+		return isNegative(v.X)
+	case *goast.BasicLit:
+		return strings.HasPrefix(v.Value, "-")
+	case *goast.UnaryExpr:
+		return v.Op == token.SUB
+	case *goast.BinaryExpr:
+		if v.Op != token.ADD && v.Op != token.SUB {
+			return false
+		}
+		if zero, ok := v.X.(*goast.BasicLit); ok && zero.Value == "0" {
+			return v.Op == token.SUB
+		}
+		return isNegative(v.X)
+	}
+	return false
+}
+
+// isPointerOperands returns true, if both operands of binary operator are
+// pointers of data, but not NULL and not pointers of functions.
+func isPointerOperands(p *program.Program, leftType, rightType string) bool {
+	for _, t := range []string{leftType, rightType} {
+		if !types.IsPointer(t) || t == types.NullPointer ||
+			types.IsFunction(t) || types.IsTypedefFunction(p, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// pointerDifference returns the amount of elements between pointers
+// `left - right`. Argument cType is the C type of result.
+func pointerDifference(p *program.Program, left, right goast.Expr,
+	cType string) (goast.Expr, error) {
+	p.AddImport("github.com/Konstantin8105/c4go/noarch")
+	return types.CastExpr(p, util.NewCallExpr("noarch.PointerDiff", left, right),
+		"int", cType)
+}
+
+// pointerComparison returns the comparison of addresses of pointers.
+func pointerComparison(p *program.Program, left goast.Expr,
+	operator token.Token, right goast.Expr) goast.Expr {
+	p.AddImport("github.com/Konstantin8105/c4go/noarch")
+	return &goast.BinaryExpr{
+		X:  util.NewCallExpr("noarch.PointerCompare", left, right),
+		Op: operator,
+		Y:  util.NewIntLit(0),
+	}
+}
@@ -0,0 +1,179 @@
+package transpiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+// refCountFunction returns the kind of function of reference counting from
+// option "-refcount" for the call of function. Calls inside of functions of
+// reference counting are not removed.
+func refCountFunction(p *program.Program, n *ast.CallExpr) (
+	kind program.RefCountKind, ok bool) {
+	if len(p.RefCounting.Functions) == 0 || isRefCountFunctionBody(p) {
+		return
+	}
+	name, err := getNameOfFunctionFromCallExpr(p, n)
+	if err != nil {
+		return
+	}
+	kind, ok = p.RefCounting.Functions[name]
+	return
+}
+
+// isRefCountFunctionBody returns true, if the current function is the
+// function of reference counting.
+func isRefCountFunctionBody(p *program.Program) bool {
+	if p.Function == nil {
+		return false
+	}
+	_, ok := p.RefCounting.Functions[p.Function.Name]
+	return ok
+}
+
+// refCountField returns the name of field with reference counter, which is
+// changed by the operator. Examples of C code:
+//
+//     obj->refcount++;
+//     --obj->refcount;
+//     obj->refcount = 1;
+//     obj->refcount -= 1;
+//
+func refCountField(p *program.Program, n ast.Node) (name string, ok bool) {
+	if len(p.RefCounting.Fields) == 0 || isRefCountFunctionBody(p) {
+		return
+	}
+	var lhs ast.Node
+	switch v := n.(type) {
+	case *ast.UnaryOperator:
+		if v.Operator == "++" || v.Operator == "--" {
+			lhs = v.Children()[0]
+		}
+	case *ast.BinaryOperator:
+		if v.Operator == "=" {
+			lhs = v.Children()[0]
+		}
+	case *ast.CompoundAssignOperator:
+		lhs = v.Children()[0]
+	}
+	for {
+		par, ok := lhs.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		lhs = par.Children()[0]
+	}
+	member, ok := lhs.(*ast.MemberExpr)
+	if !ok || !p.RefCounting.Fields[member.Name] {
+		return "", false
+	}
+	return member.Name, true
+}
+
+// removeRefCount adds the site of removed reference counting in the report.
+func removeRefCount(p *program.Program, n ast.Node, format string, args ...interface{}) {
+	p.RefCounting.Removed = append(p.RefCounting.Removed,
+		fmt.Sprintf("%s: %s", strings.TrimSpace(n.Position().GetSimpleLocation()),
+			fmt.Sprintf(format, args...)))
+}
+
+// transpileRefCountStmt removes the statement of reference counting from
+// option "-refcount". Arguments of removed functions are kept for side
+// effects and for avoid of unused variables, like for function free():
+//
+//     obj_unref(obj);     ->  _ = obj
+//     obj->refcount++;    ->  (removed)
+//
+func transpileRefCountStmt(n ast.Node, p *program.Program) (
+	stmt goast.Stmt, preStmts []goast.Stmt, postStmts []goast.Stmt,
+	ok bool, err error) {
+
+	// Example of C code: (void)obj_unref(obj);
+	if v, isCast := n.(*ast.CStyleCastExpr); isCast && v.Kind == ast.CStyleCastExprToVoid {
+		n = v.Children()[0]
+	}
+
+	if name, isField := refCountField(p, n); isField {
+		removeRefCount(p, n, "change of field %s", name)
+		return &goast.EmptyStmt{}, nil, nil, true, nil
+	}
+
+	call, isCall := n.(*ast.CallExpr)
+	if !isCall {
+		return
+	}
+	if _, isRefCount := refCountFunction(p, call); !isRefCount {
+		return
+	}
+	name, _ := getNameOfFunctionFromCallExpr(p, call)
+	removeRefCount(p, n, "call of %s", name)
+
+	for _, arg := range call.Children()[1:] {
+		e, _, newPre, newPost, err := transpileToExpr(arg, p, false)
+		if err != nil {
+			return nil, nil, nil, true, err
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		preStmts = append(preStmts, &goast.AssignStmt{
+			Lhs: []goast.Expr{goast.NewIdent("_")},
+			Tok: token.ASSIGN,
+			Rhs: []goast.Expr{e},
+		})
+	}
+	if len(preStmts) == 0 {
+		stmt = &goast.EmptyStmt{}
+	}
+	return stmt, preStmts, postStmts, true, nil
+}
+
+// transpileRefCountExpr replaces the call of function of reference counting
+// with kind "inc" in expression by the first argument, because the function
+// returns the same object:
+//
+//     list->head = obj_ref(node);  ->  list.head = node
+//
+// Calls of other functions of reference counting in expressions are not
+// removed, because the result of function is unknown.
+func transpileRefCountExpr(n *ast.CallExpr, p *program.Program) (
+	expr goast.Expr, exprType string, preStmts []goast.Stmt,
+	postStmts []goast.Stmt, ok bool, err error) {
+
+	kind, isRefCount := refCountFunction(p, n)
+	if !isRefCount {
+		return
+	}
+	name, _ := getNameOfFunctionFromCallExpr(p, n)
+	if kind != program.RefCountInc || len(n.Children()) < 2 ||
+		strings.TrimSpace(n.Type) == "void" {
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"call of %s in expression is not removed by option -refcount", name), n))
+		return
+	}
+	removeRefCount(p, n, "call of %s", name)
+
+	expr, exprType, preStmts, postStmts, err = transpileToExpr(n.Children()[1], p, false)
+	if err != nil {
+		return nil, "", nil, nil, true, err
+	}
+	expr, err = types.CastExpr(p, expr, exprType, n.Type)
+	if err != nil {
+		return nil, "", nil, nil, true, err
+	}
+	return expr, n.Type, preStmts, postStmts, true, nil
+}
+
+// refCountReport returns the report of removed sites of reference counting
+// as comment of Go code.
+func refCountReport(p *program.Program) string {
+	if len(p.RefCounting.Removed) == 0 {
+		return ""
+	}
+	return "// Reference counting is removed by option -refcount:\n//\t" +
+		strings.Join(p.RefCounting.Removed, "\n//\t")
+}
@@ -0,0 +1,205 @@
+package transpiler
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+// replacementOfCall returns the definition of function for the call of C
+// function, which is replaced by the Go function of project in according to
+// option "-replace". For example, the configuration:
+//
+//     {"c": "strlcpy", "go": "github.com/user/project/cutil.Strlcpy",
+//      "signature": "func([]byte, []byte, uint32) uint32"}
+//
+// transpiles C code:
+//
+//     strlcpy(dst, src, n);
+//
+// to Go code:
+//
+//     cutil.Strlcpy(dst, src, n)
+//
+// If the Go signature of C function is not the same as the signature of
+// replacement, then the call is not replaced and the warning is added.
+func replacementOfCall(p *program.Program, n *ast.CallExpr, name string) (
+	def *program.FunctionDefinition, ok bool) {
+	r, ok := p.Replacements[name]
+	if !ok {
+		return nil, false
+	}
+	cType, err := calledFunctionType(n)
+	if err == nil {
+		def, err = checkReplacement(p, cType, name, r)
+	}
+	if err != nil {
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"function `%s` is not replaced by `%s`: %v", name, r.Function, err), n))
+		return nil, false
+	}
+	return def, true
+}
+
+// calledFunctionType returns the C type of called function.
+func calledFunctionType(n *ast.CallExpr) (cType string, err error) {
+	if len(n.Children()) == 0 {
+		return "", fmt.Errorf("cannot find function")
+	}
+	impl, ok := n.Children()[0].(*ast.ImplicitCastExpr)
+	if !ok {
+		return "", fmt.Errorf("cannot find type of function in %T", n.Children()[0])
+	}
+	return impl.Type, nil
+}
+
+// checkReplacement compares the Go signature of C function with the
+// signature of replacement.
+func checkReplacement(p *program.Program, cType, name string,
+	r program.Replacement) (_ *program.FunctionDefinition, err error) {
+	if t, ok := p.TypedefType[cType]; ok {
+		cType = t
+	}
+	_, fields, returns, err := types.ParseFunction(cType)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 1 && fields[0] == "void" {
+		fields = nil
+	}
+	// types of package main cannot be used in package of project, so
+	// arguments are converted to base types of typedefs
+	for i := range fields {
+		fields[i] = baseTypedef(p, fields[i])
+	}
+
+	// Go types of C function
+	var cArgs []string
+	for _, field := range fields {
+		if field == "..." {
+			cArgs = append(cArgs, "...interface{}")
+			continue
+		}
+		t, err := types.ResolveType(p, field)
+		if err != nil {
+			return nil, err
+		}
+		cArgs = append(cArgs, goTypeString(t))
+	}
+	returnType := "void"
+	if len(returns) > 0 {
+		returnType = baseTypedef(p, returns[0])
+	}
+	var cResult string
+	if returnType != "void" {
+		cResult, err = types.ResolveType(p, returnType)
+		if err != nil {
+			return nil, err
+		}
+		cResult = goTypeString(cResult)
+	}
+
+	// Go types of replacement
+	goArgs, goResults, err := parseSignature(r.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cArgs) != len(goArgs) {
+		return nil, fmt.Errorf("amount of arguments is %d, but expected %d",
+			len(goArgs), len(cArgs))
+	}
+	for i := range cArgs {
+		if cArgs[i] != goArgs[i] {
+			return nil, fmt.Errorf("argument %d has type `%s`, but expected `%s`",
+				i+1, goArgs[i], cArgs[i])
+		}
+	}
+	switch {
+	case cResult == "" && len(goResults) != 0:
+		return nil, fmt.Errorf("result is not expected")
+	case cResult != "" && (len(goResults) != 1 || goResults[0] != cResult):
+		return nil, fmt.Errorf("result has type `%s`, but expected `%s`",
+			strings.Join(goResults, ", "), cResult)
+	}
+
+	return &program.FunctionDefinition{
+		Name:          name,
+		ReturnType:    returnType,
+		ArgumentTypes: fields,
+		Substitution:  r.Function,
+	}, nil
+}
+
+// baseTypedef returns the base C type of typedef, like "unsigned long" for
+// "size_t". Other types are returned as is.
+func baseTypedef(p *program.Program, cType string) string {
+	for i := 0; i < 100; i++ {
+		t, ok := p.TypedefType[cType]
+		if !ok || t == cType {
+			break
+		}
+		cType = t
+	}
+	return cType
+}
+
+// parseSignature returns types of arguments and results of Go function
+// signature, like "func(a, b []byte) int".
+func parseSignature(signature string) (args, results []string, err error) {
+	expr, err := parser.ParseExpr(signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot parse signature `%s`: %v", signature, err)
+	}
+	f, ok := expr.(*goast.FuncType)
+	if !ok {
+		return nil, nil, fmt.Errorf("signature `%s` is not function", signature)
+	}
+	list := func(fields *goast.FieldList) (types []string) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			amount := len(field.Names)
+			if amount == 0 {
+				amount = 1
+			}
+			for i := 0; i < amount; i++ {
+				types = append(types, printType(field.Type))
+			}
+		}
+		return
+	}
+	return list(f.Params), list(f.Results), nil
+}
+
+// goTypeString returns the Go type in the same form as in parseSignature.
+func goTypeString(t string) string {
+	expr, err := parser.ParseExpr(t)
+	if err != nil {
+		return t
+	}
+	return printType(expr)
+}
+
+// printType returns the Go type without names of arguments and results of
+// functions, for example "func(interface{}, interface{}) int".
+func printType(t goast.Expr) string {
+	goast.Inspect(t, func(node goast.Node) bool {
+		if f, ok := node.(*goast.Field); ok {
+			f.Names = nil
+		}
+		return true
+	})
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, token.NewFileSet(), t)
+	return buf.String()
+}
@@ -6,7 +6,6 @@ package transpiler
 import (
 	"fmt"
 	goast "go/ast"
-	"go/token"
 
 	"github.com/Konstantin8105/c4go/ast"
 	"github.com/Konstantin8105/c4go/program"
@@ -17,53 +16,17 @@ func transpileCompoundStmt(n *ast.CompoundStmt, p *program.Program) (
 	stmts := []goast.Stmt{}
 
 	for _, x := range n.Children() {
-		// Implementation va_start
-		var isVaList bool
-		if call, ok := x.(*ast.CallExpr); ok && call.Type == "void" {
-			if impl, ok := call.Children()[0].(*ast.ImplicitCastExpr); ok {
-				if impl.Type == "void (*)(struct __va_list_tag *, ...)" {
-					if decl, ok := impl.Children()[0].(*ast.DeclRefExpr); ok {
-						if decl.Name == "__builtin_va_start" {
-							isVaList = true
-						}
-					}
-				}
-			}
+		if parent, ok := x.(*ast.ParenExpr); ok {
+			x = parent.Children()[0]
 		}
-
-		var result []goast.Stmt
-		switch {
-		case isVaList:
-			// Implementation va_start
-			result = []goast.Stmt{
-				&goast.AssignStmt{
-					Lhs: []goast.Expr{
-						goast.NewIdent("c4goVaListPosition"),
-					},
-					Tok: token.ASSIGN,
-					Rhs: []goast.Expr{
-						&goast.BasicLit{
-							Kind:  token.INT,
-							Value: "0",
-						},
-					},
-				},
-			}
-		default:
-			// Other cases
-			if parent, ok := x.(*ast.ParenExpr); ok {
-				x = parent.Children()[0]
-			}
-			result, err = transpileToStmts(x, p)
-			if err != nil {
-				return nil, nil, nil, err
-			}
+		result, err := transpileToStmts(x, p)
+		if err != nil {
+			return nil, nil, nil, err
 		}
 
 		if result != nil {
 			stmts = append(stmts, result...)
 		}
-
 	}
 
 	return &goast.BlockStmt{
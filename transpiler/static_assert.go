@@ -0,0 +1,225 @@
+// This file contains functions for the static assertions of C. The
+// condition of static assertion is evaluated during transpilation, and
+// failed assertions are reported as warnings in the Go code. Go code is not
+// generated for static assertions:
+//
+//     _Static_assert(sizeof(int) == 4, "size");   ->  (nothing)
+//     _Static_assert(sizeof(int) == 8, "size");   ->  // Warning ... static assertion failed: size
+//
+
+package transpiler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	goast "go/ast"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+func transpileStaticAssertDecl(p *program.Program, n *ast.StaticAssertDecl) (
+	decls []goast.Decl, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("cannot evaluate static assertion: %v", err)
+		}
+	}()
+
+	if len(n.Children()) == 0 {
+		err = fmt.Errorf("condition is not found")
+		return
+	}
+
+	message := ""
+	if len(n.Children()) > 1 {
+		if s, ok := n.Children()[1].(*ast.StringLiteral); ok {
+			message = s.Value
+		}
+	}
+
+	value, err := evaluateConstant(p, n.Children()[0])
+	if err != nil {
+		return
+	}
+	if value == 0 || n.IsFailed {
+		p.AddMessage(p.GenerateWarningMessage(
+			fmt.Errorf("static assertion failed: %s", message), n))
+	}
+	return
+}
+
+// evaluateConstant returns the value of integer constant expression of C.
+func evaluateConstant(p *program.Program, node ast.Node) (
+	value int64, err error) {
+	switch n := node.(type) {
+	case *ast.IntegerLiteral:
+		value, err = strconv.ParseInt(strings.TrimRight(n.Value, "uUlL"), 0, 64)
+		return
+
+	case *ast.CharacterLiteral:
+		return int64(n.Value), nil
+
+	case *ast.ParenExpr, *ast.ImplicitCastExpr, *ast.CStyleCastExpr:
+		if len(n.Children()) != 1 {
+			break
+		}
+		return evaluateConstant(p, n.Children()[0])
+
+	case *ast.UnaryExprOrTypeTraitExpr:
+		if n.Function != "sizeof" {
+			break
+		}
+		if len(n.Children()) > 0 {
+			// size of expression
+			var lit *goast.BasicLit
+			lit, _, _, _, err = transpileUnaryExprOrTypeTraitExpr(n, p)
+			if err != nil {
+				return
+			}
+			return strconv.ParseInt(lit.Value, 10, 64)
+		}
+		var size int
+		size, err = types.SizeOf(p, n.Type2)
+		return int64(size), err
+
+	case *ast.OffsetOfExpr:
+		var structName, field string
+		structName, field, err = offsetOfArguments(n, p)
+		if err != nil {
+			return
+		}
+		var offset int
+		offset, err = offsetOf(p, structName, field)
+		return int64(offset), err
+
+	case *ast.UnaryOperator:
+		if len(n.Children()) != 1 {
+			break
+		}
+		var x int64
+		x, err = evaluateConstant(p, n.Children()[0])
+		if err != nil {
+			return
+		}
+		switch n.Operator {
+		case "+":
+			return x, nil
+		case "-":
+			return -x, nil
+		case "~":
+			return ^x, nil
+		case "!":
+			return boolToInt64(x == 0), nil
+		}
+
+	case *ast.BinaryOperator:
+		if len(n.Children()) != 2 {
+			break
+		}
+		var x, y int64
+		x, err = evaluateConstant(p, n.Children()[0])
+		if err != nil {
+			return
+		}
+		// logical operators are not evaluated the right operand
+		switch {
+		case n.Operator == "&&" && x == 0:
+			return 0, nil
+		case n.Operator == "||" && x != 0:
+			return 1, nil
+		}
+		y, err = evaluateConstant(p, n.Children()[1])
+		if err != nil {
+			return
+		}
+		return evaluateBinaryConstant(n.Operator, x, y)
+
+	case *ast.ConditionalOperator:
+		if len(n.Children()) != 3 {
+			break
+		}
+		var c int64
+		c, err = evaluateConstant(p, n.Children()[0])
+		if err != nil {
+			return
+		}
+		if c != 0 {
+			return evaluateConstant(p, n.Children()[1])
+		}
+		return evaluateConstant(p, n.Children()[2])
+
+	case *ast.BinaryConditionalOperator:
+		if len(n.Children()) != 5 {
+			break
+		}
+		var c int64
+		c, err = evaluateConstant(p, n.Children()[0])
+		if err != nil || c != 0 {
+			return c, err
+		}
+		return evaluateConstant(p, n.Children()[4])
+	}
+
+	return 0, fmt.Errorf("expression is not integer constant: %T", node)
+}
+
+// evaluateBinaryConstant returns the result of binary operator of C for
+// integer constants.
+func evaluateBinaryConstant(operator string, x, y int64) (int64, error) {
+	switch operator {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/", "%":
+		if y == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		if operator == "/" {
+			return x / y, nil
+		}
+		return x % y, nil
+	case "<<":
+		return x << uint64(y), nil
+	case ">>":
+		return x >> uint64(y), nil
+	case "&":
+		return x & y, nil
+	case "|":
+		return x | y, nil
+	case "^":
+		return x ^ y, nil
+	case "==":
+		return boolToInt64(x == y), nil
+	case "!=":
+		return boolToInt64(x != y), nil
+	case "<":
+		return boolToInt64(x < y), nil
+	case "<=":
+		return boolToInt64(x <= y), nil
+	case ">":
+		return boolToInt64(x > y), nil
+	case ">=":
+		return boolToInt64(x >= y), nil
+	case "&&":
+		return boolToInt64(x != 0 && y != 0), nil
+	case "||":
+		return boolToInt64(x != 0 || y != 0), nil
+	case ",":
+		return y, nil
+	}
+	return 0, fmt.Errorf("operator `%s` is not supported", operator)
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -11,10 +11,11 @@ import (
 	"github.com/Konstantin8105/c4go/ast"
 	"github.com/Konstantin8105/c4go/program"
 	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
 )
 
 func transpileSwitchStmt(n *ast.SwitchStmt, p *program.Program) (
-	_ *goast.SwitchStmt, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
+	_ goast.Stmt, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("Cannot transpileSwitchStmt : err = %v", err)
@@ -202,12 +203,199 @@ checkAgain:
 		stmts = append(stmts, singleCase)
 	}
 
-	return &goast.SwitchStmt{
+	switchStmt := &goast.SwitchStmt{
 		Tag: condition,
 		Body: &goast.BlockStmt{
 			List: stmts,
 		},
-	}, preStmts, postStmts, nil
+	}
+	caseRanges(switchStmt)
+
+	decls, err := hoistCaseDeclarations(condition, cases)
+	p.AddMessage(p.GenerateWarningMessage(err, n))
+	if len(decls) > 0 {
+		return &goast.BlockStmt{
+			List: append(decls, switchStmt),
+		}, preStmts, postStmts, nil
+	}
+
+	return switchStmt, preStmts, postStmts, nil
+}
+
+// caseRangeValue is the name of variable with the value of switch, which
+// has GNU case ranges.
+const caseRangeValue = "c4goSwitchValue"
+
+// caseRange returns the GNU case range `case low ... high:` as the value of
+// Go case. Ranges are replaced by conditions in function caseRanges.
+func caseRange(low, high goast.Expr) goast.Expr {
+	return &goast.BinaryExpr{X: low, Op: token.ELLIPSIS, Y: high}
+}
+
+// caseRanges replaces the GNU case ranges by conditions, because Go has no
+// case ranges. Value of switch is calculated once. Example of C code:
+//
+//     switch (c) {
+//     case '0' ... '9':
+//         digits++;
+//         break;
+//     case ' ':
+//         spaces++;
+//     }
+//
+// Result:
+//
+//     switch c4goSwitchValue := c; {
+//     case c4goSwitchValue >= '0' && c4goSwitchValue <= '9':
+//         digits++
+//     case c4goSwitchValue == ' ':
+//         spaces++
+//     }
+//
+func caseRanges(s *goast.SwitchStmt) {
+	isRange := func(e goast.Expr) bool {
+		b, ok := e.(*goast.BinaryExpr)
+		return ok && b.Op == token.ELLIPSIS
+	}
+	found := false
+	for _, stmt := range s.Body.List {
+		for _, e := range stmt.(*goast.CaseClause).List {
+			found = found || isRange(e)
+		}
+	}
+	if !found {
+		return
+	}
+
+	s.Init = &goast.AssignStmt{
+		Lhs: []goast.Expr{goast.NewIdent(caseRangeValue)},
+		Tok: token.DEFINE,
+		Rhs: []goast.Expr{s.Tag},
+	}
+	s.Tag = nil
+	for _, stmt := range s.Body.List {
+		list := stmt.(*goast.CaseClause).List
+		for i, e := range list {
+			if !isRange(e) {
+				list[i] = &goast.BinaryExpr{
+					X:  goast.NewIdent(caseRangeValue),
+					Op: token.EQL,
+					Y:  e,
+				}
+				continue
+			}
+			r := e.(*goast.BinaryExpr)
+			list[i] = &goast.BinaryExpr{
+				X: &goast.BinaryExpr{
+					X:  goast.NewIdent(caseRangeValue),
+					Op: token.GEQ,
+					Y:  r.X,
+				},
+				Op: token.LAND,
+				Y: &goast.BinaryExpr{
+					X:  goast.NewIdent(caseRangeValue),
+					Op: token.LEQ,
+					Y:  r.Y,
+				},
+			}
+		}
+	}
+}
+
+// hoistCaseDeclarations moves the declarations of variables of case, which
+// are used by the next cases, before the switch. In C the variable is
+// visible in the next cases, but in Go each case has own scope. Example of
+// C code:
+//
+//     switch (a) {
+//     case 1:
+//         int b = 5;
+//         r = b;
+//         break;
+//     case 2:
+//         b = 7;
+//         r = b;
+//     }
+//
+// Result:
+//
+//     {
+//         var b int32
+//         switch a {
+//         case 1:
+//             b = 5
+//             r = b
+//         case 2:
+//             b = 7
+//             r = b
+//         }
+//     }
+//
+// Declarations are replaced by assignments of initial values.
+func hoistCaseDeclarations(tag goast.Expr, cases []*goast.CaseClause) (
+	decls []goast.Stmt, err error) {
+	before := []goast.Stmt{util.NewExprStmt(tag)}
+	for i, c := range cases {
+		var after []goast.Stmt
+		for _, next := range cases[i+1:] {
+			after = append(after, next.Body...)
+		}
+
+		list := caseStatements(c)
+		var result []goast.Stmt
+		for j, stmt := range *list {
+			if !isVarDecl(stmt) || !declUsed(stmt, after) {
+				result = append(result, stmt)
+				continue
+			}
+			specs := stmt.(*goast.DeclStmt).Decl.(*goast.GenDecl).Specs
+			if e := checkHoist(specs, append(before, (*list)[:j]...)); e != nil {
+				err = e
+				result = append(result, stmt)
+				continue
+			}
+			d, assigns := splitVarDecl(specs)
+			decls = append(decls, d...)
+			result = append(result, assigns...)
+		}
+		*list = result
+
+		before = append(before, c.Body...)
+	}
+	return
+}
+
+// caseStatements returns the statements of case. Statements of case may be
+// in the block, which is followed by fallthrough.
+func caseStatements(c *goast.CaseClause) *[]goast.Stmt {
+	body := c.Body
+	if len(body) == 2 {
+		if b, ok := body[1].(*goast.BranchStmt); !ok || b.Tok != token.FALLTHROUGH {
+			return &c.Body
+		}
+		body = body[:1]
+	}
+	if len(body) == 1 {
+		if block, ok := body[0].(*goast.BlockStmt); ok {
+			return &block.List
+		}
+	}
+	return &c.Body
+}
+
+// declUsed returns true, if any variable of declaration is used in the
+// statements.
+func declUsed(decl goast.Stmt, stmts []goast.Stmt) bool {
+	for _, spec := range decl.(*goast.DeclStmt).Decl.(*goast.GenDecl).Specs {
+		if v, ok := spec.(*goast.ValueSpec); ok {
+			for _, name := range v.Names {
+				if usesName(stmts, name.Name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
 }
 
 func normalizeSwitchCases(body *ast.CompoundStmt, p *program.Program) (
@@ -329,24 +517,34 @@ func transpileCaseStmt(n *ast.CaseStmt, p *program.Program) (
 	preStmts := []goast.Stmt{}
 	postStmts := []goast.Stmt{}
 
-	c, cType, newPre, newPost, err := transpileToExpr(n.Children()[0], p, false)
-	if err != nil {
-		return nil, nil, nil, err
+	values := n.Children()[:1]
+	if n.IsRange() {
+		values = n.Children()[:2]
 	}
-	if cType == "bool" {
-		c, err = types.CastExpr(p, c, cType, "int")
-		p.AddMessage(p.GenerateWarningMessage(err, n))
+	var list []goast.Expr
+	for _, value := range values {
+		c, cType, newPre, newPost, err := transpileToExpr(value, p, false)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if cType == "bool" {
+			c, err = types.CastExpr(p, c, cType, "int")
+			p.AddMessage(p.GenerateWarningMessage(err, n))
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		list = append(list, c)
+	}
+	if len(list) == 2 {
+		list = []goast.Expr{caseRange(list[0], list[1])}
 	}
 
-	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
-
-	stmts, err := transpileStmts(n.Children()[1:], p)
+	stmts, err := transpileStmts(n.Children()[len(values):], p)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	return &goast.CaseClause{
-		List: []goast.Expr{c},
+		List: list,
 		Body: stmts,
 	}, preStmts, postStmts, nil
 }
@@ -0,0 +1,320 @@
+// This file contains functions for transpiling of threads. Threads are
+// implemented in package noarch on top of goroutines.
+
+package transpiler
+
+import (
+	"fmt"
+
+	"github.com/Konstantin8105/c4go/ast"
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
+
+	goast "go/ast"
+	"go/token"
+)
+
+// threadFunction is a function with arguments of function type, for example
+// start routine of thread. Function types cannot be written in the function
+// definitions of package program, so calls of these functions are
+// transpiled directly.
+type threadFunction struct {
+	// Header is the C header with function prototype.
+	Header string
+
+	// Substitution is the Go function.
+	Substitution string
+
+	// Amount of arguments.
+	Arguments int
+
+	// ReturnType is the C type of result.
+	ReturnType string
+}
+
+// threadFunctions - functions with arguments of function type.
+// Key is the name of C function.
+var threadFunctions = map[string]threadFunction{
+	// pthread.h
+	"pthread_create": {
+		Header:       "pthread.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.PthreadCreate",
+		Arguments:    4,
+		ReturnType:   "int",
+	},
+	"pthread_once": {
+		Header:       "pthread.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.PthreadOnce",
+		Arguments:    2,
+		ReturnType:   "int",
+	},
+	"pthread_key_create": {
+		Header:       "pthread.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.PthreadKeyCreate",
+		Arguments:    2,
+		ReturnType:   "int",
+	},
+
+	// threads.h
+	"thrd_create": {
+		Header:       "threads.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.ThrdCreate",
+		Arguments:    3,
+		ReturnType:   "int",
+	},
+	"call_once": {
+		Header:       "threads.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.CallOnce",
+		Arguments:    2,
+		ReturnType:   "void",
+	},
+	"tss_create": {
+		Header:       "threads.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.TssCreate",
+		Arguments:    2,
+		ReturnType:   "int",
+	},
+
+	// ftw.h
+	"ftw": {
+		Header:       "ftw.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Ftw",
+		Arguments:    3,
+		ReturnType:   "int",
+	},
+	"nftw": {
+		Header:       "ftw.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Nftw",
+		Arguments:    4,
+		ReturnType:   "int",
+	},
+
+	// glob.h
+	"glob": {
+		Header:       "glob.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Glob",
+		Arguments:    4,
+		ReturnType:   "int",
+	},
+
+	// search.h
+	"tsearch": {
+		Header:       "search.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Tsearch",
+		Arguments:    3,
+		ReturnType:   "void *",
+	},
+	"tfind": {
+		Header:       "search.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Tfind",
+		Arguments:    3,
+		ReturnType:   "void *",
+	},
+	"tdelete": {
+		Header:       "search.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Tdelete",
+		Arguments:    3,
+		ReturnType:   "void *",
+	},
+	"twalk": {
+		Header:       "search.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Twalk",
+		Arguments:    2,
+		ReturnType:   "void",
+	},
+	"lfind": {
+		Header:       "search.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Lfind",
+		Arguments:    5,
+		ReturnType:   "void *",
+	},
+	"lsearch": {
+		Header:       "search.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Lsearch",
+		Arguments:    5,
+		ReturnType:   "void *",
+	},
+
+	// stdlib.h
+	"atexit": {
+		Header:       "stdlib.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.Atexit",
+		Arguments:    1,
+		ReturnType:   "int",
+	},
+	"at_quick_exit": {
+		Header:       "stdlib.h",
+		Substitution: "github.com/Konstantin8105/c4go/noarch.AtQuickExit",
+		Arguments:    1,
+		ReturnType:   "int",
+	},
+}
+
+// transpileCallExprThread transpiles the call of thread function.
+// Example of C code:
+//
+//     pthread_create(&thread, NULL, worker, (void *)&data);
+//
+// Example of AST:
+//
+//     CallExpr 0x1f6a718 <line:22:5, col:54> 'int'
+//     |-ImplicitCastExpr 0x1f6a700 <col:5> 'int (*)(pthread_t *, const pthread_attr_t *, void *(*)(void *), void *)' <FunctionToPointerDecay>
+//     | `-DeclRefExpr 0x1f6a5a0 <col:5> 'int (pthread_t *, const pthread_attr_t *, void *(*)(void *), void *)' Function 0x1f4d7c0 'pthread_create' 'int (pthread_t *, const pthread_attr_t *, void *(*)(void *), void *)'
+//     |-UnaryOperator 0x1f6a5f0 <col:20, col:21> 'pthread_t *' prefix '&'
+//     | `-DeclRefExpr 0x1f6a5c8 <col:21> 'pthread_t':'unsigned long' lvalue Var 0x1f6a3a8 'thread' 'pthread_t':'unsigned long'
+//     |-ImplicitCastExpr 0x1f6a768 <col:29> 'const pthread_attr_t *' <NullToPointer>
+//     | `-...
+//     |-ImplicitCastExpr 0x1f6a780 <col:35> 'void *(*)(void *)' <FunctionToPointerDecay>
+//     | `-DeclRefExpr 0x1f6a638 <col:35> 'void *(void *)' Function 0x1f6a1f0 'worker' 'void *(void *)'
+//     `-CStyleCastExpr 0x1f6a6d8 <col:43, col:53> 'void *' <BitCast>
+//       `-...
+//
+// Result:
+//
+//     noarch.PthreadCreate(thread, nil, worker, data)
+//
+func transpileCallExprThread(n *ast.CallExpr, p *program.Program,
+	functionName string) (
+	expr *goast.CallExpr, resultType string,
+	preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Function: %s. err = %v", functionName, err)
+		}
+	}()
+
+	f := threadFunctions[functionName]
+	if len(n.Children()) != f.Arguments+1 {
+		return nil, "", nil, nil, fmt.Errorf(
+			"expected %d arguments, but found %d",
+			f.Arguments, len(n.Children())-1)
+	}
+
+	var args []goast.Expr
+	for i, arg := range n.Children()[1:] {
+		e, _, newPre, newPost, err := transpileToExpr(arg, p, false)
+		if err != nil {
+			return nil, "", nil, nil,
+				fmt.Errorf("argument position is %d. %v", i, err)
+		}
+		args = append(args, e)
+		preStmts, postStmts = combinePreAndPostStmts(
+			preStmts, postStmts, newPre, newPost)
+	}
+
+	return util.NewCallExpr(p.ImportType(f.Substitution), args...),
+		f.ReturnType, preStmts, postStmts, nil
+}
+
+// zeroValueTypes - C types, which are ready for use with zero value in Go.
+// Static initializers of variables with these types are ignored, for
+// example: PTHREAD_MUTEX_INITIALIZER.
+var zeroValueTypes = []string{
+	"pthread_mutex_t",
+	"pthread_cond_t",
+	"pthread_once_t",
+	"mtx_t",
+	"cnd_t",
+	"once_flag",
+}
+
+// isZeroValueType return true, if the C type does not need the initializer.
+func isZeroValueType(cType string) bool {
+	cType = types.CleanCType(cType)
+	for _, t := range zeroValueTypes {
+		if cType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// transpileThreadLocalVarDecl transpiles the variable with storage class
+// thread_local (_Thread_local of C11 or __thread of GNU C) in the program
+// with threads. Each thread has own value of variable.
+// Example of C code:
+//
+//     thread_local int counter = 5;
+//
+// Example of AST:
+//
+//     VarDecl 0x2b7a8e8 <line:3:1, col:34> col:26 used counter 'int' static tls cinit
+//     `-IntegerLiteral 0x2b7a950 <col:34> 'int' 5
+//
+// Result:
+//
+//     var counter = noarch.NewThreadLocal(func() interface{} {
+//         return []int{5}
+//     })
+//
+func transpileThreadLocalVarDecl(p *program.Program, n *ast.VarDecl,
+	defaultValue []goast.Expr) (decls []goast.Decl, err error) {
+	goType, err := types.ResolveType(p, n.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// value of variable is the first element of slice
+	var value goast.Expr
+	if len(defaultValue) == 1 && defaultValue[0] != nil {
+		value = &goast.CompositeLit{
+			Type: &goast.ArrayType{Elt: goast.NewIdent(goType)},
+			Elts: defaultValue,
+		}
+	} else {
+		value = util.NewCallExpr("make",
+			&goast.ArrayType{Elt: goast.NewIdent(goType)},
+			util.NewIntLit(1))
+	}
+
+	p.ThreadLocalVariables[n.Addr] = goType
+
+	return []goast.Decl{&goast.GenDecl{
+		Tok: token.VAR,
+		Specs: []goast.Spec{
+			&goast.ValueSpec{
+				Names: []*goast.Ident{util.NewIdent(n.Name)},
+				Values: []goast.Expr{util.NewCallExpr(
+					p.ImportType("github.com/Konstantin8105/c4go/noarch.NewThreadLocal"),
+					&goast.FuncLit{
+						Type: &goast.FuncType{
+							Params: &goast.FieldList{},
+							Results: &goast.FieldList{List: []*goast.Field{
+								{Type: goast.NewIdent("interface{}")},
+							}},
+						},
+						Body: &goast.BlockStmt{List: []goast.Stmt{
+							&goast.ReturnStmt{Results: []goast.Expr{value}},
+						}},
+					},
+				)},
+				Doc: p.GetMessageComments(),
+			},
+		},
+	}}, nil
+}
+
+// variableExpr returns the Go expression of variable, which is referenced by
+// DeclRefExpr. The value of thread_local variable is taken for the current
+// thread. The static local variable is the package-level variable with
+// unique name.
+func variableExpr(p *program.Program, n *ast.DeclRefExpr) *goast.Ident {
+	name := n.Name
+	if staticName, ok := p.StaticVariables[ast.ParseAddress(n.Address2)]; ok {
+		name = staticName
+	}
+	if goType, ok := p.ThreadLocalVariables[ast.ParseAddress(n.Address2)]; ok {
+		return goast.NewIdent(threadLocalValue(name, goType))
+	}
+	return util.NewIdent(name)
+}
+
+// threadLocalValue returns the expression for access to value of
+// thread_local variable for the current thread, for example:
+//
+//     counter.Get().([]int)[0]
+//
+func threadLocalValue(name, goType string) string {
+	return fmt.Sprintf("%s.Get().([]%s)[0]", name, goType)
+}
@@ -2,6 +2,7 @@ package transpiler
 
 import (
 	goast "go/ast"
+	"sort"
 	"strings"
 
 	"github.com/Konstantin8105/c4go/ast"
@@ -14,8 +15,36 @@ func transpileTranslationUnitDecl(p *program.Program, n *ast.TranslationUnitDecl
 
 	var tryLaterRecordDecl []*ast.RecordDecl
 
+	// positions of C nodes for each Go declaration, used only for the
+	// option PreserveOrder
+	positions := map[goast.Decl]ast.Position{}
+	defer func() {
+		if p.PreserveOrder {
+			sortDeclsBySource(decls, positions)
+		}
+	}()
+	addPositions := func(node ast.Node, d []goast.Decl) {
+		if !p.PreserveOrder {
+			return
+		}
+		for i := range d {
+			positions[d[i]] = node.Position()
+		}
+	}
+
+	skipFunctions := resolveInlineDefinitions(n.Children())
+	skipVariables := resolveVariableDefinitions(p, n.Children())
+
 	for i := 0; i < len(n.Children()); i++ {
 		presentNode := n.Children()[i]
+		if fd, ok := presentNode.(*ast.FunctionDecl); ok && skipFunctions[fd] {
+			// other definition of the same function is transpiled
+			continue
+		}
+		if vd, ok := presentNode.(*ast.VarDecl); ok && skipVariables[vd] {
+			// other declaration of the same variable is transpiled
+			continue
+		}
 		if rec, ok := presentNode.(*ast.RecordDecl); ok && rec.Name == "" {
 			if i+1 < len(n.Children()) {
 				switch recNode := n.Children()[i+1].(type) {
@@ -47,6 +76,7 @@ func transpileTranslationUnitDecl(p *program.Program, n *ast.TranslationUnitDecl
 			}
 			continue
 		}
+		addPositions(presentNode, d)
 		decls = append(decls, d...)
 
 	again:
@@ -54,6 +84,7 @@ func transpileTranslationUnitDecl(p *program.Program, n *ast.TranslationUnitDecl
 			// try again later
 			recDecl, err := transpileRecordDecl(p, tryLaterRecordDecl[i])
 			if err == nil {
+				addPositions(tryLaterRecordDecl[i], recDecl)
 				decls = append(decls, recDecl...)
 				if i == len(tryLaterRecordDecl)-1 {
 					if len(tryLaterRecordDecl) == 1 {
@@ -72,6 +103,7 @@ func transpileTranslationUnitDecl(p *program.Program, n *ast.TranslationUnitDecl
 	for i := range tryLaterRecordDecl {
 		recDecl, err := transpileRecordDecl(p, tryLaterRecordDecl[i])
 		if err == nil {
+			addPositions(tryLaterRecordDecl[i], recDecl)
 			decls = append(decls, recDecl...)
 		} else {
 			p.AddMessage(p.GenerateWarningMessage(err, n))
@@ -79,3 +111,35 @@ func transpileTranslationUnitDecl(p *program.Program, n *ast.TranslationUnitDecl
 	}
 	return
 }
+
+// sortDeclsBySource sorts Go declarations in order of the original C
+// source. Declarations are grouped by file in order of first appearance of
+// the file and are sorted by line and column inside of each file.
+// Declaration without position stays after the previous declaration.
+func sortDeclsBySource(decls []goast.Decl, positions map[goast.Decl]ast.Position) {
+	type key struct {
+		file, line, column int
+	}
+	fileIndex := map[string]int{}
+	keys := map[goast.Decl]key{}
+	var last key
+	for i := range decls {
+		if pos, ok := positions[decls[i]]; ok {
+			if _, ok := fileIndex[pos.File]; !ok {
+				fileIndex[pos.File] = len(fileIndex)
+			}
+			last = key{fileIndex[pos.File], pos.Line, pos.Column}
+		}
+		keys[decls[i]] = last
+	}
+	sort.SliceStable(decls, func(i, j int) bool {
+		a, b := keys[decls[i]], keys[decls[j]]
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		if a.line != b.line {
+			return a.line < b.line
+		}
+		return a.column < b.column
+	})
+}
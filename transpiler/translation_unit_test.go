@@ -0,0 +1,46 @@
+package transpiler
+
+import (
+	goast "go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/ast"
+)
+
+func TestSortDeclsBySource(t *testing.T) {
+	var decls []goast.Decl
+	positions := map[goast.Decl]ast.Position{}
+	for _, d := range []struct {
+		name string
+		pos  *ast.Position
+	}{
+		{"z", nil},
+		{"b", &ast.Position{File: "a.c", Line: 5, Column: 1}},
+		{"x", &ast.Position{File: "b.h", Line: 2, Column: 1}},
+		{"a", &ast.Position{File: "a.c", Line: 1, Column: 1}},
+		// without position is kept after "a"
+		{"c", nil},
+		// same position as "b"
+		{"d", &ast.Position{File: "a.c", Line: 5, Column: 1}},
+		{"y", &ast.Position{File: "b.h", Line: 1, Column: 9}},
+	} {
+		decl := &goast.FuncDecl{Name: goast.NewIdent(d.name)}
+		decls = append(decls, decl)
+		if d.pos != nil {
+			positions[decl] = *d.pos
+		}
+	}
+
+	sortDeclsBySource(decls, positions)
+
+	var names []string
+	for _, decl := range decls {
+		names = append(names, decl.(*goast.FuncDecl).Name.Name)
+	}
+	// declarations of files are in order of first appearance of file
+	expected := []string{"z", "a", "c", "b", "d", "y", "x"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Not expected order: %v\nExpected: %v", names, expected)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/Konstantin8105/c4go/ast"
@@ -30,6 +32,36 @@ func TranspileAST(fileName, packageName string, p *program.Program, root ast.Nod
 		return err
 	}
 
+	// Functions registered by atexit() are called on return from main()
+	for _, node := range ast.GetAllNodesOfType(root, reflect.TypeOf((*ast.DeclRefExpr)(nil))) {
+		if node.(*ast.DeclRefExpr).Name == "atexit" {
+			p.ExitHandlers = true
+			break
+		}
+	}
+
+	// Functions with attribute destructor are registered as functions of
+	// atexit()
+	constructors, destructors := findAttributedFunctions(root)
+	if len(destructors) > 0 {
+		p.ExitHandlers = true
+	}
+
+	// Old-style function definitions and functions called before
+	// declaration are registered with prototypes
+	registerKnrFunctions(p, root)
+	registerImplicitFunctions(p, root)
+
+	// Values of thread_local variables are separated only for programs with
+	// threads
+	for _, node := range ast.GetAllNodesOfType(root, reflect.TypeOf((*ast.DeclRefExpr)(nil))) {
+		if name := node.(*ast.DeclRefExpr).Name; name == "pthread_create" ||
+			name == "thrd_create" {
+			p.Threads = true
+			break
+		}
+	}
+
 	// Now begin building the Go AST.
 	decls, err := transpileToNode(root, p)
 	if err != nil {
@@ -39,6 +71,35 @@ func TranspileAST(fileName, packageName string, p *program.Program, root ast.Nod
 	}
 	p.File.Decls = append(p.File.Decls, decls...)
 
+	// Reentrant mutex for global variables from option "-guard"
+	if len(p.GuardedVariables) > 0 {
+		p.File.Decls = append(p.File.Decls, guardDecl(p))
+		var names []string
+		for name := range p.GuardedVariables {
+			if _, ok := p.GlobalVariables[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+				"guarded global variable `%s` is not found", name), nil))
+		}
+	}
+
+	// Report of removed reference counting from option "-refcount"
+	p.AddMessage(refCountReport(p))
+
+	// Functions for cast of byte buffers
+	var casts []string
+	for name := range p.ByteCasts {
+		casts = append(casts, name)
+	}
+	sort.Strings(casts)
+	for _, name := range casts {
+		p.File.Decls = append(p.File.Decls, p.ByteCasts[name]...)
+	}
+
 	if p.OutputAsTest {
 		p.AddImport("testing")
 		p.AddImport("io/ioutil")
@@ -83,6 +144,10 @@ func TranspileAST(fileName, packageName string, p *program.Program, root ast.Nod
 		})
 	}
 
+	// Functions with attributes constructor and destructor are called in
+	// the __init() function.
+	appendAttributedFunctions(p, constructors, destructors)
+
 	// Now we need to build the __init() function. This sets up certain state
 	// and variables that the runtime expects to be ready.
 	p.File.Decls = append(p.File.Decls, &goast.FuncDecl{
@@ -93,17 +158,14 @@ func TranspileAST(fileName, packageName string, p *program.Program, root ast.Nod
 		},
 	})
 
-	// only for "stdbool.h"
-	if p.IncludeHeaderIsExists("stdbool.h") {
-		p.File.Decls = append(p.File.Decls, &goast.GenDecl{
-			Tok: token.TYPE,
-			Specs: []goast.Spec{
-				&goast.TypeSpec{
-					Name: goast.NewIdent("_Bool"),
-					Type: goast.NewIdent("int"),
-				},
-			},
-		})
+	// Functions of macros are declared after all other declarations,
+	// because names of functions must not collide with names of Go code.
+	p.File.Decls = append(p.File.Decls, macroFunctionDecls(p)...)
+
+	// Constants of macros are declared after all other declarations, because
+	// names of constants must not collide with names of Go code.
+	if decl := macroConstDecl(p); decl != nil {
+		p.File.Decls = append(p.File.Decls, decl)
 	}
 
 	// Add the imports after everything else so we can ensure that they are all
@@ -115,6 +177,9 @@ func TranspileAST(fileName, packageName string, p *program.Program, root ast.Nod
 				Value: quotedImportPath,
 			},
 		}
+		if name := p.ImportName(quotedImportPath); name != "" {
+			importSpec.Name = goast.NewIdent(name)
+		}
 		importDecl := &goast.GenDecl{
 			Tok: token.IMPORT,
 		}
@@ -146,13 +211,21 @@ func transpileToExpr(node ast.Node, p *program.Program, exprIsStmt bool) (
 		postStmts = nilFilterStmts(postStmts)
 	}()
 
+	// Expression of function-like macro is the call of Go function
+	if e, t, pre, post, ok := transpileMacroCall(p, node); ok {
+		return e, t, pre, post, nil
+	}
+
 	switch n := node.(type) {
 	case *ast.StringLiteral:
 		expr, exprType, err = transpileStringLiteral(p, n, false)
 		return
 
 	case *ast.FloatingLiteral:
-		expr, exprType, err = transpileFloatingLiteral(n), "double", nil
+		expr, exprType, err = transpileFloatingLiteral(n, p), "double", nil
+
+	case *ast.ImaginaryLiteral:
+		expr, exprType, preStmts, postStmts, err = transpileImaginaryLiteral(n, p)
 
 	case *ast.PredefinedExpr:
 		expr, exprType, err = transpilePredefinedExpr(n, p)
@@ -160,6 +233,15 @@ func transpileToExpr(node ast.Node, p *program.Program, exprIsStmt bool) (
 	case *ast.ConditionalOperator:
 		expr, exprType, preStmts, postStmts, err = transpileConditionalOperator(n, p)
 
+	case *ast.BinaryConditionalOperator:
+		expr, exprType, preStmts, postStmts, err = transpileBinaryConditionalOperator(n, p)
+
+	case *ast.OpaqueValueExpr:
+		expr, exprType, preStmts, postStmts, err = transpileToExpr(n.Children()[0], p, exprIsStmt)
+
+	case *ast.AddrLabelExpr:
+		expr, exprType, err = transpileAddrLabelExpr(n, p)
+
 	case *ast.ArraySubscriptExpr:
 		expr, exprType, preStmts, postStmts, err = transpileArraySubscriptExpr(n, p)
 
@@ -179,18 +261,43 @@ func transpileToExpr(node ast.Node, p *program.Program, exprIsStmt bool) (
 		expr, exprType, err = transpileDeclRefExpr(n, p)
 
 	case *ast.IntegerLiteral:
-		expr, exprType, err = transpileIntegerLiteral(n), "int", nil
+		expr, exprType, err = transpileIntegerLiteral(n, p), "int", nil
 
 	case *ast.ParenExpr:
 		expr, exprType, preStmts, postStmts, err = transpileParenExpr(n, p)
 
+	case *ast.GenericSelectionExpr:
+		expr, exprType, preStmts, postStmts, err = transpileGenericSelectionExpr(n, p, exprIsStmt)
+
 	case *ast.CStyleCastExpr:
 		expr, exprType, preStmts, postStmts, err = transpileCStyleCastExpr(n, p, exprIsStmt)
 
 	case *ast.CharacterLiteral:
 		expr, exprType, err = transpileCharacterLiteral(n), "char", nil
+		if n.Value < 0 || n.Value > 127 {
+			// multi-character constants and negative characters
+			exprType = n.Type
+		}
+
+	case *ast.CXXBoolLiteralExpr:
+		expr, exprType, err = transpileBoolLiteral(n), "bool", nil
+
+	case *ast.CXXNullPtrLiteralExpr:
+		// `nullptr` of C23
+		expr, exprType, err = goast.NewIdent("nil"), types.NullPointer, nil
 
 	case *ast.CallExpr:
+		var ok bool
+		expr, exprType, preStmts, postStmts, ok, err = transpileBuiltinCall(n, p, exprIsStmt)
+		if ok {
+			return
+		}
+		if p.RefCounting.IsEnabled() {
+			expr, exprType, preStmts, postStmts, ok, err = transpileRefCountExpr(n, p)
+			if ok {
+				return
+			}
+		}
 		expr, exprType, preStmts, postStmts, err = transpileCallExpr(n, p)
 
 	case *ast.CompoundAssignOperator:
@@ -208,6 +315,9 @@ func transpileToExpr(node ast.Node, p *program.Program, exprIsStmt bool) (
 	case *ast.StmtExpr:
 		return transpileStmtExpr(n, p)
 
+	case *ast.BlockExpr:
+		expr, exprType, err = transpileBlockExpr(n, p)
+
 	case *ast.ImplicitValueInitExpr:
 		return transpileImplicitValueInitExpr(n, p)
 
@@ -336,15 +446,28 @@ func transpileToStmt(node ast.Node, p *program.Program) (
 
 	case *ast.GotoStmt:
 		stmt, err = transpileGotoStmt(n, p)
+
+	case *ast.IndirectGotoStmt:
+		stmt, preStmts, postStmts, err = transpileIndirectGotoStmt(n, p)
+		return
+
+	case *ast.AttributedStmt:
+		// Attributes of statement, like `[[fallthrough]]`, are ignored and
+		// the statement is the last child.
+		if len(n.Children()) > 0 {
+			return transpileToStmt(n.Children()[len(n.Children())-1], p)
+		}
 		return
 
 	case *ast.GCCAsmStmt:
 		// Go does not support inline assembly. See:
 		// https://github.com/Konstantin8105/c4go/issues/228
+		stmt = &goast.EmptyStmt{}
+		if isAsmBarrier(p, n) {
+			return
+		}
 		p.AddMessage(p.GenerateWarningMessage(
 			errors.New("cannot transpile asm, will be ignored"), n))
-
-		stmt = &goast.EmptyStmt{}
 		return
 	case *ast.DeclStmt:
 		var stmts []goast.Stmt
@@ -359,6 +482,15 @@ func transpileToStmt(node ast.Node, p *program.Program) (
 		return
 	}
 
+	// reference counting removed by option "-refcount"
+	if p.RefCounting.IsEnabled() {
+		var ok bool
+		stmt, preStmts, postStmts, ok, err = transpileRefCountStmt(node, p)
+		if ok {
+			return
+		}
+	}
+
 	// We do not care about the return type.
 	var theType string
 	expr, theType, preStmts, postStmts, err = transpileToExpr(node, p, true)
@@ -387,6 +519,21 @@ func transpileToStmt(node ast.Node, p *program.Program) (
 		}
 	}
 	if foundToVoid {
+		// Assignment is a statement in Go and cannot be used as a value.
+		// Example of C code: (void)(a |= 1)
+		// Example of Go code: a |= 1
+		e := expr
+		for {
+			par, ok := e.(*goast.ParenExpr)
+			if !ok {
+				break
+			}
+			e = par.X
+		}
+		if b, ok := e.(*goast.BinaryExpr); ok && isAssignOperator(b.Op) {
+			stmt = util.NewExprStmt(b)
+			return
+		}
 		stmt = &goast.AssignStmt{
 			Lhs: []goast.Expr{goast.NewIdent("_")},
 			Tok: token.ASSIGN,
@@ -461,6 +608,9 @@ func transpileToNode(node ast.Node, p *program.Program) (
 	case *ast.EnumDecl:
 		decls, err = transpileEnumDecl(p, n)
 
+	case *ast.StaticAssertDecl:
+		decls, err = transpileStaticAssertDecl(p, n)
+
 	case *ast.LinkageSpecDecl:
 		// ignore
 
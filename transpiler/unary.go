@@ -4,6 +4,7 @@ package transpiler
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/Konstantin8105/c4go/ast"
@@ -85,17 +86,40 @@ func transpileUnaryOperatorInc(n *ast.UnaryOperator, p *program.Program, operato
 		return
 	}
 
+	// In C the increment of boolean value is always true, the decrement
+	// is the inversion of value:
+	//
+	//     b++ -> b = true
+	//     b-- -> b = !b
+	//
+	if t, _ := types.ResolveType(p, n.Type); t == "bool" {
+		var left goast.Expr
+		left, _, preStmts, postStmts, err = transpileToExpr(n.Children()[0], p, false)
+		if err != nil {
+			return
+		}
+		var right goast.Expr = goast.NewIdent("true")
+		if operator == token.DEC {
+			right = &goast.UnaryExpr{Op: token.NOT, X: left}
+		}
+		return &goast.BinaryExpr{
+			X:  left,
+			Op: token.ASSIGN,
+			Y:  right,
+		}, n.Type, preStmts, postStmts, nil
+	}
+
 	if v, ok := n.Children()[0].(*ast.DeclRefExpr); ok {
 		switch n.Operator {
 		case "++":
 			return &goast.BinaryExpr{
-				X:  util.NewIdent(v.Name),
+				X:  variableExpr(p, v),
 				Op: token.ADD_ASSIGN,
 				Y:  &goast.BasicLit{Kind: token.INT, Value: "1"},
 			}, n.Type, nil, nil, nil
 		case "--":
 			return &goast.BinaryExpr{
-				X:  util.NewIdent(v.Name),
+				X:  variableExpr(p, v),
 				Op: token.SUB_ASSIGN,
 				Y:  &goast.BasicLit{Kind: token.INT, Value: "1"},
 			}, n.Type, nil, nil, nil
@@ -168,16 +192,11 @@ func transpileUnaryOperatorNot(n *ast.UnaryOperator, p *program.Program) (
 		), "bool", preStmts, postStmts, nil
 	}
 
-	// only if added "stdbool.h"
-	if p.IncludeHeaderIsExists("stdbool.h") {
-		if t == "_Bool" {
-			t = "int"
-			e = &goast.CallExpr{
-				Fun:    goast.NewIdent("int"),
-				Lparen: 1,
-				Args:   []goast.Expr{e},
-			}
-		}
+	if t == "bool" {
+		return &goast.UnaryExpr{
+			X:  e,
+			Op: token.NOT,
+		}, "bool", preStmts, postStmts, nil
 	}
 
 	p.AddImport("github.com/Konstantin8105/c4go/noarch")
@@ -215,6 +234,16 @@ func transpileUnaryOperatorAmpersant(n *ast.UnaryOperator, p *program.Program) (
 		return
 	}
 
+	// Pointer to the element of array is the slice from the element.
+	// Example: &a[i] -> a[i:]
+	if index, ok := expr.(*goast.IndexExpr); ok && !types.IsLastArray(eType) {
+		if _, ok := n.Children()[0].(*ast.ArraySubscriptExpr); ok {
+			expr = pointerOffset(p, index.X, index.Index)
+			eType += " *"
+			return
+		}
+	}
+
 	if types.IsLastArray(eType) {
 		// In : eType = 'int [5]'
 		// Out: eType = 'int *'
@@ -238,6 +267,20 @@ func transpileUnaryOperatorAmpersant(n *ast.UnaryOperator, p *program.Program) (
 		return
 	}
 
+	// Compound literal is the new unnamed object, so the pointer to it is
+	// the new slice with the object. Example:
+	//
+	//     &(struct point){1, 2}  ->  []point{point{1, 2}}
+	//
+	if _, ok := n.Children()[0].(*ast.CompoundLiteralExpr); ok {
+		expr = &goast.CompositeLit{
+			Type: util.NewTypeIdent("[]" + resolvedType),
+			Elts: []goast.Expr{expr},
+		}
+		eType += " *"
+		return
+	}
+
 	p.AddImport("unsafe")
 	expr = util.CreateSliceFromReference(resolvedType, expr)
 
@@ -468,7 +511,7 @@ func transpilePointerArith(n *ast.UnaryOperator, p *program.Program) (
 
 	case *ast.DeclRefExpr:
 		return &goast.IndexExpr{
-			X:     util.NewIdent(v.Name),
+			X:     variableExpr(p, v),
 			Index: e,
 		}, eType, preStmts, postStmts, err
 
@@ -566,6 +609,21 @@ func transpileUnaryOperator(n *ast.UnaryOperator, p *program.Program) (
 		}
 	}()
 
+	switch n.Operator {
+	case "__extension__":
+		// GNU extension marker, for example: macro `I` of "complex.h"
+		return transpileToExpr(n.Children()[0], p, false)
+	case "__real", "__imag":
+		// real or imaginary part of complex number
+		var e goast.Expr
+		e, _, preStmts, postStmts, err = transpileToExpr(n.Children()[0], p, false)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		return util.NewCallExpr(strings.TrimPrefix(n.Operator, "__"), e),
+			n.Type, preStmts, postStmts, nil
+	}
+
 	operator := getTokenForOperator(n.Operator)
 
 	switch operator {
@@ -574,7 +632,14 @@ func transpileUnaryOperator(n *ast.UnaryOperator, p *program.Program) (
 		// Prefix "*" used for pointer ariphmetic
 		// Example of using:
 		// *(t + 1) = ...
-		return transpilePointerArith(n, p)
+		expr, eType, preStmts, postStmts, err := transpilePointerArith(n, p)
+		if index, ok := expr.(*goast.IndexExpr); ok && err == nil &&
+			isNegative(index.Index) {
+			// *(t - 1) = ...
+			index.X = pointerOffset(p, index.X, index.Index)
+			index.Index = util.NewIntLit(0)
+		}
+		return expr, eType, preStmts, postStmts, err
 	case token.INC, token.DEC: // ++, --
 		return transpileUnaryOperatorInc(n, p, operator)
 	case token.NOT: // !
@@ -591,10 +656,10 @@ func transpileUnaryOperator(n *ast.UnaryOperator, p *program.Program) (
 
 	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 
-	return &goast.UnaryExpr{
+	return foldUnsigned(p, &goast.UnaryExpr{
 		Op: operator,
 		X:  e,
-	}, eType, preStmts, postStmts, nil
+	}, n.Type), eType, preStmts, postStmts, nil
 
 }
 
@@ -605,7 +670,7 @@ func transpileUnaryExprOrTypeTraitExpr(n *ast.UnaryExprOrTypeTraitExpr, p *progr
 	// It will have children if the sizeof() is referencing a variable.
 	// Fortunately clang already has the type in the AST for us.
 	if len(n.Children()) > 0 {
-		var realFirstChild interface{}
+		var realFirstChild ast.Node
 		t = ""
 
 		switch c := n.Children()[0].(type) {
@@ -618,7 +683,8 @@ func transpileUnaryExprOrTypeTraitExpr(n *ast.UnaryExprOrTypeTraitExpr, p *progr
 		case *ast.ArraySubscriptExpr:
 			t = c.Type
 		default:
-			panic(fmt.Sprintf("cannot find first child from: %#v", n.Children()[0]))
+			// expression without parens, like `sizeof *p`
+			t = nodeType(c)
 		}
 
 		if t == "" {
@@ -648,9 +714,15 @@ func transpileUnaryExprOrTypeTraitExpr(n *ast.UnaryExprOrTypeTraitExpr, p *progr
 				t = ty.Type
 
 			default:
-				panic(fmt.Sprintf("cannot do unary on: %#v", ty))
+				// expression, like `sizeof(a + b)`
+				t = nodeType(ty)
 			}
 		}
+
+		if t == "" {
+			return nil, "", nil, nil, fmt.Errorf(
+				"cannot find type of expression in sizeof: %T", n.Children()[0])
+		}
 	}
 
 	sizeInBytes, err := types.SizeOf(p, t)
@@ -659,24 +731,152 @@ func transpileUnaryExprOrTypeTraitExpr(n *ast.UnaryExprOrTypeTraitExpr, p *progr
 	return util.NewIntLit(sizeInBytes), n.Type1, nil, nil, nil
 }
 
+// transpileStmtExpr transpiles the GNU statement expression `({ ... })` to
+// the closure, which is called immediately. Value of statement expression is
+// the value of the last expression. Example of C code of macro:
+//
+//     int m = ({ int _a = (x); int _b = (y); _a > _b ? _a : _b; });
+//
+// Result:
+//
+//     var m int = func() int {
+//         var _a int = x
+//         var _b int = y
+//         return func() int {
+//             if _a > _b {
+//                 return _a
+//             }
+//             return _b
+//         }()
+//     }()
+//
+// Variables of statement expression are local for the closure, so the
+// macro may be used several times in the same block. Operators return,
+// break, continue and goto cannot leave the closure.
 func transpileStmtExpr(n *ast.StmtExpr, p *program.Program) (
 	*goast.CallExpr, string, []goast.Stmt, []goast.Stmt, error) {
 	returnType, err := types.ResolveType(p, n.Type)
 	if err != nil {
 		return nil, "", nil, nil, err
 	}
+	if err = stmtExprEscape(n.Children()[0], false, false); err != nil {
+		p.AddMessage(p.GenerateWarningMessage(err, n))
+	}
 
-	body, pre, post, err := transpileCompoundStmt(n.Children()[0].(*ast.CompoundStmt), p)
-	if err != nil {
-		return nil, "", pre, post, err
+	// The body of the StmtExpr is always a CompoundStmt. The last statement
+	// is the value of the StmtExpr, if the type is not void.
+	children := n.Children()[0].Children()
+	var last ast.Node
+	if returnType != "" && len(children) > 0 {
+		children, last = children[:len(children)-1], children[len(children)-1]
+	}
+
+	var stmts []goast.Stmt
+	for _, x := range children {
+		if parent, ok := x.(*ast.ParenExpr); ok {
+			x = parent.Children()[0]
+		}
+		result, err := transpileToStmts(x, p)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		stmts = append(stmts, result...)
+	}
+
+	if last != nil {
+		value, valueType, pre, post, err := transpileToExpr(last, p, false)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		// assignment cannot be the value in Go, so the assignment is
+		// the statement and the left operand is the value
+		for {
+			par, ok := value.(*goast.ParenExpr)
+			if !ok {
+				break
+			}
+			value = par.X
+		}
+		if b, ok := value.(*goast.BinaryExpr); ok && isAssignOperator(b.Op) {
+			pre = append(pre, util.NewExprStmt(b))
+			value = b.X
+		}
+		value, err = types.CastExpr(p, value, valueType, n.Type)
+		p.AddMessage(p.GenerateWarningMessage(err, n))
+
+		stmts = append(stmts, pre...)
+		if len(post) > 0 {
+			// value is calculated before the post statements
+			stmts = append(stmts, &goast.AssignStmt{
+				Lhs: []goast.Expr{goast.NewIdent(stmtExprValue)},
+				Tok: token.DEFINE,
+				Rhs: []goast.Expr{value},
+			})
+			stmts = append(stmts, post...)
+			value = goast.NewIdent(stmtExprValue)
+		}
+		stmts = append(stmts, &goast.ReturnStmt{
+			Results: []goast.Expr{value},
+		})
 	}
 
-	// The body of the StmtExpr is always a CompoundStmt. However, the last
-	// statement needs to be transformed into an explicit return statement.
-	lastStmt := body.List[len(body.List)-1]
-	body.List[len(body.List)-1] = &goast.ReturnStmt{
-		Results: []goast.Expr{lastStmt.(*goast.ExprStmt).X},
+	return util.NewFuncClosure(returnType, stmts...), n.Type, nil, nil, nil
+}
+
+// stmtExprValue is the name of variable with the value of statement
+// expression, which is calculated before the post statements.
+const stmtExprValue = "c4goStmtExprValue"
+
+// stmtExprEscape returns error, if the operator of statement expression
+// leaves the statement expression, because the statement expression is
+// transpiled to the closure.
+func stmtExprEscape(node ast.Node, inLoop, inSwitch bool) error {
+	switch node.(type) {
+	case *ast.ReturnStmt:
+		return fmt.Errorf("operator return inside statement expression " +
+			"is not supported")
+	case *ast.GotoStmt:
+		return fmt.Errorf("operator goto inside statement expression " +
+			"is not supported")
+	case *ast.BreakStmt:
+		if !inLoop && !inSwitch {
+			return fmt.Errorf("operator break of outer statement inside " +
+				"statement expression is not supported")
+		}
+	case *ast.ContinueStmt:
+		if !inLoop {
+			return fmt.Errorf("operator continue of outer loop inside " +
+				"statement expression is not supported")
+		}
+	case *ast.ForStmt, *ast.WhileStmt, *ast.DoStmt:
+		inLoop = true
+	case *ast.SwitchStmt:
+		inSwitch = true
+	case *ast.StmtExpr:
+		// checked by own transpilation
+		return nil
+	}
+	for _, child := range node.Children() {
+		if child == nil {
+			continue
+		}
+		if err := stmtExprEscape(child, inLoop, inSwitch); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return util.NewFuncClosure(returnType, body.List...), n.Type, pre, post, nil
+// nodeType returns the C type of expression node, or empty string if the
+// node has not field Type.
+func nodeType(n ast.Node) string {
+	v := reflect.ValueOf(n)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.Elem().FieldByName("Type")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
 }
@@ -50,6 +50,9 @@ type {{ .Name }} struct{
 }
 
 func (unionVar * {{ .Name }}) copy() ( {{ .Name }}){
+	if unionVar.memory == nil{
+		return {{ .Name }}{}
+	}
 	var buffer [{{ .Size }}]byte
 	for i := range buffer{
 		buffer[i] = (*((*[{{ .Size }}]byte)(unionVar.memory)))[i]
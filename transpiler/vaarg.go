@@ -1,18 +1,22 @@
 package transpiler
 
 import (
-	"bytes"
 	"fmt"
 	goast "go/ast"
-	"go/parser"
 	"go/token"
-	"html/template"
 
 	"github.com/Konstantin8105/c4go/ast"
 	"github.com/Konstantin8105/c4go/program"
 	"github.com/Konstantin8105/c4go/types"
+	"github.com/Konstantin8105/c4go/util"
 )
 
+// vaArgsName is the name of the last argument of variadic function in Go:
+//
+//     func sum(num_args int, c4goArgs ...interface{}) int
+//
+const vaArgsName = "c4goArgs"
+
 func transpileVAArgExpr(n *ast.VAArgExpr, p *program.Program) (
 	expr goast.Expr,
 	exprType string,
@@ -38,19 +42,18 @@ func transpileVAArgExpr(n *ast.VAArgExpr, p *program.Program) (
 	   }
 	   va_end(ap);
 	*/
-
-	var varName string
-	if impl, ok := n.Children()[0].(*ast.ImplicitCastExpr); ok {
-		if decl, ok := impl.Children()[0].(*ast.DeclRefExpr); ok {
-			varName = decl.Name
-		} else {
-			err = fmt.Errorf("second node is not DeclRefExpr : %T",
-				impl.Children()[0])
-			return
-		}
-	} else {
-		err = fmt.Errorf("first node is not ImplicitCastExpr : %T",
-			n.Children()[0])
+	// Result:
+	//
+	//     val += int(ap.Int64())
+	//
+	// Numerical arguments are converted, because C promotes them to int or
+	// double. Other arguments are taken by type assertion.
+	if len(n.Children()) != 1 {
+		err = fmt.Errorf("unexpected amount of children: %d", len(n.Children()))
+		return
+	}
+	list, _, preStmts, postStmts, err := transpileToExpr(n.Children()[0], p, false)
+	if err != nil {
 		return
 	}
 
@@ -59,58 +62,106 @@ func transpileVAArgExpr(n *ast.VAArgExpr, p *program.Program) (
 		return
 	}
 
-	type code struct {
-		GoType string
-		Name   string
+	arg := func(method string) *goast.CallExpr {
+		return &goast.CallExpr{
+			Fun: &goast.SelectorExpr{X: list, Sel: goast.NewIdent(method)},
+		}
 	}
+	exprType = n.Type
 
-	src := `
-package main
-
-func main() {
-	var {{.Name}} []interface{}
-	var rr int = 10
-	{{.Name}} = append({{.Name}}, rr)
-	var c4goVaListPosition int
-	/////////////////////////////////
-	// Begin of needed code
-	/////////////////////////////////
-	rrr := {{.Name}}[c4goVaListPosition].({{ .GoType }})
-	c4goVaListPosition++
-	/////////////////////////////////
-	// End of code
-	/////////////////////////////////
-	_ = rrr
-}
-`
-	un := code{
-		GoType: varType,
-		Name:   varName,
+	switch varType {
+	case "int64":
+		expr = arg("Int64")
+	case "uint64":
+		expr = arg("Uint64")
+	case "float64":
+		expr = arg("Float64")
+	case "int", "int8", "int16", "int32", "rune":
+		expr = util.NewCallExpr(varType, arg("Int64"))
+	case "uint", "uint8", "uint16", "uint32", "uintptr", "byte":
+		expr = util.NewCallExpr(varType, arg("Uint64"))
+	case "float32":
+		expr = util.NewCallExpr(varType, arg("Float64"))
+	case "interface{}":
+		expr = arg("Arg")
+	default:
+		// argument may be NULL pointer, that is nil interface in Go:
+		//
+		//     func() []byte {
+		//         v, _ := ap.Arg().([]byte)
+		//         return v
+		//     }()
+		//
+		v := p.GetNextIdentifier("c4goVaArg")
+		expr = util.NewFuncClosure(varType,
+			&goast.AssignStmt{
+				Lhs: []goast.Expr{goast.NewIdent(v), goast.NewIdent("_")},
+				Tok: token.DEFINE,
+				Rhs: []goast.Expr{&goast.TypeAssertExpr{
+					X:    arg("Arg"),
+					Type: goast.NewIdent(varType),
+				}},
+			},
+			&goast.ReturnStmt{Results: []goast.Expr{goast.NewIdent(v)}})
 	}
+	return
+}
 
-	tmpl := template.Must(template.New("").Parse(src))
-	var source bytes.Buffer
-	err = tmpl.Execute(&source, un)
-	if err != nil {
-		err = fmt.Errorf("cannot execute template \"%s\" for data %v : %v",
-			source.String(), un, err)
+// transpileVaBuiltin transpiles the builtin functions of clang for macros
+// va_start, va_end and va_copy from stdarg.h. Example of C code:
+//
+//     va_start(ap, num_args);
+//     va_copy(aq, ap);
+//     va_end(ap);
+//
+// Result:
+//
+//     noarch.VaStart(ap, c4goArgs)
+//     noarch.VaCopy(aq, ap)
+//     noarch.VaEnd(ap)
+//
+func transpileVaBuiltin(n *ast.CallExpr, p *program.Program, name string) (
+	expr *goast.CallExpr, preStmts []goast.Stmt, postStmts []goast.Stmt,
+	err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot transpile function %s. %v", name, err)
+		}
+	}()
+
+	var function string
+	var amount int
+	switch name {
+	case "__builtin_va_start":
+		function, amount = "VaStart", 1
+	case "__builtin_va_end":
+		function, amount = "VaEnd", 1
+	case "__builtin_va_copy":
+		function, amount = "VaCopy", 2
+	default:
+		err = fmt.Errorf("unknown function")
 		return
 	}
-
-	// Create the AST by parsing src.
-	fset := token.NewFileSet() // positions are relative to fset
-	f, err := parser.ParseFile(fset, "", source.String(), 0)
-	if err != nil {
-		err = fmt.Errorf("cannot parse source \"%s\" : %v",
-			source.String(), err)
+	if len(n.Children()) < amount+1 {
+		err = fmt.Errorf("not enough arguments: %d", len(n.Children())-1)
 		return
 	}
 
-	expr = f.Decls[0].(*goast.FuncDecl).Body.List[4].(*goast.AssignStmt).Rhs[0]
-	postStmts = []goast.Stmt{
-		f.Decls[0].(*goast.FuncDecl).Body.List[5],
+	var args []goast.Expr
+	for _, node := range n.Children()[1 : amount+1] {
+		arg, _, newPre, newPost, err := transpileToExpr(node, p, false)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
+		args = append(args, arg)
+	}
+	if function == "VaStart" {
+		// the last named argument of function is not needed in Go
+		args = append(args, goast.NewIdent(vaArgsName))
 	}
 
-	exprType = n.Type
+	expr = util.NewCallExpr(p.ImportType(
+		"github.com/Konstantin8105/c4go/noarch."+function), args...)
 	return
 }
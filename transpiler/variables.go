@@ -10,7 +10,6 @@ import (
 	"github.com/Konstantin8105/c4go/util"
 
 	goast "go/ast"
-	"go/parser"
 	"go/token"
 )
 
@@ -28,6 +27,10 @@ var structFieldTranslations = map[string]map[string]string{
 		"quot": "Quot",
 		"rem":  "Rem",
 	},
+	"imaxdiv_t": {
+		"quot": "Quot",
+		"rem":  "Rem",
+	},
 	"struct tm": {
 		"tm_sec":   "TmSec",
 		"tm_min":   "TmMin",
@@ -39,6 +42,207 @@ var structFieldTranslations = map[string]map[string]string{
 		"tm_yday":  "TmYday",
 		"tm_isdst": "TmIsdst",
 	},
+	"struct lconv": {
+		"decimal_point":      "DecimalPoint",
+		"thousands_sep":      "ThousandsSep",
+		"grouping":           "Grouping",
+		"int_curr_symbol":    "IntCurrSymbol",
+		"currency_symbol":    "CurrencySymbol",
+		"mon_decimal_point":  "MonDecimalPoint",
+		"mon_thousands_sep":  "MonThousandsSep",
+		"mon_grouping":       "MonGrouping",
+		"positive_sign":      "PositiveSign",
+		"negative_sign":      "NegativeSign",
+		"int_frac_digits":    "IntFracDigits",
+		"frac_digits":        "FracDigits",
+		"p_cs_precedes":      "PCsPrecedes",
+		"p_sep_by_space":     "PSepBySpace",
+		"n_cs_precedes":      "NCsPrecedes",
+		"n_sep_by_space":     "NSepBySpace",
+		"p_sign_posn":        "PSignPosn",
+		"n_sign_posn":        "NSignPosn",
+		"int_p_cs_precedes":  "IntPCsPrecedes",
+		"int_p_sep_by_space": "IntPSepBySpace",
+		"int_n_cs_precedes":  "IntNCsPrecedes",
+		"int_n_sep_by_space": "IntNSepBySpace",
+		"int_p_sign_posn":    "IntPSignPosn",
+		"int_n_sign_posn":    "IntNSignPosn",
+	},
+	"struct option": {
+		"name":    "Name",
+		"has_arg": "HasArg",
+		"flag":    "Flag",
+		"val":     "Val",
+	},
+	"struct dirent": {
+		"d_ino":    "DIno",
+		"d_off":    "DOff",
+		"d_reclen": "DReclen",
+		"d_type":   "DType",
+		"d_name":   "DName",
+	},
+	"struct FTW": {
+		"base":  "Base",
+		"level": "Level",
+	},
+	"ENTRY": {
+		"key":  "Key",
+		"data": "Data",
+	},
+	"struct entry": {
+		"key":  "Key",
+		"data": "Data",
+	},
+	"glob_t": {
+		"gl_pathc": "GlPathc",
+		"gl_pathv": "GlPathv",
+		"gl_offs":  "GlOffs",
+		"gl_flags": "GlFlags",
+	},
+	"struct passwd": {
+		"pw_name":   "PwName",
+		"pw_passwd": "PwPasswd",
+		"pw_uid":    "PwUID",
+		"pw_gid":    "PwGID",
+		"pw_gecos":  "PwGecos",
+		"pw_dir":    "PwDir",
+		"pw_shell":  "PwShell",
+	},
+	"struct stat": {
+		"st_dev":     "StDev",
+		"st_ino":     "StIno",
+		"st_nlink":   "StNlink",
+		"st_mode":    "StMode",
+		"st_uid":     "StUID",
+		"st_gid":     "StGID",
+		"st_rdev":    "StRdev",
+		"st_size":    "StSize",
+		"st_blksize": "StBlksize",
+		"st_blocks":  "StBlocks",
+		"st_atim":    "StAtim",
+		"st_mtim":    "StMtim",
+		"st_ctim":    "StCtim",
+		// macOS
+		"st_atimespec": "StAtim",
+		"st_mtimespec": "StMtim",
+		"st_ctimespec": "StCtim",
+	},
+	"struct timespec": {
+		"tv_sec":  "TvSec",
+		"tv_nsec": "TvNsec",
+	},
+	"struct sockaddr": {
+		"sa_family": "Family",
+		"sa_data":   "Data",
+	},
+	"struct sockaddr_in": {
+		"sin_family": "Family",
+		"sin_port":   "Port",
+		"sin_addr":   "Addr",
+		"sin_zero":   "Zero",
+	},
+	"struct sockaddr_in6": {
+		"sin6_family":   "Family",
+		"sin6_port":     "Port",
+		"sin6_flowinfo": "Flowinfo",
+		"sin6_addr":     "Addr6",
+		"sin6_scope_id": "ScopeID",
+	},
+	"struct sockaddr_storage": {
+		"ss_family": "Family",
+	},
+	"struct in_addr": {
+		"s_addr": "SAddr",
+	},
+	"struct addrinfo": {
+		"ai_flags":     "AiFlags",
+		"ai_family":    "AiFamily",
+		"ai_socktype":  "AiSocktype",
+		"ai_protocol":  "AiProtocol",
+		"ai_addrlen":   "AiAddrlen",
+		"ai_addr":      "AiAddr",
+		"ai_canonname": "AiCanonname",
+		"ai_next":      "AiNext",
+	},
+	"struct hostent": {
+		"h_name":      "HName",
+		"h_aliases":   "HAliases",
+		"h_addrtype":  "HAddrtype",
+		"h_length":    "HLength",
+		"h_addr_list": "HAddrList",
+	},
+	"fd_set": {
+		"__fds_bits": "FdsBits",
+		"fds_bits":   "FdsBits",
+	},
+	"struct termios": {
+		"c_iflag":  "CIflag",
+		"c_oflag":  "COflag",
+		"c_cflag":  "CCflag",
+		"c_lflag":  "CLflag",
+		"c_line":   "CLine",
+		"c_cc":     "CCc",
+		"c_ispeed": "CIspeed",
+		"c_ospeed": "COspeed",
+	},
+	"struct winsize": {
+		"ws_row":    "WsRow",
+		"ws_col":    "WsCol",
+		"ws_xpixel": "WsXpixel",
+		"ws_ypixel": "WsYpixel",
+	},
+	"struct timeval": {
+		"tv_sec":  "TvSec",
+		"tv_usec": "TvUsec",
+	},
+	"struct rusage": {
+		"ru_utime":    "RuUtime",
+		"ru_stime":    "RuStime",
+		"ru_maxrss":   "RuMaxrss",
+		"ru_ixrss":    "RuIxrss",
+		"ru_idrss":    "RuIdrss",
+		"ru_isrss":    "RuIsrss",
+		"ru_minflt":   "RuMinflt",
+		"ru_majflt":   "RuMajflt",
+		"ru_nswap":    "RuNswap",
+		"ru_inblock":  "RuInblock",
+		"ru_oublock":  "RuOublock",
+		"ru_msgsnd":   "RuMsgsnd",
+		"ru_msgrcv":   "RuMsgrcv",
+		"ru_nsignals": "RuNsignals",
+		"ru_nvcsw":    "RuNvcsw",
+		"ru_nivcsw":   "RuNivcsw",
+	},
+	"struct tms": {
+		"tms_utime":  "TmsUtime",
+		"tms_stime":  "TmsStime",
+		"tms_cutime": "TmsCutime",
+		"tms_cstime": "TmsCstime",
+	},
+	"struct utsname": {
+		"sysname":      "Sysname",
+		"nodename":     "Nodename",
+		"release":      "Release",
+		"version":      "Version",
+		"machine":      "Machine",
+		"domainname":   "Domainname",
+		"__domainname": "Domainname",
+	},
+	"struct pollfd": {
+		"fd":      "Fd",
+		"events":  "Events",
+		"revents": "Revents",
+	},
+	"regex_t": {
+		"re_nsub": "ReNsub",
+	},
+	"struct re_pattern_buffer": {
+		"re_nsub": "ReNsub",
+	},
+	"regmatch_t": {
+		"rm_so": "RmSo",
+		"rm_eo": "RmEo",
+	},
 }
 
 func transpileDeclRefExpr(n *ast.DeclRefExpr, p *program.Program) (
@@ -66,6 +270,21 @@ func transpileDeclRefExpr(n *ast.DeclRefExpr, p *program.Program) (
 		}
 	}
 
+	if n.For == "Var" {
+		// global variables of C include headers, for example: optind
+		if name := p.GetVariableSubstitution(n.Name); name != "" {
+			return goast.NewIdent(p.ImportType(name)), n.Type, nil
+		}
+		// variables with storage class thread_local
+		if _, ok := p.ThreadLocalVariables[ast.ParseAddress(n.Address2)]; ok {
+			return variableExpr(p, n), n.Type, nil
+		}
+		// static local variables
+		if _, ok := p.StaticVariables[ast.ParseAddress(n.Address2)]; ok {
+			return variableExpr(p, n), n.Type, nil
+		}
+	}
+
 	theType := n.Type
 
 	// FIXME: This is for linux to make sure the globals have the right type.
@@ -108,51 +327,6 @@ func getDefaultValueForVar(p *program.Program, a *ast.VarDecl) (
 		}
 	}
 
-	if va, ok := a.Children()[0].(*ast.VAArgExpr); ok {
-		outType, err := types.ResolveType(p, va.Type)
-		if err != nil {
-			return nil, "", nil, nil, err
-		}
-		var argsName string
-		if a, ok := va.Children()[0].(*ast.ImplicitCastExpr); ok {
-			if a, ok := a.Children()[0].(*ast.DeclRefExpr); ok {
-				argsName = a.Name
-			} else {
-				return nil, "", nil, nil, fmt.Errorf(
-					"Expect DeclRefExpr for vaar, but we have %T", a)
-			}
-		} else {
-			return nil, "", nil, nil, fmt.Errorf(
-				"Expect ImplicitCastExpr for vaar, but we have %T", a)
-		}
-		src := fmt.Sprintf(`package main
-var temp = func() %s {
-	var ret %s
-	if v, ok := %s[c4goVaListPosition].(int32); ok{
-		// for 'rune' type
-		ret = %s(v)
-	} else {
-		ret = %s[c4goVaListPosition].(%s)
-	}
-	c4goVaListPosition++
-	return ret
-}()`, outType,
-			outType,
-			argsName,
-			outType,
-			argsName, outType)
-
-		// Create the AST by parsing src.
-		fset := token.NewFileSet() // positions are relative to fset
-		f, err := parser.ParseFile(fset, "", src, 0)
-		if err != nil {
-			return nil, "", nil, nil, err
-		}
-
-		expr := f.Decls[0].(*goast.GenDecl).Specs[0].(*goast.ValueSpec).Values
-		return expr, va.Type, nil, nil, nil
-	}
-
 	defaultValue, defaultValueType, newPre, newPost, err := atomicOperation(a.Children()[0], p)
 	if err != nil {
 		return nil, defaultValueType, newPre, newPost, err
@@ -162,7 +336,7 @@ var temp = func() %s {
 	if !types.IsNullExpr(defaultValue) {
 		t, err := types.CastExpr(p, defaultValue, defaultValueType, a.Type)
 		if !p.AddMessage(p.GenerateWarningMessage(err, a)) {
-			values = append(values, t)
+			values = append(values, copyValue(p, t, a.Type))
 			defaultValueType = a.Type
 		}
 	}
@@ -227,7 +401,29 @@ func GenerateFuncType(fields, returns []string) *goast.FuncType {
 	return &ft
 }
 
+// transpileInitListExpr transpiles the initialization list of array or
+// struct. Clang gives all elements of struct and all elements of array
+// before the last initialized element, the elements without initializers
+// are ImplicitValueInitExpr. If the list has such elements, for example
+// with designated initializers:
+//
+//     struct point pt = { .y = 2 };
+//     int a[6] = { [1] = 3, [4] = 9 };
+//
+// then the elements of composite literal are keyed and the elements without
+// initializers are zero values of Go:
+//
+//     var pt point = point{y: 2}
+//     var a []int = (&[6]int{1: 3, 4: 9})[:]
+//
 func transpileInitListExpr(e *ast.InitListExpr, p *program.Program) (
+	expr goast.Expr, exprType string, err error) {
+	return transpileInitList(e, p, false)
+}
+
+// transpileInitList transpiles the initialization list. If goArray is true,
+// then the array is Go array, like the arrays inside structs.
+func transpileInitList(e *ast.InitListExpr, p *program.Program, goArray bool) (
 	expr goast.Expr, exprType string, err error) {
 	defer func() {
 		if err != nil {
@@ -239,18 +435,46 @@ func transpileInitListExpr(e *ast.InitListExpr, p *program.Program) (
 	e.Type1 = types.GenerateCorrectType(e.Type1)
 	e.Type2 = types.GenerateCorrectType(e.Type2)
 
+	arrayType, arraySize := types.GetArrayTypeAndSize(e.Type1)
+	if arraySize != -1 && types.IsFunction(arrayType) {
+		// arrays of function pointers are Go arrays, see types.ResolveType
+		goArray = true
+	}
+	var fields []string
+	if arraySize == -1 {
+		fields = initListFields(p, e)
+	}
+
+	var keyed bool
+	for _, node := range e.Children() {
+		if _, ok := node.(*ast.ImplicitValueInitExpr); ok {
+			keyed = arraySize != -1 || fields != nil
+		}
+	}
+
+	var index int
 	for _, node := range e.Children() {
 		// Skip ArrayFiller
 		if _, ok := node.(*ast.ArrayFiller); ok {
 			hasArrayFiller = true
 			continue
 		}
+		i := index
+		index++
+		if _, ok := node.(*ast.ImplicitValueInitExpr); ok && keyed {
+			// zero value of Go
+			continue
+		}
 
 		var expr goast.Expr
 		var err error
-		if sl, ok := node.(*ast.StringLiteral); ok {
-			expr, _, err = transpileStringLiteral(p, sl, true)
-		} else {
+		switch v := node.(type) {
+		case *ast.StringLiteral:
+			expr, _, err = transpileStringLiteral(p, v, true)
+		case *ast.InitListExpr:
+			// arrays inside structs and Go arrays are Go arrays
+			expr, _, err = transpileInitList(v, p, goArray || arraySize == -1)
+		default:
 			expr, _, _, _, err = transpileToExpr(node, p, true)
 		}
 		if err != nil {
@@ -258,13 +482,29 @@ func transpileInitListExpr(e *ast.InitListExpr, p *program.Program) (
 			return nil, "", err
 		}
 
+		if keyed {
+			var key goast.Expr = util.NewIntLit(i)
+			if fields != nil {
+				key = util.NewIdent(fields[i])
+			}
+			expr = &goast.KeyValueExpr{Key: key, Value: expr}
+		}
 		resp = append(resp, expr)
 	}
 
 	var t goast.Expr
 	var cTypeString string
 
-	arrayType, arraySize := types.GetArrayTypeAndSize(e.Type1)
+	if arraySize != -1 && goArray {
+		goArrayType, err := resolveGoArrayType(p, e.Type1)
+		p.AddMessage(p.GenerateWarningMessage(err, e))
+
+		return &goast.CompositeLit{
+			Type: util.NewTypeIdent(goArrayType),
+			Elts: resp,
+		}, e.Type1, nil
+	}
+
 	if arraySize != -1 {
 		goArrayType, err := types.ResolveType(p, arrayType)
 		p.AddMessage(p.GenerateWarningMessage(err, e))
@@ -307,6 +547,38 @@ func transpileInitListExpr(e *ast.InitListExpr, p *program.Program) (
 	}, e.Type1, nil
 }
 
+// initListFields returns the names of fields of struct for the elements of
+// initialization list. Result is nil, if the struct or the names of fields
+// are unknown.
+func initListFields(p *program.Program, e *ast.InitListExpr) []string {
+	s := p.GetStruct(e.Type1)
+	if s == nil {
+		s = p.GetStruct("struct " + e.Type1)
+	}
+	if s == nil {
+		s = p.GetStruct(e.Type2)
+	}
+	if s == nil {
+		s = p.GetStruct(p.TypedefType[e.Type1])
+	}
+	if s == nil || s.Type == program.UnionType ||
+		len(s.FieldNames) != len(e.Children()) {
+		return nil
+	}
+	translation := structFieldTranslations[e.Type1]
+	names := make([]string, len(s.FieldNames))
+	for i, name := range s.FieldNames {
+		if name == "" {
+			return nil
+		}
+		if alias, ok := translation[name]; ok {
+			name = alias
+		}
+		names[i] = name
+	}
+	return names
+}
+
 func transpileDeclStmt(n *ast.DeclStmt, p *program.Program) (
 	stmts []goast.Stmt, err error) {
 
@@ -321,7 +593,10 @@ func transpileDeclStmt(n *ast.DeclStmt, p *program.Program) (
 		p.AddMessage(p.GenerateWarningMessage(err, n))
 		err = nil
 	}
-	stmts = convertDeclToStmt(decls)
+	stmts = splitClosures(convertDeclToStmt(decls))
+	if len(p.PooledBuffers) > 0 {
+		stmts = freePooledBuffers(p, stmts)
+	}
 
 	return
 }
@@ -337,6 +612,27 @@ func transpileArraySubscriptExpr(n *ast.ArraySubscriptExpr, p *program.Program)
 
 	children := n.Children()
 
+	if function, ok := ctypeTable(children[0]); ok {
+		// tables of ctype.h contain negative characters and EOF
+		index, _, preStmts, postStmts, err := atomicOperation(children[1], p)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		return &goast.IndexExpr{
+			X: &goast.IndexExpr{
+				X: util.NewCallExpr(p.ImportType(
+					"github.com/Konstantin8105/c4go/linux." + function)),
+				Index: util.NewIntLit(0),
+			},
+			Index: &goast.BinaryExpr{
+				X:  index,
+				Op: token.ADD,
+				Y: goast.NewIdent(p.ImportType(
+					"github.com/Konstantin8105/c4go/linux.CtypeOffset")),
+			},
+		}, n.Type, preStmts, postStmts, nil
+	}
+
 	expression, _, newPre, newPost, err := transpileToExpr(children[0], p, false)
 	if err != nil {
 		return nil, "", nil, nil, err
@@ -349,12 +645,61 @@ func transpileArraySubscriptExpr(n *ast.ArraySubscriptExpr, p *program.Program)
 	}
 	preStmts, postStmts = combinePreAndPostStmts(preStmts, postStmts, newPre, newPost)
 
+	// negative index of pointer, example: p[-1]
+	if isNegative(index) {
+		expression = pointerOffset(p, expression, index)
+		index = util.NewIntLit(0)
+	}
+
 	return &goast.IndexExpr{
 		X:     expression,
 		Index: index,
 	}, n.Type, preStmts, postStmts, nil
 }
 
+// ctypeFunctions - functions of glibc, which return the tables of ctype.h,
+// and functions of package linux for them.
+var ctypeFunctions = map[string]string{
+	"__ctype_b_loc":       "CtypeLoc",
+	"__ctype_tolower_loc": "CtypeTolowerLoc",
+	"__ctype_toupper_loc": "CtypeToupperLoc",
+}
+
+// ctypeFields - fields of locale of glibc with the tables of ctype.h and
+// functions of package linux for them. Only the "C" locale is supported.
+var ctypeFields = map[string]string{
+	"__ctype_b":       "CtypeLoc",
+	"__ctype_tolower": "CtypeTolowerLoc",
+	"__ctype_toupper": "CtypeToupperLoc",
+}
+
+// ctypeTable returns the function of package linux, if the node is the table
+// of ctype.h of glibc. Macros of ctype.h are expanded to:
+//
+//     (*__ctype_b_loc())[(int)(c)]
+//     (locale)->__ctype_b[(int)(c)]
+//
+func ctypeTable(n ast.Node) (function string, ok bool) {
+	switch v := unwrapAllocSize(n).(type) {
+	case *ast.UnaryOperator:
+		if v.Operator != "*" || len(v.Children()) != 1 {
+			return
+		}
+		call, isCall := unwrapAllocSize(v.Children()[0]).(*ast.CallExpr)
+		if !isCall || len(call.Children()) != 1 {
+			return
+		}
+		ref, isRef := unwrapAllocSize(call.Children()[0]).(*ast.DeclRefExpr)
+		if !isRef {
+			return
+		}
+		function, ok = ctypeFunctions[ref.Name]
+	case *ast.MemberExpr:
+		function, ok = ctypeFields[v.Name]
+	}
+	return
+}
+
 func transpileMemberExpr(n *ast.MemberExpr, p *program.Program) (
 	_ goast.Expr, _ string, preStmts []goast.Stmt, postStmts []goast.Stmt, err error) {
 	defer func() {
@@ -425,9 +770,38 @@ func transpileMemberExpr(n *ast.MemberExpr, p *program.Program) (
 	if n.Name == "" {
 		n.Name = generateNameFieldDecl(types.GenerateCorrectType(n.Type))
 	}
+	// name of anonymous field is generated, but the node can be transpiled
+	// several times, so the generated name is checked
+	anonymous := n.Name == generateNameFieldDecl(types.GenerateCorrectType(n.Type))
 	rhs := n.Name
 	rhsType := "void *"
-	if structType == nil {
+
+	// Check for member name translation.
+	lhsType = strings.TrimSpace(lhsType)
+	if lhsType[len(lhsType)-1] == '*' {
+		lhsType = lhsType[:len(lhsType)-len(" *")]
+	}
+	member, isTranslated := structFieldTranslations[lhsType]
+	if isTranslated {
+		if alias, ok := member[rhs]; ok {
+			rhs = alias
+		}
+	}
+
+	// Anonymous union of struct from C standard library, like the union
+	// with field ru_maxrss in "struct rusage", is not a field of Go struct.
+	// Fields of union are fields of struct, so the struct is returned.
+	if isTranslated && anonymous {
+		if n.IsPointer {
+			lhs = &goast.IndexExpr{X: lhs, Index: util.NewIntLit(0)}
+		}
+		return lhs, lhsType, preStmts, postStmts, nil
+	}
+
+	if structType == nil && isTranslated {
+		// Struct from C standard library is implemented in package noarch,
+		// so the type of field is not needed.
+	} else if structType == nil {
 		// This case should not happen in the future. Any structs should be
 		// either parsed correctly from the source or be manually setup when the
 		// parser starts if the struct if hidden or shared between libraries.
@@ -440,8 +814,10 @@ func transpileMemberExpr(n *ast.MemberExpr, p *program.Program) (
 			", will use 'void *' for all fields. Is lvalue = %v. n.Name = %v",
 			lhsType, n.IsLvalue, n.Name)
 		p.AddMessage(p.GenerateWarningMessage(err, n))
+	} else if err = patternMemberError(p, structType, n); err != nil {
+		return
 	} else {
-		if s, ok := structType.Fields[rhs].(string); ok {
+		if s, ok := structType.Fields[n.Name].(string); ok {
 			rhsType = s
 		} else {
 			err = fmt.Errorf("cannot determine type for RHS '%v', will use"+
@@ -452,21 +828,24 @@ func transpileMemberExpr(n *ast.MemberExpr, p *program.Program) (
 	}
 
 	x := lhs
+	// Member of struct returned by function is accessed directly.
+	// Example of C code:
+	//
+	//     (div(a, b)).quot
+	//
+	// Result:
+	//
+	//     noarch.Div(a, b).Quot
+	//
+	if par, ok := x.(*goast.ParenExpr); ok {
+		if _, ok := par.X.(*goast.CallExpr); ok {
+			x = par.X
+		}
+	}
 	if n.IsPointer {
 		x = &goast.IndexExpr{X: x, Index: util.NewIntLit(0)}
 	}
 
-	// Check for member name translation.
-	lhsType = strings.TrimSpace(lhsType)
-	if lhsType[len(lhsType)-1] == '*' {
-		lhsType = lhsType[:len(lhsType)-len(" *")]
-	}
-	if member, ok := structFieldTranslations[lhsType]; ok {
-		if alias, ok := member[rhs]; ok {
-			rhs = alias
-		}
-	}
-
 	// anonymous struct member?
 	if rhs == "" {
 		rhs = "anon"
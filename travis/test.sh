@@ -32,6 +32,9 @@ if [ $COVERAGE_FILES != 0 ]; then
 	fi
 fi
 
+# check conformance of noarch functions with libc
+go test -tags=conformance -v ./noarch/conformance/
+
 # check race
 go test -tags=integration -run=TestIntegrationScripts/tests/ctype.c -race -v
 
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+)
+
+// typeMap is the map of C types to Go types, which are decided by c4go during
+// the transpilation. The map of unchanged C source must be the same for each
+// run and each version of c4go, so the map saved by option "-type-map" may be
+// kept as golden file and checked by option "-verify-type-map" after upgrade
+// of c4go. Example of JSON file:
+//
+//     {
+//       "types": {
+//         "FILE *": "*noarch.File",
+//         "long": "int32",
+//         "unsigned char *": "[]uint8"
+//       }
+//     }
+//
+type typeMap struct {
+	// Types is the map, where key is C type and value is Go type.
+	Types map[string]string `json:"types"`
+}
+
+// loadTypeMap reads the map of types from JSON file.
+func loadTypeMap(filename string) (types map[string]string, err error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read type map: %v", err)
+	}
+	var m typeMap
+	if err = json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("Cannot parse type map: %v", err)
+	}
+	if m.Types == nil {
+		m.Types = map[string]string{}
+	}
+	return m.Types, nil
+}
+
+// writeTypeMap writes the map of types in JSON file. Keys are sorted by
+// package encoding/json, so the file of the same map is always the same.
+func writeTypeMap(filename string, types map[string]string) error {
+	content, err := json.MarshalIndent(typeMap{Types: types}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append(content, '\n'), 0644)
+}
+
+// verifyTypeMap returns error with the list of C types, Go types of which
+// are not the same as in the golden map. C types, which are only in one of
+// the maps, are not changes of mapping and are ignored.
+func verifyTypeMap(golden, types map[string]string) error {
+	var changes []string
+	for cType, goType := range types {
+		if g, ok := golden[cType]; ok && g != goType {
+			changes = append(changes, fmt.Sprintf(
+				"\t`%s` : `%s` -> `%s`", cType, g, goType))
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Strings(changes)
+	var list string
+	for _, c := range changes {
+		list += "\n" + c
+	}
+	return fmt.Errorf("Go types of %d C types are changed:%s", len(changes), list)
+}
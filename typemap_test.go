@@ -0,0 +1,94 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTypeMap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "c4go-typemap-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	types := map[string]string{
+		"long":            "int32",
+		"FILE *":          "*noarch.File",
+		"unsigned char *": "[]uint8",
+	}
+	filename := filepath.Join(dir, "types.json")
+	if err = writeTypeMap(filename, types); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+  "types": {
+    "FILE *": "*noarch.File",
+    "long": "int32",
+    "unsigned char *": "[]uint8"
+  }
+}
+`
+	if string(content) != expected {
+		t.Errorf("Not expected content of type map:\n%s\n%s", content, expected)
+	}
+	golden, err := loadTypeMap(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(golden, types) {
+		t.Errorf("Not expected map of types:\n%v\n%v", golden, types)
+	}
+
+	for _, content := range []string{`not json`, `{"types":["long"]}`} {
+		if err = ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = loadTypeMap(filename); err == nil {
+			t.Errorf("Expected error for type map: %s", content)
+		}
+	}
+	if _, err = loadTypeMap(filepath.Join(dir, "not_exist.json")); err == nil {
+		t.Errorf("Expected error for not exist file")
+	}
+}
+
+func TestVerifyTypeMap(t *testing.T) {
+	golden := map[string]string{
+		"long":   "int32",
+		"size_t": "uint32",
+		"FILE *": "*noarch.File",
+	}
+	tcs := []struct {
+		types   map[string]string
+		changes []string
+	}{
+		{golden, nil},
+		{map[string]string{"long": "int32"}, nil},
+		{map[string]string{"long": "int32", "short": "int16"}, nil},
+		{
+			map[string]string{"long": "int64", "size_t": "uint64", "FILE *": "*noarch.File"},
+			[]string{"`long` : `int32` -> `int64`", "`size_t` : `uint32` -> `uint64`"},
+		},
+	}
+	for i, tc := range tcs {
+		err := verifyTypeMap(golden, tc.types)
+		if (err != nil) != (len(tc.changes) > 0) {
+			t.Errorf("Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		for _, c := range tc.changes {
+			if !strings.Contains(err.Error(), c) {
+				t.Errorf("Case %d: change %s is not found in %v", i, c, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package types
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
+)
+
+// cLayout is the layout of C type in memory.
+type cLayout struct {
+	size, align int
+
+	// fields of struct, empty for scalar types
+	fields []cField
+
+	// compatible is true, if the layout of Go type is the same as the
+	// layout of C type, so the byte buffer may be used as Go type by
+	// package unsafe
+	compatible bool
+}
+
+// cField is the layout of field of C struct.
+type cField struct {
+	name   string
+	offset int
+	// size of scalar value or element of array
+	size   int
+	nested *cLayout
+	// C type of field
+	cType string
+}
+
+// goScalarSizes - sizes of Go scalar types in bytes
+var goScalarSizes = map[string]int{
+	"bool": 1, "byte": 1, "int8": 1, "uint8": 1,
+	"int16": 2, "uint16": 2,
+	"int32": 4, "uint32": 4, "float32": 4, "rune": 4,
+	"int": 8, "uint": 8, "int64": 8, "uint64": 8, "float64": 8, "uintptr": 8,
+}
+
+// getLayout returns the layout of C type, where the base of each field is
+// aligned to the size of its scalar value as in C compilers for amd64.
+func getLayout(p *program.Program, cType string) (l *cLayout, err error) {
+	cType = GenerateCorrectType(cType)
+	if v, ok := p.TypedefType[cType]; ok {
+		return getLayout(p, v)
+	}
+	if strings.HasPrefix(cType, "enum ") || p.EnumTypedefName[cType] {
+		return getLayout(p, "int")
+	}
+
+	// arrays
+	if _, size := GetArrayTypeAndSize(cType); size != -1 {
+		base, length := arrayBase(cType)
+		l, err = getLayout(p, base)
+		if err != nil {
+			return nil, err
+		}
+		return &cLayout{
+			size:       l.size * length,
+			align:      l.align,
+			compatible: l.compatible,
+		}, nil
+	}
+
+	// pointers are not decoded
+	if IsCPointer(cType) || IsFunction(cType) {
+		return &cLayout{size: 8, align: 8}, nil
+	}
+
+	if s := p.GetStruct(cType); s != nil {
+		if s.Type == program.UnionType {
+			return getUnionLayout(p, s)
+		}
+		return getStructLayout(p, s)
+	}
+	if s := p.GetStruct("struct " + cType); s != nil {
+		return getStructLayout(p, s)
+	}
+
+	// scalar types
+	var size int
+	t := strings.Replace(cType, "unsigned ", "", -1)
+	t = strings.Replace(t, "signed ", "", -1)
+	switch t {
+	case "char", "_Bool", "bool":
+		size = 1
+	case "unsigned", "signed":
+		size = 4
+	case "short", "short int":
+		size = 2
+	case "int", "float":
+		size = 4
+	case "long", "long int", "long long", "long long int", "double":
+		size = 8
+	case "long double":
+		size = 16
+	default:
+		return nil, fmt.Errorf("cannot find layout of type `%s`", cType)
+	}
+	goType, err := ResolveType(p, cType)
+	if err != nil {
+		return nil, err
+	}
+	return &cLayout{
+		size:       size,
+		align:      size,
+		compatible: goScalarSizes[goType] == size,
+	}, nil
+}
+
+// getStructLayout returns the layout of C struct.
+func getStructLayout(p *program.Program, s *program.Struct) (*cLayout, error) {
+	l := &cLayout{align: 1, compatible: true}
+	for _, name := range s.FieldNames {
+		t := fieldType(s, name)
+		// layout of element for arrays
+		base, length := arrayBase(t)
+		fl, err := getLayout(p, base)
+		if err != nil {
+			return nil, fmt.Errorf("field `%s`: %v", name, err)
+		}
+		l.size = align(l.size, fl.align)
+		if fl.align > l.align {
+			l.align = fl.align
+		}
+		if !fl.compatible {
+			l.compatible = false
+		}
+		if name != "" {
+			field := cField{name: name, offset: l.size, size: fl.size, cType: t}
+			if util.IsGoKeyword(name) {
+				field.name += "_"
+			}
+			if len(fl.fields) > 0 {
+				field.nested = fl
+			}
+			l.fields = append(l.fields, field)
+		}
+		l.size += fl.size * length
+	}
+	l.size = align(l.size, l.align)
+	return l, nil
+}
+
+// getUnionLayout returns the layout of C union. Fields of union are not
+// decoded.
+func getUnionLayout(p *program.Program, s *program.Struct) (*cLayout, error) {
+	l := &cLayout{align: 1}
+	for _, name := range s.FieldNames {
+		fl, err := getLayout(p, fieldType(s, name))
+		if err != nil {
+			return nil, fmt.Errorf("field `%s`: %v", name, err)
+		}
+		if fl.size > l.size {
+			l.size = fl.size
+		}
+		if fl.align > l.align {
+			l.align = fl.align
+		}
+	}
+	l.size = align(l.size, l.align)
+	return l, nil
+}
+
+// fieldType returns the C type of field of struct.
+func fieldType(s *program.Struct, name string) string {
+	switch f := s.Fields[name].(type) {
+	case string:
+		return f
+	case *program.Struct:
+		return f.Name
+	}
+	return ""
+}
+
+// arrayBase returns the type of element and the amount of elements in all
+// dimensions of C array. For other types the amount is 1.
+func arrayBase(cType string) (base string, length int) {
+	length = 1
+	base, size := GetArrayTypeAndSize(GenerateCorrectType(cType))
+	for size != -1 {
+		length *= size
+		base, size = GetArrayTypeAndSize(base)
+	}
+	return base, length
+}
+
+func align(offset, a int) int {
+	if a > 1 && offset%a != 0 {
+		offset += a - offset%a
+	}
+	return offset
+}
+
+// goExpr returns the Go expression of layout for package noarch.
+func (l *cLayout) goExpr(p *program.Program) goast.Expr {
+	keyValue := func(key string, value goast.Expr) goast.Expr {
+		return &goast.KeyValueExpr{Key: goast.NewIdent(key), Value: value}
+	}
+	elts := []goast.Expr{keyValue("Size", util.NewIntLit(l.size))}
+	if len(l.fields) > 0 {
+		var fields []goast.Expr
+		for _, f := range l.fields {
+			field := &goast.CompositeLit{Elts: []goast.Expr{
+				keyValue("Name", util.NewStringLit(strconv.Quote(f.name))),
+				keyValue("Offset", util.NewIntLit(f.offset)),
+				keyValue("Size", util.NewIntLit(f.size)),
+			}}
+			if f.nested != nil {
+				field.Elts = append(field.Elts, keyValue("Struct", f.nested.goExpr(p)))
+			}
+			fields = append(fields, field)
+		}
+		elts = append(elts, keyValue("Fields", &goast.CompositeLit{
+			Type: &goast.ArrayType{Elt: goast.NewIdent(p.ImportType(
+				"github.com/Konstantin8105/c4go/noarch.FieldLayout"))},
+			Elts: fields,
+		}))
+	}
+	return &goast.UnaryExpr{
+		Op: token.AND,
+		X: &goast.CompositeLit{
+			Type: goast.NewIdent(p.ImportType(
+				"github.com/Konstantin8105/c4go/noarch.StructLayout")),
+			Elts: elts,
+		},
+	}
+}
+
+// castBytes returns the cast of byte buffer to pointer of other C type, for
+// example:
+//
+//     struct header *h = (struct header *)buffer;
+//
+// The cast is the call of generated function, which decodes the buffer in
+// according to the C layout of type:
+//
+//     var c4goLayoutHeader = &noarch.StructLayout{...}
+//
+//     // c4goBytesToHeader - cast of byte buffer to "struct header *" with
+//     // decoding in according to C layout
+//     func c4goBytesToHeader(b []byte) []header {
+//         return noarch.BytesToSlice(b, c4goLayoutHeader, []header(nil)).([]header)
+//     }
+//
+// With option "-byte-cast unsafe" the buffer is used without copy, if the
+// layout of Go type is the same as the layout of C type:
+//
+//     func c4goBytesToHeader(b []byte) []header {
+//         return unsafe.Slice((*header)(noarch.BytesPointer(b)),
+//             len(b)/int(unsafe.Sizeof(header{})))
+//     }
+//
+func castBytes(p *program.Program, expr goast.Expr, cToType, goType string) (
+	goast.Expr, error) {
+	cElement := strings.TrimSpace(CleanCType(cToType)[:len(CleanCType(cToType))-1])
+	l, err := getLayout(p, cElement)
+	if err != nil {
+		return nil, err
+	}
+	if l.size == 0 {
+		return nil, fmt.Errorf("size of type `%s` is zero", cElement)
+	}
+	goElement := goType[len("[]"):]
+	name := "c4goBytesTo" + util.GetExportedName(
+		strings.Replace(strings.Replace(goElement, ".", "", -1), "*", "", -1))
+
+	if _, ok := p.ByteCasts[name]; !ok {
+		unsafe := p.UnsafeByteCast && l.compatible
+		if p.UnsafeByteCast && !l.compatible {
+			p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+				"layout of Go type `%s` is not same as C type `%s`, "+
+					"so the byte buffer is decoded with copy", goElement, cElement), nil))
+		}
+		var body goast.Expr
+		var comments []*goast.Comment
+		var decls []goast.Decl
+		if unsafe {
+			comments = append(comments, &goast.Comment{Text: fmt.Sprintf(
+				"// %s - cast of byte buffer to \"%s\" without copy",
+				name, cToType)})
+			p.AddImport("unsafe")
+			size := util.NewCallExpr("int", util.NewCallExpr("unsafe.Sizeof",
+				&goast.StarExpr{X: util.NewCallExpr("new", goast.NewIdent(goElement))}))
+			body = util.NewCallExpr("unsafe.Slice",
+				util.NewCallExpr("(*"+goElement+")", util.NewCallExpr(p.ImportType(
+					"github.com/Konstantin8105/c4go/noarch.BytesPointer"),
+					goast.NewIdent("b"))),
+				&goast.BinaryExpr{
+					X:  util.NewCallExpr("len", goast.NewIdent("b")),
+					Op: token.QUO,
+					Y:  size,
+				})
+		} else {
+			layout := "c4goLayout" + name[len("c4goBytesTo"):]
+			decls = append(decls, &goast.GenDecl{
+				Tok: token.VAR,
+				Specs: []goast.Spec{&goast.ValueSpec{
+					Names:  []*goast.Ident{goast.NewIdent(layout)},
+					Values: []goast.Expr{l.goExpr(p)},
+				}},
+			})
+			comments = append(comments, &goast.Comment{Text: fmt.Sprintf(
+				"// %s - cast of byte buffer to \"%s\" with", name, cToType)},
+				&goast.Comment{Text: "// decoding in according to C layout"})
+			body = &goast.TypeAssertExpr{
+				X: util.NewCallExpr(p.ImportType(
+					"github.com/Konstantin8105/c4go/noarch.BytesToSlice"),
+					goast.NewIdent("b"), goast.NewIdent(layout),
+					util.NewCallExpr(goType, goast.NewIdent("nil"))),
+				Type: goast.NewIdent(goType),
+			}
+		}
+		decls = append(decls, &goast.FuncDecl{
+			Doc:  &goast.CommentGroup{List: comments},
+			Name: goast.NewIdent(name),
+			Type: &goast.FuncType{
+				Params: &goast.FieldList{List: []*goast.Field{{
+					Names: []*goast.Ident{goast.NewIdent("b")},
+					Type:  goast.NewIdent("[]byte"),
+				}}},
+				Results: &goast.FieldList{List: []*goast.Field{{
+					Type: goast.NewIdent(goType),
+				}}},
+			},
+			Body: &goast.BlockStmt{List: []goast.Stmt{
+				&goast.ReturnStmt{Results: []goast.Expr{body}},
+			}},
+		})
+		p.ByteCasts[name] = decls
+	}
+
+	return util.NewCallExpr(name, expr), nil
+}
+
+// castToBytes returns the cast of pointer of integer or floating-point type
+// to byte pointer, for example:
+//
+//     unsigned char *b = (unsigned char *)&value;
+//
+// The result is the view of memory of values in the host byte order, so
+// the code, which inspects the bytes of values, sees the same byte order as
+// the functions of byte order conversion like htonl():
+//
+//     noarch.SliceBytes(value)
+//
+func castToBytes(p *program.Program, expr goast.Expr, cFromType, goType string) goast.Expr {
+	cElement := strings.TrimSpace(CleanCType(cFromType)[:len(CleanCType(cFromType))-1])
+	if l, err := getLayout(p, cElement); err == nil && !l.compatible {
+		p.AddMessage(p.GenerateWarningMessage(fmt.Errorf(
+			"size of Go type `%s` is not same as C type `%s`, "+
+				"so the bytes of values are not same as in C",
+			goType[len("[]"):], cElement), nil))
+	}
+	return util.NewCallExpr(p.ImportType(
+		"github.com/Konstantin8105/c4go/noarch.SliceBytes"), expr)
+}
+
+// isMemoryOfValues returns true, if the memory of elements of pointer is
+// the memory of numbers or structs without pointers, so the bytes of memory
+// may be viewed by noarch.SliceBytes.
+func isMemoryOfValues(p *program.Program, cPointerType, goType string) bool {
+	if goScalarSizes[goType[len("[]"):]] > 1 {
+		return true
+	}
+	cElement := strings.TrimSpace(
+		CleanCType(cPointerType)[:len(CleanCType(cPointerType))-1])
+	l, err := getLayout(p, cElement)
+	return err == nil && l.compatible && len(l.fields) > 0
+}
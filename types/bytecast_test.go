@@ -0,0 +1,177 @@
+package types_test
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/types"
+)
+
+func TestCastBytes(t *testing.T) {
+	newProgram := func() *program.Program {
+		p := program.NewProgram()
+		p.Structs["struct point"] = &program.Struct{
+			Name:       "struct point",
+			Type:       program.StructType,
+			Fields:     map[string]interface{}{"x": "short", "y": "short"},
+			FieldNames: []string{"x", "y"},
+		}
+		p.Structs["struct hdr"] = &program.Struct{
+			Name: "struct hdr",
+			Type: program.StructType,
+			Fields: map[string]interface{}{
+				"kind":  "char",
+				"len":   "unsigned int",
+				"flags": "short [3]",
+				"pos":   "struct point",
+				"type":  "double",
+				"pts":   "struct point [2][2]",
+			},
+			FieldNames: []string{"kind", "len", "flags", "pos", "type", "pts"},
+		}
+		p.DefineType("struct point")
+		p.DefineType("struct hdr")
+		return p
+	}
+	print := func(node interface{}) string {
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, token.NewFileSet(), node); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	tcs := []struct {
+		cType  string
+		unsafe bool
+		name   string
+		code   []string
+	}{
+		{
+			cType: "struct hdr *",
+			name:  "c4goBytesToHdr",
+			code: []string{
+				`var c4goLayoutHdr = &noarch.StructLayout{Size: 48, Fields: []noarch.FieldLayout{` +
+					`{Name: "kind", Offset: 0, Size: 1}, {Name: "len", Offset: 4, Size: 4}, ` +
+					`{Name: "flags", Offset: 8, Size: 2}, {Name: "pos", Offset: 14, Size: 4, ` +
+					`Struct: &noarch.StructLayout{Size: 4, Fields: []noarch.FieldLayout{` +
+					`{Name: "x", Offset: 0, Size: 2}, {Name: "y", Offset: 2, Size: 2}}}}, ` +
+					`{Name: "type_", Offset: 24, Size: 8}, {Name: "pts", Offset: 32, Size: 4, ` +
+					`Struct: &noarch.StructLayout{Size: 4, Fields: []noarch.FieldLayout{` +
+					`{Name: "x", Offset: 0, Size: 2}, {Name: "y", Offset: 2, Size: 2}}}}}}`,
+				`return noarch.BytesToSlice(b, c4goLayoutHdr, []hdr(nil)).([]hdr)`,
+			},
+		},
+		{
+			cType:  "struct hdr *",
+			unsafe: true,
+			name:   "c4goBytesToHdr",
+			code: []string{
+				`return unsafe.Slice((*hdr)(noarch.BytesPointer(b)), ` +
+					`len(b)/int(unsafe.Sizeof(*new(hdr))))`,
+			},
+		},
+		{
+			// size of int in Go is not same as in C
+			cType:  "int *",
+			unsafe: true,
+			name:   "c4goBytesToInt",
+			code: []string{
+				`var c4goLayoutInt = &noarch.StructLayout{Size: 4}`,
+				`return noarch.BytesToSlice(b, c4goLayoutInt, []int(nil)).([]int)`,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.cType, func(t *testing.T) {
+			p := newProgram()
+			p.UnsafeByteCast = tc.unsafe
+			expr, err := types.CastExpr(p, goast.NewIdent("buf"), "char *", tc.cType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := print(expr); s != tc.name+"(buf)" {
+				t.Fatalf("Unexpected cast: %s", s)
+			}
+			var code string
+			for _, decl := range p.ByteCasts[tc.name] {
+				code += print(decl) + "\n"
+			}
+			for _, c := range tc.code {
+				if !strings.Contains(code, c) {
+					t.Errorf("Cannot find code:\n%s\nin:\n%s", c, code)
+				}
+			}
+		})
+	}
+}
+
+func TestCastToBytes(t *testing.T) {
+	tcs := []struct {
+		cFromType string
+		cToType   string
+		expected  string
+		warning   bool
+	}{
+		{"unsigned int *", "unsigned char *", "noarch.SliceBytes(buf)", false},
+		{"short *", "char *", "noarch.SliceBytes(buf)", false},
+		{"double *", "unsigned char *", "noarch.SliceBytes(buf)", false},
+		// size of int in Go is not same as in C
+		{"int *", "char *", "noarch.SliceBytes(buf)", true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.cFromType, func(t *testing.T) {
+			p := program.NewProgram()
+			expr, err := types.CastExpr(p, goast.NewIdent("buf"), tc.cFromType, tc.cToType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("Unexpected cast: %s", buf.String())
+			}
+			if warning := len(p.GetMessages()) > 0; warning != tc.warning {
+				t.Errorf("Unexpected warnings: %v", p.GetMessages())
+			}
+		})
+	}
+}
+
+func TestCastPointer(t *testing.T) {
+	tcs := []struct {
+		cFromType string
+		cToType   string
+		expected  string
+	}{
+		{"unsigned int *", "float *", "c4goBytesToFloat32(noarch.SliceBytes(buf))"},
+		{"double *", "unsigned long long *", "c4goBytesToUint64(noarch.SliceBytes(buf))"},
+		{"unsigned short *", "unsigned int *", "c4goBytesToUint32(noarch.SliceBytes(buf))"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.cFromType, func(t *testing.T) {
+			p := program.NewProgram()
+			expr, err := types.CastExpr(p, goast.NewIdent("buf"), tc.cFromType, tc.cToType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("Unexpected cast: %s", buf.String())
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"go/token"
+	"math/big"
 	"strings"
 
 	goast "go/ast"
@@ -15,11 +16,20 @@ import (
 
 // GetArrayTypeAndSize returns the size and type of a fixed array. If the type
 // is not an array with a fixed size then the the size will be -1 and the
-// returned type should be ignored.
+// returned type should be ignored. The element of array of function pointers
+// is the function pointer:
+//
+//     int (*[16])(int *)  -> "int (*)(int *)", 16
+//
 func GetArrayTypeAndSize(s string) (string, int) {
-	s = strings.Replace(s, "(", "", -1)
-	s = strings.Replace(s, ")", "", -1)
-	match := util.GetRegex(`([\w\* ]*)\[(\d+)\]((\[\d+\])*)`).FindStringSubmatch(s)
+	if match := util.GetRegex(`^(.*?\( ?\*+) ?\[(\d+)\]((\[\d+\])*)(\) ?\(.*)$`).
+		FindStringSubmatch(s); len(match) > 0 {
+		t := strings.Replace(match[1]+match[3]+match[5], "( *)", "(*)", -1)
+		return t, util.Atoi(match[2])
+	}
+	st := strings.Replace(s, "(", "", -1)
+	st = strings.Replace(st, ")", "", -1)
+	match := util.GetRegex(`([\w\* ]*)\[(\d+)\]((\[\d+\])*)`).FindStringSubmatch(st)
 	if len(match) > 0 {
 		var t = fmt.Sprintf("%s%s", match[1], match[3])
 		return strings.Trim(t, " "), util.Atoi(match[2])
@@ -28,6 +38,26 @@ func GetArrayTypeAndSize(s string) (string, int) {
 	return s, -1
 }
 
+// GetVariableArrayTypeAndSize returns the type of element and the C
+// expression of size of a variable length array. If the type is not a
+// variable length array then the size will be empty string and the returned
+// type should be ignored. Example:
+//
+//     char [n + 1]  -> "char", "n + 1"
+//     int [n][3]    -> "int [3]", "n"
+//
+func GetVariableArrayTypeAndSize(s string) (string, string) {
+	match := util.GetRegex(`^([^\[\]\(\)]+?) ?\[([^\[\]]+)\]((\[\d+\])*)$`).FindStringSubmatch(s)
+	if len(match) == 0 || util.GetRegex(`^\d+$`).MatchString(match[2]) {
+		return s, ""
+	}
+	t := strings.TrimSpace(match[1])
+	if match[3] != "" {
+		t += " " + match[3]
+	}
+	return t, strings.TrimSpace(match[2])
+}
+
 // CastExpr returns an expression that casts one type to another. For
 // reliability and flexability the existing type (fromType) must be structly
 // provided.
@@ -87,6 +117,11 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 	cFromType = CleanCType(cFromType)
 	cToType = CleanCType(cToType)
 
+	// all types of va_list are the same type in Go
+	if IsVaList(cFromType) && IsVaList(cToType) {
+		return expr, nil
+	}
+
 	// Only for "stddef.h"
 	if p.IncludeHeaderIsExists("stddef.h") {
 		if cFromType == "long" && cToType == "ptrdiff_t" {
@@ -132,6 +167,11 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 		return expr, nil
 	}
 
+	// Pointers to typedef and to the base type of typedef
+	if e, ok := castTypedefPointer(p, expr, cFromType, cToType); ok {
+		return e, nil
+	}
+
 	// Exceptions for stdout, stdin, stderr
 	if fromType == "FILE *" && toType == "struct _IO_FILE *" {
 		return expr, nil
@@ -142,9 +182,10 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 
 	// casting
 	if fromType == "void *" && toType[len(toType)-1] == '*' &&
-		!strings.Contains(toType, "FILE") && toType[len(toType)-2] != '*' {
-		toType = strings.Replace(toType, "*", " ", -1)
-		t, err := ResolveType(p, toType)
+		!strings.Contains(toType, "FILE") {
+		// type of element is the type without the last asterisk, for
+		// example: `int **` -> `int *`
+		t, err := ResolveType(p, strings.TrimSpace(toType[:len(toType)-1]))
 		if err != nil {
 			return nil, err
 		}
@@ -153,7 +194,7 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 			Lparen: 1,
 			Type: &goast.ArrayType{
 				Lbrack: 1,
-				Elt:    util.NewIdent(t),
+				Elt:    util.NewTypeIdent(t),
 			}}, nil
 	}
 
@@ -328,6 +369,46 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 		return expr, nil
 	}
 
+	// complex numbers of "complex.h"
+	if isGoComplex(fromType) || isGoComplex(toType) {
+		return castComplex(expr, fromType, toType), nil
+	}
+
+	// cast of byte buffer to pointer of other type, for example:
+	// (struct header *)buffer
+	if fromType == "[]byte" && strings.HasPrefix(toType, "[]") &&
+		!strings.HasPrefix(toType, "[][]") && IsCPointer(cToType) {
+		e, err := castBytes(p, expr, cToType, toType)
+		if err == nil {
+			return e, nil
+		}
+		p.AddMessage(p.GenerateWarningMessage(err, nil))
+	}
+
+	// cast of pointer of number to byte pointer, for example:
+	// (unsigned char *)&value
+	if (toType == "[]byte" || toType == "[]uint8") &&
+		strings.HasPrefix(fromType, "[]") && goScalarSizes[fromType[2:]] > 1 &&
+		IsCPointer(cFromType) {
+		return castToBytes(p, expr, cFromType, fromType), nil
+	}
+
+	// cast of pointer to pointer of unrelated type, for example:
+	// (uint32_t *)values
+	// The memory of values is cast as the byte buffer, so the values are
+	// used without copy only with option "-byte-cast unsafe".
+	if strings.HasPrefix(fromType, "[]") && strings.HasPrefix(toType, "[]") &&
+		!strings.HasPrefix(toType, "[][]") &&
+		IsCPointer(cFromType) && IsCPointer(cToType) &&
+		isMemoryOfValues(p, cFromType, fromType) {
+		e, err := castBytes(p, castToBytes(p, expr, cFromType, fromType),
+			cToType, toType)
+		if err == nil {
+			return e, nil
+		}
+		p.AddMessage(p.GenerateWarningMessage(err, nil))
+	}
+
 	// Compatible integer types
 	types := []string{
 		// Integer types
@@ -344,6 +425,12 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 		// Darwin specific
 		"__darwin_ct_rune_t", "darwin.CtRuneT",
 	}
+	// integer literal is converted to the constant `false` or `true`
+	if lit, ok := expr.(*goast.BasicLit); ok && lit.Kind == token.INT &&
+		toType == "bool" {
+		return util.NewIdent(fmt.Sprintf("%v", lit.Value != "0")), nil
+	}
+
 	for _, v := range types {
 		if fromType == v && toType == "bool" {
 			e := util.NewBinaryExpr(
@@ -357,9 +444,8 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 			return e, nil
 		}
 		if fromType == "bool" && toType == v {
-			e := util.NewGoExpr(`map[bool]int{false: 0, true: 1}[replaceme]`)
-			// Swap replaceme with the current expression
-			e.(*goast.IndexExpr).Index = expr
+			p.AddImport("github.com/Konstantin8105/c4go/noarch")
+			e := util.NewCallExpr("noarch.BoolToInt", expr)
 			return CastExpr(p, e, "int", cToType)
 		}
 	}
@@ -444,11 +530,22 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 		return util.NewStringLit(`""`), nil
 	}
 
-	if fromType == "_Bool" && toType == "int" {
-		return expr, nil
-	}
-
 	if util.InStrings(fromType, types) && util.InStrings(toType, types) {
+		// type char is signed in C by default, but it is byte in Go
+		if isPlainChar(p, cFromType) && !p.UnsignedChar &&
+			(integerKinds[toType].bits > 8 || strings.HasPrefix(toType, "float")) {
+			if v, ok := integerConstant(expr); !ok || v.Sign() < 0 ||
+				v.Cmp(big.NewInt(127)) > 0 {
+				if e, ok := castIntegerConstant(expr, "int8"); ok {
+					expr = e
+				} else {
+					expr = util.NewCallExpr("int8", expr)
+				}
+			}
+		}
+		if e, ok := castIntegerConstant(expr, toType); ok {
+			return e, nil
+		}
 		return util.NewCallExpr(toType, expr), nil
 	}
 
@@ -497,6 +594,244 @@ func CastExpr(p *program.Program, expr goast.Expr, cFromType, cToType string) (
 	return util.NewCallExpr(functionName, expr), nil
 }
 
+// isPlainChar returns true, if the C type is char without sign or typedef of
+// such char.
+func isPlainChar(p *program.Program, cType string) bool {
+	for i := 0; i < 10; i++ {
+		if cType == "char" {
+			return true
+		}
+		t, ok := p.GetBaseTypeOfTypedef(cType)
+		if !ok || t == cType {
+			return false
+		}
+		cType = CleanCType(t)
+	}
+	return false
+}
+
+// integerKinds - sizes in bits and signedness of Go integer types
+var integerKinds = map[string]struct {
+	bits   uint
+	signed bool
+}{
+	"int8": {8, true}, "int16": {16, true}, "int32": {32, true},
+	"int": {64, true}, "int64": {64, true},
+	"byte": {8, false}, "uint8": {8, false}, "uint16": {16, false},
+	"uint32": {32, false}, "uint": {64, false}, "uint64": {64, false},
+}
+
+// castIntegerConstant returns the conversion of constant integer expression
+// to Go integer type, if the value of constant does not fit the type. Go
+// does not allow such conversions of constants, but C converts the value
+// modulo 2^n, for example:
+//
+//     (unsigned int)-1  ->  uint32(4294967295)
+//     (signed char)200  ->  int8(-56)
+//
+func castIntegerConstant(expr goast.Expr, goType string) (goast.Expr, bool) {
+	if _, ok := integerKinds[goType]; !ok {
+		return nil, false
+	}
+	value, ok := integerConstant(expr)
+	if !ok {
+		return nil, false
+	}
+	converted := wrapInteger(value, goType)
+	if converted.Cmp(value) == 0 {
+		return nil, false
+	}
+	return util.NewCallExpr(goType, &goast.BasicLit{
+		Kind:  token.INT,
+		Value: converted.String(),
+	}), true
+}
+
+// integerConstant returns the exact value of constant integer expression
+// without the overflow of Go types, like `5`, `-1`, `0 - uint32(1)` or
+// `^uint64(0)`.
+func integerConstant(expr goast.Expr) (*big.Int, bool) {
+	switch v := expr.(type) {
+	case *goast.BasicLit:
+		if v.Kind == token.CHAR {
+			r, err := strconv.Unquote(v.Value)
+			if err != nil || len([]rune(r)) != 1 {
+				return nil, false
+			}
+			return big.NewInt(int64([]rune(r)[0])), true
+		}
+		if v.Kind != token.INT {
+			return nil, false
+		}
+		return new(big.Int).SetString(v.Value, 0)
+
+	case *goast.ParenExpr:
+		return integerConstant(v.X)
+
+	case *goast.UnaryExpr:
+		x, ok := integerConstant(v.X)
+		if !ok {
+			return nil, false
+		}
+		switch v.Op {
+		case token.ADD:
+			return x, true
+		case token.SUB:
+			return x.Neg(x), true
+		case token.XOR:
+			return x.Not(x), true
+		}
+
+	case *goast.BinaryExpr:
+		x, ok := integerConstant(v.X)
+		if !ok {
+			return nil, false
+		}
+		y, ok := integerConstant(v.Y)
+		if !ok {
+			return nil, false
+		}
+		switch v.Op {
+		case token.ADD:
+			return x.Add(x, y), true
+		case token.SUB:
+			return x.Sub(x, y), true
+		case token.MUL:
+			return x.Mul(x, y), true
+		case token.QUO, token.REM:
+			if y.Sign() == 0 {
+				return nil, false
+			}
+			if v.Op == token.QUO {
+				return x.Quo(x, y), true
+			}
+			return x.Rem(x, y), true
+		case token.AND:
+			return x.And(x, y), true
+		case token.OR:
+			return x.Or(x, y), true
+		case token.XOR:
+			return x.Xor(x, y), true
+		case token.AND_NOT:
+			return x.AndNot(x, y), true
+		case token.SHL, token.SHR:
+			if y.Sign() < 0 || y.Cmp(big.NewInt(64)) >= 0 {
+				return nil, false
+			}
+			if v.Op == token.SHL {
+				return x.Lsh(x, uint(y.Uint64())), true
+			}
+			return x.Rsh(x, uint(y.Uint64())), true
+		}
+
+	case *goast.CallExpr:
+		name, ok := v.Fun.(*goast.Ident)
+		if !ok || len(v.Args) != 1 {
+			return nil, false
+		}
+		if _, ok := integerKinds[name.Name]; !ok {
+			return nil, false
+		}
+		x, ok := integerConstant(v.Args[0])
+		if !ok {
+			return nil, false
+		}
+		return wrapInteger(x, name.Name), true
+	}
+	return nil, false
+}
+
+// wrapInteger returns the value converted to Go integer type as in C, so
+// the value is taken modulo 2^n.
+func wrapInteger(value *big.Int, goType string) *big.Int {
+	kind := integerKinds[goType]
+	modulo := new(big.Int).Lsh(big.NewInt(1), kind.bits)
+	wrapped := new(big.Int).Mod(value, modulo)
+	if kind.signed && wrapped.Cmp(new(big.Int).Rsh(modulo, 1)) >= 0 {
+		wrapped.Sub(wrapped, modulo)
+	}
+	return wrapped
+}
+
+// FoldUnsignedConstant returns the constant expression of unsigned Go
+// integer type calculated modulo 2^n, if the value of expression does not
+// fit the type. Arithmetic of unsigned types of C wraps around, but Go
+// reports the overflow of constants as error, for example:
+//
+//     0u - 1  ->  uint32(4294967295)
+//     ~0ul    ->  uint32(4294967295)
+//
+func FoldUnsignedConstant(expr goast.Expr, goType string) goast.Expr {
+	if kind, ok := integerKinds[goType]; !ok || kind.signed {
+		return expr
+	}
+	if e, ok := castIntegerConstant(expr, goType); ok {
+		return e
+	}
+	return expr
+}
+
+// IsIntegerConstant returns true, if the expression is constant integer
+// expression, like `5` or `uint64(5)`.
+func IsIntegerConstant(expr goast.Expr) bool {
+	_, ok := integerConstant(expr)
+	return ok
+}
+
+// castTypedefPointer casts the pointer to typedef to the pointer to the base
+// type of typedef and vice versa, for example `size_t *` to
+// `unsigned long *`. Values of types are the same in memory, but slices of
+// types are different in Go, so the slice is created by package unsafe:
+//
+//     (*[100000000]uint32)(unsafe.Pointer(&len[0]))[:]
+//
+func castTypedefPointer(p *program.Program, expr goast.Expr,
+	cFromType, cToType string) (_ goast.Expr, ok bool) {
+	if !IsCPointer(cFromType) || !IsCPointer(cToType) {
+		return
+	}
+	from := strings.TrimSpace(cFromType[:len(cFromType)-1])
+	to := strings.TrimSpace(cToType[:len(cToType)-1])
+	if IsCPointer(from) || IsCPointer(to) {
+		return
+	}
+	base := func(cType string) string {
+		cType = CleanCType(cType)
+		for i := 0; i < 100; i++ {
+			t, ok := p.TypedefType[cType]
+			if !ok || t == cType {
+				break
+			}
+			cType = CleanCType(t)
+		}
+		return cType
+	}
+	if from == to || base(from) != base(to) || IsCArray(base(to)) {
+		return
+	}
+	if b := base(to); strings.HasPrefix(b, "struct ") ||
+		strings.HasPrefix(b, "union ") {
+		// typedefs of structs are aliases in Go
+		return expr, true
+	}
+	goFrom, err := ResolveType(p, from)
+	if err != nil {
+		return
+	}
+	goTo, err := ResolveType(p, to)
+	if err != nil {
+		return
+	}
+	if goFrom == goTo {
+		return expr, true
+	}
+	p.AddImport("unsafe")
+	return util.CreateSliceFromReference(goTo, &goast.IndexExpr{
+		X:     expr,
+		Index: util.NewIntLit(0),
+	}), true
+}
+
 // IsNullExpr tries to determine if the expression is the result of the NULL
 // macro. In C, NULL is actually a macro that produces an expression like "(0)".
 //
@@ -515,3 +850,31 @@ func IsNullExpr(n goast.Expr) bool {
 
 	return false
 }
+
+// isGoComplex returns true, if the Go type is complex type.
+func isGoComplex(goType string) bool {
+	return goType == "complex64" || goType == "complex128"
+}
+
+// castComplex converts the complex number to the other complex type, to the
+// real number or to bool and converts the real number to the complex number
+// with zero imaginary part.
+func castComplex(expr goast.Expr, fromType, toType string) goast.Expr {
+	switch {
+	case isGoComplex(fromType) && isGoComplex(toType):
+		return util.NewCallExpr(toType, expr)
+
+	case isGoComplex(toType):
+		part := "float64"
+		if toType == "complex64" {
+			part = "float32"
+		}
+		return util.NewCallExpr("complex",
+			util.NewCallExpr(part, expr), util.NewIntLit(0))
+
+	case toType == "bool":
+		return util.NewBinaryExpr(expr, token.NEQ, util.NewIntLit(0),
+			toType, false)
+	}
+	return util.NewCallExpr(toType, util.NewCallExpr("real", expr))
+}
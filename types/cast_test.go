@@ -20,6 +20,8 @@ func toJSON(v interface{}) string {
 
 func TestCast(t *testing.T) {
 	p := program.NewProgram()
+	p.TypedefType["size_t"] = "unsigned long"
+	p.TypedefType["Node"] = "struct node"
 
 	type args struct {
 		expr     goast.Expr
@@ -39,8 +41,37 @@ func TestCast(t *testing.T) {
 		{args{util.NewIntLit(1), "int", "double"}, util.NewCallExpr("float64", util.NewIntLit(1))},
 		{args{util.NewIntLit(1), "int", "__uint16_t"}, util.NewCallExpr("uint16", util.NewIntLit(1))},
 
+		// Casting of integer constants, which does not fit the type
+		{args{&goast.UnaryExpr{Op: token.SUB, X: util.NewIntLit(1)}, "int", "unsigned int"}, util.NewCallExpr("uint32", util.NewIntLit(4294967295))},
+		{args{&goast.UnaryExpr{Op: token.SUB, X: util.NewIntLit(1)}, "int", "unsigned long long"}, util.NewCallExpr("uint64", &goast.BasicLit{Kind: token.INT, Value: "18446744073709551615"})},
+		{args{util.NewIntLit(200), "int", "signed char"}, util.NewCallExpr("int8", util.NewIntLit(-56))},
+		{args{&goast.BinaryExpr{X: util.NewIntLit(100), Op: token.ADD, Y: util.NewIntLit(100)}, "int", "signed char"}, util.NewCallExpr("int8", util.NewIntLit(-56))},
+		{args{util.NewIntLit(200), "int", "unsigned char"}, util.NewCallExpr("uint8", util.NewIntLit(200))},
+		{args{&goast.UnaryExpr{Op: token.XOR, X: util.NewIntLit(0)}, "int", "unsigned short"}, util.NewCallExpr("uint16", util.NewIntLit(65535))},
+
 		// Casting to bool
-		{args{util.NewIntLit(1), "int", "bool"}, util.NewBinaryExpr(util.NewIntLit(1), token.NEQ, util.NewIntLit(0), "bool", false)},
+		{args{util.NewIdent("x"), "int", "bool"}, util.NewBinaryExpr(util.NewIdent("x"), token.NEQ, util.NewIntLit(0), "bool", false)},
+		{args{util.NewIdent("x"), "int", "_Bool"}, util.NewBinaryExpr(util.NewIdent("x"), token.NEQ, util.NewIntLit(0), "bool", false)},
+		{args{util.NewIntLit(1), "int", "_Bool"}, util.NewIdent("true")},
+		{args{util.NewIntLit(0), "int", "_Bool"}, util.NewIdent("false")},
+
+		// Casting from bool
+		{args{util.NewIdent("b"), "_Bool", "int"}, util.NewCallExpr("noarch.BoolToInt", util.NewIdent("b"))},
+		{args{util.NewIdent("b"), "_Bool", "double"}, util.NewCallExpr("float64", util.NewCallExpr("noarch.BoolToInt", util.NewIdent("b")))},
+		{args{util.NewIdent("b"), "_Bool", "bool"}, util.NewIdent("b")},
+
+		// Casting of pointers to typedef
+		{args{util.NewIdent("n"), "size_t *", "unsigned long *"}, util.CreateSliceFromReference("uint32", &goast.IndexExpr{X: util.NewIdent("n"), Index: util.NewIntLit(0)})},
+		{args{util.NewIdent("n"), "unsigned long *", "size_t *"}, util.CreateSliceFromReference("size_t", &goast.IndexExpr{X: util.NewIdent("n"), Index: util.NewIntLit(0)})},
+		{args{util.NewIdent("n"), "Node *", "struct node *"}, util.NewIdent("n")},
+		{args{util.NewIdent("n"), "struct node *", "Node *"}, util.NewIdent("n")},
+
+		// Casting of complex numbers
+		{args{util.NewIdent("z"), "_Complex float", "_Complex double"}, util.NewCallExpr("complex128", util.NewIdent("z"))},
+		{args{util.NewIdent("x"), "double", "_Complex double"}, util.NewCallExpr("complex", util.NewCallExpr("float64", util.NewIdent("x")), util.NewIntLit(0))},
+		{args{util.NewIntLit(1), "int", "_Complex float"}, util.NewCallExpr("complex", util.NewCallExpr("float32", util.NewIntLit(1)), util.NewIntLit(0))},
+		{args{util.NewIdent("z"), "_Complex double", "float"}, util.NewCallExpr("float32", util.NewCallExpr("real", util.NewIdent("z")))},
+		{args{util.NewIdent("z"), "_Complex double", "bool"}, util.NewBinaryExpr(util.NewIdent("z"), token.NEQ, util.NewIntLit(0), "bool", false)},
 	}
 
 	for _, tt := range tests {
@@ -61,6 +92,32 @@ func TestCast(t *testing.T) {
 	}
 }
 
+func TestFoldUnsignedConstant(t *testing.T) {
+	minusOne := &goast.BinaryExpr{X: util.NewIntLit(0), Op: token.SUB, Y: util.NewCallExpr("uint32", util.NewIntLit(1))}
+	tests := []struct {
+		expr   goast.Expr
+		goType string
+		want   goast.Expr
+	}{
+		{minusOne, "uint32", util.NewCallExpr("uint32", util.NewIntLit(4294967295))},
+		{minusOne, "int32", minusOne},
+		{&goast.UnaryExpr{Op: token.XOR, X: util.NewCallExpr("uint16", util.NewIntLit(0))}, "uint16", util.NewCallExpr("uint16", util.NewIntLit(65535))},
+		{&goast.UnaryExpr{Op: token.SUB, X: util.NewCallExpr("uint64", util.NewIntLit(1))}, "uint64", util.NewCallExpr("uint64", &goast.BasicLit{Kind: token.INT, Value: "18446744073709551615"})},
+		{&goast.BinaryExpr{X: util.NewIntLit(4294967295), Op: token.ADD, Y: util.NewIntLit(2)}, "uint32", util.NewCallExpr("uint32", util.NewIntLit(1))},
+		{&goast.BinaryExpr{X: util.NewIntLit(1), Op: token.SHL, Y: util.NewIntLit(31)}, "uint32", &goast.BinaryExpr{X: util.NewIntLit(1), Op: token.SHL, Y: util.NewIntLit(31)}},
+		{&goast.BinaryExpr{X: util.NewIdent("x"), Op: token.SUB, Y: util.NewIntLit(1)}, "uint32", &goast.BinaryExpr{X: util.NewIdent("x"), Op: token.SUB, Y: util.NewIntLit(1)}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			got := FoldUnsignedConstant(tt.expr, tt.goType)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FoldUnsignedConstant()%s\n", util.ShowDiff(toJSON(got), toJSON(tt.want)))
+			}
+		})
+	}
+}
+
 func TestGetArrayTypeAndSize(t *testing.T) {
 	tests := []struct {
 		in    string
@@ -79,6 +136,10 @@ func TestGetArrayTypeAndSize(t *testing.T) {
 		{"char *const", "char *const", -1},
 		{"char *const [6]", "char *const", 6},
 		{"char *const [6][5]", "char *const [5]", 6},
+		{"int (*[16])(int *)", "int (*)(int *)", 16},
+		{"int (*[2][3])(int)", "int (*[3])(int)", 2},
+		{"void (**[4])(void)", "void (**)(void)", 4},
+		{"int ( * [16])(int *)", "int (*)(int *)", 16},
 	}
 
 	for _, tt := range tests {
@@ -93,6 +154,36 @@ func TestGetArrayTypeAndSize(t *testing.T) {
 	}
 }
 
+func TestGetVariableArrayTypeAndSize(t *testing.T) {
+	tests := []struct {
+		in    string
+		cType string
+		size  string
+	}{
+		{"int", "int", ""},
+		{"int [4]", "int [4]", ""},
+		{"int [4][3]", "int [4][3]", ""},
+		{"int []", "int []", ""},
+		{"int [n]", "int", "n"},
+		{"char [n + 1]", "char", "n + 1"},
+		{"char [(len + 1) * 2]", "char", "(len + 1) * 2"},
+		{"double [rows][3]", "double [3]", "rows"},
+		{"int *[size]", "int *", "size"},
+		{"unsigned char [count]", "unsigned char", "count"},
+	}
+
+	for _, tt := range tests {
+		cType, size := GetVariableArrayTypeAndSize(tt.in)
+		if cType != tt.cType {
+			t.Errorf("Expected type '%s', got '%s'", tt.cType, cType)
+		}
+
+		if size != tt.size {
+			t.Errorf("Expected size '%s', got '%s'", tt.size, size)
+		}
+	}
+}
+
 func TestError(t *testing.T) {
 	p := program.NewProgram()
 
@@ -59,78 +59,19 @@ func IsCFloat(p *program.Program, cType string) bool {
 	return false
 }
 
-// TODO: Some of these are based on assumptions that may not be true for all
-// architectures (like the size of an int). At some point in the future we will
-// need to find out the sizes of some of there and pick the most compatible
-// type.
-//
-// Please keep them sorted by name.
-var simpleResolveTypes = map[string]string{
-	"bool":                   "bool",
-	"char *":                 "[]byte",
-	"char":                   "byte",
-	"char*":                  "[]byte",
-	"double":                 "float64",
-	"float":                  "float32",
-	"int":                    "int",
-	"long double":            "float64",
-	"long int":               "int32",
-	"long long":              "int64",
-	"long long int":          "int64",
-	"long long unsigned int": "uint64",
-	"long unsigned int":      "uint32",
-	"long":                   "int32",
-	"short":                  "int16",
-	"signed char":            "int8",
-	"unsigned char":          "uint8",
-	"unsigned int":           "uint32",
-	"unsigned long long":     "uint64",
-	"unsigned long":          "uint32",
-	"unsigned short":         "uint16",
-	"unsigned short int":     "uint16",
-	"void":                   "",
-	"_Bool":                  "int",
-
-	// void*
-	"void*":  "interface{}",
-	"void *": "interface{}",
-
-	// null is a special case (it should probably have a less ambiguos name)
-	// when using the NULL macro.
-	"null": "null",
-
-	// Non platform-specific types.
-	"uint32":     "uint32",
-	"uint64":     "uint64",
-	"__uint16_t": "uint16",
-	"__uint32_t": "uint32",
-	"__uint64_t": "uint64",
-
-	// These are special cases that almost certainly don't work. I've put
-	// them here because for whatever reason there is no suitable type or we
-	// don't need these platform specific things to be implemented yet.
-	"__builtin_va_list": "int64",
-	"unsigned __int128": "uint64",
-	"__int128":          "int64",
-	"__mbstate_t":       "int64",
-	"__sbuf":            "int64",
-	"__sFILEX":          "interface{}",
-	"FILE":              "github.com/Konstantin8105/c4go/noarch.File",
+// vaListTypes - C types of va_list from stdarg.h
+var vaListTypes = map[string]bool{
+	"va_list":                  true,
+	"__gnuc_va_list":           true,
+	"__builtin_va_list":        true,
+	"struct __va_list_tag":     true,
+	"struct __va_list_tag *":   true,
+	"struct __va_list_tag [1]": true,
 }
 
-// CStdStructType - conversion map from C standart library structures to
-// c4go structures
-var CStdStructType = map[string]string{
-	"div_t":   "github.com/Konstantin8105/c4go/noarch.DivT",
-	"ldiv_t":  "github.com/Konstantin8105/c4go/noarch.LdivT",
-	"lldiv_t": "github.com/Konstantin8105/c4go/noarch.LldivT",
-
-	// time.h
-	"tm":        "github.com/Konstantin8105/c4go/noarch.Tm",
-	"struct tm": "github.com/Konstantin8105/c4go/noarch.Tm",
-	"time_t":    "github.com/Konstantin8105/c4go/noarch.TimeT",
-
-	"fpos_t": "int",
+// IsVaList returns true, if the C type is va_list from stdarg.h
+func IsVaList(s string) bool {
+	return vaListTypes[CleanCType(s)]
 }
 
 // NullPointer - is look : (double *)(nil) or (FILE *)(nil)
@@ -169,7 +110,19 @@ var ToVoid = "ToVoid"
 //    certainly incorrect) "interface{}" is also returned. This is to allow the
 //    transpiler to step over type errors and put something as a placeholder
 //    until a more suitable solution is found for those cases.
-func ResolveType(p *program.Program, s string) (_ string, err error) {
+//
+// All successful decisions are recorded in the type registry of program, so the map of
+// C types to Go types may be saved and compared with the map of the next run
+// (see options "-type-map" and "-verify-type-map").
+func ResolveType(p *program.Program, s string) (goType string, err error) {
+	goType, err = resolveGoType(p, s)
+	if err == nil {
+		p.RecordResolvedType(CleanCType(s), goType)
+	}
+	return
+}
+
+func resolveGoType(p *program.Program, s string) (_ string, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("Cannot resolve type '%s' : %v", s, err)
@@ -187,25 +140,20 @@ func ResolveType(p *program.Program, s string) (_ string, err error) {
 		return "interface{}", errors.New("probably an incorrect type translation 1")
 	}
 
-	// FIXME: I have no idea, how to solve.
-	// See : /issues/628
-	if strings.Contains(s, "__locale_data") {
-		s = strings.Replace(s, "struct __locale_data", "int", -1)
-		s = strings.Replace(s, "__locale_data", "int", -1)
-	}
-	if strings.Contains(s, "__locale_struct") {
-		return "int", nil
-	}
 	if strings.Contains(s, "__sFILEX") {
 		s = strings.Replace(s, "__sFILEX", "int", -1)
 	}
 
 	// The simple resolve types are the types that we know there is an exact Go
 	// equivalent. For example float, int, etc.
-	for k, v := range simpleResolveTypes {
-		if k == s {
-			return p.ImportType(v), nil
-		}
+	if v, ok := p.TypeMapping(s); ok {
+		return p.ImportType(v), nil
+	}
+
+	// va_list is an array of one element in C, so it is passed into
+	// functions by pointer
+	if IsVaList(s) {
+		return "*" + p.ImportType("github.com/Konstantin8105/c4go/noarch.VaList"), nil
 	}
 
 	if t, ok := p.GetBaseTypeOfTypedef(s); ok {
@@ -226,7 +174,7 @@ func ResolveType(p *program.Program, s string) (_ string, err error) {
 
 	// No need resolve typedef types
 	if _, ok := p.TypedefType[s]; ok {
-		if tt, ok := CStdStructType[s]; ok {
+		if tt, ok := p.StdStruct(s); ok {
 			// "div_t":   "github.com/Konstantin8105/c4go/noarch.DivT",
 			ii := p.ImportType(tt)
 			return ii, nil
@@ -234,7 +182,7 @@ func ResolveType(p *program.Program, s string) (_ string, err error) {
 		return s, nil
 	}
 
-	if tt, ok := CStdStructType[s]; ok {
+	if tt, ok := p.StdStruct(s); ok {
 		// "div_t":   "github.com/Konstantin8105/c4go/noarch.DivT",
 		ii := p.ImportType(tt)
 		return ii, nil
@@ -281,7 +229,8 @@ func ResolveType(p *program.Program, s string) (_ string, err error) {
 		// off.
 		t, err := ResolveType(p, strings.TrimSpace(s[:len(s)-1]))
 		prefix := "[]"
-		if strings.Contains(t, "noarch.File") {
+		if strings.Contains(t, "noarch.File") ||
+			strings.HasSuffix(t, "noarch.Dir") {
 			prefix = "*"
 		}
 		return prefix + t, err
@@ -351,6 +300,16 @@ func ResolveType(p *program.Program, s string) (_ string, err error) {
 		return "[]" + r, nil
 	}
 
+	// for case of variable length array : "int [n]"
+	if t, size := GetVariableArrayTypeAndSize(s); size != "" {
+		var r string
+		r, err = ResolveType(p, t)
+		if err != nil {
+			return
+		}
+		return "[]" + r, nil
+	}
+
 	errMsg := fmt.Sprintf(
 		"I couldn't find an appropriate Go type for the C type '%s'.", s)
 	return "interface{}", errors.New(errMsg)
@@ -404,6 +363,11 @@ func SeparateFunction(p *program.Program, s string) (
 		if f[i] == "" {
 			continue
 		}
+		if f[i] == "..." {
+			// variadic function
+			fields = append(fields, "...interface{}")
+			continue
+		}
 		var t string
 		t, err = ResolveType(p, f[i])
 		if err != nil {
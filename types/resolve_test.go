@@ -3,6 +3,7 @@ package types_test
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -21,15 +22,31 @@ var resolveTestCases = []resolveTestCase{
 	{"__uint16_t", "uint16"},
 	{"void *", "interface{}"},
 	{"unsigned short int", "uint16"},
+	{"_Bool", "bool"},
+	{"bool", "bool"},
+	{"_Bool [3]", "[]bool"},
 	{"div_t", "noarch.DivT"},
 	{"ldiv_t", "noarch.LdivT"},
 	{"lldiv_t", "noarch.LldivT"},
+	{"imaxdiv_t", "noarch.ImaxdivT"},
+	{"struct lconv *", "[]noarch.Lconv"},
+	{"struct __locale_struct *", "[]noarch.Locale"},
+	{"struct __locale_data *[13]", "[][]noarch.LocaleData"},
+	{"intmax_t", "int64"},
 	{"int [2]", "[]int"},
 	{"int [2][3]", "[][]int"},
 	{"int [2][3][4]", "[][][]int"},
 	{"int [2][3][4][5]", "[][][][]int"},
+	{"int [n]", "[]int"},
+	{"char [n + 1]", "[]byte"},
+	{"double [rows][3]", "[][]float64"},
 	{"int (*[2])(int, int)", "[2]func(int,int)(int)"},
 	{"int (*(*(*)))(int, int)", "[][]func(int,int)(int)"},
+	{"int (*)(int, ...)", "func(int,...interface{})(int)"},
+	{"void (*)(const char *, ...)", "func([]byte,...interface{})()"},
+	{"_Complex double", "complex128"},
+	{"_Complex float *", "[]complex64"},
+	{"_Complex long double [4]", "[]complex128"},
 }
 
 func TestResolve(t *testing.T) {
@@ -340,3 +357,60 @@ func TestResolveError(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterTypeMapping(t *testing.T) {
+	p := program.NewProgram()
+	p.RegisterTypeMapping("mpz_t", "github.com/user/bigint.Int")
+	p.RegisterTypeMapping("long double", "float32")
+	p.RegisterStdStruct("struct point", "github.com/user/geom.Point")
+
+	tcs := []resolveTestCase{
+		{"mpz_t", "bigint.Int"},
+		{"mpz_t *", "[]bigint.Int"},
+		{"long double", "float32"},
+		{"struct point", "geom.Point"},
+		{"struct point [3]", "[]geom.Point"},
+		{"div_t", "noarch.DivT"},
+	}
+	for i, tc := range tcs {
+		t.Run(fmt.Sprintf("Test %d : %s", i, tc.cType), func(t *testing.T) {
+			goType, err := types.ResolveType(p, tc.cType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if goType != tc.goType {
+				t.Errorf("Expected '%s' -> '%s', got '%s'",
+					tc.cType, tc.goType, goType)
+			}
+		})
+	}
+
+	imports := strings.Join(p.Imports(), " ")
+	for _, imp := range []string{"github.com/user/bigint", "github.com/user/geom"} {
+		if !strings.Contains(imports, imp) {
+			t.Errorf("Import %s is not found in %s", imp, imports)
+		}
+	}
+
+	// registration is not shared between programs
+	if goType, err := types.ResolveType(program.NewProgram(), "long double"); err != nil || goType != "float64" {
+		t.Errorf("Registration of type is changed the other program: %s %v",
+			goType, err)
+	}
+}
+
+func TestResolveTypeRecord(t *testing.T) {
+	p := program.NewProgram()
+	for _, cType := range []string{"const long", "long", "unsigned char *", "w:w"} {
+		_, _ = types.ResolveType(p, cType)
+	}
+	// the base type of pointer is resolved and recorded too
+	expected := map[string]string{
+		"long":            "int32",
+		"unsigned char":   "uint8",
+		"unsigned char *": "[]uint8",
+	}
+	if actual := p.ResolvedTypes(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Not expected map of resolved types:\n%v\n%v", actual, expected)
+	}
+}
@@ -2,9 +2,11 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Konstantin8105/c4go/program"
+	"github.com/Konstantin8105/c4go/util"
 )
 
 // SizeOf returns the number of bytes for a type. This the same as using the
@@ -40,10 +42,19 @@ func SizeOf(p *program.Program, cType string) (size int, err error) {
 		return SizeOf(p, "int")
 	}
 
+	// Fields of structures and unions are aligned as in C compilers, see
+	// getLayout. If the layout is not found, then the size is calculated
+	// below as the sum of parts.
+	cType = GenerateCorrectType(cType)
+	if p.GetStruct(cType) != nil || p.GetStruct("struct "+cType) != nil {
+		if l, err := getLayout(p, cType); err == nil {
+			return l.size, nil
+		}
+	}
+
 	// A structure will be the sum of its parts.
 	var isStruct, ok bool
 	var s *program.Struct
-	cType = GenerateCorrectType(cType)
 	if s, ok = p.Structs[cType]; ok {
 		isStruct = true
 	} else if s, ok = p.Structs["struct "+cType]; ok {
@@ -131,7 +142,7 @@ func SizeOf(p *program.Program, cType string) (size int, err error) {
 	}
 
 	switch cType {
-	case "char", "void":
+	case "char", "void", "_Bool", "bool":
 		return 1, nil
 
 	case "short":
@@ -145,6 +156,15 @@ func SizeOf(p *program.Program, cType string) (size int, err error) {
 
 	case "long double", "long long", "long long int", "long long unsigned int":
 		return 16, nil
+
+	case "_Complex float":
+		return 8, nil
+
+	case "_Complex double":
+		return 16, nil
+
+	case "_Complex long double":
+		return 32, nil
 	}
 
 	// Get size for array types like: `base_type [count]`
@@ -167,3 +187,81 @@ func SizeOf(p *program.Program, cType string) (size int, err error) {
 
 	return baseSize * totalArraySize, nil
 }
+
+// OffsetOf returns the offset of field of C struct in bytes. This the same as
+// using the macro offsetof in C. The field of nested struct is separated by
+// point and the element of array field is selected by index, for example:
+// "pos.x" or "points[2].y".
+func OffsetOf(p *program.Program, cType, field string) (offset int, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Cannot determine offsetof : |%s|, |%s|. err = %v",
+				cType, field, err)
+		}
+	}()
+
+	l, err := getLayout(p, CleanCType(cType))
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range strings.Split(field, ".") {
+		name = strings.TrimSpace(name)
+		var indexes []int
+		if i := strings.Index(name, "["); i > 0 {
+			indexes, err = parseIndexes(name[i:])
+			if err != nil {
+				return 0, err
+			}
+			name = strings.TrimSpace(name[:i])
+		}
+		if util.IsGoKeyword(name) {
+			name += "_"
+		}
+		var found *cField
+		for i := range l.fields {
+			if l.fields[i].name == name {
+				found = &l.fields[i]
+				break
+			}
+		}
+		if found == nil {
+			return 0, fmt.Errorf("cannot find field `%s`", name)
+		}
+		offset += found.offset
+		t := found.cType
+		for _, index := range indexes {
+			elementType, size := GetArrayTypeAndSize(t)
+			if size == -1 {
+				return 0, fmt.Errorf("field `%s` is not array", name)
+			}
+			var elementSize int
+			elementSize, err = SizeOf(p, elementType)
+			if err != nil {
+				return 0, err
+			}
+			offset += index * elementSize
+			t = elementType
+		}
+		l = found.nested
+		if l == nil {
+			l = &cLayout{}
+		}
+	}
+	return offset, nil
+}
+
+// parseIndexes returns the indexes of array elements, like "[2][1]".
+func parseIndexes(s string) (indexes []int, err error) {
+	for _, part := range strings.Split(s, "[")[1:] {
+		part = strings.TrimSpace(part)
+		if !strings.HasSuffix(part, "]") {
+			return nil, fmt.Errorf("not valid index of array `%s`", s)
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(part[:len(part)-1]))
+		if err != nil {
+			return nil, fmt.Errorf("not valid index of array `%s`: %v", s, err)
+		}
+		indexes = append(indexes, index)
+	}
+	return
+}
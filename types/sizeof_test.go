@@ -46,3 +46,80 @@ func TestSizeOf(t *testing.T) {
 		}
 	}
 }
+
+func TestOffsetOf(t *testing.T) {
+	p := program.NewProgram()
+	p.Structs["struct point"] = &program.Struct{
+		Name:       "struct point",
+		Type:       program.StructType,
+		Fields:     map[string]interface{}{"x": "short", "y": "short"},
+		FieldNames: []string{"x", "y"},
+	}
+	p.Structs["struct hdr"] = &program.Struct{
+		Name: "struct hdr",
+		Type: program.StructType,
+		Fields: map[string]interface{}{
+			"kind": "char",
+			"len":  "unsigned int",
+			"pos":  "struct point",
+			"type": "double",
+		},
+		FieldNames: []string{"kind", "len", "pos", "type"},
+	}
+	p.Structs["struct path"] = &program.Struct{
+		Name: "struct path",
+		Type: program.StructType,
+		Fields: map[string]interface{}{
+			"n":      "int",
+			"points": "struct point [4]",
+			"grid":   "char [3][5]",
+		},
+		FieldNames: []string{"n", "points", "grid"},
+	}
+
+	tcs := []struct {
+		cType   string
+		field   string
+		offset  int
+		isError bool
+	}{
+		{"struct hdr", "kind", 0, false},
+		{"struct hdr", "len", 4, false},
+		{"struct hdr", "pos.y", 10, false},
+		{"struct hdr", "type", 16, false},
+		{"struct hdr", "unknown", 0, true},
+		{"struct unknown", "x", 0, true},
+		{"struct path", "points[2]", 12, false},
+		{"struct path", "points[2].y", 14, false},
+		{"struct path", "grid[1][2]", 27, false},
+		{"struct path", "n[1]", 0, true},
+	}
+
+	for _, tc := range tcs {
+		offset, err := types.OffsetOf(p, tc.cType, tc.field)
+		if (err != nil) != tc.isError {
+			t.Errorf("%s.%s: unexpected error: %v", tc.cType, tc.field, err)
+			continue
+		}
+		if offset != tc.offset {
+			t.Errorf("Expected offsetof(%s, %s) -> '%d', got '%d'",
+				tc.cType, tc.field, tc.offset, offset)
+		}
+	}
+
+	// sizes of structs with aligned fields
+	for cType, size := range map[string]int{
+		"struct point": 4,
+		"struct hdr":   24,
+		"struct path":  36,
+	} {
+		s, err := types.SizeOf(p, cType)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", cType, err)
+			continue
+		}
+		if s != size {
+			t.Errorf("Expected '%s' -> '%d', got '%d'", cType, size, s)
+		}
+	}
+}